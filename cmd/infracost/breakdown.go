@@ -15,6 +15,22 @@ func breakdownCmd(cfg *config.Config) *cobra.Command {
 
       infracost breakdown --path /path/to/code --terraform-plan-flags "-var-file=my.tfvars"
 
+  Compare the costs of the environments of a Terraform directory:
+
+      infracost breakdown --path /path/to/code --compare-environments dev=dev.tfvars,prod=prod.tfvars
+
+  Compare the costs of the same resources in different regions:
+
+      infracost breakdown --path /path/to/code --compare-regions us-east-1,eu-west-1,ap-southeast-2
+
+  Push the monthly costs to a Prometheus Pushgateway so they can be graphed in Grafana:
+
+      infracost breakdown --path /path/to/code --pushgateway-url http://pushgateway:9091
+
+  Fail if more than 20% of the resource types are unsupported or missing usage:
+
+      infracost breakdown --path /path/to/code --usage-file infracost-usage.yml --max-uncovered-percent 20
+
   Use Terraform plan JSON:
 
       terraform plan -out tfplan.binary
@@ -22,12 +38,12 @@ func breakdownCmd(cfg *config.Config) *cobra.Command {
 			infracost breakdown --path plan.json`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := checkAPIKey(cfg.APIKey, cfg.PricingAPIEndpoint, cfg.DefaultPricingAPIEndpoint); err != nil {
+			err := loadRunFlags(cfg, cmd)
+			if err != nil {
 				return err
 			}
 
-			err := loadRunFlags(cfg, cmd)
-			if err != nil {
+			if err := checkAPIKey(cfg); err != nil {
 				return err
 			}
 
@@ -45,11 +61,15 @@ func breakdownCmd(cfg *config.Config) *cobra.Command {
 	addRunFlags(cmd)
 
 	cmd.Flags().Bool("terraform-use-state", false, "Use Terraform state instead of generating a plan. Applicable when path is a Terraform directory")
-	cmd.Flags().String("format", "table", "Output format: json, table, html")
+	cmd.Flags().String("format", "table", "Output format: json, table, html, annotations, focus, prometheus, atlantis-comment")
 	cmd.Flags().StringSlice("fields", []string{"monthlyQuantity", "unit", "monthlyCost"}, "Comma separated list of output fields: price,monthlyQuantity,unit,hourlyCost,monthlyCost.\nSupported by table and html output formats")
+	cmd.Flags().StringSlice("compare-regions", []string{}, "Price the resources in each of the regions and compare their costs, e.g. us-east-1,eu-west-1. Prices that aren't regional, such as data transfer between locations, aren't moved")
+	cmd.Flags().Bool("show-recommendations", false, "Show recommendations for resources that are likely to be over-provisioned, such as gp2 volumes and previous generation instances, with their estimated monthly savings")
+	cmd.Flags().String("pushgateway-url", "", "Push the total and project monthly costs to a Prometheus Pushgateway, e.g. http://pushgateway:9091. The metrics are grouped by the infracost job unless the URL sets a job")
+	cmd.Flags().String("group-by", "", "Show monthly costs grouped by a resource tag or project label, e.g. tag:team or label:env. Supported by table and json output formats")
 
 	_ = cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return []string{"table", "json", "html"}, cobra.ShellCompDirectiveDefault
+		return []string{"table", "json", "html", "annotations", "focus", "prometheus", "atlantis-comment"}, cobra.ShellCompDirectiveDefault
 	})
 
 	return cmd
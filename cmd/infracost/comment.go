@@ -0,0 +1,232 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/infracost/infracost/internal/comment"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func commentCmd(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "comment",
+		Short: "Post an Infracost comment to a pull request",
+		Long: `Post the cost estimate in an Infracost JSON file as a comment on a pull request.
+
+The comment has the total monthly cost change and the diff of each project.
+By default the pull request's existing Infracost comment is updated, so it
+only ever has one.`,
+		Example: `  Post a comment to a Bitbucket Cloud pull request:
+
+      infracost diff --path /path/to/code --format json > infracost.json
+      infracost comment bitbucket --path infracost.json --repo my-workspace/my-repo --pull-request 3
+
+  Post a comment to an Azure Repos pull request and set its status:
+
+      infracost comment azure-repos --path infracost.json --repo-url https://dev.azure.com/my-org/my-project/_git/my-repo --pull-request 3 --build-status`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(commentBitbucketCmd(cfg), commentAzureReposCmd(cfg))
+
+	return cmd
+}
+
+func commentBitbucketCmd(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bitbucket",
+		Short: "Post an Infracost comment to Bitbucket Cloud or Bitbucket Server",
+		Long: `Post an Infracost comment to a pull request on Bitbucket Cloud, or on Bitbucket
+Server and Data Center if --bitbucket-server-url is set.
+
+The token is a repository or project access token, or a username and app
+password in the format username:password. It can also be set with the
+INFRACOST_BITBUCKET_TOKEN environment variable.`,
+		Example: `  Post a comment to a Bitbucket Cloud pull request from Bitbucket Pipelines:
+
+      infracost comment bitbucket --path infracost.json --repo $BITBUCKET_REPO_FULL_NAME \
+        --pull-request $BITBUCKET_PR_ID --commit $BITBUCKET_COMMIT --build-status
+
+  Post a comment to a Bitbucket Server pull request:
+
+      infracost comment bitbucket --path infracost.json --bitbucket-server-url https://bitbucket.example.com \
+        --repo MYPROJECT/my-repo --pull-request 3`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token := cfg.BitbucketToken
+			if cmd.Flags().Changed("bitbucket-token") {
+				token, _ = cmd.Flags().GetString("bitbucket-token")
+			}
+
+			serverURL, _ := cmd.Flags().GetString("bitbucket-server-url")
+			repo, _ := cmd.Flags().GetString("repo")
+			pullRequest, _ := cmd.Flags().GetInt("pull-request")
+			commit, _ := cmd.Flags().GetString("commit")
+
+			if buildStatus, _ := cmd.Flags().GetBool("build-status"); buildStatus && commit == "" {
+				ui.PrintUsageErrorAndExit(cmd, "--commit is required to set the build status")
+			}
+
+			p, err := comment.NewBitbucket(serverURL, token, repo, pullRequest, commit)
+			if err != nil {
+				ui.PrintUsageErrorAndExit(cmd, err.Error())
+			}
+
+			return postComment(cmd, p)
+		},
+	}
+
+	addCommentFlags(cmd)
+	cmd.Flags().String("repo", "", "Bitbucket repo in the format workspace/repo, or PROJECT/repo for Bitbucket Server")
+	cmd.Flags().Int("pull-request", 0, "Pull request ID")
+	cmd.Flags().String("commit", "", "Commit SHA to set the build status of, required with --build-status")
+	cmd.Flags().String("bitbucket-token", "", "Bitbucket access token, or username:password for an app password")
+	cmd.Flags().String("bitbucket-server-url", "", "URL of Bitbucket Server or Data Center, e.g. https://bitbucket.example.com. Defaults to Bitbucket Cloud")
+
+	_ = cmd.MarkFlagRequired("repo")
+	_ = cmd.MarkFlagRequired("pull-request")
+
+	return cmd
+}
+
+func commentAzureReposCmd(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "azure-repos",
+		Short: "Post an Infracost comment to Azure Repos",
+		Long: `Post an Infracost comment to a pull request on Azure Repos, in Azure DevOps
+Services or Azure DevOps Server.
+
+The token is a personal access token with the Code (Read & write) scope, or
+the System.AccessToken of a pipeline. It can also be set with the
+INFRACOST_AZURE_REPOS_TOKEN environment variable.`,
+		Example: `  Post a comment from an Azure Pipelines pull request build:
+
+      infracost comment azure-repos --path infracost.json \
+        --repo-url $(System.PullRequest.SourceRepositoryURI) \
+        --pull-request $(System.PullRequest.PullRequestId) \
+        --azure-repos-token $(System.AccessToken) --build-status`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token := cfg.AzureReposToken
+			if cmd.Flags().Changed("azure-repos-token") {
+				token, _ = cmd.Flags().GetString("azure-repos-token")
+			}
+
+			repoURL, _ := cmd.Flags().GetString("repo-url")
+			pullRequest, _ := cmd.Flags().GetInt("pull-request")
+
+			p, err := comment.NewAzureRepos(repoURL, token, pullRequest)
+			if err != nil {
+				ui.PrintUsageErrorAndExit(cmd, err.Error())
+			}
+
+			return postComment(cmd, p)
+		},
+	}
+
+	addCommentFlags(cmd)
+	cmd.Flags().String("repo-url", "", "Repo URL, e.g. https://dev.azure.com/my-org/my-project/_git/my-repo")
+	cmd.Flags().Int("pull-request", 0, "Pull request ID")
+	cmd.Flags().String("azure-repos-token", "", "Azure Repos personal access token or System.AccessToken")
+
+	_ = cmd.MarkFlagRequired("repo-url")
+	_ = cmd.MarkFlagRequired("pull-request")
+
+	return cmd
+}
+
+func addCommentFlags(cmd *cobra.Command) {
+	cmd.Flags().String("path", "", "Path to the Infracost JSON file")
+	cmd.Flags().String("behavior", comment.BehaviorUpdate, "Behavior when the pull request already has an Infracost comment: update, new, delete-and-new")
+	cmd.Flags().Bool("build-status", false, "Also set an Infracost build status with the monthly cost change")
+	cmd.Flags().String("build-status-threshold", "", "Set the build status to failed if the total monthly cost increases by at least this percentage, e.g. 10%, or amount in USD, e.g. 100")
+	cmd.Flags().String("report-url", "", "URL of the full report to link the build status to, e.g. the CI job")
+	cmd.Flags().Bool("dry-run", false, "Print the comment instead of posting it")
+
+	_ = cmd.MarkFlagRequired("path")
+	_ = cmd.MarkFlagFilename("path", "json")
+
+	_ = cmd.RegisterFlagCompletionFunc("behavior", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return comment.Behaviors, cobra.ShellCompDirectiveDefault
+	})
+}
+
+func postComment(cmd *cobra.Command, p comment.Platform) error {
+	path, _ := cmd.Flags().GetString("path")
+	behavior, _ := cmd.Flags().GetString("behavior")
+	if !contains(comment.Behaviors, behavior) {
+		ui.PrintUsageErrorAndExit(cmd, fmt.Sprintf("--behavior must be one of %s", strings.Join(comment.Behaviors, ", ")))
+	}
+
+	var threshold *output.DiffThreshold
+	if cmd.Flags().Changed("build-status-threshold") {
+		s, _ := cmd.Flags().GetString("build-status-threshold")
+
+		var err error
+		threshold, err = output.ParseThreshold(s)
+		if err != nil {
+			ui.PrintUsageErrorAndExit(cmd, err.Error())
+		}
+	}
+
+	out, err := loadOutputJSON(path)
+	if err != nil {
+		return err
+	}
+
+	body, err := comment.Body(out, output.Options{})
+	if err != nil {
+		return err
+	}
+
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		fmt.Println(body)
+		return nil
+	}
+
+	c, err := comment.Post(p, body, behavior)
+	if err != nil {
+		return errors.Wrapf(err, "Error posting comment to %s", p.Name())
+	}
+	fmt.Fprintf(os.Stderr, "Posted comment to %s: %s\n", p.Name(), c.URL)
+
+	if buildStatus, _ := cmd.Flags().GetBool("build-status"); buildStatus {
+		reportURL, _ := cmd.Flags().GetString("report-url")
+
+		err = p.SetBuildStatus(comment.NewBuildStatus(out, threshold, reportURL))
+		if err != nil {
+			return errors.Wrapf(err, "Error setting %s build status", p.Name())
+		}
+		fmt.Fprintf(os.Stderr, "Set the %s build status\n", p.Name())
+	}
+
+	return nil
+}
+
+// loadOutputJSON reads and parses an Infracost JSON file, checking that it's
+// a version that can be read.
+func loadOutputJSON(path string) (output.Root, error) {
+	data, err := readArtifact(output.Artifact{Path: path})
+	if err != nil {
+		return output.Root{}, err
+	}
+
+	out, err := output.Load(data)
+	if err != nil {
+		return output.Root{}, errors.Wrapf(err, "Error parsing JSON file %s", path)
+	}
+
+	if !checkOutputVersion(out.Version) {
+		return output.Root{}, fmt.Errorf("Invalid Infracost JSON file version in %s. Supported versions are %s ≤ x ≤ %s", path, minOutputVersion, maxOutputVersion)
+	}
+
+	return out, nil
+}
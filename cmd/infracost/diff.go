@@ -1,9 +1,13 @@
 package main
 
 import (
+	"fmt"
+
 	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
 	"github.com/infracost/infracost/internal/ui"
 	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 	"github.com/spf13/cobra"
 )
 
@@ -23,12 +27,12 @@ func diffCmd(cfg *config.Config) *cobra.Command {
 			infracost diff --path plan.json`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := checkAPIKey(cfg.APIKey, cfg.PricingAPIEndpoint, cfg.DefaultPricingAPIEndpoint); err != nil {
+			err := loadRunFlags(cfg, cmd)
+			if err != nil {
 				return err
 			}
 
-			err := loadRunFlags(cfg, cmd)
-			if err != nil {
+			if err := checkAPIKey(cfg); err != nil {
 				return err
 			}
 
@@ -43,6 +47,7 @@ func diffCmd(cfg *config.Config) *cobra.Command {
 			}
 
 			cfg.Format = "diff"
+			cfg.DiffThresholdAmount, cfg.DiffThresholdPercent = loadDiffThresholdFlags(cmd)
 
 			return runMain(cmd, cfg)
 		},
@@ -50,9 +55,55 @@ func diffCmd(cfg *config.Config) *cobra.Command {
 
 	addRunFlags(cmd)
 
+	cmd.Flags().String("lock-file", "", "Path to a lock file generated by infracost lock. Fails if the costs have changed since it was generated")
+
+	_ = cmd.MarkFlagFilename("lock-file", "lock")
+
+	addDiffThresholdFlags(cmd)
+
 	return cmd
 }
 
+func addDiffThresholdFlags(cmd *cobra.Command) {
+	cmd.Flags().Float64("diff-threshold-amount", 0, "Only show the diff if the total monthly cost changes by at least this amount in USD")
+	cmd.Flags().Float64("diff-threshold-percent", 0, "Only show the diff if the total monthly cost changes by at least this percentage")
+}
+
+func loadDiffThresholdFlags(cmd *cobra.Command) (*float64, *float64) {
+	return loadNonNegativeFloatFlag(cmd, "diff-threshold-amount"), loadNonNegativeFloatFlag(cmd, "diff-threshold-percent")
+}
+
+func loadNonNegativeFloatFlag(cmd *cobra.Command, name string) *float64 {
+	if !cmd.Flags().Changed(name) {
+		return nil
+	}
+
+	v, _ := cmd.Flags().GetFloat64(name)
+	if v < 0 {
+		ui.PrintUsageErrorAndExit(cmd, fmt.Sprintf("--%s must be 0 or more", name))
+	}
+
+	return &v
+}
+
+func newDiffThreshold(amount *float64, percent *float64) *output.DiffThreshold {
+	if amount == nil && percent == nil {
+		return nil
+	}
+
+	t := &output.DiffThreshold{}
+	if amount != nil {
+		d := decimal.NewFromFloat(*amount)
+		t.Amount = &d
+	}
+	if percent != nil {
+		d := decimal.NewFromFloat(*percent)
+		t.Percent = &d
+	}
+
+	return t
+}
+
 func checkDiffConfig(cfg *config.Config) error {
 	for _, projectConfig := range cfg.Projects {
 		if projectConfig.TerraformUseState {
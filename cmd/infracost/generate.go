@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+func generateCmd(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate Infracost files",
+		Long:  "Generate Infracost files",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(generateConfigCmd(cfg))
+
+	return cmd
+}
+
+func generateConfigCmd(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Generate a config file with all the Terraform projects in a repo",
+		Long: `Generate a config file with all the Terraform projects in a repo.
+
+The path is walked to find the Terraform root modules, which are directories
+with backend or provider blocks, or terragrunt.hcl files. Directories that are
+used as local modules are skipped.`,
+		Example: `  Generate a config file for a monorepo:
+
+      infracost generate config --path . --out-file infracost.yml
+      infracost breakdown --config-file infracost.yml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, _ := cmd.Flags().GetString("path")
+			outFile, _ := cmd.Flags().GetString("out-file")
+
+			paths, err := terraform.DiscoverProjects(path)
+			if err != nil {
+				return err
+			}
+
+			if len(paths) == 0 {
+				ui.PrintWarningf("No Terraform projects found in %s", path)
+			}
+
+			spec := config.ConfigFileSpec{
+				Version:  "0.1",
+				Projects: make([]*config.Project, 0, len(paths)),
+			}
+			for _, p := range paths {
+				spec.Projects = append(spec.Projects, &config.Project{Path: p})
+			}
+
+			b, err := yaml.Marshal(spec)
+			if err != nil {
+				return errors.Wrap(err, "Error generating config file")
+			}
+
+			if outFile == "" {
+				fmt.Print(string(b))
+				return nil
+			}
+
+			err = ioutil.WriteFile(outFile, b, 0600)
+			if err != nil {
+				return errors.Wrap(err, "Error writing config file")
+			}
+
+			cmd.Printf("Config file with %d projects saved to %s\n", len(spec.Projects), ui.DisplayPath(outFile))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("path", "p", ".", "Path to the repo to find Terraform projects in")
+	cmd.Flags().String("out-file", "", "Path to save the config file to, defaults to stdout")
+
+	_ = cmd.MarkFlagFilename("out-file", "yml")
+
+	return cmd
+}
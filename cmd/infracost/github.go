@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/github"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var githubRepoRegex = regexp.MustCompile(`^[\w.-]+/[\w.-]+$`)
+
+func githubAppTokenCmd(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "github-app-token",
+		Short: "Print a GitHub App installation token for a repo",
+		Long: `Print a GitHub App installation token for a repo.
+
+This lets cost comments be posted as a GitHub App instead of using a personal
+access token. The app is configured with the INFRACOST_GITHUB_APP_ID and
+INFRACOST_GITHUB_APP_PRIVATE_KEY (or INFRACOST_GITHUB_APP_PRIVATE_KEY_FILE)
+environment variables. Tokens are cached until they expire.`,
+		Example: `  Post a comment using the app:
+
+      export INFRACOST_GITHUB_APP_ID=12345
+      export INFRACOST_GITHUB_APP_PRIVATE_KEY_FILE=/path/to/private-key.pem
+      GITHUB_TOKEN=$(infracost github-app-token --repo my-org/my-repo)`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repo, _ := cmd.Flags().GetString("repo")
+			if !githubRepoRegex.MatchString(repo) {
+				ui.PrintUsageErrorAndExit(cmd, "--repo must be in the format owner/name")
+			}
+
+			app, err := newGitHubApp(cfg)
+			if err != nil {
+				return err
+			}
+
+			token, err := app.InstallationToken(repo)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(token)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("repo", "", "GitHub repo in the format owner/name")
+	_ = cmd.MarkFlagRequired("repo")
+
+	return cmd
+}
+
+func newGitHubApp(cfg *config.Config) (*github.App, error) {
+	if cfg.GitHubAppID == "" {
+		return nil, errors.New("No INFRACOST_GITHUB_APP_ID environment variable is set")
+	}
+
+	key := []byte(cfg.GitHubAppPrivateKey)
+	if len(key) == 0 {
+		if cfg.GitHubAppPrivateKeyFile == "" {
+			return nil, errors.New("No INFRACOST_GITHUB_APP_PRIVATE_KEY or INFRACOST_GITHUB_APP_PRIVATE_KEY_FILE environment variable is set")
+		}
+
+		var err error
+		key, err = ioutil.ReadFile(cfg.GitHubAppPrivateKeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error reading GitHub App private key file")
+		}
+	}
+
+	return github.NewApp(cfg.GitHubAppID, key, cfg.GitHubAPIURL, config.GitHubAppTokenCacheFilePath())
+}
+
+func githubCheckCmd(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "github-check",
+		Short: "Publish a GitHub check run with the cost estimate of a commit",
+		Long: `Publish a GitHub check run with the cost estimate in an Infracost JSON file.
+
+The check run's title has the total monthly cost change and the Terraform
+resources with the largest cost increases are annotated in the pull request's
+files. Check runs can be created with the GITHUB_TOKEN of a GitHub Actions
+workflow that has the checks: write permission, or as the GitHub App
+configured with the INFRACOST_GITHUB_APP_ID and INFRACOST_GITHUB_APP_PRIVATE_KEY
+environment variables. Use --commit-status to set a commit status instead when
+using a personal access token, which can't create check runs.
+
+The token can also be set with the INFRACOST_GITHUB_TOKEN environment variable.`,
+		Example: `  Publish a check run from a GitHub Actions workflow that fails if the monthly cost increases by 10% or more:
+
+      infracost diff --path . --format json > infracost.json
+      infracost github-check --path infracost.json --repo $GITHUB_REPOSITORY \
+        --commit ${{ github.event.pull_request.head.sha }} --github-token ${{ github.token }} --threshold 10%
+
+  Set a commit status with a personal access token:
+
+      infracost github-check --path infracost.json --repo my-org/my-repo --commit $COMMIT_SHA \
+        --github-token $GITHUB_PAT --commit-status`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repo, _ := cmd.Flags().GetString("repo")
+			if !githubRepoRegex.MatchString(repo) {
+				ui.PrintUsageErrorAndExit(cmd, "--repo must be in the format owner/name")
+			}
+
+			var threshold *output.DiffThreshold
+			if cmd.Flags().Changed("threshold") {
+				s, _ := cmd.Flags().GetString("threshold")
+
+				var err error
+				threshold, err = output.ParseThreshold(s)
+				if err != nil {
+					ui.PrintUsageErrorAndExit(cmd, err.Error())
+				}
+			}
+
+			maxAnnotations, _ := cmd.Flags().GetInt("max-annotations")
+			if maxAnnotations < 0 {
+				ui.PrintUsageErrorAndExit(cmd, "--max-annotations must be 0 or more")
+			}
+
+			path, _ := cmd.Flags().GetString("path")
+			out, err := loadOutputJSON(path)
+			if err != nil {
+				return err
+			}
+
+			commit, _ := cmd.Flags().GetString("commit")
+			run, err := github.NewCheckRun(out, commit, threshold, maxAnnotations)
+			if err != nil {
+				return err
+			}
+			run.DetailsURL, _ = cmd.Flags().GetString("report-url")
+
+			token := cfg.GitHubToken
+			if cmd.Flags().Changed("github-token") {
+				token, _ = cmd.Flags().GetString("github-token")
+			}
+
+			var client *github.Client
+			if token != "" {
+				client = github.NewClient(cfg.GitHubAPIURL, token)
+			} else {
+				app, err := newGitHubApp(cfg)
+				if err != nil {
+					return errors.Wrap(err, "Either --github-token or a GitHub App must be set")
+				}
+
+				client, err = app.Client(repo)
+				if err != nil {
+					return err
+				}
+			}
+
+			if commitStatus, _ := cmd.Flags().GetBool("commit-status"); commitStatus {
+				err = client.CreateCommitStatus(repo, run)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(os.Stderr, "Set the GitHub commit status of %s: %s\n", commit, run.Title)
+				return nil
+			}
+
+			url, err := client.CreateCheckRun(repo, run)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Published GitHub check run with %d annotations: %s\n", len(run.Annotations), url)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("path", "", "Path to the Infracost JSON file")
+	cmd.Flags().String("repo", "", "GitHub repo in the format owner/name")
+	cmd.Flags().String("commit", "", "Commit SHA to publish the check run for, usually the pull request's head commit")
+	cmd.Flags().String("github-token", "", "GitHub token, e.g. the GITHUB_TOKEN of GitHub Actions. Defaults to the GitHub App if it's configured")
+	cmd.Flags().String("threshold", "", "Fail the check if the total monthly cost increases by at least this percentage, e.g. 10%, or amount in USD, e.g. 100")
+	cmd.Flags().Int("max-annotations", 10, "Maximum number of resources with the largest cost increases to annotate, up to 50")
+	cmd.Flags().String("report-url", "", "URL of the full report to link the check to, e.g. the CI job")
+	cmd.Flags().Bool("commit-status", false, "Set a commit status instead of a check run, for tokens that can't create check runs")
+
+	_ = cmd.MarkFlagRequired("path")
+	_ = cmd.MarkFlagRequired("repo")
+	_ = cmd.MarkFlagRequired("commit")
+	_ = cmd.MarkFlagFilename("path", "json")
+
+	return cmd
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/estimate"
+	"github.com/infracost/infracost/internal/inventory"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/tidwall/gjson"
+)
+
+func inventoryCmd(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inventory",
+		Short: "Compare running cloud resources with the resources declared in Terraform",
+		Long: `Compare running cloud resources with the resources declared in Terraform.
+
+This uses the AWS CLI to list the running EC2 instances, EBS volumes, RDS instances
+and load balancers, so read-only AWS credentials are needed. Resources whose IDs are
+in the Terraform state JSON files are managed, the rest are unmanaged and are shown
+with their estimated monthly costs.
+
+The attributes of the managed resources that differ from what's declared, and the
+declared resources that aren't running, are listed as drift. Use --format diff to
+compare the estimated costs of the declared resources with the running resources.`,
+		Example: `  Show the unmanaged resources in two accounts:
+
+      terraform show -json > state.json
+      infracost inventory --path state.json --region us-east-1 --aws-profile prod --aws-profile staging
+
+  Compare the costs of the declared and running resources:
+
+      infracost inventory --path state.json --region us-east-1 --format diff
+
+  Only include resources with a tag:
+
+      infracost inventory --path state.json --region eu-west-1 --tag team=platform`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkAPIKey(cfg); err != nil {
+				return err
+			}
+
+			paths, _ := cmd.Flags().GetStringArray("path")
+			regions, _ := cmd.Flags().GetStringSlice("region")
+			profiles, _ := cmd.Flags().GetStringSlice("aws-profile")
+			tagFlags, _ := cmd.Flags().GetStringSlice("tag")
+			format, _ := cmd.Flags().GetString("format")
+
+			if len(regions) == 0 {
+				ui.PrintUsageErrorAndExit(cmd, "No regions specified")
+			}
+
+			tags, err := inventory.ParseTagFilters(tagFlags)
+			if err != nil {
+				ui.PrintUsageErrorAndExit(cmd, err.Error())
+			}
+
+			terraformJSON := make([][]byte, 0, len(paths))
+			for _, p := range paths {
+				b, err := ioutil.ReadFile(p)
+				if err != nil {
+					return errors.Wrap(err, "Error reading Terraform JSON file")
+				}
+				terraformJSON = append(terraformJSON, b)
+			}
+
+			spinnerOpts := ui.SpinnerOptions{
+				EnableLogging: cfg.IsLogging(),
+				NoColor:       cfg.NoColor,
+			}
+			spinner = ui.NewSpinner("Listing running AWS resources", spinnerOpts)
+
+			items, err := inventory.CollectAWS(inventory.AWSOptions{
+				Profiles: profiles,
+				Regions:  regions,
+				Tags:     tags,
+			})
+			if err != nil {
+				spinner.Fail()
+				return err
+			}
+
+			spinner.Success()
+
+			declared := inventory.DeclaredResources(terraformJSON)
+			inventory.LinkDeclared(items, declared)
+			drift := inventory.FindDrift(items, declared, regions)
+
+			unmanaged := inventory.Unmanaged(items, inventory.ManagedIDs(terraformJSON))
+
+			fmt.Fprintf(os.Stderr, "Found %d running resources, %d are managed by Terraform and %d are unmanaged\n",
+				len(items), len(items)-len(unmanaged), len(unmanaged))
+
+			if len(drift) > 0 {
+				fmt.Fprintf(os.Stderr, "\nFound %d differences between the declared and running resources:\n", len(drift))
+				for _, d := range drift {
+					fmt.Fprintf(os.Stderr, "  - %s\n", d)
+				}
+			}
+
+			// The declared resources are the past resources of the managed
+			// project so the diff shows how the running costs differ
+			managedProject := schema.NewProject("Managed resources", &schema.ProjectMetadata{Type: "cloud_inventory"})
+			managedProject.HasDiff = true
+			managedProject.PastResources, err = declaredResources(cfg, paths, terraformJSON, declared)
+			if err != nil {
+				return err
+			}
+			for _, i := range items {
+				if i.TerraformAddress != "" {
+					managedProject.Resources = append(managedProject.Resources, terraform.NewResource(cfg.Environment, i.ResourceData(), nil))
+				}
+			}
+
+			unmanagedProject := schema.NewProject("Unmanaged resources", &schema.ProjectMetadata{Type: "cloud_inventory"})
+			unmanagedProject.HasDiff = true
+			for _, i := range unmanaged {
+				unmanagedProject.Resources = append(unmanagedProject.Resources, terraform.NewResource(cfg.Environment, i.ResourceData(), nil))
+			}
+
+			projects := []*schema.Project{managedProject, unmanagedProject}
+
+			costOpts, err := estimate.LoadCostOptions(cfg)
+			if err != nil {
+				return err
+			}
+
+			spinner = ui.NewSpinner("Calculating monthly cost estimate", spinnerOpts)
+
+			for _, project := range projects {
+				err = estimate.CalculateCosts(context.Background(), cfg, project, costOpts)
+				if err != nil {
+					spinner.Fail()
+					return err
+				}
+			}
+
+			spinner.Success()
+
+			r := output.ToOutputFormat(projects)
+			opts := output.Options{
+				NoColor: cfg.NoColor,
+				Fields:  cfg.Fields,
+			}
+
+			var b []byte
+			switch strings.ToLower(format) {
+			case "json":
+				b, err = output.ToJSON(r, opts)
+			case "diff":
+				b, err = output.ToDiff(r, opts)
+			default:
+				b, err = output.ToTable(r, opts)
+			}
+			if err != nil {
+				return errors.Wrap(err, "Error generating output")
+			}
+
+			fmt.Printf("\n%s\n", string(b))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayP("path", "p", []string{}, "Path to Terraform state or plan JSON files of the resources that are managed by Terraform")
+	cmd.Flags().StringSlice("region", []string{}, "Comma separated list of AWS regions to list resources from")
+	cmd.Flags().StringSlice("aws-profile", []string{}, "AWS CLI profiles of the accounts to list resources from. Defaults to the default credentials")
+	cmd.Flags().StringSlice("tag", []string{}, "Only include resources with these tags, in the format key=value")
+	cmd.Flags().String("format", "table", "Output format: json, diff, table")
+
+	_ = cmd.MarkFlagFilename("path", "json")
+
+	return cmd
+}
+
+// declaredResources returns the resources of the Terraform state or plan JSON
+// that are declared and inventoried, so their costs can be compared with the
+// running resources. For plan JSON the prior state is used.
+func declaredResources(cfg *config.Config, paths []string, terraformJSON [][]byte, declared []inventory.DeclaredResource) ([]*schema.Resource, error) {
+	addresses := make(map[string]bool, len(declared))
+	for _, d := range declared {
+		addresses[d.Address] = true
+	}
+
+	resources := make([]*schema.Resource, 0, len(declared))
+
+	for idx, j := range terraformJSON {
+		project, err := estimate.PlanJSONProject(cfg, paths[idx], j, nil, true)
+		if err != nil {
+			return nil, err
+		}
+
+		fileResources := project.Resources
+		if gjson.GetBytes(j, "prior_state").Exists() {
+			fileResources = project.PastResources
+		}
+
+		for _, r := range fileResources {
+			if addresses[r.Name] {
+				resources = append(resources, r)
+			}
+		}
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/events"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var defaultLockFile = "infracost.lock"
+
+func lockCmd(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lock",
+		Short: "Write the approved costs to a lock file",
+		Long: `Write the approved costs to a lock file that can be committed to the repo.
+
+Running infracost diff with the --lock-file flag fails if the costs have changed
+since the lock file was generated, so it must be regenerated to approve them.`,
+		Example: `  Generate a lock file:
+
+      infracost lock --path /path/to/code
+
+  Check the costs against the lock file:
+
+      infracost diff --path /path/to/code --lock-file infracost.lock`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := loadRunFlags(cfg, cmd)
+			if err != nil {
+				return err
+			}
+
+			if err := checkAPIKey(cfg); err != nil {
+				return err
+			}
+
+			err = checkRunConfig(cfg)
+			if err != nil {
+				ui.PrintUsageErrorAndExit(cmd, err.Error())
+			}
+
+			return runMain(cmd, cfg)
+		},
+	}
+
+	addRunFlags(cmd)
+
+	cmd.Flags().Bool("terraform-use-state", false, "Use Terraform state instead of generating a plan. Applicable when path is a Terraform directory")
+	cmd.Flags().String("lock-file", defaultLockFile, "Path to the lock file to write")
+
+	_ = cmd.MarkFlagFilename("lock-file", "lock")
+
+	return cmd
+}
+
+func writeLockFile(cfg *config.Config, out output.Root) error {
+	err := output.NewLockFile(out).Write(cfg.LockFile)
+	if err != nil {
+		return err
+	}
+
+	m := fmt.Sprintf("Wrote lock file to %s", ui.DisplayPath(cfg.LockFile))
+	if out.TotalMonthlyCost != nil {
+		m += fmt.Sprintf(" with a total monthly cost of $%s", out.TotalMonthlyCost.StringFixed(2))
+	}
+	if cfg.IsLogging() {
+		log.Info(m)
+	} else {
+		fmt.Fprintln(os.Stderr, m)
+	}
+
+	return nil
+}
+
+func checkLockFile(cfg *config.Config, out output.Root, lifecycle *events.LifecycleEmitter) error {
+	locked, err := output.LoadLockFile(cfg.LockFile)
+	if err != nil {
+		return err
+	}
+
+	diffs := locked.Compare(output.NewLockFile(out))
+	if len(diffs) == 0 {
+		return nil
+	}
+
+	lifecycle.PolicyViolated("lock_file", fmt.Sprintf("Costs have changed since %s was generated: %s", cfg.LockFile, strings.Join(diffs, ", ")))
+
+	m := fmt.Sprintf("Costs have changed since %s was generated:\n", ui.DisplayPath(cfg.LockFile))
+	m += fmt.Sprintf("  - %s\n\n", strings.Join(diffs, "\n  - "))
+	m += fmt.Sprintf("Run %s to approve the new costs", ui.PrimaryString("infracost lock"))
+
+	return events.NewError(errors.New(m), "Costs have changed since the lock file was generated")
+}
@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"os"
 	"runtime/debug"
+	"strings"
 
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/events"
+	"github.com/infracost/infracost/internal/i18n"
+	"github.com/infracost/infracost/internal/output"
 	"github.com/infracost/infracost/internal/ui"
 	"github.com/infracost/infracost/internal/update"
 	"github.com/infracost/infracost/internal/version"
@@ -37,7 +40,7 @@ func main() {
 			handleUnexpectedErr(cfg, unexpectedErr)
 		}
 
-		handleUpdateMessage(updateMessageChan)
+		handleUpdateMessage(cfg, updateMessageChan)
 
 		if appErr != nil || unexpectedErr != nil {
 			os.Exit(1)
@@ -76,11 +79,29 @@ func main() {
 
 	rootCmd.PersistentFlags().Bool("no-color", false, "Turn off colored output")
 	rootCmd.PersistentFlags().String("log-level", "", "Log level (trace, debug, info, warn, error, fatal)")
+	rootCmd.PersistentFlags().String("locale", "", fmt.Sprintf("Language of the output messages (%s)", strings.Join(i18n.Locales(), ", ")))
+	rootCmd.PersistentFlags().Duration("deadline", 0, "Stop fetching prices after this long, e.g. 2m, and output the partial results")
+	rootCmd.PersistentFlags().String("max-cache-size", "", "Max size of the download caches, e.g. 500MB. The least recently used entries are removed")
 
 	rootCmd.AddCommand(registerCmd(cfg))
 	rootCmd.AddCommand(diffCmd(cfg))
 	rootCmd.AddCommand(breakdownCmd(cfg))
 	rootCmd.AddCommand(outputCmd(cfg))
+	rootCmd.AddCommand(lockCmd(cfg))
+	rootCmd.AddCommand(snapshotCmd(cfg))
+	rootCmd.AddCommand(notifyCmd(cfg))
+	rootCmd.AddCommand(commentCmd(cfg))
+	rootCmd.AddCommand(serveCmd(cfg))
+	rootCmd.AddCommand(pricingCmd(cfg))
+	rootCmd.AddCommand(githubAppTokenCmd(cfg))
+	rootCmd.AddCommand(githubCheckCmd(cfg))
+	rootCmd.AddCommand(inventoryCmd(cfg))
+	rootCmd.AddCommand(generateCmd(cfg))
+	rootCmd.AddCommand(resourcesCmd())
+	rootCmd.AddCommand(planCmd())
+	rootCmd.AddCommand(usageCmd(cfg))
+	rootCmd.AddCommand(selfUpdateCmd(cfg))
+	rootCmd.AddCommand(supportBundleCmd(cfg))
 	rootCmd.AddCommand(completionCmd())
 
 	rootCmd.SetUsageTemplate(fmt.Sprintf(`%s{{if .Runnable}}
@@ -132,8 +153,8 @@ func startUpdateCheck(cfg *config.Config, c chan *update.Info) {
 	}()
 }
 
-func checkAPIKey(apiKey string, apiEndpoint string, defaultEndpoint string) error {
-	if apiEndpoint == defaultEndpoint && apiKey == "" {
+func checkAPIKey(cfg *config.Config) error {
+	if cfg.RequiresAPIKey() && cfg.APIKey == "" {
 		return errors.New(fmt.Sprintf(
 			"No INFRACOST_API_KEY environment variable is set.\nWe run a free Cloud Pricing API, to get an API key run %s",
 			ui.PrimaryString("infracost register"),
@@ -174,12 +195,18 @@ func handleUnexpectedErr(cfg *config.Config, unexpectedErr interface{}) {
 	events.SendReport(cfg, "error", fmt.Sprintf("%s\n%s", unexpectedErr, stack))
 }
 
-func handleUpdateMessage(updateMessageChan chan *update.Info) {
+func handleUpdateMessage(cfg *config.Config, updateMessageChan chan *update.Info) {
 	updateInfo := <-updateMessageChan
+
+	// The message would be out of date after the binary has been updated
+	if cfg.Environment.Command == "self-update" {
+		return
+	}
+
 	if updateInfo != nil {
 		msg := fmt.Sprintf("\n%s %s %s → %s\n%s\n",
-			ui.WarningString("Update:"),
-			"A new version of Infracost is available:",
+			ui.WarningString(i18n.T("Update:")),
+			i18n.T("A new version of Infracost is available:"),
 			ui.PrimaryString(version.Version),
 			ui.PrimaryString(updateInfo.LatestVersion),
 			ui.Indent(updateInfo.Cmd, "  "),
@@ -202,6 +229,37 @@ func loadGlobalFlags(cfg *config.Config, cmd *cobra.Command) error {
 		}
 	}
 
+	if cmd.Flags().Changed("locale") {
+		cfg.Locale, _ = cmd.Flags().GetString("locale")
+	}
+
+	if err := i18n.SetLocale(cfg.Locale); err != nil {
+		return err
+	}
+
+	roundingPolicy, err := cfg.RoundingPolicy()
+	if err != nil {
+		return err
+	}
+
+	output.SetRoundingPolicy(roundingPolicy)
+
+	if cmd.Flags().Changed("deadline") {
+		cfg.Deadline, _ = cmd.Flags().GetDuration("deadline")
+	}
+
+	if cfg.Deadline < 0 {
+		return fmt.Errorf("Invalid deadline %s, it must be 0 or more", cfg.Deadline)
+	}
+
+	if cmd.Flags().Changed("max-cache-size") {
+		cfg.MaxCacheSize, _ = cmd.Flags().GetString("max-cache-size")
+	}
+
+	if _, err := cfg.MaxCacheSizeBytes(); err != nil {
+		return err
+	}
+
 	if cmd.Flags().Changed("pricing-api-endpoint") {
 		cfg.PricingAPIEndpoint, _ = cmd.Flags().GetString("pricing-api-endpoint")
 	}
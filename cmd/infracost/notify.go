@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func notifyCmd(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "notify",
+		Short: "Post a summary of the cost change to Slack or Microsoft Teams",
+		Long: `Post a summary of the cost change in an Infracost JSON file to Slack or
+Microsoft Teams.
+
+The summary has the total monthly cost change and the change of each project.
+The webhooks can also be set with the INFRACOST_SLACK_WEBHOOK_URL and
+INFRACOST_TEAMS_WEBHOOK_URL environment variables. If a threshold is set, the
+summary is only posted when the total monthly cost changes by at least the
+threshold.`,
+		Example: `  Post to Slack when the monthly cost changes by at least 5%:
+
+      infracost diff --path /path/to/code --format json > infracost.json
+      infracost notify --path infracost.json --slack-webhook https://hooks.slack.com/services/... --threshold 5%
+
+  Post to Microsoft Teams when the monthly cost changes by at least $100, with a link to the report:
+
+      infracost notify --path infracost.json --teams-webhook https://... --threshold 100 --report-url https://ci.example.com/runs/123`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, _ := cmd.Flags().GetString("path")
+
+			slackWebhook := cfg.SlackWebhookURL
+			if cmd.Flags().Changed("slack-webhook") {
+				slackWebhook, _ = cmd.Flags().GetString("slack-webhook")
+			}
+			teamsWebhook := cfg.TeamsWebhookURL
+			if cmd.Flags().Changed("teams-webhook") {
+				teamsWebhook, _ = cmd.Flags().GetString("teams-webhook")
+			}
+
+			if slackWebhook == "" && teamsWebhook == "" {
+				ui.PrintUsageErrorAndExit(cmd, "Either --slack-webhook or --teams-webhook must be specified")
+			}
+
+			var threshold *output.DiffThreshold
+			if cmd.Flags().Changed("threshold") {
+				s, _ := cmd.Flags().GetString("threshold")
+
+				var err error
+				threshold, err = output.ParseThreshold(s)
+				if err != nil {
+					ui.PrintUsageErrorAndExit(cmd, err.Error())
+				}
+			}
+
+			out, err := loadOutputJSON(path)
+			if err != nil {
+				return err
+			}
+
+			if !threshold.IsSignificant(out) {
+				fmt.Fprintf(os.Stderr, "The monthly cost change is below the threshold of %s, no notification was posted\n", threshold.String())
+				return nil
+			}
+
+			title, _ := cmd.Flags().GetString("title")
+			reportURL, _ := cmd.Flags().GetString("report-url")
+			n := output.BuildNotification(out, title, reportURL)
+
+			if slackWebhook != "" {
+				err = output.PostNotification(slackWebhook, n.SlackPayload())
+				if err != nil {
+					return errors.Wrap(err, "Error posting to Slack")
+				}
+				fmt.Fprintln(os.Stderr, "Posted notification to Slack")
+			}
+
+			if teamsWebhook != "" {
+				err = output.PostNotification(teamsWebhook, n.TeamsPayload())
+				if err != nil {
+					return errors.Wrap(err, "Error posting to Microsoft Teams")
+				}
+				fmt.Fprintln(os.Stderr, "Posted notification to Microsoft Teams")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("path", "", "Path to the Infracost JSON file")
+	cmd.Flags().String("slack-webhook", "", "Slack incoming webhook URL to post to")
+	cmd.Flags().String("teams-webhook", "", "Microsoft Teams workflow webhook URL to post to")
+	cmd.Flags().String("threshold", "", "Only post if the total monthly cost changes by at least this percentage, e.g. 5%, or amount in USD, e.g. 100")
+	cmd.Flags().String("report-url", "", "URL of the full report to link to, e.g. the CI job or an uploaded HTML report")
+	cmd.Flags().String("title", "", "Title to start the summary with, e.g. the repo name")
+
+	_ = cmd.MarkFlagRequired("path")
+	_ = cmd.MarkFlagFilename("path", "json")
+
+	return cmd
+}
@@ -1,10 +1,13 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/output"
@@ -32,39 +35,93 @@ func outputCmd(cfg *config.Config) *cobra.Command {
 
   Merge multiple Infracost JSON files:
 
-			infracost output --format json --path out*.json`,
+      infracost output --format json --path out*.json
+
+  Merge the Infracost JSON files listed in a manifest, writing a log of the merge:
+
+      infracost output --format json --manifest infracost-manifest.yml --merge-log merge-log.json
+
+  Combine the results of projects that were split across parallel CI jobs with --shard:
+
+      infracost breakdown --config-file infracost.yml --shard 1/2 --format json > shard-1.json
+      infracost breakdown --config-file infracost.yml --shard 2/2 --format json > shard-2.json
+      infracost output --format table --path "shard-*.json"
+
+  Show the monthly costs rolled up to the cost centers in a hierarchy file:
+
+      infracost output --path out*.json --hierarchy-file infracost-hierarchy.yml
+
+  Export the estimates as FOCUS billing data rows for a FinOps platform:
+
+      infracost output --format focus --path out*.json > estimates.csv
+
+  Add the cost estimate to Atlantis plan comments from a custom workflow step. Set the
+  atlantis_project label of a project to name its section after its project in atlantis.yaml:
+
+      infracost breakdown --path $PLANFILE.json --format json > $PLANFILE.infracost.json
+      infracost output --format atlantis-comment --path $PLANFILE.infracost.json
+
+  Show the monthly costs of each AWS account with their discounts and credits:
+
+      infracost output --path out*.json --accounts-file infracost-accounts.yml`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			inputFiles := []string{}
-
 			paths, _ := cmd.Flags().GetStringArray("path")
+			manifestPath, _ := cmd.Flags().GetString("manifest")
+
+			if len(paths) == 0 && manifestPath == "" {
+				ui.PrintUsageErrorAndExit(cmd, "Either --path or --manifest must be specified")
+			}
+
+			artifacts := []output.Artifact{}
 			for _, path := range paths {
 				matches, _ := filepath.Glob(path)
-				inputFiles = append(inputFiles, matches...)
+				for _, m := range matches {
+					artifacts = append(artifacts, output.Artifact{Path: m})
+				}
+			}
+
+			if manifestPath != "" {
+				manifest, err := output.LoadManifest(manifestPath)
+				if err != nil {
+					return err
+				}
+				artifacts = append(artifacts, manifest.Artifacts...)
 			}
 
-			inputs := make([]output.ReportInput, 0, len(inputFiles))
-			for _, f := range inputFiles {
-				data, err := ioutil.ReadFile(f)
+			inputs := make([]output.ReportInput, 0, len(artifacts))
+			precedences := make([]int, 0, len(artifacts))
+			for _, a := range artifacts {
+				data, err := readArtifact(a)
 				if err != nil {
-					return errors.Wrap(err, "Error reading JSON file")
+					return err
 				}
 
 				j, err := output.Load(data)
 				if err != nil {
-					return errors.Wrap(err, "Error parsing JSON file")
+					return errors.Wrapf(err, "Error parsing JSON file %s", a.Location())
 				}
 
 				if !checkOutputVersion(j.Version) {
-					return fmt.Errorf("Invalid Infracost JSON file version. Supported versions are %s ≤ x ≤ %s", minOutputVersion, maxOutputVersion)
+					return fmt.Errorf("Invalid Infracost JSON file version in %s. Supported versions are %s ≤ x ≤ %s", a.Location(), minOutputVersion, maxOutputVersion)
 				}
 
 				inputs = append(inputs, output.ReportInput{
 					Metadata: map[string]string{
-						"filename": f,
+						"filename": a.Location(),
 					},
 					Root: j,
 				})
+				precedences = append(precedences, a.Precedence)
+			}
+
+			inputs, mergeLog := output.MergeInputs(inputs, precedences)
+
+			if mergeLogPath, _ := cmd.Flags().GetString("merge-log"); mergeLogPath != "" {
+				err := writeMergeLog(mergeLogPath, mergeLog)
+				if err != nil {
+					return err
+				}
 			}
 
 			format, _ := cmd.Flags().GetString("format")
@@ -97,6 +154,31 @@ func outputCmd(cfg *config.Config) *cobra.Command {
 			}
 			opts.ShowSkipped, _ = cmd.Flags().GetBool("show-skipped")
 
+			if cmd.Flags().Changed("group-by") {
+				opts.GroupBy, _ = cmd.Flags().GetString("group-by")
+				if _, _, err := output.ParseGroupBy(opts.GroupBy); err != nil {
+					ui.PrintUsageErrorAndExit(cmd, err.Error())
+				}
+			}
+
+			opts.DiffThreshold = newDiffThreshold(loadDiffThresholdFlags(cmd))
+
+			if hierarchyPath, _ := cmd.Flags().GetString("hierarchy-file"); hierarchyPath != "" {
+				h, err := output.LoadHierarchy(hierarchyPath)
+				if err != nil {
+					return err
+				}
+				opts.Hierarchy = h
+			}
+
+			if accountsPath, _ := cmd.Flags().GetString("accounts-file"); accountsPath != "" {
+				a, err := output.LoadAccounts(accountsPath)
+				if err != nil {
+					return err
+				}
+				opts.Accounts = a
+			}
+
 			combined := output.Combine(inputs, opts)
 
 			var (
@@ -116,6 +198,14 @@ func outputCmd(cfg *config.Config) *cobra.Command {
 				b, err = output.ToHTML(combined, opts)
 			case "diff":
 				b, err = output.ToDiff(combined, opts)
+			case "annotations":
+				b, err = output.ToAnnotations(combined, opts)
+			case "focus":
+				b, err = output.ToFOCUS(combined, opts)
+			case "prometheus":
+				b, err = output.ToPrometheus(combined, opts)
+			case "atlantis-comment":
+				b, err = output.ToAtlantisComment(combined, opts)
 			default:
 				b, err = output.ToTable(combined, opts)
 			}
@@ -130,20 +220,72 @@ func outputCmd(cfg *config.Config) *cobra.Command {
 	}
 
 	cmd.Flags().StringArrayP("path", "p", []string{}, "Path to Infracost JSON files")
-	_ = cmd.MarkFlagRequired("path")
+	cmd.Flags().String("manifest", "", "Path to a manifest file listing the paths or URLs of Infracost JSON files to combine")
+	cmd.Flags().String("merge-log", "", "Path to write a JSON log of the combined files and which duplicate projects were used")
 	_ = cmd.MarkFlagFilename("path", "json")
+	_ = cmd.MarkFlagFilename("manifest", "yml")
 
-	cmd.Flags().String("format", "table", "Output format: json, diff, table, html")
+	cmd.Flags().String("format", "table", "Output format: json, diff, table, html, annotations, focus, prometheus, atlantis-comment")
 	cmd.Flags().Bool("show-skipped", false, "Show unsupported resources, some of which might be free")
 	cmd.Flags().StringSlice("fields", []string{"monthlyQuantity", "unit", "monthlyCost"}, "Comma separated list of output fields: price,monthlyQuantity,unit,hourlyCost,monthlyCost.\nSupported by table and html output formats")
+	cmd.Flags().String("group-by", "", "Show monthly costs grouped by a resource tag or project label, e.g. tag:team or label:env. Supported by table and json output formats")
+	cmd.Flags().String("hierarchy-file", "", "Path to a cost center hierarchy file, to show the monthly costs rolled up to each cost center. Supported by table and json output formats")
+	cmd.Flags().String("accounts-file", "", "Path to an AWS accounts file, to show the monthly costs of each account after its discounts and credits. Supported by table and json output formats")
+	_ = cmd.MarkFlagFilename("hierarchy-file", "yml")
+	_ = cmd.MarkFlagFilename("accounts-file", "yml")
+
+	addDiffThresholdFlags(cmd)
 
 	_ = cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return []string{"table", "json", "html"}, cobra.ShellCompDirectiveDefault
+		return []string{"table", "json", "html", "annotations", "focus", "prometheus", "atlantis-comment"}, cobra.ShellCompDirectiveDefault
 	})
 
 	return cmd
 }
 
+// readArtifact reads the Infracost JSON of an artifact from its path or URL.
+func readArtifact(a output.Artifact) ([]byte, error) {
+	if a.URL == "" {
+		data, err := ioutil.ReadFile(a.Path)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error reading JSON file")
+		}
+		return data, nil
+	}
+
+	client := &http.Client{Timeout: 60 * time.Second}
+	resp, err := client.Get(a.URL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error downloading JSON file %s", a.URL)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, errors.Errorf("Error downloading JSON file %s: %s", a.URL, resp.Status)
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error downloading JSON file %s", a.URL)
+	}
+
+	return data, nil
+}
+
+func writeMergeLog(path string, mergeLog output.MergeLog) error {
+	b, err := json.MarshalIndent(mergeLog, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "Error generating merge log")
+	}
+
+	err = ioutil.WriteFile(path, append(b, '\n'), 0600)
+	if err != nil {
+		return errors.Wrap(err, "Error writing merge log")
+	}
+
+	return nil
+}
+
 func checkOutputVersion(v string) bool {
 	if !strings.HasPrefix(v, "v") {
 		v = "v" + v
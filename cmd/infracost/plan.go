@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func planCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Work with Terraform plan JSON files",
+		Long:  "Work with Terraform plan JSON files",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(planSanitizeCmd())
+
+	return cmd
+}
+
+func planSanitizeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sanitize <plan JSON file>",
+		Short: "Remove sensitive data from a Terraform plan JSON file",
+		Long: `Remove sensitive data from a Terraform plan JSON file so it can be attached to bug reports.
+
+Secrets, values Terraform marks as sensitive and tag values are replaced with
+REDACTED. IPs, AWS account IDs and Azure subscription IDs are replaced with
+placeholders, using the same placeholder for each occurrence of a value. The
+resource addresses and the attributes used for pricing are kept so the
+sanitized plan gives the same estimate. Check the output before sharing it,
+since secrets in attributes with unexpected names can't be detected.`,
+		Example: `  Sanitize a plan JSON file:
+
+      terraform show -json tfplan.binary > plan.json
+      infracost plan sanitize plan.json --out-file plan-sanitized.json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outFile, _ := cmd.Flags().GetString("out-file")
+
+			j, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return errors.Wrap(err, "Error reading plan JSON file")
+			}
+
+			b, err := terraform.SanitizePlanJSON(j)
+			if err != nil {
+				return err
+			}
+
+			if outFile == "" {
+				fmt.Println(string(b))
+				return nil
+			}
+
+			err = ioutil.WriteFile(outFile, append(b, '\n'), 0600)
+			if err != nil {
+				return errors.Wrap(err, "Error writing plan JSON file")
+			}
+
+			cmd.Printf("Sanitized plan JSON saved to %s\n", ui.DisplayPath(outFile))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("out-file", "", "Path to save the sanitized plan JSON to, defaults to stdout")
+
+	_ = cmd.MarkFlagFilename("out-file", "json")
+
+	return cmd
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/prices"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func pricingCmd(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pricing",
+		Short: "Manage pricing data",
+		Long:  "Manage pricing data",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(pricingDownloadCmd(cfg))
+
+	return cmd
+}
+
+func pricingDownloadCmd(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "download",
+		Short: "Download a pricing snapshot for use in offline mode",
+		Long: `Download a pricing snapshot for use in offline mode.
+
+The snapshot contains all the prices of the services in the regions, so it
+can be copied to an environment without network access and used with the
+--offline flag of the breakdown and diff commands.`,
+		Example: `  Download the EC2 and RDS prices for eu-west-1:
+
+      infracost pricing download --services ec2,rds --regions eu-west-1
+
+  Use the snapshot without calling the pricing API:
+
+      infracost breakdown --path /path/to/code --offline`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkAPIKey(cfg); err != nil {
+				return err
+			}
+
+			services, _ := cmd.Flags().GetStringSlice("services")
+			regions, _ := cmd.Flags().GetStringSlice("regions")
+			out, _ := cmd.Flags().GetString("output")
+
+			if len(services) == 0 {
+				ui.PrintUsageErrorAndExit(cmd, "No services specified")
+			}
+
+			if out == "" {
+				out = config.PricingSnapshotFilePath()
+			}
+
+			spinnerOpts := ui.SpinnerOptions{
+				EnableLogging: cfg.IsLogging(),
+				NoColor:       cfg.NoColor,
+			}
+			spinner = ui.NewSpinner("Downloading pricing snapshot", spinnerOpts)
+
+			q := prices.NewGraphQLQueryRunner(fmt.Sprintf("%s/graphql", cfg.PricingAPIEndpoint), cfg.APIKey, prices.RetryPolicyFromConfig(cfg))
+			priceBook, err := q.DownloadPricingSnapshot(context.Background(), services, regions)
+			if err != nil {
+				spinner.Fail()
+				return err
+			}
+
+			err = priceBook.Write(out)
+			if err != nil {
+				spinner.Fail()
+				return err
+			}
+
+			spinner.Success()
+
+			cmd.Printf("Saved %d products to %s\n", len(priceBook.Products), ui.DisplayPath(out))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSlice("services", []string{}, "Comma separated list of services to download, e.g. ec2,rds or AmazonEC2")
+	cmd.Flags().StringSlice("regions", []string{}, "Comma separated list of regions to download, e.g. eu-west-1. Defaults to all regions")
+	cmd.Flags().String("output", "", "Path to save the snapshot to. Defaults to the path used by --offline")
+
+	_ = cmd.MarkFlagFilename("output", "json")
+
+	return cmd
+}
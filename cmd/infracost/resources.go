@@ -0,0 +1,198 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func resourcesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resources",
+		Short: "Show the resources that Infracost supports",
+		Long:  "Show the resources that Infracost supports",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(resourcesListCmd(), resourcesDescribeCmd())
+
+	return cmd
+}
+
+func resourcesListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the supported resource types",
+		Long: `List the supported resource types, their Terraform provider and their support level:
+
+  full         Priced from the resource's arguments
+  usage-based  Some costs depend on the usage file, e.g. the requests of a Lambda function
+  free         The resource is free`,
+		Example: `  List the supported resource types as JSON:
+
+      infracost resources list --format json
+
+  List the supported Google resource types:
+
+      infracost resources list --provider google`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, _ := cmd.Flags().GetString("format")
+			provider, _ := cmd.Flags().GetString("provider")
+
+			matrix, err := terraform.ResourceSupportMatrix(provider)
+			if err != nil {
+				return err
+			}
+
+			switch strings.ToLower(format) {
+			case "json":
+				b, err := json.MarshalIndent(matrix, "", "  ")
+				if err != nil {
+					return errors.Wrap(err, "Error generating output")
+				}
+				fmt.Println(string(b))
+			case "table":
+				fmt.Println(resourceSupportMatrixToTable(matrix))
+			default:
+				ui.PrintUsageErrorAndExit(cmd, "--format only supports json or table")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("format", "table", "Output format: json, table")
+	cmd.Flags().String("provider", "", "Only list the resource types of a Terraform provider, e.g. aws, azurerm or google")
+
+	return cmd
+}
+
+func resourceSupportMatrixToTable(matrix []terraform.ResourceSupport) string {
+	t := table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+	t.Style().Options.SeparateRows = false
+	t.Style().Options.SeparateHeader = false
+	t.Style().Format.Header = text.FormatDefault
+
+	t.AppendHeader(table.Row{
+		ui.UnderlineString("Resource type"),
+		ui.UnderlineString("Provider"),
+		ui.UnderlineString("Support"),
+	})
+
+	counts := make(map[string]int)
+	for _, r := range matrix {
+		t.AppendRow(table.Row{r.ResourceType, r.Provider, r.Support})
+		counts[r.Support]++
+	}
+
+	return fmt.Sprintf("%s\n\n%d resource types: %d full, %d usage-based, %d free",
+		t.Render(),
+		len(matrix),
+		counts[terraform.SupportLevelFull],
+		counts[terraform.SupportLevelUsageBased],
+		counts[terraform.SupportLevelFree],
+	)
+}
+
+func resourcesDescribeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "describe <resource type>",
+		Short: "Show the cost components and usage keys of a resource type",
+		Long: `Show the cost components and usage keys of a resource type.
+
+The JSON output is versioned so tools like usage file editors and IDE plugins
+can use it to stay in sync with the supported resources. The cost components
+are the ones the resource has when none of its arguments are set, so
+components that depend on the resource's arguments might not be included.`,
+		Example: `  Show the usage keys of a Lambda function as JSON:
+
+      infracost resources describe aws_lambda_function --format json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, _ := cmd.Flags().GetString("format")
+
+			desc, err := terraform.DescribeResource(args[0])
+			if err != nil {
+				return err
+			}
+
+			switch strings.ToLower(format) {
+			case "json":
+				b, err := json.MarshalIndent(desc, "", "  ")
+				if err != nil {
+					return errors.Wrap(err, "Error generating output")
+				}
+				fmt.Println(string(b))
+			case "table":
+				fmt.Println(resourceDescriptionToText(desc))
+			default:
+				ui.PrintUsageErrorAndExit(cmd, "--format only supports json or table")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("format", "table", "Output format: json, table")
+
+	return cmd
+}
+
+func resourceDescriptionToText(desc *terraform.ResourceDescription) string {
+	s := ui.BoldString(desc.ResourceType)
+
+	if desc.Free {
+		return s + "\n\nThis resource is free."
+	}
+
+	for _, n := range desc.Notes {
+		s += "\n" + ui.FaintString(n)
+	}
+
+	s += "\n\n" + ui.BoldString("Cost components")
+	if len(desc.CostComponents) == 0 && len(desc.SubResources) == 0 {
+		s += "\n  None without its arguments set"
+	}
+	for _, c := range desc.CostComponents {
+		s += fmt.Sprintf("\n  %s (%s)", c.Name, c.Unit)
+	}
+	s += subResourceDescriptionsToText(desc.SubResources, "  ")
+
+	s += "\n\n" + ui.BoldString("Usage keys")
+	if len(desc.UsageKeys) == 0 {
+		s += "\n  None"
+	}
+	for _, k := range desc.UsageKeys {
+		s += fmt.Sprintf("\n  %s (%s)", k.Key, k.Type)
+		if k.Description != "" {
+			s += " " + ui.FaintString(k.Description)
+		}
+	}
+
+	return s
+}
+
+func subResourceDescriptionsToText(subResources []terraform.SubResourceDescription, indent string) string {
+	s := ""
+	for _, r := range subResources {
+		s += fmt.Sprintf("\n%s%s", indent, r.Name)
+		for _, c := range r.CostComponents {
+			s += fmt.Sprintf("\n%s  %s (%s)", indent, c.Name, c.Unit)
+		}
+		s += subResourceDescriptionsToText(r.SubResources, indent+"  ")
+	}
+
+	return s
+}
@@ -1,26 +1,32 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/estimate"
 	"github.com/infracost/infracost/internal/events"
 	"github.com/infracost/infracost/internal/output"
 	"github.com/infracost/infracost/internal/prices"
 	"github.com/infracost/infracost/internal/providers"
+	"github.com/infracost/infracost/internal/providers/terraform"
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/infracost/infracost/internal/ui"
 	"github.com/infracost/infracost/internal/usage"
 	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
 func addRunFlags(cmd *cobra.Command) {
-	cmd.Flags().StringP("path", "p", "", "Path to the Terraform directory or JSON/plan file")
+	cmd.Flags().StringP("path", "p", "", "Path to the Terraform directory or JSON/plan file, Kubernetes manifests or Helm chart, or - to read plan JSON from stdin")
 
 	cmd.Flags().String("config-file", "", "Path to Infracost config file. Cannot be used with path, terraform* or usage-file flags")
 	cmd.Flags().String("usage-file", "", "Path to Infracost usage file that specifies values for usage-based resources")
@@ -28,19 +34,72 @@ func addRunFlags(cmd *cobra.Command) {
 	cmd.Flags().String("terraform-plan-flags", "", "Flags to pass to 'terraform plan'. Applicable when path is a Terraform directory")
 	cmd.Flags().String("terraform-workspace", "", "Terraform workspace to use. Applicable when path is a Terraform directory")
 
+	cmd.Flags().StringSlice("compare-environments", []string{}, "Run the Terraform directory once for each environment's var file and compare their costs, in the format name=file, e.g. dev=dev.tfvars,prod=prod.tfvars")
+
+	cmd.Flags().Bool("auto-detect", false, "Find all the Terraform projects in the path and run them, instead of running the path as one project")
+
+	cmd.Flags().String("shard", "", "Only run this shard of the projects, in the format i/n, so they can be split across n parallel jobs and combined with 'infracost output'")
+
 	cmd.Flags().Bool("show-skipped", false, "Show unsupported resources, some of which might be free")
+	cmd.Flags().Float64("max-uncovered-percent", 0, "Fail if more than this percentage of the resource types in a project are unsupported or missing usage")
 
 	cmd.Flags().Bool("sync-usage-file", false, "Sync usage-file with missing resources, needs usage-file too (experimental)")
 
+	cmd.Flags().String("price-overrides-file", "", "Path to a price overrides file that applies negotiated discounts or fixed prices")
+	cmd.Flags().String("cost-adjustments-file", "", "Path to a cost adjustments file that changes the costs of matching cost components, e.g. to add overheads")
+	cmd.Flags().String("plugins-dir", "", "Path to a directory of plugins that price resources that aren't supported. Defaults to the plugins dir of the Infracost config dir")
+	cmd.Flags().String("custom-prices-file", "", "Path to a custom prices file with the prices of resources that aren't supported, e.g. internal rates for on-prem resources")
+	cmd.Flags().Bool("offline", false, "Use the pricing snapshot downloaded by 'infracost pricing download' instead of the pricing API")
+	cmd.Flags().String("record-fixtures", "", "Directory to record the pricing queries and their results to, so they can be replayed with --replay-fixtures")
+	cmd.Flags().String("replay-fixtures", "", "Directory of recorded pricing fixtures to use instead of the pricing API")
+	cmd.Flags().Bool("verbose", false, "Print more details of the run, such as the requests made to the pricing API")
+
 	_ = cmd.MarkFlagFilename("path", "json", "tf")
 	_ = cmd.MarkFlagFilename("config-file", "yml")
 	_ = cmd.MarkFlagFilename("usage-file", "yml")
+	_ = cmd.MarkFlagFilename("price-overrides-file", "yml")
+	_ = cmd.MarkFlagFilename("cost-adjustments-file", "yml")
+	_ = cmd.MarkFlagFilename("custom-prices-file", "yml")
+	_ = cmd.MarkFlagDirname("plugins-dir")
 }
 
 func runMain(cmd *cobra.Command, cfg *config.Config) error {
+	loadOrgDefaults(cfg)
+
+	lifecycle := events.NewLifecycleEmitter(cfg)
+	lifecycle.RunStarted(cmd.Name(), cfg.Projects)
+
+	err := runProjects(cmd, cfg, lifecycle)
+	lifecycle.RunCompleted(err)
+
+	return err
+}
+
+func runProjects(cmd *cobra.Command, cfg *config.Config, lifecycle *events.LifecycleEmitter) error {
+	// The deadline includes the time taken to load the projects, so the whole
+	// run is time-boxed
+	var deadline time.Time
+	if cfg.Deadline > 0 {
+		deadline = time.Now().Add(cfg.Deadline)
+	}
+
+	// The custom prices are loaded after the plugins so they take precedence
+	err := estimate.LoadPlugins(cfg)
+	if err != nil {
+		return err
+	}
+
+	err = estimate.LoadCustomPrices(cfg)
+	if err != nil {
+		return err
+	}
+
 	projects := make([]*schema.Project, 0)
+	// maxUncoveredPercents are the projects' own max_uncovered_percent, which
+	// are checked instead of the flag
+	maxUncoveredPercents := make(map[*schema.Project]float64)
 
-	for _, projectCfg := range cfg.Projects {
+	for i, projectCfg := range cfg.Projects {
 		provider, err := providers.Detect(cfg, projectCfg)
 
 		if err != nil {
@@ -62,16 +121,35 @@ func runMain(cmd *cobra.Command, cfg *config.Config) error {
 			return events.NewError(errors.New(m), "Cannot use Terraform state JSON with the infracost diff command")
 		}
 
+		if cfg.CompareEnvironments && provider.Type() != "terraform_dir" {
+			return errors.New("--compare-environments can only be used with a Terraform directory since it uses a var file for each environment")
+		}
+
 		m := fmt.Sprintf("Detected %s at %s", provider.DisplayType(), ui.DisplayPath(projectCfg.Path))
+		if projectCfg.Path == "" || projectCfg.Path == "-" {
+			m = fmt.Sprintf("Detected %s", provider.DisplayType())
+		}
+		if len(cfg.Projects) > 1 {
+			m = fmt.Sprintf("[%d/%d] %s", i+1, len(cfg.Projects), m)
+		}
 		if cfg.IsLogging() {
 			log.Info(m)
 		} else {
 			fmt.Fprintln(os.Stderr, m)
 		}
 
+		if installer, ok := provider.(terraform.TerraformInstaller); ok {
+			projectCfg.TerraformBinary, err = installer.InstallTerraformIfMissing()
+			if err != nil {
+				return err
+			}
+		}
+
 		cfg.Environment.SetProjectEnvironment(provider.Type(), projectCfg)
 
-		u, err := usage.LoadFromFile(projectCfg.UsageFile, cfg.SyncUsageFile)
+		syncUsageFile := projectCfg.SyncsUsageFile(cfg.SyncUsageFile)
+
+		u, err := usage.LoadFromFile(projectCfg.UsageFile, syncUsageFile)
 		if err != nil {
 			return err
 		}
@@ -82,17 +160,27 @@ func runMain(cmd *cobra.Command, cfg *config.Config) error {
 		metadata := config.DetectProjectMetadata(projectCfg)
 		metadata.Type = provider.Type()
 		provider.AddMetadata(metadata)
-		name := schema.GenerateProjectName(metadata)
+		name := config.ProjectName(projectCfg, metadata)
 
 		project := schema.NewProject(name, metadata)
-		err = provider.LoadResources(project, u)
+		err = provider.LoadResources(project, withUsageDefaults(u, cfg.UsageDefaults))
 		if err != nil {
 			return err
 		}
 
-		projects = append(projects, project)
+		added := []*schema.Project{project}
+		if len(cfg.CompareRegions) > 0 {
+			added = regionProjects(project, cfg.CompareRegions)
+		}
+		projects = append(projects, added...)
+
+		if projectCfg.MaxUncoveredPercent != nil {
+			for _, p := range added {
+				maxUncoveredPercents[p] = *projectCfg.MaxUncoveredPercent
+			}
+		}
 
-		if cfg.SyncUsageFile {
+		if syncUsageFile {
 			err = usage.SyncUsageData(project, u, projectCfg.UsageFile)
 			if err != nil {
 				return err
@@ -104,14 +192,31 @@ func runMain(cmd *cobra.Command, cfg *config.Config) error {
 		}
 	}
 
+	costOpts, err := estimate.LoadCostOptions(cfg)
+	if err != nil {
+		return err
+	}
+
 	spinnerOpts := ui.SpinnerOptions{
 		EnableLogging: cfg.IsLogging(),
 		NoColor:       cfg.NoColor,
 	}
 	spinner := ui.NewSpinner("Calculating monthly cost estimate", spinnerOpts)
 
+	total := 0
+	for _, project := range projects {
+		total += len(project.AllResources())
+	}
+
+	costOpts.Deadline = deadline
+
+	var priced int64
+	costOpts.OnResourcePriced = func() {
+		spinner.SetProgress("priced", "resources", int(atomic.AddInt64(&priced, 1)), total)
+	}
+
 	for _, project := range projects {
-		if err := prices.PopulatePrices(cfg, project); err != nil {
+		if err := estimate.CalculateCosts(context.Background(), cfg, project, costOpts); err != nil {
 			spinner.Fail()
 			fmt.Fprintln(os.Stderr, "")
 
@@ -133,51 +238,218 @@ func runMain(cmd *cobra.Command, cfg *config.Config) error {
 
 			return err
 		}
-
-		schema.CalculateCosts(project)
-		project.CalculateDiff()
 	}
 
 	spinner.Success()
 
+	stats := costOpts.PricingSession.Stats()
+	if cfg.Verbose {
+		printPricingStats(stats)
+	}
+
 	r := output.ToOutputFormat(projects)
+	r.PricingStats = &output.PricingStats{
+		Queries:    stats.Queries,
+		CacheHits:  stats.CacheHits,
+		Requests:   stats.Requests,
+		Retries:    stats.Retries,
+		DurationMs: stats.Duration.Milliseconds(),
+	}
 
-	opts := output.Options{
-		ShowSkipped: cfg.ShowSkipped,
-		NoColor:     cfg.NoColor,
-		Fields:      cfg.Fields,
+	if cfg.ShowRecommendations {
+		r.Recommendations = output.BuildRecommendations(projects)
 	}
 
-	var (
-		b   []byte
-		out string
-		err error
-	)
+	for _, p := range r.Projects {
+		lifecycle.ProjectEstimated(projectEstimatedData(p))
+	}
 
-	switch strings.ToLower(cfg.Format) {
-	case "json":
-		b, err = output.ToJSON(r, opts)
-		out = string(b)
-	case "html":
-		b, err = output.ToHTML(r, opts)
-		out = string(b)
-	case "diff":
-		b, err = output.ToDiff(r, opts)
-		out = fmt.Sprintf("\n%s", string(b))
-	default:
-		b, err = output.ToTable(r, opts)
-		out = fmt.Sprintf("\n%s", string(b))
+	if cmd.Name() == "lock" {
+		return writeLockFile(cfg, r)
+	}
+
+	opts := output.Options{
+		ShowSkipped:         cfg.ShowSkipped,
+		NoColor:             cfg.NoColor,
+		Fields:              cfg.Fields,
+		GroupBy:             cfg.GroupBy,
+		DiffThreshold:       newDiffThreshold(cfg.DiffThresholdAmount, cfg.DiffThresholdPercent),
+		CompareEnvironments: cfg.CompareEnvironments,
+		CompareRegions:      len(cfg.CompareRegions) > 0,
 	}
 
+	b, err := output.Format(cfg.Format, r, opts)
 	if err != nil {
 		return errors.Wrap(err, "Error generating output")
 	}
 
+	out := string(b)
+	switch strings.ToLower(cfg.Format) {
+	case "json", "html", "annotations", "focus", "prometheus", "atlantis-comment":
+	default:
+		out = fmt.Sprintf("\n%s", out)
+	}
+
 	fmt.Printf("%s\n", out)
 
+	warnModuleBudgets(r)
+
+	if len(cfg.Outputs) > 0 {
+		err = output.WriteOutputs(cfg.Outputs, r, opts)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(cfg.Webhooks) > 0 {
+		err = output.DeliverWebhooks(cfg.Webhooks, r, opts)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cmd.Name() == "diff" && cfg.LockFile != "" {
+		err = checkLockFile(cfg, r, lifecycle)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cfg.MaxUncoveredPercent != nil || len(maxUncoveredPercents) > 0 {
+		return checkCoverage(cfg.MaxUncoveredPercent, maxUncoveredPercents, projects, lifecycle)
+	}
+
 	return nil
 }
 
+// loadOrgDefaults merges the organization defaults from the dashboard API
+// beneath the local config. The run continues without them if they can't be
+// fetched, since the local config is still valid on its own.
+func loadOrgDefaults(cfg *config.Config) {
+	if !cfg.OrgDefaultsEnabled() {
+		return
+	}
+
+	d, err := config.FetchOrgDefaults(cfg.DashboardAPIEndpoint, cfg.APIKey)
+	if err != nil {
+		log.Warnf("Unable to get organization defaults: %v", err)
+		return
+	}
+
+	cfg.ApplyOrgDefaults(d)
+}
+
+// withUsageDefaults returns the usage data with the usage defaults added for
+// the keys that aren't in it. The usage data itself isn't changed so that the
+// defaults aren't written to the usage file when it's synced.
+func withUsageDefaults(u map[string]*schema.UsageData, defaults map[string]interface{}) map[string]*schema.UsageData {
+	if len(defaults) == 0 {
+		return u
+	}
+
+	return schema.MergeUsageMaps(schema.NewUsageMap(defaults), u)
+}
+
+// printPricingStats prints how the pricing API was used to get the prices, so
+// users of self-hosted pricing APIs can see the load a run puts on it.
+func printPricingStats(stats prices.RequestStats) {
+	fmt.Fprintf(os.Stderr, "Pricing API: %d requests (%d retries) taking %s, for %d queries of which %d were cached\n",
+		stats.Requests,
+		stats.Retries,
+		stats.Duration.Round(time.Millisecond),
+		stats.Queries,
+		stats.CacheHits,
+	)
+}
+
+// warnModuleBudgets warns if any of the module instances cost more than the
+// budget their module's author set. It doesn't fail the run since the
+// consumer might have good reasons to use the module differently.
+func warnModuleBudgets(r output.Root) {
+	overruns, err := output.ModuleBudgetOverruns(r)
+	if err != nil {
+		ui.PrintWarning(err.Error())
+	}
+
+	for _, o := range overruns {
+		ui.PrintWarning(o.Message())
+	}
+}
+
+// checkCoverage fails if too many of the resource types in any of the
+// projects weren't fully estimated, since the estimate could be much lower
+// than the real cost. The projects' own max percents are checked instead of
+// the max percent, which is nil if the flag isn't set.
+func checkCoverage(maxPercent *float64, projectMaxPercents map[*schema.Project]float64, projects []*schema.Project, lifecycle *events.LifecycleEmitter) error {
+	type failedCoverage struct {
+		output.Coverage
+		max decimal.Decimal
+	}
+
+	failed := make([]failedCoverage, 0)
+	for _, p := range projects {
+		var max decimal.Decimal
+		if m, ok := projectMaxPercents[p]; ok {
+			max = decimal.NewFromFloat(m)
+		} else if maxPercent != nil {
+			max = decimal.NewFromFloat(*maxPercent)
+		} else {
+			continue
+		}
+
+		c := output.BuildCoverage(p)
+		if c.UncoveredPercent().GreaterThan(max) {
+			failed = append(failed, failedCoverage{c, max})
+		}
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+
+	summary := fmt.Sprintf("Too many of the resource types were not fully estimated in %d project(s)", len(failed))
+	if maxPercent != nil && len(projectMaxPercents) == 0 {
+		summary = fmt.Sprintf("More than %s%% of the resource types were not fully estimated in %d project(s)", decimal.NewFromFloat(*maxPercent).String(), len(failed))
+	}
+
+	m := summary + ":\n"
+	for _, c := range failed {
+		m += fmt.Sprintf("\n  %s: %s%% of %d resource types, the maximum is %s%%\n", c.Project, c.UncoveredPercent().Round(1).String(), c.ResourceTypes, c.max.String())
+		if len(c.UnsupportedResourceTypes) > 0 {
+			m += fmt.Sprintf("    - Unsupported: %s\n", strings.Join(c.UnsupportedResourceTypes, ", "))
+		}
+		if len(c.MissingUsageResourceTypes) > 0 {
+			m += fmt.Sprintf("    - Missing usage: %s\n", strings.Join(c.MissingUsageResourceTypes, ", "))
+		}
+	}
+	m += fmt.Sprintf("\nAdd the usage to the usage file, see %s", ui.LinkString("https://infracost.io/usage-file"))
+
+	lifecycle.PolicyViolated("max_uncovered_percent", summary)
+
+	return events.NewError(errors.New(m), "Too many resource types were not fully estimated")
+}
+
+func projectEstimatedData(p output.Project) events.ProjectEstimatedData {
+	data := events.ProjectEstimatedData{
+		Name: p.Name,
+	}
+
+	if p.Metadata != nil {
+		data.Path = p.Metadata.Path
+	}
+	if p.Breakdown != nil {
+		data.TotalMonthlyCost = p.Breakdown.TotalMonthlyCost
+	}
+	if p.PastBreakdown != nil {
+		data.PastTotalMonthlyCost = p.PastBreakdown.TotalMonthlyCost
+	}
+	if p.Diff != nil {
+		data.DiffTotalMonthlyCost = p.Diff.TotalMonthlyCost
+	}
+
+	return data
+}
+
 func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 	hasPathFlag := cmd.Flags().Changed("path")
 	hasConfigFile := cmd.Flags().Changed("config-file")
@@ -234,9 +506,108 @@ func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 		projectCfg.TerraformUseState, _ = cmd.Flags().GetBool("terraform-use-state")
 	}
 
+	if autoDetect, _ := cmd.Flags().GetBool("auto-detect"); autoDetect {
+		if hasConfigFile {
+			ui.PrintUsageErrorAndExit(cmd, "--auto-detect flag cannot be used with the --config-file flag")
+		}
+
+		projects, err := autoDetectProjects(projectCfg)
+		if err != nil {
+			return err
+		}
+		cfg.Projects = projects
+	}
+
+	if cmd.Flags().Changed("compare-environments") {
+		if hasConfigFile || cmd.Flags().Changed("auto-detect") {
+			ui.PrintUsageErrorAndExit(cmd, "--compare-environments flag cannot be used with the --config-file or --auto-detect flags")
+		}
+
+		envFlags, _ := cmd.Flags().GetStringSlice("compare-environments")
+		projects, err := environmentProjects(projectCfg, envFlags)
+		if err != nil {
+			ui.PrintUsageErrorAndExit(cmd, err.Error())
+		}
+		cfg.Projects = projects
+		cfg.CompareEnvironments = true
+	}
+
+	if cmd.Flags().Changed("compare-regions") {
+		regions, _ := cmd.Flags().GetStringSlice("compare-regions")
+		err := validateCompareRegions(regions)
+		if err != nil {
+			ui.PrintUsageErrorAndExit(cmd, err.Error())
+		}
+		cfg.CompareRegions = regions
+	}
+
+	if cmd.Flags().Changed("shard") {
+		s, _ := cmd.Flags().GetString("shard")
+		shard, err := config.ParseShard(s)
+		if err != nil {
+			ui.PrintUsageErrorAndExit(cmd, err.Error())
+		}
+
+		total := len(cfg.Projects)
+		cfg.Projects = config.ShardProjects(cfg.Projects, shard)
+		log.Infof("Running %d of %d projects in shard %s", len(cfg.Projects), total, shard)
+	}
+
 	cfg.Format, _ = cmd.Flags().GetString("format")
 	cfg.ShowSkipped, _ = cmd.Flags().GetBool("show-skipped")
 	cfg.SyncUsageFile, _ = cmd.Flags().GetBool("sync-usage-file")
+	cfg.LockFile, _ = cmd.Flags().GetString("lock-file")
+	cfg.Verbose, _ = cmd.Flags().GetBool("verbose")
+	cfg.ShowRecommendations, _ = cmd.Flags().GetBool("show-recommendations")
+
+	if cmd.Flags().Changed("pushgateway-url") {
+		rawURL, _ := cmd.Flags().GetString("pushgateway-url")
+		pushURL, err := output.PushgatewayURL(rawURL)
+		if err != nil {
+			ui.PrintUsageErrorAndExit(cmd, err.Error())
+		}
+		cfg.Outputs = append(cfg.Outputs, &config.Output{Format: "prometheus", Destination: pushURL})
+	}
+
+	if cmd.Flags().Changed("max-uncovered-percent") {
+		cfg.MaxUncoveredPercent = loadNonNegativeFloatFlag(cmd, "max-uncovered-percent")
+	}
+
+	if cmd.Flags().Changed("price-overrides-file") {
+		cfg.PriceOverridesFile, _ = cmd.Flags().GetString("price-overrides-file")
+	}
+
+	if cmd.Flags().Changed("cost-adjustments-file") {
+		cfg.CostAdjustmentsFile, _ = cmd.Flags().GetString("cost-adjustments-file")
+	}
+
+	if cmd.Flags().Changed("plugins-dir") {
+		cfg.PluginsDir, _ = cmd.Flags().GetString("plugins-dir")
+	}
+
+	if cmd.Flags().Changed("custom-prices-file") {
+		cfg.CustomPricesFile, _ = cmd.Flags().GetString("custom-prices-file")
+	}
+
+	if cmd.Flags().Changed("offline") {
+		cfg.Offline, _ = cmd.Flags().GetBool("offline")
+	}
+
+	if cmd.Flags().Changed("record-fixtures") {
+		cfg.RecordFixturesDir, _ = cmd.Flags().GetString("record-fixtures")
+	}
+
+	if cmd.Flags().Changed("replay-fixtures") {
+		cfg.PricingBackend = "fixtures"
+		cfg.PricingFixturesDir, _ = cmd.Flags().GetString("replay-fixtures")
+	}
+
+	if cmd.Flags().Changed("group-by") {
+		cfg.GroupBy, _ = cmd.Flags().GetString("group-by")
+		if _, _, err := output.ParseGroupBy(cfg.GroupBy); err != nil {
+			ui.PrintUsageErrorAndExit(cmd, err.Error())
+		}
+	}
 
 	validFields := []string{"price", "monthlyQuantity", "unit", "hourlyCost", "monthlyCost"}
 	validFieldsFormats := []string{"table", "html"}
@@ -263,21 +634,128 @@ func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 	return nil
 }
 
+// autoDetectProjects returns a project for each Terraform project found in the
+// path, using the other flags of the project for each of them.
+func autoDetectProjects(projectCfg *config.Project) ([]*config.Project, error) {
+	paths, err := terraform.DiscoverProjects(projectCfg.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("No Terraform projects found in %s", projectCfg.Path)
+	}
+
+	projects := make([]*config.Project, 0, len(paths))
+	for _, path := range paths {
+		p := *projectCfg
+		p.Path = path
+		projects = append(projects, &p)
+	}
+
+	return projects, nil
+}
+
+// environmentProjects returns a project for each environment, which is the
+// project run with the environment's var file.
+func environmentProjects(projectCfg *config.Project, envFlags []string) ([]*config.Project, error) {
+	if len(envFlags) < 2 {
+		return nil, errors.New("--compare-environments needs at least two environments, e.g. dev=dev.tfvars,prod=prod.tfvars")
+	}
+
+	projects := make([]*config.Project, 0, len(envFlags))
+	seen := make(map[string]bool)
+
+	for _, f := range envFlags {
+		parts := strings.SplitN(f, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("Invalid environment %s, expected the format name=file", f)
+		}
+
+		name, varFile := parts[0], parts[1]
+		if seen[name] {
+			return nil, fmt.Errorf("Environment %s is specified more than once", name)
+		}
+		seen[name] = true
+
+		p := *projectCfg
+		p.Name = name
+		p.TerraformPlanFlags = strings.TrimSpace(fmt.Sprintf("%s -var-file=%s", projectCfg.TerraformPlanFlags, varFile))
+
+		p.Labels = map[string]string{}
+		for k, v := range projectCfg.Labels {
+			p.Labels[k] = v
+		}
+		p.Labels[output.EnvironmentLabel] = name
+
+		projects = append(projects, &p)
+	}
+
+	return projects, nil
+}
+
+// validateCompareRegions checks that the regions are regions of a cloud
+// vendor, since they're used to look up the prices.
+func validateCompareRegions(regions []string) error {
+	if len(regions) < 2 {
+		return errors.New("--compare-regions needs at least two regions, e.g. us-east-1,eu-west-1")
+	}
+
+	seen := make(map[string]bool)
+	for _, region := range regions {
+		if schema.RegionVendor(region) == "" {
+			return fmt.Errorf("Invalid region %s, expected a region name such as us-east-1, us-central1 or eastus", region)
+		}
+
+		if seen[region] {
+			return fmt.Errorf("Region %s is specified more than once", region)
+		}
+		seen[region] = true
+	}
+
+	return nil
+}
+
+// regionProjects returns a copy of the project for each region, with its
+// resources moved to that region so they're priced there.
+func regionProjects(project *schema.Project, regions []string) []*schema.Project {
+	projects := make([]*schema.Project, 0, len(regions))
+
+	for _, region := range regions {
+		p := project.InRegion(region)
+		p.Name = fmt.Sprintf("%s (%s)", project.Name, region)
+		if p.Metadata != nil {
+			p.Metadata.Labels[output.RegionLabel] = region
+		}
+
+		projects = append(projects, p)
+	}
+
+	return projects
+}
+
 func checkRunConfig(cfg *config.Config) error {
 	if cfg.Format == "json" && cfg.ShowSkipped {
 		ui.PrintWarning("show-skipped is not needed with JSON output format as that always includes them.\n")
 	}
 
-	if cfg.SyncUsageFile {
-		missingUsageFile := make([]string, 0)
-		for _, project := range cfg.Projects {
-			if project.UsageFile == "" {
-				missingUsageFile = append(missingUsageFile, project.Path)
-			}
+	syncing := 0
+	missingUsageFile := make([]string, 0)
+	for _, project := range cfg.Projects {
+		if !project.SyncsUsageFile(cfg.SyncUsageFile) {
+			continue
+		}
+
+		syncing++
+		if project.UsageFile == "" {
+			missingUsageFile = append(missingUsageFile, project.Path)
 		}
+	}
+
+	if syncing > 0 {
 		if len(missingUsageFile) == 1 {
 			ui.PrintWarning("Ignoring sync-usage-file as no usage-file is specified.\n")
-		} else if len(missingUsageFile) == len(cfg.Projects) {
+		} else if len(missingUsageFile) == syncing {
 			ui.PrintWarning("Ignoring sync-usage-file since no projects have a usage-file specified.\n")
 		} else if len(missingUsageFile) > 1 {
 			ui.PrintWarning(fmt.Sprintf("Ignoring sync-usage-file for following projects as no usage-file is specified for them: %s.\n", strings.Join(missingUsageFile, ", ")))
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/infracost/infracost/internal/update"
+	"github.com/infracost/infracost/internal/version"
+	"github.com/spf13/cobra"
+)
+
+func selfUpdateCmd(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "self-update",
+		Short: "Update Infracost to the latest version",
+		Long: `Update Infracost to the latest version.
+
+The release for this OS and architecture is downloaded from GitHub, verified
+against its checksum and replaces the current binary. Homebrew installs should
+be updated with brew upgrade instead.`,
+		Example: `  Update to the latest stable release:
+
+      infracost self-update
+
+  Update to the latest release, including prereleases:
+
+      infracost self-update --channel prerelease`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			channel := cfg.UpdateChannel
+			if cmd.Flags().Changed("channel") {
+				channel, _ = cmd.Flags().GetString("channel")
+			}
+
+			channel, err := update.ParseChannel(channel)
+			if err != nil {
+				ui.PrintUsageErrorAndExit(cmd, err.Error())
+			}
+
+			spinnerOpts := ui.SpinnerOptions{
+				EnableLogging: cfg.IsLogging(),
+				NoColor:       cfg.NoColor,
+			}
+			spinner = ui.NewSpinner("Updating Infracost", spinnerOpts)
+
+			newVersion, err := update.SelfUpdate(cfg, channel)
+			if err != nil {
+				spinner.Fail()
+				return err
+			}
+
+			spinner.Success()
+
+			if newVersion == "" {
+				ui.PrintSuccessf("Infracost %s is already the latest %s version", version.Version, channel)
+				return nil
+			}
+
+			ui.PrintSuccessf("Infracost updated from %s to %s", version.Version, newVersion)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("channel", "", "Release channel to update from: stable, prerelease. Defaults to the update_channel setting or stable")
+
+	_ = cmd.RegisterFlagCompletionFunc("channel", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{update.ChannelStable, update.ChannelPrerelease}, cobra.ShellCompDirectiveDefault
+	})
+
+	return cmd
+}
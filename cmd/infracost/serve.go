@@ -0,0 +1,529 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/estimate"
+	"github.com/infracost/infracost/internal/github"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/runtask"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/infracost/infracost/internal/usage"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var maxServeRequestBytes int64 = 50 * 1024 * 1024
+
+// The write timeout is long enough for the costs of large plans to be
+// estimated before the response is written.
+const (
+	serveReadHeaderTimeout = 10 * time.Second
+	serveReadTimeout       = 60 * time.Second
+	serveWriteTimeout      = 5 * time.Minute
+)
+
+// serveRequest is the body of a request when usage data is sent with the plan.
+// Otherwise the body is just the Terraform plan JSON.
+type serveRequest struct {
+	Plan  json.RawMessage `json:"plan"`
+	Usage json.RawMessage `json:"usage"`
+}
+
+type commentCommandResponse struct {
+	Handled    bool   `json:"handled"`
+	Command    string `json:"command,omitempty"`
+	Body       string `json:"body,omitempty"`
+	CommentURL string `json:"commentUrl,omitempty"`
+}
+
+type serveError struct {
+	Error string `json:"error"`
+}
+
+func serveCmd(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run an HTTP server that returns cost estimates for Terraform plan JSON",
+		Long: `Run an HTTP server that returns cost estimates for Terraform plan JSON.
+
+This avoids the startup cost of running the CLI for each estimate. The server has the following endpoints:
+
+  POST /breakdown  Returns the Infracost JSON breakdown for the plan JSON in the request body
+  POST /diff             Returns the Infracost JSON breakdown including the diff for the plan JSON in the request body
+  POST /comment-command  GitHub webhook that replies to /infracost commands in pull request comments,
+                         only if --comment-command-plan-url is set
+  POST /run-task         Runs a Terraform Cloud run task, only if --run-task is set
+  GET  /health           Returns 200 if the server is running
+
+The request body can either be the plan JSON or a JSON object with a "plan" key and a "usage" key
+containing the contents of an Infracost usage file. The project name can be set with the "name" query parameter.
+
+The /comment-command endpoint is the URL of a GitHub App webhook for issue_comment events. The requests
+are verified with the webhook secret set in the INFRACOST_GITHUB_WEBHOOK_SECRET environment variable,
+then the comment is fetched using the GitHub App configured with the INFRACOST_GITHUB_APP_ID and
+INFRACOST_GITHUB_APP_PRIVATE_KEY environment variables. The plan JSON of the pull request is fetched from
+--comment-command-plan-url, where {repo} and {number} are replaced by the repo and pull request number, and
+can have the same format as the request body above. Commands are written at the start of a line in the
+comment, currently the only command is:
+
+  /infracost explain <resource address>  Explains how the monthly cost of the resource was calculated
+
+The /run-task endpoint is the URL of a Terraform Cloud or Terraform Enterprise run task in the post-plan
+stage. It estimates the costs of each run's plan and sends them back as the task result, which fails if
+the costs break the --run-task-max-increase or --run-task-max-monthly-cost policies. Set the run task's
+HMAC key with the INFRACOST_RUN_TASK_HMAC_KEY environment variable, which is required to verify the requests.`,
+		Example: `  Start the server:
+
+      infracost serve --port 8080
+
+  Get a cost breakdown:
+
+      curl -X POST --data-binary @plan.json http://localhost:8080/breakdown
+
+  Reply to pull request comments with the plans that CI uploads to a bucket:
+
+      INFRACOST_GITHUB_WEBHOOK_SECRET=my-webhook-secret infracost serve \
+        --comment-command-plan-url "https://plans.example.com/{repo}/{number}/plan.json"
+
+  Run as a Terraform Cloud run task that fails if the monthly cost increases by 10% or more:
+
+      INFRACOST_RUN_TASK_HMAC_KEY=my-hmac-key infracost serve --run-task --run-task-max-increase 10%`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkAPIKey(cfg); err != nil {
+				return err
+			}
+
+			var policies *runtask.Policies
+			if runTask, _ := cmd.Flags().GetBool("run-task"); runTask {
+				if cfg.RunTaskHMACKey == "" {
+					ui.PrintUsageErrorAndExit(cmd, "--run-task requires the INFRACOST_RUN_TASK_HMAC_KEY environment variable to verify the run task requests")
+				}
+
+				policies = &runtask.Policies{}
+
+				if cmd.Flags().Changed("run-task-max-increase") {
+					s, _ := cmd.Flags().GetString("run-task-max-increase")
+
+					var err error
+					policies.MaxIncrease, err = output.ParseThreshold(s)
+					if err != nil {
+						ui.PrintUsageErrorAndExit(cmd, err.Error())
+					}
+				}
+
+				if cmd.Flags().Changed("run-task-max-monthly-cost") {
+					max := decimal.NewFromFloat(*loadNonNegativeFloatFlag(cmd, "run-task-max-monthly-cost"))
+					policies.MaxMonthlyCost = &max
+				}
+			}
+
+			planURL, _ := cmd.Flags().GetString("comment-command-plan-url")
+			if planURL != "" && cfg.GitHubWebhookSecret == "" {
+				ui.PrintUsageErrorAndExit(cmd, "--comment-command-plan-url requires the INFRACOST_GITHUB_WEBHOOK_SECRET environment variable to verify the webhook requests")
+			}
+
+			port, _ := cmd.Flags().GetInt("port")
+			addr := fmt.Sprintf(":%d", port)
+
+			log.Infof("Listening on %s", addr)
+
+			server := &http.Server{
+				Addr:              addr,
+				Handler:           newServeHandler(cfg, policies, planURL),
+				ReadHeaderTimeout: serveReadHeaderTimeout,
+				ReadTimeout:       serveReadTimeout,
+				WriteTimeout:      serveWriteTimeout,
+			}
+
+			return server.ListenAndServe()
+		},
+	}
+
+	cmd.Flags().Int("port", 8080, "Port to listen on")
+	cmd.Flags().String("comment-command-plan-url", "", "Add the /comment-command endpoint, which gets the plan JSON of pull requests from this URL")
+	cmd.Flags().Bool("run-task", false, "Add the /run-task endpoint for Terraform Cloud run tasks")
+	cmd.Flags().String("run-task-max-increase", "", "Fail the run task if the total monthly cost increases by at least this percentage, e.g. 10%, or amount in USD, e.g. 100")
+	cmd.Flags().Float64("run-task-max-monthly-cost", 0, "Fail the run task if the total monthly cost after the plan is more than this amount in USD")
+
+	return cmd
+}
+
+// newServeHandler returns the handler of the server's endpoints. The run task
+// endpoint is only added if the run task policies are set, and the comment
+// command endpoint if the plan URL is set.
+func newServeHandler(cfg *config.Config, runTaskPolicies *runtask.Policies, commentCommandPlanURL string) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	mux.HandleFunc("/breakdown", func(w http.ResponseWriter, r *http.Request) {
+		serveEstimate(cfg, w, r, false)
+	})
+
+	mux.HandleFunc("/diff", func(w http.ResponseWriter, r *http.Request) {
+		serveEstimate(cfg, w, r, true)
+	})
+
+	if commentCommandPlanURL != "" {
+		mux.HandleFunc("/comment-command", func(w http.ResponseWriter, r *http.Request) {
+			serveCommentCommand(cfg, commentCommandPlanURL, w, r)
+		})
+	}
+
+	if runTaskPolicies != nil {
+		mux.HandleFunc("/run-task", func(w http.ResponseWriter, r *http.Request) {
+			serveRunTask(cfg, *runTaskPolicies, w, r)
+		})
+	}
+
+	return mux
+}
+
+func serveEstimate(cfg *config.Config, w http.ResponseWriter, r *http.Request, hasDiff bool) {
+	if r.Method != http.MethodPost {
+		writeServeError(w, http.StatusMethodNotAllowed, errors.New("Method not allowed, use POST"))
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxServeRequestBytes))
+	if err != nil {
+		writeServeError(w, http.StatusBadRequest, errors.Wrap(err, "Error reading request body"))
+		return
+	}
+
+	planJSON, usageData, err := parseServeRequest(body)
+	if err != nil {
+		writeServeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	out, status, err := serveOutput(cfg, r, planJSON, usageData, hasDiff)
+	if err != nil {
+		writeServeError(w, status, err)
+		return
+	}
+
+	b, err := output.ToJSON(out, output.Options{})
+	if err != nil {
+		writeServeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+// serveCommentCommand replies to the /infracost command of a GitHub
+// issue_comment webhook event. Only the IDs in the verified event are used,
+// the comment is fetched from GitHub and the plan from the plan URL.
+func serveCommentCommand(cfg *config.Config, planURL string, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeServeError(w, http.StatusMethodNotAllowed, errors.New("Method not allowed, use POST"))
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxServeRequestBytes))
+	if err != nil {
+		writeServeError(w, http.StatusBadRequest, errors.Wrap(err, "Error reading request body"))
+		return
+	}
+
+	if !github.VerifyWebhookSignature(cfg.GitHubWebhookSecret, body, r.Header.Get(github.SignatureHeader)) {
+		writeServeError(w, http.StatusUnauthorized, errors.New("Invalid GitHub webhook signature"))
+		return
+	}
+
+	if r.Header.Get(github.EventHeader) != github.EventIssueComment {
+		writeServeJSON(w, commentCommandResponse{Handled: false})
+		return
+	}
+
+	event, err := github.ParseIssueCommentEvent(body)
+	if err != nil {
+		writeServeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if !event.IsNewPullRequestComment() {
+		writeServeJSON(w, commentCommandResponse{Handled: false})
+		return
+	}
+
+	repo := event.Repository.FullName
+	number := event.Issue.Number
+
+	app, err := newGitHubApp(cfg)
+	if err != nil {
+		writeServeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	comment, err := app.GetIssueComment(repo, event.Comment.ID)
+	if err != nil {
+		writeServeError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	command, ok := github.ParseCommentCommand(comment)
+	if !ok {
+		writeServeJSON(w, commentCommandResponse{Handled: false})
+		return
+	}
+
+	if command.Name != "explain" {
+		writeServeError(w, http.StatusBadRequest, fmt.Errorf("Unknown command %s, valid commands are: explain", command.Name))
+		return
+	}
+
+	if len(command.Args) != 1 {
+		writeServeError(w, http.StatusBadRequest, errors.New("Usage: /infracost explain <resource address>"))
+		return
+	}
+
+	planBody, err := fetchCommentCommandPlan(planURL, repo, number)
+	if err != nil {
+		writeServeError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	planJSON, usageData, err := parseServeRequest(planBody)
+	if err != nil {
+		writeServeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	out, status, err := estimatePlanJSON(r.Context(), cfg, fmt.Sprintf("%s#%d", repo, number), planJSON, usageData, false)
+	if err != nil {
+		writeServeError(w, status, err)
+		return
+	}
+
+	reply, err := output.ToExplainMarkdown(out, command.Args[0])
+	if err != nil {
+		writeServeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	resp := commentCommandResponse{
+		Handled: true,
+		Command: command.Name,
+		Body:    string(reply),
+	}
+
+	resp.CommentURL, err = app.CreateIssueComment(repo, number, resp.Body)
+	if err != nil {
+		log.Errorf("Error replying to comment: %v", err)
+		writeServeError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	writeServeJSON(w, resp)
+}
+
+// fetchCommentCommandPlan gets the plan JSON of the pull request from the
+// plan URL, after replacing its {repo} and {number} placeholders.
+func fetchCommentCommandPlan(planURL string, repo string, number int) ([]byte, error) {
+	url := strings.NewReplacer("{repo}", repo, "{number}", strconv.Itoa(number)).Replace(planURL)
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error getting the plan JSON of %s#%d", repo, number)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Error getting the plan JSON of %s#%d: %s", repo, number, resp.Status)
+	}
+
+	return ioutil.ReadAll(io.LimitReader(resp.Body, maxServeRequestBytes))
+}
+
+// serveRunTask responds to the run task request straight away, since Terraform
+// Cloud only waits 10 seconds, then estimates the plan and sends the result to
+// the request's callback URL.
+func serveRunTask(cfg *config.Config, policies runtask.Policies, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeServeError(w, http.StatusMethodNotAllowed, errors.New("Method not allowed, use POST"))
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxServeRequestBytes))
+	if err != nil {
+		writeServeError(w, http.StatusBadRequest, errors.Wrap(err, "Error reading request body"))
+		return
+	}
+
+	if !runtask.VerifySignature(cfg.RunTaskHMACKey, body, r.Header.Get(runtask.SignatureHeader)) {
+		writeServeError(w, http.StatusUnauthorized, errors.New("Invalid run task signature"))
+		return
+	}
+
+	req, err := runtask.ParseRequest(body)
+	if err != nil {
+		writeServeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	if req.IsVerification() {
+		log.Info("Received run task verification request")
+		return
+	}
+
+	go runTask(cfg, policies, req)
+}
+
+// runTask estimates the plan of the run task request and sends the result.
+// Errors are sent as failed results so the run doesn't wait for the task
+// until it times out.
+func runTask(cfg *config.Config, policies runtask.Policies, req runtask.Request) {
+	log.Infof("Running task for run %s of workspace %s", req.RunID, req.ProjectName())
+
+	result, err := runTaskResult(cfg, policies, req)
+	if err != nil {
+		log.Errorf("Error running task for run %s: %v", req.RunID, err)
+		result = runtask.ErrorResult(err)
+	}
+
+	err = req.SendResult(result)
+	if err != nil {
+		log.Errorf("Error sending task result for run %s: %v", req.RunID, err)
+		return
+	}
+
+	log.Infof("Sent %s task result for run %s", result.Status, req.RunID)
+}
+
+func runTaskResult(cfg *config.Config, policies runtask.Policies, req runtask.Request) (runtask.Result, error) {
+	if req.Stage != runtask.StagePostPlan {
+		return runtask.Result{}, fmt.Errorf("The %s stage isn't supported, use the post-plan stage", req.Stage)
+	}
+
+	planJSON, err := req.FetchPlanJSON()
+	if err != nil {
+		return runtask.Result{}, err
+	}
+
+	// The task runs after the request has been responded to, so it doesn't use
+	// the request's context.
+	out, _, err := estimatePlanJSON(context.Background(), cfg, req.ProjectName(), planJSON, map[string]*schema.UsageData{}, true)
+	if err != nil {
+		return runtask.Result{}, err
+	}
+
+	result, err := runtask.NewResult(out, policies)
+	if err != nil {
+		return runtask.Result{}, err
+	}
+	result.URL = req.RunAppURL
+
+	return result, nil
+}
+
+// serveOutput estimates the costs of the plan JSON. The returned status is
+// the HTTP status to respond with if there's an error.
+func serveOutput(cfg *config.Config, r *http.Request, planJSON []byte, usageData map[string]*schema.UsageData, hasDiff bool) (output.Root, int, error) {
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		name = "plan.json"
+	}
+
+	return estimatePlanJSON(r.Context(), cfg, name, planJSON, usageData, hasDiff)
+}
+
+// estimatePlanJSON estimates the costs of the plan JSON as a project with
+// the name. The returned status is the HTTP status to respond with if there's
+// an error.
+func estimatePlanJSON(ctx context.Context, cfg *config.Config, name string, planJSON []byte, usageData map[string]*schema.UsageData, hasDiff bool) (output.Root, int, error) {
+	project, err := estimate.PlanJSONProject(cfg, name, planJSON, usageData, hasDiff)
+	if err != nil {
+		return output.Root{}, http.StatusBadRequest, err
+	}
+
+	costOpts, err := estimate.LoadCostOptions(cfg)
+	if err != nil {
+		return output.Root{}, http.StatusInternalServerError, err
+	}
+
+	err = estimate.CalculateCosts(ctx, cfg, project, costOpts)
+	if err != nil {
+		log.Errorf("Error calculating costs: %v", err)
+		return output.Root{}, http.StatusInternalServerError, err
+	}
+
+	return output.ToOutputFormat([]*schema.Project{project}), http.StatusOK, nil
+}
+
+// parseServeRequest returns the plan JSON and usage data from the request
+// body. Plan JSON always has a format_version key so it can be told apart
+// from a request with a plan key.
+func parseServeRequest(body []byte) ([]byte, map[string]*schema.UsageData, error) {
+	usageData := map[string]*schema.UsageData{}
+
+	var keys map[string]json.RawMessage
+	err := json.Unmarshal(body, &keys)
+	if err != nil {
+		return nil, usageData, errors.Wrap(err, "Error parsing request body")
+	}
+
+	if _, ok := keys["format_version"]; ok {
+		return body, usageData, nil
+	}
+
+	var req serveRequest
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		return nil, usageData, errors.Wrap(err, "Error parsing request body")
+	}
+
+	if len(req.Plan) == 0 {
+		return nil, usageData, errors.New("Request body must be Terraform plan JSON or contain a plan key")
+	}
+
+	if len(req.Usage) > 0 && !bytes.Equal(req.Usage, []byte("null")) {
+		usageData, err = usage.Parse(req.Usage)
+		if err != nil {
+			return nil, usageData, err
+		}
+	}
+
+	return req.Plan, usageData, nil
+}
+
+func writeServeJSON(w http.ResponseWriter, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		writeServeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+func writeServeError(w http.ResponseWriter, status int, err error) {
+	b, _ := json.Marshal(serveError{Error: err.Error()})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(b)
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func snapshotCmd(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Record the costs of runs and report how they changed over time",
+		Long: `Record the costs of runs and report how they changed over time.
+
+Snapshots are saved to the file set by --store, the snapshot_store config or
+INFRACOST_SNAPSHOT_STORE, which defaults to snapshots.jsonl in the Infracost
+config dir. Each snapshot has the monthly cost of a project and the branch and
+commit it was estimated for.`,
+		Example: `  Save a snapshot of each project in an Infracost JSON file:
+
+      infracost breakdown --path /path/to/code --format json --out-file infracost.json
+      infracost snapshot save --path infracost.json
+
+  Show how the cost of a project changed over time:
+
+      infracost snapshot report --project my-org/my-repo/prod`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.PersistentFlags().String("store", "", "Path to the snapshot store")
+	_ = cmd.MarkPersistentFlagFilename("store", "jsonl")
+
+	cmd.AddCommand(snapshotSaveCmd(cfg), snapshotListCmd(cfg), snapshotReportCmd(cfg))
+
+	return cmd
+}
+
+func snapshotSaveCmd(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "save",
+		Short: "Save a snapshot of the projects in an Infracost JSON file",
+		Long:  "Save a snapshot of the monthly cost of each project in an Infracost JSON file",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, _ := cmd.Flags().GetString("path")
+			note, _ := cmd.Flags().GetString("note")
+
+			data, err := readArtifact(output.Artifact{Path: path})
+			if err != nil {
+				return err
+			}
+
+			out, err := output.Load(data)
+			if err != nil {
+				return errors.Wrapf(err, "Error parsing JSON file %s", path)
+			}
+
+			if !checkOutputVersion(out.Version) {
+				return fmt.Errorf("Invalid Infracost JSON file version in %s. Supported versions are %s ≤ x ≤ %s", path, minOutputVersion, maxOutputVersion)
+			}
+
+			store := snapshotStore(cfg, cmd)
+			snapshots := output.NewSnapshots(out, note)
+
+			err = output.SaveSnapshots(store, snapshots)
+			if err != nil {
+				return err
+			}
+
+			noun := "snapshots"
+			if len(snapshots) == 1 {
+				noun = "snapshot"
+			}
+			fmt.Fprintf(os.Stderr, "Saved %d %s to %s\n", len(snapshots), noun, ui.DisplayPath(store))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("path", "", "Path to the Infracost JSON file")
+	cmd.Flags().String("note", "", "Note to save with the snapshots, e.g. the reason for the change")
+
+	_ = cmd.MarkFlagRequired("path")
+	_ = cmd.MarkFlagFilename("path", "json")
+
+	return cmd
+}
+
+func snapshotListCmd(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the saved snapshots",
+		Long:  "List the saved snapshots, oldest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			snapshots, err := loadSnapshots(cfg, cmd)
+			if err != nil {
+				return err
+			}
+
+			format, _ := cmd.Flags().GetString("format")
+
+			switch strings.ToLower(format) {
+			case "json":
+				b, err := json.MarshalIndent(snapshots, "", "  ")
+				if err != nil {
+					return errors.Wrap(err, "Error generating output")
+				}
+				fmt.Println(string(b))
+			case "table":
+				fmt.Println(output.SnapshotsToTable(snapshots))
+			default:
+				ui.PrintUsageErrorAndExit(cmd, "--format only supports json or table")
+			}
+
+			return nil
+		},
+	}
+
+	addSnapshotFilterFlags(cmd)
+
+	return cmd
+}
+
+func snapshotReportCmd(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Show how the costs of the projects changed over time",
+		Long:  "Show how the monthly cost of each project changed between its snapshots",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			snapshots, err := loadSnapshots(cfg, cmd)
+			if err != nil {
+				return err
+			}
+
+			report := output.BuildTrendReport(snapshots)
+
+			format, _ := cmd.Flags().GetString("format")
+
+			switch strings.ToLower(format) {
+			case "json":
+				b, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return errors.Wrap(err, "Error generating output")
+				}
+				fmt.Println(string(b))
+			case "table":
+				if len(report.Projects) == 0 {
+					fmt.Println("No snapshots found")
+					return nil
+				}
+				fmt.Println(output.TrendReportToTable(report))
+			default:
+				ui.PrintUsageErrorAndExit(cmd, "--format only supports json or table")
+			}
+
+			return nil
+		},
+	}
+
+	addSnapshotFilterFlags(cmd)
+
+	return cmd
+}
+
+func addSnapshotFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().String("project", "", "Only include the snapshots of this project")
+	cmd.Flags().String("format", "table", "Output format: json, table")
+}
+
+func loadSnapshots(cfg *config.Config, cmd *cobra.Command) ([]output.Snapshot, error) {
+	snapshots, err := output.LoadSnapshots(snapshotStore(cfg, cmd))
+	if err != nil {
+		return nil, err
+	}
+
+	project, _ := cmd.Flags().GetString("project")
+
+	return output.FilterSnapshots(snapshots, project), nil
+}
+
+func snapshotStore(cfg *config.Config, cmd *cobra.Command) string {
+	if store, _ := cmd.Flags().GetString("store"); store != "" {
+		return store
+	}
+
+	if cfg.SnapshotStore != "" {
+		return cfg.SnapshotStore
+	}
+
+	return config.SnapshotStoreFilePath()
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/support"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func supportBundleCmd(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "support-bundle",
+		Short: "Generate a support bundle to attach to bug reports",
+		Long: `Generate a support bundle to attach to bug reports.
+
+The bundle is a zip file with:
+  version.json      The versions of Infracost, Go and Terraform
+  environment.json  The detected environment and the INFRACOST_ and TF_ environment variables
+  config.yml        The resolved config, including the config file if one is used
+  pricing.json      The pricing API settings, snapshot and a health check of the pricing API
+  logs.txt          The log file, if one is used
+
+Infracost doesn't save its logs, so to include them run the command that has
+the issue with --log-level debug and pass its output with --log-file.
+
+API keys, tokens, secrets and Terraform variables are replaced with REDACTED
+wherever they appear. Check the bundle before sharing it.`,
+		Example: `  Generate a support bundle with the logs of a run:
+
+      infracost breakdown --path /path/to/code --log-level debug 2> infracost.log
+      infracost support-bundle --log-file infracost.log`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cmd.Flags().Changed("config-file") {
+				cfgFilePath, _ := cmd.Flags().GetString("config-file")
+				err := cfg.LoadFromConfigFile(cfgFilePath)
+				if err != nil {
+					return err
+				}
+			}
+
+			opts := support.Options{}
+			opts.LogFile, _ = cmd.Flags().GetString("log-file")
+			opts.SkipHealthCheck, _ = cmd.Flags().GetBool("skip-health-check")
+
+			b, err := support.Generate(cfg, opts)
+			if err != nil {
+				return err
+			}
+
+			outFile, _ := cmd.Flags().GetString("out-file")
+			if outFile == "" {
+				outFile = fmt.Sprintf("infracost-support-bundle-%s.zip", time.Now().UTC().Format("20060102-150405"))
+			}
+
+			err = ioutil.WriteFile(outFile, b, 0600)
+			if err != nil {
+				return errors.Wrap(err, "Error writing support bundle")
+			}
+
+			cmd.Printf("Support bundle saved to %s\n", ui.DisplayPath(outFile))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("out-file", "", "Path to save the support bundle to, defaults to infracost-support-bundle-<timestamp>.zip")
+	cmd.Flags().String("config-file", "", "Path to the Infracost config file to include")
+	cmd.Flags().String("log-file", "", "Path to a log file to include, e.g. from a run with --log-level debug")
+	cmd.Flags().Bool("skip-health-check", false, "Don't check that the pricing API can be reached")
+
+	_ = cmd.MarkFlagFilename("out-file", "zip")
+	_ = cmd.MarkFlagFilename("config-file", "yml")
+
+	return cmd
+}
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/estimate"
+	"github.com/infracost/infracost/internal/providers"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/infracost/infracost/internal/usage"
+	"github.com/manifoldco/promptui"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+	"github.com/tidwall/gjson"
+)
+
+func usageCmd(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "usage",
+		Short: "Work with Infracost usage files",
+		Long:  "Work with Infracost usage files",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(usageEditCmd(cfg))
+
+	return cmd
+}
+
+func usageEditCmd(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Add the missing usage values of a project to its usage file",
+		Long: `Add the missing usage values of a project to its usage file.
+
+With --interactive you are asked for the value of each usage key that isn't
+in the usage file yet, one resource at a time, and shown how the value changes
+the resource's monthly cost. Leave a value blank to skip it. Without
+--interactive the missing keys are added as comments, the same as
+--sync-usage-file.`,
+		Example: `  Fill in the usage values of a Terraform directory:
+
+      infracost usage edit --path /code --usage-file infracost-usage.yml --interactive`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkAPIKey(cfg); err != nil {
+				return err
+			}
+
+			projectCfg := &config.Project{}
+			projectCfg.Path, _ = cmd.Flags().GetString("path")
+			projectCfg.UsageFile, _ = cmd.Flags().GetString("usage-file")
+			interactive, _ := cmd.Flags().GetBool("interactive")
+
+			if projectCfg.Path == "" {
+				ui.PrintUsageErrorAndExit(cmd, "No path specified")
+			}
+			if projectCfg.UsageFile == "" {
+				ui.PrintUsageErrorAndExit(cmd, "No usage file specified")
+			}
+
+			cfg.Projects = []*config.Project{projectCfg}
+
+			provider, err := providers.Detect(cfg, projectCfg)
+			if err != nil {
+				return err
+			}
+
+			generator, ok := provider.(terraform.PlanJSONGenerator)
+			if !ok {
+				return fmt.Errorf("Cannot edit the usage of a %s, use a Terraform directory, plan file or plan JSON file instead", provider.DisplayType())
+			}
+
+			fmt.Fprintf(os.Stderr, "Detected %s at %s\n", provider.DisplayType(), ui.DisplayPath(projectCfg.Path))
+
+			if installer, ok := provider.(terraform.TerraformInstaller); ok {
+				projectCfg.TerraformBinary, err = installer.InstallTerraformIfMissing()
+				if err != nil {
+					return err
+				}
+			}
+
+			cfg.Environment.SetProjectEnvironment(provider.Type(), projectCfg)
+
+			planJSON, err := generator.PlanJSON()
+			if err != nil {
+				return err
+			}
+
+			usageData, err := usage.LoadFromFile(projectCfg.UsageFile, true)
+			if err != nil {
+				return err
+			}
+
+			costOpts, err := estimate.LoadCostOptions(cfg)
+			if err != nil {
+				return err
+			}
+
+			// The project is priced again each time a resource's usage changes.
+			// The pricing queries are cached by the session so only the new ones
+			// are sent.
+			priceProject := func() (*schema.Project, error) {
+				project, err := estimate.PlanJSONProject(cfg, projectCfg.Path, planJSON, usageData, false)
+				if err != nil {
+					return nil, err
+				}
+
+				err = estimate.CalculateCosts(context.Background(), cfg, project, costOpts)
+				if err != nil {
+					return nil, err
+				}
+
+				return project, nil
+			}
+
+			spinnerOpts := ui.SpinnerOptions{
+				EnableLogging: cfg.IsLogging(),
+				NoColor:       cfg.NoColor,
+			}
+			spinner = ui.NewSpinner("Calculating monthly cost estimate", spinnerOpts)
+
+			project, err := priceProject()
+			if err != nil {
+				spinner.Fail()
+				return err
+			}
+
+			spinner.Success()
+
+			if interactive {
+				project, err = editUsageInteractively(project, usageData, priceProject)
+				if err != nil {
+					return err
+				}
+			}
+
+			err = usage.SyncUsageData(project, usageData, projectCfg.UsageFile)
+			if err != nil {
+				return errors.Wrap(err, "Error writing usage file")
+			}
+
+			fmt.Println("")
+			ui.PrintSuccessf("Usage file saved to %s", ui.DisplayPath(projectCfg.UsageFile))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("path", "p", "", "Path to the Terraform directory or JSON/plan file")
+	cmd.Flags().String("usage-file", "", "Path to the Infracost usage file, it is created if it doesn't exist")
+	cmd.Flags().Bool("interactive", false, "Ask for the values of the missing usage keys")
+
+	_ = cmd.MarkFlagFilename("usage-file", "yml")
+
+	return cmd
+}
+
+// editUsageInteractively asks for the missing usage values of each resource
+// and sets them in the usage data. The project is priced again after each
+// resource so the change in its cost can be shown.
+func editUsageInteractively(project *schema.Project, usageData map[string]*schema.UsageData, priceProject func() (*schema.Project, error)) (*schema.Project, error) {
+	resources := project.Resources
+	total := 0
+	for _, r := range resources {
+		items, err := missingUsageKeys(r, usageData)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) > 0 {
+			total++
+		}
+	}
+
+	if total == 0 {
+		fmt.Println("\nAll the usage keys of the project's resources are already in the usage file.")
+		return project, nil
+	}
+
+	fmt.Printf("\n%d resources have missing usage values. Leave a value blank to skip it.\n", total)
+
+	n := 0
+	for _, r := range resources {
+		items, err := missingUsageKeys(r, usageData)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) == 0 {
+			continue
+		}
+
+		n++
+		before := findResource(project, r.Name)
+
+		fmt.Printf("\n%s %s\n", ui.BoldString(r.Name), ui.FaintStringf("(%d/%d)", n, total))
+		fmt.Printf("Monthly cost: %s\n", formatUsageEditCost(before.MonthlyCost))
+
+		changed := false
+		for _, item := range items {
+			value, ok, err := promptForUsageValue(item)
+			if errors.Is(err, promptui.ErrInterrupt) || errors.Is(err, promptui.ErrEOF) {
+				return nil, errors.New("Cancelled, the usage file wasn't changed")
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			if ok {
+				usage.SetUsageValue(usageData, r.Name, item.Key, value)
+				changed = true
+			}
+		}
+
+		if !changed {
+			continue
+		}
+
+		project, err = priceProject()
+		if err != nil {
+			return nil, err
+		}
+
+		after := findResource(project, r.Name)
+		fmt.Printf("Monthly cost: %s %s\n", formatUsageEditCost(after.MonthlyCost), ui.FaintString(formatUsageEditCostChange(before.MonthlyCost, after.MonthlyCost)))
+	}
+
+	return project, nil
+}
+
+// missingUsageKeys returns the usage keys of the resource that don't have a
+// value in the usage data, including from wildcard keys.
+func missingUsageKeys(r *schema.Resource, usageData map[string]*schema.UsageData) ([]*schema.UsageSchemaItem, error) {
+	if r.IsSkipped {
+		return nil, nil
+	}
+
+	items, err := usage.ResourceUsageSchema(r)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := schema.FindUsageData(usageData, r.Name)
+
+	missing := make([]*schema.UsageSchemaItem, 0, len(items))
+	for _, item := range items {
+		if existing != nil && existing.Get(item.Key).Type != gjson.Null {
+			continue
+		}
+		missing = append(missing, item)
+	}
+
+	return missing, nil
+}
+
+// promptForUsageValue asks for the value of a usage key. It returns false if
+// the value was left blank.
+func promptForUsageValue(item *schema.UsageSchemaItem) (interface{}, bool, error) {
+	if item.Description != "" {
+		fmt.Println(ui.FaintString(item.Description))
+	}
+
+	p := promptui.Prompt{
+		Label: fmt.Sprintf("%s (default %v)", item.Key, usage.DefaultUsageValue(item)),
+		Validate: func(input string) error {
+			_, err := parseUsageValue(item, input)
+			return err
+		},
+	}
+
+	input, err := p.Run()
+	if err != nil {
+		return nil, false, err
+	}
+
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, false, nil
+	}
+
+	value, err := parseUsageValue(item, input)
+	return value, true, err
+}
+
+func parseUsageValue(item *schema.UsageSchemaItem, input string) (interface{}, error) {
+	input = strings.TrimSpace(input)
+	if input == "" || item.ValueType == schema.String {
+		return input, nil
+	}
+
+	f, err := strconv.ParseFloat(input, 64)
+	if err != nil || f < 0 {
+		return nil, errors.New("Please enter a number")
+	}
+
+	if item.ValueType == schema.Int64 && f == float64(int64(f)) {
+		return int64(f), nil
+	}
+
+	return f, nil
+}
+
+func findResource(project *schema.Project, name string) *schema.Resource {
+	for _, r := range project.Resources {
+		if r.Name == name {
+			return r
+		}
+	}
+
+	return &schema.Resource{Name: name}
+}
+
+func formatUsageEditCost(d *decimal.Decimal) string {
+	if d == nil {
+		return "-"
+	}
+
+	return fmt.Sprintf("$%s", d.StringFixed(2))
+}
+
+func formatUsageEditCostChange(before *decimal.Decimal, after *decimal.Decimal) string {
+	b := decimal.Zero
+	if before != nil {
+		b = *before
+	}
+
+	a := decimal.Zero
+	if after != nil {
+		a = *after
+	}
+
+	diff := a.Sub(b)
+	if diff.IsNegative() {
+		return fmt.Sprintf("(-$%s)", diff.Abs().StringFixed(2))
+	}
+
+	return fmt.Sprintf("(+$%s)", diff.StringFixed(2))
+}
@@ -0,0 +1,169 @@
+package comment
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const azureReposAPIVersion = "6.0"
+
+// AzureRepos posts comments to a pull request on Azure Repos, in Azure
+// DevOps Services or Azure DevOps Server. Each comment is the first comment
+// of its own thread.
+type AzureRepos struct {
+	client      *apiClient
+	projectURL  string
+	repo        string
+	pullRequest int
+}
+
+// NewAzureRepos returns the platform for the pull request of the repo URL,
+// e.g. https://dev.azure.com/my-org/my-project/_git/my-repo or
+// https://tfs.example.com/tfs/DefaultCollection/my-project/_git/my-repo. The
+// token is a personal access token or the System.AccessToken of a pipeline.
+func NewAzureRepos(repoURL string, token string, pullRequest int) (*AzureRepos, error) {
+	u, err := url.Parse(repoURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("Invalid Azure Repos repo URL %s, expected an http(s) URL", repoURL)
+	}
+
+	parts := strings.SplitN(strings.TrimSuffix(u.Path, "/"), "/_git/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" || strings.Contains(parts[1], "/") {
+		return nil, fmt.Errorf("Invalid Azure Repos repo URL %s, expected the format https://dev.azure.com/org/project/_git/repo", repoURL)
+	}
+
+	if token == "" {
+		return nil, errors.New("An Azure Repos token is required")
+	}
+
+	// Clone URLs include the organization as the user, which isn't needed
+	u.User = nil
+	u.Path = parts[0]
+	u.RawQuery = ""
+	u.Fragment = ""
+
+	return &AzureRepos{
+		client: &apiClient{
+			name:          "Azure Repos",
+			authorization: fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte(":"+token))),
+		},
+		projectURL:  u.String(),
+		repo:        parts[1],
+		pullRequest: pullRequest,
+	}, nil
+}
+
+func (a *AzureRepos) Name() string {
+	return "Azure Repos"
+}
+
+type azureReposComment struct {
+	ID        int    `json:"id"`
+	Content   string `json:"content"`
+	IsDeleted bool   `json:"isDeleted"`
+}
+
+type azureReposThread struct {
+	ID        int                 `json:"id"`
+	IsDeleted bool                `json:"isDeleted"`
+	Comments  []azureReposComment `json:"comments"`
+}
+
+func (a *AzureRepos) apiURL(path string, apiVersion string) string {
+	return fmt.Sprintf("%s/_apis/git/repositories/%s/pullRequests/%d%s?api-version=%s", a.projectURL, url.PathEscape(a.repo), a.pullRequest, path, apiVersion)
+}
+
+func (a *AzureRepos) comment(threadID int, c azureReposComment) Comment {
+	return Comment{
+		ID:       strconv.Itoa(c.ID),
+		Body:     c.Content,
+		URL:      fmt.Sprintf("%s/_git/%s/pullrequest/%d?discussionId=%d", a.projectURL, url.PathEscape(a.repo), a.pullRequest, threadID),
+		threadID: threadID,
+	}
+}
+
+func (a *AzureRepos) Comments() ([]Comment, error) {
+	var resp struct {
+		Value []azureReposThread `json:"value"`
+	}
+
+	err := a.client.call("GET", a.apiURL("/threads", azureReposAPIVersion), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]Comment, 0, len(resp.Value))
+	for _, t := range resp.Value {
+		if t.IsDeleted || len(t.Comments) == 0 || t.Comments[0].IsDeleted {
+			continue
+		}
+
+		comments = append(comments, a.comment(t.ID, t.Comments[0]))
+	}
+
+	return comments, nil
+}
+
+func (a *AzureRepos) CreateComment(body string) (Comment, error) {
+	reqBody := map[string]interface{}{
+		"comments": []map[string]interface{}{
+			{"parentCommentId": 0, "content": body, "commentType": "text"},
+		},
+		"status": "active",
+	}
+
+	var t azureReposThread
+	err := a.client.call("POST", a.apiURL("/threads", azureReposAPIVersion), reqBody, &t)
+	if err != nil {
+		return Comment{}, err
+	}
+
+	if len(t.Comments) == 0 {
+		return Comment{}, errors.New("invalid response from Azure Repos: the thread has no comments")
+	}
+
+	return a.comment(t.ID, t.Comments[0]), nil
+}
+
+func (a *AzureRepos) UpdateComment(existing Comment, body string) (Comment, error) {
+	var c azureReposComment
+	err := a.client.call("PATCH", a.apiURL(fmt.Sprintf("/threads/%d/comments/%s", existing.threadID, existing.ID), azureReposAPIVersion), map[string]string{"content": body}, &c)
+	if err != nil {
+		return Comment{}, err
+	}
+
+	return a.comment(existing.threadID, c), nil
+}
+
+func (a *AzureRepos) DeleteComment(existing Comment) error {
+	return a.client.call("DELETE", a.apiURL(fmt.Sprintf("/threads/%d/comments/%s", existing.threadID, existing.ID), azureReposAPIVersion), nil, nil)
+}
+
+// SetBuildStatus sets the Infracost status of the pull request, which can be
+// required by a branch policy.
+func (a *AzureRepos) SetBuildStatus(status BuildStatus) error {
+	state := "succeeded"
+	if status.State == StateFailed {
+		state = "failed"
+	}
+
+	reqBody := map[string]interface{}{
+		"state":       state,
+		"description": status.Description,
+		"context": map[string]string{
+			"genre": buildStatusKey,
+			"name":  buildStatusKey,
+		},
+	}
+	if status.URL != "" {
+		reqBody["targetUrl"] = status.URL
+	}
+
+	// The pull request statuses API is only available as a preview
+	return a.client.call("POST", a.apiURL("/statuses", azureReposAPIVersion+"-preview.1"), reqBody, nil)
+}
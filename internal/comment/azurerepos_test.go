@@ -0,0 +1,86 @@
+package comment
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAzureRepos(t *testing.T) {
+	var requests []string
+	var bodies []map[string]interface{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Basic OnRva2Vu", r.Header.Get("Authorization"))
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+
+		b, _ := ioutil.ReadAll(r.Body)
+		if len(b) > 0 {
+			var body map[string]interface{}
+			_ = json.Unmarshal(b, &body)
+			bodies = append(bodies, body)
+		}
+
+		base := "/my-org/my-project/_apis/git/repositories/my-repo/pullRequests/3"
+		switch {
+		case r.URL.Path == base+"/threads" && r.Method == "GET":
+			_, _ = w.Write([]byte(`{"value": [
+				{"id": 7, "comments": [{"id": 1, "content": "Looks good"}]},
+				{"id": 8, "isDeleted": true, "comments": [{"id": 1, "content": "[//]: <> (infracost-comment)\ndeleted"}]},
+				{"id": 9, "comments": [{"id": 1, "content": "[//]: <> (infracost-comment)\nold"}, {"id": 2, "content": "reply"}]}
+			]}`))
+		case r.URL.Path == base+"/threads/9/comments/1" && r.Method == "PATCH":
+			_, _ = w.Write([]byte(`{"id": 1, "content": "new"}`))
+		case r.URL.Path == base+"/threads" && r.Method == "POST":
+			_, _ = w.Write([]byte(`{"id": 10, "comments": [{"id": 1, "content": "new"}]}`))
+		case r.URL.Path == base+"/statuses":
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	a, err := NewAzureRepos(ts.URL+"/my-org/my-project/_git/my-repo", "token", 3)
+	require.NoError(t, err)
+
+	c, err := Post(a, Marker+"\nnew", BehaviorUpdate)
+	require.NoError(t, err)
+	assert.Equal(t, ts.URL+"/my-org/my-project/_git/my-repo/pullrequest/3?discussionId=9", c.URL)
+
+	c, err = Post(a, Marker+"\nnew", BehaviorNew)
+	require.NoError(t, err)
+	assert.Equal(t, ts.URL+"/my-org/my-project/_git/my-repo/pullrequest/3?discussionId=10", c.URL)
+
+	err = a.SetBuildStatus(BuildStatus{State: StateFailed, Description: "Monthly cost will change by +$10.00", URL: "https://ci.example.com"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"GET /my-org/my-project/_apis/git/repositories/my-repo/pullRequests/3/threads?api-version=6.0",
+		"PATCH /my-org/my-project/_apis/git/repositories/my-repo/pullRequests/3/threads/9/comments/1?api-version=6.0",
+		"POST /my-org/my-project/_apis/git/repositories/my-repo/pullRequests/3/threads?api-version=6.0",
+		"POST /my-org/my-project/_apis/git/repositories/my-repo/pullRequests/3/statuses?api-version=6.0-preview.1",
+	}, requests)
+	assert.Equal(t, Marker+"\nnew", bodies[0]["content"])
+	assert.Equal(t, "failed", bodies[2]["state"])
+	assert.Equal(t, "https://ci.example.com", bodies[2]["targetUrl"])
+}
+
+func TestNewAzureReposRepoURL(t *testing.T) {
+	a, err := NewAzureRepos("https://my-org@dev.azure.com/my-org/my-project/_git/my-repo", "token", 3)
+	require.NoError(t, err)
+	assert.Equal(t, "https://dev.azure.com/my-org/my-project", a.projectURL)
+	assert.Equal(t, "my-repo", a.repo)
+
+	a, err = NewAzureRepos("https://tfs.example.com/tfs/DefaultCollection/my-project/_git/my-repo/", "token", 3)
+	require.NoError(t, err)
+	assert.Equal(t, "https://tfs.example.com/tfs/DefaultCollection/my-project", a.projectURL)
+
+	_, err = NewAzureRepos("https://dev.azure.com/my-org/my-project", "token", 3)
+	assert.EqualError(t, err, "Invalid Azure Repos repo URL https://dev.azure.com/my-org/my-project, expected the format https://dev.azure.com/org/project/_git/repo")
+}
@@ -0,0 +1,266 @@
+package comment
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var DefaultBitbucketAPIURL = "https://api.bitbucket.org/2.0"
+
+var DefaultBitbucketURL = "https://bitbucket.org"
+
+const buildStatusKey = "infracost"
+
+// Bitbucket posts comments to a pull request on Bitbucket Cloud, or on
+// Bitbucket Server and Data Center if the server URL is set. The repo is in
+// the format workspace/repo for Bitbucket Cloud and PROJECT/repo for
+// Bitbucket Server.
+type Bitbucket struct {
+	client      *apiClient
+	repo        string
+	pullRequest int
+	commit      string
+	serverURL   string
+	apiURL      string
+}
+
+// NewBitbucket returns the platform for the pull request. The token is an
+// access token, or a username and app password in the format
+// username:password. The commit is only needed to set the build status.
+func NewBitbucket(serverURL string, token string, repo string, pullRequest int, commit string) (*Bitbucket, error) {
+	parts := strings.Split(repo, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("Invalid Bitbucket repo %s, expected the format workspace/repo or PROJECT/repo", repo)
+	}
+
+	if token == "" {
+		return nil, errors.New("A Bitbucket token is required")
+	}
+
+	authorization := fmt.Sprintf("Bearer %s", token)
+	if strings.Contains(token, ":") {
+		authorization = fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte(token)))
+	}
+
+	return &Bitbucket{
+		client:      &apiClient{name: "Bitbucket", authorization: authorization},
+		repo:        repo,
+		pullRequest: pullRequest,
+		commit:      commit,
+		serverURL:   strings.TrimSuffix(serverURL, "/"),
+		apiURL:      DefaultBitbucketAPIURL,
+	}, nil
+}
+
+func (b *Bitbucket) Name() string {
+	if b.isServer() {
+		return "Bitbucket Server"
+	}
+
+	return "Bitbucket"
+}
+
+func (b *Bitbucket) isServer() bool {
+	return b.serverURL != ""
+}
+
+type bitbucketCloudComment struct {
+	ID      int  `json:"id"`
+	Deleted bool `json:"deleted"`
+	Content struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+	Links struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+}
+
+type bitbucketCloudComments struct {
+	Values []bitbucketCloudComment `json:"values"`
+	Next   string                  `json:"next"`
+}
+
+type bitbucketServerComment struct {
+	ID      int    `json:"id"`
+	Version int    `json:"version"`
+	Text    string `json:"text"`
+}
+
+type bitbucketServerActivities struct {
+	Values []struct {
+		Action  string                  `json:"action"`
+		Comment *bitbucketServerComment `json:"comment"`
+	} `json:"values"`
+	IsLastPage    bool `json:"isLastPage"`
+	NextPageStart int  `json:"nextPageStart"`
+}
+
+func (c bitbucketCloudComment) comment() Comment {
+	return Comment{
+		ID:   strconv.Itoa(c.ID),
+		Body: c.Content.Raw,
+		URL:  c.Links.HTML.Href,
+	}
+}
+
+func (b *Bitbucket) serverComment(c bitbucketServerComment) Comment {
+	return Comment{
+		ID:      strconv.Itoa(c.ID),
+		Body:    c.Text,
+		URL:     fmt.Sprintf("%s?commentId=%d", b.pullRequestURL(), c.ID),
+		version: c.Version,
+	}
+}
+
+// pullRequestAPIURL is the API URL of the pull request.
+func (b *Bitbucket) pullRequestAPIURL() string {
+	if b.isServer() {
+		parts := strings.Split(b.repo, "/")
+		return fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s/pull-requests/%d", b.serverURL, parts[0], parts[1], b.pullRequest)
+	}
+
+	return fmt.Sprintf("%s/repositories/%s/pullrequests/%d", b.apiURL, b.repo, b.pullRequest)
+}
+
+// pullRequestURL is the web URL of the pull request.
+func (b *Bitbucket) pullRequestURL() string {
+	if b.isServer() {
+		parts := strings.Split(b.repo, "/")
+		return fmt.Sprintf("%s/projects/%s/repos/%s/pull-requests/%d", b.serverURL, parts[0], parts[1], b.pullRequest)
+	}
+
+	return fmt.Sprintf("%s/%s/pull-requests/%d", DefaultBitbucketURL, b.repo, b.pullRequest)
+}
+
+func (b *Bitbucket) Comments() ([]Comment, error) {
+	comments := make([]Comment, 0)
+
+	if b.isServer() {
+		// The activities are newest first, so they're reversed after they've
+		// all been fetched
+		seen := make(map[int]bool)
+		start := 0
+		for {
+			var page bitbucketServerActivities
+			err := b.client.call("GET", fmt.Sprintf("%s/activities?start=%d&limit=100", b.pullRequestAPIURL(), start), nil, &page)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, a := range page.Values {
+				if a.Action != "COMMENTED" || a.Comment == nil || seen[a.Comment.ID] {
+					continue
+				}
+				seen[a.Comment.ID] = true
+				comments = append([]Comment{b.serverComment(*a.Comment)}, comments...)
+			}
+
+			if page.IsLastPage {
+				return comments, nil
+			}
+			start = page.NextPageStart
+		}
+	}
+
+	url := fmt.Sprintf("%s/comments?pagelen=100", b.pullRequestAPIURL())
+	for url != "" {
+		var page bitbucketCloudComments
+		err := b.client.call("GET", url, nil, &page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range page.Values {
+			if !c.Deleted {
+				comments = append(comments, c.comment())
+			}
+		}
+
+		url = page.Next
+	}
+
+	return comments, nil
+}
+
+func (b *Bitbucket) CreateComment(body string) (Comment, error) {
+	url := fmt.Sprintf("%s/comments", b.pullRequestAPIURL())
+
+	if b.isServer() {
+		var c bitbucketServerComment
+		err := b.client.call("POST", url, map[string]interface{}{"text": body}, &c)
+		if err != nil {
+			return Comment{}, err
+		}
+		return b.serverComment(c), nil
+	}
+
+	var c bitbucketCloudComment
+	err := b.client.call("POST", url, map[string]interface{}{"content": map[string]string{"raw": body}}, &c)
+	if err != nil {
+		return Comment{}, err
+	}
+	return c.comment(), nil
+}
+
+func (b *Bitbucket) UpdateComment(existing Comment, body string) (Comment, error) {
+	url := fmt.Sprintf("%s/comments/%s", b.pullRequestAPIURL(), existing.ID)
+
+	if b.isServer() {
+		var c bitbucketServerComment
+		err := b.client.call("PUT", url, map[string]interface{}{"text": body, "version": existing.version}, &c)
+		if err != nil {
+			return Comment{}, err
+		}
+		return b.serverComment(c), nil
+	}
+
+	var c bitbucketCloudComment
+	err := b.client.call("PUT", url, map[string]interface{}{"content": map[string]string{"raw": body}}, &c)
+	if err != nil {
+		return Comment{}, err
+	}
+	return c.comment(), nil
+}
+
+func (b *Bitbucket) DeleteComment(existing Comment) error {
+	url := fmt.Sprintf("%s/comments/%s", b.pullRequestAPIURL(), existing.ID)
+	if b.isServer() {
+		url = fmt.Sprintf("%s?version=%d", url, existing.version)
+	}
+
+	return b.client.call("DELETE", url, nil, nil)
+}
+
+// SetBuildStatus sets the Infracost build status of the commit. Bitbucket
+// requires a URL, so the pull request is linked to if the status doesn't
+// have one.
+func (b *Bitbucket) SetBuildStatus(status BuildStatus) error {
+	if b.commit == "" {
+		return errors.New("The commit is required to set the Bitbucket build status")
+	}
+
+	url := status.URL
+	if url == "" {
+		url = b.pullRequestURL()
+	}
+
+	reqBody := map[string]string{
+		"key":         buildStatusKey,
+		"name":        "Infracost",
+		"state":       strings.ToUpper(status.State),
+		"description": status.Description,
+		"url":         url,
+	}
+
+	if b.isServer() {
+		return b.client.call("POST", fmt.Sprintf("%s/rest/build-status/1.0/commits/%s", b.serverURL, b.commit), reqBody, nil)
+	}
+
+	return b.client.call("POST", fmt.Sprintf("%s/repositories/%s/commit/%s/statuses/build", b.apiURL, b.repo, b.commit), reqBody, nil)
+}
@@ -0,0 +1,113 @@
+package comment
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketCloud(t *testing.T) {
+	var requests []string
+	var bodies []map[string]interface{}
+
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+
+		b, _ := ioutil.ReadAll(r.Body)
+		if len(b) > 0 {
+			var body map[string]interface{}
+			_ = json.Unmarshal(b, &body)
+			bodies = append(bodies, body)
+		}
+
+		switch {
+		case r.URL.Path == "/repositories/my-workspace/my-repo/pullrequests/3/comments" && r.Method == "GET" && r.URL.Query().Get("page") == "":
+			_, _ = w.Write([]byte(`{"values": [{"id": 1, "content": {"raw": "Looks good"}}], "next": "` + ts.URL + `/repositories/my-workspace/my-repo/pullrequests/3/comments?pagelen=100&page=2"}`))
+		case r.URL.Path == "/repositories/my-workspace/my-repo/pullrequests/3/comments" && r.Method == "GET":
+			_, _ = w.Write([]byte(`{"values": [{"id": 2, "content": {"raw": "[//]: <> (infracost-comment)\nold"}}, {"id": 3, "deleted": true, "content": {"raw": "[//]: <> (infracost-comment)\ndeleted"}}]}`))
+		case r.URL.Path == "/repositories/my-workspace/my-repo/pullrequests/3/comments/2" && r.Method == "PUT":
+			_, _ = w.Write([]byte(`{"id": 2, "content": {"raw": "updated"}, "links": {"html": {"href": "https://bitbucket.org/my-workspace/my-repo/pull-requests/3#comment-2"}}}`))
+		case r.URL.Path == "/repositories/my-workspace/my-repo/commit/abc123/statuses/build":
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	b, err := NewBitbucket("", "token", "my-workspace/my-repo", 3, "abc123")
+	require.NoError(t, err)
+	b.apiURL = ts.URL
+
+	c, err := Post(b, Marker+"\nnew", BehaviorUpdate)
+	require.NoError(t, err)
+	assert.Equal(t, "https://bitbucket.org/my-workspace/my-repo/pull-requests/3#comment-2", c.URL)
+
+	err = b.SetBuildStatus(BuildStatus{State: StateFailed, Description: "Monthly cost will change by +$10.00"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"GET /repositories/my-workspace/my-repo/pullrequests/3/comments?pagelen=100",
+		"GET /repositories/my-workspace/my-repo/pullrequests/3/comments?pagelen=100&page=2",
+		"PUT /repositories/my-workspace/my-repo/pullrequests/3/comments/2",
+		"POST /repositories/my-workspace/my-repo/commit/abc123/statuses/build",
+	}, requests)
+	assert.Equal(t, map[string]interface{}{"raw": Marker + "\nnew"}, bodies[0]["content"])
+	assert.Equal(t, "FAILED", bodies[1]["state"])
+	assert.Equal(t, "infracost", bodies[1]["key"])
+	assert.Equal(t, "https://bitbucket.org/my-workspace/my-repo/pull-requests/3", bodies[1]["url"])
+}
+
+func TestBitbucketServer(t *testing.T) {
+	var requests []string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Basic dXNlcjpwYXNzd29yZA==", r.Header.Get("Authorization"))
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+
+		switch {
+		case r.URL.Path == "/rest/api/1.0/projects/PROJ/repos/my-repo/pull-requests/3/activities" && r.URL.Query().Get("start") == "0":
+			_, _ = w.Write([]byte(`{"values": [{"action": "COMMENTED", "comment": {"id": 12, "version": 1, "text": "[//]: <> (infracost-comment)\nnewer"}}, {"action": "APPROVED"}], "isLastPage": false, "nextPageStart": 2}`))
+		case r.URL.Path == "/rest/api/1.0/projects/PROJ/repos/my-repo/pull-requests/3/activities":
+			_, _ = w.Write([]byte(`{"values": [{"action": "COMMENTED", "comment": {"id": 11, "version": 0, "text": "[//]: <> (infracost-comment)\nold"}}], "isLastPage": true}`))
+		case r.Method == "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/rest/api/1.0/projects/PROJ/repos/my-repo/pull-requests/3/comments" && r.Method == "POST":
+			_, _ = w.Write([]byte(`{"id": 13, "version": 0, "text": "new"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	b, err := NewBitbucket(ts.URL+"/", "user:password", "PROJ/my-repo", 3, "")
+	require.NoError(t, err)
+	assert.Equal(t, "Bitbucket Server", b.Name())
+
+	c, err := Post(b, Marker+"\nnew", BehaviorDeleteAndNew)
+	require.NoError(t, err)
+	assert.Equal(t, ts.URL+"/projects/PROJ/repos/my-repo/pull-requests/3?commentId=13", c.URL)
+
+	assert.Equal(t, []string{
+		"GET /rest/api/1.0/projects/PROJ/repos/my-repo/pull-requests/3/activities?start=0&limit=100",
+		"GET /rest/api/1.0/projects/PROJ/repos/my-repo/pull-requests/3/activities?start=2&limit=100",
+		"DELETE /rest/api/1.0/projects/PROJ/repos/my-repo/pull-requests/3/comments/11?version=0",
+		"DELETE /rest/api/1.0/projects/PROJ/repos/my-repo/pull-requests/3/comments/12?version=1",
+		"POST /rest/api/1.0/projects/PROJ/repos/my-repo/pull-requests/3/comments",
+	}, requests)
+
+	err = b.SetBuildStatus(BuildStatus{State: StateSuccessful})
+	assert.EqualError(t, err, "The commit is required to set the Bitbucket build status")
+}
+
+func TestNewBitbucketInvalidRepo(t *testing.T) {
+	_, err := NewBitbucket("", "token", "my-repo", 3, "")
+	assert.EqualError(t, err, "Invalid Bitbucket repo my-repo, expected the format workspace/repo or PROJECT/repo")
+}
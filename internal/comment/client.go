@@ -0,0 +1,66 @@
+package comment
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var apiTimeout = 30 * time.Second
+
+// apiClient calls the JSON API of a platform with the authorization header.
+type apiClient struct {
+	name          string
+	authorization string
+}
+
+func (c *apiClient) call(method string, url string, reqBody interface{}, v interface{}) error {
+	log.Debugf("Calling %s API: %s %s", c.name, method, url)
+
+	reqBytes := []byte{}
+	if reqBody != nil {
+		var err error
+		reqBytes, err = json.Marshal(reqBody)
+		if err != nil {
+			return errors.Wrapf(err, "Error generating %s request body", c.name)
+		}
+	}
+
+	req, err := http.NewRequest(method, url, bytes.NewBuffer(reqBytes))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", c.authorization)
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client := &http.Client{Timeout: apiTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("invalid response from %s: %s", c.name, resp.Status)
+	}
+
+	if v == nil || len(body) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(body, v)
+}
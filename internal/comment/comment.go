@@ -0,0 +1,146 @@
+// Package comment posts the cost estimate of a pull request as a comment on
+// it, and sets the build status of its commit, on the platforms that don't
+// have their own integration.
+package comment
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// Marker is added to the start of the comments so they can be found again
+// to be updated or deleted. It's a markdown link reference, which isn't
+// rendered.
+const Marker = "[//]: <> (infracost-comment)"
+
+// The behaviors for when the pull request already has an Infracost comment.
+const (
+	// BehaviorUpdate updates the latest Infracost comment, or creates one if
+	// there isn't one yet, so the pull request only ever has one comment
+	BehaviorUpdate = "update"
+	// BehaviorNew always creates a new comment
+	BehaviorNew = "new"
+	// BehaviorDeleteAndNew deletes the Infracost comments and creates a new
+	// one, so the latest estimate is at the bottom of the pull request
+	BehaviorDeleteAndNew = "delete-and-new"
+)
+
+// Behaviors are the valid comment behaviors.
+var Behaviors = []string{BehaviorUpdate, BehaviorNew, BehaviorDeleteAndNew}
+
+// The states of a build status.
+const (
+	StateSuccessful = "successful"
+	StateFailed     = "failed"
+)
+
+// Comment is a pull request comment. The thread ID and version are only
+// used by the platforms that need them to update a comment.
+type Comment struct {
+	ID   string
+	Body string
+	URL  string
+
+	threadID int
+	version  int
+}
+
+// BuildStatus is the status of the Infracost check of the commit.
+type BuildStatus struct {
+	State       string
+	Description string
+	URL         string
+}
+
+// NewBuildStatus returns the build status for the cost change. It's failed if
+// the threshold is set and the total monthly cost increases by at least the
+// threshold, so a branch policy can block the pull request.
+func NewBuildStatus(out output.Root, threshold *output.DiffThreshold, url string) BuildStatus {
+	state := StateSuccessful
+	if threshold != nil && threshold.IsSignificant(out) && output.TotalMonthlyCostChange(out).IsPositive() {
+		state = StateFailed
+	}
+
+	return BuildStatus{
+		State:       state,
+		Description: output.BuildNotification(out, "", "").Title,
+		URL:         url,
+	}
+}
+
+// Platform is a source control platform that comments can be posted to.
+// The comments are the ones on the pull request that the platform was
+// created for, oldest first.
+type Platform interface {
+	Name() string
+	Comments() ([]Comment, error)
+	CreateComment(body string) (Comment, error)
+	UpdateComment(c Comment, body string) (Comment, error)
+	DeleteComment(c Comment) error
+	SetBuildStatus(status BuildStatus) error
+}
+
+// Body returns the comment body for the Infracost JSON, which is the summary
+// of the total cost change and the diff of each project.
+func Body(out output.Root, opts output.Options) (string, error) {
+	opts.NoColor = true
+
+	b, err := output.ToDiff(out, opts)
+	if err != nil {
+		return "", errors.Wrap(err, "Error generating comment")
+	}
+
+	n := output.BuildNotification(out, "Infracost estimate", "")
+
+	return fmt.Sprintf("%s\n%s\n\n```\n%s\n```\n", Marker, n.Title, strings.TrimSpace(ui.StripColor(string(b)))), nil
+}
+
+// Post posts the comment body to the platform using the behavior, and
+// returns the comment that was created or updated.
+func Post(p Platform, body string, behavior string) (Comment, error) {
+	if behavior == BehaviorNew {
+		return p.CreateComment(body)
+	}
+
+	comments, err := p.Comments()
+	if err != nil {
+		return Comment{}, err
+	}
+
+	existing := make([]Comment, 0)
+	for _, c := range comments {
+		if strings.HasPrefix(strings.TrimSpace(c.Body), Marker) {
+			existing = append(existing, c)
+		}
+	}
+
+	switch behavior {
+	case BehaviorUpdate:
+		if len(existing) == 0 {
+			return p.CreateComment(body)
+		}
+
+		latest := existing[len(existing)-1]
+		if strings.TrimSpace(latest.Body) == strings.TrimSpace(body) {
+			return latest, nil
+		}
+
+		return p.UpdateComment(latest, body)
+	case BehaviorDeleteAndNew:
+		for _, c := range existing {
+			err = p.DeleteComment(c)
+			if err != nil {
+				return Comment{}, err
+			}
+		}
+
+		return p.CreateComment(body)
+	default:
+		return Comment{}, fmt.Errorf("Invalid comment behavior %s, expected one of %s", behavior, strings.Join(Behaviors, ", "))
+	}
+}
@@ -0,0 +1,136 @@
+package comment
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/output"
+)
+
+type fakePlatform struct {
+	comments []Comment
+	nextID   int
+	actions  []string
+}
+
+func (p *fakePlatform) Name() string { return "Fake" }
+
+func (p *fakePlatform) Comments() ([]Comment, error) {
+	return p.comments, nil
+}
+
+func (p *fakePlatform) CreateComment(body string) (Comment, error) {
+	p.nextID++
+	c := Comment{ID: strconv.Itoa(p.nextID), Body: body}
+	p.comments = append(p.comments, c)
+	p.actions = append(p.actions, "create "+c.ID)
+	return c, nil
+}
+
+func (p *fakePlatform) UpdateComment(c Comment, body string) (Comment, error) {
+	p.actions = append(p.actions, "update "+c.ID)
+	c.Body = body
+	return c, nil
+}
+
+func (p *fakePlatform) DeleteComment(c Comment) error {
+	p.actions = append(p.actions, "delete "+c.ID)
+	return nil
+}
+
+func (p *fakePlatform) SetBuildStatus(status BuildStatus) error {
+	return nil
+}
+
+func newFakePlatform() *fakePlatform {
+	return &fakePlatform{
+		comments: []Comment{
+			{ID: "1", Body: Marker + "\nold estimate"},
+			{ID: "2", Body: "Looks good"},
+			{ID: "3", Body: Marker + "\nnewer estimate"},
+		},
+		nextID: 3,
+	}
+}
+
+func TestPost(t *testing.T) {
+	body := Marker + "\nnew estimate"
+
+	p := newFakePlatform()
+	c, err := Post(p, body, BehaviorUpdate)
+	require.NoError(t, err)
+	assert.Equal(t, "3", c.ID)
+	assert.Equal(t, []string{"update 3"}, p.actions)
+
+	p = newFakePlatform()
+	_, err = Post(p, Marker+"\nnewer estimate", BehaviorUpdate)
+	require.NoError(t, err)
+	assert.Empty(t, p.actions)
+
+	p = &fakePlatform{}
+	_, err = Post(p, body, BehaviorUpdate)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"create 1"}, p.actions)
+
+	p = newFakePlatform()
+	_, err = Post(p, body, BehaviorNew)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"create 4"}, p.actions)
+
+	p = newFakePlatform()
+	_, err = Post(p, body, BehaviorDeleteAndNew)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"delete 1", "delete 3", "create 4"}, p.actions)
+
+	_, err = Post(newFakePlatform(), body, "hide")
+	assert.EqualError(t, err, "Invalid comment behavior hide, expected one of update, new, delete-and-new")
+}
+
+func testOutput(past int64, current int64) output.Root {
+	pastCost := decimal.NewFromInt(past)
+	currentCost := decimal.NewFromInt(current)
+	diffCost := currentCost.Sub(pastCost)
+
+	return output.Root{
+		Summary: &output.Summary{},
+		Projects: []output.Project{
+			{
+				Name:          "infracost/infracost",
+				PastBreakdown: &output.Breakdown{TotalMonthlyCost: &pastCost},
+				Breakdown:     &output.Breakdown{TotalMonthlyCost: &currentCost},
+				Diff:          &output.Breakdown{TotalMonthlyCost: &diffCost},
+			},
+		},
+	}
+}
+
+func TestBody(t *testing.T) {
+	body, err := Body(testOutput(100, 150), output.Options{})
+	require.NoError(t, err)
+
+	lines := strings.Split(body, "\n")
+	assert.Equal(t, Marker, lines[0])
+	assert.Equal(t, "Infracost estimate: Monthly cost will change by +$50.00 (+50%), from $100 to $150", lines[1])
+	assert.Equal(t, "```", lines[3])
+	assert.Contains(t, body, "Project: infracost/infracost")
+	assert.True(t, strings.HasSuffix(body, "\n```\n"))
+}
+
+func TestNewBuildStatus(t *testing.T) {
+	threshold, err := output.ParseThreshold("10%")
+	require.NoError(t, err)
+
+	status := NewBuildStatus(testOutput(100, 150), threshold, "https://ci.example.com")
+	assert.Equal(t, StateFailed, status.State)
+	assert.Equal(t, "Monthly cost will change by +$50.00 (+50%), from $100 to $150", status.Description)
+	assert.Equal(t, "https://ci.example.com", status.URL)
+
+	assert.Equal(t, StateSuccessful, NewBuildStatus(testOutput(100, 105), threshold, "").State)
+	assert.Equal(t, StateSuccessful, NewBuildStatus(testOutput(150, 100), threshold, "").State)
+	assert.Equal(t, StateSuccessful, NewBuildStatus(testOutput(100, 150), nil, "").State)
+}
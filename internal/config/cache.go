@@ -0,0 +1,149 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+var sizeRegex = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([KMGT]?B?)$`)
+
+var sizeUnits = map[string]int64{
+	"":   1,
+	"B":  1,
+	"K":  1 << 10,
+	"KB": 1 << 10,
+	"M":  1 << 20,
+	"MB": 1 << 20,
+	"G":  1 << 30,
+	"GB": 1 << 30,
+	"T":  1 << 40,
+	"TB": 1 << 40,
+}
+
+// ParseSize parses a size in bytes, or with a KB, MB, GB or TB suffix, e.g.
+// 500MB. The units are powers of 1024.
+func ParseSize(s string) (int64, error) {
+	m := sizeRegex.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(s)))
+	if m == nil {
+		return 0, fmt.Errorf("Invalid size %s, it must be a number of bytes or have a KB, MB, GB or TB suffix, e.g. 500MB", s)
+	}
+
+	n, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid size %s", s)
+	}
+
+	return int64(n * float64(sizeUnits[m[2]])), nil
+}
+
+// MaxCacheSizeBytes returns the max cache size in bytes, or 0 if the caches
+// aren't capped.
+func (c *Config) MaxCacheSizeBytes() (int64, error) {
+	if c.MaxCacheSize == "" {
+		return 0, nil
+	}
+
+	return ParseSize(c.MaxCacheSize)
+}
+
+type cacheEntry struct {
+	path     string
+	size     int64
+	lastUsed time.Time
+}
+
+// TouchCacheEntry marks a cache entry as used so it's the last to be pruned.
+func TouchCacheEntry(path string) error {
+	now := time.Now()
+	return os.Chtimes(path, now, now)
+}
+
+// PruneCache removes the least recently used entries of a cache dir until the
+// total size of the entries is within maxSize. The entries are the files or
+// directories matching the glob pattern in the dir, e.g. */* for a dir with
+// an entry for each version of each binary, and an entry's last use is its
+// modification time. The keep entry is never removed since it's in use. It
+// returns the paths of the removed entries.
+func PruneCache(dir string, pattern string, maxSize int64, keep string) ([]string, error) {
+	if maxSize <= 0 {
+		return nil, nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, pattern))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]cacheEntry, 0, len(paths))
+	var total int64
+
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			continue
+		}
+
+		size, err := dirSize(p)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, cacheEntry{path: p, size: size, lastUsed: info.ModTime()})
+		total += size
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].lastUsed.Before(entries[j].lastUsed)
+	})
+
+	removed := make([]string, 0)
+
+	for _, e := range entries {
+		if total <= maxSize {
+			break
+		}
+
+		if filepath.Clean(e.path) == filepath.Clean(keep) {
+			continue
+		}
+
+		log.Debugf("Removing %s from the cache since the cache is over the max size", e.path)
+
+		err := os.RemoveAll(e.path)
+		if err != nil {
+			return removed, errors.Wrapf(err, "Error removing %s from the cache", e.path)
+		}
+
+		total -= e.size
+		removed = append(removed, e.path)
+	}
+
+	return removed, nil
+}
+
+func dirSize(path string) (int64, error) {
+	var size int64
+
+	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			size += info.Size()
+		}
+
+		return nil
+	})
+
+	return size, err
+}
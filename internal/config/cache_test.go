@@ -0,0 +1,81 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"1024", 1024},
+		{"10B", 10},
+		{"2KB", 2048},
+		{"500MB", 500 << 20},
+		{"500mb", 500 << 20},
+		{"1.5G", 3 << 29},
+		{"1 TB", 1 << 40},
+	}
+
+	for _, tt := range tests {
+		actual, err := ParseSize(tt.input)
+		require.NoError(t, err, tt.input)
+		assert.Equal(t, tt.expected, actual, tt.input)
+	}
+
+	for _, input := range []string{"", "MB", "-1MB", "10PB", "ten"} {
+		_, err := ParseSize(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestPruneCache(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	// Create an entry for each version, with v1 the least recently used
+	for i, v := range []string{"v1", "v2", "v3"} {
+		p := filepath.Join(dir, "terraform", v)
+		require.NoError(t, os.MkdirAll(p, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(p, "terraform"), make([]byte, 100), 0600))
+
+		lastUsed := now.Add(time.Duration(i-3) * time.Hour)
+		require.NoError(t, os.Chtimes(p, lastUsed, lastUsed))
+	}
+
+	// v1 is in use so it's kept, and v2 is the next least recently used
+	removed, err := PruneCache(dir, "*/*", 250, filepath.Join(dir, "terraform", "v1"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "terraform", "v2")}, removed)
+
+	assert.DirExists(t, filepath.Join(dir, "terraform", "v1"))
+	assert.DirExists(t, filepath.Join(dir, "terraform", "v3"))
+
+	// Nothing is removed once the cache is within the max size
+	removed, err = PruneCache(dir, "*/*", 250, "")
+	require.NoError(t, err)
+	assert.Empty(t, removed)
+
+	// Touching an entry makes it the last to be removed
+	require.NoError(t, TouchCacheEntry(filepath.Join(dir, "terraform", "v1")))
+	removed, err = PruneCache(dir, "*/*", 100, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "terraform", "v3")}, removed)
+}
+
+func TestPruneCacheNotCapped(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "entry"), make([]byte, 100), 0600))
+
+	removed, err := PruneCache(dir, "*", 0, "")
+	require.NoError(t, err)
+	assert.Empty(t, removed)
+	assert.FileExists(t, filepath.Join(dir, "entry"))
+}
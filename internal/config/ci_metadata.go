@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// ciMetadata is the git and pipeline details of a CI build, which are read
+// from the environment variables that the CI platform sets.
+type ciMetadata struct {
+	Platform          string
+	RepoURL           string
+	Branch            string
+	CommitSHA         string
+	PullRequestNumber string
+	PipelineID        string
+}
+
+var githubPullRequestRefRegex = regexp.MustCompile(`^refs/pull/(\d+)/`)
+
+// detectCIMetadata reads the metadata of the CI platform that infracost is
+// running in. Values that the platform doesn't set are empty.
+func detectCIMetadata() ciMetadata {
+	platform := ciPlatform()
+	m := ciMetadata{Platform: platform}
+
+	switch {
+	case platform == "github_actions":
+		if os.Getenv("GITHUB_SERVER_URL") != "" && os.Getenv("GITHUB_REPOSITORY") != "" {
+			m.RepoURL = fmt.Sprintf("%s/%s", os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"))
+		}
+		// GITHUB_HEAD_REF is only set for pull requests, which are built from
+		// a merge commit so GITHUB_REF_NAME isn't the branch
+		m.Branch = firstEnv("GITHUB_HEAD_REF", "GITHUB_REF_NAME")
+		m.CommitSHA = os.Getenv("GITHUB_SHA")
+		if match := githubPullRequestRefRegex.FindStringSubmatch(os.Getenv("GITHUB_REF")); match != nil {
+			m.PullRequestNumber = match[1]
+		}
+		m.PipelineID = os.Getenv("GITHUB_RUN_ID")
+	case platform == "gitlab_ci":
+		m.RepoURL = os.Getenv("CI_PROJECT_URL")
+		m.Branch = firstEnv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME", "CI_COMMIT_REF_NAME")
+		m.CommitSHA = os.Getenv("CI_COMMIT_SHA")
+		m.PullRequestNumber = os.Getenv("CI_MERGE_REQUEST_IID")
+		m.PipelineID = os.Getenv("CI_PIPELINE_ID")
+	case platform == "circleci":
+		m.RepoURL = os.Getenv("CIRCLE_REPOSITORY_URL")
+		m.Branch = os.Getenv("CIRCLE_BRANCH")
+		m.CommitSHA = os.Getenv("CIRCLE_SHA1")
+		// CircleCI only sets the URL of the pull request
+		if pr := os.Getenv("CIRCLE_PULL_REQUEST"); pr != "" {
+			m.PullRequestNumber = pr[strings.LastIndex(pr, "/")+1:]
+		}
+		m.PipelineID = os.Getenv("CIRCLE_WORKFLOW_ID")
+	case platform == "jenkins":
+		m.RepoURL = os.Getenv("GIT_URL")
+		m.Branch = firstEnv("CHANGE_BRANCH", "BRANCH_NAME", "GIT_BRANCH")
+		m.CommitSHA = os.Getenv("GIT_COMMIT")
+		m.PullRequestNumber = os.Getenv("CHANGE_ID")
+		m.PipelineID = os.Getenv("BUILD_TAG")
+	case platform == "buildkite":
+		m.RepoURL = os.Getenv("BUILDKITE_REPO")
+		m.Branch = os.Getenv("BUILDKITE_BRANCH")
+		m.CommitSHA = os.Getenv("BUILDKITE_COMMIT")
+		if pr := os.Getenv("BUILDKITE_PULL_REQUEST"); pr != "false" {
+			m.PullRequestNumber = pr
+		}
+		m.PipelineID = os.Getenv("BUILDKITE_BUILD_ID")
+	case strings.HasPrefix(platform, "azure_devops_"):
+		m.RepoURL = os.Getenv("BUILD_REPOSITORY_URI")
+		m.Branch = firstEnv("SYSTEM_PULLREQUEST_SOURCEBRANCH", "BUILD_SOURCEBRANCHNAME")
+		m.Branch = strings.TrimPrefix(m.Branch, "refs/heads/")
+		m.CommitSHA = os.Getenv("BUILD_SOURCEVERSION")
+		m.PullRequestNumber = firstEnv("SYSTEM_PULLREQUEST_PULLREQUESTNUMBER", "SYSTEM_PULLREQUEST_PULLREQUESTID")
+		m.PipelineID = os.Getenv("BUILD_BUILDID")
+	case platform == "bitbucket":
+		m.RepoURL = os.Getenv("BITBUCKET_GIT_HTTP_ORIGIN")
+		m.Branch = os.Getenv("BITBUCKET_BRANCH")
+		m.CommitSHA = os.Getenv("BITBUCKET_COMMIT")
+		m.PullRequestNumber = os.Getenv("BITBUCKET_PR_ID")
+		m.PipelineID = os.Getenv("BITBUCKET_PIPELINE_UUID")
+	case platform == "atlantis":
+		m.Branch = os.Getenv("HEAD_BRANCH_NAME")
+		m.CommitSHA = os.Getenv("HEAD_COMMIT")
+		m.PullRequestNumber = os.Getenv("PULL_NUM")
+	}
+
+	return m
+}
+
+// firstEnv returns the value of the first environment variable that's set.
+func firstEnv(keys ...string) string {
+	for _, k := range keys {
+		if v := os.Getenv(k); v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// setCIEnv sets the environment variables of a CI platform and unsets the
+// ones that other CI platforms are detected by. It returns a func that
+// restores the environment.
+func setCIEnv(env map[string]string) func() {
+	keys := []string{"GITHUB_ACTIONS", "GITLAB_CI", "CIRCLECI", "JENKINS_HOME", "BUILDKITE", "SYSTEM_COLLECTIONURI", "CI"}
+	for k := range env {
+		keys = append(keys, k)
+	}
+
+	prev := make(map[string]*string, len(keys))
+	for _, k := range keys {
+		if v, ok := os.LookupEnv(k); ok {
+			prev[k] = &v
+		} else {
+			prev[k] = nil
+		}
+		os.Unsetenv(k)
+	}
+
+	for k, v := range env {
+		os.Setenv(k, v)
+	}
+
+	return func() {
+		for k, v := range prev {
+			if v == nil {
+				os.Unsetenv(k)
+			} else {
+				os.Setenv(k, *v)
+			}
+		}
+	}
+}
+
+func TestDetectCIMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected ciMetadata
+	}{
+		{
+			name: "github actions pull request",
+			env: map[string]string{
+				"GITHUB_ACTIONS":    "true",
+				"GITHUB_SERVER_URL": "https://github.com",
+				"GITHUB_REPOSITORY": "infracost/example",
+				"GITHUB_HEAD_REF":   "feature",
+				"GITHUB_REF_NAME":   "12/merge",
+				"GITHUB_REF":        "refs/pull/12/merge",
+				"GITHUB_SHA":        "abc123",
+				"GITHUB_RUN_ID":     "987",
+			},
+			expected: ciMetadata{
+				Platform:          "github_actions",
+				RepoURL:           "https://github.com/infracost/example",
+				Branch:            "feature",
+				CommitSHA:         "abc123",
+				PullRequestNumber: "12",
+				PipelineID:        "987",
+			},
+		},
+		{
+			name: "gitlab merge request",
+			env: map[string]string{
+				"GITLAB_CI":                           "true",
+				"CI_PROJECT_URL":                      "https://gitlab.com/infracost/example",
+				"CI_MERGE_REQUEST_SOURCE_BRANCH_NAME": "feature",
+				"CI_COMMIT_REF_NAME":                  "main",
+				"CI_COMMIT_SHA":                       "abc123",
+				"CI_MERGE_REQUEST_IID":                "7",
+				"CI_PIPELINE_ID":                      "456",
+			},
+			expected: ciMetadata{
+				Platform:          "gitlab_ci",
+				RepoURL:           "https://gitlab.com/infracost/example",
+				Branch:            "feature",
+				CommitSHA:         "abc123",
+				PullRequestNumber: "7",
+				PipelineID:        "456",
+			},
+		},
+		{
+			name: "circleci",
+			env: map[string]string{
+				"CIRCLECI":              "true",
+				"CIRCLE_REPOSITORY_URL": "git@github.com:infracost/example.git",
+				"CIRCLE_BRANCH":         "feature",
+				"CIRCLE_SHA1":           "abc123",
+				"CIRCLE_PULL_REQUEST":   "https://github.com/infracost/example/pull/34",
+				"CIRCLE_WORKFLOW_ID":    "wf-1",
+			},
+			expected: ciMetadata{
+				Platform:          "circleci",
+				RepoURL:           "git@github.com:infracost/example.git",
+				Branch:            "feature",
+				CommitSHA:         "abc123",
+				PullRequestNumber: "34",
+				PipelineID:        "wf-1",
+			},
+		},
+		{
+			name: "buildkite branch build",
+			env: map[string]string{
+				"BUILDKITE":              "true",
+				"BUILDKITE_BRANCH":       "main",
+				"BUILDKITE_COMMIT":       "abc123",
+				"BUILDKITE_PULL_REQUEST": "false",
+				"BUILDKITE_BUILD_ID":     "b-1",
+			},
+			expected: ciMetadata{
+				Platform:   "buildkite",
+				Branch:     "main",
+				CommitSHA:  "abc123",
+				PipelineID: "b-1",
+			},
+		},
+		{
+			name:     "not in CI",
+			env:      map[string]string{},
+			expected: ciMetadata{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer setCIEnv(test.env)()
+
+			assert.Equal(t, test.expected, detectCIMetadata())
+		})
+	}
+}
+
+func TestDetectProjectMetadataOverrides(t *testing.T) {
+	defer setCIEnv(map[string]string{
+		"GITLAB_CI":                         "true",
+		"CI_PROJECT_URL":                    "https://gitlab.com/infracost/example",
+		"CI_COMMIT_REF_NAME":                "main",
+		"CI_COMMIT_SHA":                     "abc123",
+		"CI_PIPELINE_ID":                    "456",
+		"INFRACOST_VCS_BRANCH":              "release",
+		"INFRACOST_VCS_PULL_REQUEST_NUMBER": "99",
+	})()
+
+	metadata := DetectProjectMetadata(&Project{Path: t.TempDir()})
+
+	assert.Equal(t, "https://gitlab.com/infracost/example", metadata.VCSRepoURL)
+	assert.Equal(t, "release", metadata.VCSBranch)
+	assert.Equal(t, "abc123", metadata.VCSCommitSHA)
+	assert.Equal(t, "99", metadata.VCSPullRequestNumber)
+	assert.Equal(t, "gitlab_ci", metadata.CIPlatform)
+	assert.Equal(t, "456", metadata.CIPipelineID)
+}
@@ -5,7 +5,9 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/infracost/infracost/internal/schema"
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
 	"github.com/sirupsen/logrus"
@@ -18,30 +20,194 @@ type Project struct {
 	TerraformWorkspace  string `yaml:"terraform_workspace,omitempty" envconfig:"INFRACOST_TERRAFORM_WORKSPACE"`
 	TerraformCloudHost  string `yaml:"terraform_cloud_host,omitempty" envconfig:"INFRACOST_TERRAFORM_CLOUD_HOST"`
 	TerraformCloudToken string `yaml:"terraform_cloud_token,omitempty" envconfig:"INFRACOST_TERRAFORM_CLOUD_TOKEN"`
-	UsageFile           string `yaml:"usage_file,omitempty" ignored:"true"`
-	TerraformUseState   bool   `yaml:"terraform_use_state,omitempty" ignored:"true"`
+	// TerraformCloudOrg and TerraformCloudWorkspace are used to get the plan JSON of the
+	// workspace's latest run from Terraform Cloud instead of running Terraform locally
+	TerraformCloudOrg       string `yaml:"terraform_cloud_org,omitempty" envconfig:"INFRACOST_TERRAFORM_CLOUD_ORG"`
+	TerraformCloudWorkspace string `yaml:"terraform_cloud_workspace,omitempty" envconfig:"INFRACOST_TERRAFORM_CLOUD_WORKSPACE"`
+	// TerraformAutoInstall controls if Terraform is installed when it can't be found.
+	// If it isn't set then Terraform is only installed when running in CI or a container.
+	TerraformAutoInstall    *bool  `yaml:"terraform_auto_install,omitempty" envconfig:"INFRACOST_TERRAFORM_AUTO_INSTALL"`
+	TerraformInstallDir     string `yaml:"terraform_install_dir,omitempty" envconfig:"INFRACOST_TERRAFORM_INSTALL_DIR"`
+	TerraformInstallVersion string `yaml:"terraform_install_version,omitempty" envconfig:"INFRACOST_TERRAFORM_INSTALL_VERSION"`
+	UsageFile               string `yaml:"usage_file,omitempty" ignored:"true"`
+	TerraformUseState       bool   `yaml:"terraform_use_state,omitempty" ignored:"true"`
+	// TerraformVars and TerraformVarFiles are passed to terraform plan as -var
+	// and -var-file flags after the plan flags, so they take precedence
+	TerraformVars     map[string]string `yaml:"terraform_vars,omitempty" ignored:"true"`
+	TerraformVarFiles []string          `yaml:"terraform_var_files,omitempty" ignored:"true"`
+	// Env is the environment variables that Terraform is run with for the
+	// project, e.g. the credentials of its backend
+	Env map[string]string `yaml:"env,omitempty" ignored:"true"`
+	// Skip leaves the project out of runs without removing it from the config file
+	Skip bool `yaml:"skip,omitempty" ignored:"true"`
+	// SyncUsageFile and MaxUncoveredPercent override the flags for the project
+	SyncUsageFile       *bool    `yaml:"sync_usage_file,omitempty" ignored:"true"`
+	MaxUncoveredPercent *float64 `yaml:"max_uncovered_percent,omitempty" ignored:"true"`
+	// Name is used instead of the name generated from the path or repo, and the
+	// labels are added to the project's metadata so they can be grouped by
+	Name   string            `yaml:"name,omitempty" ignored:"true"`
+	Labels map[string]string `yaml:"labels,omitempty" ignored:"true"`
+	// Kubernetes describes the cluster that Kubernetes manifests and Helm charts are deployed to
+	Kubernetes *KubernetesConfig `yaml:"kubernetes,omitempty" ignored:"true"`
+}
+
+// SyncsUsageFile returns true if the project's usage file is synced, which
+// the project's sync_usage_file overrides the flag for.
+func (p *Project) SyncsUsageFile(flag bool) bool {
+	if p.SyncUsageFile != nil {
+		return *p.SyncUsageFile
+	}
+
+	return flag
+}
+
+// KubernetesConfig describes the nodes of a cluster so the resource requests of
+// Kubernetes workloads can be priced as a share of a node. The node vCPU and memory
+// only need to be set if the instance type isn't known.
+type KubernetesConfig struct {
+	Region           string  `yaml:"region,omitempty"`
+	NodeInstanceType string  `yaml:"node_instance_type,omitempty"`
+	NodeVCPU         float64 `yaml:"node_vcpu,omitempty"`
+	NodeMemoryGB     float64 `yaml:"node_memory_gb,omitempty"`
+	// NodeCount is the number of nodes that each DaemonSet pod runs on
+	NodeCount int `yaml:"node_count,omitempty"`
+	// StorageClasses maps storage class names to EBS volume types, e.g. standard: gp3
+	StorageClasses  map[string]string `yaml:"storage_classes,omitempty"`
+	HelmValuesFiles []string          `yaml:"helm_values_files,omitempty"`
+	HelmBinary      string            `yaml:"helm_binary,omitempty"`
 }
 
 type Config struct { // nolint:golint
-	Environment *Environment
-	State       *State
-	Credentials Credentials
+	Environment *Environment `yaml:"-"`
+	State       *State       `yaml:"-"`
+	Credentials Credentials  `yaml:"-"`
 
 	Version         string `yaml:"version,omitempty" ignored:"true"`
 	LogLevel        string `yaml:"log_level,omitempty" envconfig:"INFRACOST_LOG_LEVEL"`
 	NoColor         bool   `yaml:"no_color,omitempty" envconfig:"INFRACOST_NO_COLOR"`
 	SkipUpdateCheck bool   `yaml:"skip_update_check,omitempty" envconfig:"INFRACOST_SKIP_UPDATE_CHECK"`
-
-	APIKey                    string `envconfig:"INFRACOST_API_KEY"`
+	// UpdateChannel is the release channel that updates are checked for: stable (the default) or prerelease
+	UpdateChannel string `yaml:"update_channel,omitempty" envconfig:"INFRACOST_UPDATE_CHANNEL"`
+	// Locale is the language of the output messages, e.g. de or fr_FR
+	Locale string `yaml:"locale,omitempty" envconfig:"INFRACOST_LOCALE"`
+	// Deadline is how long a run can take before it stops fetching prices and
+	// outputs the partial results, e.g. 2m
+	Deadline time.Duration `yaml:"deadline,omitempty" envconfig:"INFRACOST_DEADLINE"`
+	// MaxCacheSize caps the size of the dirs that downloads are cached in, e.g. 500MB.
+	// The least recently used entries are removed when it's exceeded
+	MaxCacheSize string `yaml:"max_cache_size,omitempty" envconfig:"INFRACOST_MAX_CACHE_SIZE"`
+
+	APIKey                    string `yaml:"-" envconfig:"INFRACOST_API_KEY"`
 	PricingAPIEndpoint        string `yaml:"pricing_api_endpoint,omitempty" envconfig:"INFRACOST_PRICING_API_ENDPOINT"`
 	DefaultPricingAPIEndpoint string `yaml:"default_pricing_api_endpoint,omitempty" envconfig:"INFRACOST_DEFAULT_PRICING_API_ENDPOINT"`
 	DashboardAPIEndpoint      string `yaml:"dashboard_api_endpoint,omitempty" envconfig:"INFRACOST_DASHBOARD_API_ENDPOINT"`
 
+	// APITimeout is the timeout in seconds of each request to the pricing API, and APIRetries is how many
+	// times requests that fail with network errors, rate limits or server errors are retried
+	APITimeout int  `yaml:"api_timeout,omitempty" envconfig:"INFRACOST_API_TIMEOUT"`
+	APIRetries *int `yaml:"api_retries,omitempty" envconfig:"INFRACOST_API_RETRIES"`
+	// APIRateLimit is the maximum number of requests per second sent to the pricing API, including retries,
+	// e.g. for self-hosted pricing APIs. It isn't limited if it's zero
+	APIRateLimit float64 `yaml:"api_rate_limit,omitempty" envconfig:"INFRACOST_API_RATE_LIMIT"`
+
+	// PricingBackend is the backend used to get prices: graphql (the default), rest, pricebook or fixtures
+	PricingBackend string `yaml:"pricing_backend,omitempty" envconfig:"INFRACOST_PRICING_BACKEND"`
+	PriceBookFile  string `yaml:"price_book_file,omitempty" envconfig:"INFRACOST_PRICE_BOOK_FILE"`
+
+	// RecordFixturesDir is where the pricing queries and their results are recorded so they
+	// can be replayed with the fixtures pricing backend from PricingFixturesDir
+	RecordFixturesDir  string `yaml:"record_fixtures_dir,omitempty" envconfig:"INFRACOST_RECORD_FIXTURES_DIR"`
+	PricingFixturesDir string `yaml:"pricing_fixtures_dir,omitempty" envconfig:"INFRACOST_PRICING_FIXTURES_DIR"`
+
+	// Offline uses a downloaded pricing snapshot instead of the pricing API and doesn't send any telemetry
+	Offline             bool   `yaml:"offline,omitempty" envconfig:"INFRACOST_OFFLINE"`
+	PricingSnapshotFile string `yaml:"pricing_snapshot_file,omitempty" envconfig:"INFRACOST_PRICING_SNAPSHOT_FILE"`
+	// DisableTelemetry stops the usage reports being sent to Infracost. It's
+	// set by the Go package unless the caller opts in to telemetry
+	DisableTelemetry bool `yaml:"-" ignored:"true"`
+
+	// WebhookURL is a comma separated list of URLs that lifecycle events are sent to
+	WebhookURL    string `yaml:"webhook_url,omitempty" envconfig:"INFRACOST_WEBHOOK_URL"`
+	WebhookSecret string `yaml:"-" envconfig:"INFRACOST_WEBHOOK_SECRET"`
+	// EventsStream is the path of a file that lifecycle events are written to, or stderr
+	EventsStream string `yaml:"events_stream,omitempty" envconfig:"INFRACOST_EVENTS_STREAM"`
+
+	// SlackWebhookURL and TeamsWebhookURL are the webhooks that the notify
+	// command posts to when the flags aren't set
+	SlackWebhookURL string `yaml:"-" envconfig:"INFRACOST_SLACK_WEBHOOK_URL"`
+	TeamsWebhookURL string `yaml:"-" envconfig:"INFRACOST_TEAMS_WEBHOOK_URL"`
+
+	// BitbucketToken and AzureReposToken are used by the comment command when
+	// the flags aren't set
+	BitbucketToken  string `yaml:"-" envconfig:"INFRACOST_BITBUCKET_TOKEN"`
+	AzureReposToken string `yaml:"-" envconfig:"INFRACOST_AZURE_REPOS_TOKEN"`
+
+	// RunTaskHMACKey is the HMAC key of the Terraform Cloud run task, used to
+	// verify that run task requests to the server came from Terraform Cloud
+	RunTaskHMACKey string `yaml:"-" envconfig:"INFRACOST_RUN_TASK_HMAC_KEY"`
+
+	// GitHubAppID and the private key are used to authenticate as a GitHub App
+	// installation when posting comments, instead of using a GITHUB_TOKEN
+	GitHubAppID             string `yaml:"github_app_id,omitempty" envconfig:"INFRACOST_GITHUB_APP_ID"`
+	GitHubAppPrivateKey     string `yaml:"-" envconfig:"INFRACOST_GITHUB_APP_PRIVATE_KEY"`
+	GitHubAppPrivateKeyFile string `yaml:"github_app_private_key_file,omitempty" envconfig:"INFRACOST_GITHUB_APP_PRIVATE_KEY_FILE"`
+	GitHubAPIURL            string `yaml:"github_api_url,omitempty" envconfig:"INFRACOST_GITHUB_API_URL"`
+
+	// GitHubWebhookSecret is the secret of the GitHub App's webhook, used to
+	// verify that comment command requests to the server came from GitHub
+	GitHubWebhookSecret string `yaml:"-" envconfig:"INFRACOST_GITHUB_WEBHOOK_SECRET"`
+
+	// GitHubToken is used by the github-check command when the flag isn't set,
+	// otherwise the GitHub App is used
+	GitHubToken string `yaml:"-" envconfig:"INFRACOST_GITHUB_TOKEN"`
+
+	PriceOverridesFile  string `yaml:"price_overrides_file,omitempty" envconfig:"INFRACOST_PRICE_OVERRIDES_FILE"`
+	CostAdjustmentsFile string `yaml:"cost_adjustments_file,omitempty" envconfig:"INFRACOST_COST_ADJUSTMENTS_FILE"`
+	// CustomPricesFile has the prices of resources that aren't in the pricing
+	// API, e.g. the internal chargeback rates of on-prem resources
+	CustomPricesFile string `yaml:"custom_prices_file,omitempty" envconfig:"INFRACOST_CUSTOM_PRICES_FILE"`
+	// PluginsDir is the dir of the plugins that price resources, it defaults
+	// to the plugins dir in the config dir
+	PluginsDir string `yaml:"plugins_dir,omitempty" envconfig:"INFRACOST_PLUGINS_DIR"`
+	// SnapshotStore is the file that the snapshots of the projects' costs are
+	// saved to, it defaults to snapshots.jsonl in the config dir
+	SnapshotStore string `yaml:"snapshot_store,omitempty" envconfig:"INFRACOST_SNAPSHOT_STORE"`
+
+	RoundingMode      string `yaml:"rounding_mode,omitempty" envconfig:"INFRACOST_ROUNDING_MODE"`
+	RoundingLevel     string `yaml:"rounding_level,omitempty" envconfig:"INFRACOST_ROUNDING_LEVEL"`
+	RoundingPrecision *int32 `yaml:"rounding_precision,omitempty" envconfig:"INFRACOST_ROUNDING_PRECISION"`
+
 	Projects      []*Project `yaml:"projects" ignored:"true"`
+	Outputs       []*Output  `yaml:"outputs,omitempty" ignored:"true"`
+	Webhooks      []*Webhook `yaml:"webhooks,omitempty" ignored:"true"`
 	Format        string     `yaml:"format,omitempty" ignored:"true"`
 	ShowSkipped   bool       `yaml:"show_skipped,omitempty" ignored:"true"`
 	SyncUsageFile bool       `yaml:"sync_usage_file,omitempty" ignored:"true"`
 	Fields        []string   `yaml:"fields,omitempty" ignored:"true"`
+	GroupBy       string     `yaml:"group_by,omitempty" ignored:"true"`
+	LockFile      string     `yaml:"lock_file,omitempty" ignored:"true"`
+
+	DiffThresholdAmount  *float64 `yaml:"diff_threshold_amount,omitempty" ignored:"true"`
+	DiffThresholdPercent *float64 `yaml:"diff_threshold_percent,omitempty" ignored:"true"`
+
+	// NoOrgDefaults turns off fetching the organization defaults from the dashboard API
+	NoOrgDefaults bool `yaml:"no_org_defaults,omitempty" envconfig:"INFRACOST_NO_ORG_DEFAULTS"`
+	// UsageDefaults are the organization's usage values, which are used for the
+	// resources that don't have usage in the usage file
+	UsageDefaults map[string]interface{} `yaml:"-" ignored:"true"`
+
+	// MaxUncoveredPercent fails the run if more than this percentage of the resource
+	// types in a project are unsupported or missing usage
+	MaxUncoveredPercent *float64 `yaml:"max_uncovered_percent,omitempty" ignored:"true"`
+
+	// CompareEnvironments is set when the projects are the same project run for each environment
+	CompareEnvironments bool `yaml:"-" ignored:"true"`
+	// CompareRegions are the regions that each project is priced in so their costs can be compared
+	CompareRegions []string `yaml:"-" ignored:"true"`
+	// ShowRecommendations adds recommendations for the resources that are likely to be over-provisioned
+	ShowRecommendations bool `yaml:"-" ignored:"true"`
+	// Verbose prints more details of the run, e.g. the requests made to the pricing API
+	Verbose bool `yaml:"-" ignored:"true"`
 }
 
 func init() {
@@ -69,6 +235,19 @@ func DefaultConfig() *Config {
 	}
 }
 
+// RequiresAPIKey checks if the pricing backend is the default Cloud Pricing API, which requires an API key.
+func (c *Config) RequiresAPIKey() bool {
+	if c.Offline {
+		return false
+	}
+
+	if c.PricingBackend != "" && c.PricingBackend != "graphql" {
+		return false
+	}
+
+	return c.PricingAPIEndpoint == c.DefaultPricingAPIEndpoint
+}
+
 func (c *Config) LoadFromConfigFile(path string) error {
 	cfgFile, err := LoadConfigFile(path)
 	if err != nil {
@@ -76,7 +255,18 @@ func (c *Config) LoadFromConfigFile(path string) error {
 	}
 
 	c.Environment.HasConfigFile = true
-	c.Projects = cfgFile.Projects
+
+	c.Projects = make([]*Project, 0, len(cfgFile.Projects))
+	for _, p := range cfgFile.Projects {
+		if p.Skip {
+			logrus.Infof("Skipping project %s since skip is set", p.Path)
+			continue
+		}
+		c.Projects = append(c.Projects, p)
+	}
+
+	c.Outputs = cfgFile.Outputs
+	c.Webhooks = cfgFile.Webhooks
 
 	// Reload the environment to overwrite any of the config file configs
 	err = c.LoadFromEnv()
@@ -160,6 +350,32 @@ func (c *Config) ConfigureLogger() error {
 	return nil
 }
 
+// RoundingPolicy returns the rounding policy for the costs, or nil if
+// no rounding has been configured, in which case costs aren't rounded.
+func (c *Config) RoundingPolicy() (*schema.RoundingPolicy, error) {
+	if c.RoundingMode == "" && c.RoundingLevel == "" && c.RoundingPrecision == nil {
+		return nil, nil
+	}
+
+	policy := &schema.RoundingPolicy{
+		Mode:      schema.RoundingModeHalfUp,
+		Level:     schema.RoundingLevelComponent,
+		Precision: 2,
+	}
+
+	if c.RoundingMode != "" {
+		policy.Mode = c.RoundingMode
+	}
+	if c.RoundingLevel != "" {
+		policy.Level = c.RoundingLevel
+	}
+	if c.RoundingPrecision != nil {
+		policy.Precision = *c.RoundingPrecision
+	}
+
+	return policy, policy.Validate()
+}
+
 func (c *Config) IsLogging() bool {
 	return c.LogLevel != ""
 }
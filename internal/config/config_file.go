@@ -3,6 +3,9 @@ package config
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
+	"reflect"
+	"regexp"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -16,8 +19,35 @@ const maxConfigFileVersion = "0.1"
 type ConfigFileSpec struct { // nolint:golint
 	Version  string     `yaml:"version"`
 	Projects []*Project `yaml:"projects" ignored:"true"`
+	Outputs  []*Output  `yaml:"outputs,omitempty" ignored:"true"`
+	Webhooks []*Webhook `yaml:"webhooks,omitempty" ignored:"true"`
 }
 
+// Output is an extra output that is written from the same run, e.g. a JSON
+// file and an HTML report uploaded to S3. The output is generated in the
+// format, or from the Go template if one is set. The destination is a file
+// path, an http(s) URL that the output is POSTed to, or an s3://bucket/key URL.
+type Output struct {
+	Format       string            `yaml:"format,omitempty"`
+	Template     string            `yaml:"template,omitempty"`
+	TemplateFile string            `yaml:"template_file,omitempty"`
+	Destination  string            `yaml:"destination"`
+	Headers      map[string]string `yaml:"headers,omitempty"`
+}
+
+// Webhook is an endpoint that the Infracost JSON of every run is POSTed to,
+// e.g. to ingest the estimates into a FinOps warehouse. If the secret is set
+// the body is signed with it, so the endpoint can check where it came from.
+// Failed deliveries are retried, 3 times unless retries is set.
+type Webhook struct {
+	URL     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+	Secret  string            `yaml:"secret,omitempty"`
+	Retries *int              `yaml:"retries,omitempty"`
+}
+
+var validOutputFormats = []string{"json", "table", "html", "diff", "annotations", "focus", "prometheus", "atlantis-comment"}
+
 func LoadConfigFile(path string) (ConfigFileSpec, error) {
 	cfgFile := ConfigFileSpec{}
 
@@ -39,9 +69,212 @@ func LoadConfigFile(path string) (ConfigFileSpec, error) {
 		return cfgFile, fmt.Errorf("Invalid config file version. Supported versions are %s ≤ x ≤ %s", minConfigFileVersion, maxConfigFileVersion)
 	}
 
+	for _, p := range cfgFile.Projects {
+		err = interpolateProject(p)
+		if err == nil {
+			err = checkProject(p)
+		}
+		if err != nil {
+			return cfgFile, err
+		}
+	}
+
+	for i, o := range cfgFile.Outputs {
+		err = checkOutput(o)
+		if err == nil {
+			err = interpolateOutput(o)
+		}
+		if err != nil {
+			return cfgFile, errors.Wrapf(err, "Invalid output %d", i+1)
+		}
+	}
+
+	for i, w := range cfgFile.Webhooks {
+		err = interpolateWebhook(w)
+		if err == nil {
+			err = checkWebhook(w)
+		}
+		if err != nil {
+			return cfgFile, errors.Wrapf(err, "Invalid webhook %d", i+1)
+		}
+	}
+
 	return cfgFile, nil
 }
 
+func checkProject(p *Project) error {
+	if p.MaxUncoveredPercent != nil && *p.MaxUncoveredPercent < 0 {
+		return fmt.Errorf("Invalid max_uncovered_percent of project %s, it must be 0 or more", p.Path)
+	}
+
+	for k := range p.Env {
+		if !envVarNameRegex.MatchString(k) {
+			return fmt.Errorf("Invalid env of project %s, %s is not a valid environment variable name", p.Path, k)
+		}
+	}
+
+	return nil
+}
+
+func checkWebhook(w *Webhook) error {
+	if !strings.HasPrefix(w.URL, "http://") && !strings.HasPrefix(w.URL, "https://") {
+		return errors.New("url must be an http(s) URL")
+	}
+
+	if w.Retries != nil && *w.Retries < 0 {
+		return errors.New("retries must be 0 or more")
+	}
+
+	return nil
+}
+
+func checkOutput(o *Output) error {
+	if o.Destination == "" {
+		return errors.New("destination is required")
+	}
+
+	if o.Template != "" && o.TemplateFile != "" {
+		return errors.New("template and template_file cannot both be set")
+	}
+
+	if o.Template == "" && o.TemplateFile == "" {
+		valid := false
+		for _, f := range validOutputFormats {
+			if strings.ToLower(o.Format) == f {
+				valid = true
+			}
+		}
+
+		if !valid {
+			return fmt.Errorf("format must be one of %s, or a template must be set", strings.Join(validOutputFormats, ", "))
+		}
+	}
+
+	return nil
+}
+
+// interpolateOutput replaces the environment variables in the destination
+// and headers of the output, so tokens don't need to be in the config file.
+func interpolateOutput(o *Output) error {
+	s, err := interpolateEnvVars(o.Destination)
+	if err != nil {
+		return errors.Wrap(err, "Error parsing destination")
+	}
+	o.Destination = s
+
+	for k, v := range o.Headers {
+		s, err := interpolateEnvVars(v)
+		if err != nil {
+			return errors.Wrapf(err, "Error parsing header %s", k)
+		}
+		o.Headers[k] = s
+	}
+
+	return nil
+}
+
+// interpolateWebhook replaces the environment variables in the URL, headers
+// and secret of the webhook, so secrets don't need to be in the config file.
+func interpolateWebhook(w *Webhook) error {
+	s, err := interpolateEnvVars(w.URL)
+	if err != nil {
+		return errors.Wrap(err, "Error parsing url")
+	}
+	w.URL = s
+
+	s, err = interpolateEnvVars(w.Secret)
+	if err != nil {
+		return errors.Wrap(err, "Error parsing secret")
+	}
+	w.Secret = s
+
+	for k, v := range w.Headers {
+		s, err := interpolateEnvVars(v)
+		if err != nil {
+			return errors.Wrapf(err, "Error parsing header %s", k)
+		}
+		w.Headers[k] = s
+	}
+
+	return nil
+}
+
+// envVarRegex matches ${VAR} and ${VAR:-default}. $${VAR} is matched so it can
+// be escaped to a literal ${VAR}.
+var envVarRegex = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+var envVarNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// interpolateProject replaces the environment variables in all the string
+// values of the project. The values are interpolated after the YAML is parsed
+// so values of the variables don't need to be escaped for YAML.
+func interpolateProject(p *Project) error {
+	v := reflect.ValueOf(p).Elem()
+
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		name := strings.SplitN(v.Type().Field(i).Tag.Get("yaml"), ",", 2)[0]
+
+		switch {
+		case f.Kind() == reflect.String:
+			s, err := interpolateEnvVars(f.String())
+			if err != nil {
+				return errors.Wrapf(err, "Error parsing %s of project %s", name, p.Path)
+			}
+			f.SetString(s)
+		case f.Kind() == reflect.Slice && f.Type().Elem().Kind() == reflect.String:
+			for j := 0; j < f.Len(); j++ {
+				s, err := interpolateEnvVars(f.Index(j).String())
+				if err != nil {
+					return errors.Wrapf(err, "Error parsing %s of project %s", name, p.Path)
+				}
+				f.Index(j).SetString(s)
+			}
+		case f.Kind() == reflect.Map && f.Type().Elem().Kind() == reflect.String:
+			iter := f.MapRange()
+			for iter.Next() {
+				s, err := interpolateEnvVars(iter.Value().String())
+				if err != nil {
+					return errors.Wrapf(err, "Error parsing %s of project %s", name, p.Path)
+				}
+				f.SetMapIndex(iter.Key(), reflect.ValueOf(s))
+			}
+		}
+	}
+
+	return nil
+}
+
+func interpolateEnvVars(s string) (string, error) {
+	missing := make([]string, 0)
+
+	result := envVarRegex.ReplaceAllStringFunc(s, func(m string) string {
+		if strings.HasPrefix(m, "$$") {
+			return m[1:]
+		}
+
+		parts := envVarRegex.FindStringSubmatch(m)
+		if val, ok := os.LookupEnv(parts[1]); ok {
+			return val
+		}
+
+		if strings.Contains(m, ":-") {
+			return parts[2]
+		}
+
+		missing = append(missing, parts[1])
+		return m
+	})
+
+	if len(missing) == 1 {
+		return "", fmt.Errorf("environment variable %s is not set", missing[0])
+	} else if len(missing) > 1 {
+		return "", fmt.Errorf("environment variables %s are not set", strings.Join(missing, ", "))
+	}
+
+	return result, nil
+}
+
 func checkVersion(v string) bool {
 	if !strings.HasPrefix(v, "v") {
 		v = "v" + v
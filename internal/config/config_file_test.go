@@ -0,0 +1,220 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfigFileInterpolatesEnvVars(t *testing.T) {
+	os.Setenv("INFRACOST_TEST_ENV", "prod")
+	os.Setenv("INFRACOST_TEST_TOKEN", "secret: with yaml chars")
+	defer os.Unsetenv("INFRACOST_TEST_ENV")
+	defer os.Unsetenv("INFRACOST_TEST_TOKEN")
+
+	path := filepath.Join(t.TempDir(), "infracost.yml")
+	err := os.WriteFile(path, []byte(`version: 0.1
+projects:
+  - path: infra/${INFRACOST_TEST_ENV}
+    terraform_plan_flags: -var-file=${INFRACOST_TEST_ENV}.tfvars -var=literal=$${INFRACOST_TEST_ENV}
+    terraform_cloud_token: ${INFRACOST_TEST_TOKEN}
+    usage_file: ${INFRACOST_TEST_UNSET:-usage.yml}
+`), 0600)
+	require.NoError(t, err)
+
+	cfgFile, err := LoadConfigFile(path)
+	require.NoError(t, err)
+	require.Len(t, cfgFile.Projects, 1)
+
+	p := cfgFile.Projects[0]
+	assert.Equal(t, "infra/prod", p.Path)
+	assert.Equal(t, "-var-file=prod.tfvars -var=literal=${INFRACOST_TEST_ENV}", p.TerraformPlanFlags)
+	assert.Equal(t, "secret: with yaml chars", p.TerraformCloudToken)
+	assert.Equal(t, "usage.yml", p.UsageFile)
+}
+
+func TestLoadConfigFileMissingEnvVar(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "infracost.yml")
+	err := os.WriteFile(path, []byte(`version: 0.1
+projects:
+  - path: infra/${INFRACOST_TEST_UNSET}
+`), 0600)
+	require.NoError(t, err)
+
+	_, err = LoadConfigFile(path)
+	assert.EqualError(t, err, "Error parsing path of project infra/${INFRACOST_TEST_UNSET}: environment variable INFRACOST_TEST_UNSET is not set")
+}
+
+func TestLoadConfigFileNameAndLabels(t *testing.T) {
+	os.Setenv("INFRACOST_TEST_ENV", "prod")
+	defer os.Unsetenv("INFRACOST_TEST_ENV")
+
+	path := filepath.Join(t.TempDir(), "infracost.yml")
+	err := os.WriteFile(path, []byte(`version: 0.1
+projects:
+  - path: infra/live/prod/eu-west-1/api
+    name: api
+    labels:
+      env: ${INFRACOST_TEST_ENV}
+      team: payments
+`), 0600)
+	require.NoError(t, err)
+
+	cfgFile, err := LoadConfigFile(path)
+	require.NoError(t, err)
+
+	p := cfgFile.Projects[0]
+	assert.Equal(t, "api", p.Name)
+	assert.Equal(t, map[string]string{"env": "prod", "team": "payments"}, p.Labels)
+
+	metadata := DetectProjectMetadata(p)
+	assert.Equal(t, p.Labels, metadata.Labels)
+	assert.Equal(t, "api", ProjectName(p, metadata))
+}
+
+func TestLoadConfigFileProjectOptions(t *testing.T) {
+	os.Setenv("INFRACOST_TEST_ENV", "prod")
+	defer os.Unsetenv("INFRACOST_TEST_ENV")
+
+	path := filepath.Join(t.TempDir(), "infracost.yml")
+	err := os.WriteFile(path, []byte(`version: 0.1
+projects:
+  - path: infra/api
+    terraform_vars:
+      environment: ${INFRACOST_TEST_ENV}
+      instance_count: "3"
+    terraform_var_files:
+      - ${INFRACOST_TEST_ENV}.tfvars
+    env:
+      AWS_PROFILE: ${INFRACOST_TEST_ENV}
+    sync_usage_file: false
+    max_uncovered_percent: 20
+  - path: infra/legacy
+    skip: true
+`), 0600)
+	require.NoError(t, err)
+
+	cfgFile, err := LoadConfigFile(path)
+	require.NoError(t, err)
+	require.Len(t, cfgFile.Projects, 2)
+
+	p := cfgFile.Projects[0]
+	assert.Equal(t, map[string]string{"environment": "prod", "instance_count": "3"}, p.TerraformVars)
+	assert.Equal(t, []string{"prod.tfvars"}, p.TerraformVarFiles)
+	assert.Equal(t, map[string]string{"AWS_PROFILE": "prod"}, p.Env)
+	assert.False(t, p.SyncsUsageFile(true))
+	assert.Equal(t, 20.0, *p.MaxUncoveredPercent)
+	assert.False(t, p.Skip)
+
+	assert.True(t, cfgFile.Projects[1].Skip)
+	assert.True(t, cfgFile.Projects[1].SyncsUsageFile(true))
+}
+
+func TestLoadConfigFileInvalidProject(t *testing.T) {
+	tests := []struct {
+		project string
+		err     string
+	}{
+		{"max_uncovered_percent: -1", "Invalid max_uncovered_percent of project infra, it must be 0 or more"},
+		{"env: {AWS-PROFILE: prod}", "Invalid env of project infra, AWS-PROFILE is not a valid environment variable name"},
+	}
+
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "infracost.yml")
+		err := os.WriteFile(path, []byte("version: 0.1\nprojects:\n  - path: infra\n    "+tt.project+"\n"), 0600)
+		require.NoError(t, err)
+
+		_, err = LoadConfigFile(path)
+		assert.EqualError(t, err, tt.err)
+	}
+}
+
+func TestLoadConfigFileOutputs(t *testing.T) {
+	os.Setenv("INFRACOST_TEST_TOKEN", "secret")
+	defer os.Unsetenv("INFRACOST_TEST_TOKEN")
+
+	path := filepath.Join(t.TempDir(), "infracost.yml")
+	err := os.WriteFile(path, []byte(`version: 0.1
+projects:
+  - path: infra
+outputs:
+  - format: json
+    destination: out/infracost.json
+  - template: "{{ .TotalMonthlyCost }}"
+    destination: https://example.com/costs
+    headers:
+      Authorization: Bearer ${INFRACOST_TEST_TOKEN}
+`), 0600)
+	require.NoError(t, err)
+
+	cfgFile, err := LoadConfigFile(path)
+	require.NoError(t, err)
+	require.Len(t, cfgFile.Outputs, 2)
+
+	assert.Equal(t, "out/infracost.json", cfgFile.Outputs[0].Destination)
+	assert.Equal(t, "Bearer secret", cfgFile.Outputs[1].Headers["Authorization"])
+}
+
+func TestLoadConfigFileInvalidOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "infracost.yml")
+	err := os.WriteFile(path, []byte(`version: 0.1
+projects:
+  - path: infra
+outputs:
+  - format: json
+    destination: out/infracost.json
+  - format: pdf
+    destination: out/infracost.pdf
+`), 0600)
+	require.NoError(t, err)
+
+	_, err = LoadConfigFile(path)
+	assert.EqualError(t, err, "Invalid output 2: format must be one of json, table, html, diff, annotations, focus, prometheus, atlantis-comment, or a template must be set")
+}
+
+func TestLoadConfigFileWebhooks(t *testing.T) {
+	os.Setenv("INFRACOST_TEST_TOKEN", "secret")
+	defer os.Unsetenv("INFRACOST_TEST_TOKEN")
+
+	path := filepath.Join(t.TempDir(), "infracost.yml")
+	err := os.WriteFile(path, []byte(`version: 0.1
+projects:
+  - path: infra
+webhooks:
+  - url: https://finops.example.com/ingest
+    secret: ${INFRACOST_TEST_TOKEN}
+    headers:
+      X-Team: platform
+    retries: 5
+  - url: ftp://finops.example.com/ingest
+`), 0600)
+	require.NoError(t, err)
+
+	_, err = LoadConfigFile(path)
+	assert.EqualError(t, err, "Invalid webhook 2: url must be an http(s) URL")
+
+	err = os.WriteFile(path, []byte(`version: 0.1
+projects:
+  - path: infra
+webhooks:
+  - url: https://finops.example.com/ingest
+    secret: ${INFRACOST_TEST_TOKEN}
+    headers:
+      X-Team: platform
+    retries: 5
+`), 0600)
+	require.NoError(t, err)
+
+	cfgFile, err := LoadConfigFile(path)
+	require.NoError(t, err)
+	require.Len(t, cfgFile.Webhooks, 1)
+
+	w := cfgFile.Webhooks[0]
+	assert.Equal(t, "https://finops.example.com/ingest", w.URL)
+	assert.Equal(t, "secret", w.Secret)
+	assert.Equal(t, "platform", w.Headers["X-Team"])
+	assert.Equal(t, 5, *w.Retries)
+}
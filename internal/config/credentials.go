@@ -77,3 +77,23 @@ func writeCredentialsFile(c Credentials) error {
 func CredentialsFilePath() string { // nolint:golint
 	return path.Join(userConfigDir(), "credentials.yml")
 }
+
+// GitHubAppTokenCacheFilePath is where GitHub App installation tokens are cached until they expire.
+func GitHubAppTokenCacheFilePath() string {
+	return path.Join(userConfigDir(), ".github_app_tokens.json")
+}
+
+// PluginsDirPath is the default dir of the plugins that price resources.
+func PluginsDirPath() string {
+	return path.Join(userConfigDir(), "plugins")
+}
+
+// SnapshotStoreFilePath is the default path of the store of cost snapshots.
+func SnapshotStoreFilePath() string {
+	return path.Join(userConfigDir(), "snapshots.jsonl")
+}
+
+// PricingSnapshotFilePath is the default path of the pricing snapshot used in offline mode.
+func PricingSnapshotFilePath() string {
+	return path.Join(userConfigDir(), "pricing-snapshot.json")
+}
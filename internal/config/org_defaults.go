@@ -0,0 +1,108 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// orgDefaultsTimeout is how long to wait for the dashboard API, so an
+// unavailable dashboard doesn't hold up the run.
+const orgDefaultsTimeout = 10 * time.Second
+
+// OrgDefaults are the settings that an organization's platform team sets in
+// the dashboard. They're merged beneath the local config, so any setting from
+// the config file, environment variables or flags overrides them.
+type OrgDefaults struct {
+	DiffThresholdAmount  *float64 `json:"diffThresholdAmount,omitempty"`
+	DiffThresholdPercent *float64 `json:"diffThresholdPercent,omitempty"`
+	MaxUncoveredPercent  *float64 `json:"maxUncoveredPercent,omitempty"`
+
+	RoundingMode      string `json:"roundingMode,omitempty"`
+	RoundingLevel     string `json:"roundingLevel,omitempty"`
+	RoundingPrecision *int32 `json:"roundingPrecision,omitempty"`
+
+	// UsageDefaults has the same format as the resource_usage of a usage file,
+	// so wildcards can be used to set the usage for all resources of a type,
+	// e.g. aws_lambda_function.*
+	UsageDefaults map[string]interface{} `json:"usageDefaults,omitempty"`
+}
+
+// OrgDefaultsEnabled checks if the organization defaults should be fetched.
+// They're only fetched for the default Cloud Pricing API, since that's the
+// API key that the dashboard knows about.
+func (c *Config) OrgDefaultsEnabled() bool {
+	return !c.NoOrgDefaults && !c.Offline && c.APIKey != "" && c.PricingAPIEndpoint == c.DefaultPricingAPIEndpoint
+}
+
+// FetchOrgDefaults gets the defaults of the API key's organization from the
+// dashboard API.
+func FetchOrgDefaults(endpoint string, apiKey string) (*OrgDefaults, error) {
+	url := fmt.Sprintf("%s/orgDefaults", endpoint)
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error generating organization defaults request")
+	}
+
+	AddAuthHeaders(apiKey, req)
+
+	client := http.Client{Timeout: orgDefaultsTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error sending organization defaults request")
+	}
+	defer resp.Body.Close()
+
+	// Organizations without any defaults don't need to be treated as errors
+	if resp.StatusCode == http.StatusNotFound {
+		return &OrgDefaults{}, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Unexpected response getting organization defaults: %d", resp.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid response from API")
+	}
+
+	var d OrgDefaults
+	err = json.Unmarshal(body, &d)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid response from API")
+	}
+
+	return &d, nil
+}
+
+// ApplyOrgDefaults sets the settings that haven't been set locally to the
+// organization defaults.
+func (c *Config) ApplyOrgDefaults(d *OrgDefaults) {
+	if c.DiffThresholdAmount == nil {
+		c.DiffThresholdAmount = d.DiffThresholdAmount
+	}
+	if c.DiffThresholdPercent == nil {
+		c.DiffThresholdPercent = d.DiffThresholdPercent
+	}
+	if c.MaxUncoveredPercent == nil {
+		c.MaxUncoveredPercent = d.MaxUncoveredPercent
+	}
+
+	if c.RoundingMode == "" {
+		c.RoundingMode = d.RoundingMode
+	}
+	if c.RoundingLevel == "" {
+		c.RoundingLevel = d.RoundingLevel
+	}
+	if c.RoundingPrecision == nil {
+		c.RoundingPrecision = d.RoundingPrecision
+	}
+
+	c.UsageDefaults = d.UsageDefaults
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchOrgDefaults(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/orgDefaults", r.URL.Path)
+		assert.Equal(t, "my-key", r.Header.Get("X-Api-Key"))
+
+		_, _ = w.Write([]byte(`{
+			"diffThresholdPercent": 5,
+			"roundingMode": "up",
+			"usageDefaults": {"aws_lambda_function.*": {"monthly_requests": 1000}}
+		}`))
+	}))
+	defer ts.Close()
+
+	d, err := FetchOrgDefaults(ts.URL, "my-key")
+	require.NoError(t, err)
+
+	assert.Nil(t, d.DiffThresholdAmount)
+	require.NotNil(t, d.DiffThresholdPercent)
+	assert.Equal(t, 5.0, *d.DiffThresholdPercent)
+	assert.Equal(t, "up", d.RoundingMode)
+	assert.Contains(t, d.UsageDefaults, "aws_lambda_function.*")
+}
+
+func TestFetchOrgDefaultsNotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	d, err := FetchOrgDefaults(ts.URL, "my-key")
+	require.NoError(t, err)
+	assert.Equal(t, &OrgDefaults{}, d)
+}
+
+func TestFetchOrgDefaultsError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	_, err := FetchOrgDefaults(ts.URL, "my-key")
+	assert.Error(t, err)
+}
+
+func TestApplyOrgDefaultsKeepsLocalConfig(t *testing.T) {
+	amount := 100.0
+	orgAmount := 50.0
+	orgPercent := 10.0
+	precision := int32(0)
+
+	c := &Config{
+		DiffThresholdAmount: &amount,
+		RoundingMode:        "down",
+	}
+	c.ApplyOrgDefaults(&OrgDefaults{
+		DiffThresholdAmount:  &orgAmount,
+		DiffThresholdPercent: &orgPercent,
+		RoundingMode:         "up",
+		RoundingPrecision:    &precision,
+	})
+
+	assert.Equal(t, 100.0, *c.DiffThresholdAmount)
+	assert.Equal(t, 10.0, *c.DiffThresholdPercent)
+	assert.Nil(t, c.MaxUncoveredPercent)
+	assert.Equal(t, "down", c.RoundingMode)
+	assert.Equal(t, int32(0), *c.RoundingPrecision)
+}
+
+func TestOrgDefaultsEnabled(t *testing.T) {
+	c := DefaultConfig()
+	assert.False(t, c.OrgDefaultsEnabled())
+
+	c.APIKey = "my-key"
+	assert.True(t, c.OrgDefaultsEnabled())
+
+	c.PricingAPIEndpoint = "http://localhost:4000"
+	assert.False(t, c.OrgDefaultsEnabled())
+}
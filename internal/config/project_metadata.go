@@ -10,12 +10,26 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// DetectProjectMetadata returns the metadata of the project. The git and CI
+// details are read from the INFRACOST_VCS_* environment variables, then from
+// the environment variables of the CI platform and finally from the git repo
+// of the project's path.
 func DetectProjectMetadata(projectCfg *Project) *schema.ProjectMetadata {
+	ci := detectCIMetadata()
+
 	vcsRepoURL := os.Getenv("INFRACOST_VCS_REPOSITORY_URL")
 	vcsSubPath := os.Getenv("INFRACOST_VCS_SUB_PATH")
+	vcsBranch := os.Getenv("INFRACOST_VCS_BRANCH")
+	vcsCommitSHA := os.Getenv("INFRACOST_VCS_COMMIT_SHA")
 	vcsPullRequestURL := os.Getenv("INFRACOST_VCS_PULL_REQUEST_URL")
+	vcsPullRequestNumber := os.Getenv("INFRACOST_VCS_PULL_REQUEST_NUMBER")
+	ciPipelineID := os.Getenv("INFRACOST_CI_PIPELINE_ID")
 	terraformWorkspace := os.Getenv("INFRACOST_TERRAFORM_WORKSPACE")
 
+	if vcsRepoURL == "" {
+		vcsRepoURL = ci.RepoURL
+	}
+
 	if vcsRepoURL == "" {
 		vcsRepoURL = gitRepo(projectCfg.Path)
 	}
@@ -24,15 +38,54 @@ func DetectProjectMetadata(projectCfg *Project) *schema.ProjectMetadata {
 		vcsSubPath = gitSubPath(projectCfg.Path)
 	}
 
+	if vcsBranch == "" {
+		vcsBranch = ci.Branch
+	}
+
+	if vcsBranch == "" && vcsRepoURL != "" {
+		vcsBranch = gitBranch(projectCfg.Path)
+	}
+
+	if vcsCommitSHA == "" {
+		vcsCommitSHA = ci.CommitSHA
+	}
+
+	if vcsCommitSHA == "" && vcsRepoURL != "" {
+		vcsCommitSHA = gitCommitSHA(projectCfg.Path)
+	}
+
+	if vcsPullRequestNumber == "" {
+		vcsPullRequestNumber = ci.PullRequestNumber
+	}
+
+	if ciPipelineID == "" {
+		ciPipelineID = ci.PipelineID
+	}
+
 	return &schema.ProjectMetadata{
-		Path:               projectCfg.Path,
-		VCSRepoURL:         vcsRepoURL,
-		VCSSubPath:         vcsSubPath,
-		VCSPullRequestURL:  vcsPullRequestURL,
-		TerraformWorkspace: terraformWorkspace,
+		Path:                 projectCfg.Path,
+		VCSRepoURL:           vcsRepoURL,
+		VCSSubPath:           vcsSubPath,
+		VCSBranch:            vcsBranch,
+		VCSCommitSHA:         vcsCommitSHA,
+		VCSPullRequestURL:    vcsPullRequestURL,
+		VCSPullRequestNumber: vcsPullRequestNumber,
+		CIPlatform:           ci.Platform,
+		CIPipelineID:         ciPipelineID,
+		TerraformWorkspace:   terraformWorkspace,
+		Labels:               projectCfg.Labels,
 	}
 }
 
+// ProjectName returns the name of the project from the config, or generates
+// one from the metadata if it isn't set.
+func ProjectName(projectCfg *Project, metadata *schema.ProjectMetadata) string {
+	if projectCfg.Name != "" {
+		return projectCfg.Name
+	}
+	return schema.GenerateProjectName(metadata)
+}
+
 func gitRepo(path string) string {
 	log.Debugf("Checking if %s is a git repo", path)
 	cmd := exec.Command("git", "ls-remote", "--get-url")
@@ -74,7 +127,33 @@ func gitSubPath(path string) string {
 }
 
 func gitToplevel(path string) (string, error) {
-	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	return gitRevParse(path, "--show-toplevel")
+}
+
+// gitBranch returns the current branch, or an empty string if HEAD is
+// detached as it usually is in CI.
+func gitBranch(path string) string {
+	branch, err := gitRevParse(path, "--abbrev-ref", "HEAD")
+	if err != nil || branch == "HEAD" {
+		log.Debugf("Could not get the git branch of %s", path)
+		return ""
+	}
+
+	return branch
+}
+
+func gitCommitSHA(path string) string {
+	sha, err := gitRevParse(path, "HEAD")
+	if err != nil {
+		log.Debugf("Could not get the git commit of %s", path)
+		return ""
+	}
+
+	return sha
+}
+
+func gitRevParse(path string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"rev-parse"}, args...)...)
 
 	if isDir(path) {
 		cmd.Dir = path
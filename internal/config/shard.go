@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Shard is one of a number of parallel CI jobs that the projects are split
+// across, e.g. 2/4 is the second of four jobs.
+type Shard struct {
+	Index int
+	Count int
+}
+
+// ParseShard parses a shard in the format i/n, where i is from 1 to n.
+func ParseShard(s string) (Shard, error) {
+	parts := strings.SplitN(s, "/", 2)
+	if len(parts) != 2 {
+		return Shard{}, fmt.Errorf("Invalid shard %s, expected the format i/n, e.g. 1/4", s)
+	}
+
+	index, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return Shard{}, fmt.Errorf("Invalid shard %s, expected the format i/n, e.g. 1/4", s)
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return Shard{}, fmt.Errorf("Invalid shard %s, expected the format i/n, e.g. 1/4", s)
+	}
+
+	if count < 1 {
+		return Shard{}, fmt.Errorf("Invalid shard %s, the number of shards must be at least 1", s)
+	}
+
+	if index < 1 || index > count {
+		return Shard{}, fmt.Errorf("Invalid shard %s, the index must be between 1 and %d", s, count)
+	}
+
+	return Shard{Index: index, Count: count}, nil
+}
+
+func (s Shard) String() string {
+	return fmt.Sprintf("%d/%d", s.Index, s.Count)
+}
+
+// ShardProjects returns the projects that belong to the shard. The projects
+// are sorted by their path, workspace and name and dealt to the shards in
+// turn, so every job gets the same split regardless of the order the
+// projects are configured in, and the shards are balanced.
+func ShardProjects(projects []*Project, shard Shard) []*Project {
+	sorted := make([]*Project, len(projects))
+	copy(sorted, projects)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return shardKey(sorted[i]) < shardKey(sorted[j])
+	})
+
+	sharded := make([]*Project, 0, len(projects)/shard.Count+1)
+	for i, p := range sorted {
+		if i%shard.Count == shard.Index-1 {
+			sharded = append(sharded, p)
+		}
+	}
+
+	return sharded
+}
+
+func shardKey(p *Project) string {
+	return strings.Join([]string{
+		p.Path,
+		p.TerraformWorkspace,
+		p.TerraformCloudOrg,
+		p.TerraformCloudWorkspace,
+		p.Name,
+	}, "\x00")
+}
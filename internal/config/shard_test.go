@@ -0,0 +1,59 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseShard(t *testing.T) {
+	s, err := ParseShard("2/4")
+	require.NoError(t, err)
+	assert.Equal(t, Shard{Index: 2, Count: 4}, s)
+	assert.Equal(t, "2/4", s.String())
+
+	for _, invalid := range []string{"", "2", "0/4", "5/4", "a/4", "1/0", "1/-2"} {
+		_, err := ParseShard(invalid)
+		assert.Error(t, err, invalid)
+	}
+
+	_, err = ParseShard("0/0")
+	assert.EqualError(t, err, "Invalid shard 0/0, the number of shards must be at least 1")
+
+	_, err = ParseShard("5/4")
+	assert.EqualError(t, err, "Invalid shard 5/4, the index must be between 1 and 4")
+}
+
+func TestShardProjects(t *testing.T) {
+	projects := []*Project{
+		{Path: "e"},
+		{Path: "b"},
+		{Path: "a", TerraformWorkspace: "prod"},
+		{Path: "d"},
+		{Path: "a", TerraformWorkspace: "dev"},
+		{Path: "c"},
+		{Path: "f"},
+	}
+
+	paths := func(ps []*Project) []string {
+		s := make([]string, 0, len(ps))
+		for _, p := range ps {
+			s = append(s, p.Path+":"+p.TerraformWorkspace)
+		}
+		return s
+	}
+
+	assert.Equal(t, []string{"a:dev", "c:", "f:"}, paths(ShardProjects(projects, Shard{Index: 1, Count: 3})))
+	assert.Equal(t, []string{"a:prod", "d:"}, paths(ShardProjects(projects, Shard{Index: 2, Count: 3})))
+	assert.Equal(t, []string{"b:", "e:"}, paths(ShardProjects(projects, Shard{Index: 3, Count: 3})))
+
+	// The split doesn't depend on the order the projects are configured in
+	reversed := make([]*Project, 0, len(projects))
+	for i := len(projects) - 1; i >= 0; i-- {
+		reversed = append(reversed, projects[i])
+	}
+	assert.Equal(t, []string{"a:prod", "d:"}, paths(ShardProjects(reversed, Shard{Index: 2, Count: 3})))
+
+	assert.Len(t, ShardProjects(projects, Shard{Index: 1, Count: 1}), len(projects))
+}
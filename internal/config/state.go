@@ -13,6 +13,7 @@ type State struct {
 	InstallID              string `json:"installId"`
 	LatestReleaseVersion   string `json:"latestReleaseVersion"`
 	LatestReleaseCheckedAt string `json:"latestReleaseCheckedAt"`
+	LatestReleaseChannel   string `json:"latestReleaseChannel,omitempty"`
 }
 
 func loadState(cfg *Config) error {
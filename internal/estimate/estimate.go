@@ -0,0 +1,164 @@
+// Package estimate calculates the costs of projects so the same steps can be
+// used by the CLI commands and by other ways of running Infracost, like the HTTP server.
+package estimate
+
+import (
+	"context"
+	"time"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/plugins"
+	"github.com/infracost/infracost/internal/prices"
+	"github.com/infracost/infracost/internal/providers"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/providers/terraform/saas"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage"
+)
+
+// CostOptions are the options from the config used to calculate the costs of a project.
+type CostOptions struct {
+	PriceOverrides  []*prices.PriceOverride
+	CostAdjustments []*schema.CostAdjustment
+	RoundingPolicy  *schema.RoundingPolicy
+	// OnResourcePriced is called after each resource has been priced so the
+	// progress can be shown, it's called from multiple goroutines.
+	OnResourcePriced func()
+	// Deadline is when to stop fetching prices, the remaining resources are
+	// marked as unpriced. It isn't used if it's zero.
+	Deadline time.Time
+	// PricingSession caches the pricing queries of the projects and tracks
+	// the requests made to the pricing API.
+	PricingSession *prices.Session
+}
+
+func LoadCostOptions(cfg *config.Config) (CostOptions, error) {
+	var opts CostOptions
+	var err error
+
+	opts.RoundingPolicy, err = cfg.RoundingPolicy()
+	if err != nil {
+		return opts, err
+	}
+
+	opts.PriceOverrides, err = prices.LoadPriceOverridesFromFile(cfg.PriceOverridesFile)
+	if err != nil {
+		return opts, err
+	}
+
+	opts.CostAdjustments, err = schema.LoadCostAdjustmentsFromFile(cfg.CostAdjustmentsFile)
+	if err != nil {
+		return opts, err
+	}
+
+	opts.PricingSession = prices.NewSession(cfg)
+
+	return opts, nil
+}
+
+// LoadPlugins adds the resources that are priced by the plugins in the plugins
+// dir to the registry so they're priced when the projects are loaded.
+func LoadPlugins(cfg *config.Config) error {
+	dir := cfg.PluginsDir
+	if dir == "" {
+		dir = config.PluginsDirPath()
+	}
+
+	ps, err := plugins.Discover(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range ps {
+		terraform.AddResources(p.RegistryItems())
+	}
+
+	return nil
+}
+
+// LoadCustomPrices adds the resources of the custom prices file to the
+// registry so they're priced when the projects are loaded.
+func LoadCustomPrices(cfg *config.Config) error {
+	items, err := saas.LoadCustomPricesFromFile(cfg.CustomPricesFile)
+	if err != nil {
+		return err
+	}
+
+	terraform.AddResources(items)
+
+	return nil
+}
+
+// CalculateCosts gets the prices of the project's resources, then calculates
+// their costs and the diff between the past and planned resources.
+func CalculateCosts(ctx context.Context, cfg *config.Config, project *schema.Project, opts CostOptions) error {
+	err := prices.PopulatePrices(ctx, cfg, project, opts.PricingSession, opts.OnResourcePriced, opts.Deadline)
+	if err != nil {
+		return err
+	}
+
+	prices.ApplyPriceOverrides(project, opts.PriceOverrides)
+	schema.ApplyCostAdjustments(project, opts.CostAdjustments)
+	schema.CalculateCosts(project)
+	schema.RoundCosts(project, opts.RoundingPolicy)
+	project.CalculateDiff()
+
+	return nil
+}
+
+// PlanJSONProject creates a project from Terraform plan JSON. If hasDiff is
+// false then only the planned resources are included.
+func PlanJSONProject(cfg *config.Config, name string, planJSON []byte, usage map[string]*schema.UsageData, hasDiff bool) (*schema.Project, error) {
+	metadata := &schema.ProjectMetadata{
+		Path: name,
+		Type: "terraform_plan_json",
+	}
+
+	project := schema.NewProject(name, metadata)
+	project.HasDiff = hasDiff
+
+	err := terraform.LoadPlanJSONResources(cfg.Environment, project, planJSON, usage)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// LoadProject detects the type of the project path and loads its resources,
+// using the project's usage file if it has one. Unlike the CLI it doesn't
+// print any messages or sync the usage file.
+func LoadProject(cfg *config.Config, projectCfg *config.Project) (*schema.Project, error) {
+	provider, err := providers.Detect(cfg, projectCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if installer, ok := provider.(terraform.TerraformInstaller); ok {
+		projectCfg.TerraformBinary, err = installer.InstallTerraformIfMissing()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	u := map[string]*schema.UsageData{}
+	if projectCfg.UsageFile != "" {
+		u, err = usage.LoadFromFile(projectCfg.UsageFile, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	metadata := config.DetectProjectMetadata(projectCfg)
+	metadata.Type = provider.Type()
+	provider.AddMetadata(metadata)
+
+	project := schema.NewProject(config.ProjectName(projectCfg, metadata), metadata)
+
+	err = provider.LoadResources(project, u)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
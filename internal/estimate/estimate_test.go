@@ -0,0 +1,118 @@
+package estimate
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPlanJSON = []byte(`{
+  "format_version": "0.1",
+  "planned_values": {
+    "root_module": {
+      "resources": [
+        {
+          "address": "aws_instance.web",
+          "mode": "managed",
+          "type": "aws_instance",
+          "name": "web",
+          "provider_name": "registry.terraform.io/hashicorp/aws",
+          "values": {"instance_type": "t3.micro"}
+        }
+      ]
+    }
+  },
+  "configuration": {
+    "provider_config": {
+      "aws": {"name": "aws", "expressions": {"region": {"constant_value": "us-east-1"}}}
+    }
+  }
+}`)
+
+func TestPlanJSONProject(t *testing.T) {
+	cfg := config.DefaultConfig()
+
+	project, err := PlanJSONProject(cfg, "test", testPlanJSON, nil, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, "test", project.Name)
+	assert.False(t, project.HasDiff)
+	require.Len(t, project.Resources, 1)
+	assert.Equal(t, "aws_instance.web", project.Resources[0].Name)
+
+	_, err = PlanJSONProject(cfg, "test", []byte(`not json`), nil, false)
+	assert.Error(t, err)
+}
+
+func TestCalculateCosts(t *testing.T) {
+	priceBookFile := filepath.Join(t.TempDir(), "pricebook.json")
+	require.NoError(t, ioutil.WriteFile(priceBookFile, []byte(`{"products": []}`), 0600))
+
+	cfg := config.DefaultConfig()
+	cfg.PricingBackend = "pricebook"
+	cfg.PriceBookFile = priceBookFile
+
+	project, err := PlanJSONProject(cfg, "test", testPlanJSON, nil, true)
+	require.NoError(t, err)
+
+	opts, err := LoadCostOptions(cfg)
+	require.NoError(t, err)
+
+	err = CalculateCosts(context.Background(), cfg, project, opts)
+	require.NoError(t, err)
+
+	require.Len(t, project.Diff, 1)
+	assert.Equal(t, "aws_instance.web", project.Diff[0].Name)
+	assert.Equal(t, "730", project.Resources[0].CostComponents[0].MonthlyQuantity.String())
+}
+
+func TestLoadCustomPrices(t *testing.T) {
+	customPricesFile := filepath.Join(t.TempDir(), "custom-prices.yml")
+	require.NoError(t, ioutil.WriteFile(customPricesFile, []byte(`
+version: 0.1
+resources:
+  vsphere_virtual_machine:
+    cost_components:
+      - name: vCPU
+        unit: vCPU
+        monthly_price: "12"
+        quantity_attribute: num_cpus
+`), 0600))
+
+	cfg := config.DefaultConfig()
+	cfg.CustomPricesFile = customPricesFile
+
+	require.NoError(t, LoadCustomPrices(cfg))
+	assert.True(t, terraform.HasSupportedProvider("vsphere_virtual_machine"))
+
+	planJSON := []byte(`{
+  "format_version": "0.1",
+  "planned_values": {
+    "root_module": {
+      "resources": [
+        {
+          "address": "vsphere_virtual_machine.vm",
+          "mode": "managed",
+          "type": "vsphere_virtual_machine",
+          "name": "vm",
+          "provider_name": "registry.terraform.io/hashicorp/vsphere",
+          "values": {"num_cpus": 2}
+        }
+      ]
+    }
+  }
+}`)
+
+	project, err := PlanJSONProject(cfg, "test", planJSON, nil, false)
+	require.NoError(t, err)
+
+	require.Len(t, project.Resources, 1)
+	require.Len(t, project.Resources[0].CostComponents, 1)
+	assert.Equal(t, "2", project.Resources[0].CostComponents[0].MonthlyQuantity.String())
+}
@@ -0,0 +1,254 @@
+package events
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+)
+
+// LifecycleSchemaVersion is the version of the lifecycle event schema. It
+// should be bumped if any fields are removed or changed, adding fields is fine.
+const LifecycleSchemaVersion = "1.0"
+
+const (
+	RunStarted       = "run.started"
+	ProjectEstimated = "project.estimated"
+	RunCompleted     = "run.completed"
+	PolicyViolated   = "policy.violated"
+)
+
+const (
+	RunStatusSuccess = "success"
+	RunStatusFailed  = "failed"
+)
+
+var webhookTimeout = 10 * time.Second
+
+// SignatureHeader is the header of the webhook requests that has the HMAC
+// SHA256 signature of the body, when the webhook has a secret.
+const SignatureHeader = "X-Infracost-Signature"
+
+// Signature returns the value of the signature header of the body.
+func Signature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type LifecycleEvent struct {
+	SchemaVersion string      `json:"schemaVersion"`
+	Type          string      `json:"type"`
+	RunID         string      `json:"runId"`
+	Timestamp     time.Time   `json:"timestamp"`
+	Data          interface{} `json:"data"`
+}
+
+type RunStartedData struct {
+	Command      string   `json:"command"`
+	ProjectPaths []string `json:"projectPaths"`
+}
+
+type ProjectEstimatedData struct {
+	Name                 string           `json:"name"`
+	Path                 string           `json:"path"`
+	TotalMonthlyCost     *decimal.Decimal `json:"totalMonthlyCost"`
+	PastTotalMonthlyCost *decimal.Decimal `json:"pastTotalMonthlyCost,omitempty"`
+	DiffTotalMonthlyCost *decimal.Decimal `json:"diffTotalMonthlyCost,omitempty"`
+}
+
+type RunCompletedData struct {
+	Status           string           `json:"status"`
+	Error            string           `json:"error,omitempty"`
+	ProjectCount     int              `json:"projectCount"`
+	TotalMonthlyCost *decimal.Decimal `json:"totalMonthlyCost"`
+	DurationSeconds  float64          `json:"durationSeconds"`
+}
+
+type PolicyViolatedData struct {
+	Policy  string `json:"policy"`
+	Message string `json:"message"`
+}
+
+// LifecycleEmitter sends the lifecycle events of a run to the configured
+// webhooks and writes them to the events stream as newline-delimited JSON.
+// Failing to send an event never fails the run.
+type LifecycleEmitter struct {
+	runID       string
+	webhookURLs []string
+	secret      string
+	stream      io.Writer
+	startTime   time.Time
+
+	mu               sync.Mutex
+	projectCount     int
+	totalMonthlyCost *decimal.Decimal
+}
+
+func NewLifecycleEmitter(cfg *config.Config) *LifecycleEmitter {
+	e := &LifecycleEmitter{
+		runID:     newRunID(),
+		secret:    cfg.WebhookSecret,
+		startTime: time.Now(),
+	}
+
+	for _, u := range strings.Split(cfg.WebhookURL, ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			e.webhookURLs = append(e.webhookURLs, u)
+		}
+	}
+
+	switch cfg.EventsStream {
+	case "":
+	case "stderr":
+		e.stream = os.Stderr
+	default:
+		f, err := os.OpenFile(cfg.EventsStream, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			log.Warnf("Unable to open events stream %s: %v", cfg.EventsStream, err)
+			break
+		}
+		e.stream = f
+	}
+
+	return e
+}
+
+func newRunID() string {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+func (e *LifecycleEmitter) RunID() string {
+	return e.runID
+}
+
+func (e *LifecycleEmitter) RunStarted(command string, projects []*config.Project) {
+	paths := make([]string, 0, len(projects))
+	for _, p := range projects {
+		paths = append(paths, p.Path)
+	}
+
+	e.Emit(RunStarted, RunStartedData{
+		Command:      command,
+		ProjectPaths: paths,
+	})
+}
+
+func (e *LifecycleEmitter) ProjectEstimated(data ProjectEstimatedData) {
+	e.mu.Lock()
+	e.projectCount++
+	if data.TotalMonthlyCost != nil {
+		total := *data.TotalMonthlyCost
+		if e.totalMonthlyCost != nil {
+			total = total.Add(*e.totalMonthlyCost)
+		}
+		e.totalMonthlyCost = &total
+	}
+	e.mu.Unlock()
+
+	e.Emit(ProjectEstimated, data)
+}
+
+func (e *LifecycleEmitter) PolicyViolated(policy string, message string) {
+	e.Emit(PolicyViolated, PolicyViolatedData{
+		Policy:  policy,
+		Message: message,
+	})
+}
+
+// RunCompleted sends the run.completed event, including the totals of the
+// projects that have been estimated.
+func (e *LifecycleEmitter) RunCompleted(err error) {
+	e.mu.Lock()
+	data := RunCompletedData{
+		Status:           RunStatusSuccess,
+		ProjectCount:     e.projectCount,
+		TotalMonthlyCost: e.totalMonthlyCost,
+		DurationSeconds:  time.Since(e.startTime).Seconds(),
+	}
+	e.mu.Unlock()
+
+	if err != nil {
+		data.Status = RunStatusFailed
+		data.Error = err.Error()
+	}
+
+	e.Emit(RunCompleted, data)
+
+	if c, ok := e.stream.(io.Closer); ok && e.stream != os.Stderr {
+		_ = c.Close()
+	}
+}
+
+func (e *LifecycleEmitter) Emit(eventType string, data interface{}) {
+	if e == nil || (len(e.webhookURLs) == 0 && e.stream == nil) {
+		return
+	}
+
+	body, err := json.Marshal(LifecycleEvent{
+		SchemaVersion: LifecycleSchemaVersion,
+		Type:          eventType,
+		RunID:         e.runID,
+		Timestamp:     time.Now().UTC(),
+		Data:          data,
+	})
+	if err != nil {
+		log.Debugf("Unable to generate %s event: %v", eventType, err)
+		return
+	}
+
+	if e.stream != nil {
+		_, err = e.stream.Write(append(body, '\n'))
+		if err != nil {
+			log.Debugf("Unable to write %s event: %v", eventType, err)
+		}
+	}
+
+	for _, u := range e.webhookURLs {
+		e.sendWebhook(u, eventType, body)
+	}
+}
+
+func (e *LifecycleEmitter) sendWebhook(url string, eventType string, body []byte) {
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(body))
+	if err != nil {
+		log.Debugf("Unable to generate %s webhook: %v", eventType, err)
+		return
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Infracost-Event", eventType)
+	if e.secret != "" {
+		req.Header.Set(SignatureHeader, Signature(e.secret, body))
+	}
+
+	client := &http.Client{Timeout: webhookTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		log.Warnf("Unable to send %s webhook to %s: %v", eventType, url, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		log.Warnf("Unexpected response sending %s webhook to %s: %d", eventType, url, resp.StatusCode)
+	}
+}
@@ -0,0 +1,66 @@
+package events
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLifecycleEmitter(t *testing.T) {
+	received := make([]string, 0)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		mac := hmac.New(sha256.New, []byte("secret"))
+		_, _ = mac.Write(body)
+		assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), r.Header.Get("X-Infracost-Signature"))
+
+		received = append(received, r.Header.Get("X-Infracost-Event"))
+	}))
+	defer ts.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.WebhookURL = ts.URL
+	cfg.WebhookSecret = "secret"
+
+	var stream bytes.Buffer
+	e := NewLifecycleEmitter(cfg)
+	e.stream = &stream
+
+	cost := decimal.NewFromInt(100)
+	e.RunStarted("breakdown", []*config.Project{{Path: "examples/terraform"}})
+	e.ProjectEstimated(ProjectEstimatedData{Name: "examples/terraform", TotalMonthlyCost: &cost})
+	e.ProjectEstimated(ProjectEstimatedData{Name: "examples/other", TotalMonthlyCost: &cost})
+	e.RunCompleted(nil)
+
+	assert.Equal(t, []string{RunStarted, ProjectEstimated, ProjectEstimated, RunCompleted}, received)
+
+	lines := strings.Split(strings.TrimSpace(stream.String()), "\n")
+	require.Len(t, lines, 4)
+
+	var completed struct {
+		SchemaVersion string
+		Type          string
+		RunID         string
+		Data          RunCompletedData
+	}
+	require.NoError(t, json.Unmarshal([]byte(lines[3]), &completed))
+	assert.Equal(t, LifecycleSchemaVersion, completed.SchemaVersion)
+	assert.Equal(t, RunCompleted, completed.Type)
+	assert.Equal(t, e.RunID(), completed.RunID)
+	assert.Equal(t, RunStatusSuccess, completed.Data.Status)
+	assert.Equal(t, 2, completed.Data.ProjectCount)
+	assert.Equal(t, "200", completed.Data.TotalMonthlyCost.String())
+}
@@ -12,6 +12,10 @@ import (
 )
 
 func SendReport(cfg *config.Config, key string, data interface{}) {
+	if cfg.Offline || cfg.DisableTelemetry {
+		return
+	}
+
 	if cfg.PricingAPIEndpoint != cfg.DefaultPricingAPIEndpoint && config.IsFalsy(os.Getenv("INFRACOST_SELF_HOSTED_TELEMETRY")) {
 		return
 	}
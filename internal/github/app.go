@@ -0,0 +1,287 @@
+// Package github authenticates with GitHub as a GitHub App installation so
+// cost comments can be posted without a personal access token.
+package github
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var DefaultAPIURL = "https://api.github.com"
+
+// tokenExpiryMargin is how long before it expires that a cached token stops
+// being used, so it doesn't expire while a comment is being posted.
+var tokenExpiryMargin = 5 * time.Minute
+
+type installationResponse struct {
+	ID int64 `json:"id"`
+}
+
+type accessTokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type issueCommentResponse struct {
+	HTMLURL string `json:"html_url"`
+}
+
+type cachedToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// App gets installation tokens for a GitHub App. The tokens are cached per
+// repo in the cache file so they can be reused until they expire.
+type App struct {
+	id        string
+	key       *rsa.PrivateKey
+	apiURL    string
+	cacheFile string
+	now       func() time.Time
+
+	mu sync.Mutex
+}
+
+func NewApp(id string, privateKeyPEM []byte, apiURL string, cacheFile string) (*App, error) {
+	if id == "" {
+		return nil, errors.New("No GitHub App ID is set")
+	}
+
+	key, err := parsePrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+
+	return &App{
+		id:        id,
+		key:       key,
+		apiURL:    strings.TrimSuffix(apiURL, "/"),
+		cacheFile: cacheFile,
+		now:       time.Now,
+	}, nil
+}
+
+func parsePrivateKey(b []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errors.New("Invalid GitHub App private key, expected a PEM encoded RSA key")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid GitHub App private key")
+	}
+
+	key, ok := k.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("Invalid GitHub App private key, expected an RSA key")
+	}
+
+	return key, nil
+}
+
+// InstallationToken returns a token for the installation of the app on the
+// repo, which should be in the format owner/name.
+func (a *App) InstallationToken(repo string) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	cache := a.readCache()
+	if t, ok := cache[repo]; ok && t.ExpiresAt.After(a.now().Add(tokenExpiryMargin)) {
+		log.Debugf("Using cached GitHub App installation token for %s", repo)
+		return t.Token, nil
+	}
+
+	jwt, err := a.jwt()
+	if err != nil {
+		return "", err
+	}
+
+	var installation installationResponse
+	err = a.call("GET", fmt.Sprintf("/repos/%s/installation", repo), jwt, nil, &installation)
+	if err != nil {
+		return "", errors.Wrapf(err, "Error finding GitHub App installation for %s", repo)
+	}
+
+	var accessToken accessTokenResponse
+	err = a.call("POST", fmt.Sprintf("/app/installations/%d/access_tokens", installation.ID), jwt, nil, &accessToken)
+	if err != nil {
+		return "", errors.Wrapf(err, "Error creating GitHub App installation token for %s", repo)
+	}
+
+	cache[repo] = cachedToken{
+		Token:     accessToken.Token,
+		ExpiresAt: accessToken.ExpiresAt,
+	}
+	a.writeCache(cache)
+
+	return accessToken.Token, nil
+}
+
+// CreateIssueComment posts a comment on the issue or pull request of the
+// repo as the app and returns the URL of the comment.
+func (a *App) CreateIssueComment(repo string, number int, body string) (string, error) {
+	token, err := a.InstallationToken(repo)
+	if err != nil {
+		return "", err
+	}
+
+	var comment issueCommentResponse
+	err = a.call("POST", fmt.Sprintf("/repos/%s/issues/%d/comments", repo, number), token, map[string]string{"body": body}, &comment)
+	if err != nil {
+		return "", errors.Wrapf(err, "Error posting GitHub comment to %s#%d", repo, number)
+	}
+
+	return comment.HTMLURL, nil
+}
+
+// jwt creates the JSON Web Token used to authenticate as the app. GitHub
+// only accepts tokens that expire within 10 minutes.
+func (a *App) jwt() (string, error) {
+	now := a.now()
+
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	claims, _ := json.Marshal(map[string]interface{}{
+		// Issue the token in the past to allow for clock drift
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(9 * time.Minute).Unix(),
+		"iss": a.id,
+	})
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	h := sha256.Sum256([]byte(unsigned))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, a.key, crypto.SHA256, h[:])
+	if err != nil {
+		return "", errors.Wrap(err, "Error signing GitHub App JWT")
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+func (a *App) call(method string, path string, token string, reqBody interface{}, v interface{}) error {
+	return call(a.apiURL, method, path, token, reqBody, v)
+}
+
+// call calls the GitHub API with the token and parses the response into v,
+// if it's set.
+func call(apiURL string, method string, path string, token string, reqBody interface{}, v interface{}) error {
+	url := apiURL + path
+	log.Debugf("Calling GitHub API: %s %s", method, url)
+
+	reqBytes := []byte{}
+	if reqBody != nil {
+		var err error
+		reqBytes, err = json.Marshal(reqBody)
+		if err != nil {
+			return errors.Wrap(err, "Error generating GitHub request body")
+		}
+	}
+
+	req, err := http.NewRequest(method, url, bytes.NewBuffer(reqBytes))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("invalid response from GitHub: %s", resp.Status)
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+func (a *App) readCache() map[string]cachedToken {
+	cache := make(map[string]cachedToken)
+	if a.cacheFile == "" {
+		return cache
+	}
+
+	b, err := ioutil.ReadFile(a.cacheFile)
+	if err != nil {
+		return cache
+	}
+
+	err = json.Unmarshal(b, &cache)
+	if err != nil {
+		log.Debugf("Ignoring invalid GitHub App token cache %s: %v", a.cacheFile, err)
+		return make(map[string]cachedToken)
+	}
+
+	return cache
+}
+
+func (a *App) writeCache(cache map[string]cachedToken) {
+	if a.cacheFile == "" {
+		return
+	}
+
+	now := a.now()
+	for repo, t := range cache {
+		if !t.ExpiresAt.After(now) {
+			delete(cache, repo)
+		}
+	}
+
+	b, err := json.Marshal(cache)
+	if err != nil {
+		log.Debugf("Unable to generate GitHub App token cache: %v", err)
+		return
+	}
+
+	err = os.MkdirAll(filepath.Dir(a.cacheFile), 0700)
+	if err == nil {
+		err = ioutil.WriteFile(a.cacheFile, b, 0600)
+	}
+	if err != nil {
+		log.Debugf("Unable to write GitHub App token cache %s: %v", a.cacheFile, err)
+	}
+}
@@ -0,0 +1,111 @@
+package github
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPrivateKey(t *testing.T) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+func TestInstallationToken(t *testing.T) {
+	var tokenRequests int
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		parts := strings.Split(auth, ".")
+		require.Len(t, parts, 3)
+
+		claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+		require.NoError(t, err)
+		var c map[string]interface{}
+		require.NoError(t, json.Unmarshal(claims, &c))
+		assert.Equal(t, "12345", c["iss"])
+
+		switch r.URL.Path {
+		case "/repos/my-org/my-repo/installation":
+			_, _ = w.Write([]byte(`{"id": 678}`))
+		case "/app/installations/678/access_tokens":
+			assert.Equal(t, "POST", r.Method)
+			tokenRequests++
+			_, _ = w.Write([]byte(`{"token": "ghs_test", "expires_at": "` + time.Now().Add(time.Hour).UTC().Format(time.RFC3339) + `"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	cacheFile := filepath.Join(t.TempDir(), "tokens.json")
+
+	app, err := NewApp("12345", testPrivateKey(t), ts.URL, cacheFile)
+	require.NoError(t, err)
+
+	token, err := app.InstallationToken("my-org/my-repo")
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_test", token)
+
+	// The second call should use the cached token
+	token, err = app.InstallationToken("my-org/my-repo")
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_test", token)
+	assert.Equal(t, 1, tokenRequests)
+
+	_, err = app.InstallationToken("my-org/other-repo")
+	assert.Error(t, err)
+}
+
+func TestNewAppInvalidKey(t *testing.T) {
+	_, err := NewApp("12345", []byte("not a key"), "", "")
+	assert.Error(t, err)
+
+	_, err = NewApp("", testPrivateKey(t), "", "")
+	assert.Error(t, err)
+}
+
+func TestCreateIssueComment(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/my-org/my-repo/installation":
+			_, _ = w.Write([]byte(`{"id": 678}`))
+		case "/app/installations/678/access_tokens":
+			_, _ = w.Write([]byte(`{"token": "ghs_test", "expires_at": "` + time.Now().Add(time.Hour).UTC().Format(time.RFC3339) + `"}`))
+		case "/repos/my-org/my-repo/issues/12/comments":
+			assert.Equal(t, "POST", r.Method)
+			assert.Equal(t, "Bearer ghs_test", r.Header.Get("Authorization"))
+
+			var body map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "hello", body["body"])
+
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"html_url": "https://github.com/my-org/my-repo/pull/12#issuecomment-1"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	app, err := NewApp("12345", testPrivateKey(t), ts.URL, "")
+	require.NoError(t, err)
+
+	url, err := app.CreateIssueComment("my-org/my-repo", 12, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/my-org/my-repo/pull/12#issuecomment-1", url)
+}
@@ -0,0 +1,203 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// CheckName is the name of the check run and the context of the commit
+// status.
+const CheckName = "Infracost"
+
+// The conclusions of a check run.
+const (
+	ConclusionSuccess = "success"
+	ConclusionFailure = "failure"
+)
+
+// maxCheckRunAnnotations is the most annotations GitHub accepts in one
+// request.
+const maxCheckRunAnnotations = 50
+
+// maxCheckRunText is the most characters GitHub accepts in the text of a
+// check run.
+const maxCheckRunText = 65535
+
+// maxStatusDescription is the most characters GitHub shows of a commit
+// status description.
+const maxStatusDescription = 140
+
+// CheckRun is a completed check run of a commit.
+type CheckRun struct {
+	HeadSHA     string
+	Conclusion  string
+	Title       string
+	Summary     string
+	Text        string
+	DetailsURL  string
+	Annotations []CheckAnnotation
+}
+
+// CheckAnnotation is an annotation of the lines of a file in a check run.
+type CheckAnnotation struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	AnnotationLevel string `json:"annotation_level"`
+	Title           string `json:"title,omitempty"`
+	Message         string `json:"message"`
+}
+
+type checkRunResponse struct {
+	HTMLURL string `json:"html_url"`
+}
+
+// NewCheckRun returns the check run of the commit for the cost estimate. The
+// title has the total monthly cost change and the resources with the largest
+// cost increases are annotated. It fails if the threshold is set and the
+// total monthly cost increases by at least the threshold.
+func NewCheckRun(out output.Root, sha string, threshold *output.DiffThreshold, maxAnnotations int) (CheckRun, error) {
+	b, err := output.ToDiff(out, output.Options{NoColor: true})
+	if err != nil {
+		return CheckRun{}, errors.Wrap(err, "Error generating check run")
+	}
+
+	conclusion := ConclusionSuccess
+	if threshold != nil && threshold.IsSignificant(out) && output.TotalMonthlyCostChange(out).IsPositive() {
+		conclusion = ConclusionFailure
+	}
+
+	n := output.BuildNotification(out, "", "")
+	summary := n.Title
+	if len(n.Projects) > 0 {
+		summary += "\n\n- " + strings.Join(n.Projects, "\n- ")
+	}
+	if conclusion == ConclusionFailure {
+		summary += fmt.Sprintf("\n\nThe monthly cost increase is over the threshold of %s.", threshold.String())
+	}
+
+	text := fmt.Sprintf("```\n%s\n```", strings.TrimSpace(ui.StripColor(string(b))))
+	if len(text) > maxCheckRunText {
+		text = text[:maxCheckRunText-len("\n...\n```")] + "\n...\n```"
+	}
+
+	if maxAnnotations > maxCheckRunAnnotations {
+		maxAnnotations = maxCheckRunAnnotations
+	}
+
+	increases := output.LargestCostIncreases(out, maxAnnotations)
+	annotations := make([]CheckAnnotation, 0, len(increases))
+	for _, r := range increases {
+		annotations = append(annotations, CheckAnnotation{
+			Path:            r.Path,
+			StartLine:       r.StartLine,
+			EndLine:         r.EndLine,
+			AnnotationLevel: "warning",
+			Title:           fmt.Sprintf("Monthly cost increase of %s", r.Project),
+			Message:         r.Message(),
+		})
+	}
+
+	return CheckRun{
+		HeadSHA:     sha,
+		Conclusion:  conclusion,
+		Title:       fmt.Sprintf("%s: %s", CheckName, output.ShortCostChange(out)),
+		Summary:     summary,
+		Text:        text,
+		Annotations: annotations,
+	}, nil
+}
+
+// Client calls the GitHub API with a token, e.g. the GITHUB_TOKEN of a
+// GitHub Actions workflow or a GitHub App installation token.
+type Client struct {
+	apiURL string
+	token  string
+}
+
+func NewClient(apiURL string, token string) *Client {
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+
+	return &Client{
+		apiURL: strings.TrimSuffix(apiURL, "/"),
+		token:  token,
+	}
+}
+
+// Client returns a client that calls the GitHub API as the app's
+// installation on the repo.
+func (a *App) Client(repo string) (*Client, error) {
+	token, err := a.InstallationToken(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClient(a.apiURL, token), nil
+}
+
+// CreateCheckRun creates the completed check run on the repo and returns its
+// URL. Check runs can only be created with a GitHub App token, which the
+// GITHUB_TOKEN of GitHub Actions is.
+func (c *Client) CreateCheckRun(repo string, run CheckRun) (string, error) {
+	reqBody := map[string]interface{}{
+		"name":       CheckName,
+		"head_sha":   run.HeadSHA,
+		"status":     "completed",
+		"conclusion": run.Conclusion,
+		"output": map[string]interface{}{
+			"title":       run.Title,
+			"summary":     run.Summary,
+			"text":        run.Text,
+			"annotations": run.Annotations,
+		},
+	}
+	if run.DetailsURL != "" {
+		reqBody["details_url"] = run.DetailsURL
+	}
+
+	var resp checkRunResponse
+	err := call(c.apiURL, "POST", fmt.Sprintf("/repos/%s/check-runs", repo), c.token, reqBody, &resp)
+	if err != nil {
+		return "", errors.Wrapf(err, "Error creating GitHub check run for %s", repo)
+	}
+
+	return resp.HTMLURL, nil
+}
+
+// CreateCommitStatus sets the Infracost commit status of the check run's
+// commit, for tokens that can't create check runs like personal access
+// tokens. Commit statuses only have a short description.
+func (c *Client) CreateCommitStatus(repo string, run CheckRun) error {
+	state := "success"
+	if run.Conclusion == ConclusionFailure {
+		state = "failure"
+	}
+
+	description := strings.TrimPrefix(run.Title, CheckName+": ")
+	if len(description) > maxStatusDescription {
+		description = description[:maxStatusDescription]
+	}
+
+	reqBody := map[string]string{
+		"state":       state,
+		"description": description,
+		"context":     strings.ToLower(CheckName),
+	}
+	if run.DetailsURL != "" {
+		reqBody["target_url"] = run.DetailsURL
+	}
+
+	err := call(c.apiURL, "POST", fmt.Sprintf("/repos/%s/statuses/%s", repo, run.HeadSHA), c.token, reqBody, nil)
+	if err != nil {
+		return errors.Wrapf(err, "Error creating GitHub commit status for %s", repo)
+	}
+
+	return nil
+}
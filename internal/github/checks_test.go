@@ -0,0 +1,158 @@
+package github
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestNewCheckRun(t *testing.T) {
+	dir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(`resource "aws_instance" "web" {
+  instance_type = "m5.4xlarge"
+}
+
+resource "aws_instance" "worker" {
+  instance_type = "m5.large"
+}
+`), 0600)
+	require.NoError(t, err)
+
+	d := func(i int64) *decimal.Decimal {
+		v := decimal.NewFromInt(i)
+		return &v
+	}
+
+	out := output.Root{
+		Projects: []output.Project{
+			{
+				Name:     "my-org/my-repo",
+				Metadata: &schema.ProjectMetadata{Path: dir},
+				PastBreakdown: &output.Breakdown{
+					Resources:        []output.Resource{{Name: "aws_instance.web", MonthlyCost: d(200)}},
+					TotalMonthlyCost: d(7400),
+				},
+				Breakdown: &output.Breakdown{
+					Resources: []output.Resource{
+						{Name: "aws_instance.web", MonthlyCost: d(500)},
+						{Name: "aws_instance.worker[0]", MonthlyCost: d(12)},
+						{Name: "module.remote.aws_instance.db", MonthlyCost: d(1000)},
+					},
+					TotalMonthlyCost: d(7712),
+				},
+				Diff: &output.Breakdown{
+					Resources: []output.Resource{
+						{Name: "aws_instance.web", MonthlyCost: d(300)},
+						{Name: "aws_instance.worker[0]", MonthlyCost: d(12)},
+						{Name: "module.remote.aws_instance.db", MonthlyCost: d(1000)},
+					},
+					TotalMonthlyCost: d(312),
+				},
+			},
+		},
+		Summary: &output.Summary{},
+	}
+
+	threshold, err := output.ParseThreshold("5%")
+	require.NoError(t, err)
+
+	run, err := NewCheckRun(out, "abc123", threshold, 10)
+	require.NoError(t, err)
+	assert.Equal(t, "Infracost: +$312/mo (+4.2%)", run.Title)
+	assert.Equal(t, ConclusionSuccess, run.Conclusion)
+	assert.True(t, strings.HasPrefix(run.Summary, "Monthly cost will change by +$312 (+4%), from $7,400 to $7,712"))
+	assert.Contains(t, run.Text, "Project: my-org/my-repo")
+
+	require.Len(t, run.Annotations, 2)
+	assert.True(t, strings.HasSuffix(run.Annotations[0].Path, "main.tf"))
+	assert.Equal(t, 1, run.Annotations[0].StartLine)
+	assert.Equal(t, 3, run.Annotations[0].EndLine)
+	assert.Equal(t, "aws_instance.web: $500.00/month (+$300)", run.Annotations[0].Message)
+	assert.Equal(t, "aws_instance.worker[0]: $12.00/month (+$12.00)", run.Annotations[1].Message)
+
+	threshold, err = output.ParseThreshold("4%")
+	require.NoError(t, err)
+
+	run, err = NewCheckRun(out, "abc123", threshold, 1)
+	require.NoError(t, err)
+	assert.Equal(t, ConclusionFailure, run.Conclusion)
+	assert.Contains(t, run.Summary, "The monthly cost increase is over the threshold of 4%.")
+	assert.Len(t, run.Annotations, 1)
+}
+
+func TestCreateCheckRunAndCommitStatus(t *testing.T) {
+	var checkRun map[string]interface{}
+	var status map[string]string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "Bearer ghs_test", r.Header.Get("Authorization"))
+
+		switch r.URL.Path {
+		case "/repos/my-org/my-repo/check-runs":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&checkRun))
+
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"html_url": "https://github.com/my-org/my-repo/runs/1"}`))
+		case "/repos/my-org/my-repo/statuses/abc123":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&status))
+
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	run := CheckRun{
+		HeadSHA:    "abc123",
+		Conclusion: ConclusionFailure,
+		Title:      "Infracost: +$312/mo (+4.2%)",
+		Summary:    "Monthly cost will change by +$312",
+		DetailsURL: "https://ci.example.com/runs/1",
+		Annotations: []CheckAnnotation{
+			{Path: "main.tf", StartLine: 1, EndLine: 3, AnnotationLevel: "warning", Message: "aws_instance.web: $500.00/month (+$300)"},
+		},
+	}
+
+	client := NewClient(ts.URL, "ghs_test")
+
+	url, err := client.CreateCheckRun("my-org/my-repo", run)
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/my-org/my-repo/runs/1", url)
+	assert.Equal(t, "Infracost", checkRun["name"])
+	assert.Equal(t, "abc123", checkRun["head_sha"])
+	assert.Equal(t, "completed", checkRun["status"])
+	assert.Equal(t, "failure", checkRun["conclusion"])
+	assert.Equal(t, "https://ci.example.com/runs/1", checkRun["details_url"])
+
+	checkOutput := checkRun["output"].(map[string]interface{})
+	assert.Equal(t, "Infracost: +$312/mo (+4.2%)", checkOutput["title"])
+	annotations := checkOutput["annotations"].([]interface{})
+	require.Len(t, annotations, 1)
+	assert.Equal(t, float64(1), annotations[0].(map[string]interface{})["start_line"])
+
+	err = client.CreateCommitStatus("my-org/my-repo", run)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"state":       "failure",
+		"description": "+$312/mo (+4.2%)",
+		"context":     "infracost",
+		"target_url":  "https://ci.example.com/runs/1",
+	}, status)
+
+	_, err = client.CreateCheckRun("my-org/other-repo", run)
+	assert.EqualError(t, err, "Error creating GitHub check run for my-org/other-repo: invalid response from GitHub: 404 Not Found")
+}
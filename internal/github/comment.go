@@ -0,0 +1,31 @@
+package github
+
+import (
+	"strings"
+)
+
+// CommentCommand is an /infracost command in a pull request comment, e.g.
+// "/infracost explain aws_instance.web".
+type CommentCommand struct {
+	Name string
+	Args []string
+}
+
+// ParseCommentCommand returns the first /infracost command in the comment
+// body. Commands have to be at the start of a line so that comments that
+// just mention a command aren't treated as one.
+func ParseCommentCommand(body string) (*CommentCommand, bool) {
+	for _, line := range strings.Split(body, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "/infracost" {
+			continue
+		}
+
+		return &CommentCommand{
+			Name: strings.ToLower(fields[1]),
+			Args: fields[2:],
+		}, true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,20 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCommentCommand(t *testing.T) {
+	c, ok := ParseCommentCommand("Thanks!\n/infracost explain aws_instance.web\n")
+	assert.True(t, ok)
+	assert.Equal(t, "explain", c.Name)
+	assert.Equal(t, []string{"aws_instance.web"}, c.Args)
+
+	_, ok = ParseCommentCommand("Try running /infracost explain aws_instance.web")
+	assert.False(t, ok)
+
+	_, ok = ParseCommentCommand("/infracost")
+	assert.False(t, ok)
+}
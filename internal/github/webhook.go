@@ -0,0 +1,105 @@
+package github
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SignatureHeader is the header of a webhook request that has the HMAC
+// SHA256 signature of the body, signed with the webhook's secret.
+const SignatureHeader = "X-Hub-Signature-256"
+
+// EventHeader is the header of a webhook request that has the event type.
+const EventHeader = "X-GitHub-Event"
+
+// EventIssueComment is the event type of comments on issues and pull requests.
+const EventIssueComment = "issue_comment"
+
+// repoRegex matches the full name of a repo. GitHub owners can't have dots
+// and repos can't be named "." or "..", so the name is always safe to use in
+// an API path.
+var repoRegex = regexp.MustCompile(`^[A-Za-z0-9-]+/[A-Za-z0-9_.-]*[A-Za-z0-9_-][A-Za-z0-9_.-]*$`)
+
+// IssueCommentEvent is the payload of an issue_comment webhook event. Only
+// the IDs are used, the comment itself is fetched from the API.
+type IssueCommentEvent struct {
+	Action string `json:"action"`
+	Issue  struct {
+		Number      int              `json:"number"`
+		PullRequest *json.RawMessage `json:"pull_request"`
+	} `json:"issue"`
+	Comment struct {
+		ID int64 `json:"id"`
+	} `json:"comment"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+type issueCommentBodyResponse struct {
+	Body string `json:"body"`
+}
+
+// VerifyWebhookSignature checks that the signature of the webhook request
+// body was signed with the secret. Requests are never verified if the secret
+// isn't set.
+func VerifyWebhookSignature(secret string, body []byte, signature string) bool {
+	if secret == "" || !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// ParseIssueCommentEvent parses the payload of an issue_comment event.
+func ParseIssueCommentEvent(body []byte) (*IssueCommentEvent, error) {
+	var e IssueCommentEvent
+	err := json.Unmarshal(body, &e)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing GitHub issue_comment event")
+	}
+
+	if !repoRegex.MatchString(e.Repository.FullName) || e.Issue.Number <= 0 || e.Comment.ID <= 0 {
+		return nil, errors.New("Invalid GitHub issue_comment event, repository, issue and comment are required")
+	}
+
+	return &e, nil
+}
+
+// IsNewPullRequestComment checks if the event is for a comment that was just
+// added to a pull request, rather than edited or added to an issue.
+func (e *IssueCommentEvent) IsNewPullRequestComment() bool {
+	return e.Action == "created" && e.Issue.PullRequest != nil
+}
+
+// GetIssueComment returns the body of the comment on the repo's issues or
+// pull requests.
+func (a *App) GetIssueComment(repo string, id int64) (string, error) {
+	token, err := a.InstallationToken(repo)
+	if err != nil {
+		return "", err
+	}
+
+	var comment issueCommentBodyResponse
+	err = a.call("GET", fmt.Sprintf("/repos/%s/issues/comments/%d", repo, id), token, nil, &comment)
+	if err != nil {
+		return "", errors.Wrapf(err, "Error getting GitHub comment %d of %s", id, repo)
+	}
+
+	return comment.Body, nil
+}
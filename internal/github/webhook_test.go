@@ -0,0 +1,74 @@
+package github
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	body := []byte(`{"action":"created"}`)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	assert.True(t, VerifyWebhookSignature("secret", body, signature))
+	assert.False(t, VerifyWebhookSignature("other-secret", body, signature))
+	assert.False(t, VerifyWebhookSignature("secret", []byte(`{"action":"edited"}`), signature))
+	assert.False(t, VerifyWebhookSignature("secret", body, hex.EncodeToString(mac.Sum(nil))))
+	assert.False(t, VerifyWebhookSignature("", body, ""))
+}
+
+func TestParseIssueCommentEvent(t *testing.T) {
+	e, err := ParseIssueCommentEvent([]byte(`{
+		"action": "created",
+		"issue": {"number": 12, "pull_request": {"url": "https://api.github.com/repos/my-org/my-repo/pulls/12"}},
+		"comment": {"id": 345, "body": "ignored"},
+		"repository": {"full_name": "my-org/my-repo"}
+	}`))
+	require.NoError(t, err)
+	assert.True(t, e.IsNewPullRequestComment())
+	assert.Equal(t, "my-org/my-repo", e.Repository.FullName)
+	assert.Equal(t, 12, e.Issue.Number)
+	assert.Equal(t, int64(345), e.Comment.ID)
+
+	e, err = ParseIssueCommentEvent([]byte(`{"action": "created", "issue": {"number": 12}, "comment": {"id": 345}, "repository": {"full_name": "my-org/my-repo"}}`))
+	require.NoError(t, err)
+	assert.False(t, e.IsNewPullRequestComment())
+
+	_, err = ParseIssueCommentEvent([]byte(`{"action": "created", "issue": {"number": 12}, "comment": {"id": 345}, "repository": {"full_name": "../my-repo"}}`))
+	assert.EqualError(t, err, "Invalid GitHub issue_comment event, repository, issue and comment are required")
+}
+
+func TestGetIssueComment(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/my-org/my-repo/installation":
+			_, _ = w.Write([]byte(`{"id": 678}`))
+		case "/app/installations/678/access_tokens":
+			_, _ = w.Write([]byte(`{"token": "ghs_test", "expires_at": "` + time.Now().Add(time.Hour).UTC().Format(time.RFC3339) + `"}`))
+		case "/repos/my-org/my-repo/issues/comments/345":
+			assert.Equal(t, "GET", r.Method)
+			assert.Equal(t, "Bearer ghs_test", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"body": "/infracost explain aws_instance.web"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	app, err := NewApp("12345", testPrivateKey(t), ts.URL, "")
+	require.NoError(t, err)
+
+	body, err := app.GetIssueComment("my-org/my-repo", 345)
+	require.NoError(t, err)
+	assert.Equal(t, "/infracost explain aws_instance.web", body)
+}
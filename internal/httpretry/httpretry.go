@@ -0,0 +1,171 @@
+// Package httpretry sends HTTP requests with retries, so the requests to the
+// pricing API and to the webhooks are retried in the same way.
+package httpretry
+
+import (
+	"context"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/infracost/infracost/internal/logging"
+)
+
+const (
+	DefaultRetries    = 3
+	DefaultTimeout    = 60 * time.Second
+	DefaultMinBackoff = 1 * time.Second
+	DefaultMaxBackoff = 30 * time.Second
+	maxRetryAfter     = 2 * time.Minute
+)
+
+// Sleep waits for the duration or until the context is done. It's a
+// variable so the backoff can be skipped in tests.
+var Sleep = func(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// Policy is how requests are retried. Network errors, rate limits and server
+// errors are retried with exponential backoff, or after the Retry-After of
+// the response if it has one.
+type Policy struct {
+	Retries    int
+	Timeout    time.Duration
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+func DefaultPolicy() Policy {
+	return Policy{
+		Retries:    DefaultRetries,
+		Timeout:    DefaultTimeout,
+		MinBackoff: DefaultMinBackoff,
+		MaxBackoff: DefaultMaxBackoff,
+	}
+}
+
+// Send sends the request returned by newRequest, creating a new one for each
+// attempt since the body can only be read once. It returns the response and
+// its body once the response isn't retryable, or the last error when all the
+// retries have failed, along with the number of attempts. If beforeRequest is
+// set it's called before each attempt, e.g. to rate limit the requests. The
+// requests should be created with the context, since no more attempts are
+// made once it's done.
+func Send(ctx context.Context, p Policy, newRequest func() (*http.Request, error), beforeRequest func(ctx context.Context) error) (*http.Response, []byte, int, error) {
+	client := &http.Client{Timeout: p.Timeout}
+
+	var (
+		resp     *http.Response
+		body     []byte
+		err      error
+		attempts int
+	)
+
+	for attempt := 0; ; attempt++ {
+		var req *http.Request
+		req, err = newRequest()
+		if err != nil {
+			return nil, nil, attempts, err
+		}
+
+		if beforeRequest != nil {
+			if err = beforeRequest(ctx); err != nil {
+				return nil, nil, attempts, errors.Wrap(err, "Request cancelled before sending")
+			}
+		}
+		attempts++
+
+		resp, err = client.Do(req)
+		if err == nil {
+			body, err = ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+
+			if err == nil && !IsRetryableStatus(resp.StatusCode) {
+				return resp, body, attempts, nil
+			}
+		}
+
+		if attempt >= p.Retries {
+			break
+		}
+
+		wait := p.backoff(attempt)
+		reason := ""
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = resp.Status
+			if d, ok := retryAfter(resp); ok {
+				wait = d
+			}
+		}
+
+		logging.FromContext(ctx).Warnf("Request to %s failed (%s), retrying in %s", req.URL.Host, reason, wait)
+		if err = Sleep(ctx, wait); err != nil {
+			return nil, nil, attempts, errors.Wrap(err, "Request cancelled before retrying")
+		}
+	}
+
+	if err != nil {
+		return nil, nil, attempts, errors.Wrapf(err, "Request failed after %d retries", p.Retries)
+	}
+
+	// Return the last response so its error can be reported
+	return resp, body, attempts, nil
+}
+
+// IsRetryableStatus checks if a response with the status should be retried.
+func IsRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || (status >= 500 && status != http.StatusNotImplemented)
+}
+
+// backoff doubles the wait for each attempt, up to the max, with jitter so
+// the concurrent requests don't all retry at the same time.
+func (p Policy) backoff(attempt int) time.Duration {
+	d := p.MinBackoff << uint(attempt)
+	if d > p.MaxBackoff || d <= 0 {
+		d = p.MaxBackoff
+	}
+
+	// nolint:gosec
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// retryAfter parses the Retry-After header, which is either a number of
+// seconds or an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	h := resp.Header.Get("Retry-After")
+	if h == "" {
+		return 0, false
+	}
+
+	var d time.Duration
+	if secs, err := strconv.Atoi(h); err == nil {
+		d = time.Duration(secs) * time.Second
+	} else if t, err := http.ParseTime(h); err == nil {
+		d = time.Until(t)
+	} else {
+		return 0, false
+	}
+
+	if d < 0 {
+		d = 0
+	}
+	if d > maxRetryAfter {
+		d = maxRetryAfter
+	}
+
+	return d, true
+}
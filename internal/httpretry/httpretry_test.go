@@ -0,0 +1,124 @@
+package httpretry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stubSleep(t *testing.T) *[]time.Duration {
+	waits := []time.Duration{}
+
+	origSleep := Sleep
+	t.Cleanup(func() { Sleep = origSleep })
+	Sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	return &waits
+}
+
+func newTestRequest(url string) func() (*http.Request, error) {
+	return func() (*http.Request, error) {
+		return http.NewRequest("POST", url, nil)
+	}
+}
+
+func TestSend(t *testing.T) {
+	waits := stubSleep(t)
+
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch requests {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			fmt.Fprint(w, "ok")
+		}
+	}))
+	defer ts.Close()
+
+	resp, body, attempts, err := Send(context.Background(), DefaultPolicy(), newTestRequest(ts.URL), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "ok", string(body))
+	assert.Equal(t, 3, requests)
+	assert.Equal(t, 3, attempts)
+
+	require.Len(t, *waits, 2)
+	assert.True(t, (*waits)[0] >= DefaultMinBackoff/2 && (*waits)[0] <= DefaultMinBackoff)
+	assert.Equal(t, 7*time.Second, (*waits)[1])
+}
+
+func TestSendExhausted(t *testing.T) {
+	waits := stubSleep(t)
+
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ts.Close()
+
+	p := DefaultPolicy()
+	p.Retries = 2
+
+	resp, _, attempts, err := Send(context.Background(), p, newTestRequest(ts.URL), nil)
+	require.NoError(t, err)
+
+	// The last response is returned so its error can be reported
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, 3, requests)
+	assert.Equal(t, 3, attempts)
+	assert.Len(t, *waits, 2)
+}
+
+func TestSendNotRetryable(t *testing.T) {
+	waits := stubSleep(t)
+
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer ts.Close()
+
+	resp, _, attempts, err := Send(context.Background(), DefaultPolicy(), newTestRequest(ts.URL), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, 1, attempts)
+	assert.Len(t, *waits, 0)
+}
+
+func TestSendTimeout(t *testing.T) {
+	stubSleep(t)
+
+	done := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer ts.Close()
+	defer close(done)
+
+	p := DefaultPolicy()
+	p.Retries = 1
+	p.Timeout = 50 * time.Millisecond
+
+	_, _, _, err := Send(context.Background(), p, newTestRequest(ts.URL), nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Request failed after 1 retries")
+}
@@ -0,0 +1,39 @@
+package i18n
+
+var de = map[string]string{
+	"Success:": "Erfolg:",
+	"Error:":   "Fehler:",
+	"Warning:": "Warnung:",
+	"Update:":  "Update:",
+
+	"An unexpected error occurred":                           "Ein unerwarteter Fehler ist aufgetreten",
+	"Please copy the above output and create a new issue at": "Bitte kopieren Sie die obige Ausgabe und erstellen Sie ein neues Issue unter",
+	"A new version of Infracost is available:":               "Eine neue Version von Infracost ist verfügbar:",
+
+	"Project:":      "Projekt:",
+	"Name":          "Name",
+	"Price":         "Preis",
+	"Monthly Qty":   "Monatl. Menge",
+	"Unit":          "Einheit",
+	"Hourly Cost":   "Stündliche Kosten",
+	"Monthly Cost":  "Monatliche Kosten",
+	"Project total": "Projekt gesamt",
+	"Total":         "Gesamt",
+	"OVERALL TOTAL": "GESAMTSUMME",
+
+	"Monthly cost depends on usage: %s per %s":                   "Monatliche Kosten hängen von der Nutzung ab: %s pro %s",
+	"To estimate usage-based resources use --usage-file, see %s": "Um nutzungsbasierte Ressourcen zu schätzen, verwenden Sie --usage-file, siehe %s",
+
+	"resource types weren't estimated as they're not supported yet":                                  "Ressourcentypen wurden nicht geschätzt, da sie noch nicht unterstützt werden",
+	"resource type wasn't estimated as it's not supported yet":                                       "Ressourcentyp wurde nicht geschätzt, da er noch nicht unterstützt wird",
+	"resources weren't estimated as their region could not be found:":                                "Ressourcen wurden nicht geschätzt, da ihre Region nicht gefunden wurde:",
+	"resource wasn't estimated as its region could not be found:":                                    "Ressource wurde nicht geschätzt, da ihre Region nicht gefunden wurde:",
+	", rerun with --show-skipped to see":                                                             ", mit --show-skipped erneut ausführen, um sie anzuzeigen",
+	"Please watch/star https://github.com/infracost/infracost as new resources are added regularly.": "Bitte folgen Sie https://github.com/infracost/infracost, da regelmäßig neue Ressourcen hinzukommen.",
+
+	"Monthly cost change for":               "Monatliche Kostenänderung für",
+	"Amount:":                               "Betrag:",
+	"Percent:":                              "Prozent:",
+	"Key: %s changed, %s added, %s removed": "Legende: %s geändert, %s hinzugefügt, %s entfernt",
+	"No changes detected. Run %s to see the full breakdown.": "Keine Änderungen erkannt. Führen Sie %s aus, um die vollständige Aufschlüsselung zu sehen.",
+}
@@ -0,0 +1,39 @@
+package i18n
+
+var es = map[string]string{
+	"Success:": "Éxito:",
+	"Error:":   "Error:",
+	"Warning:": "Advertencia:",
+	"Update:":  "Actualización:",
+
+	"An unexpected error occurred":                           "Se ha producido un error inesperado",
+	"Please copy the above output and create a new issue at": "Por favor, copie la salida anterior y cree un nuevo issue en",
+	"A new version of Infracost is available:":               "Hay una nueva versión de Infracost disponible:",
+
+	"Project:":      "Proyecto:",
+	"Name":          "Nombre",
+	"Price":         "Precio",
+	"Monthly Qty":   "Cant. mensual",
+	"Unit":          "Unidad",
+	"Hourly Cost":   "Coste por hora",
+	"Monthly Cost":  "Coste mensual",
+	"Project total": "Total del proyecto",
+	"Total":         "Total",
+	"OVERALL TOTAL": "TOTAL GENERAL",
+
+	"Monthly cost depends on usage: %s per %s":                   "El coste mensual depende del uso: %s por %s",
+	"To estimate usage-based resources use --usage-file, see %s": "Para estimar los recursos basados en el uso utilice --usage-file, consulte %s",
+
+	"resource types weren't estimated as they're not supported yet":                                  "tipos de recursos no se estimaron porque aún no son compatibles",
+	"resource type wasn't estimated as it's not supported yet":                                       "tipo de recurso no se estimó porque aún no es compatible",
+	"resources weren't estimated as their region could not be found:":                                "recursos no se estimaron porque no se encontró su región:",
+	"resource wasn't estimated as its region could not be found:":                                    "recurso no se estimó porque no se encontró su región:",
+	", rerun with --show-skipped to see":                                                             ", vuelva a ejecutar con --show-skipped para verlos",
+	"Please watch/star https://github.com/infracost/infracost as new resources are added regularly.": "Siga https://github.com/infracost/infracost ya que se añaden nuevos recursos con regularidad.",
+
+	"Monthly cost change for":               "Cambio en el coste mensual de",
+	"Amount:":                               "Importe:",
+	"Percent:":                              "Porcentaje:",
+	"Key: %s changed, %s added, %s removed": "Leyenda: %s modificado, %s añadido, %s eliminado",
+	"No changes detected. Run %s to see the full breakdown.": "No se detectaron cambios. Ejecute %s para ver el desglose completo.",
+}
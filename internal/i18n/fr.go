@@ -0,0 +1,39 @@
+package i18n
+
+var fr = map[string]string{
+	"Success:": "Succès :",
+	"Error:":   "Erreur :",
+	"Warning:": "Avertissement :",
+	"Update:":  "Mise à jour :",
+
+	"An unexpected error occurred":                           "Une erreur inattendue s'est produite",
+	"Please copy the above output and create a new issue at": "Veuillez copier la sortie ci-dessus et créer un nouveau ticket sur",
+	"A new version of Infracost is available:":               "Une nouvelle version d'Infracost est disponible :",
+
+	"Project:":      "Projet :",
+	"Name":          "Nom",
+	"Price":         "Prix",
+	"Monthly Qty":   "Qté mensuelle",
+	"Unit":          "Unité",
+	"Hourly Cost":   "Coût horaire",
+	"Monthly Cost":  "Coût mensuel",
+	"Project total": "Total du projet",
+	"Total":         "Total",
+	"OVERALL TOTAL": "TOTAL GÉNÉRAL",
+
+	"Monthly cost depends on usage: %s per %s":                   "Le coût mensuel dépend de l'utilisation : %s par %s",
+	"To estimate usage-based resources use --usage-file, see %s": "Pour estimer les ressources basées sur l'utilisation, utilisez --usage-file, voir %s",
+
+	"resource types weren't estimated as they're not supported yet":                                  "types de ressources n'ont pas été estimés car ils ne sont pas encore pris en charge",
+	"resource type wasn't estimated as it's not supported yet":                                       "type de ressource n'a pas été estimé car il n'est pas encore pris en charge",
+	"resources weren't estimated as their region could not be found:":                                "ressources n'ont pas été estimées car leur région est introuvable :",
+	"resource wasn't estimated as its region could not be found:":                                    "ressource n'a pas été estimée car sa région est introuvable :",
+	", rerun with --show-skipped to see":                                                             ", relancez avec --show-skipped pour les voir",
+	"Please watch/star https://github.com/infracost/infracost as new resources are added regularly.": "Suivez https://github.com/infracost/infracost car de nouvelles ressources sont ajoutées régulièrement.",
+
+	"Monthly cost change for":               "Variation du coût mensuel pour",
+	"Amount:":                               "Montant :",
+	"Percent:":                              "Pourcentage :",
+	"Key: %s changed, %s added, %s removed": "Légende : %s modifié, %s ajouté, %s supprimé",
+	"No changes detected. Run %s to see the full breakdown.": "Aucun changement détecté. Exécutez %s pour voir le détail complet.",
+}
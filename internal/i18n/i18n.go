@@ -0,0 +1,78 @@
+// Package i18n translates the user-facing CLI messages. Messages are looked up
+// by their English text so untranslated messages fall back to English.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultLocale is the locale the messages are written in.
+const DefaultLocale = "en"
+
+var catalogs = map[string]map[string]string{
+	"de": de,
+	"es": es,
+	"fr": fr,
+}
+
+var current map[string]string
+
+// SetLocale sets the locale of the messages. Locales can be just the language
+// (de) or include a region and encoding like the LANG environment variable
+// (de_DE.UTF-8), in which case only the language is used.
+func SetLocale(locale string) error {
+	lang := normalizeLocale(locale)
+
+	if lang == "" || lang == DefaultLocale {
+		current = nil
+		return nil
+	}
+
+	catalog, ok := catalogs[lang]
+	if !ok {
+		return fmt.Errorf("Unsupported locale %s. Supported locales are: %s", locale, strings.Join(Locales(), ", "))
+	}
+
+	current = catalog
+	return nil
+}
+
+// Locales returns the supported locales.
+func Locales() []string {
+	locales := []string{DefaultLocale}
+	for l := range catalogs {
+		locales = append(locales, l)
+	}
+	sort.Strings(locales[1:])
+	return locales
+}
+
+// T returns the message translated to the current locale.
+func T(msg string) string {
+	if translated, ok := current[msg]; ok {
+		return translated
+	}
+	return msg
+}
+
+// Tf returns the message format translated to the current locale and
+// formatted with the args.
+func Tf(msg string, a ...interface{}) string {
+	return fmt.Sprintf(T(msg), a...)
+}
+
+func normalizeLocale(locale string) string {
+	lang := strings.ToLower(strings.TrimSpace(locale))
+
+	if i := strings.IndexAny(lang, "_-.@"); i != -1 {
+		lang = lang[:i]
+	}
+
+	if lang == "c" || lang == "posix" {
+		return DefaultLocale
+	}
+
+	return lang
+}
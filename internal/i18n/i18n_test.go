@@ -0,0 +1,42 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetLocale(t *testing.T) {
+	defer func() { current = nil }()
+
+	require.NoError(t, SetLocale("de_DE.UTF-8"))
+	assert.Equal(t, "Monatliche Kosten", T("Monthly Cost"))
+	assert.Equal(t, "Legende: ~ geändert, + hinzugefügt, - entfernt", Tf("Key: %s changed, %s added, %s removed", "~", "+", "-"))
+	assert.Equal(t, "Not translated", T("Not translated"))
+
+	require.NoError(t, SetLocale("en_US"))
+	assert.Equal(t, "Monthly Cost", T("Monthly Cost"))
+
+	err := SetLocale("xx")
+	assert.EqualError(t, err, "Unsupported locale xx. Supported locales are: en, de, es, fr")
+}
+
+func TestCatalogsHaveSameFormatVerbs(t *testing.T) {
+	for lang, catalog := range catalogs {
+		for msg, translated := range catalog {
+			assert.Equal(t, countVerbs(msg), countVerbs(translated), "%s translation of %q", lang, msg)
+		}
+	}
+}
+
+func countVerbs(s string) int {
+	n := 0
+	for i := 0; i < len(s)-1; i++ {
+		if s[i] == '%' {
+			n++
+			i++
+		}
+	}
+	return n
+}
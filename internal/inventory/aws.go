@@ -0,0 +1,362 @@
+package inventory
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+)
+
+// AWSOptions are the accounts and regions to inventory. Each profile is an
+// AWS CLI profile, so multiple accounts can be inventoried. If no profiles are
+// set the default credentials are used.
+type AWSOptions struct {
+	Profiles []string
+	Regions  []string
+	Tags     map[string]string
+}
+
+// runAWSCLI runs the AWS CLI and returns its JSON output. The AWS CLI is used
+// so the same credentials and profiles as other tools can be used, and only
+// read-only describe calls are made.
+var runAWSCLI = func(profile string, region string, args ...string) ([]byte, error) {
+	args = append(args, "--region", region, "--output", "json")
+	if profile != "" {
+		args = append(args, "--profile", profile)
+	}
+
+	cmd := exec.Command("aws", args...)
+	log.Infof("Running command: %s", cmd.String())
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error running aws %s: %s", strings.Join(args[:2], " "), strings.TrimSpace(stderr.String()))
+	}
+
+	return out, nil
+}
+
+type awsCollector func(profile string, region string, opts AWSOptions) ([]Item, error)
+
+var awsCollectors = []awsCollector{
+	collectEC2Instances,
+	collectEBSVolumes,
+	collectRDSInstances,
+	collectLoadBalancers,
+}
+
+// CollectAWS inventories the running EC2 instances, EBS volumes, RDS instances
+// and load balancers that match the tags.
+func CollectAWS(opts AWSOptions) ([]Item, error) {
+	if len(opts.Regions) == 0 {
+		return nil, errors.New("No AWS regions specified")
+	}
+
+	profiles := opts.Profiles
+	if len(profiles) == 0 {
+		profiles = []string{""}
+	}
+
+	items := make([]Item, 0)
+
+	for _, profile := range profiles {
+		for _, region := range opts.Regions {
+			found := make([]Item, 0)
+			for _, collect := range awsCollectors {
+				collected, err := collect(profile, region, opts)
+				if err != nil {
+					return nil, err
+				}
+				found = append(found, collected...)
+			}
+
+			for _, i := range withoutRootVolumes(found) {
+				if matchesTags(i.Tags, opts.Tags) {
+					i.Profile = profile
+					items = append(items, i)
+				}
+			}
+		}
+	}
+
+	return items, nil
+}
+
+// withoutRootVolumes removes the EBS volumes that are the root volumes of
+// the instances, since they're priced as part of the instances.
+func withoutRootVolumes(items []Item) []Item {
+	rootVolumeIDs := make(map[string]bool)
+	for _, i := range items {
+		if i.Type != "aws_instance" {
+			continue
+		}
+
+		if root, ok := i.Values["root_block_device"].([]map[string]interface{}); ok && len(root) > 0 {
+			if id, ok := root[0]["volume_id"].(string); ok {
+				rootVolumeIDs[id] = true
+			}
+		}
+	}
+
+	filtered := make([]Item, 0, len(items))
+	for _, i := range items {
+		if i.Type == "aws_ebs_volume" && rootVolumeIDs[i.ID] {
+			continue
+		}
+		filtered = append(filtered, i)
+	}
+
+	return filtered
+}
+
+func awsTags(r gjson.Result) map[string]string {
+	tags := make(map[string]string)
+	for _, t := range r.Array() {
+		tags[t.Get("Key").String()] = t.Get("Value").String()
+	}
+	return tags
+}
+
+func collectEC2Instances(profile string, region string, opts AWSOptions) ([]Item, error) {
+	out, err := runAWSCLI(profile, region, "ec2", "describe-instances", "--filters", "Name=instance-state-name,Values=pending,running")
+	if err != nil {
+		return nil, err
+	}
+
+	instances := gjson.GetBytes(out, "Reservations.#.Instances|@flatten").Array()
+
+	rootVolumeIDs := make([]string, 0, len(instances))
+	for _, i := range instances {
+		if id := rootVolumeID(i); id != "" {
+			rootVolumeIDs = append(rootVolumeIDs, id)
+		}
+	}
+
+	rootVolumes, err := describeVolumes(profile, region, rootVolumeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]Item, 0)
+
+	for _, i := range instances {
+		tags := awsTags(i.Get("Tags"))
+
+		values := map[string]interface{}{
+			"instance_type": i.Get("InstanceType").String(),
+			"tenancy":       i.Get("Placement.Tenancy").String(),
+			"ebs_optimized": i.Get("EbsOptimized").Bool(),
+			"monitoring":    i.Get("Monitoring.State").String() == "enabled",
+		}
+
+		if v, ok := rootVolumes[rootVolumeID(i)]; ok {
+			rootBlockDevice := map[string]interface{}{"volume_id": v.Get("VolumeId").String()}
+			for k, val := range ebsVolumeValues(v) {
+				rootBlockDevice[rootBlockDeviceAttributes[k]] = val
+			}
+			values["root_block_device"] = []map[string]interface{}{rootBlockDevice}
+		} else if i.Get("RootDeviceType").String() == "instance-store" {
+			// Instance store root volumes are included in the instance price
+			values["root_block_device"] = []map[string]interface{}{{"volume_size": 0}}
+		} else {
+			// Without the root volume the default volume that Terraform
+			// would create is priced, so warn that the storage is unknown
+			log.Warnf("Could not find the root volume of EC2 instance %s, its storage cost is estimated from the default root volume", i.Get("InstanceId").String())
+		}
+
+		items = append(items, Item{
+			Type:   "aws_instance",
+			ID:     i.Get("InstanceId").String(),
+			Name:   tags["Name"],
+			Region: region,
+			Tags:   tags,
+			Values: values,
+		})
+	}
+
+	return items, nil
+}
+
+// rootBlockDeviceAttributes maps the attributes of aws_ebs_volume to the
+// attributes of the root_block_device of aws_instance.
+var rootBlockDeviceAttributes = map[string]string{
+	"type":       "volume_type",
+	"size":       "volume_size",
+	"iops":       "iops",
+	"throughput": "throughput",
+}
+
+// rootVolumeID returns the ID of the EBS volume of the instance's root device,
+// or an empty string if the root device isn't an EBS volume.
+func rootVolumeID(i gjson.Result) string {
+	root := i.Get("RootDeviceName").String()
+	for _, m := range i.Get("BlockDeviceMappings").Array() {
+		if m.Get("DeviceName").String() == root {
+			return m.Get("Ebs.VolumeId").String()
+		}
+	}
+
+	return ""
+}
+
+// describeVolumes returns the EBS volumes with the IDs, keyed by their ID.
+func describeVolumes(profile string, region string, ids []string) (map[string]gjson.Result, error) {
+	volumes := make(map[string]gjson.Result, len(ids))
+	if len(ids) == 0 {
+		return volumes, nil
+	}
+
+	args := append([]string{"ec2", "describe-volumes", "--volume-ids"}, ids...)
+	out, err := runAWSCLI(profile, region, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range gjson.GetBytes(out, "Volumes").Array() {
+		volumes[v.Get("VolumeId").String()] = v
+	}
+
+	return volumes, nil
+}
+
+// ebsVolumeValues returns the aws_ebs_volume attributes of an EBS volume.
+func ebsVolumeValues(v gjson.Result) map[string]interface{} {
+	values := map[string]interface{}{
+		"type": v.Get("VolumeType").String(),
+		"size": v.Get("Size").Int(),
+	}
+	if v.Get("VolumeType").String() != "gp2" && v.Get("Iops").Exists() {
+		values["iops"] = v.Get("Iops").Int()
+	}
+	if v.Get("Throughput").Exists() {
+		values["throughput"] = v.Get("Throughput").Int()
+	}
+
+	return values
+}
+
+func collectEBSVolumes(profile string, region string, opts AWSOptions) ([]Item, error) {
+	out, err := runAWSCLI(profile, region, "ec2", "describe-volumes")
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]Item, 0)
+
+	for _, v := range gjson.GetBytes(out, "Volumes").Array() {
+		tags := awsTags(v.Get("Tags"))
+
+		items = append(items, Item{
+			Type:   "aws_ebs_volume",
+			ID:     v.Get("VolumeId").String(),
+			Name:   tags["Name"],
+			Region: region,
+			Tags:   tags,
+			Values: ebsVolumeValues(v),
+		})
+	}
+
+	return items, nil
+}
+
+func collectRDSInstances(profile string, region string, opts AWSOptions) ([]Item, error) {
+	out, err := runAWSCLI(profile, region, "rds", "describe-db-instances")
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]Item, 0)
+
+	for _, db := range gjson.GetBytes(out, "DBInstances").Array() {
+		values := map[string]interface{}{
+			"instance_class":    db.Get("DBInstanceClass").String(),
+			"engine":            db.Get("Engine").String(),
+			"multi_az":          db.Get("MultiAZ").Bool(),
+			"allocated_storage": db.Get("AllocatedStorage").Int(),
+			"storage_type":      db.Get("StorageType").String(),
+			"license_model":     db.Get("LicenseModel").String(),
+		}
+		if db.Get("Iops").Exists() {
+			values["iops"] = db.Get("Iops").Int()
+		}
+
+		items = append(items, Item{
+			Type:   "aws_db_instance",
+			ID:     db.Get("DBInstanceIdentifier").String(),
+			ARN:    db.Get("DBInstanceArn").String(),
+			Region: region,
+			Tags:   awsTags(db.Get("TagList")),
+			Values: values,
+		})
+	}
+
+	return items, nil
+}
+
+func collectLoadBalancers(profile string, region string, opts AWSOptions) ([]Item, error) {
+	out, err := runAWSCLI(profile, region, "elbv2", "describe-load-balancers")
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]Item, 0)
+	arns := make([]string, 0)
+
+	for _, lb := range gjson.GetBytes(out, "LoadBalancers").Array() {
+		arn := lb.Get("LoadBalancerArn").String()
+		arns = append(arns, arn)
+
+		items = append(items, Item{
+			Type:   "aws_lb",
+			ID:     arn,
+			ARN:    arn,
+			Name:   lb.Get("LoadBalancerName").String(),
+			Region: region,
+			Tags:   map[string]string{},
+			Values: map[string]interface{}{
+				"load_balancer_type": lb.Get("Type").String(),
+			},
+		})
+	}
+
+	// The load balancer tags have to be described separately, which is only
+	// needed if they are being filtered on.
+	if len(opts.Tags) == 0 || len(arns) == 0 {
+		return items, nil
+	}
+
+	tags := make(map[string]map[string]string)
+
+	// describe-tags accepts up to 20 ARNs
+	for start := 0; start < len(arns); start += 20 {
+		end := start + 20
+		if end > len(arns) {
+			end = len(arns)
+		}
+
+		args := append([]string{"elbv2", "describe-tags", "--resource-arns"}, arns[start:end]...)
+		out, err := runAWSCLI(profile, region, args...)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, d := range gjson.GetBytes(out, "TagDescriptions").Array() {
+			tags[d.Get("ResourceArn").String()] = awsTags(d.Get("Tags"))
+		}
+	}
+
+	for i := range items {
+		if t, ok := tags[items[i].ARN]; ok {
+			items[i].Tags = t
+		}
+	}
+
+	return items, nil
+}
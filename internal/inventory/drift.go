@@ -0,0 +1,178 @@
+package inventory
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// driftAttributes are the attributes that are compared between the declared
+// and running resources of each inventoried type. These are the attributes
+// of the items that change their costs.
+var driftAttributes = map[string][]string{
+	"aws_instance":    {"instance_type", "tenancy", "ebs_optimized", "monitoring"},
+	"aws_ebs_volume":  {"type", "size", "iops", "throughput"},
+	"aws_db_instance": {"instance_class", "engine", "multi_az", "allocated_storage", "storage_type", "iops"},
+	"aws_lb":          {"load_balancer_type"},
+	"aws_alb":         {"load_balancer_type"},
+}
+
+// DeclaredResource is a resource of one of the inventoried types that's
+// declared in Terraform state or plan JSON.
+type DeclaredResource struct {
+	Address string
+	Type    string
+	ID      string
+	ARN     string
+	Values  gjson.Result
+}
+
+// Region returns the region of the resource from its ARN, or an empty string
+// if it doesn't have one.
+func (d DeclaredResource) Region() string {
+	p := strings.Split(d.ARN, ":")
+	if len(p) < 4 {
+		return ""
+	}
+	return p[3]
+}
+
+// Drift is a difference between a resource that's declared in Terraform and
+// the running resource, or a declared resource that isn't running.
+type Drift struct {
+	Address    string `json:"address"`
+	ID         string `json:"id"`
+	Attribute  string `json:"attribute,omitempty"`
+	Declared   string `json:"declared,omitempty"`
+	Running    string `json:"running,omitempty"`
+	NotRunning bool   `json:"notRunning,omitempty"`
+}
+
+func (d Drift) String() string {
+	if d.NotRunning {
+		return fmt.Sprintf("%s (%s) is declared but isn't running", d.Address, d.ID)
+	}
+	return fmt.Sprintf("%s (%s) %s is %s but %s is running", d.Address, d.ID, d.Attribute, d.Declared, d.Running)
+}
+
+// DeclaredResources returns the resources of the inventoried types in the
+// Terraform state or plan JSON, including the resources of child modules.
+// Resources that haven't been created yet don't have an ID so are left out.
+func DeclaredResources(terraformJSON [][]byte) []DeclaredResource {
+	declared := make([]DeclaredResource, 0)
+
+	for _, j := range terraformJSON {
+		parsed := gjson.ParseBytes(j)
+
+		root := parsed.Get("values.root_module")
+		if !root.Exists() {
+			root = parsed.Get("prior_state.values.root_module")
+		}
+
+		declared = append(declared, declaredModuleResources(root)...)
+	}
+
+	return declared
+}
+
+func declaredModuleResources(module gjson.Result) []DeclaredResource {
+	declared := make([]DeclaredResource, 0)
+
+	for _, r := range module.Get("resources").Array() {
+		t := r.Get("type").String()
+		if _, ok := driftAttributes[t]; !ok || r.Get("mode").String() == "data" {
+			continue
+		}
+
+		id := r.Get("values.id").String()
+		if id == "" {
+			continue
+		}
+
+		declared = append(declared, DeclaredResource{
+			Address: r.Get("address").String(),
+			Type:    t,
+			ID:      id,
+			ARN:     r.Get("values.arn").String(),
+			Values:  r.Get("values"),
+		})
+	}
+
+	for _, m := range module.Get("child_modules").Array() {
+		declared = append(declared, declaredModuleResources(m)...)
+	}
+
+	return declared
+}
+
+// LinkDeclared sets the Terraform address of the items that are declared in
+// Terraform, so their costs can be compared with the declared resources.
+func LinkDeclared(items []Item, declared []DeclaredResource) {
+	for _, d := range declared {
+		if i := findItem(items, d); i != nil {
+			i.TerraformAddress = d.Address
+		}
+	}
+}
+
+// FindDrift compares the attributes of the declared resources with the
+// running items. Declared resources in the inventoried regions that aren't
+// running are also drift, the rest can't be checked.
+func FindDrift(items []Item, declared []DeclaredResource, regions []string) []Drift {
+	drift := make([]Drift, 0)
+
+	for _, d := range declared {
+		i := findItem(items, d)
+		if i == nil {
+			if contains(regions, d.Region()) {
+				drift = append(drift, Drift{Address: d.Address, ID: d.ID, NotRunning: true})
+			}
+			continue
+		}
+
+		for _, attr := range driftAttributes[d.Type] {
+			declaredVal := d.Values.Get(attr)
+			runningVal, ok := i.Values[attr]
+			if !ok || !declaredVal.Exists() || declaredVal.Type == gjson.Null {
+				continue
+			}
+
+			if declaredVal.String() != fmt.Sprintf("%v", runningVal) {
+				drift = append(drift, Drift{
+					Address:   d.Address,
+					ID:        d.ID,
+					Attribute: attr,
+					Declared:  declaredVal.String(),
+					Running:   fmt.Sprintf("%v", runningVal),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(drift, func(a, b int) bool {
+		return drift[a].Address < drift[b].Address
+	})
+
+	return drift
+}
+
+func findItem(items []Item, d DeclaredResource) *Item {
+	for idx := range items {
+		i := &items[idx]
+		if i.ID == d.ID || (i.ARN != "" && i.ARN == d.ARN) || (i.ARN != "" && i.ARN == d.ID) {
+			return i
+		}
+	}
+	return nil
+}
+
+func contains(arr []string, s string) bool {
+	for _, a := range arr {
+		if a == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+// Package inventory finds the billable cloud resources that are running but
+// aren't managed by Terraform, so their costs can be estimated.
+package inventory
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/tidwall/gjson"
+)
+
+// Item is a live cloud resource. Values are the attributes of the equivalent
+// Terraform resource type so the resource can be priced like Terraform resources.
+type Item struct {
+	Type    string                 `json:"type"`
+	ID      string                 `json:"id"`
+	ARN     string                 `json:"arn,omitempty"`
+	Name    string                 `json:"name,omitempty"`
+	Region  string                 `json:"region"`
+	Profile string                 `json:"profile,omitempty"`
+	Tags    map[string]string      `json:"tags,omitempty"`
+	Values  map[string]interface{} `json:"values"`
+	// TerraformAddress is the address of the resource in Terraform if it's
+	// declared there.
+	TerraformAddress string `json:"terraformAddress,omitempty"`
+}
+
+// Address is the name used for the item in the cost breakdown. Declared items
+// use their Terraform address so they can be compared with the declared resources.
+func (i Item) Address() string {
+	if i.TerraformAddress != "" {
+		return i.TerraformAddress
+	}
+
+	name := i.ID
+	if i.Name != "" && i.Name != i.ID {
+		name = fmt.Sprintf("%s (%s)", i.ID, i.Name)
+	}
+	return fmt.Sprintf("%s.%s", i.Type, name)
+}
+
+// ResourceData converts the item to the resource data that the Terraform
+// resource registry uses to create the cost components.
+func (i Item) ResourceData() *schema.ResourceData {
+	values := make(map[string]interface{}, len(i.Values)+1)
+	for k, v := range i.Values {
+		values[k] = v
+	}
+	values["region"] = i.Region
+
+	j, _ := json.Marshal(values)
+
+	return schema.NewResourceData(i.Type, "registry.terraform.io/hashicorp/aws", i.Address(), i.Tags, gjson.ParseBytes(j))
+}
+
+// ManagedIDs returns all the string values of the resources in Terraform state
+// or plan JSON. Live resources whose ID or ARN is in this set are managed by
+// Terraform. All values are used rather than just the id attribute, since
+// some billable resources like the root volumes of instances are only
+// recorded as attributes of another resource.
+func ManagedIDs(terraformJSON [][]byte) map[string]bool {
+	ids := make(map[string]bool)
+
+	for _, j := range terraformJSON {
+		parsed := gjson.ParseBytes(j)
+		for _, key := range []string{"values", "prior_state.values"} {
+			collectStrings(parsed.Get(key), ids)
+		}
+	}
+
+	return ids
+}
+
+func collectStrings(r gjson.Result, ids map[string]bool) {
+	switch {
+	case r.IsObject() || r.IsArray():
+		r.ForEach(func(_, v gjson.Result) bool {
+			collectStrings(v, ids)
+			return true
+		})
+	case r.Type == gjson.String && r.String() != "":
+		ids[r.String()] = true
+	}
+}
+
+// Unmanaged returns the items that aren't in the managed IDs, sorted by their address.
+func Unmanaged(items []Item, managed map[string]bool) []Item {
+	unmanaged := make([]Item, 0)
+
+	for _, i := range items {
+		if managed[i.ID] || (i.ARN != "" && managed[i.ARN]) {
+			continue
+		}
+		unmanaged = append(unmanaged, i)
+	}
+
+	sort.Slice(unmanaged, func(a, b int) bool {
+		return unmanaged[a].Address() < unmanaged[b].Address()
+	})
+
+	return unmanaged
+}
+
+// ParseTagFilters parses tag filters in the format key=value.
+func ParseTagFilters(tags []string) (map[string]string, error) {
+	filters := make(map[string]string, len(tags))
+
+	for _, t := range tags {
+		parts := strings.SplitN(t, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("Invalid tag filter '%s', expected key=value", t)
+		}
+		filters[parts[0]] = parts[1]
+	}
+
+	return filters, nil
+}
+
+func matchesTags(tags map[string]string, filters map[string]string) bool {
+	for k, v := range filters {
+		if tags[k] != v {
+			return false
+		}
+	}
+	return true
+}
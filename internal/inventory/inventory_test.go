@@ -0,0 +1,126 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stubAWSCLI(t *testing.T, responses map[string]string) {
+	orig := runAWSCLI
+	t.Cleanup(func() { runAWSCLI = orig })
+
+	runAWSCLI = func(profile string, region string, args ...string) ([]byte, error) {
+		return []byte(responses[args[0]+" "+args[1]]), nil
+	}
+}
+
+func TestCollectAWS(t *testing.T) {
+	stubAWSCLI(t, map[string]string{
+		"ec2 describe-instances": `{"Reservations": [{"Instances": [
+			{"InstanceId": "i-managed", "InstanceType": "t3.micro", "Placement": {"Tenancy": "default"}},
+			{"InstanceId": "i-unmanaged", "InstanceType": "m5.large", "Placement": {"Tenancy": "default"}, "RootDeviceName": "/dev/xvda", "BlockDeviceMappings": [{"DeviceName": "/dev/xvda", "Ebs": {"VolumeId": "vol-root"}}], "Tags": [{"Key": "Name", "Value": "web"}, {"Key": "team", "Value": "platform"}]}
+		]}]}`,
+		"ec2 describe-volumes":          `{"Volumes": [{"VolumeId": "vol-root", "VolumeType": "gp2", "Size": 8}, {"VolumeId": "vol-data", "VolumeType": "gp3", "Size": 100, "Tags": [{"Key": "team", "Value": "platform"}]}]}`,
+		"rds describe-db-instances":     `{"DBInstances": []}`,
+		"elbv2 describe-load-balancers": `{"LoadBalancers": [{"LoadBalancerArn": "arn:aws:elasticloadbalancing:us-east-1:123:loadbalancer/app/web/1", "LoadBalancerName": "web", "Type": "application"}]}`,
+		"elbv2 describe-tags":           `{"TagDescriptions": []}`,
+	})
+
+	items, err := CollectAWS(AWSOptions{Regions: []string{"us-east-1"}})
+	require.NoError(t, err)
+	assert.Len(t, items, 4)
+
+	items, err = CollectAWS(AWSOptions{Regions: []string{"us-east-1"}, Tags: map[string]string{"team": "platform"}})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "aws_instance.i-unmanaged (web)", items[0].Address())
+	assert.Equal(t, "aws_ebs_volume.vol-data", items[1].Address())
+
+	// The root volume is priced with the instance rather than on its own
+	rootBlockDevice := items[0].Values["root_block_device"].([]map[string]interface{})
+	require.Len(t, rootBlockDevice, 1)
+	assert.Equal(t, "vol-root", rootBlockDevice[0]["volume_id"])
+	assert.EqualValues(t, 8, rootBlockDevice[0]["volume_size"])
+}
+
+func TestUnmanaged(t *testing.T) {
+	state := []byte(`{"values": {"root_module": {"resources": [
+		{"address": "aws_instance.app", "type": "aws_instance", "values": {"id": "i-managed", "root_block_device": [{"volume_id": "vol-root"}]}}
+	]}}}`)
+
+	items := []Item{
+		{Type: "aws_instance", ID: "i-unmanaged", Region: "us-east-1"},
+		{Type: "aws_instance", ID: "i-managed", Region: "us-east-1"},
+		{Type: "aws_ebs_volume", ID: "vol-root", Region: "us-east-1"},
+	}
+
+	unmanaged := Unmanaged(items, ManagedIDs([][]byte{state}))
+	require.Len(t, unmanaged, 1)
+	assert.Equal(t, "i-unmanaged", unmanaged[0].ID)
+}
+
+func TestItemResourceData(t *testing.T) {
+	i := Item{
+		Type:   "aws_instance",
+		ID:     "i-123",
+		Region: "eu-west-1",
+		Values: map[string]interface{}{
+			"instance_type":     "m5.large",
+			"root_block_device": []map[string]interface{}{{"volume_type": "gp2", "volume_size": 8}},
+		},
+	}
+
+	r := terraform.NewResource(config.NewEnvironment(), i.ResourceData(), nil)
+	assert.Equal(t, "aws_instance.i-123", r.Name)
+	assert.False(t, r.IsSkipped)
+	require.NotEmpty(t, r.CostComponents)
+	assert.Equal(t, "eu-west-1", *r.CostComponents[0].ProductFilter.Region)
+}
+
+func TestFindDrift(t *testing.T) {
+	state := []byte(`{"values": {"root_module": {
+		"resources": [
+			{"address": "aws_instance.app", "mode": "managed", "type": "aws_instance", "values": {"id": "i-app", "arn": "arn:aws:ec2:us-east-1:123:instance/i-app", "instance_type": "t3.micro", "tenancy": "default"}},
+			{"address": "aws_instance.gone", "mode": "managed", "type": "aws_instance", "values": {"id": "i-gone", "arn": "arn:aws:ec2:us-east-1:123:instance/i-gone", "instance_type": "t3.micro"}},
+			{"address": "aws_instance.other", "mode": "managed", "type": "aws_instance", "values": {"id": "i-other", "arn": "arn:aws:ec2:eu-west-1:123:instance/i-other", "instance_type": "t3.micro"}},
+			{"address": "data.aws_instance.lookup", "mode": "data", "type": "aws_instance", "values": {"id": "i-app"}}
+		],
+		"child_modules": [{"resources": [
+			{"address": "module.db.aws_db_instance.main", "mode": "managed", "type": "aws_db_instance", "values": {"id": "db-main", "arn": "arn:aws:rds:us-east-1:123:db:main", "instance_class": "db.t3.medium", "multi_az": false}}
+		]}]
+	}}}`)
+
+	declared := DeclaredResources([][]byte{state})
+	require.Len(t, declared, 4)
+
+	items := []Item{
+		{Type: "aws_instance", ID: "i-app", Region: "us-east-1", Values: map[string]interface{}{"instance_type": "m5.large", "tenancy": "default"}},
+		{Type: "aws_db_instance", ID: "main", ARN: "arn:aws:rds:us-east-1:123:db:main", Region: "us-east-1", Values: map[string]interface{}{"instance_class": "db.t3.medium", "multi_az": false}},
+		{Type: "aws_instance", ID: "i-unmanaged", Region: "us-east-1"},
+	}
+
+	LinkDeclared(items, declared)
+	assert.Equal(t, "aws_instance.app", items[0].Address())
+	assert.Equal(t, "module.db.aws_db_instance.main", items[1].Address())
+	assert.Equal(t, "aws_instance.i-unmanaged", items[2].Address())
+
+	drift := FindDrift(items, declared, []string{"us-east-1"})
+	assert.Equal(t, []Drift{
+		{Address: "aws_instance.app", ID: "i-app", Attribute: "instance_type", Declared: "t3.micro", Running: "m5.large"},
+		{Address: "aws_instance.gone", ID: "i-gone", NotRunning: true},
+	}, drift)
+	assert.Equal(t, "aws_instance.app (i-app) instance_type is t3.micro but m5.large is running", drift[0].String())
+}
+
+func TestParseTagFilters(t *testing.T) {
+	tags, err := ParseTagFilters([]string{"team=platform", "env=prod=1"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "platform", "env": "prod=1"}, tags)
+
+	_, err = ParseTagFilters([]string{"team"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,29 @@
+// Package logging passes a logger through a context, so the code that's run
+// by the Go package can log to the caller's logger rather than the global
+// logrus logger that the CLI configures.
+package logging
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type loggerKey struct{}
+
+// WithLogger returns a copy of the context that carries the logger.
+func WithLogger(ctx context.Context, logger log.FieldLogger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger of the context, or the global logger if
+// it doesn't have one.
+func FromContext(ctx context.Context) log.FieldLogger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerKey{}).(log.FieldLogger); ok && logger != nil {
+			return logger
+		}
+	}
+
+	return log.StandardLogger()
+}
@@ -0,0 +1,189 @@
+package output
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/infracost/infracost/internal/i18n"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/shopspring/decimal"
+)
+
+var accountsVersion = "0.1"
+
+// AccountLabel is the project label that sets which AWS account a project
+// is deployed to.
+const AccountLabel = "aws_account_id"
+
+var awsAccountIDRegex = regexp.MustCompile(`^\d{12}$`)
+
+// Accounts are the AWS accounts of an organization that the projects roll
+// up to, with any discounts or credits that are specific to each account.
+type Accounts struct {
+	Version  string     `yaml:"version"`
+	Accounts []*Account `yaml:"accounts"`
+}
+
+// Account is an AWS account. Projects are assigned to it if their
+// aws_account_id label is the account ID, or if they match one of its
+// project glob patterns. The discount is applied before the monthly credit.
+type Account struct {
+	ID              string   `yaml:"id"`
+	Name            string   `yaml:"name,omitempty"`
+	Projects        []string `yaml:"projects,omitempty"`
+	DiscountPercent float64  `yaml:"discount_percent,omitempty"`
+	MonthlyCredit   float64  `yaml:"monthly_credit,omitempty"`
+}
+
+// AccountRollups are the monthly costs of each AWS account and the total
+// for the organization, which only includes the assigned projects.
+type AccountRollups struct {
+	Accounts           []AccountRollup  `json:"accounts"`
+	TotalMonthlyCost   *decimal.Decimal `json:"totalMonthlyCost"`
+	UnassignedProjects []string         `json:"unassignedProjects,omitempty"`
+}
+
+// AccountRollup is the monthly cost of an account. The subtotal is the cost
+// of its projects, and the total is after its discount and credit.
+type AccountRollup struct {
+	ID                  string           `json:"id"`
+	Name                string           `json:"name,omitempty"`
+	Projects            []string         `json:"projects"`
+	SubtotalMonthlyCost *decimal.Decimal `json:"subtotalMonthlyCost"`
+	Discount            *decimal.Decimal `json:"discount,omitempty"`
+	Credit              *decimal.Decimal `json:"credit,omitempty"`
+	TotalMonthlyCost    *decimal.Decimal `json:"totalMonthlyCost"`
+}
+
+func LoadAccounts(path string) (*Accounts, error) {
+	var a Accounts
+	err := loadRollupFile(path, "accounts", accountsVersion, &a)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, account := range a.Accounts {
+		if !awsAccountIDRegex.MatchString(account.ID) {
+			return nil, fmt.Errorf("Invalid accounts file: '%s' is not a 12 digit AWS account ID", account.ID)
+		}
+
+		if seen[account.ID] {
+			return nil, fmt.Errorf("Invalid accounts file: account %s is listed more than once", account.ID)
+		}
+		seen[account.ID] = true
+
+		if account.DiscountPercent < 0 || account.DiscountPercent > 100 {
+			return nil, fmt.Errorf("Invalid accounts file: discount_percent of account %s must be between 0 and 100", account.ID)
+		}
+
+		if account.MonthlyCredit < 0 {
+			return nil, fmt.Errorf("Invalid accounts file: monthly_credit of account %s must be 0 or more", account.ID)
+		}
+
+		err = validateProjectPatterns("accounts", fmt.Sprintf("account %s", account.ID), account.Projects)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &a, nil
+}
+
+// BuildAccountRollups totals the monthly costs of the projects for each
+// account, applying the account's discount and credit. A project is
+// assigned to the first account that it matches.
+func BuildAccountRollups(out Root, a *Accounts) *AccountRollups {
+	rollups := &AccountRollups{
+		Accounts:         make([]AccountRollup, 0, len(a.Accounts)),
+		TotalMonthlyCost: decimalPtr(decimal.Zero),
+	}
+
+	assigned := make(map[int]bool)
+
+	for _, account := range a.Accounts {
+		account := account
+
+		r := AccountRollup{
+			ID:   account.ID,
+			Name: account.Name,
+		}
+
+		r.Projects, r.SubtotalMonthlyCost = rollupProjects(out, assigned, func(p Project) bool {
+			return matchesAccount(p, account)
+		})
+
+		total := *r.SubtotalMonthlyCost
+
+		if account.DiscountPercent > 0 {
+			discount := total.Mul(decimal.NewFromFloat(account.DiscountPercent)).Div(decimal.NewFromInt(100))
+			r.Discount = &discount
+			total = total.Sub(discount)
+		}
+
+		if account.MonthlyCredit > 0 {
+			// The credit can't be more than the cost of the account
+			credit := decimal.Min(decimal.NewFromFloat(account.MonthlyCredit), total)
+			r.Credit = &credit
+			total = total.Sub(credit)
+		}
+
+		r.TotalMonthlyCost = &total
+		rollups.TotalMonthlyCost = decimalPtr(rollups.TotalMonthlyCost.Add(total))
+		rollups.Accounts = append(rollups.Accounts, r)
+	}
+
+	rollups.UnassignedProjects = unassignedProjects(out, assigned)
+
+	return rollups
+}
+
+func matchesAccount(p Project, a *Account) bool {
+	if p.Metadata != nil && p.Metadata.Labels[AccountLabel] == a.ID {
+		return true
+	}
+
+	return matchesProjectPatterns(p, a.Projects)
+}
+
+func accountRollupsToTable(rollups *AccountRollups) string {
+	t := newSummaryTable()
+	appendSummaryHeader(t, 1, "Account", "Projects", "Subtotal", "Discounts & credits", i18n.T("Monthly Cost"))
+
+	for _, r := range rollups.Accounts {
+		name := r.ID
+		if r.Name != "" {
+			name = fmt.Sprintf("%s (%s)", r.Name, r.ID)
+		}
+
+		reductions := decimal.Zero
+		if r.Discount != nil {
+			reductions = reductions.Add(*r.Discount)
+		}
+		if r.Credit != nil {
+			reductions = reductions.Add(*r.Credit)
+		}
+
+		reductionsOut := "-"
+		if !reductions.IsZero() {
+			reductionsOut = "-" + formatCost2DP(&reductions)
+		}
+
+		t.AppendRow(table.Row{
+			name,
+			len(r.Projects),
+			formatCost2DP(r.SubtotalMonthlyCost),
+			reductionsOut,
+			formatCost2DP(r.TotalMonthlyCost),
+		})
+	}
+
+	t.AppendRow(table.Row{""})
+	t.AppendRow(table.Row{ui.BoldString("Organization total"), "", "", "", formatCost2DP(rollups.TotalMonthlyCost)})
+
+	s := fmt.Sprintf("%s\n\n%s", ui.BoldString("Monthly cost by AWS account"), t.Render())
+	s += unassignedProjectsToText(rollups.UnassignedProjects, "an account")
+
+	return s
+}
@@ -0,0 +1,110 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/infracost/infracost/internal/i18n"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/shopspring/decimal"
+)
+
+// CostAdjustment is the change that a cost adjustment made to the monthly
+// cost of a cost component.
+type CostAdjustment struct {
+	Name        string          `json:"name"`
+	MonthlyCost decimal.Decimal `json:"monthlyCost"`
+}
+
+// AdjustmentSummary is the total change that a cost adjustment made to the
+// monthly costs of all the projects.
+type AdjustmentSummary struct {
+	Name           string          `json:"name"`
+	CostComponents int             `json:"costComponents"`
+	MonthlyCost    decimal.Decimal `json:"monthlyCost"`
+}
+
+func outputCostAdjustments(c *schema.CostComponent) []CostAdjustment {
+	if len(c.Adjustments()) == 0 {
+		return nil
+	}
+
+	adjustments := make([]CostAdjustment, 0, len(c.Adjustments()))
+	for _, a := range c.Adjustments() {
+		adjustments = append(adjustments, CostAdjustment{
+			Name:        a.Name,
+			MonthlyCost: a.MonthlyCost,
+		})
+	}
+
+	return adjustments
+}
+
+// buildAdjustmentSummaries totals the cost adjustments of the projects, in
+// the order they were first applied. It returns nil if no adjustments were
+// applied.
+func buildAdjustmentSummaries(projects []Project) []AdjustmentSummary {
+	var summaries []AdjustmentSummary
+	indexes := make(map[string]int)
+
+	var addResource func(r Resource)
+	addResource = func(r Resource) {
+		for _, c := range r.CostComponents {
+			for _, a := range c.Adjustments {
+				i, ok := indexes[a.Name]
+				if !ok {
+					i = len(summaries)
+					indexes[a.Name] = i
+					summaries = append(summaries, AdjustmentSummary{Name: a.Name})
+				}
+
+				summaries[i].CostComponents++
+				summaries[i].MonthlyCost = summaries[i].MonthlyCost.Add(a.MonthlyCost)
+			}
+		}
+
+		for _, s := range r.SubResources {
+			addResource(s)
+		}
+	}
+
+	for _, p := range projects {
+		if p.Breakdown == nil {
+			continue
+		}
+
+		for _, r := range p.Breakdown.Resources {
+			addResource(r)
+		}
+	}
+
+	return summaries
+}
+
+// adjustmentsToTable lists the change each cost adjustment made to the
+// monthly cost, so it's clear how much of the estimate comes from them.
+func adjustmentsToTable(summaries []AdjustmentSummary) string {
+	nameWidth := 0
+	for _, a := range summaries {
+		if len(a.Name) > nameWidth {
+			nameWidth = len(a.Name)
+		}
+	}
+
+	s := ui.BoldString(i18n.T("Cost adjustments"))
+
+	for _, a := range summaries {
+		components := i18n.T("1 cost component")
+		if a.CostComponents != 1 {
+			components = i18n.Tf("%d cost components", a.CostComponents)
+		}
+
+		s += fmt.Sprintf("\n  %s  %s  %s",
+			padRight(a.Name, nameWidth),
+			formatCostChange(decimalPtr(a.MonthlyCost)),
+			ui.FaintStringf("(%s)", components),
+		)
+	}
+
+	return s
+}
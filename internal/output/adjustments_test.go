@@ -0,0 +1,58 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/shopspring/decimal"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestBuildAdjustmentSummaries(t *testing.T) {
+	projects := []Project{
+		{
+			Name: "infracost/infracost/dev",
+			Breakdown: &Breakdown{
+				Resources: []Resource{
+					{
+						Name: "aws_instance.web",
+						CostComponents: []CostComponent{
+							{Name: "Instance usage", Adjustments: []CostAdjustment{{Name: "Dev utilization", MonthlyCost: decimal.NewFromInt(-70)}}},
+						},
+						SubResources: []Resource{
+							{
+								Name: "root_block_device",
+								CostComponents: []CostComponent{
+									{Name: "Storage", Adjustments: []CostAdjustment{{Name: "Dev utilization", MonthlyCost: decimal.NewFromInt(-7)}}},
+								},
+							},
+						},
+					},
+					{
+						Name: "aws_nat_gateway.nat",
+						CostComponents: []CostComponent{
+							{Name: "Data processed", Adjustments: []CostAdjustment{{Name: "Data transfer overhead", MonthlyCost: decimal.NewFromInt(15)}}},
+						},
+					},
+				},
+			},
+		},
+		{Name: "infracost/infracost/empty"},
+	}
+
+	summaries := buildAdjustmentSummaries(projects)
+
+	assert.Equal(t, 2, len(summaries))
+	assert.Equal(t, "Dev utilization", summaries[0].Name)
+	assert.Equal(t, 2, summaries[0].CostComponents)
+	assert.Equal(t, "-77", summaries[0].MonthlyCost.String())
+	assert.Equal(t, "Data transfer overhead", summaries[1].Name)
+	assert.Equal(t, 1, summaries[1].CostComponents)
+
+	table := ui.StripColor(adjustmentsToTable(summaries))
+	assert.Equal(t, true, strings.Contains(table, "Dev utilization         -$77.00  (2 cost components)"))
+	assert.Equal(t, true, strings.Contains(table, "Data transfer overhead  +$15.00  (1 cost component)"))
+
+	assert.Equal(t, 0, len(buildAdjustmentSummaries(projects[1:])))
+}
@@ -0,0 +1,251 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+)
+
+var addressIndexRegex = regexp.MustCompile(`\[[^\]]*\]`)
+
+// The annotations use the Reviewdog Diagnostic Format (rdjson) since it can be
+// passed straight to reviewdog with -f=rdjson and is simple for IDE plugins to
+// read: https://github.com/reviewdog/reviewdog/tree/master/proto/rdf
+type annotationsRoot struct {
+	Source      annotationSource `json:"source"`
+	Diagnostics []annotation     `json:"diagnostics"`
+}
+
+type annotationSource struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+type annotation struct {
+	Message  string             `json:"message"`
+	Location annotationLocation `json:"location"`
+	Severity string             `json:"severity"`
+	Code     annotationCode     `json:"code"`
+}
+
+type annotationLocation struct {
+	Path  string          `json:"path"`
+	Range annotationRange `json:"range"`
+}
+
+type annotationRange struct {
+	Start annotationPosition `json:"start"`
+	End   annotationPosition `json:"end"`
+}
+
+type annotationPosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type annotationCode struct {
+	Value string `json:"value"`
+}
+
+// ToAnnotations maps each costed resource to where it is declared in the
+// Terraform files of its project and outputs an annotation with its monthly
+// cost. Resources that can't be found in the Terraform files, e.g. ones in
+// remote modules, are skipped.
+func ToAnnotations(out Root, opts Options) ([]byte, error) {
+	root := annotationsRoot{
+		Source: annotationSource{
+			Name: "infracost",
+			URL:  "https://infracost.io",
+		},
+		Diagnostics: make([]annotation, 0),
+	}
+
+	sources := newResourceSources()
+
+	for _, project := range out.Projects {
+		if project.Breakdown == nil {
+			continue
+		}
+
+		for _, r := range project.Breakdown.Resources {
+			message, ok := annotationMessage(project, r)
+			if !ok {
+				continue
+			}
+
+			sourceRange, ok := sources.find(project, r.Name)
+			if !ok {
+				continue
+			}
+
+			root.Diagnostics = append(root.Diagnostics, annotation{
+				Message: message,
+				Location: annotationLocation{
+					Path: relativePath(sourceRange.Filename),
+					Range: annotationRange{
+						Start: annotationPosition{Line: sourceRange.StartLine, Column: sourceRange.StartColumn},
+						End:   annotationPosition{Line: sourceRange.EndLine, Column: sourceRange.EndColumn},
+					},
+				},
+				Severity: "INFO",
+				Code:     annotationCode{Value: r.Name},
+			})
+		}
+	}
+
+	sort.SliceStable(root.Diagnostics, func(i, j int) bool {
+		a, b := root.Diagnostics[i].Location, root.Diagnostics[j].Location
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Range.Start.Line < b.Range.Start.Line
+	})
+
+	return json.MarshalIndent(root, "", "  ")
+}
+
+// resourceSources finds where resources are declared in the Terraform files
+// of their project. The files of each project dir are only parsed once.
+type resourceSources struct {
+	ranges map[string]map[string]terraform.SourceRange
+}
+
+func newResourceSources() *resourceSources {
+	return &resourceSources{ranges: make(map[string]map[string]terraform.SourceRange)}
+}
+
+func (s *resourceSources) find(project Project, name string) (terraform.SourceRange, bool) {
+	if project.Metadata == nil || project.Metadata.Path == "" {
+		return terraform.SourceRange{}, false
+	}
+
+	dir := projectDir(project)
+
+	ranges, ok := s.ranges[dir]
+	if !ok {
+		var err error
+		ranges, err = terraform.ResourceSourceRanges(dir)
+		if err != nil {
+			log.Debugf("Error finding Terraform resources in %s: %v", dir, err)
+		}
+		s.ranges[dir] = ranges
+	}
+
+	sourceRange, ok := ranges[addressIndexRegex.ReplaceAllString(name, "")]
+	return sourceRange, ok
+}
+
+// ResourceCostIncrease is a resource whose monthly cost increases, and where
+// it's declared in the Terraform files.
+type ResourceCostIncrease struct {
+	Project     string
+	Name        string
+	Path        string
+	StartLine   int
+	EndLine     int
+	MonthlyCost decimal.Decimal
+	Change      decimal.Decimal
+}
+
+// Message describes the cost increase of the resource.
+func (r ResourceCostIncrease) Message() string {
+	return fmt.Sprintf("%s: %s/month (%s)", r.Name, formatCost2DP(&r.MonthlyCost), formatCostChange(&r.Change))
+}
+
+// LargestCostIncreases returns the resources with the largest monthly cost
+// increases, largest first, up to the limit. Like the annotations, resources
+// that can't be found in the Terraform files are skipped.
+func LargestCostIncreases(out Root, limit int) []ResourceCostIncrease {
+	sources := newResourceSources()
+
+	increases := make([]ResourceCostIncrease, 0)
+	for _, project := range out.Projects {
+		if project.Diff == nil || project.Breakdown == nil {
+			continue
+		}
+
+		for _, d := range project.Diff.Resources {
+			if d.MonthlyCost == nil || !d.MonthlyCost.IsPositive() {
+				continue
+			}
+
+			r := findResourceByName(project.Breakdown.Resources, d.Name)
+			if r == nil || r.MonthlyCost == nil {
+				continue
+			}
+
+			sourceRange, ok := sources.find(project, d.Name)
+			if !ok {
+				continue
+			}
+
+			increases = append(increases, ResourceCostIncrease{
+				Project:     project.Name,
+				Name:        d.Name,
+				Path:        relativePath(sourceRange.Filename),
+				StartLine:   sourceRange.StartLine,
+				EndLine:     sourceRange.EndLine,
+				MonthlyCost: *r.MonthlyCost,
+				Change:      *d.MonthlyCost,
+			})
+		}
+	}
+
+	sort.SliceStable(increases, func(i, j int) bool {
+		return increases[i].Change.GreaterThan(increases[j].Change)
+	})
+
+	if len(increases) > limit {
+		increases = increases[:limit]
+	}
+
+	return increases
+}
+
+// annotationMessage returns the message for the resource, resources without
+// a cost or a cost change aren't annotated.
+func annotationMessage(project Project, r Resource) (string, bool) {
+	if r.MonthlyCost == nil {
+		return "", false
+	}
+
+	var diff *Resource
+	if project.Diff != nil {
+		diff = findResourceByName(project.Diff.Resources, r.Name)
+	}
+
+	if diff == nil || diff.MonthlyCost == nil || diff.MonthlyCost.IsZero() {
+		if r.MonthlyCost.IsZero() {
+			return "", false
+		}
+		return fmt.Sprintf("%s: %s/month", r.Name, formatCost2DP(r.MonthlyCost)), true
+	}
+
+	return fmt.Sprintf("%s: %s/month (%s)", r.Name, formatCost2DP(r.MonthlyCost), formatCostChange(diff.MonthlyCost)), true
+}
+
+func relativePath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+
+	rel, err := filepath.Rel(wd, abs)
+	if err != nil {
+		return path
+	}
+
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,84 @@
+package output
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestToAnnotations(t *testing.T) {
+	dir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(`resource "aws_instance" "web" {
+  instance_type = "m5.large"
+}
+
+resource "aws_eip" "free" {
+}
+
+module "db" {
+  source = "./modules/db"
+}
+`), 0600)
+	assert.Equal(t, nil, err)
+
+	err = os.MkdirAll(filepath.Join(dir, "modules", "db"), 0700)
+	assert.Equal(t, nil, err)
+
+	err = os.WriteFile(filepath.Join(dir, "modules", "db", "db.tf"), []byte(`
+resource "aws_db_instance" "db" {
+  instance_class = "db.t3.large"
+}
+`), 0600)
+	assert.Equal(t, nil, err)
+
+	out := Root{
+		Projects: []Project{
+			{
+				Name:     "test",
+				Metadata: &schema.ProjectMetadata{Path: dir},
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web[0]", MonthlyCost: decimalPtr(decimal.NewFromInt(70))},
+						{Name: "aws_eip.free", MonthlyCost: decimalPtr(decimal.Zero)},
+						{Name: "module.db.aws_db_instance.db", MonthlyCost: decimalPtr(decimal.NewFromInt(120))},
+						{Name: "module.remote.aws_instance.other", MonthlyCost: decimalPtr(decimal.NewFromInt(10))},
+					},
+				},
+				Diff: &Breakdown{
+					Resources: []Resource{
+						{Name: "module.db.aws_db_instance.db", MonthlyCost: decimalPtr(decimal.NewFromInt(20))},
+					},
+				},
+			},
+		},
+	}
+
+	b, err := ToAnnotations(out, Options{})
+	assert.Equal(t, nil, err)
+
+	var result annotationsRoot
+	err = json.Unmarshal(b, &result)
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, "infracost", result.Source.Name)
+	assert.Equal(t, 2, len(result.Diagnostics))
+
+	web := result.Diagnostics[0]
+	assert.Equal(t, "aws_instance.web[0]: $70.00/month", web.Message)
+	assert.Equal(t, "aws_instance.web[0]", web.Code.Value)
+	assert.Equal(t, true, strings.HasSuffix(web.Location.Path, "/main.tf"))
+	assert.Equal(t, 1, web.Location.Range.Start.Line)
+	assert.Equal(t, 3, web.Location.Range.End.Line)
+
+	db := result.Diagnostics[1]
+	assert.Equal(t, "module.db.aws_db_instance.db: $120.00/month (+$20.00)", db.Message)
+	assert.Equal(t, true, strings.HasSuffix(db.Location.Path, "modules/db/db.tf"))
+	assert.Equal(t, 2, db.Location.Range.Start.Line)
+}
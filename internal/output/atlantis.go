@@ -0,0 +1,127 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/shopspring/decimal"
+)
+
+// AtlantisProjectLabel is the project label that sets the name of the
+// project's section in the Atlantis comment, so it can match the project
+// name in atlantis.yaml when it's different to the Infracost project name.
+const AtlantisProjectLabel = "atlantis_project"
+
+// AtlantisCommentMaxLength is the maximum length of the atlantis-comment
+// output. It's below the smallest comment size limit of the VCS providers
+// that Atlantis supports, which is Bitbucket's 32768 characters, leaving room
+// for the Terraform plan output that it's appended to.
+var AtlantisCommentMaxLength = 30000
+
+type atlantisSection struct {
+	heading string
+	details string
+	summary string
+}
+
+func (s atlantisSection) String(withDetails bool) string {
+	if withDetails && s.details != "" {
+		return fmt.Sprintf("%s\n\n%s\n%s", s.heading, s.details, s.summary)
+	}
+
+	return fmt.Sprintf("%s\n%s", s.heading, s.summary)
+}
+
+// ToAtlantisComment outputs the diff of each project in its own section
+// named after the Atlantis project, as plain text so it can be appended to
+// the plan output in Atlantis comments. If the output is longer than
+// AtlantisCommentMaxLength, the resource details are left out, then the
+// sections of the last projects are left out until it fits.
+func ToAtlantisComment(out Root, opts Options) ([]byte, error) {
+	if !opts.DiffThreshold.IsSignificant(out) {
+		return []byte(ui.StripColor(insignificantDiffMessage(out, opts.DiffThreshold))), nil
+	}
+
+	title := BuildNotification(out, "Infracost estimate", "").Title
+
+	sections := make([]atlantisSection, 0, len(out.Projects))
+	for _, project := range out.Projects {
+		if project.Diff == nil {
+			continue
+		}
+
+		sections = append(sections, atlantisProjectSection(project))
+	}
+
+	footer := "Key: ~ changed, + added, - removed"
+	if len(sections) == 0 {
+		footer = "No changes detected. Run infracost breakdown to see the full breakdown."
+	}
+
+	s := joinAtlantisSections(title, sections, footer, true)
+	if len(s) > AtlantisCommentMaxLength {
+		footer = "Resource details were left out to fit the comment size limit, run infracost diff to see them.\n" + footer
+		s = joinAtlantisSections(title, sections, footer, false)
+	}
+
+	for n := len(sections) - 1; len(s) > AtlantisCommentMaxLength && n >= 0; n-- {
+		more := fmt.Sprintf("...and %d more projects not shown", len(sections)-n)
+		if len(sections)-n == 1 {
+			more = "...and 1 more project not shown"
+		}
+		s = joinAtlantisSections(title, sections[:n], more+"\n\n"+footer, false)
+	}
+
+	return []byte(s), nil
+}
+
+func joinAtlantisSections(title string, sections []atlantisSection, footer string, withDetails bool) string {
+	parts := make([]string, 0, len(sections)+2)
+	parts = append(parts, title)
+	for _, s := range sections {
+		parts = append(parts, s.String(withDetails))
+	}
+	parts = append(parts, footer)
+
+	return strings.Join(parts, "\n\n")
+}
+
+func atlantisProjectSection(project Project) atlantisSection {
+	name := project.Name
+	if label := project.Metadata.Labels[AtlantisProjectLabel]; label != "" {
+		name = label
+	}
+
+	details := ""
+	for _, diffResource := range project.Diff.Resources {
+		oldResource := findResourceByName(project.PastBreakdown.Resources, diffResource.Name)
+		newResource := findResourceByName(project.Breakdown.Resources, diffResource.Name)
+
+		details += resourceToDiff(diffResource, oldResource, newResource, true)
+		details += "\n"
+	}
+
+	var oldCost, newCost *decimal.Decimal
+	if project.PastBreakdown != nil {
+		oldCost = project.PastBreakdown.TotalMonthlyCost
+	}
+	if project.Breakdown != nil {
+		newCost = project.Breakdown.TotalMonthlyCost
+	}
+
+	summary := fmt.Sprintf("Monthly cost change: %s (%s -> %s)",
+		formatCostChange(project.Diff.TotalMonthlyCost),
+		formatCost(oldCost),
+		formatCost(newCost),
+	)
+	if percent := formatPercentChange(oldCost, newCost); percent != "" {
+		summary += fmt.Sprintf(", %s", percent)
+	}
+
+	return atlantisSection{
+		heading: fmt.Sprintf("Project: %s", name),
+		details: strings.TrimRight(ui.StripColor(details), "\n"),
+		summary: summary,
+	}
+}
@@ -0,0 +1,71 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/shopspring/decimal"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestToAtlantisComment(t *testing.T) {
+	project := func(name string, pastCost int64, cost int64) Project {
+		resources := func(monthlyCost int64) []Resource {
+			r := make([]Resource, 0, 10)
+			for i := 0; i < 10; i++ {
+				r = append(r, Resource{
+					Name:        fmt.Sprintf("aws_instance.web[%d]", i),
+					HourlyCost:  decimalPtr(decimal.NewFromInt(monthlyCost).Div(decimal.NewFromInt(7300))),
+					MonthlyCost: decimalPtr(decimal.NewFromInt(monthlyCost).Div(decimal.NewFromInt(10))),
+				})
+			}
+			return r
+		}
+
+		return Project{
+			Name:          name,
+			Metadata:      &schema.ProjectMetadata{},
+			PastBreakdown: &Breakdown{Resources: resources(pastCost), TotalMonthlyCost: decimalPtr(decimal.NewFromInt(pastCost))},
+			Breakdown:     &Breakdown{Resources: resources(cost), TotalMonthlyCost: decimalPtr(decimal.NewFromInt(cost))},
+			Diff:          &Breakdown{Resources: resources(cost - pastCost), TotalMonthlyCost: decimalPtr(decimal.NewFromInt(cost - pastCost))},
+		}
+	}
+
+	staging := project("infracost/infracost/staging", 100, 150)
+	staging.Metadata.Labels = map[string]string{AtlantisProjectLabel: "staging"}
+	out := Root{
+		Projects: []Project{staging, project("infracost/infracost/prod", 200, 200)},
+		Summary:  &Summary{},
+	}
+
+	b, err := ToAtlantisComment(out, Options{})
+	assert.Equal(t, nil, err)
+
+	s := string(b)
+	assert.Equal(t, s, ui.StripColor(s))
+	assert.Equal(t, true, strings.HasPrefix(s, "Infracost estimate: Monthly cost will change by +$50.00 (+17%), from $300 to $350"))
+	assert.Equal(t, true, strings.Contains(s, "Project: staging\n\n~ aws_instance.web[0]"))
+	assert.Equal(t, true, strings.Contains(s, "Monthly cost change: +$50.00 ($100 -> $150), +50%"))
+	assert.Equal(t, true, strings.Contains(s, "Project: infracost/infracost/prod\n"))
+	assert.Equal(t, true, strings.HasSuffix(s, "Key: ~ changed, + added, - removed"))
+
+	defer func(n int) { AtlantisCommentMaxLength = n }(AtlantisCommentMaxLength)
+
+	AtlantisCommentMaxLength = len(s) - 1
+	b, _ = ToAtlantisComment(out, Options{})
+	s = string(b)
+	assert.Equal(t, false, strings.Contains(s, "aws_instance.web"))
+	assert.Equal(t, true, strings.Contains(s, "Project: staging\nMonthly cost change: +$50.00"))
+	assert.Equal(t, true, strings.Contains(s, "Resource details were left out"))
+
+	AtlantisCommentMaxLength = len(s) - 10
+	b, _ = ToAtlantisComment(out, Options{})
+	s = string(b)
+	assert.Equal(t, true, len(s) <= AtlantisCommentMaxLength)
+	assert.Equal(t, true, strings.Contains(s, "Project: staging"))
+	assert.Equal(t, false, strings.Contains(s, "Project: infracost/infracost/prod"))
+	assert.Equal(t, true, strings.Contains(s, "...and 1 more project not shown"))
+}
@@ -51,8 +51,8 @@ func Combine(inputs []ReportInput, opts Options) Root {
 
 	combined.Version = outputVersion
 	combined.Projects = projects
-	combined.TotalHourlyCost = totalHourlyCost
-	combined.TotalMonthlyCost = totalMonthlyCost
+	combined.TotalHourlyCost = roundTotal(totalHourlyCost)
+	combined.TotalMonthlyCost = roundTotal(totalMonthlyCost)
 	combined.TimeGenerated = time.Now()
 	combined.Summary = combinedResourceSummaries(summaries)
 
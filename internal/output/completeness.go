@@ -0,0 +1,80 @@
+package output
+
+import (
+	"github.com/infracost/infracost/internal/i18n"
+	"github.com/shopspring/decimal"
+)
+
+// Completeness is how many of the resources were priced when the deadline
+// was reached before all the prices were fetched.
+type Completeness struct {
+	PricedResources int             `json:"pricedResources"`
+	TotalResources  int             `json:"totalResources"`
+	Percent         decimal.Decimal `json:"percent"`
+}
+
+// buildCompleteness counts the priced resources of the projects, a resource
+// is only priced if all its cost components were. It returns nil if all the
+// resources were priced.
+func buildCompleteness(projects []Project) *Completeness {
+	total := 0
+	priced := 0
+
+	for _, p := range projects {
+		if p.Breakdown == nil {
+			continue
+		}
+
+		for _, r := range p.Breakdown.Resources {
+			// Free resources don't have any prices to fetch
+			if len(r.CostComponents) == 0 && len(r.SubResources) == 0 {
+				continue
+			}
+
+			total++
+			if !resourceIsUnpriced(r) {
+				priced++
+			}
+		}
+	}
+
+	if priced == total {
+		return nil
+	}
+
+	return &Completeness{
+		PricedResources: priced,
+		TotalResources:  total,
+		Percent:         decimal.NewFromInt(int64(priced)).Div(decimal.NewFromInt(int64(total))).Mul(decimal.NewFromInt(100)).Round(1),
+	}
+}
+
+func (r *Root) completenessMessage() string {
+	if r.Completeness == nil {
+		return ""
+	}
+
+	return i18n.Tf("The deadline was reached, %d of %d resources were priced (%s%% complete). The costs of the rest are missing from the totals.",
+		r.Completeness.PricedResources,
+		r.Completeness.TotalResources,
+		r.Completeness.Percent.String(),
+	)
+}
+
+// resourceIsUnpriced returns true if any of the cost components of the
+// resource or its sub-resources weren't priced.
+func resourceIsUnpriced(resource Resource) bool {
+	for _, c := range resource.CostComponents {
+		if c.Unpriced {
+			return true
+		}
+	}
+
+	for _, s := range resource.SubResources {
+		if resourceIsUnpriced(s) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,52 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestToOutputFormatCompleteness(t *testing.T) {
+	hours := decimal.NewFromInt(730)
+
+	newResource := func(name string) *schema.Resource {
+		return &schema.Resource{
+			Name: name,
+			CostComponents: []*schema.CostComponent{
+				{Name: "Instance usage", UnitMultiplier: 1, HourlyQuantity: decimalPtr(decimal.NewFromInt(1)), ProductFilter: &schema.ProductFilter{}},
+			},
+			SubResources: []*schema.Resource{
+				{Name: "root_block_device", CostComponents: []*schema.CostComponent{
+					{Name: "Storage", UnitMultiplier: 1, MonthlyQuantity: &hours, ProductFilter: &schema.ProductFilter{}},
+				}},
+			},
+		}
+	}
+
+	priced := newResource("aws_instance.priced")
+	unpriced := newResource("aws_instance.unpriced")
+	unpriced.SetUnpriced()
+
+	project := &schema.Project{
+		Name:      "my-project",
+		Resources: []*schema.Resource{priced, unpriced},
+	}
+	schema.CalculateCosts(project)
+
+	out := ToOutputFormat([]*schema.Project{project})
+
+	assert.Equal(t, 1, out.Completeness.PricedResources)
+	assert.Equal(t, 2, out.Completeness.TotalResources)
+	assert.Equal(t, "50", out.Completeness.Percent.String())
+
+	b, err := ToTable(out, Options{Fields: []string{"monthlyQuantity", "unit", "monthlyCost"}})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), "Not priced, the deadline was reached"))
+	assert.Equal(t, true, strings.Contains(string(b), "1 of 2 resources were priced (50% complete)"))
+	assert.Equal(t, false, strings.Contains(string(b), "--usage-file"))
+
+	assert.Equal(t, true, ToOutputFormat([]*schema.Project{{Resources: []*schema.Resource{priced}}}).Completeness == nil)
+}
@@ -0,0 +1,78 @@
+package output
+
+import (
+	"sort"
+
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+)
+
+// Coverage is how many of the costed resource types of a project were fully
+// estimated. A resource type is uncovered if it's not supported yet or if any
+// of its resources have costs that depend on usage that hasn't been set.
+// Free resource types aren't counted.
+type Coverage struct {
+	Project                   string
+	ResourceTypes             int
+	UnsupportedResourceTypes  []string
+	MissingUsageResourceTypes []string
+}
+
+func BuildCoverage(project *schema.Project) Coverage {
+	types := make(map[string]bool)
+	unsupported := make(map[string]bool)
+	missingUsage := make(map[string]bool)
+
+	for _, r := range project.Resources {
+		if !terraform.HasSupportedProvider(r.ResourceType) || r.NoPrice {
+			continue
+		}
+
+		types[r.ResourceType] = true
+
+		if r.IsSkipped {
+			unsupported[r.ResourceType] = true
+		} else if resourceHasNilCosts(outputResource(r)) {
+			missingUsage[r.ResourceType] = true
+		}
+	}
+
+	c := Coverage{
+		Project:                   project.Name,
+		ResourceTypes:             len(types),
+		UnsupportedResourceTypes:  sortedKeys(unsupported),
+		MissingUsageResourceTypes: make([]string, 0, len(missingUsage)),
+	}
+
+	// A type with unsupported resources is only counted once
+	for _, t := range sortedKeys(missingUsage) {
+		if !unsupported[t] {
+			c.MissingUsageResourceTypes = append(c.MissingUsageResourceTypes, t)
+		}
+	}
+
+	return c
+}
+
+// UncoveredPercent returns the percentage of the resource types that are
+// uncovered.
+func (c Coverage) UncoveredPercent() decimal.Decimal {
+	if c.ResourceTypes == 0 {
+		return decimal.Zero
+	}
+
+	uncovered := len(c.UnsupportedResourceTypes) + len(c.MissingUsageResourceTypes)
+
+	return decimal.NewFromInt(int64(uncovered)).Div(decimal.NewFromInt(int64(c.ResourceTypes))).Mul(decimal.NewFromInt(100))
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
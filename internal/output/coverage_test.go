@@ -0,0 +1,34 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestBuildCoverage(t *testing.T) {
+	priced := &schema.CostComponent{Name: "Instance usage", MonthlyCost: decimalPtr(decimal.NewFromInt(10))}
+	usageBased := &schema.CostComponent{Name: "Requests"}
+
+	project := &schema.Project{
+		Name: "my-project",
+		Resources: []*schema.Resource{
+			{Name: "aws_instance.web", ResourceType: "aws_instance", MonthlyCost: decimalPtr(decimal.NewFromInt(10)), CostComponents: []*schema.CostComponent{priced}},
+			{Name: "aws_lambda_function.fn", ResourceType: "aws_lambda_function", CostComponents: []*schema.CostComponent{usageBased}},
+			{Name: "aws_foo.bar", ResourceType: "aws_foo", IsSkipped: true},
+			{Name: "aws_iam_role.role", ResourceType: "aws_iam_role", NoPrice: true},
+		},
+	}
+
+	c := BuildCoverage(project)
+
+	assert.Equal(t, "my-project", c.Project)
+	assert.Equal(t, 3, c.ResourceTypes)
+	assert.Equal(t, []string{"aws_foo"}, c.UnsupportedResourceTypes)
+	assert.Equal(t, []string{"aws_lambda_function"}, c.MissingUsageResourceTypes)
+	assert.Equal(t, "66.7", c.UncoveredPercent().Round(1).String())
+
+	assert.Equal(t, "0", BuildCoverage(&schema.Project{}).UncoveredPercent().String())
+}
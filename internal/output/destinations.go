@@ -0,0 +1,225 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Masterminds/sprig"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+var destinationTimeout = 60 * time.Second
+
+// Format generates the output in one of the output formats.
+func Format(format string, out Root, opts Options) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case "json":
+		return ToJSON(out, opts)
+	case "html":
+		return ToHTML(out, opts)
+	case "diff":
+		return ToDiff(out, opts)
+	case "annotations":
+		return ToAnnotations(out, opts)
+	case "focus":
+		return ToFOCUS(out, opts)
+	case "prometheus":
+		return ToPrometheus(out, opts)
+	case "atlantis-comment":
+		return ToAtlantisComment(out, opts)
+	default:
+		return ToTable(out, opts)
+	}
+}
+
+// ToTemplate generates the output from a Go template, which is executed with
+// the Infracost JSON output so it has the same fields.
+func ToTemplate(tmplText string, out Root, opts Options) ([]byte, error) {
+	tmpl := template.New("output")
+	tmpl.Funcs(sprig.TxtFuncMap())
+	tmpl.Funcs(template.FuncMap{
+		"formatCost2DP":  formatCost2DP,
+		"formatPrice":    formatPrice,
+		"formatQuantity": formatQuantity,
+	})
+
+	tmpl, err := tmpl.Parse(tmplText)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "Error parsing output template")
+	}
+
+	// Add the same extra fields as the JSON output
+	if opts.GroupBy != "" {
+		grouping, err := BuildGrouping(out, opts.GroupBy)
+		if err != nil {
+			return []byte{}, err
+		}
+		out.Grouping = grouping
+	}
+	if opts.Hierarchy != nil {
+		out.CostCenters = BuildCostCenterRollups(out, opts.Hierarchy)
+	}
+	if opts.Accounts != nil {
+		out.Accounts = BuildAccountRollups(out, opts.Accounts)
+	}
+	if opts.CompareEnvironments {
+		out.Environments = BuildEnvironmentComparison(out)
+	}
+	if opts.CompareRegions {
+		out.Regions = BuildRegionComparison(out)
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, out)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "Error executing output template")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// WriteOutputs generates each of the outputs and writes it to its
+// destination. The table and diff outputs are never colored.
+func WriteOutputs(outputs []*config.Output, out Root, opts Options) error {
+	for _, o := range outputs {
+		b, err := generateOutput(o, out, opts)
+		if err != nil {
+			return err
+		}
+
+		err = writeDestination(o, b)
+		if err != nil {
+			return err
+		}
+
+		log.Infof("Wrote output to %s", redactDestination(o.Destination))
+	}
+
+	return nil
+}
+
+func generateOutput(o *config.Output, out Root, opts Options) ([]byte, error) {
+	tmplText := o.Template
+	if o.TemplateFile != "" {
+		b, err := ioutil.ReadFile(o.TemplateFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error reading output template file")
+		}
+		tmplText = string(b)
+	}
+
+	if tmplText != "" {
+		return ToTemplate(tmplText, out, opts)
+	}
+
+	b, err := Format(o.Format, out, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(ui.StripColor(string(b))), nil
+}
+
+func writeDestination(o *config.Output, b []byte) error {
+	contentType := outputContentType(o)
+
+	switch {
+	case strings.HasPrefix(o.Destination, "s3://"):
+		parts := strings.SplitN(strings.TrimPrefix(o.Destination, "s3://"), "/", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("Invalid S3 destination %s, expected the format s3://bucket/key", o.Destination)
+		}
+
+		return putS3Object(parts[0], parts[1], b, contentType)
+	case strings.HasPrefix(o.Destination, "http://") || strings.HasPrefix(o.Destination, "https://"):
+		return postDestination(o, b, contentType)
+	default:
+		err := os.MkdirAll(filepath.Dir(o.Destination), 0755)
+		if err == nil {
+			err = ioutil.WriteFile(o.Destination, b, 0644) // nolint:gosec
+		}
+		if err != nil {
+			return errors.Wrapf(err, "Error writing output to %s", o.Destination)
+		}
+
+		return nil
+	}
+}
+
+func postDestination(o *config.Output, b []byte, contentType string) error {
+	req, err := http.NewRequest("POST", o.Destination, bytes.NewBuffer(b))
+	if err != nil {
+		return errors.Wrapf(err, "Error creating request to %s", redactDestination(o.Destination))
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range o.Headers {
+		req.Header.Set(k, v)
+	}
+
+	client := &http.Client{Timeout: destinationTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "Error sending output to %s", redactDestination(o.Destination))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("Error sending output to %s: %s", redactDestination(o.Destination), resp.Status)
+	}
+
+	return nil
+}
+
+// redactDestination returns the destination without the credentials, path
+// and query of URL destinations, since they can have tokens from the
+// interpolated environment variables.
+func redactDestination(dest string) string {
+	if !strings.HasPrefix(dest, "http://") && !strings.HasPrefix(dest, "https://") {
+		return dest
+	}
+
+	u, err := url.Parse(dest)
+	if err != nil {
+		return schema.RedactedValue
+	}
+
+	redacted := fmt.Sprintf("%s://%s", u.Scheme, u.Host)
+	if strings.Trim(u.Path, "/") != "" || u.RawQuery != "" {
+		redacted += "/" + schema.RedactedValue
+	}
+
+	return redacted
+}
+
+func outputContentType(o *config.Output) string {
+	if o.Template != "" || o.TemplateFile != "" {
+		return "text/plain; charset=utf-8"
+	}
+
+	switch strings.ToLower(o.Format) {
+	case "json", "annotations":
+		return "application/json"
+	case "html":
+		return "text/html; charset=utf-8"
+	case "focus":
+		return "text/csv; charset=utf-8"
+	case "prometheus":
+		return prometheusContentType
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
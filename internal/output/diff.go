@@ -2,9 +2,12 @@ package output
 
 import (
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/dustin/go-humanize"
 	"github.com/fatih/color"
+	"github.com/infracost/infracost/internal/i18n"
 	"github.com/infracost/infracost/internal/ui"
 	"github.com/shopspring/decimal"
 )
@@ -16,6 +19,10 @@ const (
 )
 
 func ToDiff(out Root, opts Options) ([]byte, error) {
+	if !opts.DiffThreshold.IsSignificant(out) {
+		return []byte(insignificantDiffMessage(out, opts.DiffThreshold)), nil
+	}
+
 	s := ""
 
 	hasNilCosts := false
@@ -31,7 +38,7 @@ func ToDiff(out Root, opts Options) ([]byte, error) {
 		}
 
 		s += fmt.Sprintf("%s %s\n\n",
-			ui.BoldString("Project:"),
+			ui.BoldString(i18n.T("Project:")),
 			project.Name,
 		)
 
@@ -60,43 +67,59 @@ func ToDiff(out Root, opts Options) ([]byte, error) {
 			newCost = project.Breakdown.TotalMonthlyCost
 		}
 
-		s += fmt.Sprintf("%s %s\nAmount:  %s %s",
-			ui.BoldString("Monthly cost change for"),
+		amountLabel, percentLabel := i18n.T("Amount:"), i18n.T("Percent:")
+		labelWidth := utf8.RuneCountInString(amountLabel)
+		if w := utf8.RuneCountInString(percentLabel); w > labelWidth {
+			labelWidth = w
+		}
+
+		s += fmt.Sprintf("%s %s\n%s %s %s",
+			ui.BoldString(i18n.T("Monthly cost change for")),
 			ui.BoldString(project.Name),
+			padRight(amountLabel, labelWidth),
 			formatCostChange(project.Diff.TotalMonthlyCost),
 			ui.FaintStringf("(%s -> %s)", formatCost(oldCost), formatCost(newCost)),
 		)
 
 		percent := formatPercentChange(oldCost, newCost)
 		if percent != "" {
-			s += fmt.Sprintf("\nPercent: %s",
+			s += fmt.Sprintf("\n%s %s",
+				padRight(percentLabel, labelWidth),
 				percent,
 			)
 		}
 
+		if groups := planActionGroups(project); len(groups) > 0 {
+			s += "\n\n" + planActionsToDiff(groups)
+		}
+
 		if i != len(out.Projects)-1 {
 			s += "\n\n"
 		}
 	}
 
 	s += "\n\n----------------------------------\n"
-	s += fmt.Sprintf("Key: %s changed, %s added, %s removed",
+	s += i18n.Tf("Key: %s changed, %s added, %s removed",
 		opChar(UPDATED),
 		opChar(ADDED),
 		opChar(REMOVED),
 	)
 
 	if hasNilCosts {
-		s += fmt.Sprintf("\n\nTo estimate usage-based resources use --usage-file, see %s",
+		s += "\n\n" + i18n.Tf("To estimate usage-based resources use --usage-file, see %s",
 			ui.LinkString("https://infracost.io/usage-file"),
 		)
 	}
 
 	if hasEmptyDiff {
-		s += fmt.Sprintf("\n\nNo changes detected. Run %s to see the full breakdown.",
+		s += "\n\n" + i18n.Tf("No changes detected. Run %s to see the full breakdown.",
 			ui.PrimaryString("infracost breakdown"))
 	}
 
+	if msg := out.completenessMessage(); msg != "" {
+		s += "\n\n" + ui.WarningString(msg)
+	}
+
 	unsupportedMsg := out.unsupportedResourcesMessage(opts.ShowSkipped)
 	if unsupportedMsg != "" {
 		s += "\n\n" + unsupportedMsg
@@ -147,7 +170,11 @@ func resourceToDiff(diffResource Resource, oldResource *Resource, newResource *R
 		var oldComponent, newComponent *CostComponent
 
 		if oldResource != nil {
-			oldComponent = findCostComponentByName(oldResource.CostComponents, diffComponent.Name)
+			oldName := diffComponent.Name
+			if diffComponent.PastName != "" {
+				oldName = diffComponent.PastName
+			}
+			oldComponent = findCostComponentByName(oldResource.CostComponents, oldName)
 		}
 
 		if newResource != nil {
@@ -198,9 +225,16 @@ func costComponentToDiff(diffComponent CostComponent, oldComponent *CostComponen
 		newPrice = &newComponent.Price
 	}
 
-	s += fmt.Sprintf("%s %s\n", opChar(op), diffComponent.Name)
+	name := diffComponent.Name
+	if diffComponent.PastName != "" {
+		name = renamedCostComponentLabel(diffComponent.PastName, diffComponent.Name)
+	}
+
+	s += fmt.Sprintf("%s %s\n", opChar(op), name)
 
-	if oldCost == nil && newCost == nil {
+	if (oldComponent != nil && oldComponent.Unpriced) || (newComponent != nil && newComponent.Unpriced) {
+		s += "  Not priced, the deadline was reached\n"
+	} else if oldCost == nil && newCost == nil {
 		s += "  Monthly cost depends on usage\n"
 		s += fmt.Sprintf("    %s per %s%s\n",
 			formatPriceChange(diffComponent.Price),
@@ -217,6 +251,38 @@ func costComponentToDiff(diffComponent CostComponent, oldComponent *CostComponen
 	return s
 }
 
+// renamedCostComponentLabel shows which details of a renamed cost component
+// changed, e.g. "Instance usage (Linux/UNIX, on-demand, m5.large -> m5.2xlarge)".
+func renamedCostComponentLabel(pastName string, name string) string {
+	base, details := splitCostComponentName(name)
+	pastBase, pastDetails := splitCostComponentName(pastName)
+
+	if base != pastBase || len(details) != len(pastDetails) {
+		return fmt.Sprintf("%s -> %s", pastName, name)
+	}
+
+	parts := make([]string, 0, len(details))
+	for i, d := range details {
+		if d != pastDetails[i] {
+			d = fmt.Sprintf("%s -> %s", pastDetails[i], d)
+		}
+		parts = append(parts, d)
+	}
+
+	return fmt.Sprintf("%s (%s)", base, strings.Join(parts, ", "))
+}
+
+// splitCostComponentName splits the name of a cost component into the name
+// before its details in brackets and the comma-separated details.
+func splitCostComponentName(name string) (string, []string) {
+	i := strings.Index(name, "(")
+	if i == -1 || !strings.HasSuffix(name, ")") {
+		return name, nil
+	}
+
+	return strings.TrimSpace(name[:i]), strings.Split(name[i+1:len(name)-1], ", ")
+}
+
 func opChar(op int) string {
 	switch op {
 	case ADDED:
@@ -293,6 +359,13 @@ func formatPercentChange(oldCost *decimal.Decimal, newCost *decimal.Decimal) str
 	return fmt.Sprintf("%s%s%%", percentSym, humanize.FormatFloat("#,###.", f))
 }
 
+func padRight(s string, width int) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
+
 func getSym(d decimal.Decimal) string {
 	if d.IsPositive() {
 		return "+"
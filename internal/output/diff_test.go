@@ -0,0 +1,44 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/shopspring/decimal"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestToDiffRenamedCostComponent(t *testing.T) {
+	newResource := func(instanceType string, price float64) *schema.Resource {
+		c := &schema.CostComponent{
+			Name:           fmt.Sprintf("Instance usage (Linux/UNIX, on-demand, %s)", instanceType),
+			Unit:           "hours",
+			UnitMultiplier: 1,
+			HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+		}
+		c.SetPrice(decimal.NewFromFloat(price))
+
+		return &schema.Resource{Name: "aws_instance.web", CostComponents: []*schema.CostComponent{c}}
+	}
+
+	project := schema.NewProject("my-project", &schema.ProjectMetadata{})
+	project.PastResources = []*schema.Resource{newResource("m5.large", 0.096)}
+	project.Resources = []*schema.Resource{newResource("m5.2xlarge", 0.384)}
+	schema.CalculateCosts(project)
+	project.CalculateDiff()
+
+	out := ToOutputFormat([]*schema.Project{project})
+
+	c := out.Projects[0].Diff.Resources[0].CostComponents[0]
+	assert.Equal(t, "Instance usage (Linux/UNIX, on-demand, m5.2xlarge)", c.Name)
+	assert.Equal(t, "Instance usage (Linux/UNIX, on-demand, m5.large)", c.PastName)
+
+	b, err := ToDiff(out, Options{NoColor: true})
+	assert.Equal(t, nil, err)
+
+	s := ui.StripColor(string(b))
+	assert.Equal(t, true, strings.Contains(s, "~ Instance usage (Linux/UNIX, on-demand, m5.large -> m5.2xlarge)\n      +$210 ($70.08 -> $280)"))
+}
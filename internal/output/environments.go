@@ -0,0 +1,118 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/infracost/infracost/internal/i18n"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/shopspring/decimal"
+)
+
+// EnvironmentLabel is the project label that names the environment a
+// project was run for when environments are compared.
+const EnvironmentLabel = "environment"
+
+// EnvironmentComparison is the monthly cost of each resource in each
+// environment. The costs are in the same order as the environments, and are
+// nil if the resource isn't in that environment.
+type EnvironmentComparison struct {
+	Environments      []string             `json:"environments"`
+	Resources         []ComparisonResource `json:"resources"`
+	TotalMonthlyCosts []*decimal.Decimal   `json:"totalMonthlyCosts"`
+}
+
+// ComparisonResource is the monthly cost of a resource in each of the
+// compared projects.
+type ComparisonResource struct {
+	Name         string             `json:"name"`
+	MonthlyCosts []*decimal.Decimal `json:"monthlyCosts"`
+}
+
+// BuildEnvironmentComparison compares the projects that have the environment
+// label, in the order they were run.
+func BuildEnvironmentComparison(out Root) *EnvironmentComparison {
+	c := &EnvironmentComparison{}
+	c.Environments, c.Resources, c.TotalMonthlyCosts = buildLabelComparison(out, EnvironmentLabel)
+
+	return c
+}
+
+// buildLabelComparison compares the projects that have the label, in the
+// order they were run. It returns the label values, the monthly cost of each
+// resource in each project and the total monthly cost of each project.
+func buildLabelComparison(out Root, label string) ([]string, []ComparisonResource, []*decimal.Decimal) {
+	values := []string{}
+	resources := []ComparisonResource{}
+	totals := []*decimal.Decimal{}
+
+	projects := make([]Project, 0, len(out.Projects))
+	for _, p := range out.Projects {
+		if p.Metadata == nil || p.Metadata.Labels[label] == "" {
+			continue
+		}
+
+		projects = append(projects, p)
+		values = append(values, p.Metadata.Labels[label])
+	}
+
+	resourceCosts := make(map[string][]*decimal.Decimal)
+
+	for i, p := range projects {
+		var total *decimal.Decimal
+
+		if p.Breakdown != nil {
+			for _, r := range p.Breakdown.Resources {
+				if _, ok := resourceCosts[r.Name]; !ok {
+					resourceCosts[r.Name] = make([]*decimal.Decimal, len(projects))
+				}
+				resourceCosts[r.Name][i] = r.MonthlyCost
+			}
+			total = p.Breakdown.TotalMonthlyCost
+		}
+
+		totals = append(totals, total)
+	}
+
+	names := make([]string, 0, len(resourceCosts))
+	for name := range resourceCosts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		resources = append(resources, ComparisonResource{
+			Name:         name,
+			MonthlyCosts: resourceCosts[name],
+		})
+	}
+
+	return values, resources, totals
+}
+
+func environmentComparisonToTable(c *EnvironmentComparison) string {
+	return comparisonToTable("Monthly cost by environment", c.Environments, c.Resources, c.TotalMonthlyCosts)
+}
+
+func comparisonToTable(title string, columns []string, resources []ComparisonResource, totals []*decimal.Decimal) string {
+	t := newSummaryTable()
+	appendSummaryHeader(t, 1, append([]string{i18n.T("Name")}, columns...)...)
+
+	for _, r := range resources {
+		row := table.Row{r.Name}
+		for _, cost := range r.MonthlyCosts {
+			row = append(row, formatCost2DP(cost))
+		}
+		t.AppendRow(row)
+	}
+
+	totalRow := table.Row{ui.BoldString(i18n.T("Total"))}
+	for _, cost := range totals {
+		totalRow = append(totalRow, ui.BoldString(formatCost2DP(cost)))
+	}
+	t.AppendRow(table.Row{""})
+	t.AppendRow(totalRow)
+
+	return fmt.Sprintf("%s\n\n%s", ui.BoldString(title), t.Render())
+}
@@ -0,0 +1,68 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/infracost/infracost/internal/i18n"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/shopspring/decimal"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestBuildEnvironmentComparison(t *testing.T) {
+	out := Root{
+		Projects: []Project{
+			{
+				Name:     "dev",
+				Metadata: &schema.ProjectMetadata{Labels: map[string]string{EnvironmentLabel: "dev"}},
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web", MonthlyCost: decimalPtr(decimal.NewFromInt(10))},
+					},
+					TotalMonthlyCost: decimalPtr(decimal.NewFromInt(10)),
+				},
+			},
+			{
+				Name:     "prod",
+				Metadata: &schema.ProjectMetadata{Labels: map[string]string{EnvironmentLabel: "prod"}},
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web", MonthlyCost: decimalPtr(decimal.NewFromInt(40))},
+						{Name: "aws_db_instance.db", MonthlyCost: decimalPtr(decimal.NewFromInt(100))},
+					},
+					TotalMonthlyCost: decimalPtr(decimal.NewFromInt(140)),
+				},
+			},
+			{Name: "shared", Breakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(5))}},
+		},
+	}
+
+	c := BuildEnvironmentComparison(out)
+
+	assert.Equal(t, []string{"dev", "prod"}, c.Environments)
+	assert.Equal(t, 2, len(c.Resources))
+
+	db := c.Resources[0]
+	assert.Equal(t, "aws_db_instance.db", db.Name)
+	assert.Equal(t, true, db.MonthlyCosts[0] == nil)
+	assert.Equal(t, "100", db.MonthlyCosts[1].String())
+
+	web := c.Resources[1]
+	assert.Equal(t, "10", web.MonthlyCosts[0].String())
+	assert.Equal(t, "40", web.MonthlyCosts[1].String())
+
+	assert.Equal(t, "10", c.TotalMonthlyCosts[0].String())
+	assert.Equal(t, "140", c.TotalMonthlyCosts[1].String())
+
+	table := ui.StripColor(environmentComparisonToTable(c))
+	assert.Equal(t, true, strings.Contains(table, "aws_db_instance.db"))
+	assert.Equal(t, true, strings.Contains(table, "$140"))
+	err := i18n.SetLocale("de")
+	assert.Equal(t, nil, err)
+	defer func() { _ = i18n.SetLocale("en") }()
+
+	table = ui.StripColor(environmentComparisonToTable(c))
+	assert.Equal(t, true, strings.Contains(table, "Gesamt"))
+}
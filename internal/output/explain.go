@@ -0,0 +1,109 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ToExplainMarkdown explains how the monthly cost of the resource with the
+// address was calculated, as Markdown so it can be posted as a comment. The
+// breakdown of the first project that has the resource is used.
+func ToExplainMarkdown(out Root, address string) ([]byte, error) {
+	for _, p := range out.Projects {
+		if p.Breakdown == nil {
+			continue
+		}
+
+		for _, r := range p.Breakdown.Resources {
+			if r.Name == address {
+				return []byte(explainResource(p, r)), nil
+			}
+		}
+	}
+
+	return nil, errors.Errorf("Resource %s was not found, it might not be supported or might have no costs", address)
+}
+
+func explainResource(p Project, r Resource) string {
+	s := fmt.Sprintf("#### Cost explanation for `%s`\n\n", r.Name)
+	s += fmt.Sprintf("Project: `%s`\n\n", p.Name)
+
+	s += "| Cost component | Monthly qty | Unit | Price | Monthly cost |\n"
+	s += "| --- | ---: | --- | ---: | ---: |\n"
+
+	derivations := make([]string, 0)
+
+	var addRows func(r Resource, prefix string)
+	addRows = func(r Resource, prefix string) {
+		for _, c := range r.CostComponents {
+			name := escapeMarkdownTableCell(prefix + c.Name)
+
+			s += fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
+				name,
+				formatQuantity(c.MonthlyQuantity),
+				escapeMarkdownTableCell(c.Unit),
+				formatPrice(c.Price),
+				formatCost2DP(c.MonthlyCost),
+			)
+
+			derivations = append(derivations, fmt.Sprintf("- **%s**: %s", name, explainCostComponent(c)))
+		}
+
+		for _, sub := range r.SubResources {
+			addRows(sub, prefix+sub.Name+" › ")
+		}
+	}
+	addRows(r, "")
+
+	s += "\n**How it's calculated**\n\n"
+	s += strings.Join(derivations, "\n")
+	s += fmt.Sprintf("\n\n**Monthly cost: %s**\n", formatCost2DP(r.MonthlyCost))
+
+	return s
+}
+
+var markdownTableCellReplacer = strings.NewReplacer("|", "\\|", "\r\n", " ", "\n", " ", "\r", " ")
+
+// escapeMarkdownTableCell escapes the pipes and newlines that would break the
+// table, since the cost component names can include values from the HCL.
+func escapeMarkdownTableCell(s string) string {
+	return markdownTableCellReplacer.Replace(s)
+}
+
+// explainCostComponent returns the calculation of the cost component's
+// monthly cost and where its price came from.
+func explainCostComponent(c CostComponent) string {
+	if c.MonthlyQuantity == nil {
+		return fmt.Sprintf("monthly cost depends on usage, %s per %s, set the usage in the usage file", formatPrice(c.Price), c.Unit)
+	}
+
+	s := fmt.Sprintf("%s %s × %s = %s", formatQuantity(c.MonthlyQuantity), c.Unit, formatPrice(c.Price), formatCost2DP(c.MonthlyCost))
+
+	if c.MonthlyCost != nil && !c.MonthlyQuantity.Mul(c.Price).Round(2).Equal(c.MonthlyCost.Round(2)) {
+		s += " after discounts"
+	}
+
+	if c.PriceSource == nil {
+		return s
+	}
+
+	source := strings.TrimSpace(strings.Join([]string{
+		strings.ToUpper(c.PriceSource.VendorName),
+		c.PriceSource.Service,
+		c.PriceSource.Region,
+	}, " "))
+	if c.PriceSource.SKU != "" {
+		source += fmt.Sprintf(", SKU %s", c.PriceSource.SKU)
+	}
+	if c.PriceSource.Override != "" {
+		source += fmt.Sprintf(", overridden by %s", c.PriceSource.Override)
+	}
+
+	if source != "" {
+		s += fmt.Sprintf(" (%s)", strings.TrimPrefix(source, ", "))
+	}
+
+	return s
+}
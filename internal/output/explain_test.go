@@ -0,0 +1,62 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestToExplainMarkdown(t *testing.T) {
+	out := Root{
+		Projects: []Project{
+			{
+				Name: "my-project",
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{
+							Name:        "aws_instance.web",
+							MonthlyCost: decimalPtr(decimal.NewFromFloat(7.592)),
+							CostComponents: []CostComponent{
+								{
+									Name:            "Instance usage (Linux/UNIX, on-demand, t3.micro)",
+									Unit:            "hours",
+									MonthlyQuantity: decimalPtr(decimal.NewFromInt(730)),
+									Price:           decimal.NewFromFloat(0.0104),
+									MonthlyCost:     decimalPtr(decimal.NewFromFloat(7.592)),
+									PriceSource:     &PriceSource{VendorName: "aws", Service: "AmazonEC2", Region: "us-east-1", SKU: "ABC123"},
+								},
+							},
+							SubResources: []Resource{
+								{
+									Name: "root_block_device",
+									CostComponents: []CostComponent{
+										{Name: "Storage (general purpose SSD, gp2)", Unit: "GB", Price: decimal.NewFromFloat(0.1)},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	b, err := ToExplainMarkdown(out, "aws_instance.web")
+	assert.Equal(t, nil, err)
+
+	s := string(b)
+	assert.Equal(t, true, strings.Contains(s, "`aws_instance.web`"))
+	assert.Equal(t, true, strings.Contains(s, "730 hours × $0.0104 = $7.59 (AWS AmazonEC2 us-east-1, SKU ABC123)"))
+	assert.Equal(t, true, strings.Contains(s, "**root_block_device › Storage (general purpose SSD, gp2)**: monthly cost depends on usage"))
+	assert.Equal(t, true, strings.Contains(s, "**Monthly cost: $7.59**"))
+
+	_, err = ToExplainMarkdown(out, "aws_instance.missing")
+	assert.NotEqual(t, nil, err)
+
+	out.Projects[0].Breakdown.Resources[0].CostComponents[0].Name = "Instance usage (a|b\nc)"
+	b, err = ToExplainMarkdown(out, "aws_instance.web")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), "| Instance usage (a\\|b c) | 730 | hours |"))
+}
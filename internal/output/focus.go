@@ -0,0 +1,163 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// focusColumns are the columns of the FOCUS output. The names follow the
+// FinOps Open Cost and Usage Specification so pipelines that ingest billing
+// data can ingest the estimates too, and the columns that aren't in the
+// specification have the x_ prefix it uses for custom columns. The costs are
+// monthly estimates rather than billed amounts.
+var focusColumns = []string{
+	"ProviderName",
+	"ServiceName",
+	"RegionId",
+	"SkuId",
+	"SkuPriceId",
+	"ResourceId",
+	"ResourceName",
+	"ResourceType",
+	"ChargeCategory",
+	"ChargeDescription",
+	"ConsumedQuantity",
+	"ConsumedUnit",
+	"ListUnitPrice",
+	"EffectiveCost",
+	"BillingCurrency",
+	"Tags",
+	"x_ProjectName",
+	"x_PriceOverride",
+}
+
+// ToFOCUS outputs a CSV row for each cost component of the projects, in the
+// FOCUS billing data format. Cost components without a monthly cost, e.g.
+// usage-based ones without usage, are skipped since they have no cost to
+// ingest.
+func ToFOCUS(out Root, opts Options) ([]byte, error) {
+	buf := bytes.NewBuffer([]byte{})
+	w := csv.NewWriter(buf)
+
+	err := w.Write(focusColumns)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "Error writing FOCUS output")
+	}
+
+	for _, p := range out.Projects {
+		if p.Breakdown == nil {
+			continue
+		}
+
+		for _, r := range p.Breakdown.Resources {
+			tags, err := focusTags(r.Tags)
+			if err != nil {
+				return []byte{}, err
+			}
+
+			for _, row := range focusResourceRows(p, r, r.Name, addressResourceType(r.Name), tags) {
+				err = w.Write(row)
+				if err != nil {
+					return []byte{}, errors.Wrap(err, "Error writing FOCUS output")
+				}
+			}
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return []byte{}, errors.Wrap(err, "Error writing FOCUS output")
+	}
+
+	// The other formats don't end with a newline, so the output is printed the
+	// same way for all of them
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// focusResourceRows returns the rows of the cost components of the resource
+// and its sub-resources. Sub-resources are billed as part of the top-level
+// resource, so they have its ID, type and tags.
+func focusResourceRows(p Project, r Resource, resourceID string, resourceType string, tags string) [][]string {
+	rows := make([][]string, 0, len(r.CostComponents))
+
+	for _, c := range r.CostComponents {
+		if c.MonthlyCost == nil {
+			continue
+		}
+
+		var providerName, serviceName, region, sku, priceID, override string
+		if c.PriceSource != nil {
+			providerName = c.PriceSource.VendorName
+			serviceName = c.PriceSource.Service
+			region = c.PriceSource.Region
+			sku = c.PriceSource.SKU
+			priceID = c.PriceSource.PriceHash
+			override = c.PriceSource.Override
+		}
+
+		rows = append(rows, []string{
+			providerName,
+			serviceName,
+			region,
+			sku,
+			priceID,
+			resourceID,
+			r.Name,
+			resourceType,
+			"Usage",
+			c.Name,
+			formatFOCUSDecimal(c.MonthlyQuantity),
+			c.Unit,
+			c.Price.String(),
+			c.MonthlyCost.String(),
+			"USD",
+			tags,
+			p.Name,
+			override,
+		})
+	}
+
+	for _, s := range r.SubResources {
+		rows = append(rows, focusResourceRows(p, s, resourceID, resourceType, tags)...)
+	}
+
+	return rows
+}
+
+// focusTags returns the tags as a JSON object, which is how FOCUS stores them.
+func focusTags(tags map[string]string) (string, error) {
+	if len(tags) == 0 {
+		return "", nil
+	}
+
+	b, err := json.Marshal(tags)
+	if err != nil {
+		return "", errors.Wrap(err, "Error writing FOCUS tags")
+	}
+
+	return string(b), nil
+}
+
+// addressResourceType returns the resource type from a resource address,
+// e.g. aws_instance from module.web.aws_instance.web["a"].
+func addressResourceType(address string) string {
+	parts := strings.Split(addressIndexRegex.ReplaceAllString(address, ""), ".")
+	if len(parts) < 2 {
+		return ""
+	}
+
+	return parts[len(parts)-2]
+}
+
+func formatFOCUSDecimal(d *decimal.Decimal) string {
+	if d == nil {
+		return ""
+	}
+
+	return d.String()
+}
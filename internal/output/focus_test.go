@@ -0,0 +1,61 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestToFOCUS(t *testing.T) {
+	out := Root{
+		Projects: []Project{
+			{
+				Name: "infracost/infracost/prod",
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{
+							Name: "module.web.aws_instance.web[\"a.b\"]",
+							Tags: map[string]string{"team": "web"},
+							CostComponents: []CostComponent{
+								{
+									Name:            "Instance usage (Linux/UNIX, on-demand, t3.micro)",
+									Unit:            "hours",
+									MonthlyQuantity: decimalPtr(decimal.NewFromInt(730)),
+									Price:           decimal.RequireFromString("0.0104"),
+									MonthlyCost:     decimalPtr(decimal.RequireFromString("7.592")),
+									PriceSource:     &PriceSource{VendorName: "aws", Service: "AmazonEC2", Region: "us-east-1", SKU: "ABC123", PriceHash: "hash"},
+								},
+								{Name: "CPU credits", Unit: "vCPU-hours"},
+							},
+							SubResources: []Resource{
+								{
+									Name: "root_block_device",
+									CostComponents: []CostComponent{
+										{
+											Name:            "Storage (general purpose SSD, gp2)",
+											Unit:            "GB",
+											MonthlyQuantity: decimalPtr(decimal.NewFromInt(8)),
+											Price:           decimal.RequireFromString("0.1"),
+											MonthlyCost:     decimalPtr(decimal.RequireFromString("0.8")),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	b, err := ToFOCUS(out, Options{})
+	assert.Equal(t, nil, err)
+
+	lines := strings.Split(string(b), "\n")
+	assert.Equal(t, 3, len(lines))
+	assert.Equal(t, strings.Join(focusColumns, ","), lines[0])
+	assert.Equal(t, `aws,AmazonEC2,us-east-1,ABC123,hash,"module.web.aws_instance.web[""a.b""]","module.web.aws_instance.web[""a.b""]",aws_instance,Usage,"Instance usage (Linux/UNIX, on-demand, t3.micro)",730,hours,0.0104,7.592,USD,"{""team"":""web""}",infracost/infracost/prod,`, lines[1])
+	assert.Equal(t, `,,,,,"module.web.aws_instance.web[""a.b""]",root_block_device,aws_instance,Usage,"Storage (general purpose SSD, gp2)",8,GB,0.1,0.8,USD,"{""team"":""web""}",infracost/infracost/prod,`, lines[2])
+}
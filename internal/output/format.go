@@ -20,10 +20,11 @@ func formatCost(d *decimal.Decimal) string {
 		return "-"
 	}
 
-	f, _ := d.Float64()
+	f, _ := roundForDisplay(d, 2).Float64()
 
 	s := humanize.FormatFloat("#,###.##", f)
 	if d.GreaterThanOrEqual(decimal.NewFromInt(int64(roundCostsAbove))) {
+		f, _ = roundForDisplay(d, 0).Float64()
 		s = humanize.FormatFloat("#,###.", f)
 	}
 
@@ -35,7 +36,7 @@ func formatCost2DP(d *decimal.Decimal) string {
 		return "-"
 	}
 
-	f, _ := d.Float64()
+	f, _ := roundForDisplay(d, 2).Float64()
 
 	s := humanize.FormatFloat("#,###.##", f)
 	return "$" + s
@@ -0,0 +1,161 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/infracost/infracost/internal/i18n"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/shopspring/decimal"
+)
+
+const missingGroupValue = "(missing)"
+
+var validGroupByKinds = []string{"tag", "label"}
+
+type CostGroup struct {
+	Value            string           `json:"value"`
+	ResourceCount    int              `json:"resourceCount"`
+	TotalMonthlyCost *decimal.Decimal `json:"totalMonthlyCost"`
+}
+
+type Grouping struct {
+	By               string      `json:"by"`
+	Groups           []CostGroup `json:"groups"`
+	MissingResources []string    `json:"missingResources,omitempty"`
+}
+
+// ParseGroupBy splits a group by string, e.g. tag:team, into its kind and key.
+func ParseGroupBy(groupBy string) (string, string, error) {
+	p := strings.SplitN(groupBy, ":", 2)
+	if len(p) != 2 || p[1] == "" || !contains(validGroupByKinds, p[0]) {
+		return "", "", fmt.Errorf("Invalid group by '%s', expected one of the formats: %s", groupBy, strings.Join(groupByFormats(), ", "))
+	}
+
+	return p[0], p[1], nil
+}
+
+func groupByFormats() []string {
+	f := make([]string, 0, len(validGroupByKinds))
+	for _, k := range validGroupByKinds {
+		f = append(f, fmt.Sprintf("%s:<key>", k))
+	}
+	return f
+}
+
+// BuildGrouping totals the monthly cost of the top-level resources in the
+// breakdown of each project by the value of the group by key, which is either
+// a resource tag or a project label. Resources without a value for the key are
+// added to a missing group and listed so they can be fixed. For labels the
+// projects are listed instead.
+func BuildGrouping(out Root, groupBy string) (*Grouping, error) {
+	kind, key, err := ParseGroupBy(groupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	groupMap := make(map[string]*CostGroup)
+	missing := make([]string, 0)
+
+	for _, project := range out.Projects {
+		if project.Breakdown == nil {
+			continue
+		}
+
+		var labelValue string
+		if kind == "label" {
+			if project.Metadata != nil {
+				labelValue = project.Metadata.Labels[key]
+			}
+			if labelValue == "" {
+				labelValue = missingGroupValue
+				missing = append(missing, project.Name)
+			}
+		}
+
+		for _, r := range project.Breakdown.Resources {
+			v := labelValue
+			if kind == "tag" {
+				var ok bool
+				v, ok = r.Tags[key]
+				if !ok || v == "" {
+					v = missingGroupValue
+					missing = append(missing, r.Name)
+				}
+			}
+
+			g, ok := groupMap[v]
+			if !ok {
+				g = &CostGroup{
+					Value:            v,
+					TotalMonthlyCost: decimalPtr(decimal.Zero),
+				}
+				groupMap[v] = g
+			}
+
+			g.ResourceCount++
+			if r.MonthlyCost != nil {
+				g.TotalMonthlyCost = decimalPtr(g.TotalMonthlyCost.Add(*r.MonthlyCost))
+			}
+		}
+	}
+
+	groups := make([]CostGroup, 0, len(groupMap))
+	for _, g := range groupMap {
+		groups = append(groups, *g)
+	}
+
+	// Sort by most expensive first, always keeping the missing group at the end
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Value == missingGroupValue || groups[j].Value == missingGroupValue {
+			return groups[j].Value == missingGroupValue && groups[i].Value != missingGroupValue
+		}
+		if groups[i].TotalMonthlyCost.Equal(*groups[j].TotalMonthlyCost) {
+			return groups[i].Value < groups[j].Value
+		}
+		return groups[i].TotalMonthlyCost.GreaterThan(*groups[j].TotalMonthlyCost)
+	})
+
+	sort.Strings(missing)
+
+	return &Grouping{
+		By:               groupBy,
+		Groups:           groups,
+		MissingResources: missing,
+	}, nil
+}
+
+func groupingToTable(grouping *Grouping) string {
+	t := newSummaryTable()
+	appendSummaryHeader(t, 1, "Value", "Resources", i18n.T("Monthly Cost"))
+
+	for _, g := range grouping.Groups {
+		t.AppendRow(table.Row{g.Value, g.ResourceCount, formatCost2DP(g.TotalMonthlyCost)})
+	}
+
+	s := fmt.Sprintf("%s %s\n\n%s", ui.BoldString("Monthly cost by"), grouping.By, t.Render())
+
+	if len(grouping.MissingResources) > 0 {
+		kind, key, _ := ParseGroupBy(grouping.By)
+
+		resourceLabel := "resources are"
+		if len(grouping.MissingResources) == 1 {
+			resourceLabel = "resource is"
+		}
+		if kind == "label" {
+			resourceLabel = "projects are"
+			if len(grouping.MissingResources) == 1 {
+				resourceLabel = "project is"
+			}
+		}
+
+		s += fmt.Sprintf("\n\n%d %s missing the %s %s:", len(grouping.MissingResources), resourceLabel, ui.PrimaryString(key), kind)
+		for _, name := range grouping.MissingResources {
+			s += fmt.Sprintf("\n  %s", name)
+		}
+	}
+
+	return s
+}
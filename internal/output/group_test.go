@@ -0,0 +1,103 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestBuildGrouping(t *testing.T) {
+	out := Root{
+		Projects: []Project{
+			{
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{
+							Name:        "aws_instance.web",
+							Tags:        map[string]string{"team": "frontend"},
+							MonthlyCost: decimalPtr(decimal.NewFromInt(100)),
+						},
+						{
+							Name:        "aws_instance.api",
+							Tags:        map[string]string{"team": "backend"},
+							MonthlyCost: decimalPtr(decimal.NewFromInt(200)),
+						},
+						{
+							Name:        "aws_lambda_function.worker",
+							Tags:        map[string]string{"team": "backend"},
+							MonthlyCost: nil,
+						},
+						{
+							Name:        "aws_nat_gateway.nat",
+							MonthlyCost: decimalPtr(decimal.NewFromInt(30)),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	grouping, err := BuildGrouping(out, "tag:team")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, len(grouping.Groups))
+
+	assert.Equal(t, "backend", grouping.Groups[0].Value)
+	assert.Equal(t, 2, grouping.Groups[0].ResourceCount)
+	assert.Equal(t, "200", grouping.Groups[0].TotalMonthlyCost.String())
+
+	assert.Equal(t, "frontend", grouping.Groups[1].Value)
+	assert.Equal(t, missingGroupValue, grouping.Groups[2].Value)
+	assert.Equal(t, []string{"aws_nat_gateway.nat"}, grouping.MissingResources)
+
+	_, err = BuildGrouping(out, "team")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBuildGroupingByLabel(t *testing.T) {
+	out := Root{
+		Projects: []Project{
+			{
+				Name:     "api",
+				Metadata: &schema.ProjectMetadata{Labels: map[string]string{"env": "prod"}},
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.api", MonthlyCost: decimalPtr(decimal.NewFromInt(100))},
+						{Name: "aws_db_instance.db", MonthlyCost: decimalPtr(decimal.NewFromInt(50))},
+					},
+				},
+			},
+			{
+				Name:     "api-staging",
+				Metadata: &schema.ProjectMetadata{Labels: map[string]string{"env": "staging"}},
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.api", MonthlyCost: decimalPtr(decimal.NewFromInt(20))},
+					},
+				},
+			},
+			{
+				Name:     "sandbox",
+				Metadata: &schema.ProjectMetadata{},
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.test", MonthlyCost: decimalPtr(decimal.NewFromInt(5))},
+					},
+				},
+			},
+		},
+	}
+
+	grouping, err := BuildGrouping(out, "label:env")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, len(grouping.Groups))
+
+	assert.Equal(t, "prod", grouping.Groups[0].Value)
+	assert.Equal(t, 2, grouping.Groups[0].ResourceCount)
+	assert.Equal(t, "150", grouping.Groups[0].TotalMonthlyCost.String())
+
+	assert.Equal(t, "staging", grouping.Groups[1].Value)
+	assert.Equal(t, missingGroupValue, grouping.Groups[2].Value)
+	assert.Equal(t, []string{"sandbox"}, grouping.MissingResources)
+}
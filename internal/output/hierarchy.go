@@ -0,0 +1,149 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/infracost/infracost/internal/i18n"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+var hierarchyVersion = "0.1"
+
+// Hierarchy defines the cost centers that projects roll up to, e.g.
+// org → department → team. Levels names each depth of the tree.
+type Hierarchy struct {
+	Version     string        `yaml:"version"`
+	Levels      []string      `yaml:"levels,omitempty"`
+	CostCenters []*CostCenter `yaml:"cost_centers"`
+}
+
+// CostCenter is a node in the hierarchy. Projects are glob patterns that are
+// matched against the project names and paths. A project is assigned to the
+// first cost center it matches.
+type CostCenter struct {
+	Name     string        `yaml:"name"`
+	Projects []string      `yaml:"projects,omitempty"`
+	Children []*CostCenter `yaml:"children,omitempty"`
+}
+
+// CostCenterRollups are the monthly costs of the cost centers, where each
+// cost center's total includes its projects and all its children.
+type CostCenterRollups struct {
+	CostCenters        []CostCenterRollup `json:"costCenters"`
+	UnassignedProjects []string           `json:"unassignedProjects,omitempty"`
+}
+
+type CostCenterRollup struct {
+	Name             string             `json:"name"`
+	Level            string             `json:"level,omitempty"`
+	TotalMonthlyCost *decimal.Decimal   `json:"totalMonthlyCost"`
+	Projects         []string           `json:"projects,omitempty"`
+	Children         []CostCenterRollup `json:"children,omitempty"`
+}
+
+func LoadHierarchy(path string) (*Hierarchy, error) {
+	var h Hierarchy
+	err := loadRollupFile(path, "hierarchy", hierarchyVersion, &h)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range h.CostCenters {
+		if err := validateCostCenter(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return &h, nil
+}
+
+func validateCostCenter(c *CostCenter) error {
+	if c.Name == "" {
+		return errors.New("Invalid hierarchy file: all cost centers must have a name")
+	}
+
+	err := validateProjectPatterns("hierarchy", fmt.Sprintf("cost center %s", c.Name), c.Projects)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range c.Children {
+		if err := validateCostCenter(child); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// BuildCostCenterRollups totals the monthly costs of the projects for each
+// cost center in the hierarchy.
+func BuildCostCenterRollups(out Root, h *Hierarchy) *CostCenterRollups {
+	assigned := make(map[int]bool)
+
+	rollups := &CostCenterRollups{
+		CostCenters: make([]CostCenterRollup, 0, len(h.CostCenters)),
+	}
+
+	for _, c := range h.CostCenters {
+		rollups.CostCenters = append(rollups.CostCenters, rollupCostCenter(out, h, c, 0, assigned))
+	}
+
+	rollups.UnassignedProjects = unassignedProjects(out, assigned)
+
+	return rollups
+}
+
+func rollupCostCenter(out Root, h *Hierarchy, c *CostCenter, depth int, assigned map[int]bool) CostCenterRollup {
+	r := CostCenterRollup{
+		Name: c.Name,
+	}
+
+	if depth < len(h.Levels) {
+		r.Level = h.Levels[depth]
+	}
+
+	r.Projects, r.TotalMonthlyCost = rollupProjects(out, assigned, func(p Project) bool {
+		return matchesProjectPatterns(p, c.Projects)
+	})
+
+	for _, child := range c.Children {
+		childRollup := rollupCostCenter(out, h, child, depth+1, assigned)
+		r.TotalMonthlyCost = decimalPtr(r.TotalMonthlyCost.Add(*childRollup.TotalMonthlyCost))
+		r.Children = append(r.Children, childRollup)
+	}
+
+	return r
+}
+
+func costCenterRollupsToTable(rollups *CostCenterRollups) string {
+	t := newSummaryTable()
+	appendSummaryHeader(t, 2, i18n.T("Name"), "Level", i18n.T("Monthly Cost"))
+
+	for _, r := range rollups.CostCenters {
+		t.AppendRow(table.Row{ui.BoldString(r.Name), r.Level, formatCost2DP(r.TotalMonthlyCost)})
+		buildCostCenterRows(t, r, "")
+	}
+
+	s := fmt.Sprintf("%s\n\n%s", ui.BoldString("Monthly cost by cost center"), t.Render())
+	s += unassignedProjectsToText(rollups.UnassignedProjects, "a cost center")
+
+	return s
+}
+
+func buildCostCenterRows(t table.Writer, r CostCenterRollup, prefix string) {
+	for i, child := range r.Children {
+		labelPrefix := prefix + "├─"
+		nextPrefix := prefix + "│  "
+		if i == len(r.Children)-1 {
+			labelPrefix = prefix + "└─"
+			nextPrefix = prefix + "   "
+		}
+
+		t.AppendRow(table.Row{fmt.Sprintf("%s %s", ui.FaintString(labelPrefix), child.Name), child.Level, formatCost2DP(child.TotalMonthlyCost)})
+		buildCostCenterRows(t, child, nextPrefix)
+	}
+}
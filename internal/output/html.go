@@ -40,8 +40,9 @@ func ToHTML(out Root, opts Options) ([]byte, error) {
 	err = tmpl.Execute(bufw, struct {
 		Root                        Root
 		UnsupportedResourcesMessage string
+		CompletenessMessage         string
 		Options                     Options
-	}{out, unsupportedResourcesMessage, opts})
+	}{out, unsupportedResourcesMessage, out.completenessMessage(), opts})
 	if err != nil {
 		return []byte{}, err
 	}
@@ -5,5 +5,29 @@ import (
 )
 
 func ToJSON(out Root, opts Options) ([]byte, error) {
+	if opts.GroupBy != "" {
+		grouping, err := BuildGrouping(out, opts.GroupBy)
+		if err != nil {
+			return []byte{}, err
+		}
+		out.Grouping = grouping
+	}
+
+	if opts.Hierarchy != nil {
+		out.CostCenters = BuildCostCenterRollups(out, opts.Hierarchy)
+	}
+
+	if opts.Accounts != nil {
+		out.Accounts = BuildAccountRollups(out, opts.Accounts)
+	}
+
+	if opts.CompareEnvironments {
+		out.Environments = BuildEnvironmentComparison(out)
+	}
+
+	if opts.CompareRegions {
+		out.Regions = BuildRegionComparison(out)
+	}
+
 	return json.Marshal(out)
 }
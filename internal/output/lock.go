@@ -0,0 +1,161 @@
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+var lockFileVersion = "0.1"
+
+// LockFile records the approved monthly costs of the projects so that later
+// runs can check if the costs have changed since they were approved.
+type LockFile struct {
+	Version          string           `json:"version"`
+	TotalMonthlyCost *decimal.Decimal `json:"totalMonthlyCost"`
+	TimeGenerated    time.Time        `json:"timeGenerated"`
+	Projects         []LockProject    `json:"projects"`
+}
+
+type LockProject struct {
+	Name             string           `json:"name"`
+	TotalMonthlyCost *decimal.Decimal `json:"totalMonthlyCost"`
+	InputsHash       string           `json:"inputsHash"`
+}
+
+// NewLockFile creates a lock file from the planned breakdown of each project.
+func NewLockFile(out Root) *LockFile {
+	projects := make([]LockProject, 0, len(out.Projects))
+	for _, p := range out.Projects {
+		var totalMonthlyCost *decimal.Decimal
+		var resources []Resource
+		if p.Breakdown != nil {
+			totalMonthlyCost = p.Breakdown.TotalMonthlyCost
+			resources = p.Breakdown.Resources
+		}
+
+		projects = append(projects, LockProject{
+			Name:             p.Name,
+			TotalMonthlyCost: totalMonthlyCost,
+			InputsHash:       inputsHash(resources),
+		})
+	}
+
+	return &LockFile{
+		Version:          lockFileVersion,
+		TotalMonthlyCost: out.TotalMonthlyCost,
+		TimeGenerated:    out.TimeGenerated,
+		Projects:         projects,
+	}
+}
+
+// inputsHash hashes the resources and the quantities of their cost components,
+// so it changes if the Terraform code or usage changes, but not if only the
+// prices change.
+func inputsHash(resources []Resource) string {
+	var b strings.Builder
+	writeResourceInputs(&b, resources, "")
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(b.String())))
+}
+
+func writeResourceInputs(b *strings.Builder, resources []Resource, prefix string) {
+	for _, r := range resources {
+		name := prefix + r.Name
+		b.WriteString(name + "\n")
+		for _, c := range r.CostComponents {
+			b.WriteString(fmt.Sprintf("%s|%s|%s|%s|%s\n", name, c.Name, c.Unit, formatLockQuantity(c.HourlyQuantity), formatLockQuantity(c.MonthlyQuantity)))
+		}
+		writeResourceInputs(b, r.SubResources, name+"/")
+	}
+}
+
+func formatLockQuantity(d *decimal.Decimal) string {
+	if d == nil {
+		return ""
+	}
+	return d.String()
+}
+
+func LoadLockFile(path string) (*LockFile, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading lock file")
+	}
+
+	var l LockFile
+	err = json.Unmarshal(b, &l)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing lock file")
+	}
+
+	if l.Version != lockFileVersion {
+		return nil, fmt.Errorf("Invalid lock file version. Supported versions are %s", lockFileVersion)
+	}
+
+	return &l, nil
+}
+
+func (l *LockFile) Write(path string) error {
+	b, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "Error generating lock file")
+	}
+
+	err = ioutil.WriteFile(path, append(b, '\n'), 0600)
+	if err != nil {
+		return errors.Wrap(err, "Error writing lock file")
+	}
+
+	return nil
+}
+
+// Compare returns a description of each difference between the locked costs
+// and the current costs. If there are no differences it returns an empty slice.
+func (l *LockFile) Compare(current *LockFile) []string {
+	diffs := make([]string, 0)
+
+	if !decimalsEqual(l.TotalMonthlyCost, current.TotalMonthlyCost) {
+		diffs = append(diffs, fmt.Sprintf("Total monthly cost changed from %s to %s", formatCost2DP(l.TotalMonthlyCost), formatCost2DP(current.TotalMonthlyCost)))
+	}
+
+	locked := make(map[string]LockProject, len(l.Projects))
+	for _, p := range l.Projects {
+		locked[p.Name] = p
+	}
+
+	for _, p := range current.Projects {
+		lp, ok := locked[p.Name]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("Project %s is not in the lock file", p.Name))
+			continue
+		}
+		delete(locked, p.Name)
+
+		if !decimalsEqual(lp.TotalMonthlyCost, p.TotalMonthlyCost) {
+			diffs = append(diffs, fmt.Sprintf("Project %s monthly cost changed from %s to %s", p.Name, formatCost2DP(lp.TotalMonthlyCost), formatCost2DP(p.TotalMonthlyCost)))
+		} else if lp.InputsHash != p.InputsHash {
+			diffs = append(diffs, fmt.Sprintf("Project %s resources or usage changed", p.Name))
+		}
+	}
+
+	for _, p := range l.Projects {
+		if _, ok := locked[p.Name]; ok {
+			diffs = append(diffs, fmt.Sprintf("Project %s is in the lock file but was not found", p.Name))
+		}
+	}
+
+	return diffs
+}
+
+func decimalsEqual(a *decimal.Decimal, b *decimal.Decimal) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
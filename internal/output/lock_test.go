@@ -0,0 +1,44 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestLockFileCompare(t *testing.T) {
+	out := Root{
+		TotalMonthlyCost: decimalPtr(decimal.NewFromInt(100)),
+		Projects: []Project{
+			{
+				Name: "infracost/infracost/examples/terraform",
+				Breakdown: &Breakdown{
+					TotalMonthlyCost: decimalPtr(decimal.NewFromInt(100)),
+					Resources: []Resource{
+						{
+							Name:        "aws_instance.web",
+							MonthlyCost: decimalPtr(decimal.NewFromInt(100)),
+							CostComponents: []CostComponent{
+								{Name: "Instance usage", Unit: "hours", MonthlyQuantity: decimalPtr(decimal.NewFromInt(730))},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	locked := NewLockFile(out)
+	assert.Equal(t, []string{}, locked.Compare(NewLockFile(out)))
+
+	out.Projects[0].Breakdown.Resources[0].CostComponents[0].MonthlyQuantity = decimalPtr(decimal.NewFromInt(365))
+	assert.Equal(t, []string{"Project infracost/infracost/examples/terraform resources or usage changed"}, locked.Compare(NewLockFile(out)))
+
+	out.TotalMonthlyCost = decimalPtr(decimal.NewFromInt(50))
+	out.Projects[0].Breakdown.TotalMonthlyCost = decimalPtr(decimal.NewFromInt(50))
+	assert.Equal(t, []string{
+		"Total monthly cost changed from $100.00 to $50.00",
+		"Project infracost/infracost/examples/terraform monthly cost changed from $100.00 to $50.00",
+	}, locked.Compare(NewLockFile(out)))
+}
@@ -0,0 +1,267 @@
+package output
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v2"
+)
+
+var manifestVersion = "0.1"
+
+// Manifest lists the Infracost JSON artifacts of a sharded pipeline that
+// should be combined into one report.
+type Manifest struct {
+	Version   string     `yaml:"version"`
+	Artifacts []Artifact `yaml:"artifacts"`
+}
+
+// Artifact is an Infracost JSON file, either a path or a URL. If the same
+// project is in more than one artifact then the one with the highest
+// precedence is used. If they have the same precedence the one generated most
+// recently is used, and if they were generated at the same time the one listed
+// last is used.
+type Artifact struct {
+	Path       string `yaml:"path,omitempty"`
+	URL        string `yaml:"url,omitempty"`
+	Precedence int    `yaml:"precedence,omitempty"`
+}
+
+func (a Artifact) Location() string {
+	if a.URL != "" {
+		return a.URL
+	}
+	return a.Path
+}
+
+// MergeLog records how the artifacts were merged so it can be checked why a
+// project was or wasn't included in the combined report.
+type MergeLog struct {
+	Artifacts  []MergeLogArtifact  `json:"artifacts"`
+	Duplicates []MergeLogDuplicate `json:"duplicates"`
+	Renamed    []MergeLogRename    `json:"renamed,omitempty"`
+}
+
+type MergeLogArtifact struct {
+	Source     string   `json:"source"`
+	Version    string   `json:"version"`
+	Precedence int      `json:"precedence"`
+	Projects   []string `json:"projects"`
+}
+
+type MergeLogDuplicate struct {
+	Project  string   `json:"project"`
+	UsedFrom string   `json:"usedFrom"`
+	Ignored  []string `json:"ignored"`
+}
+
+// MergeLogRename is a project that was renamed since an earlier project in
+// the same artifact has the same name.
+type MergeLogRename struct {
+	Source  string `json:"source"`
+	Project string `json:"project"`
+	NewName string `json:"newName"`
+}
+
+func LoadManifest(path string) (*Manifest, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading manifest file")
+	}
+
+	var m Manifest
+	err = yaml.Unmarshal(b, &m)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing manifest file")
+	}
+
+	if m.Version != manifestVersion {
+		return nil, fmt.Errorf("Invalid manifest file version. Supported versions are %s", manifestVersion)
+	}
+
+	for i, a := range m.Artifacts {
+		if (a.Path == "") == (a.URL == "") {
+			return nil, fmt.Errorf("Invalid manifest file: artifact %d must have either a path or a url", i+1)
+		}
+	}
+
+	return &m, nil
+}
+
+type mergeCandidate struct {
+	input      int
+	source     string
+	precedence int
+}
+
+// MergeInputs removes duplicate projects from the inputs using the precedence
+// rules of Artifact, so each project is only included once when they are
+// combined. Projects with the same name in the same input are different
+// projects, so they're renamed first with a suffix, e.g. "app (2)". The
+// precedences are in the same order as the inputs and the source of each
+// input is taken from its filename metadata.
+func MergeInputs(inputs []ReportInput, precedences []int) ([]ReportInput, MergeLog) {
+	mergeLog := MergeLog{
+		Artifacts:  make([]MergeLogArtifact, 0, len(inputs)),
+		Duplicates: make([]MergeLogDuplicate, 0),
+	}
+
+	inputs, mergeLog.Renamed = uniqueProjectNames(inputs)
+
+	candidates := make(map[string][]mergeCandidate)
+	projectNames := make([]string, 0)
+
+	for i, input := range inputs {
+		names := make([]string, 0, len(input.Root.Projects))
+		for _, p := range input.Root.Projects {
+			if _, ok := candidates[p.Name]; !ok {
+				projectNames = append(projectNames, p.Name)
+			}
+			candidates[p.Name] = append(candidates[p.Name], mergeCandidate{input: i, source: input.Metadata["filename"], precedence: precedences[i]})
+			names = append(names, p.Name)
+		}
+
+		mergeLog.Artifacts = append(mergeLog.Artifacts, MergeLogArtifact{
+			Source:     input.Metadata["filename"],
+			Version:    input.Root.Version,
+			Precedence: precedences[i],
+			Projects:   names,
+		})
+	}
+
+	// The input that each project should be used from
+	usedFrom := make(map[string]int)
+
+	for _, name := range projectNames {
+		c := candidates[name]
+		if len(c) == 1 {
+			usedFrom[name] = c[0].input
+			continue
+		}
+
+		sort.SliceStable(c, func(i, j int) bool {
+			if c[i].precedence != c[j].precedence {
+				return c[i].precedence > c[j].precedence
+			}
+			ti, tj := inputs[c[i].input].Root.TimeGenerated, inputs[c[j].input].Root.TimeGenerated
+			if !ti.Equal(tj) {
+				return ti.After(tj)
+			}
+			return c[i].input > c[j].input
+		})
+
+		usedFrom[name] = c[0].input
+
+		ignored := make([]string, 0, len(c)-1)
+		for _, other := range c[1:] {
+			ignored = append(ignored, other.source)
+		}
+
+		mergeLog.Duplicates = append(mergeLog.Duplicates, MergeLogDuplicate{
+			Project:  name,
+			UsedFrom: c[0].source,
+			Ignored:  ignored,
+		})
+	}
+
+	if len(mergeLog.Duplicates) == 0 {
+		return inputs, mergeLog
+	}
+
+	merged := make([]ReportInput, 0, len(inputs))
+	for i, input := range inputs {
+		projects := make([]Project, 0, len(input.Root.Projects))
+		for _, p := range input.Root.Projects {
+			if usedFrom[p.Name] == i {
+				projects = append(projects, p)
+			}
+		}
+
+		if len(projects) != len(input.Root.Projects) {
+			input.Root.Projects = projects
+			input.Root.TotalHourlyCost, input.Root.TotalMonthlyCost = projectTotals(projects)
+		}
+
+		merged = append(merged, input)
+	}
+
+	return merged, mergeLog
+}
+
+// uniqueProjectNames suffixes the names of the projects that have the same
+// name as an earlier project in the same input. The inputs are copied rather
+// than changed.
+func uniqueProjectNames(inputs []ReportInput) ([]ReportInput, []MergeLogRename) {
+	var renamed []MergeLogRename
+
+	result := make([]ReportInput, 0, len(inputs))
+
+	for _, input := range inputs {
+		used := make(map[string]bool, len(input.Root.Projects))
+		for _, p := range input.Root.Projects {
+			used[p.Name] = true
+		}
+
+		seen := make(map[string]bool, len(input.Root.Projects))
+		var projects []Project
+
+		for i, p := range input.Root.Projects {
+			if !seen[p.Name] {
+				seen[p.Name] = true
+				continue
+			}
+
+			if projects == nil {
+				projects = make([]Project, len(input.Root.Projects))
+				copy(projects, input.Root.Projects)
+			}
+
+			name := p.Name
+			for n := 2; used[name]; n++ {
+				name = fmt.Sprintf("%s (%d)", p.Name, n)
+			}
+			used[name] = true
+			seen[name] = true
+
+			renamed = append(renamed, MergeLogRename{Source: input.Metadata["filename"], Project: p.Name, NewName: name})
+			projects[i].Name = name
+		}
+
+		if projects != nil {
+			input.Root.Projects = projects
+		}
+
+		result = append(result, input)
+	}
+
+	return result, renamed
+}
+
+func projectTotals(projects []Project) (*decimal.Decimal, *decimal.Decimal) {
+	var totalHourlyCost, totalMonthlyCost *decimal.Decimal
+
+	for _, p := range projects {
+		if p.Breakdown == nil {
+			continue
+		}
+
+		if p.Breakdown.TotalHourlyCost != nil {
+			if totalHourlyCost == nil {
+				totalHourlyCost = decimalPtr(decimal.Zero)
+			}
+			totalHourlyCost = decimalPtr(totalHourlyCost.Add(*p.Breakdown.TotalHourlyCost))
+		}
+
+		if p.Breakdown.TotalMonthlyCost != nil {
+			if totalMonthlyCost == nil {
+				totalMonthlyCost = decimalPtr(decimal.Zero)
+			}
+			totalMonthlyCost = decimalPtr(totalMonthlyCost.Add(*p.Breakdown.TotalMonthlyCost))
+		}
+	}
+
+	return roundTotal(totalHourlyCost), roundTotal(totalMonthlyCost)
+}
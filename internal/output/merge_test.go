@@ -0,0 +1,89 @@
+package output
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestMergeInputs(t *testing.T) {
+	now := time.Now()
+
+	project := func(name string, cost int64) Project {
+		return Project{
+			Name: name,
+			Breakdown: &Breakdown{
+				TotalHourlyCost:  decimalPtr(decimal.NewFromInt(cost).Div(decimal.NewFromInt(730))),
+				TotalMonthlyCost: decimalPtr(decimal.NewFromInt(cost)),
+			},
+		}
+	}
+
+	inputs := []ReportInput{
+		{
+			Metadata: map[string]string{"filename": "a.json"},
+			Root:     Root{Version: "0.2", TimeGenerated: now, Projects: []Project{project("app", 10), project("db", 20)}, TotalMonthlyCost: decimalPtr(decimal.NewFromInt(30))},
+		},
+		{
+			Metadata: map[string]string{"filename": "b.json"},
+			Root:     Root{Version: "0.2", TimeGenerated: now.Add(time.Minute), Projects: []Project{project("db", 30)}, TotalMonthlyCost: decimalPtr(decimal.NewFromInt(30))},
+		},
+		{
+			Metadata: map[string]string{"filename": "c.json"},
+			Root:     Root{Version: "0.2", TimeGenerated: now, Projects: []Project{project("app", 40)}},
+		},
+	}
+
+	merged, mergeLog := MergeInputs(inputs, []int{5, 0, 0})
+
+	assert.Equal(t, 3, len(mergeLog.Artifacts))
+	assert.Equal(t, []MergeLogDuplicate{
+		{Project: "app", UsedFrom: "a.json", Ignored: []string{"c.json"}},
+		{Project: "db", UsedFrom: "a.json", Ignored: []string{"b.json"}},
+	}, mergeLog.Duplicates)
+
+	assert.Equal(t, 2, len(merged[0].Root.Projects))
+	assert.Equal(t, 0, len(merged[1].Root.Projects))
+	assert.Equal(t, 0, len(merged[2].Root.Projects))
+	assert.Equal(t, "30", merged[0].Root.TotalMonthlyCost.String())
+	assert.Equal(t, true, merged[1].Root.TotalMonthlyCost == nil)
+
+	// Without precedences the most recently generated project is used
+	_, mergeLog = MergeInputs(inputs, []int{0, 0, 0})
+	assert.Equal(t, "c.json", mergeLog.Duplicates[0].UsedFrom)
+	assert.Equal(t, "b.json", mergeLog.Duplicates[1].UsedFrom)
+
+	// Projects with the same name in the same input are renamed rather than
+	// treated as duplicates
+	inputs = []ReportInput{
+		{
+			Metadata: map[string]string{"filename": "a.json"},
+			Root:     Root{Version: "0.2", TimeGenerated: now, Projects: []Project{project("app", 10), project("app", 20), project("app (2)", 30)}},
+		},
+		{
+			Metadata: map[string]string{"filename": "b.json"},
+			Root:     Root{Version: "0.2", TimeGenerated: now.Add(time.Minute), Projects: []Project{project("app", 40), project("app", 50)}},
+		},
+	}
+
+	merged, mergeLog = MergeInputs(inputs, []int{0, 0})
+
+	assert.Equal(t, []MergeLogRename{
+		{Source: "a.json", Project: "app", NewName: "app (3)"},
+		{Source: "b.json", Project: "app", NewName: "app (2)"},
+	}, mergeLog.Renamed)
+	assert.Equal(t, []string{"app", "app (3)", "app (2)"}, mergeLog.Artifacts[0].Projects)
+	assert.Equal(t, []MergeLogDuplicate{
+		{Project: "app", UsedFrom: "b.json", Ignored: []string{"a.json"}},
+		{Project: "app (2)", UsedFrom: "b.json", Ignored: []string{"a.json"}},
+	}, mergeLog.Duplicates)
+
+	assert.Equal(t, 1, len(merged[0].Root.Projects))
+	assert.Equal(t, "app (3)", merged[0].Root.Projects[0].Name)
+	assert.Equal(t, 2, len(merged[1].Root.Projects))
+
+	// The inputs aren't changed
+	assert.Equal(t, "app", inputs[0].Root.Projects[1].Name)
+}
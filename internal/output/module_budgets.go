@@ -0,0 +1,180 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/shopspring/decimal"
+)
+
+// ModuleBudgetOverrun is an instance of a module that costs more than the
+// budget that the module's author set for it.
+type ModuleBudgetOverrun struct {
+	Project          string
+	Address          string
+	Budget           *terraform.ModuleBudget
+	TotalMonthlyCost decimal.Decimal
+}
+
+// ModuleBudgetOverruns finds the budgets of the modules called by each
+// project and returns the module instances that are over their budget. Only
+// projects with a Terraform directory or plan JSON file path are checked.
+func ModuleBudgetOverruns(out Root) ([]ModuleBudgetOverrun, error) {
+	overruns := make([]ModuleBudgetOverrun, 0)
+	budgetsByDir := make(map[string]map[string]*terraform.ModuleBudget)
+
+	for _, project := range out.Projects {
+		if project.Breakdown == nil || project.Metadata == nil || project.Metadata.Path == "" || project.Metadata.Path == "-" {
+			continue
+		}
+
+		dir := projectDir(project)
+
+		budgets, ok := budgetsByDir[dir]
+		if !ok {
+			var err error
+			budgets, err = terraform.ModuleBudgets(dir)
+			if err != nil {
+				return overruns, err
+			}
+			budgetsByDir[dir] = budgets
+		}
+
+		if len(budgets) > 0 {
+			overruns = append(overruns, BuildModuleBudgetOverruns(project, budgets)...)
+		}
+	}
+
+	return overruns, nil
+}
+
+// BuildModuleBudgetOverruns totals the monthly costs of each instance of the
+// modules that have budgets and returns the instances that are over their
+// budget, sorted by address.
+func BuildModuleBudgetOverruns(project Project, budgets map[string]*terraform.ModuleBudget) []ModuleBudgetOverrun {
+	costs := make(map[string]decimal.Decimal)
+
+	if project.Breakdown != nil {
+		for _, r := range project.Breakdown.Resources {
+			if r.MonthlyCost == nil {
+				continue
+			}
+
+			for _, instance := range moduleInstanceAddresses(r.Name) {
+				if _, ok := budgets[addressIndexRegex.ReplaceAllString(instance, "")]; ok {
+					costs[instance] = costs[instance].Add(*r.MonthlyCost)
+				}
+			}
+		}
+	}
+
+	overruns := make([]ModuleBudgetOverrun, 0)
+	for instance, cost := range costs {
+		budget := budgets[addressIndexRegex.ReplaceAllString(instance, "")]
+		if cost.GreaterThan(budget.MonthlyBudget) {
+			overruns = append(overruns, ModuleBudgetOverrun{
+				Project:          project.Name,
+				Address:          instance,
+				Budget:           budget,
+				TotalMonthlyCost: cost,
+			})
+		}
+	}
+
+	sort.Slice(overruns, func(i, j int) bool {
+		return overruns[i].Address < overruns[j].Address
+	})
+
+	return overruns
+}
+
+// Message returns the warning for the module's consumers.
+func (o ModuleBudgetOverrun) Message() string {
+	msg := fmt.Sprintf("%s costs %s/month in project %s, which is over the %s/month budget set by the module's author",
+		o.Address,
+		formatCost2DP(&o.TotalMonthlyCost),
+		o.Project,
+		formatCost2DP(&o.Budget.MonthlyBudget),
+	)
+
+	if o.Budget.Message != "" {
+		msg += ": " + o.Budget.Message
+	}
+
+	return msg
+}
+
+// moduleInstanceAddresses returns the addresses of the module instances that
+// the resource is in, from the outermost module, e.g. module.a[0] and
+// module.a[0].module.b for module.a[0].module.b.aws_instance.web.
+func moduleInstanceAddresses(address string) []string {
+	instances := make([]string, 0)
+
+	remaining := address
+	current := ""
+
+	for strings.HasPrefix(remaining, "module.") {
+		end := moduleSegmentEnd(remaining)
+		if end == -1 {
+			break
+		}
+
+		current += remaining[:end]
+		instances = append(instances, current)
+
+		remaining = remaining[end:]
+		if !strings.HasPrefix(remaining, ".") {
+			break
+		}
+		current += "."
+		remaining = remaining[1:]
+	}
+
+	return instances
+}
+
+// moduleSegmentEnd returns the end of the first module segment of the
+// address, including its index, or -1 if there isn't a complete segment.
+func moduleSegmentEnd(address string) int {
+	i := len("module.")
+	for i < len(address) && address[i] != '.' && address[i] != '[' {
+		i++
+	}
+
+	if i < len(address) && address[i] == '[' {
+		// Skip to the end of the index, the key can contain dots if it's quoted
+		inQuotes := false
+		for ; i < len(address); i++ {
+			switch address[i] {
+			case '"':
+				inQuotes = !inQuotes
+			case ']':
+				if !inQuotes {
+					return i + 1
+				}
+			}
+		}
+		return -1
+	}
+
+	if i == len(address) {
+		return -1
+	}
+
+	return i
+}
+
+// projectDir returns the Terraform directory of the project, which is the
+// directory of the file if the project's path is a plan JSON file.
+func projectDir(project Project) string {
+	dir := project.Metadata.Path
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+
+	return dir
+}
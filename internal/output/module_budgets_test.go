@@ -0,0 +1,45 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/shopspring/decimal"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestModuleInstanceAddresses(t *testing.T) {
+	assert.Equal(t, []string{}, moduleInstanceAddresses("aws_instance.web"))
+	assert.Equal(t, []string{"module.web"}, moduleInstanceAddresses("module.web.aws_instance.web"))
+	assert.Equal(t, []string{"module.web[0]", "module.web[0].module.cache"}, moduleInstanceAddresses("module.web[0].module.cache.aws_elasticache_cluster.cache[1]"))
+	assert.Equal(t, []string{`module.web["a.b"]`}, moduleInstanceAddresses(`module.web["a.b"].aws_instance.web`))
+}
+
+func TestBuildModuleBudgetOverruns(t *testing.T) {
+	budgets := map[string]*terraform.ModuleBudget{
+		"module.web":              {Address: "module.web", MonthlyBudget: decimal.NewFromInt(100), Message: "Use large-web for production"},
+		"module.web.module.cache": {Address: "module.web.module.cache", MonthlyBudget: decimal.NewFromInt(50)},
+	}
+
+	project := Project{
+		Name: "prod",
+		Breakdown: &Breakdown{
+			Resources: []Resource{
+				{Name: `module.web["a"].aws_instance.web`, MonthlyCost: decimalPtr(decimal.NewFromInt(80))},
+				{Name: `module.web["a"].module.cache.aws_elasticache_cluster.cache`, MonthlyCost: decimalPtr(decimal.NewFromInt(40))},
+				{Name: `module.web["b"].aws_instance.web`, MonthlyCost: decimalPtr(decimal.NewFromInt(20))},
+				{Name: `module.web["b"].module.cache.aws_elasticache_cluster.cache`, MonthlyCost: decimalPtr(decimal.NewFromInt(60))},
+				{Name: "aws_instance.other", MonthlyCost: decimalPtr(decimal.NewFromInt(1000))},
+			},
+		},
+	}
+
+	overruns := BuildModuleBudgetOverruns(project, budgets)
+
+	assert.Equal(t, 2, len(overruns))
+	assert.Equal(t, `module.web["a"]`, overruns[0].Address)
+	assert.Equal(t, "120", overruns[0].TotalMonthlyCost.String())
+	assert.Equal(t, `module.web["a"] costs $120.00/month in project prod, which is over the $100.00/month budget set by the module's author: Use large-web for production`, overruns[0].Message())
+	assert.Equal(t, `module.web["b"].module.cache`, overruns[1].Address)
+	assert.Equal(t, "60", overruns[1].TotalMonthlyCost.String())
+}
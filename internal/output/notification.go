@@ -0,0 +1,228 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// maxNotificationProjects is the number of projects that are listed in a
+// notification, the rest are summarized so the message stays short.
+const maxNotificationProjects = 10
+
+// Notification is a short summary of the cost change of a run that's posted
+// to a chat channel. The projects are the ones whose cost changed, sorted by
+// the size of the change.
+type Notification struct {
+	Title     string
+	Projects  []string
+	ReportURL string
+}
+
+// ParseThreshold parses a threshold such as 5% or 100, which is an amount in
+// USD, into a diff threshold.
+func ParseThreshold(s string) (*DiffThreshold, error) {
+	t := &DiffThreshold{}
+
+	v := strings.TrimSpace(s)
+	isPercent := strings.HasSuffix(v, "%")
+	v = strings.TrimPrefix(strings.TrimSuffix(v, "%"), "$")
+
+	d, err := decimal.NewFromString(v)
+	if err != nil || d.IsNegative() {
+		return nil, fmt.Errorf("Invalid threshold %s, expected a percentage such as 5%% or an amount such as 100", s)
+	}
+
+	if isPercent {
+		t.Percent = &d
+	} else {
+		t.Amount = &d
+	}
+
+	return t, nil
+}
+
+// BuildNotification summarizes the total cost change of the projects and the
+// change of each project. The title is the first line of the notification.
+func BuildNotification(out Root, title string, reportURL string) Notification {
+	oldCost, newCost := totalCostChange(out)
+	change := newCost.Sub(oldCost)
+
+	summary := fmt.Sprintf("Monthly cost will change by %s", formatCostChange(&change))
+	if change.IsZero() {
+		summary = "Monthly cost will not change"
+	}
+	if percent := formatPercentChange(&oldCost, &newCost); percent != "" && !change.IsZero() {
+		summary += fmt.Sprintf(" (%s)", percent)
+	}
+	summary += fmt.Sprintf(", from %s to %s", formatCost(&oldCost), formatCost(&newCost))
+
+	if title != "" {
+		summary = fmt.Sprintf("%s: %s", title, summary)
+	}
+
+	type projectChange struct {
+		change decimal.Decimal
+		line   string
+	}
+
+	changes := make([]projectChange, 0, len(out.Projects))
+	for _, p := range out.Projects {
+		projectOld, projectNew := totalCostChange(Root{Projects: []Project{p}})
+		c := projectNew.Sub(projectOld)
+		if c.IsZero() {
+			continue
+		}
+
+		line := fmt.Sprintf("%s: %s", p.Name, formatCostChange(&c))
+		if percent := formatPercentChange(&projectOld, &projectNew); percent != "" {
+			line += fmt.Sprintf(" (%s)", percent)
+		}
+
+		changes = append(changes, projectChange{c, line})
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].change.Abs().GreaterThan(changes[j].change.Abs())
+	})
+
+	projects := make([]string, 0, len(changes))
+	for i, c := range changes {
+		if i == maxNotificationProjects {
+			projects = append(projects, fmt.Sprintf("and %d more projects", len(changes)-maxNotificationProjects))
+			break
+		}
+		projects = append(projects, c.line)
+	}
+
+	return Notification{
+		Title:     summary,
+		Projects:  projects,
+		ReportURL: reportURL,
+	}
+}
+
+// ShortCostChange returns the total monthly cost change in the short format
+// used in titles, e.g. +$312/mo (+4.2%).
+func ShortCostChange(out Root) string {
+	oldCost, newCost := totalCostChange(out)
+	change := newCost.Sub(oldCost)
+
+	s := fmt.Sprintf("%s/mo", formatCostChange(&change))
+	if oldCost.IsZero() || change.IsZero() {
+		return s
+	}
+
+	p := change.Div(oldCost).Mul(decimal.NewFromInt(100))
+	sym := ""
+	if p.IsPositive() {
+		sym = "+"
+	}
+
+	return fmt.Sprintf("%s (%s%s%%)", s, sym, p.StringFixed(1))
+}
+
+// SlackPayload returns the message for a Slack incoming webhook. The text is
+// shown in the notifications and the blocks are shown in the channel.
+func (n Notification) SlackPayload() interface{} {
+	type text struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	type block struct {
+		Type string `json:"type"`
+		Text *text  `json:"text,omitempty"`
+	}
+
+	blocks := []block{
+		{Type: "section", Text: &text{Type: "mrkdwn", Text: fmt.Sprintf("*%s*", escapeSlackText(n.Title))}},
+	}
+
+	if len(n.Projects) > 0 {
+		lines := make([]string, 0, len(n.Projects))
+		for _, p := range n.Projects {
+			lines = append(lines, fmt.Sprintf("• %s", escapeSlackText(p)))
+		}
+		blocks = append(blocks, block{Type: "section", Text: &text{Type: "mrkdwn", Text: strings.Join(lines, "\n")}})
+	}
+
+	if n.ReportURL != "" {
+		blocks = append(blocks, block{Type: "section", Text: &text{Type: "mrkdwn", Text: fmt.Sprintf("<%s|View the full report>", n.ReportURL)}})
+	}
+
+	return map[string]interface{}{
+		"text":   n.Title,
+		"blocks": blocks,
+	}
+}
+
+// TeamsPayload returns the message for a Microsoft Teams workflow webhook,
+// which posts an Adaptive Card.
+func (n Notification) TeamsPayload() interface{} {
+	body := []map[string]interface{}{
+		{"type": "TextBlock", "text": n.Title, "weight": "Bolder", "wrap": true},
+	}
+
+	if len(n.Projects) > 0 {
+		lines := make([]string, 0, len(n.Projects))
+		for _, p := range n.Projects {
+			lines = append(lines, fmt.Sprintf("- %s", p))
+		}
+		body = append(body, map[string]interface{}{"type": "TextBlock", "text": strings.Join(lines, "\r"), "wrap": true})
+	}
+
+	card := map[string]interface{}{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body":    body,
+	}
+
+	if n.ReportURL != "" {
+		card["actions"] = []map[string]interface{}{
+			{"type": "Action.OpenUrl", "title": "View the full report", "url": n.ReportURL},
+		}
+	}
+
+	return map[string]interface{}{
+		"type": "message",
+		"attachments": []map[string]interface{}{
+			{"contentType": "application/vnd.microsoft.card.adaptive", "content": card},
+		},
+	}
+}
+
+// PostNotification posts the payload to a Slack or Teams webhook as JSON.
+func PostNotification(webhookURL string, payload interface{}) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "Error generating notification")
+	}
+
+	client := &http.Client{Timeout: destinationTimeout}
+	resp, err := client.Post(webhookURL, "application/json", bytes.NewBuffer(b))
+	if err != nil {
+		return errors.Wrap(err, "Error posting notification")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("Error posting notification: %s", resp.Status)
+	}
+
+	return nil
+}
+
+var slackTextReplacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// escapeSlackText escapes the characters that Slack uses for links and
+// mentions, since project names are set by the user.
+func escapeSlackText(s string) string {
+	return slackTextReplacer.Replace(s)
+}
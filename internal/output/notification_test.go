@@ -0,0 +1,89 @@
+package output
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestParseThreshold(t *testing.T) {
+	threshold, err := ParseThreshold("5%")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "5", threshold.Percent.String())
+	assert.Equal(t, true, threshold.Amount == nil)
+
+	threshold, err = ParseThreshold("$100.5")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "100.5", threshold.Amount.String())
+	assert.Equal(t, true, threshold.Percent == nil)
+
+	_, err = ParseThreshold("-5%")
+	assert.NotEqual(t, nil, err)
+
+	_, err = ParseThreshold("five")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBuildNotification(t *testing.T) {
+	out := Root{
+		Projects: []Project{
+			{
+				Name:          "infracost/web",
+				PastBreakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(100))},
+				Breakdown:     &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(110))},
+			},
+			{
+				Name:          "infracost/db",
+				PastBreakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(200))},
+				Breakdown:     &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(150))},
+			},
+			{
+				Name:          "infracost/<unchanged>",
+				PastBreakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(50))},
+				Breakdown:     &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(50))},
+			},
+		},
+	}
+
+	n := BuildNotification(out, "infracost/infracost", "https://ci.example.com/runs/1")
+	assert.Equal(t, "infracost/infracost: Monthly cost will change by -$40.00 (-11%), from $350 to $310", n.Title)
+	assert.Equal(t, []string{"infracost/db: -$50.00 (-25%)", "infracost/web: +$10.00 (+10%)"}, n.Projects)
+
+	b, err := json.Marshal(n.SlackPayload())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `{"blocks":[{"type":"section","text":{"type":"mrkdwn","text":"*infracost/infracost: Monthly cost will change by -$40.00 (-11%), from $350 to $310*"}},{"type":"section","text":{"type":"mrkdwn","text":"• infracost/db: -$50.00 (-25%)\n• infracost/web: +$10.00 (+10%)"}},{"type":"section","text":{"type":"mrkdwn","text":"\u003chttps://ci.example.com/runs/1|View the full report\u003e"}}],"text":"infracost/infracost: Monthly cost will change by -$40.00 (-11%), from $350 to $310"}`, string(b))
+}
+
+func TestPostNotification(t *testing.T) {
+	var body map[string]interface{}
+	var contentType string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}))
+	defer ts.Close()
+
+	n := Notification{Title: "Monthly cost will not change, from $10 to $10"}
+
+	err := PostNotification(ts.URL, n.TeamsPayload())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "application/json", contentType)
+	assert.Equal(t, "message", body["type"])
+
+	attachments := body["attachments"].([]interface{})
+	card := attachments[0].(map[string]interface{})["content"].(map[string]interface{})
+	assert.Equal(t, "AdaptiveCard", card["type"])
+	assert.Equal(t, nil, card["actions"])
+
+	ts.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	err = PostNotification(ts.URL, n.SlackPayload())
+	assert.Equal(t, "Error posting notification: 403 Forbidden", err.Error())
+}
@@ -3,8 +3,10 @@ package output
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
+	"github.com/infracost/infracost/internal/i18n"
 	"github.com/infracost/infracost/internal/providers/terraform"
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/shopspring/decimal"
@@ -13,12 +15,21 @@ import (
 var outputVersion = "0.2"
 
 type Root struct {
-	Version          string           `json:"version"`
-	Projects         []Project        `json:"projects"`
-	TotalHourlyCost  *decimal.Decimal `json:"totalHourlyCost"`
-	TotalMonthlyCost *decimal.Decimal `json:"totalMonthlyCost"`
-	TimeGenerated    time.Time        `json:"timeGenerated"`
-	Summary          *Summary         `json:"summary"`
+	Version          string                 `json:"version"`
+	Projects         []Project              `json:"projects"`
+	TotalHourlyCost  *decimal.Decimal       `json:"totalHourlyCost"`
+	TotalMonthlyCost *decimal.Decimal       `json:"totalMonthlyCost"`
+	TimeGenerated    time.Time              `json:"timeGenerated"`
+	Summary          *Summary               `json:"summary"`
+	Grouping         *Grouping              `json:"grouping,omitempty"`
+	CostCenters      *CostCenterRollups     `json:"costCenters,omitempty"`
+	Environments     *EnvironmentComparison `json:"environments,omitempty"`
+	Regions          *RegionComparison      `json:"regions,omitempty"`
+	Accounts         *AccountRollups        `json:"accounts,omitempty"`
+	Completeness     *Completeness          `json:"completeness,omitempty"`
+	Adjustments      []AdjustmentSummary    `json:"adjustments,omitempty"`
+	Recommendations  []Recommendation       `json:"recommendations,omitempty"`
+	PricingStats     *PricingStats          `json:"pricingStats,omitempty"`
 }
 
 type Project struct {
@@ -27,6 +38,16 @@ type Project struct {
 	PastBreakdown *Breakdown              `json:"pastBreakdown"`
 	Breakdown     *Breakdown              `json:"breakdown"`
 	Diff          *Breakdown              `json:"diff"`
+	// SkippedResources are the resources that weren't priced and why, e.g.
+	// because they're free or their resource type isn't supported yet.
+	SkippedResources []SkippedResource `json:"skippedResources,omitempty"`
+}
+
+type SkippedResource struct {
+	Name         string `json:"name"`
+	ResourceType string `json:"resourceType"`
+	Reason       string `json:"reason"`
+	Message      string `json:"message,omitempty"`
 }
 
 type Breakdown struct {
@@ -36,13 +57,31 @@ type Breakdown struct {
 }
 
 type CostComponent struct {
-	Name            string           `json:"name"`
+	Name string `json:"name"`
+	// PastName is set in diffs when the cost component was renamed, e.g.
+	// because the instance type changed
+	PastName        string           `json:"pastName,omitempty"`
 	Unit            string           `json:"unit"`
 	HourlyQuantity  *decimal.Decimal `json:"hourlyQuantity"`
 	MonthlyQuantity *decimal.Decimal `json:"monthlyQuantity"`
 	Price           decimal.Decimal  `json:"price"`
 	HourlyCost      *decimal.Decimal `json:"hourlyCost"`
 	MonthlyCost     *decimal.Decimal `json:"monthlyCost"`
+	PriceSource     *PriceSource     `json:"priceSource,omitempty"`
+	Unpriced        bool             `json:"unpriced,omitempty"`
+	Adjustments     []CostAdjustment `json:"adjustments,omitempty"`
+}
+
+// PriceSource identifies the vendor product and price that a cost
+// component was priced from so it can be matched with billing data.
+type PriceSource struct {
+	VendorName  string `json:"vendorName,omitempty"`
+	Service     string `json:"service,omitempty"`
+	Region      string `json:"region,omitempty"`
+	SKU         string `json:"sku,omitempty"`
+	ProductHash string `json:"productHash,omitempty"`
+	PriceHash   string `json:"priceHash,omitempty"`
+	Override    string `json:"override,omitempty"`
 }
 
 type Resource struct {
@@ -53,6 +92,7 @@ type Resource struct {
 	MonthlyCost    *decimal.Decimal  `json:"monthlyCost"`
 	CostComponents []CostComponent   `json:"costComponents,omitempty"`
 	SubResources   []Resource        `json:"subresources,omitempty"`
+	PlanActions    []string          `json:"planActions,omitempty"`
 }
 
 type Summary struct {
@@ -70,11 +110,19 @@ type SummaryOptions struct {
 }
 
 type Options struct {
-	NoColor     bool
-	ShowSkipped bool
-	GroupLabel  string
-	GroupKey    string
-	Fields      []string
+	NoColor       bool
+	ShowSkipped   bool
+	GroupLabel    string
+	GroupKey      string
+	Fields        []string
+	GroupBy       string
+	DiffThreshold *DiffThreshold
+	Hierarchy     *Hierarchy
+	Accounts      *Accounts
+	// CompareEnvironments adds a comparison of the projects that have the environment label
+	CompareEnvironments bool
+	// CompareRegions adds a comparison of the projects that have the region label
+	CompareRegions bool
 }
 
 func outputBreakdown(resources []*schema.Resource) *Breakdown {
@@ -93,8 +141,8 @@ func outputBreakdown(resources []*schema.Resource) *Breakdown {
 
 	return &Breakdown{
 		Resources:        arr,
-		TotalHourlyCost:  totalMonthlyCost,
-		TotalMonthlyCost: totalHourlyCost,
+		TotalHourlyCost:  roundTotal(totalMonthlyCost),
+		TotalMonthlyCost: roundTotal(totalHourlyCost),
 	}
 }
 
@@ -104,12 +152,16 @@ func outputResource(r *schema.Resource) Resource {
 
 		comps = append(comps, CostComponent{
 			Name:            c.Name,
+			PastName:        c.PastName,
 			Unit:            c.Unit,
 			HourlyQuantity:  c.UnitMultiplierHourlyQuantity(),
 			MonthlyQuantity: c.UnitMultiplierMonthlyQuantity(),
 			Price:           c.UnitMultiplierPrice(),
 			HourlyCost:      c.HourlyCost,
 			MonthlyCost:     c.MonthlyCost,
+			PriceSource:     outputPriceSource(c),
+			Unpriced:        c.IsUnpriced(),
+			Adjustments:     outputCostAdjustments(c),
 		})
 	}
 
@@ -121,12 +173,83 @@ func outputResource(r *schema.Resource) Resource {
 	return Resource{
 		Name:           r.Name,
 		Metadata:       map[string]string{},
-		Tags:           r.Tags,
+		Tags:           outputTags(r),
 		HourlyCost:     r.HourlyCost,
 		MonthlyCost:    r.MonthlyCost,
 		CostComponents: comps,
 		SubResources:   subresources,
+		PlanActions:    r.PlanActions,
+	}
+}
+
+// outputTags returns the tags of the resource with the values of its
+// sensitive tags redacted, so the output can be shared.
+func outputTags(r *schema.Resource) map[string]string {
+	if len(r.SensitiveTags) == 0 {
+		return r.Tags
+	}
+
+	tags := make(map[string]string, len(r.Tags))
+	for k, v := range r.Tags {
+		if r.SensitiveTags[k] {
+			v = schema.RedactedValue
+		}
+		tags[k] = v
+	}
+
+	return tags
+}
+
+func outputSkippedResources(resources []*schema.Resource) []SkippedResource {
+	skipped := make([]SkippedResource, 0)
+
+	for _, r := range resources {
+		if !r.IsSkipped {
+			continue
+		}
+
+		reason := r.SkipReason
+		if reason == "" {
+			reason = schema.SkipReasonUnsupported
+			if r.NoPrice {
+				reason = schema.SkipReasonFree
+			}
+		}
+
+		skipped = append(skipped, SkippedResource{
+			Name:         r.Name,
+			ResourceType: r.ResourceType,
+			Reason:       reason,
+			Message:      r.SkipMessage,
+		})
+	}
+
+	sort.Slice(skipped, func(i, j int) bool {
+		return skipped[i].Name < skipped[j].Name
+	})
+
+	return skipped
+}
+
+func outputPriceSource(c *schema.CostComponent) *PriceSource {
+	if c.PriceHash() == "" {
+		return nil
+	}
+
+	s := &PriceSource{
+		SKU:         c.ProductSKU(),
+		ProductHash: c.ProductHash(),
+		PriceHash:   c.PriceHash(),
+		Override:    c.PriceOverride(),
 	}
+
+	if c.ProductFilter != nil {
+		s.VendorName = derefString(c.ProductFilter.VendorName)
+		s.Service = derefString(c.ProductFilter.Service)
+		s.Region = derefString(c.ProductFilter.Region)
+	}
+
+	return s
 }
 
 func ToOutputFormat(projects []*schema.Project) Root {
@@ -166,6 +289,8 @@ func ToOutputFormat(projects []*schema.Project) Root {
 			PastBreakdown: pastBreakdown,
 			Breakdown:     breakdown,
 			Diff:          diff,
+
+			SkippedResources: outputSkippedResources(project.Resources),
 		})
 	}
 
@@ -176,28 +301,32 @@ func ToOutputFormat(projects []*schema.Project) Root {
 	out := Root{
 		Version:          outputVersion,
 		Projects:         outProjects,
-		TotalHourlyCost:  totalHourlyCost,
-		TotalMonthlyCost: totalMonthlyCost,
+		TotalHourlyCost:  roundTotal(totalHourlyCost),
+		TotalMonthlyCost: roundTotal(totalMonthlyCost),
 		TimeGenerated:    time.Now(),
 		Summary:          resourceSummary,
+		Completeness:     buildCompleteness(outProjects),
+		Adjustments:      buildAdjustmentSummaries(outProjects),
 	}
 
 	return out
 }
 
 func (r *Root) unsupportedResourcesMessage(showSkipped bool) string {
+	missingRegionMsg := r.missingRegionMessage()
+
 	if r.Summary.UnsupportedResourceCounts == nil || len(*r.Summary.UnsupportedResourceCounts) == 0 {
-		return ""
+		return missingRegionMsg
 	}
 
 	unsupportedTypeCount := len(*r.Summary.UnsupportedResourceCounts)
 
-	unsupportedMsg := "resource types weren't estimated as they're not supported yet"
+	unsupportedMsg := i18n.T("resource types weren't estimated as they're not supported yet")
 	if unsupportedTypeCount == 1 {
-		unsupportedMsg = "resource type wasn't estimated as it's not supported yet"
+		unsupportedMsg = i18n.T("resource type wasn't estimated as it's not supported yet")
 	}
 
-	showSkippedMsg := ", rerun with --show-skipped to see"
+	showSkippedMsg := i18n.T(", rerun with --show-skipped to see")
 	if showSkipped {
 		showSkippedMsg = ""
 	}
@@ -206,7 +335,7 @@ func (r *Root) unsupportedResourcesMessage(showSkipped bool) string {
 		unsupportedTypeCount,
 		unsupportedMsg,
 		showSkippedMsg,
-		"Please watch/star https://github.com/infracost/infracost as new resources are added regularly.",
+		i18n.T("Please watch/star https://github.com/infracost/infracost as new resources are added regularly."),
 	)
 
 	if showSkipped {
@@ -230,9 +359,38 @@ func (r *Root) unsupportedResourcesMessage(showSkipped bool) string {
 		}
 	}
 
+	if missingRegionMsg != "" {
+		msg = missingRegionMsg + "\n" + msg
+	}
+
 	return msg
 }
 
+// missingRegionMessage lists the resources that weren't estimated since their
+// region couldn't be found. They're always listed since their resource types
+// are supported, so they're likely to be a mistake in the config.
+func (r *Root) missingRegionMessage() string {
+	names := make([]string, 0)
+	for _, p := range r.Projects {
+		for _, s := range p.SkippedResources {
+			if s.Reason == schema.SkipReasonMissingRegion {
+				names = append(names, s.Name)
+			}
+		}
+	}
+
+	if len(names) == 0 {
+		return ""
+	}
+
+	msg := i18n.T("resources weren't estimated as their region could not be found:")
+	if len(names) == 1 {
+		msg = i18n.T("resource wasn't estimated as its region could not be found:")
+	}
+
+	return fmt.Sprintf("%d %s %s", len(names), msg, strings.Join(names, ", "))
+}
+
 func BuildSummary(resources []*schema.Resource, opts SummaryOptions) *Summary {
 	supportedResourceCounts := make(map[string]int)
 	unsupportedResourceCounts := make(map[string]int)
@@ -247,6 +405,9 @@ func BuildSummary(resources []*schema.Resource, opts SummaryOptions) *Summary {
 
 		if r.NoPrice {
 			totalNoPriceResources++
+		} else if r.IsSkipped && r.SkipReason == schema.SkipReasonMissingRegion {
+			// The resource type is supported so it isn't counted as unsupported
+			continue
 		} else if r.IsSkipped {
 			totalUnsupportedResources++
 			if _, ok := unsupportedResourceCounts[r.ResourceType]; !ok {
@@ -343,6 +504,13 @@ func decimalPtr(d decimal.Decimal) *decimal.Decimal {
 	return &d
 }
 
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
 func breakdownHasNilCosts(breakdown Breakdown) bool {
 	for _, resource := range breakdown.Resources {
 		if resourceHasNilCosts(resource) {
@@ -353,13 +521,15 @@ func breakdownHasNilCosts(breakdown Breakdown) bool {
 	return false
 }
 
+// resourceHasNilCosts returns true if any of the costs of the resource
+// depend on usage. Costs that weren't priced aren't counted.
 func resourceHasNilCosts(resource Resource) bool {
-	if resource.MonthlyCost == nil {
+	if resource.MonthlyCost == nil && !resourceIsUnpriced(resource) {
 		return true
 	}
 
 	for _, costComponent := range resource.CostComponents {
-		if costComponent.MonthlyCost == nil {
+		if costComponent.MonthlyCost == nil && !costComponent.Unpriced {
 			return true
 		}
 	}
@@ -1,8 +1,10 @@
 package output
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/infracost/infracost/internal/schema"
 	"github.com/shopspring/decimal"
 	"gopkg.in/go-playground/assert.v1"
 )
@@ -32,3 +34,45 @@ func TestCalculateTotalCosts(t *testing.T) {
 	actual, _ = totalMonthlyCost.Float64()
 	assert.Equal(t, expected, actual)
 }
+
+func TestToOutputFormatSensitiveTags(t *testing.T) {
+	r := &schema.Resource{
+		Name:          "aws_instance.web",
+		Tags:          map[string]string{"Environment": "prod", "Owner": "secret-owner"},
+		SensitiveTags: map[string]bool{"Owner": true},
+	}
+
+	out := ToOutputFormat([]*schema.Project{{Name: "my-project", Resources: []*schema.Resource{r}}})
+
+	assert.Equal(t, map[string]string{"Environment": "prod", "Owner": "REDACTED"}, out.Projects[0].Breakdown.Resources[0].Tags)
+	assert.Equal(t, "secret-owner", r.Tags["Owner"])
+}
+
+func TestToOutputFormatSkippedResources(t *testing.T) {
+	project := &schema.Project{
+		Name: "my-project",
+		Resources: []*schema.Resource{
+			{Name: "aws_instance.web", ResourceType: "aws_instance"},
+			{Name: "aws_iam_role.role", ResourceType: "aws_iam_role", IsSkipped: true, NoPrice: true, SkipMessage: "Free resource.", SkipReason: schema.SkipReasonFree},
+			{Name: "aws_fake.fake", ResourceType: "aws_fake", IsSkipped: true, SkipMessage: "This resource is not currently supported", SkipReason: schema.SkipReasonUnsupported},
+			{Name: "aws_nat_gateway.nat", ResourceType: "aws_nat_gateway", IsSkipped: true, SkipMessage: "The region of this resource could not be found", SkipReason: schema.SkipReasonMissingRegion},
+		},
+	}
+	schema.CalculateCosts(project)
+
+	out := ToOutputFormat([]*schema.Project{project})
+
+	assert.Equal(t, []SkippedResource{
+		{Name: "aws_fake.fake", ResourceType: "aws_fake", Reason: schema.SkipReasonUnsupported, Message: "This resource is not currently supported"},
+		{Name: "aws_iam_role.role", ResourceType: "aws_iam_role", Reason: schema.SkipReasonFree, Message: "Free resource."},
+		{Name: "aws_nat_gateway.nat", ResourceType: "aws_nat_gateway", Reason: schema.SkipReasonMissingRegion, Message: "The region of this resource could not be found"},
+	}, out.Projects[0].SkippedResources)
+
+	// Resources that are missing their region aren't counted as unsupported
+	assert.Equal(t, map[string]int{"aws_fake": 1}, *out.Summary.UnsupportedResourceCounts)
+
+	b, err := ToTable(out, Options{Fields: []string{"monthlyQuantity", "unit", "monthlyCost"}})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), "1 resource wasn't estimated as its region could not be found: aws_nat_gateway.nat"))
+	assert.Equal(t, true, strings.Contains(string(b), "1 resource type wasn't estimated as it's not supported yet"))
+}
@@ -0,0 +1,175 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/i18n"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/shopspring/decimal"
+)
+
+// The plan action groups of the diff summary, in the order they're shown.
+const (
+	planActionCreate  = "Create"
+	planActionUpdate  = "Update"
+	planActionReplace = "Replace"
+	planActionDestroy = "Destroy"
+	planActionOther   = "Other"
+)
+
+var planActionOrder = []string{planActionCreate, planActionUpdate, planActionReplace, planActionDestroy, planActionOther}
+
+// planActionGroup is the monthly cost change of the resources in the diff
+// that have the same Terraform plan action.
+type planActionGroup struct {
+	Action                  string
+	Resources               int
+	MonthlyCost             decimal.Decimal
+	CreateBeforeDestroy     int
+	CreateBeforeDestroyCost decimal.Decimal
+}
+
+// planActionGroups groups the diff resources of the project by their plan
+// action. Resources whose cost changes without being changed in the plan,
+// e.g. because they reference a changed resource, are grouped as other. It
+// returns nil if the plan actions aren't known, e.g. for a state file.
+func planActionGroups(project Project) []*planActionGroup {
+	if project.Diff == nil {
+		return nil
+	}
+
+	groups := make(map[string]*planActionGroup)
+	hasActions := false
+
+	for _, r := range project.Diff.Resources {
+		if len(r.PlanActions) > 0 {
+			hasActions = true
+		}
+
+		action := planActionLabel(r.PlanActions)
+
+		g, ok := groups[action]
+		if !ok {
+			g = &planActionGroup{Action: action}
+			groups[action] = g
+		}
+
+		g.Resources++
+		if r.MonthlyCost != nil {
+			g.MonthlyCost = g.MonthlyCost.Add(*r.MonthlyCost)
+		}
+
+		// Replacing a resource with create_before_destroy runs the old and new
+		// resources at the same time until the old one is destroyed.
+		if action == planActionReplace && r.PlanActions[0] == "create" {
+			g.CreateBeforeDestroy++
+
+			newResource := findResourceByName(project.Breakdown.Resources, r.Name)
+			if newResource != nil && newResource.HourlyCost != nil {
+				g.CreateBeforeDestroyCost = g.CreateBeforeDestroyCost.Add(*newResource.HourlyCost)
+			}
+		}
+	}
+
+	if !hasActions {
+		return nil
+	}
+
+	result := make([]*planActionGroup, 0, len(groups))
+	for _, action := range planActionOrder {
+		if g, ok := groups[action]; ok {
+			result = append(result, g)
+		}
+	}
+
+	return result
+}
+
+func planActionLabel(actions []string) string {
+	switch {
+	case len(actions) == 2 && containsAction(actions, "create") && containsAction(actions, "delete"):
+		return planActionReplace
+	case len(actions) == 1 && actions[0] == "create":
+		return planActionCreate
+	case len(actions) == 1 && actions[0] == "update":
+		return planActionUpdate
+	case len(actions) == 1 && actions[0] == "delete":
+		return planActionDestroy
+	default:
+		return planActionOther
+	}
+}
+
+func containsAction(actions []string, action string) bool {
+	for _, a := range actions {
+		if a == action {
+			return true
+		}
+	}
+
+	return false
+}
+
+// planActionsToDiff returns the monthly cost change of the project for each
+// plan action, followed by notes about replacements that run both resources
+// at the same time and destroys that save money.
+func planActionsToDiff(groups []*planActionGroup) string {
+	actionWidth := 0
+	for _, g := range groups {
+		if w := len(i18n.T(g.Action)); w > actionWidth {
+			actionWidth = w
+		}
+	}
+
+	resourceLabels := make([]string, 0, len(groups))
+	resourcesWidth := 0
+	for _, g := range groups {
+		l := pluralizeResources(g.Resources)
+		resourceLabels = append(resourceLabels, l)
+		if len(l) > resourcesWidth {
+			resourcesWidth = len(l)
+		}
+	}
+
+	s := ui.BoldString(i18n.T("Monthly cost change by plan action"))
+
+	notes := make([]string, 0)
+
+	for i, g := range groups {
+		s += fmt.Sprintf("\n  %s  %s  %s",
+			padRight(i18n.T(g.Action), actionWidth),
+			padRight(resourceLabels[i], resourcesWidth),
+			formatCostChange(decimalPtr(g.MonthlyCost)),
+		)
+
+		if g.CreateBeforeDestroy > 0 {
+			notes = append(notes, i18n.Tf("%s replaced with create_before_destroy, so the old and new resources run at the same time. The new resources cost %s per hour until the old ones are destroyed.",
+				pluralizeResources(g.CreateBeforeDestroy),
+				formatCost2DP(decimalPtr(g.CreateBeforeDestroyCost)),
+			))
+		}
+
+		if g.Action == planActionDestroy && g.MonthlyCost.IsNegative() {
+			savings := g.MonthlyCost.Abs()
+			notes = append(notes, i18n.Tf("Destroying %s saves %s per month.",
+				pluralizeResources(g.Resources),
+				formatCost2DP(&savings),
+			))
+		}
+	}
+
+	if len(notes) > 0 {
+		s += "\n\n" + strings.Join(notes, "\n")
+	}
+
+	return s
+}
+
+func pluralizeResources(n int) string {
+	if n == 1 {
+		return i18n.T("1 resource")
+	}
+
+	return i18n.Tf("%d resources", n)
+}
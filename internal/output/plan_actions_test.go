@@ -0,0 +1,80 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/shopspring/decimal"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestToDiffPlanActions(t *testing.T) {
+	resource := func(name string, monthlyCost int64, actions ...string) Resource {
+		return Resource{
+			Name:        name,
+			HourlyCost:  decimalPtr(decimal.NewFromInt(monthlyCost).Div(decimal.NewFromInt(730))),
+			MonthlyCost: decimalPtr(decimal.NewFromInt(monthlyCost)),
+			PlanActions: actions,
+		}
+	}
+
+	project := Project{
+		Name: "my-project",
+		PastBreakdown: &Breakdown{
+			Resources: []Resource{
+				resource("aws_instance.updated", 100, "update"),
+				resource("aws_instance.replaced", 100, "create", "delete"),
+				resource("aws_instance.destroyed", 50, "delete"),
+			},
+			TotalMonthlyCost: decimalPtr(decimal.NewFromInt(250)),
+		},
+		Breakdown: &Breakdown{
+			Resources: []Resource{
+				resource("aws_instance.created", 200, "create"),
+				resource("aws_instance.updated", 150, "update"),
+				resource("aws_instance.replaced", 730, "create", "delete"),
+			},
+			TotalMonthlyCost: decimalPtr(decimal.NewFromInt(1080)),
+		},
+		Diff: &Breakdown{
+			Resources: []Resource{
+				resource("aws_instance.created", 200, "create"),
+				resource("aws_instance.updated", 50, "update"),
+				resource("aws_instance.replaced", 630, "create", "delete"),
+				resource("aws_instance.destroyed", -50, "delete"),
+			},
+			TotalMonthlyCost: decimalPtr(decimal.NewFromInt(830)),
+		},
+	}
+
+	groups := planActionGroups(project)
+	assert.Equal(t, 4, len(groups))
+	assert.Equal(t, planActionReplace, groups[2].Action)
+	assert.Equal(t, 1, groups[2].CreateBeforeDestroy)
+	assert.Equal(t, "1", groups[2].CreateBeforeDestroyCost.String())
+
+	b, err := ToDiff(Root{Projects: []Project{project}, Summary: &Summary{}}, Options{NoColor: true})
+	assert.Equal(t, nil, err)
+
+	s := ui.StripColor(string(b))
+	assert.Equal(t, true, strings.Contains(s, `Monthly cost change by plan action
+  Create   1 resource  +$200
+  Update   1 resource  +$50.00
+  Replace  1 resource  +$630
+  Destroy  1 resource  -$50.00`))
+	assert.Equal(t, true, strings.Contains(s, "1 resource replaced with create_before_destroy, so the old and new resources run at the same time. The new resources cost $1.00 per hour until the old ones are destroyed."))
+	assert.Equal(t, true, strings.Contains(s, "Destroying 1 resource saves $50.00 per month."))
+
+	project.Diff.Resources[0].PlanActions = nil
+	project.Diff.Resources[1].PlanActions = nil
+	project.Diff.Resources[2].PlanActions = []string{"delete", "create"}
+	project.Diff.Resources[3].PlanActions = nil
+	groups = planActionGroups(project)
+	assert.Equal(t, 2, len(groups))
+	assert.Equal(t, planActionOther, groups[1].Action)
+	assert.Equal(t, 0, groups[0].CreateBeforeDestroy)
+
+	project.Diff.Resources[2].PlanActions = nil
+	assert.Equal(t, true, planActionGroups(project) == nil)
+}
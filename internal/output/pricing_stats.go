@@ -0,0 +1,14 @@
+package output
+
+// PricingStats is how the pricing API was used to get the prices of the run
+// that generated the output, so users of self-hosted pricing APIs can plan
+// their capacity. Queries are counted for each cost component, and requests
+// are the HTTP requests sent for the queries that weren't cached, including
+// the retries.
+type PricingStats struct {
+	Queries    int   `json:"queries"`
+	CacheHits  int   `json:"cacheHits"`
+	Requests   int   `json:"requests"`
+	Retries    int   `json:"retries"`
+	DurationMs int64 `json:"durationMs"`
+}
@@ -0,0 +1,154 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// prometheusContentType is the content type of the OpenMetrics text format.
+// The Pushgateway parses it with its text format parser, which skips the
+// # EOF line as a comment.
+const prometheusContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// defaultPushgatewayJob is the job that the metrics are grouped by when the
+// Pushgateway URL doesn't set one.
+const defaultPushgatewayJob = "infracost"
+
+var prometheusLabelReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+type prometheusMetric struct {
+	name    string
+	help    string
+	samples []prometheusSample
+}
+
+type prometheusSample struct {
+	labels [][2]string
+	value  *decimal.Decimal
+}
+
+// ToPrometheus outputs the total and per-project costs as gauges in the
+// OpenMetrics text format, so they can be scraped or pushed to a Pushgateway
+// and graphed alongside the actual spend. The costs are in USD and the past
+// costs are only output for projects that have a past breakdown, e.g. from
+// a diff.
+func ToPrometheus(out Root, opts Options) ([]byte, error) {
+	totalPast := decimal.Zero
+	hasPast := false
+
+	projectCosts := make([]prometheusSample, 0, len(out.Projects))
+	projectPastCosts := make([]prometheusSample, 0, len(out.Projects))
+	projectResources := make([]prometheusSample, 0, len(out.Projects))
+
+	for _, p := range out.Projects {
+		labels := [][2]string{{"project", p.Name}}
+
+		if p.Breakdown != nil {
+			projectCosts = append(projectCosts, prometheusSample{labels, p.Breakdown.TotalMonthlyCost})
+			projectResources = append(projectResources, prometheusSample{labels, decimalPtr(decimal.NewFromInt(int64(len(p.Breakdown.Resources))))})
+		}
+
+		if p.PastBreakdown != nil {
+			projectPastCosts = append(projectPastCosts, prometheusSample{labels, p.PastBreakdown.TotalMonthlyCost})
+			if p.PastBreakdown.TotalMonthlyCost != nil {
+				totalPast = totalPast.Add(*p.PastBreakdown.TotalMonthlyCost)
+			}
+			hasPast = true
+		}
+	}
+
+	metrics := []prometheusMetric{
+		{
+			name:    "infracost_total_monthly_cost",
+			help:    "The estimated monthly cost of all the projects in USD.",
+			samples: []prometheusSample{{nil, out.TotalMonthlyCost}},
+		},
+		{
+			name:    "infracost_project_monthly_cost",
+			help:    "The estimated monthly cost of the project in USD.",
+			samples: projectCosts,
+		},
+		{
+			name:    "infracost_project_resources",
+			help:    "The number of resources in the cost breakdown of the project.",
+			samples: projectResources,
+		},
+	}
+
+	if hasPast {
+		metrics = append(metrics,
+			prometheusMetric{
+				name:    "infracost_total_past_monthly_cost",
+				help:    "The estimated monthly cost of all the projects before the changes in USD.",
+				samples: []prometheusSample{{nil, decimalPtr(totalPast)}},
+			},
+			prometheusMetric{
+				name:    "infracost_project_past_monthly_cost",
+				help:    "The estimated monthly cost of the project before the changes in USD.",
+				samples: projectPastCosts,
+			},
+		)
+	}
+
+	if !out.TimeGenerated.IsZero() {
+		metrics = append(metrics, prometheusMetric{
+			name:    "infracost_time_generated_seconds",
+			help:    "The Unix time that the estimate was generated.",
+			samples: []prometheusSample{{nil, decimalPtr(decimal.NewFromInt(out.TimeGenerated.Unix()))}},
+		})
+	}
+
+	buf := bytes.NewBuffer([]byte{})
+	for _, m := range metrics {
+		fmt.Fprintf(buf, "# HELP %s %s\n", m.name, m.help)
+		fmt.Fprintf(buf, "# TYPE %s gauge\n", m.name)
+
+		for _, s := range m.samples {
+			value := decimal.Zero
+			if s.value != nil {
+				value = *s.value
+			}
+
+			fmt.Fprintf(buf, "%s%s %s\n", m.name, formatPrometheusLabels(s.labels), value.String())
+		}
+	}
+
+	// The other formats don't end with a newline, so the output is printed the
+	// same way for all of them
+	buf.WriteString("# EOF")
+
+	return buf.Bytes(), nil
+}
+
+func formatPrometheusLabels(labels [][2]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(labels))
+	for _, l := range labels {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, l[0], prometheusLabelReplacer.Replace(l[1])))
+	}
+
+	return fmt.Sprintf("{%s}", strings.Join(pairs, ","))
+}
+
+// PushgatewayURL returns the URL that the metrics are pushed to for a
+// Pushgateway URL. If the URL is only the address of the Pushgateway, the
+// metrics are grouped by the infracost job.
+func PushgatewayURL(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("Invalid Pushgateway URL %s, expected an http(s) URL", rawURL)
+	}
+
+	if !strings.Contains(u.Path, "/metrics/job/") {
+		u.Path = fmt.Sprintf("%s/metrics/job/%s", strings.TrimSuffix(u.Path, "/"), defaultPushgatewayJob)
+	}
+
+	return u.String(), nil
+}
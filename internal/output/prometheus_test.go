@@ -0,0 +1,75 @@
+package output
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestToPrometheus(t *testing.T) {
+	out := Root{
+		TotalMonthlyCost: decimalPtr(decimal.RequireFromString("150.5")),
+		TimeGenerated:    time.Unix(1600000000, 0),
+		Projects: []Project{
+			{
+				Name:          "infracost/\"web\"",
+				PastBreakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(100))},
+				Breakdown: &Breakdown{
+					Resources:        []Resource{{Name: "aws_instance.web"}, {Name: "aws_eip.web"}},
+					TotalMonthlyCost: decimalPtr(decimal.RequireFromString("150.5")),
+				},
+			},
+			{
+				Name:      "infracost/db",
+				Breakdown: &Breakdown{},
+			},
+		},
+	}
+
+	b, err := ToPrometheus(out, Options{})
+	assert.Equal(t, nil, err)
+
+	expected := `# HELP infracost_total_monthly_cost The estimated monthly cost of all the projects in USD.
+# TYPE infracost_total_monthly_cost gauge
+infracost_total_monthly_cost 150.5
+# HELP infracost_project_monthly_cost The estimated monthly cost of the project in USD.
+# TYPE infracost_project_monthly_cost gauge
+infracost_project_monthly_cost{project="infracost/\"web\""} 150.5
+infracost_project_monthly_cost{project="infracost/db"} 0
+# HELP infracost_project_resources The number of resources in the cost breakdown of the project.
+# TYPE infracost_project_resources gauge
+infracost_project_resources{project="infracost/\"web\""} 2
+infracost_project_resources{project="infracost/db"} 0
+# HELP infracost_total_past_monthly_cost The estimated monthly cost of all the projects before the changes in USD.
+# TYPE infracost_total_past_monthly_cost gauge
+infracost_total_past_monthly_cost 100
+# HELP infracost_project_past_monthly_cost The estimated monthly cost of the project before the changes in USD.
+# TYPE infracost_project_past_monthly_cost gauge
+infracost_project_past_monthly_cost{project="infracost/\"web\""} 100
+# HELP infracost_time_generated_seconds The Unix time that the estimate was generated.
+# TYPE infracost_time_generated_seconds gauge
+infracost_time_generated_seconds 1600000000
+# EOF`
+	assert.Equal(t, expected, string(b))
+}
+
+func TestPushgatewayURL(t *testing.T) {
+	tests := []struct {
+		rawURL   string
+		expected string
+		err      bool
+	}{
+		{"http://pushgateway:9091", "http://pushgateway:9091/metrics/job/infracost", false},
+		{"https://pushgateway.example.com/", "https://pushgateway.example.com/metrics/job/infracost", false},
+		{"http://pushgateway:9091/metrics/job/costs/env/prod", "http://pushgateway:9091/metrics/job/costs/env/prod", false},
+		{"pushgateway:9091", "", true},
+	}
+
+	for _, test := range tests {
+		actual, err := PushgatewayURL(test.rawURL)
+		assert.Equal(t, test.err, err != nil)
+		assert.Equal(t, test.expected, actual)
+	}
+}
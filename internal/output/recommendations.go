@@ -0,0 +1,253 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/infracost/infracost/internal/i18n"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/shopspring/decimal"
+)
+
+// The types of recommendations, which are output so tools can filter them.
+const (
+	RecommendationGP3                = "gp2_to_gp3"
+	RecommendationPreviousGeneration = "previous_generation_instance"
+	RecommendationUnattachedEIP      = "unattached_eip"
+	RecommendationOversizedRDS       = "oversized_rds"
+)
+
+// Recommendation is a change to a resource that's likely to reduce its cost.
+// The savings are estimated from the monthly cost of the cost components that
+// the change affects.
+type Recommendation struct {
+	Project        string           `json:"project"`
+	ResourceName   string           `json:"resourceName"`
+	ResourceType   string           `json:"resourceType"`
+	Type           string           `json:"type"`
+	Description    string           `json:"description"`
+	MonthlyCost    *decimal.Decimal `json:"monthlyCost"`
+	MonthlySavings *decimal.Decimal `json:"monthlySavings"`
+}
+
+// gp3SavingsRatio is how much cheaper gp3 storage is per GB than gp2 storage.
+var gp3SavingsRatio = decimal.NewFromFloat(0.2)
+
+// previousGenerationFamilies maps the previous generation EC2 instance
+// families to the current generation family that replaces them, and the
+// ratio of their on-demand prices in us-east-1.
+var previousGenerationFamilies = map[string]struct {
+	family     string
+	priceRatio float64
+}{
+	"t2": {"t3", 0.9},
+	"m3": {"m5", 0.72},
+	"m4": {"m5", 0.96},
+	"c3": {"c5", 0.81},
+	"c4": {"c5", 0.85},
+	"r3": {"r5", 0.76},
+	"r4": {"r5", 0.95},
+	"i2": {"i3", 0.37},
+	"d2": {"d3", 0.72},
+}
+
+// rdsInstanceSizes are the sizes of RDS instance classes in vCPUs. Classes of
+// oversizedRDSMinSize or larger are recommended to be checked, since most
+// databases don't need them.
+var rdsInstanceSizes = []struct {
+	name  string
+	vCPUs int64
+}{
+	{"large", 2},
+	{"xlarge", 4},
+	{"2xlarge", 8},
+	{"4xlarge", 16},
+	{"8xlarge", 32},
+	{"12xlarge", 48},
+	{"16xlarge", 64},
+	{"24xlarge", 96},
+}
+
+const oversizedRDSMinSize = "8xlarge"
+
+// BuildRecommendations finds the planned resources of the projects that are
+// likely to be over-provisioned, sorted by their estimated savings.
+func BuildRecommendations(projects []*schema.Project) []Recommendation {
+	recs := make([]Recommendation, 0)
+
+	for _, p := range projects {
+		for _, r := range p.Resources {
+			if r.IsSkipped {
+				continue
+			}
+
+			for _, rec := range resourceRecommendations(r) {
+				rec.Project = p.Name
+				rec.ResourceName = r.Name
+				rec.ResourceType = r.ResourceType
+				recs = append(recs, rec)
+			}
+		}
+	}
+
+	sort.SliceStable(recs, func(i, j int) bool {
+		return recs[i].MonthlySavings.GreaterThan(*recs[j].MonthlySavings)
+	})
+
+	return recs
+}
+
+func resourceRecommendations(r *schema.Resource) []Recommendation {
+	recs := make([]Recommendation, 0)
+
+	if r.ResourceType == "aws_eip" && r.MonthlyCost != nil && r.MonthlyCost.IsPositive() {
+		recs = append(recs, Recommendation{
+			Type:           RecommendationUnattachedEIP,
+			Description:    "Release the Elastic IP or attach it to a running instance, unattached Elastic IPs are charged hourly.",
+			MonthlyCost:    r.MonthlyCost,
+			MonthlySavings: r.MonthlyCost,
+		})
+	}
+
+	gp2Cost := decimal.Zero
+	for _, c := range allCostComponents(r) {
+		if c.MonthlyCost == nil || !c.MonthlyCost.IsPositive() || c.ProductFilter == nil {
+			continue
+		}
+
+		if productFilterAttribute(c.ProductFilter, "volumeApiName") == "gp2" {
+			gp2Cost = gp2Cost.Add(*c.MonthlyCost)
+		}
+
+		instanceType := productFilterAttribute(c.ProductFilter, "instanceType")
+		if instanceType == "" || c.ProductFilter.ProductFamily == nil {
+			continue
+		}
+
+		switch *c.ProductFilter.ProductFamily {
+		case "Compute Instance":
+			if rec, ok := previousGenerationRecommendation(instanceType, *c.MonthlyCost); ok {
+				recs = append(recs, rec)
+			}
+		case "Database Instance":
+			if rec, ok := oversizedRDSRecommendation(instanceType, *c.MonthlyCost); ok {
+				recs = append(recs, rec)
+			}
+		}
+	}
+
+	if gp2Cost.IsPositive() {
+		recs = append(recs, Recommendation{
+			Type:           RecommendationGP3,
+			Description:    "Use gp3 volumes instead of gp2, they're 20% cheaper per GB and have a higher baseline performance.",
+			MonthlyCost:    decimalPtr(gp2Cost),
+			MonthlySavings: decimalPtr(gp2Cost.Mul(gp3SavingsRatio)),
+		})
+	}
+
+	return recs
+}
+
+func previousGenerationRecommendation(instanceType string, monthlyCost decimal.Decimal) (Recommendation, bool) {
+	parts := strings.SplitN(instanceType, ".", 2)
+	if len(parts) != 2 {
+		return Recommendation{}, false
+	}
+
+	next, ok := previousGenerationFamilies[parts[0]]
+	if !ok {
+		return Recommendation{}, false
+	}
+
+	replacement := fmt.Sprintf("%s.%s", next.family, parts[1])
+
+	return Recommendation{
+		Type:           RecommendationPreviousGeneration,
+		Description:    fmt.Sprintf("%s is a previous generation instance type, %s is a current generation replacement.", instanceType, replacement),
+		MonthlyCost:    decimalPtr(monthlyCost),
+		MonthlySavings: decimalPtr(monthlyCost.Mul(decimal.NewFromInt(1).Sub(decimal.NewFromFloat(next.priceRatio)))),
+	}, true
+}
+
+func oversizedRDSRecommendation(instanceClass string, monthlyCost decimal.Decimal) (Recommendation, bool) {
+	i := strings.LastIndex(instanceClass, ".")
+	if i == -1 {
+		return Recommendation{}, false
+	}
+	prefix, size := instanceClass[:i], instanceClass[i+1:]
+
+	minIndex := -1
+	for j, s := range rdsInstanceSizes {
+		if s.name == oversizedRDSMinSize {
+			minIndex = j
+		}
+		if s.name != size {
+			continue
+		}
+		if minIndex == -1 || j < minIndex {
+			return Recommendation{}, false
+		}
+
+		smaller := rdsInstanceSizes[j-1]
+		ratio := decimal.NewFromInt(smaller.vCPUs).Div(decimal.NewFromInt(s.vCPUs))
+		savings := monthlyCost.Mul(decimal.NewFromInt(1).Sub(ratio))
+
+		return Recommendation{
+			Type:           RecommendationOversizedRDS,
+			Description:    fmt.Sprintf("%s is a large instance class, check its utilization. The next size down, %s.%s, would cost %s%% less.", instanceClass, prefix, smaller.name, decimal.NewFromInt(1).Sub(ratio).Mul(decimal.NewFromInt(100)).Round(0).String()),
+			MonthlyCost:    decimalPtr(monthlyCost),
+			MonthlySavings: decimalPtr(savings),
+		}, true
+	}
+
+	return Recommendation{}, false
+}
+
+// allCostComponents returns the cost components of the resource and its
+// sub-resources.
+func allCostComponents(r *schema.Resource) []*schema.CostComponent {
+	components := append([]*schema.CostComponent{}, r.CostComponents...)
+	for _, s := range r.SubResources {
+		components = append(components, allCostComponents(s)...)
+	}
+
+	return components
+}
+
+func productFilterAttribute(f *schema.ProductFilter, key string) string {
+	for _, a := range f.AttributeFilters {
+		if a.Key == key && a.Value != nil {
+			return *a.Value
+		}
+	}
+
+	return ""
+}
+
+func recommendationsToTable(recs []Recommendation) string {
+	t := newSummaryTable()
+	t.AppendHeader(table.Row{
+		ui.UnderlineString("Resource"),
+		ui.UnderlineString("Recommendation"),
+		ui.UnderlineString("Monthly savings"),
+	})
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 2, WidthMax: 80},
+		{Number: 3, Align: text.AlignRight, AlignHeader: text.AlignRight},
+	})
+
+	total := decimal.Zero
+	for _, rec := range recs {
+		t.AppendRow(table.Row{rec.ResourceName, rec.Description, formatCost2DP(rec.MonthlySavings)})
+		total = total.Add(*rec.MonthlySavings)
+	}
+
+	t.AppendRow(table.Row{""})
+	t.AppendRow(table.Row{ui.BoldString(i18n.T("Total")), "", ui.BoldString(formatCost2DP(decimalPtr(total)))})
+
+	return fmt.Sprintf("%s\n\n%s", ui.BoldString("Recommendations"), t.Render())
+}
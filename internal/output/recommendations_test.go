@@ -0,0 +1,84 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/shopspring/decimal"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestBuildRecommendations(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	newComponent := func(productFamily string, key string, value string, monthlyCost int64) *schema.CostComponent {
+		return &schema.CostComponent{
+			ProductFilter: &schema.ProductFilter{
+				VendorName:       strPtr("aws"),
+				ProductFamily:    strPtr(productFamily),
+				AttributeFilters: []*schema.AttributeFilter{{Key: key, Value: strPtr(value)}},
+			},
+			MonthlyCost: decimalPtr(decimal.NewFromInt(monthlyCost)),
+		}
+	}
+
+	project := schema.NewProject("my-project", &schema.ProjectMetadata{})
+	project.Resources = []*schema.Resource{
+		{
+			Name:           "aws_instance.web",
+			ResourceType:   "aws_instance",
+			CostComponents: []*schema.CostComponent{newComponent("Compute Instance", "instanceType", "m4.large", 100)},
+			SubResources: []*schema.Resource{
+				{Name: "root_block_device", CostComponents: []*schema.CostComponent{newComponent("Storage", "volumeApiName", "gp2", 10)}},
+				{Name: "ebs_block_device[0]", CostComponents: []*schema.CostComponent{newComponent("Storage", "volumeApiName", "gp2", 40)}},
+			},
+		},
+		{
+			Name:           "aws_db_instance.db",
+			ResourceType:   "aws_db_instance",
+			CostComponents: []*schema.CostComponent{newComponent("Database Instance", "instanceType", "db.r5.16xlarge", 4000)},
+		},
+		{
+			Name:           "aws_db_instance.small",
+			ResourceType:   "aws_db_instance",
+			CostComponents: []*schema.CostComponent{newComponent("Database Instance", "instanceType", "db.r5.4xlarge", 1000)},
+		},
+		{
+			Name:         "aws_eip.ip",
+			ResourceType: "aws_eip",
+			MonthlyCost:  decimalPtr(decimal.NewFromFloat(3.65)),
+		},
+		{
+			Name:         "aws_ebs_volume.free",
+			ResourceType: "aws_ebs_volume",
+			IsSkipped:    true,
+		},
+	}
+
+	recs := BuildRecommendations([]*schema.Project{project})
+	assert.Equal(t, 4, len(recs))
+
+	assert.Equal(t, "aws_db_instance.db", recs[0].ResourceName)
+	assert.Equal(t, RecommendationOversizedRDS, recs[0].Type)
+	assert.Equal(t, "1000", recs[0].MonthlySavings.String())
+	assert.Equal(t, "db.r5.16xlarge is a large instance class, check its utilization. The next size down, db.r5.12xlarge, would cost 25% less.", recs[0].Description)
+
+	assert.Equal(t, RecommendationGP3, recs[1].Type)
+	assert.Equal(t, "aws_instance.web", recs[1].ResourceName)
+	assert.Equal(t, "50", recs[1].MonthlyCost.String())
+	assert.Equal(t, "10", recs[1].MonthlySavings.String())
+
+	assert.Equal(t, RecommendationPreviousGeneration, recs[2].Type)
+	assert.Equal(t, "4", recs[2].MonthlySavings.String())
+	assert.Equal(t, "m4.large is a previous generation instance type, m5.large is a current generation replacement.", recs[2].Description)
+
+	assert.Equal(t, RecommendationUnattachedEIP, recs[3].Type)
+	assert.Equal(t, "my-project", recs[3].Project)
+	assert.Equal(t, "3.65", recs[3].MonthlySavings.String())
+
+	table := ui.StripColor(recommendationsToTable(recs))
+	assert.Equal(t, true, strings.Contains(table, "Recommendations"))
+	assert.Equal(t, true, strings.Contains(table, "$1,017.65"))
+}
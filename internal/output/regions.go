@@ -0,0 +1,31 @@
+package output
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// RegionLabel is the project label that names the region a project was
+// priced in when regions are compared.
+const RegionLabel = "region"
+
+// RegionComparison is the monthly cost of each resource in each region. The
+// costs are in the same order as the regions, and are nil if the resource
+// isn't priced in that region.
+type RegionComparison struct {
+	Regions           []string             `json:"regions"`
+	Resources         []ComparisonResource `json:"resources"`
+	TotalMonthlyCosts []*decimal.Decimal   `json:"totalMonthlyCosts"`
+}
+
+// BuildRegionComparison compares the projects that have the region label, in
+// the order they were priced.
+func BuildRegionComparison(out Root) *RegionComparison {
+	c := &RegionComparison{}
+	c.Regions, c.Resources, c.TotalMonthlyCosts = buildLabelComparison(out, RegionLabel)
+
+	return c
+}
+
+func regionComparisonToTable(c *RegionComparison) string {
+	return comparisonToTable("Monthly cost by region", c.Regions, c.Resources, c.TotalMonthlyCosts)
+}
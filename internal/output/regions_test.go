@@ -0,0 +1,50 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/shopspring/decimal"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestBuildRegionComparison(t *testing.T) {
+	out := Root{
+		Projects: []Project{
+			{
+				Name:     "my-project (us-east-1)",
+				Metadata: &schema.ProjectMetadata{Labels: map[string]string{RegionLabel: "us-east-1"}},
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web", MonthlyCost: decimalPtr(decimal.NewFromInt(70))},
+					},
+					TotalMonthlyCost: decimalPtr(decimal.NewFromInt(70)),
+				},
+			},
+			{
+				Name:     "my-project (ap-southeast-2)",
+				Metadata: &schema.ProjectMetadata{Labels: map[string]string{RegionLabel: "ap-southeast-2"}},
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web", MonthlyCost: decimalPtr(decimal.NewFromInt(88))},
+					},
+					TotalMonthlyCost: decimalPtr(decimal.NewFromInt(88)),
+				},
+			},
+		},
+	}
+
+	c := BuildRegionComparison(out)
+
+	assert.Equal(t, []string{"us-east-1", "ap-southeast-2"}, c.Regions)
+	assert.Equal(t, 1, len(c.Resources))
+	assert.Equal(t, "70", c.Resources[0].MonthlyCosts[0].String())
+	assert.Equal(t, "88", c.Resources[0].MonthlyCosts[1].String())
+
+	table := ui.StripColor(regionComparisonToTable(c))
+	assert.Equal(t, true, strings.Contains(table, "Monthly cost by region"))
+	assert.Equal(t, true, strings.Contains(table, "ap-southeast-2"))
+	assert.Equal(t, true, strings.Contains(table, "$88.00"))
+}
@@ -0,0 +1,159 @@
+package output
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v2"
+)
+
+// loadRollupFile reads a versioned YAML file that projects are rolled up
+// with, such as the hierarchy or accounts file, into v. The kind is used in
+// the error messages.
+func loadRollupFile(path string, kind string, version string, v interface{}) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(err, "Error reading %s file", kind)
+	}
+
+	var header struct {
+		Version string `yaml:"version"`
+	}
+	err = yaml.Unmarshal(b, &header)
+	if err == nil {
+		err = yaml.Unmarshal(b, v)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "Error parsing %s file", kind)
+	}
+
+	if header.Version != version {
+		return fmt.Errorf("Invalid %s file version. Supported versions are %s", kind, version)
+	}
+
+	return nil
+}
+
+// validateProjectPatterns checks the project glob patterns of a cost center
+// or account, which is named by owner in the error message.
+func validateProjectPatterns(kind string, owner string, patterns []string) error {
+	for _, p := range patterns {
+		if _, err := filepath.Match(p, ""); err != nil {
+			return fmt.Errorf("Invalid %s file: %s has an invalid project pattern '%s'", kind, owner, p)
+		}
+	}
+
+	return nil
+}
+
+// matchesProjectPatterns checks if the project's name or path matches any of
+// the glob patterns.
+func matchesProjectPatterns(p Project, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, p.Name); ok {
+			return true
+		}
+
+		if p.Metadata != nil && p.Metadata.Path != "" {
+			if ok, _ := filepath.Match(pattern, p.Metadata.Path); ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// rollupProjects assigns the projects that match and haven't already been
+// assigned, returning their names and total monthly cost.
+func rollupProjects(out Root, assigned map[int]bool, matches func(p Project) bool) ([]string, *decimal.Decimal) {
+	names := []string{}
+	total := decimal.Zero
+
+	for i, p := range out.Projects {
+		if assigned[i] || !matches(p) {
+			continue
+		}
+
+		assigned[i] = true
+		names = append(names, p.Name)
+
+		if p.Breakdown != nil && p.Breakdown.TotalMonthlyCost != nil {
+			total = total.Add(*p.Breakdown.TotalMonthlyCost)
+		}
+	}
+
+	return names, &total
+}
+
+func unassignedProjects(out Root, assigned map[int]bool) []string {
+	var names []string
+
+	for i, p := range out.Projects {
+		if !assigned[i] {
+			names = append(names, p.Name)
+		}
+	}
+
+	return names
+}
+
+// unassignedProjectsToText lists the projects that weren't assigned to any
+// of the rollups, e.g. "a cost center".
+func unassignedProjectsToText(names []string, target string) string {
+	if len(names) == 0 {
+		return ""
+	}
+
+	projectLabel := "projects aren't"
+	if len(names) == 1 {
+		projectLabel = "project isn't"
+	}
+
+	s := fmt.Sprintf("\n\n%d %s assigned to %s:", len(names), projectLabel, target)
+	for _, name := range names {
+		s += fmt.Sprintf("\n  %s", name)
+	}
+
+	return s
+}
+
+// newSummaryTable returns a table writer with the borderless style of the
+// summary tables.
+func newSummaryTable() table.Writer {
+	t := table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+	t.Style().Options.SeparateRows = false
+	t.Style().Options.SeparateHeader = false
+	t.Style().Format.Header = text.FormatDefault
+
+	return t
+}
+
+// appendSummaryHeader adds the underlined headers to the table. The first
+// leftColumns columns are left aligned and the rest are right aligned, since
+// they're costs or counts.
+func appendSummaryHeader(t table.Writer, leftColumns int, headers ...string) {
+	row := make(table.Row, 0, len(headers))
+	columnConfigs := make([]table.ColumnConfig, 0, len(headers))
+
+	for i, h := range headers {
+		row = append(row, ui.UnderlineString(h))
+
+		align := text.AlignRight
+		if i < leftColumns {
+			align = text.AlignLeft
+		}
+		columnConfigs = append(columnConfigs, table.ColumnConfig{Number: i + 1, Align: align, AlignHeader: align})
+	}
+
+	t.AppendHeader(row)
+	t.SetColumnConfigs(columnConfigs)
+}
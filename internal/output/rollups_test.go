@@ -0,0 +1,133 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/shopspring/decimal"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestBuildCostCenterRollups(t *testing.T) {
+	out := Root{
+		Projects: []Project{
+			{Name: "infra/networking/vpc", Breakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(100))}},
+			{Name: "infra/eks", Breakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(50))}},
+			{Name: "payments", Metadata: &schema.ProjectMetadata{Path: "services/payments-api"}, Breakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(25))}},
+			{Name: "sandbox", Breakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(5))}},
+		},
+	}
+
+	h := &Hierarchy{
+		Levels: []string{"org", "department", "team"},
+		CostCenters: []*CostCenter{
+			{
+				Name: "Acme",
+				Children: []*CostCenter{
+					{
+						Name: "Engineering",
+						Children: []*CostCenter{
+							{Name: "Platform", Projects: []string{"infra/*", "infra/networking/*"}},
+							{Name: "Payments", Projects: []string{"services/payments*"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	rollups := BuildCostCenterRollups(out, h)
+
+	assert.Equal(t, []string{"sandbox"}, rollups.UnassignedProjects)
+	assert.Equal(t, 1, len(rollups.CostCenters))
+
+	acme := rollups.CostCenters[0]
+	assert.Equal(t, "org", acme.Level)
+	assert.Equal(t, "175", acme.TotalMonthlyCost.String())
+
+	engineering := acme.Children[0]
+	assert.Equal(t, "department", engineering.Level)
+	assert.Equal(t, "175", engineering.TotalMonthlyCost.String())
+
+	platform := engineering.Children[0]
+	assert.Equal(t, "team", platform.Level)
+	assert.Equal(t, []string{"infra/networking/vpc", "infra/eks"}, platform.Projects)
+	assert.Equal(t, "150", platform.TotalMonthlyCost.String())
+
+	payments := engineering.Children[1]
+	assert.Equal(t, []string{"payments"}, payments.Projects)
+	assert.Equal(t, "25", payments.TotalMonthlyCost.String())
+}
+
+func TestBuildAccountRollups(t *testing.T) {
+	out := Root{
+		Projects: []Project{
+			{
+				Name:      "prod-app",
+				Metadata:  &schema.ProjectMetadata{Labels: map[string]string{AccountLabel: "111111111111"}},
+				Breakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(1000))},
+			},
+			{
+				Name:      "sandbox-1",
+				Metadata:  &schema.ProjectMetadata{Path: "sandbox/1"},
+				Breakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(300))},
+			},
+			{Name: "other", Breakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(5))}},
+		},
+	}
+
+	accounts := &Accounts{
+		Accounts: []*Account{
+			{ID: "111111111111", Name: "production", DiscountPercent: 10, MonthlyCredit: 50},
+			{ID: "333333333333", Projects: []string{"sandbox-*"}, MonthlyCredit: 500},
+		},
+	}
+
+	rollups := BuildAccountRollups(out, accounts)
+
+	assert.Equal(t, 2, len(rollups.Accounts))
+
+	prod := rollups.Accounts[0]
+	assert.Equal(t, []string{"prod-app"}, prod.Projects)
+	assert.Equal(t, "1000", prod.SubtotalMonthlyCost.String())
+	assert.Equal(t, "100", prod.Discount.String())
+	assert.Equal(t, "50", prod.Credit.String())
+	assert.Equal(t, "850", prod.TotalMonthlyCost.String())
+
+	// The credit is capped at the cost of the account
+	sandbox := rollups.Accounts[1]
+	assert.Equal(t, []string{"sandbox-1"}, sandbox.Projects)
+	assert.Equal(t, "300", sandbox.Credit.String())
+	assert.Equal(t, "0", sandbox.TotalMonthlyCost.String())
+
+	assert.Equal(t, "850", rollups.TotalMonthlyCost.String())
+	assert.Equal(t, []string{"other"}, rollups.UnassignedProjects)
+
+	table := ui.StripColor(accountRollupsToTable(rollups))
+	assert.Equal(t, true, strings.Contains(table, "production (111111111111)"))
+	assert.Equal(t, true, strings.Contains(table, "-$150.00"))
+}
+
+func TestLoadAccounts(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.yml")
+	err := os.WriteFile(valid, []byte("version: 0.1\naccounts:\n  - id: \"111111111111\"\n    discount_percent: 10\n"), 0600)
+	assert.Equal(t, nil, err)
+
+	a, err := LoadAccounts(valid)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "111111111111", a.Accounts[0].ID)
+	assert.Equal(t, 10.0, a.Accounts[0].DiscountPercent)
+
+	invalid := filepath.Join(dir, "invalid.yml")
+	err = os.WriteFile(invalid, []byte("version: 0.1\naccounts:\n  - id: \"1234\"\n"), 0600)
+	assert.Equal(t, nil, err)
+
+	_, err = LoadAccounts(invalid)
+	assert.NotEqual(t, nil, err)
+}
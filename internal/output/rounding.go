@@ -0,0 +1,38 @@
+package output
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+)
+
+// roundingPolicy is used to round the totals and format the costs of all
+// the outputs. Costs aren't rounded when it's nil.
+var roundingPolicy *schema.RoundingPolicy
+
+// SetRoundingPolicy sets the rounding policy of the outputs. The costs of the
+// resources are rounded when they're calculated, this rounds the project and
+// overall totals, including the totals of merged and combined reports, so they
+// match the policy too.
+func SetRoundingPolicy(policy *schema.RoundingPolicy) {
+	roundingPolicy = policy
+}
+
+func roundTotal(d *decimal.Decimal) *decimal.Decimal {
+	if roundingPolicy == nil {
+		return d
+	}
+
+	return roundingPolicy.Round(d)
+}
+
+// roundForDisplay rounds the cost to the decimal places that are shown using
+// the mode of the rounding policy, so values on a tie are shown the same way
+// they would be rounded.
+func roundForDisplay(d *decimal.Decimal, places int32) *decimal.Decimal {
+	if roundingPolicy == nil || d == nil {
+		return d
+	}
+
+	p := schema.RoundingPolicy{Mode: roundingPolicy.Mode, Precision: places}
+	return p.Round(d)
+}
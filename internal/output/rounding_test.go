@@ -0,0 +1,49 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestRoundingPolicy(t *testing.T) {
+	SetRoundingPolicy(&schema.RoundingPolicy{Mode: schema.RoundingModeHalfEven, Level: schema.RoundingLevelTotal, Precision: 1})
+	defer SetRoundingPolicy(nil)
+
+	project := func(name, cost string) *schema.Project {
+		return &schema.Project{
+			Name: name,
+			Resources: []*schema.Resource{
+				{
+					Name:        "aws_instance.web",
+					HourlyCost:  decimalPtr(decimal.RequireFromString(cost).Div(decimal.NewFromInt(730))),
+					MonthlyCost: decimalPtr(decimal.RequireFromString(cost)),
+				},
+			},
+		}
+	}
+
+	out := ToOutputFormat([]*schema.Project{project("app", "10.25"), project("db", "20.35")})
+	assert.Equal(t, "10.2", out.Projects[0].Breakdown.TotalMonthlyCost.String())
+	assert.Equal(t, "0", out.Projects[0].Breakdown.TotalHourlyCost.String())
+	assert.Equal(t, "30.6", out.TotalMonthlyCost.String())
+
+	merged, _ := MergeInputs([]ReportInput{
+		{Metadata: map[string]string{"filename": "a.json"}, Root: out},
+		{Metadata: map[string]string{"filename": "b.json"}, Root: ToOutputFormat([]*schema.Project{project("db", "5.05")})},
+	}, []int{0, 5})
+	assert.Equal(t, "10.2", merged[0].Root.TotalMonthlyCost.String())
+
+	combined := Combine(merged, Options{})
+	assert.Equal(t, "15.2", combined.TotalMonthlyCost.String())
+
+	// The costs are shown using the rounding mode of the policy
+	assert.Equal(t, "$0.12", formatCost2DP(decimalPtr(decimal.RequireFromString("0.125"))))
+	assert.Equal(t, "$0.14", formatCost2DP(decimalPtr(decimal.RequireFromString("0.135"))))
+	assert.Equal(t, "$102", formatCost(decimalPtr(decimal.RequireFromString("102.5"))))
+
+	SetRoundingPolicy(nil)
+	assert.Equal(t, "10.25", ToOutputFormat([]*schema.Project{project("app", "10.25")}).TotalMonthlyCost.String())
+}
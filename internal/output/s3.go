@@ -0,0 +1,63 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+)
+
+// s3Endpoint overrides the endpoint of the S3 API, it's empty to use the
+// default AWS endpoint. It's a var so it can be changed in the tests.
+var s3Endpoint = ""
+
+// putS3Object uploads the object using the AWS SDK's default credential chain,
+// so the credentials can come from the environment, a shared config profile,
+// web identity or an instance role.
+func putS3Object(bucket string, key string, body []byte, contentType string) error {
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return errors.Wrap(err, "Error loading AWS config")
+	}
+
+	region := aws.StringValue(sess.Config.Region)
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), destinationTimeout)
+	defer cancel()
+
+	_, err = s3.New(sess, s3Config(bucket, region)).PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(body),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "Error uploading output to s3://%s/%s", bucket, key)
+	}
+
+	return nil
+}
+
+// s3Config returns the S3 client config for the bucket. Bucket names with dots
+// don't match the wildcard TLS certificate of the virtual-hosted style URLs so
+// they use path-style URLs.
+func s3Config(bucket string, region string) *aws.Config {
+	cfg := aws.NewConfig().
+		WithRegion(region).
+		WithS3ForcePathStyle(strings.Contains(bucket, "."))
+
+	if s3Endpoint != "" {
+		cfg = cfg.WithEndpoint(s3Endpoint)
+	}
+
+	return cfg
+}
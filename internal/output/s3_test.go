@@ -0,0 +1,14 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestS3Config(t *testing.T) {
+	assert.Equal(t, false, aws.BoolValue(s3Config("my-bucket", "us-east-1").S3ForcePathStyle))
+	assert.Equal(t, true, aws.BoolValue(s3Config("reports.example.com", "us-east-1").S3ForcePathStyle))
+	assert.Equal(t, "eu-west-1", aws.StringValue(s3Config("my-bucket", "eu-west-1").Region))
+}
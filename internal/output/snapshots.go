@@ -0,0 +1,330 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+var snapshotVersion = "0.1"
+
+// Snapshot records the monthly cost of a project at a point in time, with the
+// commit it was estimated for, so the trend of the cost can be reported.
+// Snapshots are stored one per line so saving one only appends to the file.
+type Snapshot struct {
+	Version              string           `json:"version"`
+	Project              string           `json:"project"`
+	TimeGenerated        time.Time        `json:"timeGenerated"`
+	TotalMonthlyCost     *decimal.Decimal `json:"totalMonthlyCost"`
+	Resources            int              `json:"resources"`
+	VCSBranch            string           `json:"vcsBranch,omitempty"`
+	VCSCommitSHA         string           `json:"vcsCommitSha,omitempty"`
+	VCSPullRequestNumber string           `json:"vcsPullRequestNumber,omitempty"`
+	Note                 string           `json:"note,omitempty"`
+}
+
+// NewSnapshots creates a snapshot of the planned breakdown of each project.
+func NewSnapshots(out Root, note string) []Snapshot {
+	snapshots := make([]Snapshot, 0, len(out.Projects))
+
+	for _, p := range out.Projects {
+		s := Snapshot{
+			Version:       snapshotVersion,
+			Project:       p.Name,
+			TimeGenerated: out.TimeGenerated.UTC(),
+			Note:          note,
+		}
+
+		if p.Breakdown != nil {
+			s.TotalMonthlyCost = p.Breakdown.TotalMonthlyCost
+			s.Resources = len(p.Breakdown.Resources)
+		}
+
+		if p.Metadata != nil {
+			s.VCSBranch = p.Metadata.VCSBranch
+			s.VCSCommitSHA = p.Metadata.VCSCommitSHA
+			s.VCSPullRequestNumber = p.Metadata.VCSPullRequestNumber
+		}
+
+		snapshots = append(snapshots, s)
+	}
+
+	return snapshots
+}
+
+// LoadSnapshots returns the snapshots in the store, sorted by the time they
+// were generated. A missing store has no snapshots.
+func LoadSnapshots(path string) ([]Snapshot, error) {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return []Snapshot{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading snapshot store")
+	}
+
+	snapshots := make([]Snapshot, 0)
+
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var s Snapshot
+		err = json.Unmarshal(scanner.Bytes(), &s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error parsing snapshot store line %d", line)
+		}
+
+		if s.Version != snapshotVersion {
+			return nil, fmt.Errorf("Invalid snapshot version on line %d. Supported versions are %s", line, snapshotVersion)
+		}
+
+		snapshots = append(snapshots, s)
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].TimeGenerated.Before(snapshots[j].TimeGenerated)
+	})
+
+	return snapshots, nil
+}
+
+// SaveSnapshots appends the snapshots to the store, creating it if needed.
+func SaveSnapshots(path string, snapshots []Snapshot) error {
+	var buf bytes.Buffer
+	for _, s := range snapshots {
+		b, err := json.Marshal(s)
+		if err != nil {
+			return errors.Wrap(err, "Error generating snapshot")
+		}
+		buf.Write(b)
+		buf.WriteByte('\n')
+	}
+
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return errors.Wrap(err, "Error creating snapshot store dir")
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrap(err, "Error opening snapshot store")
+	}
+	defer f.Close()
+
+	_, err = f.Write(buf.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "Error writing snapshot store")
+	}
+
+	return nil
+}
+
+// FilterSnapshots returns the snapshots of the project, or all the snapshots
+// if project is empty.
+func FilterSnapshots(snapshots []Snapshot, project string) []Snapshot {
+	if project == "" {
+		return snapshots
+	}
+
+	filtered := make([]Snapshot, 0)
+	for _, s := range snapshots {
+		if s.Project == project {
+			filtered = append(filtered, s)
+		}
+	}
+
+	return filtered
+}
+
+// TrendReport is how the monthly cost of each project changed between its
+// snapshots.
+type TrendReport struct {
+	Projects []ProjectTrend `json:"projects"`
+}
+
+type ProjectTrend struct {
+	Name                     string           `json:"name"`
+	Points                   []TrendPoint     `json:"points"`
+	FirstMonthlyCost         *decimal.Decimal `json:"firstMonthlyCost"`
+	LastMonthlyCost          *decimal.Decimal `json:"lastMonthlyCost"`
+	MonthlyCostChange        *decimal.Decimal `json:"monthlyCostChange"`
+	MonthlyCostChangePercent *decimal.Decimal `json:"monthlyCostChangePercent,omitempty"`
+}
+
+// TrendPoint is a snapshot and how its monthly cost changed since the
+// previous snapshot of the project.
+type TrendPoint struct {
+	TimeGenerated     time.Time        `json:"timeGenerated"`
+	VCSBranch         string           `json:"vcsBranch,omitempty"`
+	VCSCommitSHA      string           `json:"vcsCommitSha,omitempty"`
+	Note              string           `json:"note,omitempty"`
+	TotalMonthlyCost  *decimal.Decimal `json:"totalMonthlyCost"`
+	MonthlyCostChange *decimal.Decimal `json:"monthlyCostChange"`
+}
+
+// BuildTrendReport groups the snapshots by project, in the order the
+// projects were first snapshotted. The snapshots must be sorted by time.
+func BuildTrendReport(snapshots []Snapshot) *TrendReport {
+	r := &TrendReport{Projects: []ProjectTrend{}}
+	index := make(map[string]int)
+
+	for _, s := range snapshots {
+		i, ok := index[s.Project]
+		if !ok {
+			i = len(r.Projects)
+			index[s.Project] = i
+			r.Projects = append(r.Projects, ProjectTrend{Name: s.Project, Points: []TrendPoint{}})
+		}
+
+		p := &r.Projects[i]
+
+		var change *decimal.Decimal
+		if len(p.Points) > 0 {
+			change = diffDecimals(s.TotalMonthlyCost, p.Points[len(p.Points)-1].TotalMonthlyCost)
+		}
+
+		p.Points = append(p.Points, TrendPoint{
+			TimeGenerated:     s.TimeGenerated,
+			VCSBranch:         s.VCSBranch,
+			VCSCommitSHA:      s.VCSCommitSHA,
+			Note:              s.Note,
+			TotalMonthlyCost:  s.TotalMonthlyCost,
+			MonthlyCostChange: change,
+		})
+	}
+
+	for i := range r.Projects {
+		p := &r.Projects[i]
+		p.FirstMonthlyCost = p.Points[0].TotalMonthlyCost
+		p.LastMonthlyCost = p.Points[len(p.Points)-1].TotalMonthlyCost
+		p.MonthlyCostChange = diffDecimals(p.LastMonthlyCost, p.FirstMonthlyCost)
+
+		if p.FirstMonthlyCost != nil && !p.FirstMonthlyCost.IsZero() && p.MonthlyCostChange != nil {
+			p.MonthlyCostChangePercent = decimalPtr(p.MonthlyCostChange.Div(*p.FirstMonthlyCost).Mul(decimal.NewFromInt(100)).Round(1))
+		}
+	}
+
+	return r
+}
+
+// diffDecimals returns a - b, treating a missing cost as zero. It returns nil
+// if both are missing.
+func diffDecimals(a *decimal.Decimal, b *decimal.Decimal) *decimal.Decimal {
+	if a == nil && b == nil {
+		return nil
+	}
+
+	d := decimal.Zero
+	if a != nil {
+		d = d.Add(*a)
+	}
+	if b != nil {
+		d = d.Sub(*b)
+	}
+
+	return &d
+}
+
+// SnapshotsToTable lists the snapshots, newest last.
+func SnapshotsToTable(snapshots []Snapshot) string {
+	t := newSummaryTable()
+
+	t.AppendHeader(table.Row{
+		ui.UnderlineString("Time"),
+		ui.UnderlineString("Project"),
+		ui.UnderlineString("Branch"),
+		ui.UnderlineString("Commit"),
+		ui.UnderlineString("Monthly cost"),
+		ui.UnderlineString("Note"),
+	})
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 5, Align: text.AlignRight, AlignHeader: text.AlignRight},
+	})
+
+	for _, s := range snapshots {
+		t.AppendRow(table.Row{
+			s.TimeGenerated.Format("2006-01-02 15:04"),
+			s.Project,
+			s.VCSBranch,
+			shortSHA(s.VCSCommitSHA),
+			formatCost2DP(s.TotalMonthlyCost),
+			s.Note,
+		})
+	}
+
+	return t.Render()
+}
+
+// TrendReportToTable shows how the monthly cost of each project changed
+// between its snapshots.
+func TrendReportToTable(r *TrendReport) string {
+	s := ""
+
+	for i, p := range r.Projects {
+		if i != 0 {
+			s += "\n\n----------------------------------\n"
+		}
+
+		s += fmt.Sprintf("%s %s\n\n", ui.BoldString("Project:"), p.Name)
+
+		t := newSummaryTable()
+		t.AppendHeader(table.Row{
+			ui.UnderlineString("Time"),
+			ui.UnderlineString("Branch"),
+			ui.UnderlineString("Commit"),
+			ui.UnderlineString("Monthly cost"),
+			ui.UnderlineString("Change"),
+		})
+		t.SetColumnConfigs([]table.ColumnConfig{
+			{Number: 4, Align: text.AlignRight, AlignHeader: text.AlignRight},
+			{Number: 5, Align: text.AlignRight, AlignHeader: text.AlignRight},
+		})
+
+		for _, pt := range p.Points {
+			t.AppendRow(table.Row{
+				pt.TimeGenerated.Format("2006-01-02 15:04"),
+				pt.VCSBranch,
+				shortSHA(pt.VCSCommitSHA),
+				formatCost2DP(pt.TotalMonthlyCost),
+				formatCostChange(pt.MonthlyCostChange),
+			})
+		}
+
+		s += t.Render()
+		s += fmt.Sprintf("\n\n%s %s%s across %d snapshots",
+			ui.BoldString("Change:"),
+			formatCostChange(p.MonthlyCostChange),
+			formatCostChangeDetails(p.FirstMonthlyCost, p.LastMonthlyCost),
+			len(p.Points),
+		)
+		if pc := formatPercentChange(p.FirstMonthlyCost, p.LastMonthlyCost); pc != "" {
+			s += fmt.Sprintf(", %s", pc)
+		}
+	}
+
+	return s
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+
+	return sha
+}
@@ -0,0 +1,61 @@
+package output
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/shopspring/decimal"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestSnapshots(t *testing.T) {
+	store := filepath.Join(t.TempDir(), "snapshots", "snapshots.jsonl")
+
+	newOutput := func(timeGenerated time.Time, cost int64, sha string) Root {
+		return Root{
+			TimeGenerated: timeGenerated,
+			Projects: []Project{
+				{
+					Name:     "prod",
+					Metadata: &schema.ProjectMetadata{VCSBranch: "main", VCSCommitSHA: sha},
+					Breakdown: &Breakdown{
+						Resources:        []Resource{{Name: "aws_instance.web"}},
+						TotalMonthlyCost: decimalPtr(decimal.NewFromInt(cost)),
+					},
+				},
+			},
+		}
+	}
+
+	// Saved out of order to check they're sorted by time
+	err := SaveSnapshots(store, NewSnapshots(newOutput(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), 150, "bbb"), ""))
+	assert.Equal(t, nil, err)
+	err = SaveSnapshots(store, NewSnapshots(newOutput(time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), 100, "aaa"), "first"))
+	assert.Equal(t, nil, err)
+
+	snapshots, err := LoadSnapshots(store)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(snapshots))
+	assert.Equal(t, "aaa", snapshots[0].VCSCommitSHA)
+	assert.Equal(t, "first", snapshots[0].Note)
+	assert.Equal(t, 1, snapshots[0].Resources)
+	assert.Equal(t, 0, len(FilterSnapshots(snapshots, "staging")))
+
+	r := BuildTrendReport(snapshots)
+	assert.Equal(t, 1, len(r.Projects))
+	p := r.Projects[0]
+	assert.Equal(t, true, p.Points[0].MonthlyCostChange == nil)
+	assert.Equal(t, "50", p.Points[1].MonthlyCostChange.String())
+	assert.Equal(t, "50", p.MonthlyCostChange.String())
+	assert.Equal(t, "50", p.MonthlyCostChangePercent.String())
+
+	assert.Equal(t, true, strings.Contains(ui.StripColor(TrendReportToTable(r)), "Change: +$50.00 ($100 -> $150) across 2 snapshots, +50%"))
+
+	missing, err := LoadSnapshots(filepath.Join(t.TempDir(), "missing.jsonl"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(missing))
+}
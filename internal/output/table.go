@@ -2,8 +2,11 @@ package output
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"unicode/utf8"
 
+	"github.com/infracost/infracost/internal/i18n"
 	"github.com/infracost/infracost/internal/ui"
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/jedib0t/go-pretty/v6/text"
@@ -29,9 +32,10 @@ func ToTable(out Root, opts Options) ([]byte, error) {
 			s += "----------------------------------\n"
 		}
 
-		s += fmt.Sprintf("%s %s\n\n",
-			ui.BoldString("Project:"),
+		s += fmt.Sprintf("%s %s%s\n\n",
+			ui.BoldString(i18n.T("Project:")),
 			project.Name,
+			projectLabelsString(project),
 		)
 
 		if breakdownHasNilCosts(*project.Breakdown) {
@@ -42,7 +46,7 @@ func ToTable(out Root, opts Options) ([]byte, error) {
 
 		// Get the last table length so we can align the overall total with it
 		if i == len(out.Projects)-1 {
-			tableLen = len(ui.StripColor(strings.SplitN(tableOut, "\n", 2)[0]))
+			tableLen = utf8.RuneCountInString(ui.StripColor(strings.SplitN(tableOut, "\n", 2)[0]))
 		}
 
 		s += tableOut
@@ -60,19 +64,77 @@ func ToTable(out Root, opts Options) ([]byte, error) {
 
 	totalOut := formatCost2DP(out.TotalMonthlyCost)
 
+	totalLabel := " " + i18n.T("OVERALL TOTAL")
+
 	s += fmt.Sprintf("%s%s",
-		ui.BoldString(" OVERALL TOTAL"),
-		fmt.Sprintf("%*s ", tableLen-15, totalOut), // pad based on the last line length
+		ui.BoldString(totalLabel),
+		fmt.Sprintf("%*s ", tableLen-utf8.RuneCountInString(totalLabel)-1, totalOut), // pad based on the last line length
 	)
 
+	if opts.GroupBy != "" {
+		grouping, err := BuildGrouping(out, opts.GroupBy)
+		if err != nil {
+			return []byte{}, err
+		}
+
+		s += "\n----------------------------------\n"
+		s += groupingToTable(grouping)
+		s += "\n"
+	}
+
+	if opts.Hierarchy != nil {
+		s += "\n----------------------------------\n"
+		s += costCenterRollupsToTable(BuildCostCenterRollups(out, opts.Hierarchy))
+		s += "\n"
+	}
+
+	if opts.Accounts != nil {
+		s += "\n----------------------------------\n"
+		s += accountRollupsToTable(BuildAccountRollups(out, opts.Accounts))
+		s += "\n"
+	}
+
+	if opts.CompareEnvironments {
+		s += "\n----------------------------------\n"
+		s += environmentComparisonToTable(BuildEnvironmentComparison(out))
+		s += "\n"
+	}
+
+	if opts.CompareRegions {
+		s += "\n----------------------------------\n"
+		s += regionComparisonToTable(BuildRegionComparison(out))
+		s += "\n"
+	}
+
+	if len(out.Adjustments) > 0 {
+		s += "\n----------------------------------\n"
+		s += adjustmentsToTable(out.Adjustments)
+		s += "\n"
+	}
+
+	if len(out.Recommendations) > 0 {
+		s += "\n----------------------------------\n"
+		s += recommendationsToTable(out.Recommendations)
+		s += "\n"
+	}
+
 	unsupportedMsg := out.unsupportedResourcesMessage(opts.ShowSkipped)
+	completenessMsg := out.completenessMessage()
 
-	if hasNilCosts || unsupportedMsg != "" {
+	if hasNilCosts || unsupportedMsg != "" || completenessMsg != "" {
 		s += "\n----------------------------------"
 	}
 
+	if completenessMsg != "" {
+		s += "\n" + ui.WarningString(completenessMsg)
+
+		if hasNilCosts || unsupportedMsg != "" {
+			s += "\n"
+		}
+	}
+
 	if hasNilCosts {
-		s += fmt.Sprintf("\nTo estimate usage-based resources use --usage-file, see %s",
+		s += "\n" + i18n.Tf("To estimate usage-based resources use --usage-file, see %s",
 			ui.LinkString("https://infracost.io/usage-file"),
 		)
 
@@ -88,18 +150,34 @@ func ToTable(out Root, opts Options) ([]byte, error) {
 	return []byte(s), nil
 }
 
+// projectLabelsString returns the labels of the project sorted by key, e.g.
+// " (env=prod, team=payments)", so they can be shown next to its name.
+func projectLabelsString(project Project) string {
+	if project.Metadata == nil || len(project.Metadata.Labels) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(project.Metadata.Labels))
+	for k := range project.Metadata.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	labels := make([]string, 0, len(keys))
+	for _, k := range keys {
+		labels = append(labels, fmt.Sprintf("%s=%s", k, project.Metadata.Labels[k]))
+	}
+
+	return ui.FaintStringf(" (%s)", strings.Join(labels, ", "))
+}
+
 func tableForBreakdown(breakdown Breakdown, fields []string, includeTotal bool) string {
-	t := table.NewWriter()
-	t.Style().Options.DrawBorder = false
-	t.Style().Options.SeparateColumns = false
-	t.Style().Options.SeparateRows = false
-	t.Style().Options.SeparateHeader = false
-	t.Style().Format.Header = text.FormatDefault
+	t := newSummaryTable()
 
 	var columns []table.ColumnConfig
 	var headers table.Row
 	headers = append(headers,
-		ui.UnderlineString("Name"),
+		ui.UnderlineString(i18n.T("Name")),
 	)
 
 	i := 1
@@ -112,7 +190,7 @@ func tableForBreakdown(breakdown Breakdown, fields []string, includeTotal bool)
 	i++
 
 	if contains(fields, "price") {
-		headers = append(headers, ui.UnderlineString("Price"))
+		headers = append(headers, ui.UnderlineString(i18n.T("Price")))
 		columns = append(columns, table.ColumnConfig{
 			Number:      i,
 			Align:       text.AlignRight,
@@ -121,7 +199,7 @@ func tableForBreakdown(breakdown Breakdown, fields []string, includeTotal bool)
 		i++
 	}
 	if contains(fields, "monthlyQuantity") {
-		headers = append(headers, ui.UnderlineString("Monthly Qty"))
+		headers = append(headers, ui.UnderlineString(i18n.T("Monthly Qty")))
 		columns = append(columns, table.ColumnConfig{
 			Number:      i,
 			Align:       text.AlignRight,
@@ -130,7 +208,7 @@ func tableForBreakdown(breakdown Breakdown, fields []string, includeTotal bool)
 		i++
 	}
 	if contains(fields, "unit") {
-		headers = append(headers, ui.UnderlineString("Unit"))
+		headers = append(headers, ui.UnderlineString(i18n.T("Unit")))
 		columns = append(columns, table.ColumnConfig{
 			Number:      i,
 			Align:       text.AlignLeft,
@@ -139,7 +217,7 @@ func tableForBreakdown(breakdown Breakdown, fields []string, includeTotal bool)
 		i++
 	}
 	if contains(fields, "hourlyCost") {
-		headers = append(headers, ui.UnderlineString("Hourly Cost"))
+		headers = append(headers, ui.UnderlineString(i18n.T("Hourly Cost")))
 		columns = append(columns, table.ColumnConfig{
 			Number:      i,
 			Align:       text.AlignRight,
@@ -148,7 +226,7 @@ func tableForBreakdown(breakdown Breakdown, fields []string, includeTotal bool)
 		i++
 	}
 	if contains(fields, "monthlyCost") {
-		headers = append(headers, ui.UnderlineString("Monthly Cost"))
+		headers = append(headers, ui.UnderlineString(i18n.T("Monthly Cost")))
 		columns = append(columns, table.ColumnConfig{
 			Number:      i,
 			Align:       text.AlignRight,
@@ -173,7 +251,7 @@ func tableForBreakdown(breakdown Breakdown, fields []string, includeTotal bool)
 
 	if includeTotal {
 		var totalCostRow table.Row
-		totalCostRow = append(totalCostRow, ui.BoldString("Project total"))
+		totalCostRow = append(totalCostRow, ui.BoldString(i18n.T("Project total")))
 		numOfFields := i - 3
 		for q := 0; q < numOfFields; q++ {
 			totalCostRow = append(totalCostRow, "")
@@ -211,10 +289,13 @@ func buildCostComponentRows(t table.Writer, costComponents []CostComponent, pref
 		label := fmt.Sprintf("%s %s", ui.FaintString(labelPrefix), c.Name)
 
 		if c.MonthlyCost == nil {
-			price := fmt.Sprintf("Monthly cost depends on usage: %s per %s",
+			price := i18n.Tf("Monthly cost depends on usage: %s per %s",
 				formatPrice(c.Price),
 				c.Unit,
 			)
+			if c.Unpriced {
+				price = i18n.T("Not priced, the deadline was reached")
+			}
 
 			t.AppendRow(table.Row{
 				label,
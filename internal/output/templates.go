@@ -114,6 +114,20 @@ table.overall-total {
   margin-top: 1rem;
 }
 
+table.recommendations {
+  margin-top: 1.5rem;
+  min-width: 946px;
+}
+
+table.recommendations th {
+  background-color: #6b7280;
+  color: #ffffff;
+}
+
+table.recommendations th.monthly-cost {
+  text-align: right;
+}
+
 {{end}}
 
 {{define "faviconBase64"}}
@@ -194,6 +208,8 @@ iVBORw0KGgoAAAANSUhEUgAAAMAAAADACAMAAABlApw1AAAABGdBTUEAALGPC/xhBQAAAAFzUkdCAK7O
       {{if contains .Fields "monthlyCost"}}
         <td class="monthly-cost">{{.CostComponent.MonthlyCost | formatCost2DP}}</td>
       {{end}}
+    {{else if .CostComponent.Unpriced}}
+      <td colspan="{{len .Fields}}" class="usage-cost">Not priced, the deadline was reached</td>
     {{else}}
       <td colspan="{{len .Fields}}" class="usage-cost">Cost depends on usage: {{.CostComponent.Price | formatPrice}} per {{.CostComponent.Unit}}</td>
     {{end}}
@@ -278,7 +294,31 @@ iVBORw0KGgoAAAANSUhEUgAAAMAAAADACAMAAABlApw1AAAABGdBTUEAALGPC/xhBQAAAAFzUkdCAK7O
       </tbody>
     </table>
 
+    {{if .Root.Recommendations}}
+      <table class="recommendations">
+        <thead>
+          <tr>
+            <th>Resource</th>
+            <th>Recommendation</th>
+            <th class="monthly-cost">Monthly savings</th>
+          </tr>
+        </thead>
+        <tbody>
+          {{range .Root.Recommendations}}
+            <tr>
+              <td class="name">{{.ResourceName}}</td>
+              <td>{{.Description}}</td>
+              <td class="monthly-cost">{{.MonthlySavings | formatCost2DP}}</td>
+            </tr>
+          {{end}}
+        </tbody>
+      </table>
+    {{end}}
+
     <div class="warnings">
+      {{if .CompletenessMessage}}
+        <p>{{.CompletenessMessage}}</p>
+      {{end}}
       <p>{{.UnsupportedResourcesMessage | replaceNewLines}}</p>
     </div>
   </body>
@@ -0,0 +1,98 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/shopspring/decimal"
+)
+
+// DiffThreshold is the minimum change in the total monthly cost for a diff
+// to be shown. If both the amount and percent are set the change must be
+// below both of them for the diff to be suppressed.
+type DiffThreshold struct {
+	Amount  *decimal.Decimal
+	Percent *decimal.Decimal
+}
+
+// totalCostChange returns the total past and current monthly costs of all the projects.
+func totalCostChange(out Root) (decimal.Decimal, decimal.Decimal) {
+	oldCost := decimal.Zero
+	newCost := decimal.Zero
+
+	for _, p := range out.Projects {
+		if p.PastBreakdown != nil && p.PastBreakdown.TotalMonthlyCost != nil {
+			oldCost = oldCost.Add(*p.PastBreakdown.TotalMonthlyCost)
+		}
+		if p.Breakdown != nil && p.Breakdown.TotalMonthlyCost != nil {
+			newCost = newCost.Add(*p.Breakdown.TotalMonthlyCost)
+		}
+	}
+
+	return oldCost, newCost
+}
+
+// TotalMonthlyCostChange returns the change in the total monthly cost of all
+// the projects.
+func TotalMonthlyCostChange(out Root) decimal.Decimal {
+	oldCost, newCost := totalCostChange(out)
+	return newCost.Sub(oldCost)
+}
+
+// IsSignificant checks if the total monthly cost change is at or above the threshold.
+func (t *DiffThreshold) IsSignificant(out Root) bool {
+	if t == nil || (t.Amount == nil && t.Percent == nil) {
+		return true
+	}
+
+	oldCost, newCost := totalCostChange(out)
+	change := newCost.Sub(oldCost).Abs()
+
+	if t.Amount != nil && change.GreaterThanOrEqual(*t.Amount) {
+		return true
+	}
+
+	if t.Percent != nil {
+		if oldCost.IsZero() {
+			return !change.IsZero()
+		}
+
+		p := change.Div(oldCost.Abs()).Mul(decimal.NewFromInt(100))
+		if p.GreaterThanOrEqual(*t.Percent) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *DiffThreshold) String() string {
+	parts := make([]string, 0, 2)
+	if t.Amount != nil {
+		parts = append(parts, formatCost(t.Amount))
+	}
+	if t.Percent != nil {
+		parts = append(parts, fmt.Sprintf("%s%%", t.Percent.String()))
+	}
+	return strings.Join(parts, " and ")
+}
+
+func insignificantDiffMessage(out Root, threshold *DiffThreshold) string {
+	oldCost, newCost := totalCostChange(out)
+	change := newCost.Sub(oldCost)
+
+	s := fmt.Sprintf("%s Monthly cost change is %s",
+		ui.BoldString("No significant cost change."),
+		formatCostChange(&change),
+	)
+
+	percent := formatPercentChange(&oldCost, &newCost)
+	if percent != "" {
+		s += fmt.Sprintf(" (%s)", percent)
+	}
+
+	s += fmt.Sprintf(", which is below the threshold of %s.", threshold.String())
+
+	return s
+}
@@ -0,0 +1,36 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/shopspring/decimal"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestDiffThresholdIsSignificant(t *testing.T) {
+	out := Root{
+		Projects: []Project{
+			{
+				PastBreakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(1000))},
+				Breakdown:     &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(1020))},
+			},
+		},
+	}
+
+	var nilThreshold *DiffThreshold
+	assert.Equal(t, true, nilThreshold.IsSignificant(out))
+
+	assert.Equal(t, false, (&DiffThreshold{Amount: decimalPtr(decimal.NewFromInt(50))}).IsSignificant(out))
+	assert.Equal(t, true, (&DiffThreshold{Amount: decimalPtr(decimal.NewFromInt(20))}).IsSignificant(out))
+	assert.Equal(t, false, (&DiffThreshold{Percent: decimalPtr(decimal.NewFromInt(5))}).IsSignificant(out))
+	assert.Equal(t, true, (&DiffThreshold{Percent: decimalPtr(decimal.NewFromInt(1))}).IsSignificant(out))
+	assert.Equal(t, true, (&DiffThreshold{
+		Amount:  decimalPtr(decimal.NewFromInt(50)),
+		Percent: decimalPtr(decimal.NewFromInt(1)),
+	}).IsSignificant(out))
+
+	b, err := ToDiff(out, Options{NoColor: true, DiffThreshold: &DiffThreshold{Amount: decimalPtr(decimal.NewFromInt(50))}})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "No significant cost change. Monthly cost change is +$20.00 (+2%), which is below the threshold of $50.00.", ui.StripColor(string(b)))
+}
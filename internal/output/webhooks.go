@@ -0,0 +1,96 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/events"
+	"github.com/infracost/infracost/internal/httpretry"
+)
+
+// DeliverWebhooks POSTs the Infracost JSON of the run to each of the
+// webhooks. Failed requests are retried with the same policy as the pricing
+// API requests. Deliveries that fail after all the retries are warned about
+// rather than failing the run, since the estimate itself has succeeded.
+func DeliverWebhooks(webhooks []*config.Webhook, out Root, opts Options) error {
+	b, err := ToJSON(out, opts)
+	if err != nil {
+		return err
+	}
+
+	deliveryID := newDeliveryID()
+
+	for _, w := range webhooks {
+		err := deliverWebhook(w, deliveryID, b)
+		if err != nil {
+			log.Warnf("Unable to deliver Infracost JSON to webhook %s: %v", redactDestination(w.URL), err)
+		}
+	}
+
+	return nil
+}
+
+// deliverWebhook sends the body to the webhook until it's accepted or the
+// retries have run out. The delivery ID is the same for every attempt so the
+// endpoint can ignore duplicates.
+func deliverWebhook(w *config.Webhook, deliveryID string, body []byte) error {
+	p := httpretry.DefaultPolicy()
+	p.Timeout = destinationTimeout
+	if w.Retries != nil && *w.Retries >= 0 {
+		p.Retries = *w.Retries
+	}
+
+	resp, _, attempts, err := httpretry.Send(context.Background(), p, func() (*http.Request, error) {
+		return newWebhookRequest(w, deliveryID, body)
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %d after %d attempts", resp.StatusCode, attempts)
+	}
+
+	log.Infof("Delivered Infracost JSON to webhook %s (delivery %s, attempt %d, status %d)", redactDestination(w.URL), deliveryID, attempts, resp.StatusCode)
+
+	return nil
+}
+
+// newWebhookRequest creates the request of a delivery. The delivery ID and
+// signature are set after the webhook's headers so they can't be overwritten.
+func newWebhookRequest(w *config.Webhook, deliveryID string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequest("POST", w.URL, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range w.Headers {
+		req.Header.Set(k, v)
+	}
+
+	req.Header.Set("X-Infracost-Delivery", deliveryID)
+	req.Header.Del(events.SignatureHeader)
+	if w.Secret != "" {
+		req.Header.Set(events.SignatureHeader, events.Signature(w.Secret, body))
+	}
+
+	return req, nil
+}
+
+func newDeliveryID() string {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,78 @@
+package output
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/events"
+	"github.com/infracost/infracost/internal/httpretry"
+	"github.com/shopspring/decimal"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestDeliverWebhooks(t *testing.T) {
+	var waits []time.Duration
+	origSleep := httpretry.Sleep
+	httpretry.Sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	defer func() { httpretry.Sleep = origSleep }()
+
+	var attempts, rejected int
+	var deliveryIDs []string
+	var body []byte
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ingest":
+			attempts++
+			deliveryIDs = append(deliveryIDs, r.Header.Get("X-Infracost-Delivery"))
+			if attempts == 1 {
+				w.Header().Set("Retry-After", "7")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			body, _ = ioutil.ReadAll(r.Body)
+			assert.Equal(t, "platform", r.Header.Get("X-Team"))
+
+			// The signature can't be overwritten by the webhook's headers
+			assert.Equal(t, events.Signature("secret", body), r.Header.Get(events.SignatureHeader))
+		default:
+			rejected++
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer ts.Close()
+
+	out := Root{
+		Version:          outputVersion,
+		Projects:         []Project{{Name: "my-project", Breakdown: &Breakdown{}}},
+		TotalMonthlyCost: decimalPtr(decimal.NewFromInt(42)),
+		Summary:          &Summary{},
+	}
+
+	retries := 5
+	err := DeliverWebhooks([]*config.Webhook{
+		{URL: ts.URL + "/ingest", Secret: "secret", Headers: map[string]string{"X-Team": "platform", "X-Infracost-Signature": "forged"}},
+		{URL: ts.URL + "/invalid", Retries: &retries},
+	}, out, Options{})
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, deliveryIDs[0], deliveryIDs[1])
+	assert.Equal(t, 1, rejected)
+	assert.Equal(t, []time.Duration{7 * time.Second}, waits)
+
+	var j Root
+	err = json.Unmarshal(body, &j)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "42", j.TotalMonthlyCost.String())
+}
@@ -0,0 +1,269 @@
+// Package plugins runs the external plugins that price resources that aren't
+// supported, e.g. the resources of niche Terraform providers. A plugin is an
+// executable in the plugins dir whose name starts with infracost-plugin-. It's
+// run with the describe argument to list the resource types it prices, then
+// with the resource argument for each resource, which gets the resource's
+// values and usage as JSON on stdin and writes its cost components as JSON to
+// stdout.
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+)
+
+// ProtocolVersion is the version of the JSON that's sent to and received from
+// plugins. Plugins with a different version are skipped.
+const ProtocolVersion = 1
+
+const executablePrefix = "infracost-plugin-"
+
+// timeout is how long a plugin can take to describe itself or to price a
+// resource.
+var timeout = 30 * time.Second
+
+// Description is the output of the describe command.
+type Description struct {
+	ProtocolVersion int      `json:"protocolVersion"`
+	Name            string   `json:"name"`
+	ResourceTypes   []string `json:"resourceTypes"`
+}
+
+// ResourceRequest is the input of the resource command.
+type ResourceRequest struct {
+	ProtocolVersion int                    `json:"protocolVersion"`
+	Type            string                 `json:"type"`
+	Address         string                 `json:"address"`
+	Values          json.RawMessage        `json:"values"`
+	Usage           map[string]interface{} `json:"usage,omitempty"`
+}
+
+// Resource is the output of the resource command. The name is only used for
+// sub-resources.
+type Resource struct {
+	Name           string          `json:"name,omitempty"`
+	NoPrice        bool            `json:"noPrice,omitempty"`
+	CostComponents []CostComponent `json:"costComponents"`
+	SubResources   []Resource      `json:"subresources,omitempty"`
+}
+
+// CostComponent has either a fixed price or the product and price filters
+// that its price is looked up with in the Cloud Pricing API.
+type CostComponent struct {
+	Name            string                `json:"name"`
+	Unit            string                `json:"unit"`
+	UnitMultiplier  int                   `json:"unitMultiplier,omitempty"`
+	HourlyQuantity  *decimal.Decimal      `json:"hourlyQuantity,omitempty"`
+	MonthlyQuantity *decimal.Decimal      `json:"monthlyQuantity,omitempty"`
+	Price           *decimal.Decimal      `json:"price,omitempty"`
+	ProductFilter   *schema.ProductFilter `json:"productFilter,omitempty"`
+	PriceFilter     *schema.PriceFilter   `json:"priceFilter,omitempty"`
+}
+
+// Plugin is a plugin executable and the resource types that it prices.
+type Plugin struct {
+	Path        string
+	Description Description
+}
+
+// Discover describes each plugin in the dir. Plugins that can't be described
+// or that have an unsupported protocol version are skipped with a warning so
+// a broken plugin doesn't stop the run. A missing dir has no plugins.
+func Discover(dir string) ([]*Plugin, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading plugins dir")
+	}
+
+	plugins := make([]*Plugin, 0)
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), executablePrefix) {
+			continue
+		}
+
+		if runtime.GOOS != "windows" && entry.Mode()&0111 == 0 {
+			log.Debugf("Skipping plugin %s since it isn't executable", entry.Name())
+			continue
+		}
+
+		p := &Plugin{Path: filepath.Join(dir, entry.Name())}
+
+		out, err := p.run("describe", nil)
+		if err != nil {
+			log.Warnf("Skipping plugin %s. %s", entry.Name(), err)
+			continue
+		}
+
+		err = json.Unmarshal(out, &p.Description)
+		if err != nil {
+			log.Warnf("Skipping plugin %s. Invalid description: %s", entry.Name(), err)
+			continue
+		}
+
+		if p.Description.ProtocolVersion != ProtocolVersion {
+			log.Warnf("Skipping plugin %s. Protocol version %d isn't supported, it must be %d", entry.Name(), p.Description.ProtocolVersion, ProtocolVersion)
+			continue
+		}
+
+		if p.Description.Name == "" {
+			p.Description.Name = strings.TrimPrefix(entry.Name(), executablePrefix)
+		}
+
+		log.Debugf("Found plugin %s for %s", p.Description.Name, strings.Join(p.Description.ResourceTypes, ", "))
+
+		plugins = append(plugins, p)
+	}
+
+	return plugins, nil
+}
+
+// RegistryItems returns a registry item for each resource type that the
+// plugin prices.
+func (p *Plugin) RegistryItems() []*schema.RegistryItem {
+	items := make([]*schema.RegistryItem, 0, len(p.Description.ResourceTypes))
+
+	for _, resourceType := range p.Description.ResourceTypes {
+		items = append(items, &schema.RegistryItem{
+			Name:  resourceType,
+			Notes: []string{fmt.Sprintf("Priced by the %s plugin.", p.Description.Name)},
+			RFunc: p.newResource,
+		})
+	}
+
+	return items
+}
+
+func (p *Plugin) newResource(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	req := ResourceRequest{
+		ProtocolVersion: ProtocolVersion,
+		Type:            d.Type,
+		Address:         d.Address,
+		Values:          json.RawMessage(d.RawValues.Raw),
+	}
+
+	if req.Values == nil {
+		req.Values = json.RawMessage("{}")
+	}
+
+	if u != nil {
+		req.Usage = make(map[string]interface{}, len(u.Attributes))
+		for k, v := range u.Attributes {
+			req.Usage[k] = v.Value()
+		}
+	}
+
+	in, err := json.Marshal(req)
+	if err != nil {
+		log.Warnf("Skipping resource %s. %s", d.Address, err)
+		return nil
+	}
+
+	out, err := p.run("resource", in)
+	if err != nil {
+		log.Warnf("Skipping resource %s. The %s plugin failed: %s", d.Address, p.Description.Name, err)
+		return nil
+	}
+
+	var res Resource
+	err = json.Unmarshal(out, &res)
+	if err != nil {
+		log.Warnf("Skipping resource %s. The %s plugin returned invalid JSON: %s", d.Address, p.Description.Name, err)
+		return nil
+	}
+
+	r := res.toSchema()
+	r.Name = d.Address
+
+	return r
+}
+
+func (p *Plugin) run(command string, stdin []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, p.Path, command)
+	cmd.Stdin = bytes.NewReader(stdin)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("%s timed out after %s", command, timeout)
+	}
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg != "" {
+			return nil, fmt.Errorf("%s failed: %s", command, msg)
+		}
+		return nil, fmt.Errorf("%s failed: %s", command, err)
+	}
+
+	return out, nil
+}
+
+func (r Resource) toSchema() *schema.Resource {
+	res := &schema.Resource{
+		Name:           r.Name,
+		NoPrice:        r.NoPrice,
+		IsSkipped:      r.NoPrice,
+		CostComponents: make([]*schema.CostComponent, 0, len(r.CostComponents)),
+	}
+
+	for _, c := range r.CostComponents {
+		res.CostComponents = append(res.CostComponents, c.toSchema())
+	}
+
+	for _, s := range r.SubResources {
+		res.SubResources = append(res.SubResources, s.toSchema())
+	}
+
+	return res
+}
+
+func (c CostComponent) toSchema() *schema.CostComponent {
+	unitMultiplier := c.UnitMultiplier
+	if unitMultiplier == 0 {
+		unitMultiplier = 1
+	}
+
+	cc := &schema.CostComponent{
+		Name:            c.Name,
+		Unit:            c.Unit,
+		UnitMultiplier:  unitMultiplier,
+		HourlyQuantity:  c.HourlyQuantity,
+		MonthlyQuantity: c.MonthlyQuantity,
+		ProductFilter:   c.ProductFilter,
+		PriceFilter:     c.PriceFilter,
+	}
+
+	// Cost components without a product filter have a fixed price so they
+	// aren't looked up in the pricing API
+	if c.ProductFilter == nil {
+		price := decimal.Zero
+		if c.Price != nil {
+			price = *c.Price
+		}
+		cc.SetPrice(price)
+	}
+
+	return cc
+}
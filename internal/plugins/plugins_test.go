@@ -0,0 +1,133 @@
+package plugins
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// writePlugin writes a shell script plugin that prints the description, or
+// the response after echoing its input to the file in the first line of the
+// response.
+func writePlugin(t *testing.T, dir, name, script string) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin scripts need a shell")
+	}
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script), 0700)) // nolint:gosec
+}
+
+func TestDiscover(t *testing.T) {
+	dir := t.TempDir()
+
+	writePlugin(t, dir, "infracost-plugin-vmware", `
+if [ "$1" = "describe" ]; then
+  echo '{"protocolVersion": 1, "name": "vmware", "resourceTypes": ["vsphere_virtual_machine"]}'
+fi
+`)
+	writePlugin(t, dir, "infracost-plugin-old", `
+echo '{"protocolVersion": 0, "resourceTypes": ["old_resource"]}'
+`)
+	writePlugin(t, dir, "infracost-plugin-broken", `
+echo 'Error' >&2
+exit 1
+`)
+	writePlugin(t, dir, "other-executable", `
+echo '{"protocolVersion": 1, "resourceTypes": ["other_resource"]}'
+`)
+
+	plugins, err := Discover(dir)
+	require.NoError(t, err)
+	require.Len(t, plugins, 1)
+	assert.Equal(t, "vmware", plugins[0].Description.Name)
+
+	items := plugins[0].RegistryItems()
+	require.Len(t, items, 1)
+	assert.Equal(t, "vsphere_virtual_machine", items[0].Name)
+	assert.Equal(t, []string{"Priced by the vmware plugin."}, items[0].Notes)
+
+	plugins, err = Discover(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, plugins)
+}
+
+func TestPluginResource(t *testing.T) {
+	dir := t.TempDir()
+	inputFile := filepath.Join(dir, "input.json")
+
+	writePlugin(t, dir, "infracost-plugin-vmware", `
+if [ "$1" = "describe" ]; then
+  echo '{"protocolVersion": 1, "name": "vmware", "resourceTypes": ["vsphere_virtual_machine"]}'
+  exit 0
+fi
+cat > `+inputFile+`
+echo '{
+  "costComponents": [
+    {"name": "vCPU", "unit": "vCPU", "monthlyQuantity": "4", "price": "12"},
+    {"name": "Instance usage", "unit": "hours", "hourlyQuantity": "1", "productFilter": {"vendorName": "aws", "service": "AmazonEC2"}}
+  ],
+  "subresources": [
+    {"name": "Storage", "costComponents": [{"name": "Disk", "unit": "GB", "monthlyQuantity": "100", "price": "0.1"}]}
+  ]
+}'
+`)
+
+	plugins, err := Discover(dir)
+	require.NoError(t, err)
+	require.Len(t, plugins, 1)
+
+	d := schema.NewResourceData("vsphere_virtual_machine", "registry.terraform.io/hashicorp/vsphere", "vsphere_virtual_machine.vm", nil, gjson.Parse(`{"num_cpus": 4}`))
+	u := schema.NewUsageData("vsphere_virtual_machine.vm", map[string]gjson.Result{"monthly_hrs": gjson.Parse("100")})
+
+	r := plugins[0].RegistryItems()[0].RFunc(d, u)
+	require.NotNil(t, r)
+	assert.Equal(t, "vsphere_virtual_machine.vm", r.Name)
+
+	require.Len(t, r.CostComponents, 2)
+	assert.Equal(t, "12", r.CostComponents[0].Price().String())
+	assert.Nil(t, r.CostComponents[0].ProductFilter)
+	assert.Equal(t, "AmazonEC2", *r.CostComponents[1].ProductFilter.Service)
+	assert.Equal(t, "1", r.CostComponents[1].HourlyQuantity.String())
+
+	require.Len(t, r.SubResources, 1)
+	assert.Equal(t, "Storage", r.SubResources[0].Name)
+
+	input, err := ioutil.ReadFile(inputFile)
+	require.NoError(t, err)
+	assert.Equal(t, "vsphere_virtual_machine.vm", gjson.GetBytes(input, "address").String())
+	assert.Equal(t, int64(4), gjson.GetBytes(input, "values.num_cpus").Int())
+	assert.Equal(t, int64(100), gjson.GetBytes(input, "usage.monthly_hrs").Int())
+	assert.Equal(t, int64(ProtocolVersion), gjson.GetBytes(input, "protocolVersion").Int())
+}
+
+func TestPluginResourceFailure(t *testing.T) {
+	dir := t.TempDir()
+
+	writePlugin(t, dir, "infracost-plugin-slow", `
+if [ "$1" = "describe" ]; then
+  echo '{"protocolVersion": 1, "resourceTypes": ["slow_resource"]}'
+  exit 0
+fi
+sleep 1
+`)
+
+	plugins, err := Discover(dir)
+	require.NoError(t, err)
+	require.Len(t, plugins, 1)
+	assert.Equal(t, "slow", plugins[0].Description.Name)
+
+	defaultTimeout := timeout
+	timeout = 100 * time.Millisecond
+	defer func() { timeout = defaultTimeout }()
+
+	d := schema.NewResourceData("slow_resource", "", "slow_resource.r", nil, gjson.Parse(`{}`))
+	assert.Nil(t, plugins[0].RegistryItems()[0].RFunc(d, nil))
+}
@@ -0,0 +1,74 @@
+package prices
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/infracost/infracost/internal/config"
+)
+
+const defaultPricingBackend = "graphql"
+
+// BackendFactory creates the QueryRunner used to get prices from a pricing backend.
+type BackendFactory func(cfg *config.Config) (QueryRunner, error)
+
+var backends = map[string]BackendFactory{
+	"graphql": func(cfg *config.Config) (QueryRunner, error) {
+		return NewGraphQLQueryRunner(fmt.Sprintf("%s/graphql", cfg.PricingAPIEndpoint), cfg.APIKey, RetryPolicyFromConfig(cfg)), nil
+	},
+	"rest": func(cfg *config.Config) (QueryRunner, error) {
+		return NewRESTQueryRunner(cfg.PricingAPIEndpoint, cfg.APIKey, RetryPolicyFromConfig(cfg)), nil
+	},
+	"pricebook": func(cfg *config.Config) (QueryRunner, error) {
+		return NewPriceBookQueryRunnerFromFile(cfg.PriceBookFile)
+	},
+	"offline": func(cfg *config.Config) (QueryRunner, error) {
+		return NewOfflineQueryRunner(cfg)
+	},
+	"fixtures": func(cfg *config.Config) (QueryRunner, error) {
+		return NewFixtureQueryRunner(cfg.PricingFixturesDir)
+	},
+}
+
+// RegisterBackend adds a pricing backend that can be selected using the
+// pricing_backend config or INFRACOST_PRICING_BACKEND environment variable.
+func RegisterBackend(name string, factory BackendFactory) {
+	backends[name] = factory
+}
+
+func backendNames() []string {
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewQueryRunner creates the QueryRunner for the configured pricing backend.
+func NewQueryRunner(cfg *config.Config) (QueryRunner, error) {
+	name := cfg.PricingBackend
+	if cfg.Offline {
+		name = "offline"
+	}
+	if name == "" {
+		name = defaultPricingBackend
+	}
+
+	factory, ok := backends[name]
+	if !ok {
+		return nil, fmt.Errorf("Invalid pricing backend '%s', valid backends are: %s", name, strings.Join(backendNames(), ", "))
+	}
+
+	q, err := factory(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.RecordFixturesDir != "" {
+		q = NewFixtureRecorder(q, cfg.RecordFixturesDir)
+	}
+
+	return q, nil
+}
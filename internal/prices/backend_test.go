@@ -0,0 +1,91 @@
+package prices
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/httpretry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewQueryRunner(t *testing.T) {
+	cfg := config.DefaultConfig()
+
+	q, err := NewQueryRunner(cfg)
+	require.NoError(t, err)
+	assert.IsType(t, &GraphQLQueryRunner{}, q)
+
+	cfg.PricingBackend = "rest"
+	q, err = NewQueryRunner(cfg)
+	require.NoError(t, err)
+	assert.IsType(t, &RESTQueryRunner{}, q)
+
+	cfg.PricingBackend = "pricebook"
+	_, err = NewQueryRunner(cfg)
+	assert.Error(t, err)
+
+	cfg.PricingBackend = "invalid"
+	_, err = NewQueryRunner(cfg)
+	assert.EqualError(t, err, "Invalid pricing backend 'invalid', valid backends are: fixtures, graphql, offline, pricebook, rest")
+}
+
+func TestPriceBookQueryRunner(t *testing.T) {
+	priceBook := &PriceBook{
+		Products: []*PriceBookProduct{
+			{
+				VendorName:    "aws",
+				Service:       "AmazonEC2",
+				ProductFamily: "Compute Instance",
+				Region:        "us-east-1",
+				SKU:           "sku-t3-micro",
+				ProductHash:   "product-t3-micro",
+				Attributes:    map[string]string{"instanceType": "t3.micro", "operatingSystem": "Linux"},
+				Prices: []*PriceBookPrice{
+					{PriceHash: "reserved", USD: "0.006", PurchaseOption: "reserved"},
+					{PriceHash: "on-demand", USD: "0.0104", PurchaseOption: "on_demand"},
+				},
+			},
+			{
+				VendorName:    "aws",
+				Service:       "AmazonEC2",
+				ProductFamily: "Compute Instance",
+				Region:        "us-east-1",
+				Attributes:    map[string]string{"instanceType": "t3.small", "operatingSystem": "Linux"},
+			},
+		},
+	}
+
+	r, c := testResource()
+	err := GetPrices(context.Background(), r, NewPriceBookQueryRunner(priceBook))
+	require.NoError(t, err)
+
+	assert.Equal(t, "0.0104", c.Price().String())
+	assert.Equal(t, "on-demand", c.PriceHash())
+	assert.Equal(t, "sku-t3-micro", c.ProductSKU())
+}
+
+func TestRESTQueryRunner(t *testing.T) {
+	var queries []RESTQuery
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := json.NewDecoder(r.Body).Decode(&queries)
+		require.NoError(t, err)
+
+		_, _ = w.Write([]byte(`[{"products": [{"productHash": "product-t3-micro", "sku": "sku-t3-micro", "prices": [{"priceHash": "on-demand", "USD": "0.0104"}]}]}]`))
+	}))
+	defer ts.Close()
+
+	r, c := testResource()
+	err := GetPrices(context.Background(), r, NewRESTQueryRunner(ts.URL, "", httpretry.DefaultPolicy()))
+	require.NoError(t, err)
+
+	assert.Len(t, queries, 1)
+	assert.Equal(t, "t3.micro", *queries[0].ProductFilter.AttributeFilters[0].Value)
+	assert.Equal(t, "0.0104", c.Price().String())
+	assert.Equal(t, "on-demand", c.PriceHash())
+}
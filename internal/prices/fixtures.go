@@ -0,0 +1,201 @@
+package prices
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+)
+
+// Fixture is a recorded pricing query and its result. Only the filters and
+// the result are recorded, so fixtures don't contain resource names or API
+// keys and can be committed with tests or shared when reporting issues.
+type Fixture struct {
+	ProductFilter *schema.ProductFilter `json:"productFilter"`
+	PriceFilter   *schema.PriceFilter   `json:"priceFilter"`
+	Result        json.RawMessage       `json:"result"`
+}
+
+func fixtureKey(product *schema.ProductFilter, price *schema.PriceFilter) (string, error) {
+	b, err := json.Marshal(struct {
+		ProductFilter *schema.ProductFilter `json:"productFilter"`
+		PriceFilter   *schema.PriceFilter   `json:"priceFilter"`
+	}{product, price})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// fixturePath groups the fixtures by vendor and service so they are easier
+// to review, e.g. aws/AmazonEC2/<key>.json.
+func fixturePath(dir string, product *schema.ProductFilter, key string) string {
+	vendor, service := "unknown", "unknown"
+	if product != nil && product.VendorName != nil {
+		vendor = *product.VendorName
+	}
+	if product != nil && product.Service != nil {
+		service = *product.Service
+	}
+
+	return filepath.Join(dir, vendor, service, key+".json")
+}
+
+// FixtureRecorder runs the queries with another QueryRunner and records each
+// query and its result to a fixture file in the directory.
+type FixtureRecorder struct {
+	runner QueryRunner
+	dir    string
+}
+
+func NewFixtureRecorder(runner QueryRunner, dir string) *FixtureRecorder {
+	return &FixtureRecorder{
+		runner: runner,
+		dir:    dir,
+	}
+}
+
+func (f *FixtureRecorder) setSession(s *Session) {
+	if r, ok := f.runner.(sessionQueryRunner); ok {
+		r.setSession(s)
+	}
+}
+
+func (f *FixtureRecorder) RunQueries(ctx context.Context, r *schema.Resource) ([]QueryResult, error) {
+	results, err := f.runner.RunQueries(ctx, r)
+	if err != nil {
+		return results, err
+	}
+
+	for _, res := range results {
+		err := f.record(res)
+		if err != nil {
+			return results, err
+		}
+	}
+
+	return results, nil
+}
+
+func (f *FixtureRecorder) record(res QueryResult) error {
+	c := res.CostComponent
+
+	key, err := fixtureKey(c.ProductFilter, c.PriceFilter)
+	if err != nil {
+		return errors.Wrap(err, "Error generating pricing fixture")
+	}
+
+	b, err := json.MarshalIndent(Fixture{
+		ProductFilter: c.ProductFilter,
+		PriceFilter:   c.PriceFilter,
+		Result:        json.RawMessage(res.Result.Raw),
+	}, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "Error generating pricing fixture")
+	}
+
+	path := fixturePath(f.dir, c.ProductFilter, key)
+
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return errors.Wrap(err, "Error creating pricing fixtures directory")
+	}
+
+	err = ioutil.WriteFile(path, append(b, '\n'), 0600)
+	if err != nil {
+		return errors.Wrap(err, "Error writing pricing fixture")
+	}
+
+	log.Debugf("Recorded pricing fixture %s for %s %s", path, res.Resource.Name, c.Name)
+
+	return nil
+}
+
+// FixtureQueryRunner replays the results of the fixtures recorded with a
+// FixtureRecorder, so prices can be looked up without calling a pricing API.
+type FixtureQueryRunner struct {
+	dir     string
+	results map[string]gjson.Result
+}
+
+func NewFixtureQueryRunner(dir string) (*FixtureQueryRunner, error) {
+	if dir == "" {
+		return nil, errors.New("No pricing fixtures directory specified")
+	}
+
+	results := make(map[string]gjson.Result)
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		var f Fixture
+		err = json.Unmarshal(b, &f)
+		if err != nil {
+			return errors.Wrapf(err, "Error parsing pricing fixture %s", path)
+		}
+
+		key, err := fixtureKey(f.ProductFilter, f.PriceFilter)
+		if err != nil {
+			return err
+		}
+
+		results[key] = gjson.ParseBytes(f.Result)
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading pricing fixtures")
+	}
+
+	return &FixtureQueryRunner{
+		dir:     dir,
+		results: results,
+	}, nil
+}
+
+func (q *FixtureQueryRunner) RunQueries(_ context.Context, r *schema.Resource) ([]QueryResult, error) {
+	keys := resourceQueryKeys(r)
+	results := make([]QueryResult, 0, len(keys))
+
+	for _, k := range keys {
+		key, err := fixtureKey(k.CostComponent.ProductFilter, k.CostComponent.PriceFilter)
+		if err != nil {
+			return results, err
+		}
+
+		res, ok := q.results[key]
+		if !ok {
+			return results, fmt.Errorf("No pricing fixture found in %s for %s %s. Record the fixtures with --record-fixtures", q.dir, k.Resource.Name, k.CostComponent.Name)
+		}
+
+		results = append(results, QueryResult{
+			queryKey: k,
+			Result:   res,
+		})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,75 @@
+package prices
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndReplayFixtures(t *testing.T) {
+	dir := t.TempDir()
+
+	priceBook := &PriceBook{
+		Products: []*PriceBookProduct{
+			{
+				VendorName:  "aws",
+				Service:     "AmazonEC2",
+				Region:      "us-east-1",
+				ProductHash: "product1",
+				Attributes:  map[string]string{"instanceType": "t3.micro"},
+				Prices:      []*PriceBookPrice{{PriceHash: "price1", USD: "0.0104", Unit: "Hrs"}},
+			},
+		},
+	}
+
+	newResource := func() *schema.Resource {
+		return &schema.Resource{
+			Name: "aws_instance.secret_name",
+			CostComponents: []*schema.CostComponent{
+				{
+					Name: "Instance usage",
+					ProductFilter: &schema.ProductFilter{
+						VendorName: strPtr("aws"),
+						Service:    strPtr("AmazonEC2"),
+						Region:     strPtr("us-east-1"),
+						AttributeFilters: []*schema.AttributeFilter{
+							{Key: "instanceType", Value: strPtr("t3.micro")},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	recorder := NewFixtureRecorder(NewPriceBookQueryRunner(priceBook), dir)
+	r := newResource()
+	require.NoError(t, GetPrices(context.Background(), r, recorder))
+	assert.Equal(t, "0.0104", r.CostComponents[0].Price().String())
+
+	files, err := filepath.Glob(filepath.Join(dir, "aws", "AmazonEC2", "*.json"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	b, err := ioutil.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.False(t, strings.Contains(string(b), "secret_name"), "fixtures should not contain resource names")
+
+	replayer, err := NewFixtureQueryRunner(dir)
+	require.NoError(t, err)
+
+	r = newResource()
+	require.NoError(t, GetPrices(context.Background(), r, replayer))
+	assert.Equal(t, "0.0104", r.CostComponents[0].Price().String())
+	assert.Equal(t, "price1", r.CostComponents[0].PriceHash())
+
+	r = newResource()
+	r.CostComponents[0].ProductFilter.Region = strPtr("eu-west-1")
+	err = GetPrices(context.Background(), r, replayer)
+	assert.Error(t, err)
+}
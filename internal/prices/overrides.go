@@ -0,0 +1,173 @@
+package prices
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+const priceOverridesFileVersion = "0.1"
+
+type PriceOverridesFile struct {
+	Version   string           `yaml:"version"`
+	Overrides []*PriceOverride `yaml:"overrides"`
+}
+
+// PriceOverride replaces the price of any cost components matching all its
+// specified fields, e.g. to apply negotiated discounts. Either Discount, the
+// percentage to take off the price, or Price, a fixed price per unit, can be set.
+type PriceOverride struct {
+	Name          string   `yaml:"name"`
+	VendorName    string   `yaml:"vendor_name,omitempty"`
+	Service       string   `yaml:"service,omitempty"`
+	Region        string   `yaml:"region,omitempty"`
+	ProductFamily string   `yaml:"product_family,omitempty"`
+	UsageType     string   `yaml:"usage_type,omitempty"`
+	CostComponent string   `yaml:"cost_component,omitempty"`
+	Discount      *float64 `yaml:"discount,omitempty"`
+	Price         *float64 `yaml:"price,omitempty"`
+}
+
+func (o *PriceOverride) validate() error {
+	if o.Name == "" {
+		return errors.New("Price override is missing a name")
+	}
+
+	if (o.Discount == nil) == (o.Price == nil) {
+		return fmt.Errorf("Price override '%s' must have either a discount or a price", o.Name)
+	}
+
+	if o.Discount != nil && (*o.Discount < 0 || *o.Discount > 100) {
+		return fmt.Errorf("Price override '%s' has an invalid discount %v, it must be a percentage between 0 and 100", o.Name, *o.Discount)
+	}
+
+	if o.Price != nil && *o.Price < 0 {
+		return fmt.Errorf("Price override '%s' has an invalid price %v, it must be 0 or more", o.Name, *o.Price)
+	}
+
+	return nil
+}
+
+// matches checks if the cost component matches the override. The fields
+// are checked against the cost component's product filter, ignoring case.
+// The usage type matches if it is contained in the usagetype attribute filter.
+func (o *PriceOverride) matches(c *schema.CostComponent) bool {
+	if o.CostComponent != "" && !strings.EqualFold(o.CostComponent, c.Name) {
+		return false
+	}
+
+	f := c.ProductFilter
+	if f == nil {
+		return o.VendorName == "" && o.Service == "" && o.Region == "" && o.ProductFamily == "" && o.UsageType == ""
+	}
+
+	if !matchesFilterValue(o.VendorName, f.VendorName) ||
+		!matchesFilterValue(o.Service, f.Service) ||
+		!matchesFilterValue(o.Region, f.Region) ||
+		!matchesFilterValue(o.ProductFamily, f.ProductFamily) {
+		return false
+	}
+
+	if o.UsageType == "" {
+		return true
+	}
+
+	for _, a := range f.AttributeFilters {
+		if a.Key != "usagetype" {
+			continue
+		}
+
+		v := a.Value
+		if v == nil {
+			v = a.ValueRegex
+		}
+		if v != nil && strings.Contains(strings.ToLower(*v), strings.ToLower(o.UsageType)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (o *PriceOverride) apply(c *schema.CostComponent) {
+	if o.Price != nil {
+		c.SetPrice(decimal.NewFromFloat(*o.Price))
+	} else {
+		discountMul := decimal.NewFromInt(100).Sub(decimal.NewFromFloat(*o.Discount)).Div(decimal.NewFromInt(100))
+		c.SetPrice(c.Price().Mul(discountMul))
+	}
+
+	c.SetPriceOverride(o.Name)
+}
+
+func matchesFilterValue(expected string, actual *string) bool {
+	if expected == "" {
+		return true
+	}
+
+	return actual != nil && strings.EqualFold(expected, *actual)
+}
+
+func LoadPriceOverridesFromFile(path string) ([]*PriceOverride, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	out, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading price overrides file")
+	}
+
+	var f PriceOverridesFile
+	err = yaml.Unmarshal(out, &f)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing price overrides file")
+	}
+
+	if f.Version != priceOverridesFileVersion {
+		return nil, fmt.Errorf("Invalid price overrides file version. Supported versions are %s", priceOverridesFileVersion)
+	}
+
+	for _, o := range f.Overrides {
+		err = o.validate()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return f.Overrides, nil
+}
+
+// ApplyPriceOverrides updates the prices of the cost components in the project
+// using the first matching override. PopulatePrices must be called before this.
+func ApplyPriceOverrides(project *schema.Project, overrides []*PriceOverride) {
+	if len(overrides) == 0 {
+		return
+	}
+
+	for _, r := range project.AllResources() {
+		applyResourcePriceOverrides(r, overrides)
+	}
+}
+
+func applyResourcePriceOverrides(r *schema.Resource, overrides []*PriceOverride) {
+	for _, c := range r.CostComponents {
+		for _, o := range overrides {
+			if o.matches(c) {
+				log.Debugf("Using price override '%s' for %s %s", o.Name, r.Name, c.Name)
+				o.apply(c)
+				break
+			}
+		}
+	}
+
+	for _, s := range r.SubResources {
+		applyResourcePriceOverrides(s, overrides)
+	}
+}
@@ -0,0 +1,88 @@
+package prices
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyPriceOverrides(t *testing.T) {
+	discount := 22.0
+	price := 0.021
+
+	overrides := []*PriceOverride{
+		{Name: "EC2 compute EDP", Service: "AmazonEC2", ProductFamily: "compute instance", Discount: &discount},
+		{Name: "S3 storage", Service: "AmazonS3", UsageType: "TimedStorage", Price: &price},
+	}
+
+	instance := &schema.CostComponent{
+		Name: "Instance usage",
+		ProductFilter: &schema.ProductFilter{
+			Service:       strPtr("AmazonEC2"),
+			ProductFamily: strPtr("Compute Instance"),
+		},
+	}
+	instance.SetPrice(decimal.NewFromInt(10))
+
+	storage := &schema.CostComponent{
+		Name: "Storage",
+		ProductFilter: &schema.ProductFilter{
+			Service: strPtr("AmazonS3"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "usagetype", ValueRegex: strPtr("/TimedStorage-ByteHrs/")},
+			},
+		},
+	}
+	storage.SetPrice(decimal.NewFromFloat(0.023))
+
+	requests := &schema.CostComponent{
+		Name: "PUT, COPY, POST, LIST requests",
+		ProductFilter: &schema.ProductFilter{
+			Service: strPtr("AmazonS3"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "usagetype", ValueRegex: strPtr("/Requests-Tier1/")},
+			},
+		},
+	}
+	requests.SetPrice(decimal.NewFromFloat(0.005))
+
+	project := schema.NewProject("test", &schema.ProjectMetadata{})
+	project.Resources = []*schema.Resource{
+		{
+			Name:           "aws_instance.web",
+			CostComponents: []*schema.CostComponent{instance},
+		},
+		{
+			Name: "aws_s3_bucket.bucket",
+			SubResources: []*schema.Resource{
+				{Name: "Standard", CostComponents: []*schema.CostComponent{storage, requests}},
+			},
+		},
+	}
+
+	ApplyPriceOverrides(project, overrides)
+
+	assert.Equal(t, "7.8", instance.Price().String())
+	assert.Equal(t, "EC2 compute EDP", instance.PriceOverride())
+	assert.Equal(t, "0.021", storage.Price().String())
+	assert.Equal(t, "S3 storage", storage.PriceOverride())
+	assert.Equal(t, "0.005", requests.Price().String())
+	assert.Equal(t, "", requests.PriceOverride())
+}
+
+func TestPriceOverrideValidate(t *testing.T) {
+	discount := 120.0
+	price := 0.1
+
+	assert.Error(t, (&PriceOverride{Name: "none"}).validate())
+	assert.Error(t, (&PriceOverride{Name: "both", Discount: &discount, Price: &price}).validate())
+	assert.Error(t, (&PriceOverride{Name: "too much", Discount: &discount}).validate())
+	assert.Error(t, (&PriceOverride{Price: &price}).validate())
+	assert.NoError(t, (&PriceOverride{Name: "price", Price: &price}).validate())
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,191 @@
+package prices
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/infracost/infracost/internal/logging"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+)
+
+// PriceBook is a local set of products and prices that can be used instead of
+// the Cloud Pricing API, e.g. an internal rate card exported to a JSON file.
+type PriceBook struct {
+	Products []*PriceBookProduct `json:"products"`
+}
+
+type PriceBookProduct struct {
+	VendorName    string            `json:"vendorName"`
+	Service       string            `json:"service"`
+	ProductFamily string            `json:"productFamily"`
+	Region        string            `json:"region"`
+	SKU           string            `json:"sku"`
+	ProductHash   string            `json:"productHash"`
+	Attributes    map[string]string `json:"attributes"`
+	Prices        []*PriceBookPrice `json:"prices"`
+}
+
+type PriceBookPrice struct {
+	PriceHash          string `json:"priceHash"`
+	USD                string `json:"USD"`
+	PurchaseOption     string `json:"purchaseOption"`
+	Unit               string `json:"unit"`
+	Description        string `json:"description"`
+	StartUsageAmount   string `json:"startUsageAmount"`
+	EndUsageAmount     string `json:"endUsageAmount"`
+	TermLength         string `json:"termLength"`
+	TermPurchaseOption string `json:"termPurchaseOption"`
+	TermOfferingClass  string `json:"termOfferingClass"`
+}
+
+// PriceBookQueryRunner matches the product and price filters of the cost
+// components against the products in a price book.
+type PriceBookQueryRunner struct {
+	priceBook *PriceBook
+}
+
+func NewPriceBookQueryRunner(priceBook *PriceBook) *PriceBookQueryRunner {
+	return &PriceBookQueryRunner{
+		priceBook: priceBook,
+	}
+}
+
+func NewPriceBookQueryRunnerFromFile(path string) (*PriceBookQueryRunner, error) {
+	if path == "" {
+		return nil, errors.New("No price book file specified. Set the INFRACOST_PRICE_BOOK_FILE environment variable to use the pricebook pricing backend")
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading price book file")
+	}
+
+	var priceBook PriceBook
+	err = json.Unmarshal(b, &priceBook)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing price book file")
+	}
+
+	return NewPriceBookQueryRunner(&priceBook), nil
+}
+
+func (q *PriceBookQueryRunner) RunQueries(ctx context.Context, r *schema.Resource) ([]QueryResult, error) {
+	keys := resourceQueryKeys(r)
+	results := make([]QueryResult, 0, len(keys))
+
+	logging.FromContext(ctx).Debugf("Getting pricing details from price book for %s", r.Name)
+
+	for _, k := range keys {
+		products := make([]map[string]interface{}, 0)
+
+		for _, p := range q.priceBook.Products {
+			if !matchProductFilter(p, k.CostComponent.ProductFilter) {
+				continue
+			}
+
+			prices := make([]map[string]string, 0)
+			for _, price := range p.Prices {
+				if matchPriceFilter(price, k.CostComponent.PriceFilter) {
+					prices = append(prices, map[string]string{"priceHash": price.PriceHash, "USD": price.USD})
+				}
+			}
+
+			products = append(products, map[string]interface{}{
+				"productHash": p.ProductHash,
+				"sku":         p.SKU,
+				"prices":      prices,
+			})
+		}
+
+		j, err := json.Marshal(map[string]interface{}{"data": map[string]interface{}{"products": products}})
+		if err != nil {
+			return []QueryResult{}, errors.Wrap(err, "Error generating price book results")
+		}
+
+		results = append(results, QueryResult{
+			queryKey: k,
+			Result:   gjson.ParseBytes(j),
+		})
+	}
+
+	return results, nil
+}
+
+func matchProductFilter(p *PriceBookProduct, f *schema.ProductFilter) bool {
+	if f == nil {
+		return false
+	}
+
+	if !matchString(p.VendorName, f.VendorName) ||
+		!matchString(p.Service, f.Service) ||
+		!matchString(p.ProductFamily, f.ProductFamily) ||
+		!matchString(p.Region, f.Region) ||
+		!matchString(p.SKU, f.Sku) {
+		return false
+	}
+
+	for _, a := range f.AttributeFilters {
+		v, ok := p.Attributes[a.Key]
+		if a.Value != nil && (!ok || v != *a.Value) {
+			return false
+		}
+		if a.ValueRegex != nil && (!ok || !matchRegex(v, *a.ValueRegex)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchPriceFilter(p *PriceBookPrice, f *schema.PriceFilter) bool {
+	if f == nil {
+		return true
+	}
+
+	return matchString(p.PurchaseOption, f.PurchaseOption) &&
+		matchString(p.Unit, f.Unit) &&
+		matchString(p.Description, f.Description) &&
+		(f.DescriptionRegex == nil || matchRegex(p.Description, *f.DescriptionRegex)) &&
+		matchString(p.StartUsageAmount, f.StartUsageAmount) &&
+		matchString(p.EndUsageAmount, f.EndUsageAmount) &&
+		matchString(p.TermLength, f.TermLength) &&
+		matchString(p.TermPurchaseOption, f.TermPurchaseOption) &&
+		matchString(p.TermOfferingClass, f.TermOfferingClass)
+}
+
+func matchString(actual string, expected *string) bool {
+	return expected == nil || actual == *expected
+}
+
+// matchRegex matches a value using the regex format of the Cloud Pricing API,
+// e.g. /^t3\.micro$/i where the i flag makes it case insensitive.
+func matchRegex(value string, pattern string) bool {
+	flags := ""
+	if strings.HasPrefix(pattern, "/") {
+		i := strings.LastIndex(pattern, "/")
+		if i > 0 {
+			flags = pattern[i+1:]
+			pattern = pattern[1:i]
+		}
+	}
+
+	if strings.Contains(flags, "i") {
+		pattern = fmt.Sprintf("(?i)%s", pattern)
+	}
+
+	r, err := regexp.Compile(pattern)
+	if err != nil {
+		log.Debugf("Invalid regex %s: %s", pattern, err)
+		return false
+	}
+
+	return r.MatchString(value)
+}
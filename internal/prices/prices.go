@@ -1,22 +1,36 @@
 package prices
 
 import (
-	"fmt"
+	"context"
 	"runtime"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/events"
+	"github.com/infracost/infracost/internal/logging"
 	"github.com/infracost/infracost/internal/output"
 	"github.com/infracost/infracost/internal/schema"
 
 	"github.com/shopspring/decimal"
-	log "github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
 )
 
-func PopulatePrices(cfg *config.Config, project *schema.Project) error {
-	q := NewGraphQLQueryRunner(fmt.Sprintf("%s/graphql", cfg.PricingAPIEndpoint), cfg.APIKey)
+// PopulatePrices gets the prices of the project's resources. If the session
+// is set the queries are cached and tracked in it. If onPriced is set it's
+// called after each resource has been priced. If the deadline isn't zero then
+// the resources that haven't been priced by then are marked as unpriced.
+func PopulatePrices(ctx context.Context, cfg *config.Config, project *schema.Project, session *Session, onPriced func(), deadline time.Time) error {
+	q, err := NewQueryRunner(cfg)
+	if err != nil {
+		return err
+	}
+
+	if session != nil {
+		q = session.QueryRunner(q)
+	}
+
 	resources := project.AllResources()
 
 	var wg sync.WaitGroup
@@ -33,7 +47,7 @@ func PopulatePrices(cfg *config.Config, project *schema.Project) error {
 		events.SendReport(cfg, "summary", summary)
 	}()
 
-	err := GetPricesConcurrent(resources, q)
+	err = GetPricesConcurrent(ctx, resources, q, onPriced, deadline)
 	if err != nil {
 		return err
 	}
@@ -46,7 +60,23 @@ func PopulatePrices(cfg *config.Config, project *schema.Project) error {
 // GetPricesConcurrent gets the prices of all resources concurrently.
 // Concurrency level is calculated using the following formula:
 // max(min(4, numCPU * 4), 16)
-func GetPricesConcurrent(resources []*schema.Resource, q QueryRunner) error {
+// If onPriced is set it's called by the workers after each resource. Once the
+// deadline has passed the queries that are running are cancelled, and those
+// resources and the remaining ones are marked as unpriced instead.
+func GetPricesConcurrent(ctx context.Context, resources []*schema.Resource, q QueryRunner, onPriced func(), deadline time.Time) error {
+	pricingCtx := ctx
+	if !deadline.IsZero() {
+		var cancel context.CancelFunc
+		pricingCtx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+
+	// The deadline has passed if the pricing context is done but the parent
+	// context isn't, otherwise the parent was cancelled and its error is returned
+	deadlinePassed := func() bool {
+		return pricingCtx.Err() != nil && ctx.Err() == nil
+	}
+
 	// Set the number of workers
 	numWorkers := 4
 	numCPU := runtime.NumCPU()
@@ -60,11 +90,28 @@ func GetPricesConcurrent(resources []*schema.Resource, q QueryRunner) error {
 	jobs := make(chan *schema.Resource, numJobs)
 	resultErrors := make(chan error, numJobs)
 
+	var unpriced int64
+
 	// Fire up the workers
 	for i := 0; i < numWorkers; i++ {
 		go func(jobs <-chan *schema.Resource, resultErrors chan<- error) {
 			for r := range jobs {
-				err := GetPrices(r, q)
+				if !r.IsSkipped && deadlinePassed() {
+					r.SetUnpriced()
+					atomic.AddInt64(&unpriced, 1)
+					resultErrors <- nil
+					continue
+				}
+
+				err := GetPrices(pricingCtx, r, q)
+				if err != nil && deadlinePassed() {
+					r.SetUnpriced()
+					atomic.AddInt64(&unpriced, 1)
+					err = nil
+				}
+				if onPriced != nil {
+					onPriced()
+				}
 				resultErrors <- err
 			}
 		}(jobs, resultErrors)
@@ -74,6 +121,7 @@ func GetPricesConcurrent(resources []*schema.Resource, q QueryRunner) error {
 	for _, r := range resources {
 		jobs <- r
 	}
+	close(jobs)
 
 	// Get the result of the jobs
 	for i := 0; i < numJobs; i++ {
@@ -82,68 +130,77 @@ func GetPricesConcurrent(resources []*schema.Resource, q QueryRunner) error {
 			return err
 		}
 	}
+
+	if unpriced > 0 {
+		logging.FromContext(ctx).Warnf("Deadline reached, %d of %d resources weren't priced", unpriced, numJobs)
+	}
+
 	return nil
 }
 
-func GetPrices(r *schema.Resource, q QueryRunner) error {
+func GetPrices(ctx context.Context, r *schema.Resource, q QueryRunner) error {
 	if r.IsSkipped {
 		return nil
 	}
-	results, err := q.RunQueries(r)
+	results, err := q.RunQueries(ctx, r)
 	if err != nil {
 		return err
 	}
 
 	for _, r := range results {
-		setCostComponentPrice(r.Resource, r.CostComponent, r.Result)
+		setCostComponentPrice(ctx, r.Resource, r.CostComponent, r.Result)
 	}
 
 	return nil
 }
 
-func setCostComponentPrice(r *schema.Resource, c *schema.CostComponent, res gjson.Result) {
+func setCostComponentPrice(ctx context.Context, r *schema.Resource, c *schema.CostComponent, res gjson.Result) {
+	logger := logging.FromContext(ctx)
+
 	var p decimal.Decimal
 
 	products := res.Get("data.products").Array()
 	if len(products) == 0 {
 		if c.IgnoreIfMissingPrice {
-			log.Debugf("No products found for %s %s, ignoring since IgnoreIfMissingPrice is set.", r.Name, c.Name)
+			logger.Debugf("No products found for %s %s, ignoring since IgnoreIfMissingPrice is set.", r.Name, c.Name)
 			r.RemoveCostComponent(c)
 			return
 		}
 
-		log.Warnf("No products found for %s %s, using 0.00", r.Name, c.Name)
+		logger.Warnf("No products found for %s %s, using 0.00", r.Name, c.Name)
 		c.SetPrice(decimal.Zero)
 		return
 	}
 	if len(products) > 1 {
-		log.Warnf("Multiple products found for %s %s, using the first product", r.Name, c.Name)
+		logger.Warnf("Multiple products found for %s %s, using the first product", r.Name, c.Name)
 	}
 
 	prices := products[0].Get("prices").Array()
 	if len(prices) == 0 {
 		if c.IgnoreIfMissingPrice {
-			log.Debugf("No prices found for %s %s, ignoring since IgnoreIfMissingPrice is set.", r.Name, c.Name)
+			logger.Debugf("No prices found for %s %s, ignoring since IgnoreIfMissingPrice is set.", r.Name, c.Name)
 			r.RemoveCostComponent(c)
 			return
 		}
 
-		log.Warnf("No prices found for %s %s, using 0.00", r.Name, c.Name)
+		logger.Warnf("No prices found for %s %s, using 0.00", r.Name, c.Name)
 		c.SetPrice(decimal.Zero)
 		return
 	}
 	if len(prices) > 1 {
-		log.Warnf("Multiple prices found for %s %s, using the first price", r.Name, c.Name)
+		logger.Warnf("Multiple prices found for %s %s, using the first price", r.Name, c.Name)
 	}
 
 	var err error
 	p, err = decimal.NewFromString(prices[0].Get("USD").String())
 	if err != nil {
-		log.Warnf("Error converting price (using 0.00) '%v': %s", prices[0].Get("USD").String(), err.Error())
+		logger.Warnf("Error converting price (using 0.00) '%v': %s", prices[0].Get("USD").String(), err.Error())
 		c.SetPrice(decimal.Zero)
 		return
 	}
 
 	c.SetPrice(p)
 	c.SetPriceHash(prices[0].Get("priceHash").String())
+	c.SetProductHash(products[0].Get("productHash").String())
+	c.SetProductSKU(products[0].Get("sku").String())
 }
@@ -0,0 +1,97 @@
+package prices
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPricesConcurrentReportsProgress(t *testing.T) {
+	resources := make([]*schema.Resource, 0, 50)
+	for i := 0; i < 50; i++ {
+		r, _ := testResource()
+		resources = append(resources, r)
+	}
+	resources = append(resources, &schema.Resource{Name: "aws_skipped.skipped", IsSkipped: true})
+
+	var priced int64
+	err := GetPricesConcurrent(context.Background(), resources, NewPriceBookQueryRunner(&PriceBook{}), func() {
+		atomic.AddInt64(&priced, 1)
+	}, time.Time{})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(len(resources)), priced)
+}
+
+func TestGetPricesConcurrentDeadline(t *testing.T) {
+	resources := make([]*schema.Resource, 0, 10)
+	for i := 0; i < 10; i++ {
+		r, c := testResource()
+		one := decimal.NewFromInt(1)
+		c.HourlyQuantity = &one
+		resources = append(resources, r)
+	}
+
+	one := decimal.NewFromInt(1)
+	fixed := &schema.CostComponent{Name: "Fixed fee", UnitMultiplier: 1, MonthlyQuantity: &one}
+	fixed.SetPrice(decimal.NewFromInt(5))
+	resources[0].CostComponents = append(resources[0].CostComponents, fixed)
+
+	var priced int64
+	err := GetPricesConcurrent(context.Background(), resources, NewPriceBookQueryRunner(&PriceBook{}), func() {
+		atomic.AddInt64(&priced, 1)
+	}, time.Now().Add(-time.Second))
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(0), priced)
+
+	for _, r := range resources {
+		r.CalculateCosts()
+
+		for _, c := range r.CostComponents {
+			if c == fixed {
+				continue
+			}
+			assert.True(t, c.IsUnpriced())
+			assert.Nil(t, c.MonthlyCost)
+		}
+	}
+
+	assert.False(t, fixed.IsUnpriced())
+	assert.Equal(t, "5", fixed.MonthlyCost.String())
+	assert.Nil(t, resources[1].MonthlyCost)
+}
+
+// blockingQueryRunner doesn't return until the context is done, like a
+// request to the pricing API that hangs.
+type blockingQueryRunner struct{}
+
+func (q blockingQueryRunner) RunQueries(ctx context.Context, r *schema.Resource) ([]QueryResult, error) {
+	<-ctx.Done()
+	return []QueryResult{}, ctx.Err()
+}
+
+func TestGetPricesConcurrentDeadlineCancelsQueries(t *testing.T) {
+	r, _ := testResource()
+
+	err := GetPricesConcurrent(context.Background(), []*schema.Resource{r}, blockingQueryRunner{}, nil, time.Now().Add(50*time.Millisecond))
+	require.NoError(t, err)
+
+	assert.True(t, r.CostComponents[0].IsUnpriced())
+}
+
+func TestGetPricesConcurrentCancelled(t *testing.T) {
+	r, _ := testResource()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := GetPricesConcurrent(ctx, []*schema.Resource{r}, blockingQueryRunner{}, nil, time.Now().Add(time.Minute))
+	assert.ErrorIs(t, err, context.Canceled)
+}
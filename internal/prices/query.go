@@ -2,16 +2,17 @@ package prices
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 
 	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/httpretry"
+	"github.com/infracost/infracost/internal/logging"
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/pkg/errors"
 
-	log "github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
 )
 
@@ -46,32 +47,39 @@ type GraphQLQuery struct {
 }
 
 type QueryRunner interface {
-	RunQueries(resource *schema.Resource) ([]QueryResult, error)
+	RunQueries(ctx context.Context, resource *schema.Resource) ([]QueryResult, error)
 }
 
 type GraphQLQueryRunner struct {
-	endpoint string
-	apiKey   string
+	endpoint    string
+	apiKey      string
+	retryPolicy httpretry.Policy
+	session     *Session
 }
 
-func NewGraphQLQueryRunner(endpoint string, apiKey string) *GraphQLQueryRunner {
+func NewGraphQLQueryRunner(endpoint string, apiKey string, retryPolicy httpretry.Policy) *GraphQLQueryRunner {
 	return &GraphQLQueryRunner{
-		endpoint: endpoint,
-		apiKey:   apiKey,
+		endpoint:    endpoint,
+		apiKey:      apiKey,
+		retryPolicy: retryPolicy,
 	}
 }
 
-func (q *GraphQLQueryRunner) RunQueries(r *schema.Resource) ([]QueryResult, error) {
+func (q *GraphQLQueryRunner) setSession(s *Session) {
+	q.session = s
+}
+
+func (q *GraphQLQueryRunner) RunQueries(ctx context.Context, r *schema.Resource) ([]QueryResult, error) {
 	keys, queries := q.batchQueries(r)
 
 	if len(queries) == 0 {
-		log.Debugf("Skipping getting pricing details for %s since there are no queries to run", r.Name)
+		logging.FromContext(ctx).Debugf("Skipping getting pricing details for %s since there are no queries to run", r.Name)
 		return []QueryResult{}, nil
 	}
 
-	log.Debugf("Getting pricing details from %s for %s", q.endpoint, r.Name)
+	logging.FromContext(ctx).Debugf("Getting pricing details from %s for %s", q.endpoint, r.Name)
 
-	results, err := q.getQueryResults(queries)
+	results, err := q.getQueryResults(ctx, queries)
 	if err != nil {
 		return []QueryResult{}, err
 	}
@@ -87,6 +95,8 @@ func (q *GraphQLQueryRunner) buildQuery(product *schema.ProductFilter, price *sc
 	query := `
 		query($productFilter: ProductFilter!, $priceFilter: PriceFilter) {
 			products(filter: $productFilter) {
+				productHash
+				sku
 				prices(filter: $priceFilter) {
 					priceHash
 					USD
@@ -98,11 +108,11 @@ func (q *GraphQLQueryRunner) buildQuery(product *schema.ProductFilter, price *sc
 	return GraphQLQuery{query, v}
 }
 
-func (q *GraphQLQueryRunner) getQueryResults(queries []GraphQLQuery) ([]gjson.Result, error) {
+func (q *GraphQLQueryRunner) getQueryResults(ctx context.Context, queries []GraphQLQuery) ([]gjson.Result, error) {
 	results := make([]gjson.Result, 0, len(queries))
 
 	if len(queries) == 0 {
-		log.Debug("skipping GraphQL request as no queries have been specified")
+		logging.FromContext(ctx).Debug("skipping GraphQL request as no queries have been specified")
 		return results, nil
 	}
 
@@ -111,24 +121,20 @@ func (q *GraphQLQueryRunner) getQueryResults(queries []GraphQLQuery) ([]gjson.Re
 		return results, errors.Wrap(err, "Error generating request for pricing API")
 	}
 
-	req, err := http.NewRequest("POST", q.endpoint, bytes.NewBuffer(queriesBody))
-	if err != nil {
-		return results, errors.Wrap(err, "Error generating request for pricing API")
-	}
+	resp, body, err := sendWithRetries(ctx, q.retryPolicy, q.session, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", q.endpoint, bytes.NewBuffer(queriesBody))
+		if err != nil {
+			return nil, errors.Wrap(err, "Error generating request for pricing API")
+		}
 
-	config.AddAuthHeaders(q.apiKey, req)
+		config.AddAuthHeaders(q.apiKey, req)
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+		return req, nil
+	})
 	if err != nil {
 		return results, errors.Wrap(err, "Error sending request to pricing API")
 	}
-	defer resp.Body.Close()
 
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return results, &PricingAPIError{err, "Invalid response from pricing API"}
-	}
 	if resp.StatusCode != 200 {
 		var r pricingAPIErrorResponse
 		err = json.Unmarshal(body, &r)
@@ -149,22 +155,36 @@ func (q *GraphQLQueryRunner) getQueryResults(queries []GraphQLQuery) ([]gjson.Re
 // Batch all the queries for this resource so we can use one GraphQL call.
 // Use queryKeys to keep track of which query maps to which sub-resource and price component.
 func (q *GraphQLQueryRunner) batchQueries(r *schema.Resource) ([]queryKey, []GraphQLQuery) {
+	keys := resourceQueryKeys(r)
+	queries := make([]GraphQLQuery, 0, len(keys))
+
+	for _, k := range keys {
+		queries = append(queries, q.buildQuery(k.CostComponent.ProductFilter, k.CostComponent.PriceFilter))
+	}
+
+	return keys, queries
+}
+
+// resourceQueryKeys returns a key for each cost component of the resource and its sub-resources.
+// Cost components without a product filter have a fixed price so they aren't queried.
+func resourceQueryKeys(r *schema.Resource) []queryKey {
 	keys := make([]queryKey, 0)
-	queries := make([]GraphQLQuery, 0)
 
 	for _, c := range r.CostComponents {
-		keys = append(keys, queryKey{r, c})
-		queries = append(queries, q.buildQuery(c.ProductFilter, c.PriceFilter))
+		if c.ProductFilter != nil {
+			keys = append(keys, queryKey{r, c})
+		}
 	}
 
 	for _, r := range r.FlattenedSubResources() {
 		for _, c := range r.CostComponents {
-			keys = append(keys, queryKey{r, c})
-			queries = append(queries, q.buildQuery(c.ProductFilter, c.PriceFilter))
+			if c.ProductFilter != nil {
+				keys = append(keys, queryKey{r, c})
+			}
 		}
 	}
 
-	return keys, queries
+	return keys
 }
 
 func (q *GraphQLQueryRunner) zipQueryResults(k []queryKey, r []gjson.Result) []QueryResult {
@@ -0,0 +1,101 @@
+package prices
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/httpretry"
+	"github.com/infracost/infracost/internal/logging"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/pkg/errors"
+
+	"github.com/tidwall/gjson"
+)
+
+// RESTQuery is sent to a REST pricing backend for each cost component.
+type RESTQuery struct {
+	ProductFilter *schema.ProductFilter `json:"productFilter"`
+	PriceFilter   *schema.PriceFilter   `json:"priceFilter"`
+}
+
+// RESTQueryRunner gets prices from a service that doesn't implement the
+// GraphQL schema of the Cloud Pricing API. The queries for a resource are
+// POSTed to the endpoint as a JSON array and it must respond with an array
+// containing the matching products for each query, in the same order, e.g.
+// [{"products": [{"productHash": "...", "sku": "...", "prices": [{"priceHash": "...", "USD": "0.1"}]}]}]
+type RESTQueryRunner struct {
+	endpoint    string
+	apiKey      string
+	retryPolicy httpretry.Policy
+	session     *Session
+}
+
+func NewRESTQueryRunner(endpoint string, apiKey string, retryPolicy httpretry.Policy) *RESTQueryRunner {
+	return &RESTQueryRunner{
+		endpoint:    endpoint,
+		apiKey:      apiKey,
+		retryPolicy: retryPolicy,
+	}
+}
+
+func (q *RESTQueryRunner) setSession(s *Session) {
+	q.session = s
+}
+
+func (q *RESTQueryRunner) RunQueries(ctx context.Context, r *schema.Resource) ([]QueryResult, error) {
+	keys := resourceQueryKeys(r)
+
+	if len(keys) == 0 {
+		logging.FromContext(ctx).Debugf("Skipping getting pricing details for %s since there are no queries to run", r.Name)
+		return []QueryResult{}, nil
+	}
+
+	logging.FromContext(ctx).Debugf("Getting pricing details from %s for %s", q.endpoint, r.Name)
+
+	queries := make([]RESTQuery, 0, len(keys))
+	for _, k := range keys {
+		queries = append(queries, RESTQuery{k.CostComponent.ProductFilter, k.CostComponent.PriceFilter})
+	}
+
+	body, err := json.Marshal(queries)
+	if err != nil {
+		return []QueryResult{}, errors.Wrap(err, "Error generating request for pricing backend")
+	}
+
+	resp, respBody, err := sendWithRetries(ctx, q.retryPolicy, q.session, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", q.endpoint, bytes.NewBuffer(body))
+		if err != nil {
+			return nil, errors.Wrap(err, "Error generating request for pricing backend")
+		}
+
+		config.AddAuthHeaders(q.apiKey, req)
+
+		return req, nil
+	})
+	if err != nil {
+		return []QueryResult{}, errors.Wrap(err, "Error sending request to pricing backend")
+	}
+
+	if resp.StatusCode != 200 {
+		return []QueryResult{}, &PricingAPIError{errors.Errorf("unexpected status %s", resp.Status), "Invalid response from pricing backend"}
+	}
+
+	results := gjson.ParseBytes(respBody).Array()
+	if len(results) != len(keys) {
+		return []QueryResult{}, &PricingAPIError{errors.Errorf("expected %d results but got %d", len(keys), len(results)), "Invalid response from pricing backend"}
+	}
+
+	res := make([]QueryResult, 0, len(keys))
+	for i, k := range keys {
+		// Wrap the result so it's the same as the GraphQL response
+		res = append(res, QueryResult{
+			queryKey: k,
+			Result:   gjson.Parse(`{"data":` + results[i].Raw + `}`),
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,39 @@
+package prices
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/httpretry"
+)
+
+// RetryPolicyFromConfig returns the default retry policy with the timeout
+// and retries from the config.
+func RetryPolicyFromConfig(cfg *config.Config) httpretry.Policy {
+	p := httpretry.DefaultPolicy()
+
+	if cfg.APIRetries != nil && *cfg.APIRetries >= 0 {
+		p.Retries = *cfg.APIRetries
+	}
+
+	if cfg.APITimeout > 0 {
+		p.Timeout = time.Duration(cfg.APITimeout) * time.Second
+	}
+
+	return p
+}
+
+// sendWithRetries sends the request with the retry policy. If the session is
+// set each attempt is rate limited and recorded in its stats.
+func sendWithRetries(ctx context.Context, p httpretry.Policy, session *Session, newRequest func() (*http.Request, error)) (*http.Response, []byte, error) {
+	start := time.Now()
+
+	resp, body, attempts, err := httpretry.Send(ctx, p, newRequest, session.beforeRequest)
+	if attempts > 0 {
+		session.recordRequest(attempts, time.Since(start))
+	}
+
+	return resp, body, err
+}
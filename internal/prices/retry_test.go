@@ -0,0 +1,44 @@
+package prices
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/httpretry"
+	"github.com/stretchr/testify/assert"
+)
+
+func stubSleep(t *testing.T) *[]time.Duration {
+	waits := []time.Duration{}
+
+	origSleep := httpretry.Sleep
+	t.Cleanup(func() { httpretry.Sleep = origSleep })
+	httpretry.Sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	return &waits
+}
+
+func newTestRequest(url string) func() (*http.Request, error) {
+	return func() (*http.Request, error) {
+		return http.NewRequest("POST", url, nil)
+	}
+}
+
+func TestRetryPolicyFromConfig(t *testing.T) {
+	cfg := config.DefaultConfig()
+	assert.Equal(t, httpretry.DefaultPolicy(), RetryPolicyFromConfig(cfg))
+
+	retries := 0
+	cfg.APIRetries = &retries
+	cfg.APITimeout = 5
+
+	p := RetryPolicyFromConfig(cfg)
+	assert.Equal(t, 0, p.Retries)
+	assert.Equal(t, 5*time.Second, p.Timeout)
+}
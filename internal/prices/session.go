@@ -0,0 +1,217 @@
+package prices
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/httpretry"
+	"github.com/infracost/infracost/internal/logging"
+	"github.com/infracost/infracost/internal/schema"
+
+	"github.com/tidwall/gjson"
+)
+
+// RequestStats are the totals of the pricing queries of a run. Queries are
+// counted for each cost component, and requests are the HTTP requests sent to
+// the pricing API for the queries that weren't cached, including the retries.
+type RequestStats struct {
+	Queries   int
+	CacheHits int
+	Requests  int
+	Retries   int
+	Duration  time.Duration
+}
+
+// Session is shared by the pricing queries of a run. The results are cached
+// so queries that are repeated by resources with the same filters are only
+// sent once, the requests to the pricing API are rate limited across all the
+// projects, and the stats are tracked so they can be reported, e.g. to
+// capacity-plan a self-hosted pricing API.
+type Session struct {
+	limiter *rateLimiter
+
+	mu    sync.Mutex
+	cache map[string]gjson.Result
+	stats RequestStats
+}
+
+// NewSession creates a session using the rate limit from the config.
+func NewSession(cfg *config.Config) *Session {
+	s := &Session{
+		cache: make(map[string]gjson.Result),
+	}
+
+	if cfg.APIRateLimit > 0 {
+		s.limiter = newRateLimiter(cfg.APIRateLimit)
+	}
+
+	return s
+}
+
+// Stats returns the totals of the queries run in the session so far.
+func (s *Session) Stats() RequestStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.stats
+}
+
+// sessionQueryRunner is implemented by the QueryRunners that send requests
+// to the pricing API, so their requests are rate limited and tracked.
+type sessionQueryRunner interface {
+	setSession(s *Session)
+}
+
+// QueryRunner returns a QueryRunner that caches the results of the runner and
+// tracks its queries in the session.
+func (s *Session) QueryRunner(runner QueryRunner) QueryRunner {
+	if r, ok := runner.(sessionQueryRunner); ok {
+		r.setSession(s)
+	}
+
+	return &cachedQueryRunner{
+		runner:  runner,
+		session: s,
+	}
+}
+
+// beforeRequest waits until the request can be sent without going over the
+// rate limit, or returns the context's error if it's done first.
+func (s *Session) beforeRequest(ctx context.Context) error {
+	if s == nil || s.limiter == nil {
+		return nil
+	}
+
+	return s.limiter.wait(ctx)
+}
+
+// recordRequest adds a request that was sent with its retries. It's called
+// once the retries have finished, so the duration includes the backoff.
+func (s *Session) recordRequest(attempts int, d time.Duration) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stats.Requests += attempts
+	s.stats.Retries += attempts - 1
+	s.stats.Duration += d
+}
+
+func (s *Session) cachedResult(key string) (gjson.Result, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res, ok := s.cache[key]
+	return res, ok
+}
+
+func (s *Session) cacheResult(key string, res gjson.Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cache[key] = res
+}
+
+func (s *Session) recordQueries(queries int, cacheHits int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stats.Queries += queries
+	s.stats.CacheHits += cacheHits
+}
+
+// cachedQueryRunner runs the queries that aren't in the session's cache with
+// another QueryRunner. The queries are cached by their filters, the same as
+// the fixtures, since the results don't depend on the resource.
+type cachedQueryRunner struct {
+	runner  QueryRunner
+	session *Session
+}
+
+func (q *cachedQueryRunner) RunQueries(ctx context.Context, r *schema.Resource) ([]QueryResult, error) {
+	keys := resourceQueryKeys(r)
+	results := make([]QueryResult, 0, len(keys))
+
+	// The queries that aren't cached are run for a resource with just their
+	// cost components, then matched back to the resource they're from
+	uncached := &schema.Resource{Name: r.Name}
+	uncachedKeys := make(map[*schema.CostComponent]queryKey)
+	cacheKeys := make(map[*schema.CostComponent]string)
+
+	for _, k := range keys {
+		cacheKey, err := fixtureKey(k.CostComponent.ProductFilter, k.CostComponent.PriceFilter)
+		if err == nil {
+			if res, ok := q.session.cachedResult(cacheKey); ok {
+				results = append(results, QueryResult{queryKey: k, Result: res})
+				continue
+			}
+			cacheKeys[k.CostComponent] = cacheKey
+		}
+
+		uncached.CostComponents = append(uncached.CostComponents, k.CostComponent)
+		uncachedKeys[k.CostComponent] = k
+	}
+
+	q.session.recordQueries(len(keys), len(results))
+
+	if len(uncached.CostComponents) == 0 {
+		logging.FromContext(ctx).Debugf("Using cached pricing details for %s", r.Name)
+		return results, nil
+	}
+
+	uncachedResults, err := q.runner.RunQueries(ctx, uncached)
+	if err != nil {
+		return []QueryResult{}, err
+	}
+
+	for _, res := range uncachedResults {
+		k, ok := uncachedKeys[res.CostComponent]
+		if !ok {
+			continue
+		}
+
+		if cacheKey, ok := cacheKeys[res.CostComponent]; ok {
+			q.session.cacheResult(cacheKey, res.Result)
+		}
+
+		results = append(results, QueryResult{queryKey: k, Result: res.Result})
+	}
+
+	return results, nil
+}
+
+// rateLimiter spaces out the requests so no more than the limit are sent each
+// second.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64) *rateLimiter {
+	return &rateLimiter{
+		interval: time.Duration(float64(time.Second) / requestsPerSecond),
+	}
+}
+
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if wait > 0 {
+		return httpretry.Sleep(ctx, wait)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package prices
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/httpretry"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+type countingQueryRunner struct {
+	queries int
+}
+
+func (q *countingQueryRunner) RunQueries(_ context.Context, r *schema.Resource) ([]QueryResult, error) {
+	keys := resourceQueryKeys(r)
+	q.queries += len(keys)
+
+	results := make([]QueryResult, 0, len(keys))
+	for _, k := range keys {
+		results = append(results, QueryResult{
+			queryKey: k,
+			Result:   gjson.Parse(`{"data":{"products":[{"prices":[{"USD":"` + *k.CostComponent.ProductFilter.Service + `"}]}]}}`),
+		})
+	}
+
+	return results, nil
+}
+
+func sessionTestResource(name string, services ...string) *schema.Resource {
+	r := &schema.Resource{Name: name}
+	for _, s := range services {
+		service := s
+		r.CostComponents = append(r.CostComponents, &schema.CostComponent{
+			Name:          service,
+			ProductFilter: &schema.ProductFilter{Service: &service},
+		})
+	}
+
+	return r
+}
+
+func TestSessionCachesQueries(t *testing.T) {
+	runner := &countingQueryRunner{}
+	s := NewSession(&config.Config{})
+	q := s.QueryRunner(runner)
+
+	r1 := sessionTestResource("r1", "1", "2")
+	r2 := sessionTestResource("r2", "2", "3")
+
+	_, err := q.RunQueries(context.Background(), r1)
+	require.NoError(t, err)
+
+	results, err := q.RunQueries(context.Background(), r2)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, runner.queries)
+	assert.Equal(t, RequestStats{Queries: 4, CacheHits: 1}, s.Stats())
+
+	// The results are for the resource's own cost components, including the
+	// cached ones
+	require.Len(t, results, 2)
+	for _, res := range results {
+		assert.Equal(t, r2, res.Resource)
+		assert.Equal(t, res.CostComponent.Name, res.Result.Get("data.products.0.prices.0.USD").String())
+	}
+}
+
+func TestSessionRecordsRequests(t *testing.T) {
+	stubSleep(t)
+
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer ts.Close()
+
+	s := NewSession(&config.Config{})
+	_, _, err := sendWithRetries(context.Background(), httpretry.DefaultPolicy(), s, newTestRequest(ts.URL))
+	require.NoError(t, err)
+
+	stats := s.Stats()
+	assert.Equal(t, 2, stats.Requests)
+	assert.Equal(t, 1, stats.Retries)
+	assert.True(t, stats.Duration > 0)
+}
+
+func TestSessionRateLimit(t *testing.T) {
+	waits := stubSleep(t)
+
+	s := NewSession(&config.Config{APIRateLimit: 4})
+	for i := 0; i < 3; i++ {
+		require.NoError(t, s.beforeRequest(context.Background()))
+	}
+
+	// The stubbed sleep doesn't wait, so the requests after the first are
+	// spaced out from when the first was sent
+	require.Len(t, *waits, 2)
+	assert.InDelta(t, float64(250*time.Millisecond), float64((*waits)[0]), float64(50*time.Millisecond))
+	assert.InDelta(t, float64(500*time.Millisecond), float64((*waits)[1]), float64(50*time.Millisecond))
+}
@@ -0,0 +1,187 @@
+package prices
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/logging"
+	"github.com/pkg/errors"
+
+	"github.com/tidwall/gjson"
+)
+
+// snapshotServiceAliases are short names that can be used for common services
+// when downloading a pricing snapshot. Any other value is used as the service name.
+var snapshotServiceAliases = map[string]string{
+	"cloudwatch": "AmazonCloudWatch",
+	"dynamodb":   "AmazonDynamoDB",
+	"ec2":        "AmazonEC2",
+	"eks":        "AmazonEKS",
+	"elb":        "AWSELB",
+	"lambda":     "AWSLambda",
+	"rds":        "AmazonRDS",
+	"s3":         "AmazonS3",
+}
+
+var snapshotQuery = `
+	query($productFilter: ProductFilter!) {
+		products(filter: $productFilter) {
+			vendorName
+			service
+			productFamily
+			region
+			sku
+			productHash
+			attributes {
+				key
+				value
+			}
+			prices {
+				priceHash
+				USD
+				purchaseOption
+				unit
+				description
+				startUsageAmount
+				endUsageAmount
+				termLength
+				termPurchaseOption
+				termOfferingClass
+			}
+		}
+	}
+`
+
+// SnapshotServiceName returns the service name for a service alias, e.g. ec2 is AmazonEC2.
+func SnapshotServiceName(service string) string {
+	if name, ok := snapshotServiceAliases[strings.ToLower(service)]; ok {
+		return name
+	}
+	return service
+}
+
+// DownloadPricingSnapshot downloads all the products and prices of the
+// services in the regions from the pricing API so they can be used in offline
+// mode. If no regions are given then the products for all regions are downloaded.
+func (q *GraphQLQueryRunner) DownloadPricingSnapshot(ctx context.Context, services []string, regions []string) (*PriceBook, error) {
+	queries := make([]GraphQLQuery, 0)
+
+	for _, service := range services {
+		if len(regions) == 0 {
+			queries = append(queries, buildSnapshotQuery(SnapshotServiceName(service), nil))
+			continue
+		}
+
+		for _, region := range regions {
+			r := region
+			queries = append(queries, buildSnapshotQuery(SnapshotServiceName(service), &r))
+		}
+	}
+
+	logging.FromContext(ctx).Debugf("Downloading pricing snapshot from %s", q.endpoint)
+
+	results, err := q.getQueryResults(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+
+	priceBook := &PriceBook{
+		Products: make([]*PriceBookProduct, 0),
+	}
+
+	for _, result := range results {
+		for _, p := range result.Get("data.products").Array() {
+			priceBook.Products = append(priceBook.Products, parseSnapshotProduct(p))
+		}
+	}
+
+	return priceBook, nil
+}
+
+func buildSnapshotQuery(service string, region *string) GraphQLQuery {
+	filter := map[string]interface{}{
+		"service": service,
+	}
+	if region != nil {
+		filter["region"] = *region
+	}
+
+	return GraphQLQuery{
+		Query:     snapshotQuery,
+		Variables: map[string]interface{}{"productFilter": filter},
+	}
+}
+
+func parseSnapshotProduct(r gjson.Result) *PriceBookProduct {
+	p := &PriceBookProduct{
+		VendorName:    r.Get("vendorName").String(),
+		Service:       r.Get("service").String(),
+		ProductFamily: r.Get("productFamily").String(),
+		Region:        r.Get("region").String(),
+		SKU:           r.Get("sku").String(),
+		ProductHash:   r.Get("productHash").String(),
+		Attributes:    make(map[string]string),
+		Prices:        make([]*PriceBookPrice, 0),
+	}
+
+	for _, a := range r.Get("attributes").Array() {
+		p.Attributes[a.Get("key").String()] = a.Get("value").String()
+	}
+
+	for _, price := range r.Get("prices").Array() {
+		p.Prices = append(p.Prices, &PriceBookPrice{
+			PriceHash:          price.Get("priceHash").String(),
+			USD:                price.Get("USD").String(),
+			PurchaseOption:     price.Get("purchaseOption").String(),
+			Unit:               price.Get("unit").String(),
+			Description:        price.Get("description").String(),
+			StartUsageAmount:   price.Get("startUsageAmount").String(),
+			EndUsageAmount:     price.Get("endUsageAmount").String(),
+			TermLength:         price.Get("termLength").String(),
+			TermPurchaseOption: price.Get("termPurchaseOption").String(),
+			TermOfferingClass:  price.Get("termOfferingClass").String(),
+		})
+	}
+
+	return p
+}
+
+// NewOfflineQueryRunner returns the query runner for the pricing snapshot that
+// is used in offline mode.
+func NewOfflineQueryRunner(cfg *config.Config) (*PriceBookQueryRunner, error) {
+	path := cfg.PricingSnapshotFile
+	if path == "" {
+		path = config.PricingSnapshotFilePath()
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, fmt.Errorf("No pricing snapshot found at %s. Run infracost pricing download to download one before running in offline mode", path)
+	}
+
+	return NewPriceBookQueryRunnerFromFile(path)
+}
+
+func (b *PriceBook) Write(path string) error {
+	j, err := json.Marshal(b)
+	if err != nil {
+		return errors.Wrap(err, "Error generating pricing snapshot")
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return errors.Wrap(err, "Error creating pricing snapshot directory")
+	}
+
+	err = ioutil.WriteFile(path, j, 0600)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("Error writing pricing snapshot to %s", path))
+	}
+
+	return nil
+}
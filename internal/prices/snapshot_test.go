@@ -0,0 +1,99 @@
+package prices
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/httpretry"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testResource() (*schema.Resource, *schema.CostComponent) {
+	c := &schema.CostComponent{
+		Name: "Instance usage (Linux/UNIX, on-demand, t3.micro)",
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("aws"),
+			Region:        strPtr("us-east-1"),
+			Service:       strPtr("AmazonEC2"),
+			ProductFamily: strPtr("Compute Instance"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "instanceType", Value: strPtr("t3.micro")},
+				{Key: "operatingSystem", ValueRegex: strPtr("/^linux$/i")},
+			},
+		},
+		PriceFilter: &schema.PriceFilter{
+			PurchaseOption: strPtr("on_demand"),
+		},
+	}
+
+	return &schema.Resource{Name: "aws_instance.web", CostComponents: []*schema.CostComponent{c}}, c
+}
+
+func TestDownloadPricingSnapshot(t *testing.T) {
+	var queries []GraphQLQuery
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := json.NewDecoder(r.Body).Decode(&queries)
+		require.NoError(t, err)
+
+		_, _ = w.Write([]byte(`[
+			{"data": {"products": [{
+				"vendorName": "aws",
+				"service": "AmazonEC2",
+				"productFamily": "Compute Instance",
+				"region": "us-east-1",
+				"sku": "sku-t3-micro",
+				"productHash": "product-t3-micro",
+				"attributes": [{"key": "instanceType", "value": "t3.micro"}, {"key": "operatingSystem", "value": "Linux"}],
+				"prices": [{"priceHash": "on-demand", "USD": "0.0104", "purchaseOption": "on_demand", "unit": "Hrs"}]
+			}]}},
+			{"data": {"products": []}}
+		]`))
+	}))
+	defer ts.Close()
+
+	priceBook, err := NewGraphQLQueryRunner(ts.URL, "", httpretry.DefaultPolicy()).DownloadPricingSnapshot(context.Background(), []string{"ec2", "AmazonRDS"}, []string{"us-east-1"})
+	require.NoError(t, err)
+
+	require.Len(t, queries, 2)
+	assert.Equal(t, map[string]interface{}{"service": "AmazonEC2", "region": "us-east-1"}, queries[0].Variables["productFilter"])
+	assert.Equal(t, map[string]interface{}{"service": "AmazonRDS", "region": "us-east-1"}, queries[1].Variables["productFilter"])
+
+	require.Len(t, priceBook.Products, 1)
+	assert.Equal(t, "t3.micro", priceBook.Products[0].Attributes["instanceType"])
+
+	path := filepath.Join(t.TempDir(), "snapshot", "pricing-snapshot.json")
+	require.NoError(t, priceBook.Write(path))
+
+	cfg := config.DefaultConfig()
+	cfg.Offline = true
+	cfg.PricingSnapshotFile = path
+
+	q, err := NewQueryRunner(cfg)
+	require.NoError(t, err)
+	assert.IsType(t, &PriceBookQueryRunner{}, q)
+
+	r, c := testResource()
+	err = GetPrices(context.Background(), r, q)
+	require.NoError(t, err)
+
+	assert.Equal(t, "0.0104", c.Price().String())
+	assert.Equal(t, "on-demand", c.PriceHash())
+}
+
+func TestOfflineMissingSnapshot(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Offline = true
+	cfg.PricingSnapshotFile = filepath.Join(t.TempDir(), "missing.json")
+
+	_, err := NewQueryRunner(cfg)
+	assert.Error(t, err)
+	assert.False(t, cfg.RequiresAPIKey())
+}
@@ -8,11 +8,19 @@ import (
 	"os"
 
 	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/providers/kubernetes"
 	"github.com/infracost/infracost/internal/providers/terraform"
 	"github.com/infracost/infracost/internal/schema"
 )
 
 func Detect(cfg *config.Config, projectCfg *config.Project) (schema.Provider, error) {
+	if projectCfg.TerraformCloudOrg != "" && projectCfg.TerraformCloudWorkspace != "" {
+		return terraform.NewCloudProvider(cfg, projectCfg), nil
+	}
+
+	if projectCfg.Path == "-" {
+		return terraform.NewStdinPlanJSONProvider(cfg, projectCfg, os.Stdin)
+	}
 
 	if _, err := os.Stat(projectCfg.Path); os.IsNotExist(err) {
 		return nil, fmt.Errorf("No such file or directory %s", projectCfg.Path)
@@ -34,6 +42,14 @@ func Detect(cfg *config.Config, projectCfg *config.Project) (schema.Provider, er
 		return terraform.NewDirProvider(cfg, projectCfg), nil
 	}
 
+	if kubernetes.IsHelmChart(projectCfg.Path) {
+		return kubernetes.NewHelmProvider(cfg, projectCfg), nil
+	}
+
+	if kubernetes.IsManifests(projectCfg.Path) {
+		return kubernetes.NewManifestsProvider(cfg, projectCfg), nil
+	}
+
 	return nil, fmt.Errorf("Could not detect path type for %s", projectCfg.Path)
 }
 
@@ -0,0 +1,130 @@
+package kubernetes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"gopkg.in/yaml.v2"
+)
+
+// parseObjects parses a multi-document YAML stream of Kubernetes objects.
+// Documents that aren't Kubernetes objects are ignored, and List objects are
+// expanded into their items.
+func parseObjects(manifests []byte) ([]gjson.Result, error) {
+	objects := make([]gjson.Result, 0)
+
+	dec := yaml.NewDecoder(bytes.NewReader(manifests))
+	for {
+		var doc interface{}
+		err := dec.Decode(&doc)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "Error parsing Kubernetes manifests")
+		}
+
+		if doc == nil {
+			continue
+		}
+
+		j, err := json.Marshal(convertYAML(doc))
+		if err != nil {
+			return nil, errors.Wrap(err, "Error parsing Kubernetes manifests")
+		}
+
+		obj := gjson.ParseBytes(j)
+		if !obj.Get("apiVersion").Exists() || !obj.Get("kind").Exists() {
+			continue
+		}
+
+		if strings.HasSuffix(obj.Get("kind").String(), "List") {
+			objects = append(objects, obj.Get("items").Array()...)
+			continue
+		}
+
+		objects = append(objects, obj)
+	}
+
+	return objects, nil
+}
+
+// convertYAML converts the maps decoded by yaml.v2 to maps with string keys
+// so they can be marshalled to JSON.
+func convertYAML(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			m[fmt.Sprintf("%v", k)] = convertYAML(val)
+		}
+		return m
+	case []interface{}:
+		for i, val := range t {
+			t[i] = convertYAML(val)
+		}
+		return t
+	default:
+		return v
+	}
+}
+
+var memorySuffixes = map[string]float64{
+	"Ki": math.Pow(2, 10),
+	"Mi": math.Pow(2, 20),
+	"Gi": math.Pow(2, 30),
+	"Ti": math.Pow(2, 40),
+	"Pi": math.Pow(2, 50),
+	"k":  1e3,
+	"K":  1e3,
+	"M":  1e6,
+	"G":  1e9,
+	"T":  1e12,
+	"P":  1e15,
+}
+
+// parseCPU parses a CPU quantity, e.g. 500m or 2, into a number of cores.
+func parseCPU(q string) (float64, error) {
+	q = strings.TrimSpace(q)
+	if strings.HasSuffix(q, "m") {
+		v, err := strconv.ParseFloat(strings.TrimSuffix(q, "m"), 64)
+		if err != nil {
+			return 0, fmt.Errorf("Invalid CPU quantity '%s'", q)
+		}
+		return v / 1000, nil
+	}
+
+	v, err := strconv.ParseFloat(q, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid CPU quantity '%s'", q)
+	}
+	return v, nil
+}
+
+// parseMemoryGiB parses a memory or storage quantity, e.g. 512Mi or 1G, into GiB.
+func parseMemoryGiB(quantity string) (float64, error) {
+	q := strings.TrimSpace(quantity)
+
+	multiplier := 1.0
+	for _, suffix := range []string{"Ki", "Mi", "Gi", "Ti", "Pi", "k", "K", "M", "G", "T", "P"} {
+		if strings.HasSuffix(q, suffix) {
+			multiplier = memorySuffixes[suffix]
+			q = strings.TrimSuffix(q, suffix)
+			break
+		}
+	}
+
+	v, err := strconv.ParseFloat(q, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid memory quantity '%s'", quantity)
+	}
+
+	return v * multiplier / math.Pow(2, 30), nil
+}
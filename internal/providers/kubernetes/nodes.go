@@ -0,0 +1,71 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/config"
+)
+
+type node struct {
+	InstanceType string
+	VCPU         float64
+	MemoryGiB    float64
+}
+
+// memoryPerVCPU is the GiB of memory per vCPU of the general purpose, compute
+// optimized and memory optimized EC2 instance families.
+var memoryPerVCPU = map[string]float64{
+	"m4": 4, "m5": 4, "m5a": 4, "m5n": 4, "m6i": 4, "m6a": 4, "m6g": 4, "m7g": 4,
+	"c4": 1.875, "c5": 2, "c5a": 2, "c5n": 2.625, "c6i": 2, "c6a": 2, "c6g": 2, "c7g": 2,
+	"r4": 7.625, "r5": 8, "r5a": 8, "r5n": 8, "r6i": 8, "r6a": 8, "r6g": 8, "r7g": 8,
+	"t3": 4, "t3a": 4, "t4g": 4,
+}
+
+// vCPUs of each instance size, the burstable sizes below large are listed separately
+// since they don't follow the same memory per vCPU.
+var sizeVCPUs = map[string]float64{
+	"large": 2, "xlarge": 4, "2xlarge": 8, "4xlarge": 16, "8xlarge": 32, "9xlarge": 36,
+	"12xlarge": 48, "16xlarge": 64, "18xlarge": 72, "24xlarge": 96, "32xlarge": 128,
+}
+
+var burstableNodes = map[string]node{
+	"nano":   {VCPU: 2, MemoryGiB: 0.5},
+	"micro":  {VCPU: 2, MemoryGiB: 1},
+	"small":  {VCPU: 2, MemoryGiB: 2},
+	"medium": {VCPU: 2, MemoryGiB: 4},
+}
+
+// nodeCapacity returns the vCPU and memory of the cluster's nodes. The
+// capacity from the config is used if it's set, otherwise it's looked up
+// from the instance type.
+func nodeCapacity(cluster config.KubernetesConfig) (node, error) {
+	n := node{
+		InstanceType: cluster.NodeInstanceType,
+		VCPU:         cluster.NodeVCPU,
+		MemoryGiB:    cluster.NodeMemoryGB,
+	}
+
+	if n.VCPU > 0 && n.MemoryGiB > 0 {
+		return n, nil
+	}
+
+	parts := strings.SplitN(cluster.NodeInstanceType, ".", 2)
+	if len(parts) == 2 {
+		family, size := parts[0], parts[1]
+
+		if b, ok := burstableNodes[size]; ok && strings.HasPrefix(family, "t") {
+			n.VCPU, n.MemoryGiB = b.VCPU, b.MemoryGiB
+			return n, nil
+		}
+
+		ratio, familyOK := memoryPerVCPU[family]
+		vcpu, sizeOK := sizeVCPUs[size]
+		if familyOK && sizeOK {
+			n.VCPU, n.MemoryGiB = vcpu, vcpu*ratio
+			return n, nil
+		}
+	}
+
+	return n, fmt.Errorf("Unknown Kubernetes node instance type %s, set the node_vcpu and node_memory_gb in the kubernetes config", cluster.NodeInstanceType)
+}
@@ -0,0 +1,204 @@
+// Package kubernetes estimates the costs of Kubernetes manifests and Helm
+// charts from the resource requests of their workloads, their persistent
+// volume claims and their load balancer services.
+package kubernetes
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultRegion           = "us-east-1"
+	defaultNodeInstanceType = "m5.large"
+	defaultHelmBinary       = "helm"
+	helmReleaseName         = "infracost"
+)
+
+type Provider struct {
+	env     *config.Environment
+	path    string
+	isHelm  bool
+	cluster config.KubernetesConfig
+}
+
+// NewManifestsProvider returns a provider for a file or directory of rendered Kubernetes manifests.
+func NewManifestsProvider(cfg *config.Config, projectCfg *config.Project) schema.Provider {
+	return newProvider(cfg, projectCfg, false)
+}
+
+// NewHelmProvider returns a provider for a Helm chart, which is rendered with helm template.
+func NewHelmProvider(cfg *config.Config, projectCfg *config.Project) schema.Provider {
+	return newProvider(cfg, projectCfg, true)
+}
+
+func newProvider(cfg *config.Config, projectCfg *config.Project, isHelm bool) *Provider {
+	cluster := config.KubernetesConfig{}
+	if projectCfg.Kubernetes != nil {
+		cluster = *projectCfg.Kubernetes
+	}
+
+	if cluster.Region == "" {
+		cluster.Region = defaultRegion
+	}
+
+	if cluster.NodeInstanceType == "" {
+		log.Warnf("No Kubernetes node_instance_type set for %s, using %s", projectCfg.Path, defaultNodeInstanceType)
+		cluster.NodeInstanceType = defaultNodeInstanceType
+	}
+
+	if cluster.NodeCount == 0 {
+		cluster.NodeCount = 1
+	}
+
+	if cluster.HelmBinary == "" {
+		cluster.HelmBinary = defaultHelmBinary
+	}
+
+	return &Provider{
+		env:     cfg.Environment,
+		path:    projectCfg.Path,
+		isHelm:  isHelm,
+		cluster: cluster,
+	}
+}
+
+func (p *Provider) Type() string {
+	if p.isHelm {
+		return "helm_chart"
+	}
+	return "kubernetes_manifests"
+}
+
+func (p *Provider) DisplayType() string {
+	if p.isHelm {
+		return "Helm chart"
+	}
+	return "Kubernetes manifests"
+}
+
+func (p *Provider) AddMetadata(metadata *schema.ProjectMetadata) {
+	// no op
+}
+
+func (p *Provider) LoadResources(project *schema.Project, usage map[string]*schema.UsageData) error {
+	var (
+		manifests []byte
+		err       error
+	)
+
+	if p.isHelm {
+		manifests, err = p.helmTemplate()
+	} else {
+		manifests, err = readManifests(p.path)
+	}
+	if err != nil {
+		return err
+	}
+
+	objects, err := parseObjects(manifests)
+	if err != nil {
+		return err
+	}
+
+	node, err := nodeCapacity(p.cluster)
+	if err != nil {
+		return err
+	}
+
+	project.HasDiff = false
+	project.Resources = append(project.Resources, newResources(p.env, objects, p.cluster, node, usage)...)
+
+	return nil
+}
+
+func (p *Provider) helmTemplate() ([]byte, error) {
+	args := []string{"template", helmReleaseName, p.path}
+	for _, f := range p.cluster.HelmValuesFiles {
+		args = append(args, "--values", f)
+	}
+
+	cmd := exec.Command(p.cluster.HelmBinary, args...)
+	log.Infof("Running command: %s", cmd.String())
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error running helm template: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	return out, nil
+}
+
+// IsHelmChart returns true if the path is the directory of a Helm chart.
+func IsHelmChart(path string) bool {
+	info, err := os.Stat(filepath.Join(path, "Chart.yaml"))
+	return err == nil && !info.IsDir()
+}
+
+// IsManifests returns true if the path is a YAML file of Kubernetes manifests or
+// a directory that contains them.
+func IsManifests(path string) bool {
+	b, err := readManifests(path)
+	if err != nil || len(b) == 0 {
+		return false
+	}
+
+	objects, err := parseObjects(b)
+	return err == nil && len(objects) > 0
+}
+
+// readManifests reads the YAML file, or all the YAML files in the directory,
+// as one multi-document YAML stream.
+func readManifests(path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		if !isYAMLFile(path) {
+			return nil, nil
+		}
+		return ioutil.ReadFile(path)
+	}
+
+	files, err := ioutil.ReadDir(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading Kubernetes manifests directory")
+	}
+
+	var buf bytes.Buffer
+	for _, f := range files {
+		if f.IsDir() || !isYAMLFile(f.Name()) {
+			continue
+		}
+
+		b, err := ioutil.ReadFile(filepath.Join(path, f.Name()))
+		if err != nil {
+			return nil, errors.Wrap(err, "Error reading Kubernetes manifest")
+		}
+
+		buf.WriteString("\n---\n")
+		buf.Write(b)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func isYAMLFile(name string) bool {
+	ext := filepath.Ext(name)
+	return ext == ".yaml" || ext == ".yml"
+}
@@ -0,0 +1,202 @@
+package kubernetes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuantities(t *testing.T) {
+	cpuTests := map[string]float64{
+		"500m": 0.5,
+		"2":    2,
+		"0.25": 0.25,
+	}
+	for q, expected := range cpuTests {
+		v, err := parseCPU(q)
+		require.NoError(t, err)
+		assert.InDelta(t, expected, v, 0.0001, q)
+	}
+
+	memTests := map[string]float64{
+		"1Gi":   1,
+		"512Mi": 0.5,
+		"1G":    1e9 / (1 << 30),
+	}
+	for q, expected := range memTests {
+		v, err := parseMemoryGiB(q)
+		require.NoError(t, err)
+		assert.InDelta(t, expected, v, 0.0001, q)
+	}
+
+	_, err := parseCPU("abc")
+	assert.Error(t, err)
+}
+
+func TestNodeCapacity(t *testing.T) {
+	n, err := nodeCapacity(config.KubernetesConfig{NodeInstanceType: "m5.xlarge"})
+	require.NoError(t, err)
+	assert.Equal(t, 4.0, n.VCPU)
+	assert.Equal(t, 16.0, n.MemoryGiB)
+
+	n, err = nodeCapacity(config.KubernetesConfig{NodeInstanceType: "t3.medium"})
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, n.VCPU)
+	assert.Equal(t, 4.0, n.MemoryGiB)
+
+	n, err = nodeCapacity(config.KubernetesConfig{NodeInstanceType: "x9.huge", NodeVCPU: 8, NodeMemoryGB: 64})
+	require.NoError(t, err)
+	assert.Equal(t, 8.0, n.VCPU)
+
+	_, err = nodeCapacity(config.KubernetesConfig{NodeInstanceType: "x9.huge"})
+	assert.Error(t, err)
+}
+
+func TestNewResources(t *testing.T) {
+	manifests := []byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+  namespace: prod
+  labels:
+    team: platform
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+        - name: api
+          resources:
+            requests:
+              cpu: 500m
+              memory: 1Gi
+        - name: sidecar
+          resources:
+            limits:
+              cpu: 500m
+              memory: 512Mi
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+        - name: db
+          resources:
+            requests:
+              memory: 8Gi
+  volumeClaimTemplates:
+    - metadata:
+        name: data
+      spec:
+        storageClassName: fast
+        resources:
+          requests:
+            storage: 9.5Gi
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  annotations:
+    service.beta.kubernetes.io/aws-load-balancer-type: nlb
+spec:
+  type: LoadBalancer
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: internal
+spec:
+  type: ClusterIP
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: report
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: idle
+spec:
+  containers:
+    - name: idle
+`)
+
+	objects, err := parseObjects(manifests)
+	require.NoError(t, err)
+
+	cluster := config.KubernetesConfig{
+		Region:           "us-east-1",
+		NodeInstanceType: "m5.xlarge",
+		NodeCount:        1,
+		StorageClasses:   map[string]string{"fast": "gp3"},
+	}
+	n, err := nodeCapacity(cluster)
+	require.NoError(t, err)
+
+	resources := newResources(config.NewEnvironment(), objects, cluster, n, nil)
+
+	names := make([]string, 0, len(resources))
+	for _, r := range resources {
+		names = append(names, r.Name)
+	}
+	assert.Equal(t, []string{
+		"Deployment/prod/api",
+		"StatefulSet/db",
+		"StatefulSet/db/data",
+		"Service/web",
+		"CronJob/report",
+		"Pod/idle",
+	}, names)
+
+	api := resources[0]
+	assert.Equal(t, "kubernetes_deployment", api.ResourceType)
+	assert.Equal(t, map[string]string{"team": "platform"}, api.Tags)
+	require.Len(t, api.CostComponents, 1)
+	// 1 vCPU of 4 is more than 1.5 GiB of 16, so 0.25 of a node per replica
+	assert.Equal(t, "0.75", api.CostComponents[0].HourlyQuantity.String())
+
+	db := resources[1]
+	// 8 GiB of 16 is half a node per replica
+	assert.Equal(t, "1", db.CostComponents[0].HourlyQuantity.String())
+
+	volume := resources[2]
+	assert.Equal(t, "kubernetes_persistentvolumeclaim", volume.ResourceType)
+	assert.NotEmpty(t, volume.CostComponents)
+
+	lb := resources[3]
+	assert.Equal(t, "kubernetes_service", lb.ResourceType)
+	assert.NotEmpty(t, lb.CostComponents)
+
+	assert.True(t, resources[4].IsSkipped)
+	assert.True(t, resources[5].NoPrice)
+}
+
+func TestIsManifests(t *testing.T) {
+	dir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(dir, "app.yaml"), []byte("apiVersion: v1\nkind: Service\nmetadata:\n  name: web\n"), 0600)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "values.yml"), []byte("replicas: 2\n"), 0600)
+	require.NoError(t, err)
+
+	assert.True(t, IsManifests(dir))
+	assert.True(t, IsManifests(filepath.Join(dir, "app.yaml")))
+	assert.False(t, IsManifests(filepath.Join(dir, "values.yml")))
+	assert.False(t, IsHelmChart(dir))
+
+	err = os.WriteFile(filepath.Join(dir, "Chart.yaml"), []byte("name: app\n"), 0600)
+	require.NoError(t, err)
+	assert.True(t, IsHelmChart(dir))
+}
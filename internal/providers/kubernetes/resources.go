@@ -0,0 +1,271 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	awsProviderName            = "registry.terraform.io/hashicorp/aws"
+	defaultVolumeType          = "gp2"
+	loadBalancerTypeAnnotation = "service.beta.kubernetes.io/aws-load-balancer-type"
+)
+
+// podSpecPaths are where the pod spec is in each kind of workload.
+var podSpecPaths = map[string]string{
+	"Pod":                   "spec",
+	"Deployment":            "spec.template.spec",
+	"StatefulSet":           "spec.template.spec",
+	"ReplicaSet":            "spec.template.spec",
+	"ReplicationController": "spec.template.spec",
+	"DaemonSet":             "spec.template.spec",
+}
+
+// usageBasedKinds run for an unknown amount of time, so they are skipped.
+var usageBasedKinds = map[string]bool{
+	"Job":     true,
+	"CronJob": true,
+}
+
+func newResources(env *config.Environment, objects []gjson.Result, cluster config.KubernetesConfig, n node, usage map[string]*schema.UsageData) []*schema.Resource {
+	resources := make([]*schema.Resource, 0)
+
+	for _, obj := range objects {
+		kind := obj.Get("kind").String()
+		name := objectName(obj)
+		tags := objectLabels(obj)
+
+		switch {
+		case podSpecPaths[kind] != "":
+			replicas := workloadReplicas(obj, cluster)
+			resources = append(resources, newWorkloadResource(obj, name, tags, replicas, cluster, n))
+
+			for _, claim := range obj.Get("spec.volumeClaimTemplates").Array() {
+				claimName := fmt.Sprintf("%s/%s", name, claim.Get("metadata.name").String())
+				if r := newVolumeResource(env, claim, claimName, tags, replicas, cluster, usage[claimName]); r != nil {
+					resources = append(resources, r)
+				}
+			}
+		case usageBasedKinds[kind]:
+			resources = append(resources, &schema.Resource{
+				Name:         name,
+				ResourceType: resourceType(kind),
+				Tags:         tags,
+				IsSkipped:    true,
+				SkipMessage:  "This resource runs for an unknown amount of time",
+			})
+		case kind == "PersistentVolumeClaim":
+			if r := newVolumeResource(env, obj, name, tags, 1, cluster, usage[name]); r != nil {
+				resources = append(resources, r)
+			}
+		case kind == "Service" && obj.Get("spec.type").String() == "LoadBalancer":
+			resources = append(resources, newLoadBalancerResource(env, obj, name, tags, cluster, usage[name]))
+		}
+	}
+
+	return resources
+}
+
+// objectName is the name of the object in the breakdown, e.g. Deployment/api
+// or Deployment/prod/api if it has a namespace.
+func objectName(obj gjson.Result) string {
+	parts := []string{obj.Get("kind").String()}
+	if ns := obj.Get("metadata.namespace").String(); ns != "" {
+		parts = append(parts, ns)
+	}
+	parts = append(parts, obj.Get("metadata.name").String())
+	return strings.Join(parts, "/")
+}
+
+// objectLabels are used as the tags of the resource so costs can be grouped by them.
+func objectLabels(obj gjson.Result) map[string]string {
+	labels := make(map[string]string)
+	for k, v := range obj.Get("metadata.labels").Map() {
+		labels[k] = v.String()
+	}
+	return labels
+}
+
+func resourceType(kind string) string {
+	return "kubernetes_" + strings.ToLower(kind)
+}
+
+func workloadReplicas(obj gjson.Result, cluster config.KubernetesConfig) int64 {
+	switch obj.Get("kind").String() {
+	case "Pod":
+		return 1
+	case "DaemonSet":
+		return int64(cluster.NodeCount)
+	}
+
+	if r := obj.Get("spec.replicas"); r.Exists() {
+		return r.Int()
+	}
+	return 1
+}
+
+// podRequests returns the total CPU cores and memory GiB requested by the
+// containers of the pod. Limits are used for containers without requests.
+func podRequests(spec gjson.Result) (float64, float64) {
+	var cpu, mem float64
+
+	for _, c := range spec.Get("containers").Array() {
+		cpuQ := c.Get("resources.requests.cpu")
+		if !cpuQ.Exists() {
+			cpuQ = c.Get("resources.limits.cpu")
+		}
+		if cpuQ.Exists() {
+			v, err := parseCPU(cpuQ.String())
+			if err != nil {
+				log.Warnf("%s in container %s", err, c.Get("name").String())
+			}
+			cpu += v
+		}
+
+		memQ := c.Get("resources.requests.memory")
+		if !memQ.Exists() {
+			memQ = c.Get("resources.limits.memory")
+		}
+		if memQ.Exists() {
+			v, err := parseMemoryGiB(memQ.String())
+			if err != nil {
+				log.Warnf("%s in container %s", err, c.Get("name").String())
+			}
+			mem += v
+		}
+	}
+
+	return cpu, mem
+}
+
+// newWorkloadResource prices the pods of the workload as a share of a node,
+// which is the larger of the share of the node's vCPUs and memory that the
+// pods request.
+func newWorkloadResource(obj gjson.Result, name string, tags map[string]string, replicas int64, cluster config.KubernetesConfig, n node) *schema.Resource {
+	kind := obj.Get("kind").String()
+	cpu, mem := podRequests(obj.Get(podSpecPaths[kind]))
+
+	r := &schema.Resource{
+		Name:         name,
+		ResourceType: resourceType(kind),
+		Tags:         tags,
+	}
+
+	if cpu == 0 && mem == 0 {
+		log.Warnf("%s has no resource requests so its cost can't be estimated", name)
+		r.NoPrice = true
+		return r
+	}
+
+	share := math.Max(cpu/n.VCPU, mem/n.MemoryGiB) * float64(replicas)
+
+	r.CostComponents = []*schema.CostComponent{
+		{
+			Name: fmt.Sprintf("Node share (%s, %d x %s vCPU, %s GiB)",
+				n.InstanceType, replicas, formatFloat(cpu), formatFloat(mem)),
+			Unit:           "hours",
+			UnitMultiplier: 1,
+			HourlyQuantity: decimalPtr(decimal.NewFromFloat(share)),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(cluster.Region),
+				Service:       strPtr("AmazonEC2"),
+				ProductFamily: strPtr("Compute Instance"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "instanceType", Value: strPtr(n.InstanceType)},
+					{Key: "tenancy", Value: strPtr("Shared")},
+					{Key: "operatingSystem", Value: strPtr("Linux")},
+					{Key: "preInstalledSw", Value: strPtr("NA")},
+					{Key: "licenseModel", Value: strPtr("No License required")},
+					{Key: "capacitystatus", Value: strPtr("Used")},
+				},
+			},
+			PriceFilter: &schema.PriceFilter{
+				PurchaseOption: strPtr("on_demand"),
+			},
+		},
+	}
+
+	return r
+}
+
+// newVolumeResource prices a persistent volume claim as an EBS volume of the
+// type that its storage class maps to.
+func newVolumeResource(env *config.Environment, claim gjson.Result, name string, tags map[string]string, replicas int64, cluster config.KubernetesConfig, u *schema.UsageData) *schema.Resource {
+	storage := claim.Get("spec.resources.requests.storage")
+	if !storage.Exists() {
+		log.Warnf("%s has no storage request so its cost can't be estimated", name)
+		return nil
+	}
+
+	size, err := parseMemoryGiB(storage.String())
+	if err != nil {
+		log.Warnf("%s in %s", err, name)
+		return nil
+	}
+
+	volumeType := defaultVolumeType
+	if t, ok := cluster.StorageClasses[claim.Get("spec.storageClassName").String()]; ok {
+		volumeType = t
+	}
+
+	r := newTerraformResource(env, "aws_ebs_volume", name, tags, map[string]interface{}{
+		"region": cluster.Region,
+		"type":   volumeType,
+		"size":   math.Ceil(size) * float64(replicas),
+	}, u)
+	r.ResourceType = resourceType("PersistentVolumeClaim")
+
+	return r
+}
+
+// newLoadBalancerResource prices a LoadBalancer service as the load balancer
+// that the AWS load balancer controller creates for it.
+func newLoadBalancerResource(env *config.Environment, svc gjson.Result, name string, tags map[string]string, cluster config.KubernetesConfig, u *schema.UsageData) *schema.Resource {
+	var r *schema.Resource
+
+	lbType := svc.Get("metadata.annotations").Map()[loadBalancerTypeAnnotation].String()
+	if lbType == "nlb" || lbType == "external" {
+		r = newTerraformResource(env, "aws_lb", name, tags, map[string]interface{}{
+			"region":             cluster.Region,
+			"load_balancer_type": "network",
+		}, u)
+	} else {
+		r = newTerraformResource(env, "aws_elb", name, tags, map[string]interface{}{
+			"region": cluster.Region,
+		}, u)
+	}
+
+	r.ResourceType = resourceType("Service")
+
+	return r
+}
+
+func newTerraformResource(env *config.Environment, resourceType string, name string, tags map[string]string, values map[string]interface{}, u *schema.UsageData) *schema.Resource {
+	j, _ := json.Marshal(values)
+	d := schema.NewResourceData(resourceType, awsProviderName, name, tags, gjson.ParseBytes(j))
+	return terraform.NewResource(env, d, u)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -19,19 +19,14 @@ func GetAutoscalingGroupRegistryItem() *schema.RegistryItem {
 			"launch_template.0.id",
 			"launch_template.0.name",
 			"mixed_instances_policy.0.launch_template.0.launch_template_specification.0.launch_template_id",
+			"mixed_instances_policy.0.launch_template.0.launch_template_specification.0.launch_template_name",
 		},
 	}
 }
 
 func NewAutoscalingGroup(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 	region := d.Get("region").String()
-	desiredCapacity := decimal.NewFromInt(d.Get("desired_capacity").Int())
-	if u != nil && u.Get("instances").Exists() {
-		if desiredCapacity.GreaterThan(decimal.Zero) {
-			log.Debugf("Overriding the desired_capacity for %s by usage data", d.Address)
-		}
-		desiredCapacity = decimal.NewFromInt(u.Get("instances").Int())
-	}
+	desiredCapacity := autoscalingGroupCapacity(d, u)
 
 	subResources := make([]*schema.Resource, 0)
 
@@ -45,6 +40,9 @@ func NewAutoscalingGroup(d *schema.ResourceData, u *schema.UsageData) *schema.Re
 		launchTemplateRef = launchTemplateRefName
 	}
 	mixedInstanceLaunchTemplateRef := d.References("mixed_instances_policy.0.launch_template.0.launch_template_specification.0.launch_template_id")
+	if len(mixedInstanceLaunchTemplateRef) == 0 {
+		mixedInstanceLaunchTemplateRef = d.References("mixed_instances_policy.0.launch_template.0.launch_template_specification.0.launch_template_name")
+	}
 
 	if len(launchConfigurationRef) > 0 {
 		lc := newLaunchConfiguration(launchConfigurationRef[0].Address, launchConfigurationRef[0], u, region)
@@ -86,6 +84,25 @@ func NewAutoscalingGroup(d *schema.ResourceData, u *schema.UsageData) *schema.Re
 	}
 }
 
+// autoscalingGroupCapacity returns the number of instances to price. The
+// instances usage is the average number of instances, otherwise the desired
+// capacity is used, which AWS sets to the min size if it's not set.
+func autoscalingGroupCapacity(d *schema.ResourceData, u *schema.UsageData) decimal.Decimal {
+	desiredCapacity := decimal.NewFromInt(d.Get("min_size").Int())
+	if d.Get("desired_capacity").Exists() && d.Get("desired_capacity").Type != gjson.Null {
+		desiredCapacity = decimal.NewFromInt(d.Get("desired_capacity").Int())
+	}
+
+	if u != nil && u.Get("instances").Exists() {
+		if desiredCapacity.GreaterThan(decimal.Zero) {
+			log.Debugf("Overriding the desired_capacity for %s by usage data", d.Address)
+		}
+		return decimal.NewFromFloat(u.Get("instances").Float())
+	}
+
+	return desiredCapacity
+}
+
 func newLaunchConfiguration(name string, d *schema.ResourceData, u *schema.UsageData, region string) *schema.Resource {
 	tenancy := "Shared"
 	if d.Get("placement_tenancy").String() == "host" {
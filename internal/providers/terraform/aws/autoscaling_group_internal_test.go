@@ -0,0 +1,35 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestAutoscalingGroupCapacity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		values   string
+		usage    *schema.UsageData
+		expected string
+	}{
+		{"desired capacity", `{"desired_capacity": 3, "min_size": 1, "max_size": 5}`, nil, "3"},
+		{"min size if desired capacity isn't set", `{"min_size": 2, "max_size": 5}`, nil, "2"},
+		{"min size if desired capacity is null", `{"desired_capacity": null, "min_size": 2, "max_size": 5}`, nil, "2"},
+		{
+			"average instances from usage",
+			`{"desired_capacity": 3, "min_size": 1, "max_size": 10}`,
+			&schema.UsageData{Attributes: map[string]gjson.Result{"instances": gjson.Parse("4.5")}},
+			"4.5",
+		},
+	}
+
+	for _, test := range tests {
+		d := schema.NewResourceData("aws_autoscaling_group", "aws", "aws_autoscaling_group.asg", nil, gjson.Parse(test.values))
+		assert.Equal(t, test.expected, autoscalingGroupCapacity(d, test.usage).String(), test.name)
+	}
+}
@@ -161,6 +161,30 @@ func NewDBInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource
 		})
 	}
 
+	backupRetention := decimal.NewFromInt(d.Get("backup_retention_period").Int())
+	if backupRetention.GreaterThan(decimal.NewFromInt(1)) {
+		var totalBackupStorageGB *decimal.Decimal
+		if u != nil && u.Get("backup_snapshot_size_gb").Exists() {
+			totalBackupStorageGB = decimalPtr(calculateBackupStorage(decimal.NewFromFloat(u.Get("backup_snapshot_size_gb").Float()), backupRetention))
+		}
+
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            "Backup storage",
+			Unit:            "GB",
+			UnitMultiplier:  1,
+			MonthlyQuantity: totalBackupStorageGB,
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(region),
+				Service:       strPtr("AmazonRDS"),
+				ProductFamily: strPtr("Storage Snapshot"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: strPtr("/RDS:ChargedBackupUsage$/")},
+				},
+			},
+		})
+	}
+
 	return &schema.Resource{
 		Name:           d.Address,
 		CostComponents: costComponents,
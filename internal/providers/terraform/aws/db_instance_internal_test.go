@@ -0,0 +1,46 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestDBInstanceBackupStorage(t *testing.T) {
+	d := schema.NewResourceData("aws_db_instance", "aws", "aws_db_instance.db", nil, gjson.Parse(`{
+		"region": "us-east-1",
+		"engine": "mysql",
+		"instance_class": "db.t3.large",
+		"allocated_storage": 100,
+		"backup_retention_period": 7
+	}`))
+	u := &schema.UsageData{
+		Attributes: map[string]gjson.Result{
+			"backup_snapshot_size_gb": gjson.Parse(`100`),
+		},
+	}
+
+	r := NewDBInstance(d, u)
+	require.Len(t, r.CostComponents, 3)
+
+	backup := r.CostComponents[2]
+	assert.Equal(t, "Backup storage", backup.Name)
+	assert.True(t, decimal.NewFromInt(600).Equal(*backup.MonthlyQuantity))
+}
+
+func TestDBInstanceWithoutBackupRetention(t *testing.T) {
+	d := schema.NewResourceData("aws_db_instance", "aws", "aws_db_instance.db", nil, gjson.Parse(`{
+		"region": "us-east-1",
+		"engine": "mysql",
+		"instance_class": "db.t3.large",
+		"backup_retention_period": 1
+	}`))
+
+	r := NewDBInstance(d, nil)
+	assert.Len(t, r.CostComponents, 2)
+}
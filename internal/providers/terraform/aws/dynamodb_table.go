@@ -19,7 +19,11 @@ func GetDynamoDBTableRegistryItem() *schema.RegistryItem {
 func NewDynamoDBTable(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 	region := d.Get("region").String()
 
+	// Terraform defaults to provisioned capacity if the billing mode isn't set
 	billingMode := d.Get("billing_mode").String()
+	if billingMode == "" {
+		billingMode = "PROVISIONED"
+	}
 
 	var readCapacity int64
 	if d.Get("read_capacity").Exists() {
@@ -38,13 +42,23 @@ func NewDynamoDBTable(d *schema.ResourceData, u *schema.UsageData) *schema.Resou
 		}
 	}
 
+	globalSecondaryIndexes := []aws.DynamoDbGlobalSecondaryIndex{}
+	for _, data := range d.Get("global_secondary_index").Array() {
+		globalSecondaryIndexes = append(globalSecondaryIndexes, aws.DynamoDbGlobalSecondaryIndex{
+			Name:          data.Get("name").String(),
+			WriteCapacity: data.Get("write_capacity").Int(),
+			ReadCapacity:  data.Get("read_capacity").Int(),
+		})
+	}
+
 	args := &aws.DynamoDbTableArguments{
-		Address:        d.Address,
-		Region:         region,
-		BillingMode:    billingMode,
-		WriteCapacity:  writeCapacity,
-		ReadCapacity:   readCapacity,
-		ReplicaRegions: replicaRegions,
+		Address:                d.Address,
+		Region:                 region,
+		BillingMode:            billingMode,
+		WriteCapacity:          writeCapacity,
+		ReadCapacity:           readCapacity,
+		ReplicaRegions:         replicaRegions,
+		GlobalSecondaryIndexes: globalSecondaryIndexes,
 	}
 	args.PopulateUsage(u)
 
@@ -19,8 +19,8 @@ func GetECSServiceRegistryItem() *schema.RegistryItem {
 }
 
 func NewECSService(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
-	launchType := d.Get("launch_type").String()
-	if launchType != "FARGATE" {
+	onDemandCount, spotCount, ok := fargateTaskCounts(d)
+	if !ok {
 		return &schema.Resource{
 			Name:      d.Address,
 			IsSkipped: true,
@@ -29,9 +29,11 @@ func NewECSService(d *schema.ResourceData, u *schema.UsageData) *schema.Resource
 	}
 
 	region := d.Get("region").String()
-	desiredCount := int64(0)
-	if d.Get("desired_count").Exists() {
-		desiredCount = d.Get("desired_count").Int()
+
+	// Task hours default to the tasks running all month
+	taskHours := decimal.NewFromInt(730)
+	if u != nil && u.Get("monthly_task_hrs").Exists() {
+		taskHours = decimal.NewFromFloat(u.Get("monthly_task_hrs").Float())
 	}
 
 	var taskDefinition *schema.ResourceData
@@ -41,67 +43,152 @@ func NewECSService(d *schema.ResourceData, u *schema.UsageData) *schema.Resource
 	}
 	memory := decimal.Zero
 	cpu := decimal.Zero
+	isARM := false
 	if taskDefinition != nil {
 		memory = convertResourceString(taskDefinition.Get("memory").String())
 		cpu = convertResourceString(taskDefinition.Get("cpu").String())
+		isARM = strings.EqualFold(taskDefinition.Get("runtime_platform.0.cpu_architecture").String(), "ARM64")
 	}
 
-	costComponents := []*schema.CostComponent{
-		{
-			Name:           "Per GB per hour",
-			Unit:           "GB",
-			UnitMultiplier: schema.HourToMonthUnitMultiplier,
-			HourlyQuantity: decimalPtr(decimal.NewFromInt(desiredCount).Mul(memory)),
+	costComponents := make([]*schema.CostComponent, 0)
+
+	if onDemandCount.IsPositive() || !spotCount.IsPositive() {
+		costComponents = append(costComponents, fargateCostComponents(region, onDemandCount.Mul(taskHours), memory, cpu, isARM, false)...)
+	}
+	if spotCount.IsPositive() {
+		costComponents = append(costComponents, fargateCostComponents(region, spotCount.Mul(taskHours), memory, cpu, isARM, true)...)
+	}
+
+	if taskDefinition != nil && taskDefinition.Get("inference_accelerator.0").Exists() {
+		deviceType := taskDefinition.Get("inference_accelerator.0.device_type").String()
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            fmt.Sprintf("Inference accelerator (%s)", deviceType),
+			Unit:            "hours",
+			UnitMultiplier:  1,
+			MonthlyQuantity: decimalPtr(onDemandCount.Add(spotCount).Mul(taskHours)),
 			ProductFilter: &schema.ProductFilter{
 				VendorName:    strPtr("aws"),
 				Region:        strPtr(region),
-				Service:       strPtr("AmazonECS"),
-				ProductFamily: strPtr("Compute"),
+				Service:       strPtr("AmazonEI"),
+				ProductFamily: strPtr("Elastic Inference"),
 				AttributeFilters: []*schema.AttributeFilter{
-					{Key: "usagetype", ValueRegex: strPtr("/Fargate-GB-Hours/")},
+					{Key: "usagetype", ValueRegex: strPtr(fmt.Sprintf("/%s/", deviceType))},
 				},
 			},
-		},
+		})
+	}
+
+	return &schema.Resource{
+		Name:           d.Address,
+		CostComponents: costComponents,
+	}
+}
+
+// fargateCostComponents returns the memory and vCPU cost components of the
+// Fargate tasks, taskHours is the number of tasks multiplied by the hours
+// they run for.
+func fargateCostComponents(region string, taskHours decimal.Decimal, memory decimal.Decimal, cpu decimal.Decimal, isARM bool, isSpot bool) []*schema.CostComponent {
+	usageTypePrefix := "Fargate-"
+	if isSpot {
+		usageTypePrefix = "SpotUsage-Fargate-"
+	}
+	if isARM {
+		usageTypePrefix += "ARM-"
+	}
+
+	labels := make([]string, 0, 2)
+	if isARM {
+		labels = append(labels, "ARM")
+	}
+	if isSpot {
+		labels = append(labels, "spot")
+	}
+	nameSuffix := ""
+	if len(labels) > 0 {
+		nameSuffix = fmt.Sprintf(" (%s)", strings.Join(labels, ", "))
+	}
+
+	return []*schema.CostComponent{
 		{
-			Name:           "Per vCPU per hour",
-			Unit:           "CPU",
-			UnitMultiplier: schema.HourToMonthUnitMultiplier,
-			HourlyQuantity: decimalPtr(decimal.NewFromInt(desiredCount).Mul(cpu)),
+			Name:            "Per GB per hour" + nameSuffix,
+			Unit:            "GB",
+			UnitMultiplier:  schema.HourToMonthUnitMultiplier,
+			MonthlyQuantity: decimalPtr(taskHours.Mul(memory)),
 			ProductFilter: &schema.ProductFilter{
 				VendorName:    strPtr("aws"),
 				Region:        strPtr(region),
 				Service:       strPtr("AmazonECS"),
 				ProductFamily: strPtr("Compute"),
 				AttributeFilters: []*schema.AttributeFilter{
-					{Key: "usagetype", ValueRegex: strPtr("/Fargate-vCPU-Hours:perCPU/")},
+					{Key: "usagetype", ValueRegex: strPtr(fmt.Sprintf("/^([A-Z0-9]+-)?%sGB-Hours$/", usageTypePrefix))},
 				},
 			},
 		},
-	}
-
-	if taskDefinition != nil && taskDefinition.Get("inference_accelerator.0").Exists() {
-		deviceType := taskDefinition.Get("inference_accelerator.0.device_type").String()
-		costComponents = append(costComponents, &schema.CostComponent{
-			Name:           fmt.Sprintf("Inference accelerator (%s)", deviceType),
-			Unit:           "hours",
-			UnitMultiplier: 1,
-			HourlyQuantity: decimalPtr(decimal.NewFromInt(desiredCount)),
+		{
+			Name:            "Per vCPU per hour" + nameSuffix,
+			Unit:            "CPU",
+			UnitMultiplier:  schema.HourToMonthUnitMultiplier,
+			MonthlyQuantity: decimalPtr(taskHours.Mul(cpu)),
 			ProductFilter: &schema.ProductFilter{
 				VendorName:    strPtr("aws"),
 				Region:        strPtr(region),
-				Service:       strPtr("AmazonEI"),
-				ProductFamily: strPtr("Elastic Inference"),
+				Service:       strPtr("AmazonECS"),
+				ProductFamily: strPtr("Compute"),
 				AttributeFilters: []*schema.AttributeFilter{
-					{Key: "usagetype", ValueRegex: strPtr(fmt.Sprintf("/%s/", deviceType))},
+					{Key: "usagetype", ValueRegex: strPtr(fmt.Sprintf("/^([A-Z0-9]+-)?%svCPU-Hours:perCPU$/", usageTypePrefix))},
 				},
 			},
-		})
+		},
 	}
+}
 
-	return &schema.Resource{
-		Name:           d.Address,
-		CostComponents: costComponents,
+// fargateTaskCounts splits the desired count of the service between the
+// FARGATE and FARGATE_SPOT capacity providers. The base of each provider is
+// placed first, then the rest of the tasks are split by the weights. If the
+// service doesn't run on Fargate it returns false.
+func fargateTaskCounts(d *schema.ResourceData) (decimal.Decimal, decimal.Decimal, bool) {
+	desiredCount := decimal.Zero
+	if d.Get("desired_count").Exists() {
+		desiredCount = decimal.NewFromInt(d.Get("desired_count").Int())
+	}
+
+	if d.Get("launch_type").String() == "FARGATE" {
+		return desiredCount, decimal.Zero, true
+	}
+
+	strategies := d.Get("capacity_provider_strategy").Array()
+	if len(strategies) == 0 {
+		return decimal.Zero, decimal.Zero, false
+	}
+
+	counts := map[string]decimal.Decimal{}
+	weights := map[string]decimal.Decimal{}
+	totalWeight := decimal.Zero
+	remaining := desiredCount
+
+	for _, s := range strategies {
+		provider := s.Get("capacity_provider").String()
+		if provider != "FARGATE" && provider != "FARGATE_SPOT" {
+			// Mixing Fargate and EC2 capacity providers isn't allowed
+			return decimal.Zero, decimal.Zero, false
+		}
+
+		base := decimal.Min(decimal.NewFromInt(s.Get("base").Int()), remaining)
+		counts[provider] = counts[provider].Add(base)
+		remaining = remaining.Sub(base)
+
+		weight := decimal.NewFromInt(s.Get("weight").Int())
+		weights[provider] = weights[provider].Add(weight)
+		totalWeight = totalWeight.Add(weight)
 	}
+
+	if remaining.IsPositive() && totalWeight.IsPositive() {
+		for provider, weight := range weights {
+			counts[provider] = counts[provider].Add(remaining.Mul(weight).Div(totalWeight))
+		}
+	}
+
+	return counts["FARGATE"], counts["FARGATE_SPOT"], true
 }
 
 func convertResourceString(rawValue string) decimal.Decimal {
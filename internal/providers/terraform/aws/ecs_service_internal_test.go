@@ -3,8 +3,10 @@ package aws
 import (
 	"testing"
 
+	"github.com/infracost/infracost/internal/schema"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
 )
 
 func TestConvertResourceString(t *testing.T) {
@@ -36,3 +38,85 @@ func TestConvertResourceString(t *testing.T) {
 		assert.Equal(t, test.expected.String(), actual.String())
 	}
 }
+
+func TestFargateTaskCounts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		values   string
+		onDemand string
+		spot     string
+		ok       bool
+	}{
+		{"launch type", `{"launch_type": "FARGATE", "desired_count": 4}`, "4", "0", true},
+		{"EC2 launch type", `{"launch_type": "EC2", "desired_count": 4}`, "0", "0", false},
+		{"no launch type", `{"desired_count": 4}`, "0", "0", false},
+		{
+			"spot only",
+			`{"desired_count": 4, "capacity_provider_strategy": [{"capacity_provider": "FARGATE_SPOT", "weight": 1}]}`,
+			"0", "4", true,
+		},
+		{
+			"base and weights",
+			`{"desired_count": 10, "capacity_provider_strategy": [
+				{"capacity_provider": "FARGATE", "base": 2, "weight": 1},
+				{"capacity_provider": "FARGATE_SPOT", "weight": 3}
+			]}`,
+			"4", "6", true,
+		},
+		{
+			"EC2 capacity provider",
+			`{"desired_count": 4, "capacity_provider_strategy": [{"capacity_provider": "my-asg", "weight": 1}]}`,
+			"0", "0", false,
+		},
+	}
+
+	for _, test := range tests {
+		d := schema.NewResourceData("aws_ecs_service", "aws", "aws_ecs_service.svc", nil, gjson.Parse(test.values))
+
+		onDemand, spot, ok := fargateTaskCounts(d)
+		assert.Equal(t, test.ok, ok, test.name)
+		assert.Equal(t, test.onDemand, onDemand.String(), test.name)
+		assert.Equal(t, test.spot, spot.String(), test.name)
+	}
+}
+
+func TestNewECSServiceSpotAndARM(t *testing.T) {
+	t.Parallel()
+
+	taskDefinition := schema.NewResourceData("aws_ecs_task_definition", "aws", "aws_ecs_task_definition.task", nil, gjson.Parse(`{
+		"cpu": "1024",
+		"memory": "2048",
+		"runtime_platform": [{"cpu_architecture": "ARM64", "operating_system_family": "LINUX"}]
+	}`))
+	d := schema.NewResourceData("aws_ecs_service", "aws", "aws_ecs_service.svc", nil, gjson.Parse(`{
+		"region": "us-east-1",
+		"desired_count": 2,
+		"capacity_provider_strategy": [
+			{"capacity_provider": "FARGATE", "weight": 1},
+			{"capacity_provider": "FARGATE_SPOT", "weight": 1}
+		]
+	}`))
+	d.AddReference("task_definition", taskDefinition)
+
+	u := &schema.UsageData{Attributes: map[string]gjson.Result{"monthly_task_hrs": gjson.Parse("100")}}
+
+	r := NewECSService(d, u)
+
+	names := make([]string, 0, len(r.CostComponents))
+	for _, c := range r.CostComponents {
+		names = append(names, c.Name)
+	}
+	assert.Equal(t, []string{
+		"Per GB per hour (ARM)",
+		"Per vCPU per hour (ARM)",
+		"Per GB per hour (ARM, spot)",
+		"Per vCPU per hour (ARM, spot)",
+	}, names)
+
+	// 1 task for 100 hours with 2 GB and 1 vCPU
+	assert.Equal(t, "200", r.CostComponents[0].MonthlyQuantity.String())
+	assert.Equal(t, "100", r.CostComponents[1].MonthlyQuantity.String())
+	assert.Equal(t, "/^([A-Z0-9]+-)?SpotUsage-Fargate-ARM-vCPU-Hours:perCPU$/", *r.CostComponents[3].ProductFilter.AttributeFilters[0].ValueRegex)
+}
@@ -0,0 +1,33 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetKinesisStreamRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws_kinesis_stream",
+		RFunc: NewKinesisStream,
+	}
+}
+
+func NewKinesisStream(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := d.Get("region").String()
+
+	retentionHours := int64(24)
+	if d.Get("retention_period").Exists() {
+		retentionHours = d.Get("retention_period").Int()
+	}
+
+	args := &aws.KinesisStreamArguments{
+		Address:        d.Address,
+		Region:         region,
+		StreamMode:     d.Get("stream_mode_details.0.stream_mode").String(),
+		ShardCount:     d.Get("shard_count").Int(),
+		RetentionHours: retentionHours,
+	}
+	args.PopulateUsage(u)
+
+	return aws.NewKinesisStream(args)
+}
@@ -0,0 +1,51 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestNewKinesisStream(t *testing.T) {
+	t.Parallel()
+
+	componentNames := func(r *schema.Resource) []string {
+		names := make([]string, 0, len(r.CostComponents))
+		for _, c := range r.CostComponents {
+			names = append(names, c.Name)
+		}
+		return names
+	}
+
+	d := schema.NewResourceData("aws_kinesis_stream", "aws", "aws_kinesis_stream.provisioned", nil, gjson.Parse(`{
+		"region": "us-east-1",
+		"shard_count": 4,
+		"retention_period": 48
+	}`))
+	u := &schema.UsageData{Attributes: map[string]gjson.Result{"monthly_put_payload_units": gjson.Parse("5000000")}}
+
+	r := NewKinesisStream(d, u)
+	assert.Equal(t, []string{"Shard hours", "PUT payload units", "Extended retention"}, componentNames(r))
+	assert.Equal(t, "4", r.CostComponents[0].HourlyQuantity.String())
+	assert.Equal(t, "5000000", r.CostComponents[1].MonthlyQuantity.String())
+
+	d = schema.NewResourceData("aws_kinesis_stream", "aws", "aws_kinesis_stream.default_retention", nil, gjson.Parse(`{
+		"region": "us-east-1",
+		"shard_count": 1
+	}`))
+	r = NewKinesisStream(d, nil)
+	assert.Equal(t, []string{"Shard hours", "PUT payload units"}, componentNames(r))
+	assert.Nil(t, r.CostComponents[1].MonthlyQuantity)
+
+	d = schema.NewResourceData("aws_kinesis_stream", "aws", "aws_kinesis_stream.on_demand", nil, gjson.Parse(`{
+		"region": "us-east-1",
+		"stream_mode_details": [{"stream_mode": "ON_DEMAND"}]
+	}`))
+	u = &schema.UsageData{Attributes: map[string]gjson.Result{"monthly_data_ingested_gb": gjson.Parse("100")}}
+
+	r = NewKinesisStream(d, u)
+	assert.Equal(t, []string{"Stream (on-demand)", "Data ingested (on-demand)", "Data retrieved (on-demand)"}, componentNames(r))
+	assert.Equal(t, "100", r.CostComponents[1].MonthlyQuantity.String())
+}
@@ -21,7 +21,7 @@ func NewKMSKey(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 		CustomerMasterKeyCostComponent(region),
 	}
 
-	costComponents = appendRequestComponentsForSpec(costComponents, spec, region)
+	costComponents = appendRequestComponentsForSpec(costComponents, spec, region, u)
 
 	return &schema.Resource{
 		Name:           d.Address,
@@ -47,11 +47,12 @@ func CustomerMasterKeyCostComponent(region string) *schema.CostComponent {
 	}
 }
 
-func appendRequestComponentsForSpec(costComponents []*schema.CostComponent, spec string, region string) []*schema.CostComponent {
+func appendRequestComponentsForSpec(costComponents []*schema.CostComponent, spec string, region string, u *schema.UsageData) []*schema.CostComponent {
+	monthlyRequests := kmsRequestsUsage(u, "monthly_requests")
 
 	switch spec {
 	case "RSA_2048":
-		costComponents = append(costComponents, requestPriceComponent("Requests (RSA 2048)", region, "/KMS-Requests-Asymmetric-RSA_2048/"))
+		costComponents = append(costComponents, requestPriceComponent("Requests (RSA 2048)", region, "/KMS-Requests-Asymmetric-RSA_2048/", monthlyRequests))
 		return costComponents
 	case
 		"RSA_3072",
@@ -60,21 +61,30 @@ func appendRequestComponentsForSpec(costComponents []*schema.CostComponent, spec
 		"ECC_NIST_P384",
 		"ECC_NIST_P521",
 		"ECC_SECG_P256K1":
-		costComponents = append(costComponents, requestPriceComponent("Requests (asymmetric)", region, "/KMS-Requests-Asymmetric$/"))
+		costComponents = append(costComponents, requestPriceComponent("Requests (asymmetric)", region, "/KMS-Requests-Asymmetric$/", monthlyRequests))
 		return costComponents
 	}
 
-	costComponents = append(costComponents, requestPriceComponent("Requests", region, "/KMS-Requests$/"))
-	costComponents = append(costComponents, requestPriceComponent("ECC GenerateDataKeyPair requests", region, "/KMS-Requests-GenerateDatakeyPair-ECC/"))
-	costComponents = append(costComponents, requestPriceComponent("RSA GenerateDataKeyPair requests", region, "/KMS-Requests-GenerateDatakeyPair-ECC/"))
+	costComponents = append(costComponents, requestPriceComponent("Requests", region, "/KMS-Requests$/", monthlyRequests))
+	costComponents = append(costComponents, requestPriceComponent("ECC GenerateDataKeyPair requests", region, "/KMS-Requests-GenerateDatakeyPair-ECC/", kmsRequestsUsage(u, "monthly_ecc_generate_data_key_pair_requests")))
+	costComponents = append(costComponents, requestPriceComponent("RSA GenerateDataKeyPair requests", region, "/KMS-Requests-GenerateDatakeyPair-RSA/", kmsRequestsUsage(u, "monthly_rsa_generate_data_key_pair_requests")))
 	return costComponents
 }
 
-func requestPriceComponent(name string, region string, usagetype string) *schema.CostComponent {
+func kmsRequestsUsage(u *schema.UsageData, key string) *decimal.Decimal {
+	if u != nil && u.Get(key).Exists() {
+		return decimalPtr(decimal.NewFromInt(u.Get(key).Int()))
+	}
+
+	return nil
+}
+
+func requestPriceComponent(name string, region string, usagetype string, monthlyRequests *decimal.Decimal) *schema.CostComponent {
 	return &schema.CostComponent{
-		Name:           name,
-		Unit:           "10k requests",
-		UnitMultiplier: 10000,
+		Name:            name,
+		Unit:            "10k requests",
+		UnitMultiplier:  10000,
+		MonthlyQuantity: monthlyRequests,
 		ProductFilter: &schema.ProductFilter{
 			VendorName: strPtr("aws"),
 			Region:     strPtr(region),
@@ -0,0 +1,50 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestKMSKeyRequestUsage(t *testing.T) {
+	d := schema.NewResourceData("aws_kms_key", "aws", "aws_kms_key.key", nil, gjson.Parse(`{"region": "us-east-1"}`))
+	u := &schema.UsageData{
+		Attributes: map[string]gjson.Result{
+			"monthly_requests": gjson.Parse(`2000000`),
+			"monthly_rsa_generate_data_key_pair_requests": gjson.Parse(`5000`),
+		},
+	}
+
+	r := NewKMSKey(d, u)
+	require.Len(t, r.CostComponents, 4)
+
+	assert.Equal(t, "Requests", r.CostComponents[1].Name)
+	assert.True(t, decimal.NewFromInt(2000000).Equal(*r.CostComponents[1].MonthlyQuantity))
+
+	assert.Equal(t, "ECC GenerateDataKeyPair requests", r.CostComponents[2].Name)
+	assert.Nil(t, r.CostComponents[2].MonthlyQuantity)
+
+	assert.Equal(t, "RSA GenerateDataKeyPair requests", r.CostComponents[3].Name)
+	assert.Equal(t, "/KMS-Requests-GenerateDatakeyPair-RSA/", *r.CostComponents[3].ProductFilter.AttributeFilters[0].ValueRegex)
+	assert.True(t, decimal.NewFromInt(5000).Equal(*r.CostComponents[3].MonthlyQuantity))
+}
+
+func TestKMSKeyAsymmetricRequestUsage(t *testing.T) {
+	d := schema.NewResourceData("aws_kms_key", "aws", "aws_kms_key.key", nil, gjson.Parse(`{"region": "us-east-1", "customer_master_key_spec": "ECC_NIST_P256"}`))
+	u := &schema.UsageData{
+		Attributes: map[string]gjson.Result{
+			"monthly_requests": gjson.Parse(`30000`),
+		},
+	}
+
+	r := NewKMSKey(d, u)
+	require.Len(t, r.CostComponents, 2)
+
+	assert.Equal(t, "Requests (asymmetric)", r.CostComponents[1].Name)
+	assert.True(t, decimal.NewFromInt(30000).Equal(*r.CostComponents[1].MonthlyQuantity))
+}
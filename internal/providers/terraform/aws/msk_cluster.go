@@ -19,42 +19,79 @@ func NewMskCluster(d *schema.ResourceData, u *schema.UsageData) *schema.Resource
 
 	brokerNodes := decimal.NewFromInt(d.Get("number_of_broker_nodes").Int())
 	instanceType := d.Get("broker_node_group_info.0.instance_type").String()
-	ebsVolumeSize := decimal.NewFromInt(d.Get("broker_node_group_info.0.ebs_volume_size").Int()).Mul(brokerNodes)
 
-	return &schema.Resource{
-		Name: d.Address,
-		CostComponents: []*schema.CostComponent{
-			{
-				Name:           fmt.Sprintf("Instance (%s)", instanceType),
-				Unit:           "hours",
-				UnitMultiplier: 1,
-				HourlyQuantity: &brokerNodes,
-				ProductFilter: &schema.ProductFilter{
-					VendorName:    strPtr("aws"),
-					Region:        strPtr(region),
-					Service:       strPtr("AmazonMSK"),
-					ProductFamily: strPtr("Managed Streaming for Apache Kafka (MSK)"),
-					AttributeFilters: []*schema.AttributeFilter{
-						{Key: "usagetype", ValueRegex: strPtr(fmt.Sprintf("/%s/i", instanceType))},
-						{Key: "locationType", Value: strPtr("AWS Region")},
-					},
+	// ebs_volume_size was replaced by storage_info in v4 of the AWS provider
+	volumeSize := d.Get("broker_node_group_info.0.ebs_volume_size")
+	if !volumeSize.Exists() {
+		volumeSize = d.Get("broker_node_group_info.0.storage_info.0.ebs_storage_info.0.volume_size")
+	}
+	ebsVolumeSize := decimal.NewFromInt(volumeSize.Int()).Mul(brokerNodes)
+
+	costComponents := []*schema.CostComponent{
+		{
+			Name:           fmt.Sprintf("Instance (%s)", instanceType),
+			Unit:           "hours",
+			UnitMultiplier: 1,
+			HourlyQuantity: &brokerNodes,
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(region),
+				Service:       strPtr("AmazonMSK"),
+				ProductFamily: strPtr("Managed Streaming for Apache Kafka (MSK)"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: strPtr(fmt.Sprintf("/%s/i", instanceType))},
+					{Key: "locationType", Value: strPtr("AWS Region")},
+				},
+			},
+		},
+		{
+			Name:            "Storage",
+			Unit:            "GB",
+			UnitMultiplier:  1,
+			MonthlyQuantity: decimalPtr(ebsVolumeSize),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(region),
+				Service:       strPtr("AmazonMSK"),
+				ProductFamily: strPtr("Managed Streaming for Apache Kafka (MSK)"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "storageFamily", Value: strPtr("GP2")},
 				},
 			},
-			{
-				Name:            "Storage",
+		},
+	}
+
+	// Data transfer within the cluster is free, but the standard AWS data
+	// transfer prices are charged for data to and from the clients
+	fromLocation, ok := regionMapping[region]
+	if ok && u != nil {
+		if u.Get("monthly_intra_region_gb").Exists() {
+			intraRegionGb := decimal.NewFromFloat(u.Get("monthly_intra_region_gb").Float())
+
+			costComponents = append(costComponents, &schema.CostComponent{
+				Name:            "Intra-region data transfer",
 				Unit:            "GB",
 				UnitMultiplier:  1,
-				MonthlyQuantity: decimalPtr(ebsVolumeSize),
+				MonthlyQuantity: decimalPtr(intraRegionGb.Mul(decimal.NewFromInt(2))),
 				ProductFilter: &schema.ProductFilter{
 					VendorName:    strPtr("aws"),
-					Region:        strPtr(region),
-					Service:       strPtr("AmazonMSK"),
-					ProductFamily: strPtr("Managed Streaming for Apache Kafka (MSK)"),
+					Service:       strPtr("AWSDataTransfer"),
+					ProductFamily: strPtr("Data Transfer"),
 					AttributeFilters: []*schema.AttributeFilter{
-						{Key: "storageFamily", Value: strPtr("GP2")},
+						{Key: "transferType", Value: strPtr("IntraRegion")},
+						{Key: "fromLocation", Value: strPtr(fromLocation)},
 					},
 				},
-			},
-		},
+			})
+		}
+
+		if u.Get("monthly_outbound_internet_gb").Exists() {
+			costComponents = append(costComponents, outboundInternet(fromLocation, u.Get("monthly_outbound_internet_gb").Int())...)
+		}
+	}
+
+	return &schema.Resource{
+		Name:           d.Address,
+		CostComponents: costComponents,
 	}
 }
@@ -0,0 +1,33 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestNewMskClusterStorageInfoAndDataTransfer(t *testing.T) {
+	t.Parallel()
+
+	d := schema.NewResourceData("aws_msk_cluster", "aws", "aws_msk_cluster.cluster", nil, gjson.Parse(`{
+		"region": "us-east-1",
+		"number_of_broker_nodes": 3,
+		"broker_node_group_info": [{
+			"instance_type": "kafka.m5.large",
+			"storage_info": [{"ebs_storage_info": [{"volume_size": 100}]}]
+		}]
+	}`))
+	u := &schema.UsageData{Attributes: map[string]gjson.Result{
+		"monthly_intra_region_gb": gjson.Parse("50"),
+	}}
+
+	r := NewMskCluster(d, u)
+
+	assert.Equal(t, 3, len(r.CostComponents))
+	assert.Equal(t, "Storage", r.CostComponents[1].Name)
+	assert.Equal(t, "300", r.CostComponents[1].MonthlyQuantity.String())
+	assert.Equal(t, "Intra-region data transfer", r.CostComponents[2].Name)
+	assert.Equal(t, "100", r.CostComponents[2].MonthlyQuantity.String())
+}
@@ -53,6 +53,7 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	GetKinesisDataAnalyticsRegistryItem(),
 	GetKinesisDataAnalyticsSnapshotRegistryItem(),
 	GetKinesisFirehoseDeliveryStreamRegistryItem(),
+	GetKinesisStreamRegistryItem(),
 	GetLambdaFunctionRegistryItem(),
 	GetLBRegistryItem(),
 	GetLightsailInstanceRegistryItem(),
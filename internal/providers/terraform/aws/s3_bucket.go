@@ -97,6 +97,10 @@ func s3SubResources(d *schema.ResourceData, u *schema.UsageData) []*schema.Resou
 		}
 	}
 
+	if d.Get("versioning.0.enabled").Bool() {
+		subResourceMap["Noncurrent versions"] = s3NoncurrentVersionsResource(region, d, u)
+	}
+
 	if u != nil {
 		if subResourceMap["Intelligent tiering"] == nil {
 			if u.Get("intelligent_tiering.frequent_access_storage_gb").Exists() {
@@ -509,6 +513,42 @@ func s3ResourceForStorageClass(region string, storageClass string, u *schema.Usa
 	}
 }
 
+// s3NoncurrentVersionsResource prices the noncurrent versions that a versioned
+// bucket keeps when objects are overwritten or deleted. These are stored at
+// the Standard price until the noncurrent version expiration of the lifecycle
+// rules, and are kept forever if the rules don't expire them.
+func s3NoncurrentVersionsResource(region string, d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	var retentionDays int64
+	for _, rule := range d.Get("lifecycle_rule").Array() {
+		if !rule.Get("enabled").Bool() {
+			continue
+		}
+		if days := rule.Get("noncurrent_version_expiration.0.days").Int(); days > retentionDays {
+			retentionDays = days
+		}
+	}
+
+	var storage *decimal.Decimal
+	if u != nil && u.Get("noncurrent_versions.storage_gb").Exists() {
+		storage = decimalPtr(decimal.NewFromFloat(u.Get("noncurrent_versions.storage_gb").Float()))
+	} else if u != nil && u.Get("noncurrent_versions.monthly_changed_gb").Exists() && retentionDays > 0 {
+		storage = decimalPtr(calculateRetainedStorage(decimal.NewFromFloat(u.Get("noncurrent_versions.monthly_changed_gb").Float()), retentionDays))
+	}
+
+	return &schema.Resource{
+		Name: "Noncurrent versions",
+		CostComponents: []*schema.CostComponent{
+			s3StorageVolumeTypeCostComponent("Storage", "AmazonS3", region, "TimedStorage-ByteHrs", "Standard", storage),
+		},
+	}
+}
+
+// calculateRetainedStorage returns the storage of the data that's changed each
+// month once it has been retained for the number of days.
+func calculateRetainedStorage(monthlyChangedGB decimal.Decimal, retentionDays int64) decimal.Decimal {
+	return monthlyChangedGB.Mul(decimal.NewFromInt(retentionDays)).Div(decimal.NewFromInt(30))
+}
+
 func s3StorageCostComponent(name string, service string, region string, usageType string, dataStorage *decimal.Decimal) *schema.CostComponent {
 	return &schema.CostComponent{
 		Name:            name,
@@ -0,0 +1,70 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestS3BucketNoncurrentVersions(t *testing.T) {
+	d := schema.NewResourceData("aws_s3_bucket", "aws", "aws_s3_bucket.bucket", nil, gjson.Parse(`{
+		"region": "us-east-1",
+		"versioning": [{"enabled": true}],
+		"lifecycle_rule": [
+			{"enabled": true, "noncurrent_version_expiration": [{"days": 30}]},
+			{"enabled": true, "noncurrent_version_expiration": [{"days": 90}]},
+			{"enabled": false, "noncurrent_version_expiration": [{"days": 365}]}
+		]
+	}`))
+	u := &schema.UsageData{
+		Attributes: map[string]gjson.Result{
+			"noncurrent_versions.monthly_changed_gb": gjson.Parse(`100`),
+		},
+	}
+
+	r := NewS3Bucket(d, u)
+	noncurrent := findSubResource(r, "Noncurrent versions")
+	require.NotNil(t, noncurrent)
+	assert.True(t, decimal.NewFromInt(300).Equal(*noncurrent.CostComponents[0].MonthlyQuantity))
+
+	u.Attributes["noncurrent_versions.storage_gb"] = gjson.Parse(`50`)
+	noncurrent = findSubResource(NewS3Bucket(d, u), "Noncurrent versions")
+	assert.True(t, decimal.NewFromInt(50).Equal(*noncurrent.CostComponents[0].MonthlyQuantity))
+}
+
+func TestS3BucketNoncurrentVersionsWithoutExpiration(t *testing.T) {
+	d := schema.NewResourceData("aws_s3_bucket", "aws", "aws_s3_bucket.bucket", nil, gjson.Parse(`{
+		"region": "us-east-1",
+		"versioning": [{"enabled": true}]
+	}`))
+	u := &schema.UsageData{
+		Attributes: map[string]gjson.Result{
+			"noncurrent_versions.monthly_changed_gb": gjson.Parse(`100`),
+		},
+	}
+
+	noncurrent := findSubResource(NewS3Bucket(d, u), "Noncurrent versions")
+	require.NotNil(t, noncurrent)
+	assert.Nil(t, noncurrent.CostComponents[0].MonthlyQuantity)
+}
+
+func TestS3BucketWithoutVersioning(t *testing.T) {
+	d := schema.NewResourceData("aws_s3_bucket", "aws", "aws_s3_bucket.bucket", nil, gjson.Parse(`{"region": "us-east-1"}`))
+
+	assert.Nil(t, findSubResource(NewS3Bucket(d, nil), "Noncurrent versions"))
+}
+
+func findSubResource(r *schema.Resource, name string) *schema.Resource {
+	for _, s := range r.SubResources {
+		if s.Name == name {
+			return s
+		}
+	}
+
+	return nil
+}
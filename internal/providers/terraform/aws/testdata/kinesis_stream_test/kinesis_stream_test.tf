@@ -0,0 +1,37 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_metadata_api_check     = true
+  skip_requesting_account_id  = true
+  skip_get_ec2_platforms      = true
+  skip_region_validation      = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+resource "aws_kinesis_stream" "provisioned" {
+  name        = "provisioned"
+  shard_count = 2
+}
+
+resource "aws_kinesis_stream" "provisioned_with_usage" {
+  name             = "provisioned-with-usage"
+  shard_count      = 4
+  retention_period = 48
+}
+
+resource "aws_kinesis_stream" "on_demand" {
+  name = "on-demand"
+
+  stream_mode_details {
+    stream_mode = "ON_DEMAND"
+  }
+}
+
+resource "aws_kinesis_stream" "on_demand_with_usage" {
+  name = "on-demand-with-usage"
+
+  stream_mode_details {
+    stream_mode = "ON_DEMAND"
+  }
+}
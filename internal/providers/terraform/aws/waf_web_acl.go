@@ -29,7 +29,7 @@ func NewWafWebACL(d *schema.ResourceData, u *schema.UsageData) *schema.Resource
 		region,
 		"Web ACL usage",
 		"months",
-		"USE1-WebACL",
+		"WebACL",
 		1,
 		decimalPtr(decimal.NewFromInt(1)),
 	))
@@ -57,7 +57,7 @@ func NewWafWebACL(d *schema.ResourceData, u *schema.UsageData) *schema.Resource
 		region,
 		"Rules",
 		"months",
-		"USE1-Rule",
+		"Rule",
 		1,
 		rule,
 	))
@@ -76,7 +76,7 @@ func NewWafWebACL(d *schema.ResourceData, u *schema.UsageData) *schema.Resource
 			region,
 			"Rule groups",
 			"months",
-			"USE1-Rule",
+			"Rule",
 			1,
 			decimalPtr(decimal.NewFromInt(int64(count))),
 		))
@@ -90,7 +90,7 @@ func NewWafWebACL(d *schema.ResourceData, u *schema.UsageData) *schema.Resource
 		region,
 		"Requests",
 		"1M requests",
-		"USE1-Request",
+		"Request",
 		1000000,
 		monthlyRequests,
 	))
@@ -113,7 +113,7 @@ func wafWebACLUsageCostComponent(region, displayName, unit, usagetype string, un
 			Service:       strPtr("awswaf"),
 			ProductFamily: strPtr("Web Application Firewall"),
 			AttributeFilters: []*schema.AttributeFilter{
-				{Key: "usagetype", ValueRegex: strPtr(fmt.Sprintf("/^%s$/i", usagetype))},
+				{Key: "usagetype", ValueRegex: strPtr(fmt.Sprintf("/^[A-Z0-9]+-%s$/i", usagetype))},
 			},
 		},
 		PriceFilter: &schema.PriceFilter{
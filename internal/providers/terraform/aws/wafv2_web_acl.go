@@ -20,28 +20,44 @@ func NewWafv2WebACL(d *schema.ResourceData, u *schema.UsageData) *schema.Resourc
 	region := d.Get("region").String()
 
 	var costComponents []*schema.CostComponent
-	var ruleGroupRules, managedRuleGroupRules, monthlyRequests, rule *decimal.Decimal
-	var sumForRules decimal.Decimal
+	var monthlyRequests *decimal.Decimal
 
 	costComponents = append(costComponents, wafWebACLUsageCostComponent(
 		region,
 		"Web ACL usage",
 		"months",
-		"USE1-WebACLV2",
+		"WebACLV2",
 		1,
 		decimalPtr(decimal.NewFromInt(1)),
 	))
-	if d.Get("rule.0.action").Type != gjson.Null {
-		rules := d.Get("rule.0.action").Array()
-		rule = decimalPtr(decimal.NewFromInt(int64(len(rules))))
-	}
-	if u != nil && u.Get("rule_group_rules").Type != gjson.Null && u.Get("managed_rule_group_rules").Type != gjson.Null {
-		ruleGroupRules = decimalPtr(decimal.NewFromInt(u.Get("rule_group_rules").Int()))
-		managedRuleGroupRules = decimalPtr(decimal.NewFromInt(u.Get("managed_rule_group_rules").Int()))
-		sumForRules = ruleGroupRules.Add(*managedRuleGroupRules)
-		if rule.IsPositive() {
-			sumForRules = sumForRules.Add(*rule)
+
+	// Each rule of the web ACL is charged, but rules that reference a rule
+	// group or a managed rule group are shown as rule groups.
+	var rules, ruleGroups, managedRuleGroups int64
+	for _, rule := range d.Get("rule").Array() {
+		isRuleGroup := false
+
+		if len(rule.Get("statement.0.rule_group_reference_statement").Array()) > 0 {
+			ruleGroups++
+			isRuleGroup = true
+		}
+
+		if len(rule.Get("statement.0.managed_rule_group_statement").Array()) > 0 {
+			managedRuleGroups++
+			isRuleGroup = true
 		}
+
+		if !isRuleGroup {
+			rules++
+		}
+	}
+
+	sumForRules := decimal.NewFromInt(rules)
+	if u != nil && u.Get("rule_group_rules").Type != gjson.Null {
+		sumForRules = sumForRules.Add(decimal.NewFromInt(u.Get("rule_group_rules").Int()))
+	}
+	if u != nil && u.Get("managed_rule_group_rules").Type != gjson.Null {
+		sumForRules = sumForRules.Add(decimal.NewFromInt(u.Get("managed_rule_group_rules").Int()))
 	}
 
 	if sumForRules.IsPositive() {
@@ -49,49 +65,31 @@ func NewWafv2WebACL(d *schema.ResourceData, u *schema.UsageData) *schema.Resourc
 			region,
 			"Rules",
 			"months",
-			"USE1-RuleV2",
+			"RuleV2",
 			1,
 			&sumForRules,
 		))
 	}
 
-	if d.Get("rule.0.statement.0.rule_group_reference_statement").Type != gjson.Null {
-		counter := 0
-		if d.Get("rule").Type != gjson.Null {
-			rules := d.Get("rule").Array()
-			for _, rule := range rules {
-				if rule.Get("statement").Type != gjson.Null {
-					statements := rule.Get("statement").Array()
-					for _, statement := range statements {
-						if statement.Get("rule_group_reference_statement").Type != gjson.Null {
-							counter++
-						}
-					}
-				}
-			}
-		}
-
-		if counter > 0 {
-			costComponents = append(costComponents, wafWebACLUsageCostComponent(
-				region,
-				"Rule groups",
-				"months",
-				"USE1-RuleV2",
-				1,
-				decimalPtr(decimal.NewFromInt(int64(counter))),
-			))
-		}
+	if ruleGroups > 0 {
+		costComponents = append(costComponents, wafWebACLUsageCostComponent(
+			region,
+			"Rule groups",
+			"months",
+			"RuleV2",
+			1,
+			decimalPtr(decimal.NewFromInt(ruleGroups)),
+		))
 	}
-	manageQuantity := d.Get("rule.0.statement.0.managed_rule_group_statement.0.name").Array()
 
-	if len(manageQuantity) > 0 {
+	if managedRuleGroups > 0 {
 		costComponents = append(costComponents, wafWebACLUsageCostComponent(
 			region,
 			"Managed rule groups",
 			"months",
-			"USE1-RuleV2",
+			"RuleV2",
 			1,
-			decimalPtr(decimal.NewFromInt(int64(len(manageQuantity)))),
+			decimalPtr(decimal.NewFromInt(managedRuleGroups)),
 		))
 	}
 
@@ -103,7 +101,7 @@ func NewWafv2WebACL(d *schema.ResourceData, u *schema.UsageData) *schema.Resourc
 		region,
 		"Requests",
 		"1M requests",
-		"USE1-RequestV2-Tier1",
+		"RequestV2-Tier1",
 		1000000,
 		monthlyRequests,
 	))
@@ -0,0 +1,46 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestWafv2WebACLRules(t *testing.T) {
+	d := schema.NewResourceData("aws_wafv2_web_acl", "aws", "aws_wafv2_web_acl.acl", nil, gjson.Parse(`{
+		"region": "eu-west-1",
+		"rule": [
+			{"statement": [{"managed_rule_group_statement": [{"name": "AWSManagedRulesCommonRuleSet"}]}]},
+			{"statement": [{"managed_rule_group_statement": [{"name": "AWSManagedRulesSQLiRuleSet"}]}]},
+			{"statement": [{"rule_group_reference_statement": [{"arn": "arn"}]}]},
+			{"statement": [{"ip_set_reference_statement": [{"arn": "arn"}]}]},
+			{"statement": [{"rate_based_statement": [{"limit": 100}]}]}
+		]
+	}`))
+	u := &schema.UsageData{
+		Attributes: map[string]gjson.Result{
+			"rule_group_rules": gjson.Parse(`3`),
+		},
+	}
+
+	r := NewWafv2WebACL(d, u)
+	require.Len(t, r.CostComponents, 5)
+
+	quantities := map[string]decimal.Decimal{}
+	for _, c := range r.CostComponents {
+		if c.MonthlyQuantity != nil {
+			quantities[c.Name] = *c.MonthlyQuantity
+		}
+		assert.Equal(t, "eu-west-1", *c.ProductFilter.Region)
+	}
+
+	assert.True(t, decimal.NewFromInt(5).Equal(quantities["Rules"]))
+	assert.True(t, decimal.NewFromInt(1).Equal(quantities["Rule groups"]))
+	assert.True(t, decimal.NewFromInt(2).Equal(quantities["Managed rule groups"]))
+	assert.Equal(t, "/^[A-Z0-9]+-WebACLV2$/i", *r.CostComponents[0].ProductFilter.AttributeFilters[0].ValueRegex)
+}
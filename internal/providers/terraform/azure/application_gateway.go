@@ -0,0 +1,215 @@
+package azure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage"
+	"github.com/shopspring/decimal"
+)
+
+// capacityUnitsPerInstance is the number of capacity units that are reserved
+// for each v2 instance when the capacity is set.
+const capacityUnitsPerInstance = 10
+
+func GetAzureRMApplicationGatewayRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "azurerm_application_gateway",
+		RFunc: NewAzureRMApplicationGateway,
+		ReferenceAttributes: []string{
+			"resource_group_name",
+		},
+	}
+}
+
+func NewAzureRMApplicationGateway(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := lookupRegion(d, []string{"resource_group_name"})
+
+	skuName := d.Get("sku.0.name").String()
+	tier := strings.ToLower(d.Get("sku.0.tier").String())
+
+	var costComponents []*schema.CostComponent
+
+	if strings.HasSuffix(tier, "_v2") {
+		costComponents = applicationGatewayV2CostComponents(d, u, region, tier)
+	} else {
+		costComponents = applicationGatewayV1CostComponents(d, u, region, skuName, tier)
+	}
+
+	return &schema.Resource{
+		Name:           d.Address,
+		CostComponents: costComponents,
+	}
+}
+
+func applicationGatewayV1CostComponents(d *schema.ResourceData, u *schema.UsageData, region, skuName, tier string) []*schema.CostComponent {
+	name := "Standard"
+	productName := "Application Gateway Standard"
+	if tier == "waf" {
+		name = "WAF"
+		productName = "Application Gateway WAF"
+	}
+
+	// The v1 SKU names are the tier and size, e.g. Standard_Medium
+	size := "Medium"
+	if parts := strings.Split(skuName, "_"); len(parts) > 1 {
+		size = parts[1]
+	}
+
+	capacity := decimal.NewFromInt(1)
+	if d.Get("sku.0.capacity").Exists() {
+		capacity = decimal.NewFromInt(d.Get("sku.0.capacity").Int())
+	}
+
+	costComponents := []*schema.CostComponent{
+		{
+			Name:           fmt.Sprintf("Gateway usage (%s, %s)", name, size),
+			Unit:           "hours",
+			UnitMultiplier: 1,
+			HourlyQuantity: decimalPtr(capacity),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("azure"),
+				Region:        strPtr(region),
+				Service:       strPtr("Application Gateway"),
+				ProductFamily: strPtr("Networking"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "productName", Value: strPtr(productName)},
+					{Key: "meterName", Value: strPtr(fmt.Sprintf("%s Gateway", size))},
+				},
+			},
+			PriceFilter: &schema.PriceFilter{
+				PurchaseOption: strPtr("Consumption"),
+			},
+		},
+	}
+
+	var dataProcessed *decimal.Decimal
+	if u != nil && u.Get("monthly_data_processed_gb").Exists() {
+		dataProcessed = decimalPtr(decimal.NewFromFloat(u.Get("monthly_data_processed_gb").Float()))
+	}
+
+	// The Medium and Large sizes include some data processing for free
+	var tierLimits []int
+	var tierNames []string
+	var tierStarts []string
+	switch size {
+	case "Medium":
+		tierLimits = []int{10240, 40960}
+		tierNames = []string{"first 10TB", "next 40TB", "over 50TB"}
+		tierStarts = []string{"0", "10240", "51200"}
+	case "Large":
+		tierLimits = []int{40960, 10240}
+		tierNames = []string{"first 40TB", "next 10TB", "over 50TB"}
+		tierStarts = []string{"0", "40960", "51200"}
+	}
+
+	if len(tierLimits) == 0 {
+		return append(costComponents, applicationGatewayDataProcessedCostComponent("Data processed", region, productName, size, "0", dataProcessed))
+	}
+
+	var tiers []decimal.Decimal
+	if dataProcessed != nil {
+		tiers = usage.CalculateTierBuckets(*dataProcessed, tierLimits)
+	}
+
+	for i, name := range tierNames {
+		var quantity *decimal.Decimal
+		if dataProcessed != nil {
+			if tiers[i].IsZero() && i > 0 {
+				continue
+			}
+			quantity = decimalPtr(tiers[i])
+		}
+
+		costComponents = append(costComponents, applicationGatewayDataProcessedCostComponent(
+			fmt.Sprintf("Data processed (%s)", name), region, productName, size, tierStarts[i], quantity))
+	}
+
+	return costComponents
+}
+
+func applicationGatewayDataProcessedCostComponent(name, region, productName, size, startUsage string, quantity *decimal.Decimal) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            "GB",
+		UnitMultiplier:  1,
+		MonthlyQuantity: quantity,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("azure"),
+			Region:        strPtr(region),
+			Service:       strPtr("Application Gateway"),
+			ProductFamily: strPtr("Networking"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "productName", Value: strPtr(productName)},
+				{Key: "skuName", Value: strPtr(size)},
+				{Key: "meterName", ValueRegex: strPtr("/Data Processed/i")},
+			},
+		},
+		PriceFilter: &schema.PriceFilter{
+			PurchaseOption:   strPtr("Consumption"),
+			StartUsageAmount: strPtr(startUsage),
+		},
+	}
+}
+
+func applicationGatewayV2CostComponents(d *schema.ResourceData, u *schema.UsageData, region, tier string) []*schema.CostComponent {
+	name := "Standard v2"
+	productName := "Application Gateway Standard v2"
+	if tier == "waf_v2" {
+		name = "WAF v2"
+		productName = "Application Gateway WAF v2"
+	}
+
+	// The capacity units are billed for the instances that are reserved by
+	// the capacity or autoscaling minimum, and for the usage above that.
+	var capacityUnits *decimal.Decimal
+	if u != nil && u.Get("capacity_units").Exists() {
+		capacityUnits = decimalPtr(decimal.NewFromFloat(u.Get("capacity_units").Float()))
+	} else if d.Get("sku.0.capacity").Int() > 0 {
+		capacityUnits = decimalPtr(decimal.NewFromInt(d.Get("sku.0.capacity").Int() * capacityUnitsPerInstance))
+	} else if d.Get("autoscale_configuration.0.min_capacity").Int() > 0 {
+		capacityUnits = decimalPtr(decimal.NewFromInt(d.Get("autoscale_configuration.0.min_capacity").Int() * capacityUnitsPerInstance))
+	}
+
+	return []*schema.CostComponent{
+		{
+			Name:           fmt.Sprintf("Gateway usage (%s)", name),
+			Unit:           "hours",
+			UnitMultiplier: 1,
+			HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("azure"),
+				Region:        strPtr(region),
+				Service:       strPtr("Application Gateway"),
+				ProductFamily: strPtr("Networking"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "productName", Value: strPtr(productName)},
+					{Key: "meterName", ValueRegex: strPtr("/Fixed Cost/i")},
+				},
+			},
+			PriceFilter: &schema.PriceFilter{
+				PurchaseOption: strPtr("Consumption"),
+			},
+		},
+		{
+			Name:           "Capacity units",
+			Unit:           "CU",
+			UnitMultiplier: schema.HourToMonthUnitMultiplier,
+			HourlyQuantity: capacityUnits,
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("azure"),
+				Region:        strPtr(region),
+				Service:       strPtr("Application Gateway"),
+				ProductFamily: strPtr("Networking"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "productName", Value: strPtr(productName)},
+					{Key: "meterName", ValueRegex: strPtr("/Capacity Units/i")},
+				},
+			},
+			PriceFilter: &schema.PriceFilter{
+				PurchaseOption: strPtr("Consumption"),
+			},
+		},
+	}
+}
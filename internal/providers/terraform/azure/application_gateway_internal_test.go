@@ -0,0 +1,63 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func testApplicationGateway(sku string) *schema.ResourceData {
+	return schema.NewResourceData("azurerm_application_gateway", "azurerm", "azurerm_application_gateway.gateway", nil, gjson.Parse(`{
+		"location": "eastus",
+		"sku": [`+sku+`]
+	}`))
+}
+
+func TestApplicationGatewayV1(t *testing.T) {
+	u := &schema.UsageData{
+		Attributes: map[string]gjson.Result{
+			"monthly_data_processed_gb": gjson.Parse(`20000`),
+		},
+	}
+
+	r := NewAzureRMApplicationGateway(testApplicationGateway(`{"name": "WAF_Medium", "tier": "WAF", "capacity": 2}`), u)
+	assert.Equal(t, 3, len(r.CostComponents))
+
+	assert.Equal(t, "Gateway usage (WAF, Medium)", r.CostComponents[0].Name)
+	assert.Equal(t, true, decimal.NewFromInt(2).Equal(*r.CostComponents[0].HourlyQuantity))
+
+	assert.Equal(t, "Data processed (first 10TB)", r.CostComponents[1].Name)
+	assert.Equal(t, true, decimal.NewFromInt(10240).Equal(*r.CostComponents[1].MonthlyQuantity))
+
+	assert.Equal(t, "Data processed (next 40TB)", r.CostComponents[2].Name)
+	assert.Equal(t, true, decimal.NewFromInt(9760).Equal(*r.CostComponents[2].MonthlyQuantity))
+
+	// Small gateways have no free data processing
+	r = NewAzureRMApplicationGateway(testApplicationGateway(`{"name": "Standard_Small", "tier": "Standard"}`), u)
+	assert.Equal(t, 2, len(r.CostComponents))
+	assert.Equal(t, "Data processed", r.CostComponents[1].Name)
+	assert.Equal(t, true, decimal.NewFromInt(20000).Equal(*r.CostComponents[1].MonthlyQuantity))
+}
+
+func TestApplicationGatewayV2(t *testing.T) {
+	r := NewAzureRMApplicationGateway(testApplicationGateway(`{"name": "WAF_v2", "tier": "WAF_v2", "capacity": 3}`), nil)
+	assert.Equal(t, 2, len(r.CostComponents))
+
+	assert.Equal(t, "Gateway usage (WAF v2)", r.CostComponents[0].Name)
+	assert.Equal(t, "Capacity units", r.CostComponents[1].Name)
+	assert.Equal(t, true, decimal.NewFromInt(30).Equal(*r.CostComponents[1].HourlyQuantity))
+
+	u := &schema.UsageData{
+		Attributes: map[string]gjson.Result{
+			"capacity_units": gjson.Parse(`15`),
+		},
+	}
+
+	r = NewAzureRMApplicationGateway(testApplicationGateway(`{"name": "Standard_v2", "tier": "Standard_v2"}`), u)
+	assert.Equal(t, "Gateway usage (Standard v2)", r.CostComponents[0].Name)
+	assert.Equal(t, true, decimal.NewFromInt(15).Equal(*r.CostComponents[1].HourlyQuantity))
+}
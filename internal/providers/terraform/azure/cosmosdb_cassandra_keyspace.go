@@ -63,7 +63,7 @@ func cosmosDBCostComponents(d *schema.ResourceData, u *schema.UsageData, account
 		model = Autoscale
 	} else {
 		model = Serverless
-		availabilityZone := geoLocations[0].Get("zone_zone_redundant").Bool()
+		availabilityZone := geoLocations[0].Get("zone_redundant").Bool()
 		location := geoLocations[0].Get("location").String()
 		costComponents = append(costComponents, serverlessCosmosCostComponent(location, availabilityZone, u))
 	}
@@ -166,7 +166,7 @@ func serverlessCosmosCostComponent(location string, availabilityZone bool, u *sc
 		requestUnits = decimalPtr(requestUnits.Div(decimal.NewFromInt(1000000)))
 	}
 
-	if availabilityZone {
+	if availabilityZone && requestUnits != nil {
 		requestUnits = decimalPtr(requestUnits.Mul(decimal.NewFromFloat(1.25)))
 	}
 
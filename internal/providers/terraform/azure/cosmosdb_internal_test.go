@@ -0,0 +1,34 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestCosmosDBServerlessZoneRedundant(t *testing.T) {
+	account := schema.NewResourceData("azurerm_cosmosdb_account", "azurerm", "azurerm_cosmosdb_account.account", nil, gjson.Parse(`{
+		"location": "westus",
+		"geo_location": [{"location": "westus", "failover_priority": 0, "zone_redundant": true}]
+	}`))
+	d := schema.NewResourceData("azurerm_cosmosdb_sql_database", "azurerm", "azurerm_cosmosdb_sql_database.db", nil, gjson.Parse(`{}`))
+	u := &schema.UsageData{
+		Attributes: map[string]gjson.Result{
+			"monthly_serverless_request_units": gjson.Parse(`10000000`),
+		},
+	}
+
+	costComponents := cosmosDBCostComponents(d, u, account)
+	serverless := costComponents[0]
+	assert.Equal(t, "Provisioned throughput (serverless)", serverless.Name)
+	assert.Equal(t, true, decimal.NewFromFloat(12.5).Equal(*serverless.MonthlyQuantity))
+
+	// Without usage the zone redundancy can't be applied to the request units
+	// so the quantity should be left unset
+	costComponents = cosmosDBCostComponents(d, nil, account)
+	assert.Equal(t, true, costComponents[0].MonthlyQuantity == nil)
+}
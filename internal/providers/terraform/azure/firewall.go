@@ -12,11 +12,14 @@ func GetAzureRMFirewallRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
 		Name:  "azurerm_firewall",
 		RFunc: NewAzureRMFirewall,
+		ReferenceAttributes: []string{
+			"resource_group_name",
+		},
 	}
 }
 
 func NewAzureRMFirewall(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
-	region := lookupRegion(d, []string{})
+	region := lookupRegion(d, []string{"resource_group_name"})
 
 	var costComponents []*schema.CostComponent
 
@@ -58,7 +61,7 @@ func NewAzureRMFirewall(d *schema.ResourceData, u *schema.UsageData) *schema.Res
 
 	var dataProcessed *decimal.Decimal
 	if u != nil && u.Get("monthly_data_processed_gb").Exists() {
-		dataProcessed = decimalPtr(decimal.NewFromInt(u.Get("monthly_data_processed_gb").Int()))
+		dataProcessed = decimalPtr(decimal.NewFromFloat(u.Get("monthly_data_processed_gb").Float()))
 	}
 
 	costComponents = append(costComponents, &schema.CostComponent{
@@ -23,16 +23,57 @@ func GetAzureRMAppFunctionRegistryItem() *schema.RegistryItem {
 func NewAzureRMAppFunction(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 	region := lookupRegion(d, []string{})
 
+	var skuTier, skuSize string
+	kind := "Windows"
+
+	appServicePlanID := d.References("app_service_plan_id")
+
+	if len(appServicePlanID) > 0 {
+		skuTier = strings.ToLower(appServicePlanID[0].Get("sku.0.tier").String())
+		skuSize = strings.ToLower(appServicePlanID[0].Get("sku.0.size").String())
+		kind = strings.ToLower(appServicePlanID[0].Get("kind").String())
+	}
+
+	premium := kind == "elastic" || skuTier == "elasticpremium"
+	consumption := kind == "functionapp"
+
+	return functionAppResource(d.Address, region, premium, consumption, skuSize, u)
+}
+
+// newAzureRMServicePlanFunctionApp creates the function apps that use an
+// azurerm_service_plan, which has the SKU name instead of the tier and size,
+// e.g. Y1 for consumption plans and EP1 for elastic premium plans.
+func newAzureRMServicePlanFunctionApp(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := lookupRegion(d, []string{})
+
+	var skuName string
+
+	servicePlanID := d.References("service_plan_id")
+	if len(servicePlanID) > 0 {
+		skuName = strings.ToLower(servicePlanID[0].Get("sku_name").String())
+	}
+
+	premium := strings.HasPrefix(skuName, "ep")
+	consumption := skuName == "y1"
+
+	return functionAppResource(d.Address, region, premium, consumption, skuName, u)
+}
+
+// functionAppResource returns the vCPU and memory of the instances for
+// premium plans, otherwise the executions and execution time from the usage
+// for consumption plans. Function apps on dedicated plans are skipped since
+// their costs are the plan's costs.
+func functionAppResource(address string, region string, premium bool, consumption bool, skuSize string, u *schema.UsageData) *schema.Resource {
 	var memorySize, executionTime, executions, gbSeconds *decimal.Decimal
 	var skuCPU *int64
 	var skuMemory *float64
-	var skuTier, skuSize string
-	kind := "Windows"
 
 	if u != nil && u.Get("monthly_executions").Type != gjson.Null {
 		executions = decimalPtr(decimal.NewFromInt(u.Get("monthly_executions").Int()))
 	}
-	if u != nil && u.Get("execution_duration_ms").Type != gjson.Null &&
+	if u != nil && u.Get("monthly_gb_seconds").Type != gjson.Null {
+		gbSeconds = decimalPtr(decimal.NewFromFloat(u.Get("monthly_gb_seconds").Float()))
+	} else if u != nil && u.Get("execution_duration_ms").Type != gjson.Null &&
 		u.Get("memory_mb").Type != gjson.Null &&
 		executions != nil {
 
@@ -52,14 +93,6 @@ func NewAzureRMAppFunction(d *schema.ResourceData, u *schema.UsageData) *schema.
 		"ep3": 14.0,
 	}
 
-	appServicePlanID := d.References("app_service_plan_id")
-
-	if len(appServicePlanID) > 0 {
-		skuTier = strings.ToLower(appServicePlanID[0].Get("sku.0.tier").String())
-		skuSize = strings.ToLower(appServicePlanID[0].Get("sku.0.size").String())
-		kind = strings.ToLower(appServicePlanID[0].Get("kind").String())
-	}
-
 	if val, ok := skuMapCPU[skuSize]; ok {
 		skuCPU = &val
 	}
@@ -74,25 +107,25 @@ func NewAzureRMAppFunction(d *schema.ResourceData, u *schema.UsageData) *schema.
 
 	costComponents := make([]*schema.CostComponent, 0)
 
-	if (kind == "elastic" || skuTier == "elasticpremium") && skuCPU != nil && skuMemory != nil {
+	if premium && skuCPU != nil && skuMemory != nil {
 		costComponents = append(costComponents, AppFunctionPremiumCPUCostComponent(skuSize, instances, skuCPU, region))
 		costComponents = append(costComponents, AppFunctionPremiumMemoryCostComponent(skuSize, instances, skuMemory, region))
 	} else {
-		if kind == "functionapp" || gbSeconds != nil {
+		if consumption || gbSeconds != nil {
 			costComponents = append(costComponents, AppFunctionConsumptionExecutionTimeCostComponent(gbSeconds, region))
 		}
-		if kind == "functionapp" || executions != nil {
+		if consumption || executions != nil {
 			costComponents = append(costComponents, AppFunctionConsumptionExecutionsCostComponent(executions, region))
 		}
 	}
 
 	if len(costComponents) > 1 {
 		return &schema.Resource{
-			Name:           d.Address,
+			Name:           address,
 			CostComponents: costComponents,
 		}
 	}
-	log.Warnf("Skipping resource %s. Could not find a way to get its cost components from the resource or usage file.", d.Address)
+	log.Warnf("Skipping resource %s. Could not find a way to get its cost components from the resource or usage file.", address)
 	return nil
 }
 
@@ -0,0 +1,131 @@
+package azure
+
+import (
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+)
+
+func GetAzureRMLoadBalancerRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "azurerm_lb",
+		RFunc: NewAzureRMLoadBalancer,
+		ReferenceAttributes: []string{
+			"resource_group_name",
+		},
+	}
+}
+
+func GetAzureRMLoadBalancerRuleRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "azurerm_lb_rule",
+		RFunc: NewAzureRMLoadBalancerRule,
+		ReferenceAttributes: []string{
+			"loadbalancer_id",
+		},
+	}
+}
+
+func GetAzureRMLoadBalancerOutboundRuleRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "azurerm_lb_outbound_rule",
+		RFunc: NewAzureRMLoadBalancerRule,
+		ReferenceAttributes: []string{
+			"loadbalancer_id",
+		},
+	}
+}
+
+func NewAzureRMLoadBalancer(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	// Basic load balancers are free
+	if isBasicLoadBalancer(d) {
+		return &schema.Resource{
+			Name:      d.Address,
+			NoPrice:   true,
+			IsSkipped: true,
+		}
+	}
+
+	region := lookupRegion(d, []string{"resource_group_name"})
+
+	var dataProcessed *decimal.Decimal
+	if u != nil && u.Get("monthly_data_processed_gb").Exists() {
+		dataProcessed = decimalPtr(decimal.NewFromFloat(u.Get("monthly_data_processed_gb").Float()))
+	}
+
+	return &schema.Resource{
+		Name: d.Address,
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:            "Data processed",
+				Unit:            "GB",
+				UnitMultiplier:  1,
+				MonthlyQuantity: dataProcessed,
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr("azure"),
+					Region:        strPtr(region),
+					Service:       strPtr("Load Balancer"),
+					ProductFamily: strPtr("Networking"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "meterName", Value: strPtr("Standard Data Processed")},
+					},
+				},
+				PriceFilter: &schema.PriceFilter{
+					PurchaseOption: strPtr("Consumption"),
+				},
+			},
+		},
+	}
+}
+
+// NewAzureRMLoadBalancerRule prices the load balancing and outbound rules of
+// Standard load balancers. Each rule is priced at the rate for the rules over
+// the first 5, since the rules are separate resources and the first 5 are
+// billed as a group.
+func NewAzureRMLoadBalancerRule(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	lbs := d.References("loadbalancer_id")
+	if len(lbs) > 0 && isBasicLoadBalancer(lbs[0]) {
+		return &schema.Resource{
+			Name:      d.Address,
+			NoPrice:   true,
+			IsSkipped: true,
+		}
+	}
+
+	region := lookupRegion(d, []string{"loadbalancer_id"})
+	if len(lbs) > 0 && d.Get("location").String() == "" {
+		region = lookupRegion(lbs[0], []string{"resource_group_name"})
+	}
+
+	return &schema.Resource{
+		Name: d.Address,
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:           "Rule usage",
+				Unit:           "hours",
+				UnitMultiplier: 1,
+				HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr("azure"),
+					Region:        strPtr(region),
+					Service:       strPtr("Load Balancer"),
+					ProductFamily: strPtr("Networking"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "meterName", Value: strPtr("Standard Overage LB Rules and Outbound Rules")},
+					},
+				},
+				PriceFilter: &schema.PriceFilter{
+					PurchaseOption: strPtr("Consumption"),
+				},
+			},
+		},
+	}
+}
+
+// isBasicLoadBalancer returns true for load balancers with the Basic SKU, which
+// is the default.
+func isBasicLoadBalancer(d *schema.ResourceData) bool {
+	sku := d.Get("sku").String()
+	return sku == "" || strings.EqualFold(sku, "basic")
+}
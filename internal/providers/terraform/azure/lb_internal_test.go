@@ -0,0 +1,49 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func testLoadBalancer(sku string) *schema.ResourceData {
+	return schema.NewResourceData("azurerm_lb", "azurerm", "azurerm_lb.lb", nil, gjson.Parse(`{
+		"location": "eastus",
+		"sku": "`+sku+`"
+	}`))
+}
+
+func TestLoadBalancer(t *testing.T) {
+	u := &schema.UsageData{
+		Attributes: map[string]gjson.Result{
+			"monthly_data_processed_gb": gjson.Parse(`1000`),
+		},
+	}
+
+	r := NewAzureRMLoadBalancer(testLoadBalancer("Standard"), u)
+	assert.Equal(t, 1, len(r.CostComponents))
+	assert.Equal(t, "Data processed", r.CostComponents[0].Name)
+	assert.Equal(t, true, decimal.NewFromInt(1000).Equal(*r.CostComponents[0].MonthlyQuantity))
+
+	r = NewAzureRMLoadBalancer(testLoadBalancer("Basic"), u)
+	assert.Equal(t, true, r.NoPrice)
+}
+
+func TestLoadBalancerRule(t *testing.T) {
+	d := schema.NewResourceData("azurerm_lb_rule", "azurerm", "azurerm_lb_rule.rule", nil, gjson.Parse(`{}`))
+	d.AddReference("loadbalancer_id", testLoadBalancer("Standard"))
+
+	r := NewAzureRMLoadBalancerRule(d, nil)
+	assert.Equal(t, 1, len(r.CostComponents))
+	assert.Equal(t, "eastus", *r.CostComponents[0].ProductFilter.Region)
+
+	d = schema.NewResourceData("azurerm_lb_rule", "azurerm", "azurerm_lb_rule.rule", nil, gjson.Parse(`{}`))
+	d.AddReference("loadbalancer_id", testLoadBalancer(""))
+
+	r = NewAzureRMLoadBalancerRule(d, nil)
+	assert.Equal(t, true, r.NoPrice)
+}
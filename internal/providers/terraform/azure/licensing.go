@@ -0,0 +1,41 @@
+package azure
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/tidwall/gjson"
+)
+
+// usageHybridBenefit checks if the Windows or SQL Server licenses of a
+// resource are covered by Azure Hybrid Benefit. The hybrid_benefit usage key
+// overrides the license type of the resource, since the licenses are often
+// assigned outside of Terraform.
+func usageHybridBenefit(u *schema.UsageData, licenseTypeBenefit bool) bool {
+	if u != nil && u.Get("hybrid_benefit").Type != gjson.Null {
+		return u.Get("hybrid_benefit").Bool()
+	}
+
+	return licenseTypeBenefit
+}
+
+// usageDevTest checks if a resource is in a Dev/Test subscription, which is
+// billed at the Dev/Test rates that don't include Windows or SQL Server
+// licenses. Wildcard usage keys can be used to set it for all the resources
+// of a type, e.g. azurerm_windows_virtual_machine.*
+func usageDevTest(u *schema.UsageData) bool {
+	return u != nil && u.Get("dev_test").Bool()
+}
+
+// sqlLicenseIncluded checks if the SQL Server license of a database is paid
+// for with the database.
+func sqlLicenseIncluded(d *schema.ResourceData, u *schema.UsageData) bool {
+	licenseType := "LicenseIncluded"
+	if d.Get("license_type").Type != gjson.Null {
+		licenseType = d.Get("license_type").String()
+	}
+
+	if usageDevTest(u) {
+		return false
+	}
+
+	return !usageHybridBenefit(u, licenseType != "LicenseIncluded")
+}
@@ -0,0 +1,71 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/tidwall/gjson"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestWindowsVirtualMachineLicensing(t *testing.T) {
+	tests := []struct {
+		name                   string
+		licenseType            string
+		priority               string
+		usage                  string
+		expectedName           string
+		expectedPurchaseOption string
+	}{
+		{"pay as you go", "", "", `{}`, "Instance usage (pay as you go, Standard_D2s_v3)", "Consumption"},
+		{"license type", "Windows_Server", "", `{}`, "Instance usage (hybrid benefit, Standard_D2s_v3)", "DevTestConsumption"},
+		{"usage hybrid benefit", "", "", `{"hybrid_benefit": true}`, "Instance usage (hybrid benefit, Standard_D2s_v3)", "DevTestConsumption"},
+		{"usage overrides license type", "Windows_Server", "", `{"hybrid_benefit": false}`, "Instance usage (pay as you go, Standard_D2s_v3)", "Consumption"},
+		{"dev/test", "Windows_Server", "", `{"dev_test": true}`, "Instance usage (dev/test, Standard_D2s_v3)", "DevTestConsumption"},
+		{"spot", "", "Spot", `{"dev_test": true}`, "Instance usage (spot, Standard_D2s_v3)", "Consumption"},
+	}
+
+	for _, test := range tests {
+		u := &schema.UsageData{Attributes: gjson.Parse(test.usage).Map()}
+
+		c := windowsVirtualMachineCostComponent("eastus", "Standard_D2s_v3", test.licenseType, test.priority, u)
+		assert.Equal(t, test.expectedName, c.Name)
+		assert.Equal(t, test.expectedPurchaseOption, *c.PriceFilter.PurchaseOption)
+	}
+}
+
+func TestMSSQLManagedInstanceLicensing(t *testing.T) {
+	tests := []struct {
+		name            string
+		licenseType     string
+		usage           string
+		expectedLicense bool
+	}{
+		{"license included", "LicenseIncluded", `{}`, true},
+		{"base price", "BasePrice", `{}`, false},
+		{"usage hybrid benefit", "LicenseIncluded", `{"hybrid_benefit": true}`, false},
+		{"usage overrides license type", "BasePrice", `{"hybrid_benefit": false}`, true},
+		{"dev/test", "LicenseIncluded", `{"dev_test": true}`, false},
+	}
+
+	for _, test := range tests {
+		d := schema.NewResourceData("azurerm_mssql_managed_instance", "azurerm", "azurerm_mssql_managed_instance.instance", nil, gjson.Parse(`{
+			"location": "eastus",
+			"sku_name": "GP_Gen5",
+			"vcores": 8,
+			"license_type": "`+test.licenseType+`"
+		}`))
+		u := &schema.UsageData{Attributes: gjson.Parse(test.usage).Map()}
+
+		r := NewAzureRMMSSQLManagedInstance(d, u)
+
+		hasLicense := false
+		for _, c := range r.CostComponents {
+			if c.Name == "SQL license" {
+				hasLicense = true
+			}
+		}
+		assert.Equal(t, test.expectedLicense, hasLicense)
+	}
+}
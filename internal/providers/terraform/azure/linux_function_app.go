@@ -0,0 +1,19 @@
+package azure
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetAzureRMLinuxFunctionAppRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "azurerm_linux_function_app",
+		RFunc: NewAzureRMLinuxFunctionApp,
+		ReferenceAttributes: []string{
+			"service_plan_id",
+		},
+	}
+}
+
+func NewAzureRMLinuxFunctionApp(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	return newAzureRMServicePlanFunctionApp(d, u)
+}
@@ -0,0 +1,70 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func testServicePlanFunctionApp(skuName string) *schema.ResourceData {
+	plan := schema.NewResourceData("azurerm_service_plan", "azurerm", "azurerm_service_plan.plan", nil, gjson.Parse(`{
+		"location": "eastus",
+		"os_type": "Linux",
+		"sku_name": "`+skuName+`"
+	}`))
+
+	d := schema.NewResourceData("azurerm_linux_function_app", "azurerm", "azurerm_linux_function_app.app", nil, gjson.Parse(`{
+		"location": "eastus"
+	}`))
+	d.AddReference("service_plan_id", plan)
+
+	return d
+}
+
+func TestLinuxFunctionAppConsumption(t *testing.T) {
+	u := &schema.UsageData{
+		Attributes: map[string]gjson.Result{
+			"monthly_executions": gjson.Parse(`3000000`),
+			"monthly_gb_seconds": gjson.Parse(`500000`),
+		},
+	}
+
+	r := NewAzureRMLinuxFunctionApp(testServicePlanFunctionApp("Y1"), u)
+	assert.Equal(t, 2, len(r.CostComponents))
+
+	assert.Equal(t, "Execution time", r.CostComponents[0].Name)
+	assert.Equal(t, true, decimal.NewFromInt(500000).Equal(*r.CostComponents[0].MonthlyQuantity))
+
+	assert.Equal(t, "Executions", r.CostComponents[1].Name)
+	assert.Equal(t, true, decimal.NewFromInt(300000).Equal(*r.CostComponents[1].MonthlyQuantity))
+
+	// The consumption costs are shown without usage so users know to add it
+	r = NewAzureRMLinuxFunctionApp(testServicePlanFunctionApp("Y1"), nil)
+	assert.Equal(t, 2, len(r.CostComponents))
+	assert.Equal(t, true, r.CostComponents[0].MonthlyQuantity == nil)
+}
+
+func TestLinuxFunctionAppPremium(t *testing.T) {
+	u := &schema.UsageData{
+		Attributes: map[string]gjson.Result{
+			"instances": gjson.Parse(`3`),
+		},
+	}
+
+	r := NewAzureRMLinuxFunctionApp(testServicePlanFunctionApp("EP2"), u)
+	assert.Equal(t, 2, len(r.CostComponents))
+
+	assert.Equal(t, "vCPU (EP2)", r.CostComponents[0].Name)
+	assert.Equal(t, true, decimal.NewFromInt(6).Equal(*r.CostComponents[0].HourlyQuantity))
+
+	assert.Equal(t, "Memory (EP2)", r.CostComponents[1].Name)
+	assert.Equal(t, true, decimal.NewFromInt(21).Equal(*r.CostComponents[1].HourlyQuantity))
+}
+
+func TestLinuxFunctionAppDedicatedPlan(t *testing.T) {
+	assert.Equal(t, true, NewAzureRMLinuxFunctionApp(testServicePlanFunctionApp("P1v2"), nil) == nil)
+}
@@ -18,7 +18,7 @@ func NewAzureRMLinuxVirtualMachineScaleSet(d *schema.ResourceData, u *schema.Usa
 
 	instanceType := d.Get("sku").String()
 
-	costComponents := []*schema.CostComponent{linuxVirtualMachineCostComponent(region, instanceType)}
+	costComponents := []*schema.CostComponent{linuxVirtualMachineCostComponent(region, instanceType, d.Get("priority").String())}
 	subResources := make([]*schema.Resource, 0)
 
 	if d.Get("additional_capabilities.0.ultra_ssd_enabled").Bool() {
@@ -29,10 +29,11 @@ func NewAzureRMLinuxVirtualMachineScaleSet(d *schema.ResourceData, u *schema.Usa
 	if osDisk != nil {
 		subResources = append(subResources, osDisk)
 	}
+	subResources = append(subResources, dataDiskSubResources(region, d, u)...)
 
 	instanceCount := decimal.NewFromInt(d.Get("instances").Int())
 	if u != nil && u.Get("instances").Type != gjson.Null {
-		instanceCount = decimal.NewFromInt(u.Get("instances").Int())
+		instanceCount = decimal.NewFromFloat(u.Get("instances").Float())
 	}
 
 	r := &schema.Resource{
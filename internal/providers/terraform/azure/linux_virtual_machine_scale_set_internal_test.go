@@ -0,0 +1,83 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestLinuxVirtualMachineScaleSetSpot(t *testing.T) {
+	d := schema.NewResourceData("azurerm_linux_virtual_machine_scale_set", "azurerm", "azurerm_linux_virtual_machine_scale_set.spot", nil, gjson.Parse(`{
+		"location": "eastus",
+		"sku": "Standard_D2s_v3",
+		"instances": 2,
+		"priority": "Spot"
+	}`))
+
+	r := NewAzureRMLinuxVirtualMachineScaleSet(d, nil)
+
+	assert.Equal(t, 1, len(r.CostComponents))
+	c := r.CostComponents[0]
+	assert.Equal(t, "Instance usage (spot, Standard_D2s_v3)", c.Name)
+	assert.Equal(t, "/ Spot$/i", *c.ProductFilter.AttributeFilters[0].ValueRegex)
+	assert.Equal(t, "Consumption", *c.PriceFilter.PurchaseOption)
+	assert.Equal(t, true, decimal.NewFromInt(2).Equal(*c.HourlyQuantity))
+}
+
+func TestWindowsVirtualMachineScaleSetSpotIgnoresHybridBenefit(t *testing.T) {
+	d := schema.NewResourceData("azurerm_windows_virtual_machine_scale_set", "azurerm", "azurerm_windows_virtual_machine_scale_set.spot", nil, gjson.Parse(`{
+		"location": "eastus",
+		"sku": "Standard_D2s_v3",
+		"instances": 1,
+		"priority": "Spot",
+		"license_type": "Windows_Server"
+	}`))
+
+	r := NewAzureRMWindowsVirtualMachineScaleSet(d, nil)
+
+	assert.Equal(t, 1, len(r.CostComponents))
+	c := r.CostComponents[0]
+	assert.Equal(t, "Instance usage (spot, Standard_D2s_v3)", c.Name)
+	assert.Equal(t, "Consumption", *c.PriceFilter.PurchaseOption)
+}
+
+func TestLinuxVirtualMachineScaleSetDataDisks(t *testing.T) {
+	d := schema.NewResourceData("azurerm_linux_virtual_machine_scale_set", "azurerm", "azurerm_linux_virtual_machine_scale_set.disks", nil, gjson.Parse(`{
+		"location": "eastus",
+		"sku": "Standard_F2",
+		"instances": 3,
+		"data_disk": [
+			{"storage_account_type": "Standard_LRS", "disk_size_gb": 100},
+			{"storage_account_type": "Premium_LRS", "disk_size_gb": 500}
+		]
+	}`))
+	u := &schema.UsageData{
+		Attributes: map[string]gjson.Result{
+			"instances":                         gjson.Parse(`4.5`),
+			"data_disk.monthly_disk_operations": gjson.Parse(`1000000`),
+		},
+	}
+
+	r := NewAzureRMLinuxVirtualMachineScaleSet(d, u)
+
+	assert.Equal(t, true, decimal.NewFromFloat(4.5).Equal(*r.CostComponents[0].HourlyQuantity))
+
+	assert.Equal(t, 2, len(r.SubResources))
+	for _, s := range r.SubResources {
+		assert.Equal(t, "data_disk", s.Name)
+	}
+
+	standard := r.SubResources[0]
+	assert.Equal(t, 2, len(standard.CostComponents))
+	assert.Equal(t, "Storage (S10)", standard.CostComponents[0].Name)
+	assert.Equal(t, true, decimal.NewFromFloat(4.5).Equal(*standard.CostComponents[0].MonthlyQuantity))
+	assert.Equal(t, true, decimal.NewFromInt(450).Equal(*standard.CostComponents[1].MonthlyQuantity))
+
+	premium := r.SubResources[1]
+	assert.Equal(t, 1, len(premium.CostComponents))
+	assert.Equal(t, "Storage (P20)", premium.CostComponents[0].Name)
+}
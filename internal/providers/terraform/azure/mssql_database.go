@@ -19,6 +19,9 @@ func GetAzureRMMSSQLDatabaseRegistryItem() *schema.RegistryItem {
 		ReferenceAttributes: []string{
 			"server_id",
 		},
+		Notes: []string{
+			"Azure Hybrid Benefit and Dev/Test pricing can be set with the hybrid_benefit and dev_test usage keys.",
+		},
 	}
 }
 
@@ -199,11 +202,7 @@ func vCorePurchaseCostComponents(d *schema.ResourceData, u *schema.UsageData, sk
 	}
 
 	if tier != "General Purpose - Serverless" {
-		licenseType := "LicenseIncluded"
-		if d.Get("license_type").Type != gjson.Null {
-			licenseType = d.Get("license_type").String()
-		}
-		if licenseType == "LicenseIncluded" {
+		if sqlLicenseIncluded(d, u) {
 			costComponents = append(costComponents, sqlLicenseCostComponent(region, cores, serviceName, tier))
 		}
 	}
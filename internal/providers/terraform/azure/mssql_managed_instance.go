@@ -0,0 +1,124 @@
+package azure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+)
+
+func GetAzureRMMSSQLManagedInstanceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "azurerm_mssql_managed_instance",
+		RFunc: NewAzureRMMSSQLManagedInstance,
+		Notes: []string{
+			"Azure Hybrid Benefit and Dev/Test pricing can be set with the hybrid_benefit and dev_test usage keys.",
+		},
+	}
+}
+
+func NewAzureRMMSSQLManagedInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := lookupRegion(d, []string{})
+	serviceName := "SQL Managed Instance"
+
+	sku := d.Get("sku_name").String()
+	s := strings.Split(sku, "_")
+	if len(s) != 2 {
+		log.Warnf("Unrecognized MSSQL Managed Instance SKU format for resource %s: %s", d.Address, sku)
+		return nil
+	}
+
+	tier, ok := map[string]string{
+		"GP": "General Purpose",
+		"BC": "Business Critical",
+	}[s[0]]
+	if !ok {
+		log.Warnf("Invalid tier in MSSQL Managed Instance SKU for resource %s: %s", d.Address, sku)
+		return nil
+	}
+
+	family, ok := map[string]string{
+		"Gen4": "Compute Gen4",
+		"Gen5": "Compute Gen5",
+	}[s[1]]
+	if !ok {
+		log.Warnf("Invalid family in MSSQL Managed Instance SKU for resource %s: %s", d.Address, sku)
+		return nil
+	}
+
+	cores := d.Get("vcores").Int()
+
+	costComponents := []*schema.CostComponent{
+		{
+			Name:           fmt.Sprintf("Compute (%s)", sku),
+			Unit:           "vCore-hours",
+			UnitMultiplier: 1,
+			HourlyQuantity: decimalPtr(decimal.NewFromInt(cores)),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("azure"),
+				Region:        strPtr(region),
+				Service:       strPtr(serviceName),
+				ProductFamily: strPtr("Databases"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "productName", ValueRegex: strPtr(fmt.Sprintf("/%s - %s/", tier, family))},
+					{Key: "skuName", Value: strPtr("vCore")},
+				},
+			},
+			PriceFilter: &schema.PriceFilter{
+				PurchaseOption: strPtr("Consumption"),
+			},
+		},
+	}
+
+	if sqlLicenseIncluded(d, u) {
+		costComponents = append(costComponents, sqlLicenseCostComponent(region, fmt.Sprintf("%d", cores), serviceName, tier))
+	}
+
+	storageGB := decimalPtr(decimal.NewFromInt(32))
+	if d.Get("storage_size_in_gb").Type != gjson.Null {
+		storageGB = decimalPtr(decimal.NewFromInt(d.Get("storage_size_in_gb").Int()))
+	}
+	costComponents = append(costComponents, mssqlStorageComponent(storageGB, region, serviceName, tier, false))
+
+	var backupStorageGB *decimal.Decimal
+	if u != nil && u.Get("backup_storage_gb").Type != gjson.Null {
+		backupStorageGB = decimalPtr(decimal.NewFromFloat(u.Get("backup_storage_gb").Float()))
+	}
+
+	backupRedundancy := "RA-GRS"
+	switch strings.ToUpper(d.Get("storage_account_type").String()) {
+	case "LRS":
+		backupRedundancy = "LRS"
+	case "ZRS":
+		backupRedundancy = "ZRS"
+	}
+
+	costComponents = append(costComponents, &schema.CostComponent{
+		Name:            fmt.Sprintf("PITR backup storage (%s)", backupRedundancy),
+		Unit:            "GB",
+		UnitMultiplier:  1,
+		MonthlyQuantity: backupStorageGB,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("azure"),
+			Region:        strPtr(region),
+			Service:       strPtr(serviceName),
+			ProductFamily: strPtr("Databases"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "productName", ValueRegex: strPtr("/PITR Backup Storage/")},
+				{Key: "skuName", Value: strPtr(fmt.Sprintf("Backup %s", backupRedundancy))},
+				{Key: "meterName", Value: strPtr(fmt.Sprintf("%s Data Stored", backupRedundancy))},
+			},
+		},
+		PriceFilter: &schema.PriceFilter{
+			PurchaseOption: strPtr("Consumption"),
+		},
+	})
+
+	return &schema.Resource{
+		Name:           d.Address,
+		CostComponents: costComponents,
+	}
+}
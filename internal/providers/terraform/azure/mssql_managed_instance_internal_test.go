@@ -0,0 +1,69 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestMSSQLManagedInstance(t *testing.T) {
+	d := schema.NewResourceData("azurerm_mssql_managed_instance", "azurerm", "azurerm_mssql_managed_instance.instance", nil, gjson.Parse(`{
+		"location": "eastus",
+		"sku_name": "GP_Gen5",
+		"vcores": 8,
+		"storage_size_in_gb": 256,
+		"storage_account_type": "LRS"
+	}`))
+	u := &schema.UsageData{
+		Attributes: map[string]gjson.Result{
+			"backup_storage_gb": gjson.Parse(`500`),
+		},
+	}
+
+	r := NewAzureRMMSSQLManagedInstance(d, u)
+	assert.Equal(t, 4, len(r.CostComponents))
+
+	compute := r.CostComponents[0]
+	assert.Equal(t, "Compute (GP_Gen5)", compute.Name)
+	assert.Equal(t, true, decimal.NewFromInt(8).Equal(*compute.HourlyQuantity))
+	assert.Equal(t, "/General Purpose - Compute Gen5/", *compute.ProductFilter.AttributeFilters[0].ValueRegex)
+
+	assert.Equal(t, "SQL license", r.CostComponents[1].Name)
+	assert.Equal(t, true, decimal.NewFromInt(8).Equal(*r.CostComponents[1].HourlyQuantity))
+
+	assert.Equal(t, "Storage", r.CostComponents[2].Name)
+	assert.Equal(t, true, decimal.NewFromInt(256).Equal(*r.CostComponents[2].MonthlyQuantity))
+
+	backup := r.CostComponents[3]
+	assert.Equal(t, "PITR backup storage (LRS)", backup.Name)
+	assert.Equal(t, true, decimal.NewFromInt(500).Equal(*backup.MonthlyQuantity))
+}
+
+func TestMSSQLManagedInstanceBasePrice(t *testing.T) {
+	d := schema.NewResourceData("azurerm_mssql_managed_instance", "azurerm", "azurerm_mssql_managed_instance.instance", nil, gjson.Parse(`{
+		"location": "eastus",
+		"sku_name": "BC_Gen5",
+		"vcores": 4,
+		"license_type": "BasePrice"
+	}`))
+
+	r := NewAzureRMMSSQLManagedInstance(d, nil)
+	assert.Equal(t, 3, len(r.CostComponents))
+	assert.Equal(t, "/Business Critical - Compute Gen5/", *r.CostComponents[0].ProductFilter.AttributeFilters[0].ValueRegex)
+	assert.Equal(t, "PITR backup storage (RA-GRS)", r.CostComponents[2].Name)
+	assert.Equal(t, true, r.CostComponents[2].MonthlyQuantity == nil)
+}
+
+func TestMSSQLManagedInstanceInvalidSku(t *testing.T) {
+	d := schema.NewResourceData("azurerm_mssql_managed_instance", "azurerm", "azurerm_mssql_managed_instance.instance", nil, gjson.Parse(`{
+		"location": "eastus",
+		"sku_name": "XX_Gen5",
+		"vcores": 4
+	}`))
+
+	assert.Equal(t, true, NewAzureRMMSSQLManagedInstance(d, nil) == nil)
+}
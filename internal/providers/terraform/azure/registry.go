@@ -6,8 +6,11 @@ import "github.com/infracost/infracost/internal/schema"
 var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	GetAzureRMApiManagementRegistryItem(),
 	GetAzureRMAppIsolatedServicePlanRegistryItem(),
+	GetAzureRMApplicationGatewayRegistryItem(),
 	GetAzureRMAppIntegrationServiceEnvironmentRegistryItem(),
 	GetAzureRMAppFunctionRegistryItem(),
+	GetAzureRMLinuxFunctionAppRegistryItem(),
+	GetAzureRMWindowsFunctionAppRegistryItem(),
 	GetAzureRMAppNATGatewayRegistryItem(),
 	GetAzureRMAppServiceCertificateBindingRegistryItem(),
 	GetAzureRMAppServiceCertificateOrderRegistryItem(),
@@ -53,10 +56,14 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	GetAzureRMKubernetesClusterRegistryItem(),
 	GetAzureRMKubernetesClusterNodePoolRegistryItem(),
 	GetAzureRMLinuxVirtualMachineRegistryItem(),
+	GetAzureRMLoadBalancerRegistryItem(),
+	GetAzureRMLoadBalancerRuleRegistryItem(),
+	GetAzureRMLoadBalancerOutboundRuleRegistryItem(),
 	GetAzureRMLinuxVirtualMachineScaleSetRegistryItem(),
 	GetAzureRMManagedDiskRegistryItem(),
 	GetAzureRMMariaDBServerRegistryItem(),
 	GetAzureRMMSSQLDatabaseRegistryItem(),
+	GetAzureRMMSSQLManagedInstanceRegistryItem(),
 	GetAzureRMMySQLServerRegistryItem(),
 	GetAzureRMNotificationHubNamespaceRegistryItem(),
 	GetAzureRMPostgreSQLFlexibleServerRegistryItem(),
@@ -75,6 +82,7 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	GetAzureRMStorageAccountRegistryItem(),
 	GetAzureRMVirtualMachineScaleSetRegistryItem(),
 	GetAzureRMVirtualMachineRegistryItem(),
+	GetAzureRMVirtualNetworkGatewayRegistryItem(),
 	GetAzureRMWindowsVirtualMachineRegistryItem(),
 	GetAzureRMWindowsVirtualMachineScaleSetRegistryItem(),
 }
@@ -184,14 +192,23 @@ var FreeResources []string = []string{
 	"azurerm_key_vault_certificate_issuer",
 	"azurerm_key_vault_secret",
 
+	// Azure Load Balancer
+	"azurerm_lb_backend_address_pool",
+	"azurerm_lb_backend_address_pool_address",
+	"azurerm_lb_nat_pool",
+	"azurerm_lb_nat_rule",
+	"azurerm_lb_probe",
+
 	// Azure Networking
 	"azurerm_application_security_group",
+	"azurerm_local_network_gateway",
 	"azurerm_network_interface",
 	"azurerm_network_interface_security_group_association",
 	"azurerm_network_security_group",
 	"azurerm_subnet",
 	"azurerm_subnet_network_security_group_association",
 	"azurerm_virtual_network",
+	"azurerm_virtual_network_gateway_connection",
 
 	// Azure Notification Hub
 	"azurerm_notification_hub",
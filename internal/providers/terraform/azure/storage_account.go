@@ -28,8 +28,8 @@ func NewAzureRMStorageAccount(d *schema.ResourceData, u *schema.UsageData) *sche
 		accountKind = d.Get("account_kind").String()
 	}
 
-	if accountKind != "BlockBlobStorage" && accountKind != "FileStorage" {
-		log.Warnf("Skipping resource %s. Infracost only supports BlockBlobStorage and FileStorage account kinds", d.Address)
+	if !Contains([]string{"StorageV2", "BlobStorage", "BlockBlobStorage", "FileStorage"}, accountKind) {
+		log.Warnf("Skipping resource %s. Infracost only supports StorageV2, BlobStorage, BlockBlobStorage and FileStorage account kinds", d.Address)
 		return nil
 	}
 
@@ -40,11 +40,26 @@ func NewAzureRMStorageAccount(d *schema.ResourceData, u *schema.UsageData) *sche
 		accessTier = d.Get("access_tier").String()
 	}
 
-	if accountKind == "BlockBlobStorage" {
-		productName = map[string]string{
-			"Standard": "Blob Storage",
-			"Premium":  "Premium Block Blob",
-		}[accountTier]
+	if accountKind != "FileStorage" {
+		if accountTier == "Premium" && accountKind != "BlockBlobStorage" {
+			log.Warnf("Skipping resource %s. Infracost only supports the Premium account tier for BlockBlobStorage accounts", d.Address)
+			return nil
+		}
+
+		// Accounts with a hierarchical namespace are Data Lake Storage Gen2
+		// accounts, which have their own prices and operations
+		hns := d.Get("is_hns_enabled").Bool() && accountTier == "Standard"
+
+		switch {
+		case hns:
+			productName = "Azure Data Lake Storage Gen2 Hierarchical Namespace"
+		case accountTier == "Premium":
+			productName = "Premium Block Blob"
+		case accountTier == "Standard" && accountKind == "StorageV2":
+			productName = "General Block Blob v2"
+		case accountTier == "Standard":
+			productName = "Blob Storage"
+		}
 
 		if productName == "" {
 			log.Warnf("Unrecognized account tier for resource %s: %s", d.Address, accountTier)
@@ -52,16 +67,22 @@ func NewAzureRMStorageAccount(d *schema.ResourceData, u *schema.UsageData) *sche
 		}
 
 		validPremiumReplicationTypes := []string{"ZRS", "LRS"}
-		validStandardReplicationTypes := []string{"LRS", "GRS", "RAGRS"}
+		validStandardReplicationTypes := []string{"LRS", "ZRS", "GRS", "RAGRS", "GZRS", "RAGZRS"}
 
-		if accessTier == "Premium" && (!Contains(validPremiumReplicationTypes, accountReplicationType) || !Contains(validStandardReplicationTypes, accountReplicationType)) {
+		if accountTier == "Premium" && !Contains(validPremiumReplicationTypes, accountReplicationType) {
+			log.Warnf("%s redundancy does not supports for %s performance tier", accountReplicationType, accountTier)
+		}
+		if accountTier == "Standard" && !Contains(validStandardReplicationTypes, accountReplicationType) {
 			log.Warnf("%s redundancy does not supports for %s performance tier", accountReplicationType, accountTier)
 		}
 
 		var capacity, writeOperations, listOperations, readOperations, otherOperations, dataRetrieval, dataWrite, blobIndex *decimal.Decimal
 
-		if accountReplicationType == "RAGRS" {
+		switch accountReplicationType {
+		case "RAGRS":
 			accountReplicationType = "RA-GRS"
+		case "RAGZRS":
+			accountReplicationType = "RA-GZRS"
 		}
 
 		skuName := fmt.Sprintf("%s %s", accessTier, accountReplicationType)
@@ -112,58 +133,73 @@ func NewAzureRMStorageAccount(d *schema.ResourceData, u *schema.UsageData) *sche
 			costComponents = append(costComponents, blobDataStorageCostComponent(region, "Capacity", skuName, "0", productName, unknown))
 		}
 
-		if u != nil && u.Get("monthly_write_operations").Type != gjson.Null {
-			writeOperations = decimalPtr(decimal.NewFromInt(u.Get("monthly_write_operations").Int()))
+		// Deleted and overwritten blobs are kept as soft-deleted data for the
+		// retention days and are charged at the same rate as active data.
+		retentionDays := d.Get("blob_properties.0.delete_retention_policy.0.days").Int()
+		if retentionDays > 0 {
+			var softDeleted *decimal.Decimal
+			if u != nil && u.Get("monthly_deleted_data_gb").Type != gjson.Null {
+				softDeleted = decimalPtr(decimal.NewFromFloat(u.Get("monthly_deleted_data_gb").Float()).Mul(decimal.NewFromInt(retentionDays)).Div(decimal.NewFromInt(30)))
+			}
+			costComponents = append(costComponents, blobDataStorageCostComponent(region, "Soft-deleted capacity", skuName, "0", productName, softDeleted))
 		}
 
-		costComponents = append(costComponents, blobOperationsCostComponent(
-			region,
-			"Write operations",
-			"10K operations",
-			skuName,
-			"Write Operations",
-			productName,
-			writeOperations,
-			10000))
-
-		if u != nil && u.Get("monthly_list_and_create_container_operations").Type != gjson.Null {
-			listOperations = decimalPtr(decimal.NewFromInt(u.Get("monthly_list_and_create_container_operations").Int()))
+		if u != nil && u.Get("monthly_write_operations").Type != gjson.Null {
+			writeOperations = decimalPtr(decimal.NewFromInt(u.Get("monthly_write_operations").Int()))
 		}
-		costComponents = append(costComponents, blobOperationsCostComponent(
-			region,
-			"List and create container operations",
-			"10K operations",
-			skuName,
-			"List and Create Container Operations",
-			productName,
-			listOperations,
-			10000))
-
 		if u != nil && u.Get("monthly_read_operations").Type != gjson.Null {
 			readOperations = decimalPtr(decimal.NewFromInt(u.Get("monthly_read_operations").Int()))
 		}
-		costComponents = append(costComponents, blobOperationsCostComponent(
-			region,
-			"Read operations",
-			"10K operations",
-			skuName,
-			"Read Operations",
-			productName,
-			readOperations,
-			10000))
-
 		if u != nil && u.Get("monthly_other_operations").Type != gjson.Null {
 			otherOperations = decimalPtr(decimal.NewFromInt(u.Get("monthly_other_operations").Int()))
 		}
-		costComponents = append(costComponents, blobOperationsCostComponent(
-			region,
-			"All other operations",
-			"10K operations",
-			skuName,
-			"All Other Operations",
-			productName,
-			otherOperations,
-			10000))
+
+		if hns {
+			costComponents = append(costComponents, dataLakeOperationsCostComponents(region, accessTier, skuName, productName, writeOperations, readOperations, otherOperations, u)...)
+		} else {
+			costComponents = append(costComponents, blobOperationsCostComponent(
+				region,
+				"Write operations",
+				"10K operations",
+				skuName,
+				"Write Operations",
+				productName,
+				writeOperations,
+				10000))
+
+			if u != nil && u.Get("monthly_list_and_create_container_operations").Type != gjson.Null {
+				listOperations = decimalPtr(decimal.NewFromInt(u.Get("monthly_list_and_create_container_operations").Int()))
+			}
+			costComponents = append(costComponents, blobOperationsCostComponent(
+				region,
+				"List and create container operations",
+				"10K operations",
+				skuName,
+				"List and Create Container Operations",
+				productName,
+				listOperations,
+				10000))
+
+			costComponents = append(costComponents, blobOperationsCostComponent(
+				region,
+				"Read operations",
+				"10K operations",
+				skuName,
+				"Read Operations",
+				productName,
+				readOperations,
+				10000))
+
+			costComponents = append(costComponents, blobOperationsCostComponent(
+				region,
+				"All other operations",
+				"10K operations",
+				skuName,
+				"All Other Operations",
+				productName,
+				otherOperations,
+				10000))
+		}
 
 		if accountTier != "Premium" {
 			if u != nil && u.Get("monthly_data_retrieval_gb").Type != gjson.Null {
@@ -192,18 +228,21 @@ func NewAzureRMStorageAccount(d *schema.ResourceData, u *schema.UsageData) *sche
 				dataWrite,
 				1))
 
-			if u != nil && u.Get("blob_index_tags").Type != gjson.Null {
-				blobIndex = decimalPtr(decimal.NewFromInt(u.Get("blob_index_tags").Int()))
+			// Blob index tags aren't supported for hierarchical namespaces
+			if !hns {
+				if u != nil && u.Get("blob_index_tags").Type != gjson.Null {
+					blobIndex = decimalPtr(decimal.NewFromInt(u.Get("blob_index_tags").Int()))
+				}
+				costComponents = append(costComponents, blobOperationsCostComponent(
+					region,
+					"Blob index",
+					"10K tags",
+					skuName,
+					"Index Tags",
+					productName,
+					blobIndex,
+					10000))
 			}
-			costComponents = append(costComponents, blobOperationsCostComponent(
-				region,
-				"Blob index",
-				"10K tags",
-				skuName,
-				"Index Tags",
-				productName,
-				blobIndex,
-				10000))
 		}
 	}
 	if accountKind == "FileStorage" {
@@ -390,6 +429,30 @@ func blobOperationsCostComponent(region, name, unit, skuName, meterName, product
 		},
 	}
 }
+
+// dataLakeOperationsCostComponents returns the operations of accounts with a
+// hierarchical namespace. The directory operations are priced as iterative
+// operations, and the meter names start with the access tier so they're
+// matched exactly since e.g. /Write Operations$/ would also match the
+// iterative write operations.
+func dataLakeOperationsCostComponents(region, accessTier, skuName, productName string, writeOperations, readOperations, otherOperations *decimal.Decimal, u *schema.UsageData) []*schema.CostComponent {
+	var iterativeWriteOperations, iterativeReadOperations *decimal.Decimal
+	if u != nil && u.Get("monthly_iterative_write_operations").Type != gjson.Null {
+		iterativeWriteOperations = decimalPtr(decimal.NewFromInt(u.Get("monthly_iterative_write_operations").Int()))
+	}
+	if u != nil && u.Get("monthly_iterative_read_operations").Type != gjson.Null {
+		iterativeReadOperations = decimalPtr(decimal.NewFromInt(u.Get("monthly_iterative_read_operations").Int()))
+	}
+
+	return []*schema.CostComponent{
+		blobOperationsCostComponent(region, "Write operations", "10K operations", skuName, fmt.Sprintf("^%s Write Operations", accessTier), productName, writeOperations, 10000),
+		blobOperationsCostComponent(region, "Read operations", "10K operations", skuName, fmt.Sprintf("^%s Read Operations", accessTier), productName, readOperations, 10000),
+		blobOperationsCostComponent(region, "Iterative write operations", "100 operations", skuName, fmt.Sprintf("^%s Iterative Write Operations", accessTier), productName, iterativeWriteOperations, 100),
+		blobOperationsCostComponent(region, "Iterative read operations", "10K operations", skuName, fmt.Sprintf("^%s Iterative Read Operations", accessTier), productName, iterativeReadOperations, 10000),
+		blobOperationsCostComponent(region, "All other operations", "10K operations", skuName, fmt.Sprintf("^%s Other Operations", accessTier), productName, otherOperations, 10000),
+	}
+}
+
 func fileDataStorageCostComponent(region, name, skuName, meterName string, quantity *decimal.Decimal) *schema.CostComponent {
 	return &schema.CostComponent{
 		Name:                 name,
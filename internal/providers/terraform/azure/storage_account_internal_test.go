@@ -0,0 +1,116 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestStorageAccountSoftDeletedCapacity(t *testing.T) {
+	d := schema.NewResourceData("azurerm_storage_account", "azurerm", "azurerm_storage_account.account", nil, gjson.Parse(`{
+		"location": "eastus",
+		"account_kind": "BlockBlobStorage",
+		"account_tier": "Standard",
+		"account_replication_type": "LRS",
+		"blob_properties": [{"delete_retention_policy": [{"days": 15}]}]
+	}`))
+	u := &schema.UsageData{
+		Attributes: map[string]gjson.Result{
+			"monthly_deleted_data_gb": gjson.Parse(`200`),
+		},
+	}
+
+	r := NewAzureRMStorageAccount(d, u)
+
+	var softDeleted *schema.CostComponent
+	for _, c := range r.CostComponents {
+		if c.Name == "Soft-deleted capacity" {
+			softDeleted = c
+		}
+	}
+
+	assert.NotEqual(t, nil, softDeleted)
+	assert.Equal(t, true, decimal.NewFromInt(100).Equal(*softDeleted.MonthlyQuantity))
+}
+
+func TestStorageAccountWithoutSoftDelete(t *testing.T) {
+	d := schema.NewResourceData("azurerm_storage_account", "azurerm", "azurerm_storage_account.account", nil, gjson.Parse(`{
+		"location": "eastus",
+		"account_kind": "BlockBlobStorage",
+		"account_tier": "Standard",
+		"account_replication_type": "LRS"
+	}`))
+
+	for _, c := range NewAzureRMStorageAccount(d, nil).CostComponents {
+		assert.NotEqual(t, "Soft-deleted capacity", c.Name)
+	}
+}
+
+func TestStorageAccountStorageV2(t *testing.T) {
+	d := schema.NewResourceData("azurerm_storage_account", "azurerm", "azurerm_storage_account.account", nil, gjson.Parse(`{
+		"location": "eastus",
+		"account_tier": "Standard",
+		"account_replication_type": "RAGZRS",
+		"access_tier": "Cool"
+	}`))
+
+	r := NewAzureRMStorageAccount(d, nil)
+	assert.NotEqual(t, nil, r)
+
+	capacity := r.CostComponents[0]
+	assert.Equal(t, "Capacity", capacity.Name)
+	assert.Equal(t, "General Block Blob v2", *capacity.ProductFilter.AttributeFilters[0].Value)
+	assert.Equal(t, "Cool RA-GZRS", *capacity.ProductFilter.AttributeFilters[1].Value)
+}
+
+func TestStorageAccountPremiumStorageV2(t *testing.T) {
+	d := schema.NewResourceData("azurerm_storage_account", "azurerm", "azurerm_storage_account.account", nil, gjson.Parse(`{
+		"location": "eastus",
+		"account_kind": "StorageV2",
+		"account_tier": "Premium",
+		"account_replication_type": "LRS"
+	}`))
+
+	assert.Equal(t, true, NewAzureRMStorageAccount(d, nil) == nil)
+}
+
+func TestStorageAccountHierarchicalNamespace(t *testing.T) {
+	d := schema.NewResourceData("azurerm_storage_account", "azurerm", "azurerm_storage_account.account", nil, gjson.Parse(`{
+		"location": "eastus",
+		"account_kind": "StorageV2",
+		"account_tier": "Standard",
+		"account_replication_type": "LRS",
+		"is_hns_enabled": true
+	}`))
+	u := &schema.UsageData{
+		Attributes: map[string]gjson.Result{
+			"monthly_iterative_write_operations": gjson.Parse(`2000`),
+		},
+	}
+
+	r := NewAzureRMStorageAccount(d, u)
+
+	names := make([]string, 0, len(r.CostComponents))
+	for _, c := range r.CostComponents {
+		names = append(names, c.Name)
+		assert.Equal(t, "Azure Data Lake Storage Gen2 Hierarchical Namespace", *c.ProductFilter.AttributeFilters[0].Value)
+	}
+	assert.Equal(t, []string{
+		"Capacity",
+		"Write operations",
+		"Read operations",
+		"Iterative write operations",
+		"Iterative read operations",
+		"All other operations",
+		"Data retrieval",
+		"Data write",
+	}, names)
+
+	iterativeWrite := r.CostComponents[3]
+	assert.Equal(t, "/^Hot Iterative Write Operations$/i", *iterativeWrite.ProductFilter.AttributeFilters[2].ValueRegex)
+	assert.Equal(t, true, decimal.NewFromInt(20).Equal(*iterativeWrite.MonthlyQuantity))
+}
@@ -0,0 +1,84 @@
+provider "azurerm" {
+  skip_provider_registration = true
+  features {}
+}
+
+resource "azurerm_resource_group" "example" {
+  name     = "example-resources"
+  location = "eastus"
+}
+
+resource "azurerm_public_ip" "example" {
+  name                = "example-pip"
+  resource_group_name = azurerm_resource_group.example.name
+  location            = azurerm_resource_group.example.location
+  allocation_method   = "Static"
+  sku                 = "Standard"
+}
+
+resource "azurerm_lb" "basic" {
+  name                = "example-basic-lb"
+  resource_group_name = azurerm_resource_group.example.name
+  location            = azurerm_resource_group.example.location
+}
+
+resource "azurerm_lb" "standard" {
+  name                = "example-standard-lb"
+  resource_group_name = azurerm_resource_group.example.name
+  location            = azurerm_resource_group.example.location
+  sku                 = "Standard"
+
+  frontend_ip_configuration {
+    name                 = "frontend-ip-configuration"
+    public_ip_address_id = azurerm_public_ip.example.id
+  }
+}
+
+resource "azurerm_lb" "standard_with_usage" {
+  name                = "example-standard-lb-with-usage"
+  resource_group_name = azurerm_resource_group.example.name
+  location            = azurerm_resource_group.example.location
+  sku                 = "Standard"
+}
+
+resource "azurerm_lb_backend_address_pool" "standard" {
+  name            = "backend-address-pool"
+  loadbalancer_id = azurerm_lb.standard.id
+}
+
+resource "azurerm_lb_probe" "standard" {
+  name            = "http-probe"
+  loadbalancer_id = azurerm_lb.standard.id
+  port            = 80
+}
+
+resource "azurerm_lb_rule" "standard" {
+  name                           = "http-rule"
+  loadbalancer_id                = azurerm_lb.standard.id
+  protocol                       = "Tcp"
+  frontend_port                  = 80
+  backend_port                   = 80
+  frontend_ip_configuration_name = "frontend-ip-configuration"
+  backend_address_pool_ids       = [azurerm_lb_backend_address_pool.standard.id]
+  probe_id                       = azurerm_lb_probe.standard.id
+}
+
+resource "azurerm_lb_outbound_rule" "standard" {
+  name                    = "outbound-rule"
+  loadbalancer_id         = azurerm_lb.standard.id
+  protocol                = "Tcp"
+  backend_address_pool_id = azurerm_lb_backend_address_pool.standard.id
+
+  frontend_ip_configuration {
+    name = "frontend-ip-configuration"
+  }
+}
+
+resource "azurerm_lb_rule" "basic" {
+  name                           = "http-rule"
+  loadbalancer_id                = azurerm_lb.basic.id
+  protocol                       = "Tcp"
+  frontend_port                  = 80
+  backend_port                   = 80
+  frontend_ip_configuration_name = "frontend-ip-configuration"
+}
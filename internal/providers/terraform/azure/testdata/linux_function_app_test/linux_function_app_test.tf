@@ -0,0 +1,87 @@
+provider "azurerm" {
+  skip_provider_registration = true
+  features {}
+}
+
+resource "azurerm_resource_group" "example" {
+  name     = "example-resources"
+  location = "eastus"
+}
+
+resource "azurerm_service_plan" "consumption" {
+  name                = "example-consumption"
+  resource_group_name = azurerm_resource_group.example.name
+  location            = azurerm_resource_group.example.location
+  os_type             = "Linux"
+  sku_name            = "Y1"
+}
+
+resource "azurerm_service_plan" "premium" {
+  name                = "example-premium"
+  resource_group_name = azurerm_resource_group.example.name
+  location            = azurerm_resource_group.example.location
+  os_type             = "Linux"
+  sku_name            = "EP2"
+}
+
+resource "azurerm_service_plan" "dedicated" {
+  name                = "example-dedicated"
+  resource_group_name = azurerm_resource_group.example.name
+  location            = azurerm_resource_group.example.location
+  os_type             = "Linux"
+  sku_name            = "P1v2"
+}
+
+resource "azurerm_linux_function_app" "consumption" {
+  name                = "example-consumption"
+  resource_group_name = azurerm_resource_group.example.name
+  location            = azurerm_resource_group.example.location
+  service_plan_id     = azurerm_service_plan.consumption.id
+
+  site_config {}
+}
+
+resource "azurerm_linux_function_app" "consumption_with_usage" {
+  name                = "example-consumption-with-usage"
+  resource_group_name = azurerm_resource_group.example.name
+  location            = azurerm_resource_group.example.location
+  service_plan_id     = azurerm_service_plan.consumption.id
+
+  site_config {}
+}
+
+resource "azurerm_linux_function_app" "consumption_with_gb_seconds" {
+  name                = "example-consumption-with-gb-seconds"
+  resource_group_name = azurerm_resource_group.example.name
+  location            = azurerm_resource_group.example.location
+  service_plan_id     = azurerm_service_plan.consumption.id
+
+  site_config {}
+}
+
+resource "azurerm_linux_function_app" "premium" {
+  name                = "example-premium"
+  resource_group_name = azurerm_resource_group.example.name
+  location            = azurerm_resource_group.example.location
+  service_plan_id     = azurerm_service_plan.premium.id
+
+  site_config {}
+}
+
+resource "azurerm_linux_function_app" "premium_with_usage" {
+  name                = "example-premium-with-usage"
+  resource_group_name = azurerm_resource_group.example.name
+  location            = azurerm_resource_group.example.location
+  service_plan_id     = azurerm_service_plan.premium.id
+
+  site_config {}
+}
+
+resource "azurerm_linux_function_app" "dedicated" {
+  name                = "example-dedicated"
+  resource_group_name = azurerm_resource_group.example.name
+  location            = azurerm_resource_group.example.location
+  service_plan_id     = azurerm_service_plan.dedicated.id
+
+  site_config {}
+}
@@ -0,0 +1,76 @@
+provider "azurerm" {
+  skip_provider_registration = true
+  features {}
+}
+
+resource "azurerm_resource_group" "example" {
+  name     = "example-resources"
+  location = "eastus"
+}
+
+resource "azurerm_mssql_managed_instance" "general_purpose" {
+  name                         = "example-mi-gp"
+  resource_group_name          = azurerm_resource_group.example.name
+  location                     = "eastus"
+  license_type                 = "LicenseIncluded"
+  sku_name                     = "GP_Gen5"
+  storage_size_in_gb           = 64
+  subnet_id                    = "fake"
+  vcores                       = 8
+  administrator_login          = "fake"
+  administrator_login_password = "fake"
+}
+
+resource "azurerm_mssql_managed_instance" "business_critical_lrs" {
+  name                         = "example-mi-bc"
+  resource_group_name          = azurerm_resource_group.example.name
+  location                     = "eastus"
+  license_type                 = "LicenseIncluded"
+  sku_name                     = "BC_Gen5"
+  storage_size_in_gb           = 256
+  storage_account_type         = "LRS"
+  subnet_id                    = "fake"
+  vcores                       = 4
+  administrator_login          = "fake"
+  administrator_login_password = "fake"
+}
+
+resource "azurerm_mssql_managed_instance" "base_price" {
+  name                         = "example-mi-base-price"
+  resource_group_name          = azurerm_resource_group.example.name
+  location                     = "eastus"
+  license_type                 = "BasePrice"
+  sku_name                     = "GP_Gen5"
+  storage_size_in_gb           = 32
+  storage_account_type         = "ZRS"
+  subnet_id                    = "fake"
+  vcores                       = 4
+  administrator_login          = "fake"
+  administrator_login_password = "fake"
+}
+
+resource "azurerm_mssql_managed_instance" "with_usage" {
+  name                         = "example-mi-with-usage"
+  resource_group_name          = azurerm_resource_group.example.name
+  location                     = "eastus"
+  license_type                 = "LicenseIncluded"
+  sku_name                     = "GP_Gen5"
+  storage_size_in_gb           = 128
+  subnet_id                    = "fake"
+  vcores                       = 8
+  administrator_login          = "fake"
+  administrator_login_password = "fake"
+}
+
+resource "azurerm_mssql_managed_instance" "dev_test" {
+  name                         = "example-mi-dev-test"
+  resource_group_name          = azurerm_resource_group.example.name
+  location                     = "eastus"
+  license_type                 = "LicenseIncluded"
+  sku_name                     = "BC_Gen5"
+  storage_size_in_gb           = 32
+  subnet_id                    = "fake"
+  vcores                       = 4
+  administrator_login          = "fake"
+  administrator_login_password = "fake"
+}
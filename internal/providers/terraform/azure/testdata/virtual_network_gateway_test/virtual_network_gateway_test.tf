@@ -0,0 +1,99 @@
+provider "azurerm" {
+  skip_provider_registration = true
+  features {}
+}
+
+resource "azurerm_resource_group" "example" {
+  name     = "example-resources"
+  location = "eastus"
+}
+
+resource "azurerm_virtual_network" "example" {
+  name                = "example-network"
+  location            = azurerm_resource_group.example.location
+  resource_group_name = azurerm_resource_group.example.name
+  address_space       = ["10.0.0.0/16"]
+}
+
+resource "azurerm_subnet" "gateway" {
+  name                 = "GatewaySubnet"
+  resource_group_name  = azurerm_resource_group.example.name
+  virtual_network_name = azurerm_virtual_network.example.name
+  address_prefixes     = ["10.0.1.0/24"]
+}
+
+resource "azurerm_public_ip" "example" {
+  name                = "example-pip"
+  location            = azurerm_resource_group.example.location
+  resource_group_name = azurerm_resource_group.example.name
+  allocation_method   = "Dynamic"
+}
+
+resource "azurerm_virtual_network_gateway" "basic" {
+  name                = "example-basic"
+  location            = azurerm_resource_group.example.location
+  resource_group_name = azurerm_resource_group.example.name
+  type                = "Vpn"
+  vpn_type            = "RouteBased"
+  sku                 = "Basic"
+
+  ip_configuration {
+    public_ip_address_id = azurerm_public_ip.example.id
+    subnet_id            = azurerm_subnet.gateway.id
+  }
+}
+
+resource "azurerm_virtual_network_gateway" "vpn_gw1" {
+  name                = "example-vpn-gw1"
+  location            = azurerm_resource_group.example.location
+  resource_group_name = azurerm_resource_group.example.name
+  type                = "Vpn"
+  vpn_type            = "RouteBased"
+  sku                 = "VpnGw1"
+
+  ip_configuration {
+    public_ip_address_id = azurerm_public_ip.example.id
+    subnet_id            = azurerm_subnet.gateway.id
+  }
+}
+
+resource "azurerm_virtual_network_gateway" "vpn_gw2az_with_usage" {
+  name                = "example-vpn-gw2az-with-usage"
+  location            = azurerm_resource_group.example.location
+  resource_group_name = azurerm_resource_group.example.name
+  type                = "Vpn"
+  vpn_type            = "RouteBased"
+  sku                 = "VpnGw2AZ"
+
+  ip_configuration {
+    public_ip_address_id = azurerm_public_ip.example.id
+    subnet_id            = azurerm_subnet.gateway.id
+  }
+}
+
+resource "azurerm_virtual_network_gateway" "vpn_gw1_with_included_connections" {
+  name                = "example-vpn-gw1-with-included-connections"
+  location            = azurerm_resource_group.example.location
+  resource_group_name = azurerm_resource_group.example.name
+  type                = "Vpn"
+  vpn_type            = "RouteBased"
+  sku                 = "VpnGw1"
+
+  ip_configuration {
+    public_ip_address_id = azurerm_public_ip.example.id
+    subnet_id            = azurerm_subnet.gateway.id
+  }
+}
+
+resource "azurerm_virtual_network_gateway" "express_route" {
+  name                = "example-express-route"
+  location            = azurerm_resource_group.example.location
+  resource_group_name = azurerm_resource_group.example.name
+  type                = "ExpressRoute"
+  sku                 = "ErGw1AZ"
+
+  ip_configuration {
+    public_ip_address_id = azurerm_public_ip.example.id
+    subnet_id            = azurerm_subnet.gateway.id
+  }
+}
@@ -31,9 +31,9 @@ func NewAzureRMVirtualMachine(d *schema.ResourceData, u *schema.UsageData) *sche
 
 	if os == "Windows" {
 		licenseType := d.Get("license_type").String()
-		costComponents = append(costComponents, windowsVirtualMachineCostComponent(region, instanceType, licenseType))
+		costComponents = append(costComponents, windowsVirtualMachineCostComponent(region, instanceType, licenseType, "", u))
 	} else {
-		costComponents = append(costComponents, linuxVirtualMachineCostComponent(region, instanceType))
+		costComponents = append(costComponents, linuxVirtualMachineCostComponent(region, instanceType, ""))
 	}
 
 	costComponents = append(costComponents, ultraSSDReservationCostComponent(region))
@@ -101,6 +101,28 @@ func legacyOSDiskSubResource(region string, diskData gjson.Result, monthlyDiskOp
 	}
 }
 
+// dataDiskSubResources returns a sub resource for each of the data_disk
+// blocks of a scale set. The monthly disk operations usage is per disk.
+func dataDiskSubResources(region string, d *schema.ResourceData, u *schema.UsageData) []*schema.Resource {
+	subResources := make([]*schema.Resource, 0)
+
+	var monthlyDiskOperations *decimal.Decimal
+	if u != nil && u.Get("data_disk.monthly_disk_operations").Exists() {
+		monthlyDiskOperations = decimalPtr(decimal.NewFromInt(u.Get("data_disk.monthly_disk_operations").Int()))
+	}
+
+	for _, diskData := range d.Get("data_disk").Array() {
+		diskType := diskData.Get("storage_account_type").String()
+
+		subResources = append(subResources, &schema.Resource{
+			Name:           "data_disk",
+			CostComponents: managedDiskCostComponents(region, diskType, diskData, monthlyDiskOperations),
+		})
+	}
+
+	return subResources
+}
+
 func osDiskSubResource(region string, d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 	if len(d.Get("os_disk").Array()) == 0 {
 		return nil
@@ -0,0 +1,128 @@
+package azure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+)
+
+// includedP2SConnections is the number of P2S connections included in the
+// price of the VPN gateway.
+const includedP2SConnections = 128
+
+func GetAzureRMVirtualNetworkGatewayRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "azurerm_virtual_network_gateway",
+		RFunc: NewAzureRMVirtualNetworkGateway,
+		ReferenceAttributes: []string{
+			"resource_group_name",
+		},
+	}
+}
+
+func NewAzureRMVirtualNetworkGateway(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := lookupRegion(d, []string{"resource_group_name"})
+
+	sku := d.Get("sku").String()
+	if sku == "" {
+		sku = "Basic"
+	}
+
+	if strings.EqualFold(d.Get("type").String(), "ExpressRoute") {
+		return &schema.Resource{
+			Name: d.Address,
+			CostComponents: []*schema.CostComponent{
+				virtualNetworkGatewayCostComponent(fmt.Sprintf("ExpressRoute gateway (%s)", sku), region, "ExpressRoute", "ExpressRoute Gateway", sku),
+			},
+		}
+	}
+
+	costComponents := []*schema.CostComponent{
+		virtualNetworkGatewayCostComponent(fmt.Sprintf("VPN gateway (%s)", sku), region, "VPN Gateway", "VPN Gateway", sku),
+	}
+
+	// The first 128 P2S connections are included for all SKUs other than Basic,
+	// which doesn't support the additional connections.
+	if !strings.EqualFold(sku, "Basic") {
+		var p2sConnections *decimal.Decimal
+		if u != nil && u.Get("p2s_connections").Exists() {
+			p2sConnections = decimalPtr(decimal.Max(decimal.NewFromInt(u.Get("p2s_connections").Int()-includedP2SConnections), decimal.Zero))
+		}
+
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:           fmt.Sprintf("VPN gateway P2S connections (over %d)", includedP2SConnections),
+			Unit:           "connections",
+			UnitMultiplier: schema.HourToMonthUnitMultiplier,
+			HourlyQuantity: p2sConnections,
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("azure"),
+				Region:        strPtr(region),
+				Service:       strPtr("VPN Gateway"),
+				ProductFamily: strPtr("Networking"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "productName", Value: strPtr("VPN Gateway")},
+					{Key: "skuName", Value: strPtr(sku)},
+					{Key: "meterName", ValueRegex: strPtr("/P2S Connection/i")},
+				},
+			},
+			PriceFilter: &schema.PriceFilter{
+				PurchaseOption: strPtr("Consumption"),
+			},
+		})
+	}
+
+	var dataTransfer *decimal.Decimal
+	if u != nil && u.Get("monthly_data_transfer_gb").Exists() {
+		dataTransfer = decimalPtr(decimal.NewFromFloat(u.Get("monthly_data_transfer_gb").Float()))
+	}
+
+	costComponents = append(costComponents, &schema.CostComponent{
+		Name:            "VPN gateway data transfer",
+		Unit:            "GB",
+		UnitMultiplier:  1,
+		MonthlyQuantity: dataTransfer,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("azure"),
+			Region:        strPtr(region),
+			Service:       strPtr("VPN Gateway"),
+			ProductFamily: strPtr("Networking"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "productName", Value: strPtr("VPN Gateway Bandwidth")},
+				{Key: "meterName", ValueRegex: strPtr("/Inter-Virtual Network Data Transfer Out/i")},
+			},
+		},
+		PriceFilter: &schema.PriceFilter{
+			PurchaseOption: strPtr("Consumption"),
+		},
+	})
+
+	return &schema.Resource{
+		Name:           d.Address,
+		CostComponents: costComponents,
+	}
+}
+
+func virtualNetworkGatewayCostComponent(name, region, service, productName, sku string) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:           name,
+		Unit:           "hours",
+		UnitMultiplier: 1,
+		HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("azure"),
+			Region:        strPtr(region),
+			Service:       strPtr(service),
+			ProductFamily: strPtr("Networking"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "productName", Value: strPtr(productName)},
+				{Key: "skuName", Value: strPtr(sku)},
+				{Key: "meterName", Value: strPtr(sku)},
+			},
+		},
+		PriceFilter: &schema.PriceFilter{
+			PurchaseOption: strPtr("Consumption"),
+		},
+	}
+}
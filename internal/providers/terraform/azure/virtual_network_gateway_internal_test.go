@@ -0,0 +1,58 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func testVirtualNetworkGateway(gatewayType, sku string) *schema.ResourceData {
+	return schema.NewResourceData("azurerm_virtual_network_gateway", "azurerm", "azurerm_virtual_network_gateway.gateway", nil, gjson.Parse(`{
+		"location": "eastus",
+		"type": "`+gatewayType+`",
+		"sku": "`+sku+`"
+	}`))
+}
+
+func TestVirtualNetworkGatewayVpn(t *testing.T) {
+	u := &schema.UsageData{
+		Attributes: map[string]gjson.Result{
+			"p2s_connections":          gjson.Parse(`200`),
+			"monthly_data_transfer_gb": gjson.Parse(`100.5`),
+		},
+	}
+
+	r := NewAzureRMVirtualNetworkGateway(testVirtualNetworkGateway("Vpn", "VpnGw1"), u)
+	assert.Equal(t, 3, len(r.CostComponents))
+
+	assert.Equal(t, "VPN gateway (VpnGw1)", r.CostComponents[0].Name)
+
+	assert.Equal(t, "VPN gateway P2S connections (over 128)", r.CostComponents[1].Name)
+	assert.Equal(t, true, decimal.NewFromInt(72).Equal(*r.CostComponents[1].HourlyQuantity))
+
+	assert.Equal(t, "VPN gateway data transfer", r.CostComponents[2].Name)
+	assert.Equal(t, true, decimal.NewFromFloat(100.5).Equal(*r.CostComponents[2].MonthlyQuantity))
+
+	// The included connections aren't charged for
+	u.Attributes["p2s_connections"] = gjson.Parse(`50`)
+	r = NewAzureRMVirtualNetworkGateway(testVirtualNetworkGateway("Vpn", "VpnGw1"), u)
+	assert.Equal(t, true, r.CostComponents[1].HourlyQuantity.IsZero())
+}
+
+func TestVirtualNetworkGatewayBasic(t *testing.T) {
+	r := NewAzureRMVirtualNetworkGateway(testVirtualNetworkGateway("Vpn", ""), nil)
+	assert.Equal(t, 2, len(r.CostComponents))
+	assert.Equal(t, "VPN gateway (Basic)", r.CostComponents[0].Name)
+	assert.Equal(t, "VPN gateway data transfer", r.CostComponents[1].Name)
+}
+
+func TestVirtualNetworkGatewayExpressRoute(t *testing.T) {
+	r := NewAzureRMVirtualNetworkGateway(testVirtualNetworkGateway("ExpressRoute", "ErGw1AZ"), nil)
+	assert.Equal(t, 1, len(r.CostComponents))
+	assert.Equal(t, "ExpressRoute gateway (ErGw1AZ)", r.CostComponents[0].Name)
+	assert.Equal(t, "ExpressRoute", *r.CostComponents[0].ProductFilter.Service)
+}
@@ -0,0 +1,19 @@
+package azure
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetAzureRMWindowsFunctionAppRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "azurerm_windows_function_app",
+		RFunc: NewAzureRMWindowsFunctionApp,
+		ReferenceAttributes: []string{
+			"service_plan_id",
+		},
+	}
+}
+
+func NewAzureRMWindowsFunctionApp(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	return newAzureRMServicePlanFunctionApp(d, u)
+}
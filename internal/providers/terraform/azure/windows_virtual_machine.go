@@ -13,7 +13,8 @@ func GetAzureRMWindowsVirtualMachineRegistryItem() *schema.RegistryItem {
 		Name:  "azurerm_windows_virtual_machine",
 		RFunc: NewAzureRMWindowsVirtualMachine,
 		Notes: []string{
-			"Low priority, Spot and Reserved instances are not supported.",
+			"Low priority and Reserved instances are not supported.",
+			"Azure Hybrid Benefit and Dev/Test pricing can be set with the hybrid_benefit and dev_test usage keys.",
 		},
 	}
 }
@@ -24,7 +25,7 @@ func NewAzureRMWindowsVirtualMachine(d *schema.ResourceData, u *schema.UsageData
 	instanceType := d.Get("size").String()
 	licenseType := d.Get("license_type").String()
 
-	costComponents := []*schema.CostComponent{windowsVirtualMachineCostComponent(region, instanceType, licenseType)}
+	costComponents := []*schema.CostComponent{windowsVirtualMachineCostComponent(region, instanceType, licenseType, d.Get("priority").String(), u)}
 
 	if d.Get("additional_capabilities.0.ultra_ssd_enabled").Bool() {
 		costComponents = append(costComponents, ultraSSDReservationCostComponent(region))
@@ -44,7 +45,7 @@ func NewAzureRMWindowsVirtualMachine(d *schema.ResourceData, u *schema.UsageData
 	}
 }
 
-func windowsVirtualMachineCostComponent(region string, instanceType string, licenseType string) *schema.CostComponent {
+func windowsVirtualMachineCostComponent(region string, instanceType string, licenseType string, priority string, u *schema.UsageData) *schema.CostComponent {
 	purchaseOption := "Consumption"
 	purchaseOptionLabel := "pay as you go"
 
@@ -53,8 +54,19 @@ func windowsVirtualMachineCostComponent(region string, instanceType string, lice
 		productNameRe = "/Virtual Machines .* Series Basic Windows$/"
 	}
 
-	// Handle Azure Hybrid Benefit
-	if licenseType == "Windows_Client" || licenseType == "Windows_Server" {
+	skuNameRe := "/^(?!.*(Low Priority|Spot)$).*$/i"
+
+	// Handle Azure Hybrid Benefit and Dev/Test subscriptions, which both
+	// remove the Windows license so they use the Dev/Test price. Spot VMs are
+	// always priced at the Spot price since they aren't applied to them.
+	hybridBenefit := usageHybridBenefit(u, licenseType == "Windows_Client" || licenseType == "Windows_Server")
+	if strings.EqualFold(priority, "Spot") {
+		purchaseOptionLabel = "spot"
+		skuNameRe = "/ Spot$/i"
+	} else if usageDevTest(u) {
+		purchaseOption = "DevTestConsumption"
+		purchaseOptionLabel = "dev/test"
+	} else if hybridBenefit {
 		purchaseOption = "DevTestConsumption"
 		purchaseOptionLabel = "hybrid benefit"
 	}
@@ -70,7 +82,7 @@ func windowsVirtualMachineCostComponent(region string, instanceType string, lice
 			Service:       strPtr("Virtual Machines"),
 			ProductFamily: strPtr("Compute"),
 			AttributeFilters: []*schema.AttributeFilter{
-				{Key: "skuName", ValueRegex: strPtr("/^(?!.*(Low Priority|Spot)$).*$/i")},
+				{Key: "skuName", ValueRegex: strPtr(skuNameRe)},
 				{Key: "armSkuName", ValueRegex: strPtr(fmt.Sprintf("/^%s$/i", instanceType))},
 				{Key: "productName", ValueRegex: strPtr(productNameRe)},
 			},
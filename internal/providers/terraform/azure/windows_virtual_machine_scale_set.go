@@ -10,6 +10,9 @@ func GetAzureRMWindowsVirtualMachineScaleSetRegistryItem() *schema.RegistryItem
 	return &schema.RegistryItem{
 		Name:  "azurerm_windows_virtual_machine_scale_set",
 		RFunc: NewAzureRMWindowsVirtualMachineScaleSet,
+		Notes: []string{
+			"Azure Hybrid Benefit and Dev/Test pricing can be set with the hybrid_benefit and dev_test usage keys.",
+		},
 	}
 }
 
@@ -19,7 +22,7 @@ func NewAzureRMWindowsVirtualMachineScaleSet(d *schema.ResourceData, u *schema.U
 	instanceType := d.Get("sku").String()
 	licenseType := d.Get("license_type").String()
 
-	costComponents := []*schema.CostComponent{windowsVirtualMachineCostComponent(region, instanceType, licenseType)}
+	costComponents := []*schema.CostComponent{windowsVirtualMachineCostComponent(region, instanceType, licenseType, d.Get("priority").String(), u)}
 
 	if d.Get("additional_capabilities.0.ultra_ssd_enabled").Bool() {
 		costComponents = append(costComponents, ultraSSDReservationCostComponent(region))
@@ -31,10 +34,11 @@ func NewAzureRMWindowsVirtualMachineScaleSet(d *schema.ResourceData, u *schema.U
 	if osDisk != nil {
 		subResources = append(subResources, osDisk)
 	}
+	subResources = append(subResources, dataDiskSubResources(region, d, u)...)
 
 	instanceCount := decimal.NewFromInt(d.Get("instances").Int())
 	if u != nil && u.Get("instances").Type != gjson.Null {
-		instanceCount = decimal.NewFromInt(u.Get("instances").Int())
+		instanceCount = decimal.NewFromFloat(u.Get("instances").Float())
 	}
 
 	r := &schema.Resource{
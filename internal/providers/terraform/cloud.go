@@ -52,6 +52,29 @@ func cloudAPI(host string, path string, token string) ([]byte, error) {
 	return ioutil.ReadAll(resp.Body)
 }
 
+// cloudRunPlanJSON downloads the plan JSON of a Terraform Cloud run.
+func cloudRunPlanJSON(host string, runID string, token string) ([]byte, error) {
+	body, err := cloudAPI(host, fmt.Sprintf("/api/v2/runs/%s/plan", runID), token)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	var parsedResp struct {
+		Data struct {
+			Links map[string]string
+		}
+	}
+	if json.Unmarshal(body, &parsedResp) != nil {
+		return []byte{}, err
+	}
+
+	jsonPath, ok := parsedResp.Data.Links["json-output"]
+	if !ok || jsonPath == "" {
+		return []byte{}, errors.New("Could not parse path to plan JSON from remote")
+	}
+	return cloudAPI(host, jsonPath, token)
+}
+
 func findCloudToken(host string) string {
 	if os.Getenv("TF_CLI_CONFIG_FILE") != "" {
 		log.Debugf("TF_CLI_CONFIG_FILE is set, checking %s for Terraform Cloud credentials", os.Getenv("TF_CLI_CONFIG_FILE"))
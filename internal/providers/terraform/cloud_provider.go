@@ -0,0 +1,152 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/pkg/errors"
+)
+
+var defaultCloudHost = "app.terraform.io"
+
+// plannedRunStatuses are the statuses of runs that have a finished plan.
+var plannedRunStatuses = map[string]bool{
+	"planned":              true,
+	"planned_and_finished": true,
+	"cost_estimating":      true,
+	"cost_estimated":       true,
+	"policy_checking":      true,
+	"policy_checked":       true,
+	"policy_override":      true,
+	"policy_soft_failed":   true,
+	"post_plan_running":    true,
+	"post_plan_completed":  true,
+	"confirmed":            true,
+	"apply_queued":         true,
+	"applying":             true,
+	"applied":              true,
+}
+
+// CloudProvider gets the plan JSON of the latest run of a Terraform Cloud or
+// Terraform Enterprise workspace, so Terraform doesn't need to be run locally.
+type CloudProvider struct {
+	Host        string
+	Token       string
+	Org         string
+	Workspace   string
+	env         *config.Environment
+	spinnerOpts ui.SpinnerOptions
+}
+
+func NewCloudProvider(cfg *config.Config, projectCfg *config.Project) schema.Provider {
+	host := projectCfg.TerraformCloudHost
+	if host == "" {
+		host = defaultCloudHost
+	}
+
+	return &CloudProvider{
+		Host:      host,
+		Token:     projectCfg.TerraformCloudToken,
+		Org:       projectCfg.TerraformCloudOrg,
+		Workspace: projectCfg.TerraformCloudWorkspace,
+		env:       cfg.Environment,
+		spinnerOpts: ui.SpinnerOptions{
+			EnableLogging: cfg.IsLogging(),
+			NoColor:       cfg.NoColor,
+		},
+	}
+}
+
+func (p *CloudProvider) Type() string {
+	return "terraform_cloud"
+}
+
+func (p *CloudProvider) DisplayType() string {
+	return fmt.Sprintf("Terraform Cloud workspace %s/%s", p.Org, p.Workspace)
+}
+
+func (p *CloudProvider) AddMetadata(metadata *schema.ProjectMetadata) {
+	metadata.TerraformWorkspace = p.Workspace
+	if metadata.Path == "" {
+		metadata.Path = fmt.Sprintf("%s/%s", p.Org, p.Workspace)
+	}
+}
+
+func (p *CloudProvider) LoadResources(project *schema.Project, usage map[string]*schema.UsageData) error {
+	spinner := ui.NewSpinner("Downloading plan JSON from Terraform Cloud", p.spinnerOpts)
+
+	j, err := p.latestPlanJSON()
+	if err != nil {
+		spinner.Fail()
+		return err
+	}
+
+	spinner.Success()
+
+	return LoadPlanJSONResources(p.env, project, j, usage)
+}
+
+func (p *CloudProvider) latestPlanJSON() ([]byte, error) {
+	token := p.Token
+	if token == "" {
+		token = findCloudToken(p.Host)
+	}
+	if token == "" {
+		return []byte{}, ErrMissingCloudToken
+	}
+
+	body, err := cloudAPI(p.Host, fmt.Sprintf("/api/v2/organizations/%s/workspaces/%s", url.PathEscape(p.Org), url.PathEscape(p.Workspace)), token)
+	if err != nil {
+		return []byte{}, errors.Wrapf(err, "Error getting Terraform Cloud workspace %s/%s", p.Org, p.Workspace)
+	}
+
+	var workspace struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &workspace); err != nil || workspace.Data.ID == "" {
+		return []byte{}, errors.New("Could not parse Terraform Cloud workspace ID")
+	}
+
+	body, err = cloudAPI(p.Host, fmt.Sprintf("/api/v2/workspaces/%s/runs?page%%5Bsize%%5D=20", workspace.Data.ID), token)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "Error getting Terraform Cloud runs")
+	}
+
+	runID, err := latestPlannedRunID(body)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return cloudRunPlanJSON(p.Host, runID, token)
+}
+
+// latestPlannedRunID returns the ID of the most recent run that has a
+// finished plan from the Terraform Cloud list runs response.
+func latestPlannedRunID(body []byte) (string, error) {
+	var runs struct {
+		Data []struct {
+			ID         string `json:"id"`
+			Attributes struct {
+				Status string `json:"status"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &runs); err != nil {
+		return "", errors.Wrap(err, "Could not parse Terraform Cloud runs")
+	}
+
+	// Runs are returned newest first
+	for _, r := range runs.Data {
+		if plannedRunStatuses[r.Attributes.Status] {
+			return r.ID, nil
+		}
+	}
+
+	return "", errors.New("No Terraform Cloud runs with a finished plan were found for the workspace")
+}
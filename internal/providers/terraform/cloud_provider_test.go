@@ -0,0 +1,26 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestPlannedRunID(t *testing.T) {
+	body := []byte(`{
+		"data": [
+			{"id": "run-planning", "attributes": {"status": "planning"}},
+			{"id": "run-errored", "attributes": {"status": "errored"}},
+			{"id": "run-planned", "attributes": {"status": "planned_and_finished"}},
+			{"id": "run-applied", "attributes": {"status": "applied"}}
+		]
+	}`)
+
+	id, err := latestPlannedRunID(body)
+	require.NoError(t, err)
+	assert.Equal(t, "run-planned", id)
+
+	_, err = latestPlannedRunID([]byte(`{"data": [{"id": "run-pending", "attributes": {"status": "pending"}}]}`))
+	assert.Error(t, err)
+}
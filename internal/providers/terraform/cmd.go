@@ -9,6 +9,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	log "github.com/sirupsen/logrus"
@@ -21,6 +22,36 @@ type CmdOptions struct {
 	Dir                 string
 	TerraformWorkspace  string
 	TerraformConfigFile string
+	// Env is added to the environment of the command
+	Env map[string]string
+}
+
+// redactVarFlags returns the args with the values of the -var flags redacted
+// so they can be logged, since Terraform variables can contain secrets.
+func redactVarFlags(args []string) []string {
+	redacted := make([]string, 0, len(args))
+	for i, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "-var="):
+			arg = "-var=" + redactVarValue(strings.TrimPrefix(arg, "-var="))
+		case i > 0 && args[i-1] == "-var":
+			arg = redactVarValue(arg)
+		}
+
+		redacted = append(redacted, arg)
+	}
+
+	return redacted
+}
+
+// redactVarValue redacts the value of a name=value var.
+func redactVarValue(v string) string {
+	parts := strings.SplitN(v, "=", 2)
+	if len(parts) != 2 {
+		return v
+	}
+
+	return parts[0] + "=REDACTED"
 }
 
 type CmdError struct {
@@ -39,7 +70,7 @@ func Cmd(opts *CmdOptions, args ...string) ([]byte, error) {
 	}
 
 	cmd := exec.Command(exe, args...)
-	log.Infof("Running command: %s", cmd.String())
+	log.Infof("Running command: %s", strings.Join(append([]string{cmd.Path}, redactVarFlags(args)...), " "))
 	cmd.Dir = opts.Dir
 	cmd.Env = os.Environ()
 	cmd.Env = append(cmd.Env, "TF_IN_AUTOMATION=true")
@@ -52,6 +83,10 @@ func Cmd(opts *CmdOptions, args ...string) ([]byte, error) {
 		cmd.Env = append(cmd.Env, fmt.Sprintf("TF_CLI_CONFIG_FILE=%s", opts.TerraformConfigFile))
 	}
 
+	for k, v := range opts.Env {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", k, v))
+	}
+
 	logWriter := &cmdLogWriter{
 		logger: log.StandardLogger().WithField("binary", "terraform"),
 		level:  log.DebugLevel,
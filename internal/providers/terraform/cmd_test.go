@@ -0,0 +1,14 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactVarFlags(t *testing.T) {
+	args := []string{"plan", "-input=false", "-var-file=prod.tfvars", "-var=region=us-east-1", "-var=db_password=hunter2=", "-var", "token=abc", "-var=invalid"}
+	expected := []string{"plan", "-input=false", "-var-file=prod.tfvars", "-var=region=REDACTED", "-var=db_password=REDACTED", "-var", "token=REDACTED", "-var=invalid"}
+
+	assert.Equal(t, expected, redactVarFlags(args))
+}
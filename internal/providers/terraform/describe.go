@@ -0,0 +1,225 @@
+package terraform
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+)
+
+// resourceDescriptionVersion is the version of the resource description JSON,
+// it should be increased whenever a field is changed or removed so tools that
+// read it can check they support it.
+const resourceDescriptionVersion = "0.1"
+
+// ResourceDescription describes what Infracost prices for a resource type, so
+// tools like usage file editors can stay in sync with the resource registry.
+type ResourceDescription struct {
+	Version             string                     `json:"version"`
+	ResourceType        string                     `json:"resourceType"`
+	Free                bool                       `json:"free"`
+	Notes               []string                   `json:"notes,omitempty"`
+	ReferenceAttributes []string                   `json:"referenceAttributes,omitempty"`
+	CostComponents      []CostComponentDescription `json:"costComponents"`
+	SubResources        []SubResourceDescription   `json:"subResources,omitempty"`
+	UsageKeys           []UsageKeyDescription      `json:"usageKeys"`
+}
+
+type CostComponentDescription struct {
+	Name string `json:"name"`
+	Unit string `json:"unit"`
+}
+
+type SubResourceDescription struct {
+	Name           string                     `json:"name"`
+	CostComponents []CostComponentDescription `json:"costComponents"`
+	SubResources   []SubResourceDescription   `json:"subResources,omitempty"`
+}
+
+type UsageKeyDescription struct {
+	Key          string      `json:"key"`
+	Type         string      `json:"type"`
+	DefaultValue interface{} `json:"defaultValue"`
+	Description  string      `json:"description,omitempty"`
+}
+
+// The support levels of resource types. Usage-based resources have costs that
+// depend on the values of the usage file, e.g. the requests of a Lambda
+// function, so they're only fully priced when the usage file is set.
+const (
+	SupportLevelFull       = "full"
+	SupportLevelUsageBased = "usage-based"
+	SupportLevelFree       = "free"
+)
+
+// ResourceSupport is the Terraform provider and support level of a resource
+// type.
+type ResourceSupport struct {
+	ResourceType string `json:"resourceType"`
+	Provider     string `json:"provider"`
+	Support      string `json:"support"`
+}
+
+// SupportedResourceTypes returns the resource types in the registry, sorted
+// by name.
+func SupportedResourceTypes() []string {
+	registryMap := GetResourceRegistryMap()
+
+	types := make([]string, 0, len(*registryMap))
+	for t := range *registryMap {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	return types
+}
+
+// ResourceSupportMatrix returns the support of the resource types in the
+// registry, sorted by name. If provider is set only the resource types of that
+// provider are returned.
+func ResourceSupportMatrix(provider string) ([]ResourceSupport, error) {
+	matrix := make([]ResourceSupport, 0)
+
+	for _, resourceType := range SupportedResourceTypes() {
+		resourceProvider := resourceTypeProvider(resourceType)
+		if provider != "" && resourceProvider != provider {
+			continue
+		}
+
+		desc, err := DescribeResource(resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		support := SupportLevelFull
+		if desc.Free {
+			support = SupportLevelFree
+		} else if len(desc.UsageKeys) > 0 {
+			support = SupportLevelUsageBased
+		}
+
+		matrix = append(matrix, ResourceSupport{
+			ResourceType: resourceType,
+			Provider:     resourceProvider,
+			Support:      support,
+		})
+	}
+
+	return matrix, nil
+}
+
+// resourceTypeProvider returns the name of the Terraform provider of the
+// resource type, which is the prefix of its name, e.g. aws or azurerm.
+func resourceTypeProvider(resourceType string) string {
+	return strings.SplitN(resourceType, "_", 2)[0]
+}
+
+// DescribeResource returns the cost components and usage keys of the resource
+// type. The cost components are the ones the resource has when none of its
+// arguments are set, so components that depend on the resource's arguments,
+// e.g. the storage type, might not be included.
+func DescribeResource(resourceType string) (*ResourceDescription, error) {
+	registryItem, ok := (*GetResourceRegistryMap())[resourceType]
+	if !ok {
+		return nil, fmt.Errorf("Resource type %s is not supported", resourceType)
+	}
+
+	desc := &ResourceDescription{
+		Version:             resourceDescriptionVersion,
+		ResourceType:        resourceType,
+		Free:                registryItem.NoPrice,
+		Notes:               registryItem.Notes,
+		ReferenceAttributes: registryItem.ReferenceAttributes,
+		CostComponents:      []CostComponentDescription{},
+		UsageKeys:           []UsageKeyDescription{},
+	}
+
+	if registryItem.NoPrice {
+		return desc, nil
+	}
+
+	r := exampleResource(registryItem)
+	if r == nil {
+		r = &schema.Resource{ResourceType: resourceType}
+	}
+	r.ResourceType = resourceType
+
+	desc.CostComponents = describeCostComponents(r.CostComponents)
+	for _, s := range r.SubResources {
+		desc.SubResources = append(desc.SubResources, describeSubResource(s))
+	}
+
+	usageSchema, err := usage.ResourceUsageSchema(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error loading the usage keys")
+	}
+
+	for _, s := range usageSchema {
+		desc.UsageKeys = append(desc.UsageKeys, UsageKeyDescription{
+			Key:          s.Key,
+			Type:         usageValueTypeName(s.ValueType),
+			DefaultValue: usage.DefaultUsageValue(s),
+			Description:  s.Description,
+		})
+	}
+
+	return desc, nil
+}
+
+// exampleResource creates the resource with none of its arguments set and no
+// usage. Some resources expect arguments to be set, so it returns nil if
+// creating the resource panics.
+func exampleResource(registryItem *schema.RegistryItem) (r *schema.Resource) {
+	defer func() {
+		if err := recover(); err != nil {
+			log.Debugf("Error creating an example %s resource: %v", registryItem.Name, err)
+			r = nil
+		}
+	}()
+
+	d := schema.NewResourceData(registryItem.Name, "", registryItem.Name+".example", map[string]string{}, gjson.Parse("{}"))
+	return registryItem.RFunc(d, nil)
+}
+
+func describeCostComponents(costComponents []*schema.CostComponent) []CostComponentDescription {
+	descs := make([]CostComponentDescription, 0, len(costComponents))
+	for _, c := range costComponents {
+		descs = append(descs, CostComponentDescription{
+			Name: c.Name,
+			Unit: c.Unit,
+		})
+	}
+
+	return descs
+}
+
+func describeSubResource(r *schema.Resource) SubResourceDescription {
+	desc := SubResourceDescription{
+		Name:           r.Name,
+		CostComponents: describeCostComponents(r.CostComponents),
+	}
+
+	for _, s := range r.SubResources {
+		desc.SubResources = append(desc.SubResources, describeSubResource(s))
+	}
+
+	return desc
+}
+
+func usageValueTypeName(t schema.UsageVariableType) string {
+	switch t {
+	case schema.Float64:
+		return "float"
+	case schema.String:
+		return "string"
+	case schema.Bool:
+		return "boolean"
+	default:
+		return "integer"
+	}
+}
@@ -0,0 +1,75 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribeResource(t *testing.T) {
+	desc, err := DescribeResource("aws_lambda_function")
+	require.NoError(t, err)
+
+	assert.Equal(t, resourceDescriptionVersion, desc.Version)
+	assert.False(t, desc.Free)
+	assert.Equal(t, []CostComponentDescription{
+		{Name: "Requests", Unit: "1M requests"},
+		{Name: "Duration", Unit: "GB-seconds"},
+	}, desc.CostComponents)
+
+	keys := make([]string, 0, len(desc.UsageKeys))
+	for _, k := range desc.UsageKeys {
+		keys = append(keys, k.Key)
+		assert.Equal(t, "integer", k.Type)
+		assert.NotEmpty(t, k.Description)
+	}
+	assert.Equal(t, []string{"monthly_requests", "request_duration_ms"}, keys)
+}
+
+func TestDescribeResource_subResources(t *testing.T) {
+	desc, err := DescribeResource("aws_instance")
+	require.NoError(t, err)
+
+	require.Len(t, desc.SubResources, 1)
+	assert.Equal(t, "root_block_device", desc.SubResources[0].Name)
+	assert.NotEmpty(t, desc.SubResources[0].CostComponents)
+}
+
+func TestDescribeResource_free(t *testing.T) {
+	desc, err := DescribeResource("aws_iam_role")
+	require.NoError(t, err)
+
+	assert.True(t, desc.Free)
+	assert.Empty(t, desc.CostComponents)
+	assert.Empty(t, desc.UsageKeys)
+}
+
+func TestDescribeResource_unsupported(t *testing.T) {
+	_, err := DescribeResource("aws_unknown")
+	assert.EqualError(t, err, "Resource type aws_unknown is not supported")
+}
+
+func TestDescribeResource_allResources(t *testing.T) {
+	for _, resourceType := range SupportedResourceTypes() {
+		_, err := DescribeResource(resourceType)
+		assert.NoError(t, err, resourceType)
+	}
+}
+
+func TestResourceSupportMatrix(t *testing.T) {
+	matrix, err := ResourceSupportMatrix("aws")
+	require.NoError(t, err)
+	require.NotEmpty(t, matrix)
+
+	support := make(map[string]string, len(matrix))
+	for _, r := range matrix {
+		assert.Equal(t, "aws", r.Provider)
+		support[r.ResourceType] = r.Support
+	}
+
+	assert.Equal(t, SupportLevelUsageBased, support["aws_lambda_function"])
+	assert.Equal(t, SupportLevelFree, support["aws_iam_role"])
+	assert.Equal(t, SupportLevelFull, support["aws_eip"])
+	assert.NotContains(t, support, "google_compute_instance")
+}
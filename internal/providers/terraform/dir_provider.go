@@ -1,7 +1,6 @@
 package terraform
 
 import (
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/url"
@@ -9,6 +8,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/infracost/infracost/internal/config"
@@ -29,11 +29,18 @@ type DirProvider struct {
 	env                 *config.Environment
 	spinnerOpts         ui.SpinnerOptions
 	PlanFlags           string
+	Vars                map[string]string
+	VarFiles            []string
+	Env                 map[string]string
 	Workspace           string
 	UseState            bool
 	TerraformBinary     string
 	TerraformCloudHost  string
 	TerraformCloudToken string
+	autoInstall         *bool
+	installDir          string
+	installVersion      string
+	maxCacheSize        int64
 }
 
 func NewDirProvider(cfg *config.Config, projectCfg *config.Project) schema.Provider {
@@ -42,6 +49,9 @@ func NewDirProvider(cfg *config.Config, projectCfg *config.Project) schema.Provi
 		terraformBinary = defaultTerraformBinary
 	}
 
+	// The max cache size is validated when the flags are loaded
+	maxCacheSize, _ := cfg.MaxCacheSizeBytes()
+
 	return &DirProvider{
 		Path: projectCfg.Path,
 		env:  cfg.Environment,
@@ -51,11 +61,18 @@ func NewDirProvider(cfg *config.Config, projectCfg *config.Project) schema.Provi
 			Indent:        "  ",
 		},
 		PlanFlags:           projectCfg.TerraformPlanFlags,
+		Vars:                projectCfg.TerraformVars,
+		VarFiles:            projectCfg.TerraformVarFiles,
+		Env:                 projectCfg.Env,
 		Workspace:           projectCfg.TerraformWorkspace,
 		UseState:            projectCfg.TerraformUseState,
 		TerraformBinary:     terraformBinary,
 		TerraformCloudHost:  projectCfg.TerraformCloudHost,
 		TerraformCloudToken: projectCfg.TerraformCloudToken,
+		autoInstall:         projectCfg.TerraformAutoInstall,
+		installDir:          projectCfg.TerraformInstallDir,
+		installVersion:      projectCfg.TerraformInstallVersion,
+		maxCacheSize:        maxCacheSize,
 	}
 }
 
@@ -71,6 +88,7 @@ func (p *DirProvider) checks() error {
 	_, err := exec.LookPath(p.TerraformBinary)
 	if err != nil {
 		msg := fmt.Sprintf("Terraform binary \"%s\" could not be found.\nSet a custom Terraform binary in your Infracost config or using the environment variable INFRACOST_TERRAFORM_BINARY.", p.TerraformBinary)
+		msg += "\nAlternatively, set INFRACOST_TERRAFORM_AUTO_INSTALL=true to install Terraform automatically."
 		return events.NewError(errors.Errorf(msg), "Terraform binary could not be found")
 	}
 
@@ -102,25 +120,33 @@ func (p *DirProvider) AddMetadata(metadata *schema.ProjectMetadata) {
 	metadata.TerraformWorkspace = terraformWorkspace
 }
 
-func (p *DirProvider) LoadResources(project *schema.Project, usage map[string]*schema.UsageData) error {
-	var j []byte
-	var err error
-
+// PlanJSON returns the plan JSON of the directory, or the state JSON if the
+// state is used instead of a plan.
+func (p *DirProvider) PlanJSON() ([]byte, error) {
 	if p.UseState {
-		j, err = p.generateStateJSON()
-	} else {
-		j, err = p.generatePlanJSON()
+		return p.generateStateJSON()
 	}
+
+	return p.generatePlanJSON()
+}
+
+func (p *DirProvider) LoadResources(project *schema.Project, usage map[string]*schema.UsageData) error {
+	j, err := p.PlanJSON()
 	if err != nil {
 		return err
 	}
 
+	spinner := ui.NewSpinner("Parsing Terraform JSON", p.spinnerOpts)
+
 	parser := NewParser(p.env)
 	pastResources, resources, err := parser.parseJSON(j, usage)
 	if err != nil {
+		spinner.Fail()
 		return errors.Wrap(err, "Error parsing Terraform JSON")
 	}
 
+	spinner.Success()
+
 	project.HasDiff = !p.UseState
 	if project.HasDiff {
 		project.PastResources = pastResources
@@ -180,6 +206,7 @@ func (p *DirProvider) buildCommandOpts() (*CmdOptions, error) {
 		TerraformBinary:    p.TerraformBinary,
 		TerraformWorkspace: p.Workspace,
 		Dir:                p.Path,
+		Env:                p.Env,
 	}
 
 	cfgFile, err := CreateConfigFile(p.Path, p.TerraformCloudHost, p.TerraformCloudToken)
@@ -209,6 +236,7 @@ func (p *DirProvider) runPlan(opts *CmdOptions, initOnFail bool) (string, []byte
 
 	args := []string{"plan", "-input=false", "-lock=false", "-no-color"}
 	args = append(args, flags...)
+	args = append(args, p.varFlags()...)
 	_, err = Cmd(opts, append(args, fmt.Sprintf("-out=%s", f.Name()))...)
 
 	// Check if the error requires a remote run or an init
@@ -258,6 +286,27 @@ func (p *DirProvider) runPlan(opts *CmdOptions, initOnFail bool) (string, []byte
 	return f.Name(), planJSON, nil
 }
 
+// varFlags returns the -var-file and -var flags of the project's var files
+// and vars. The vars are sorted so the plan command is the same every run.
+func (p *DirProvider) varFlags() []string {
+	flags := make([]string, 0, len(p.VarFiles)+len(p.Vars))
+	for _, f := range p.VarFiles {
+		flags = append(flags, fmt.Sprintf("-var-file=%s", f))
+	}
+
+	keys := make([]string, 0, len(p.Vars))
+	for k := range p.Vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		flags = append(flags, fmt.Sprintf("-var=%s=%s", k, p.Vars[k]))
+	}
+
+	return flags
+}
+
 func (p *DirProvider) runInit(opts *CmdOptions) error {
 	spinner := ui.NewSpinner("Running terraform init", p.spinnerOpts)
 
@@ -304,25 +353,7 @@ func (p *DirProvider) runRemotePlan(opts *CmdOptions, args []string) ([]byte, er
 		return []byte{}, ErrMissingCloudToken
 	}
 
-	body, err := cloudAPI(host, fmt.Sprintf("/api/v2/runs/%s/plan", runID), token)
-	if err != nil {
-		return []byte{}, err
-	}
-
-	var parsedResp struct {
-		Data struct {
-			Links map[string]string
-		}
-	}
-	if json.Unmarshal(body, &parsedResp) != nil {
-		return []byte{}, err
-	}
-
-	jsonPath, ok := parsedResp.Data.Links["json-output"]
-	if !ok || jsonPath == "" {
-		return []byte{}, errors.New("Could not parse path to plan JSON from remote")
-	}
-	return cloudAPI(host, jsonPath, token)
+	return cloudRunPlanJSON(host, runID, token)
 }
 
 func (p *DirProvider) runShow(opts *CmdOptions, planFile string) ([]byte, error) {
@@ -0,0 +1,178 @@
+package terraform
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	"github.com/hashicorp/hcl2/hclparse"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// skipDiscoverDirs are directories that never contain projects, so they
+// aren't walked.
+var skipDiscoverDirs = map[string]bool{
+	"node_modules":      true,
+	".terraform":        true,
+	".terragrunt-cache": true,
+	".git":              true,
+}
+
+var discoverFileSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "terraform"},
+		{Type: "provider", LabelNames: []string{"name"}},
+		{Type: "module", LabelNames: []string{"name"}},
+	},
+}
+
+var discoverTerraformBlockSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "backend", LabelNames: []string{"type"}},
+		{Type: "cloud"},
+	},
+}
+
+// DiscoverProjects walks the directory and returns the paths of the Terraform
+// root modules in it. A directory is a root module if it has a backend or
+// provider block, or a terragrunt.hcl file that includes a parent config or
+// sets the Terraform source. Directories that are used as local modules by
+// other directories are not root modules, even if they have provider blocks.
+func DiscoverProjects(root string) ([]string, error) {
+	parser := hclparse.NewParser()
+
+	candidates := make([]string, 0)
+	modules := make(map[string]bool)
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			return nil
+		}
+
+		if path != root && (skipDiscoverDirs[info.Name()] || strings.HasPrefix(info.Name(), ".")) {
+			return filepath.SkipDir
+		}
+
+		isRoot, moduleDirs, err := inspectDiscoverDir(parser, path)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range moduleDirs {
+			modules[m] = true
+		}
+
+		if isRoot {
+			candidates = append(candidates, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error discovering projects in %s", root)
+	}
+
+	projects := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		if modules[filepath.Clean(c)] {
+			log.Debugf("Skipping %s since it is used as a module", c)
+			continue
+		}
+		projects = append(projects, c)
+	}
+
+	sort.Strings(projects)
+
+	return projects, nil
+}
+
+// inspectDiscoverDir returns if the directory is a root module and the
+// directories of any local modules it uses.
+func inspectDiscoverDir(parser *hclparse.Parser, dir string) (bool, []string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return false, nil, err
+	}
+
+	isRoot := false
+	moduleDirs := make([]string, 0)
+
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+
+		filename := filepath.Join(dir, f.Name())
+
+		if f.Name() == "terragrunt.hcl" {
+			if isTerragruntProject(parser, filename) {
+				isRoot = true
+			}
+			continue
+		}
+
+		if filepath.Ext(f.Name()) != ".tf" {
+			continue
+		}
+
+		file, diags := parser.ParseHCLFile(filename)
+		if diags.HasErrors() {
+			log.Debugf("Error parsing %s: %s", filename, diags.Error())
+			continue
+		}
+
+		content, _, _ := file.Body.PartialContent(discoverFileSchema)
+
+		for _, block := range content.Blocks {
+			switch block.Type {
+			case "provider":
+				isRoot = true
+			case "terraform":
+				tfContent, _, _ := block.Body.PartialContent(discoverTerraformBlockSchema)
+				if len(tfContent.Blocks) > 0 {
+					isRoot = true
+				}
+			case "module":
+				if source := moduleSource(block); isLocalModuleSource(source) {
+					moduleDirs = append(moduleDirs, filepath.Clean(filepath.Join(dir, source)))
+				}
+			}
+		}
+	}
+
+	return isRoot, moduleDirs, nil
+}
+
+// isTerragruntProject returns false for parent terragrunt.hcl files that only
+// have shared config such as remote_state, since they aren't deployed themselves.
+func isTerragruntProject(parser *hclparse.Parser, filename string) bool {
+	file, diags := parser.ParseHCLFile(filename)
+	if diags.HasErrors() {
+		log.Debugf("Error parsing %s: %s", filename, diags.Error())
+		return false
+	}
+
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return false
+	}
+
+	// The include block can have a label in newer Terragrunt versions, so the
+	// blocks are checked directly rather than with a schema.
+	for _, block := range body.Blocks {
+		if block.Type == "terraform" || block.Type == "include" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,56 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoverProjects(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"README.md": "# infra",
+		"prod/main.tf": `
+terraform {
+  backend "s3" {}
+}
+
+module "vpc" {
+  source = "../modules/vpc"
+}
+`,
+		"staging/providers.tf": `provider "aws" {
+  region = "us-east-1"
+}
+`,
+		"modules/vpc/main.tf": `provider "aws" {}
+
+resource "aws_vpc" "main" {}
+`,
+		"modules/unused/main.tf":                     `resource "aws_eip" "ip" {}`,
+		"live/terragrunt.hcl":                        `remote_state {}`,
+		"live/dev/terragrunt.hcl":                    `include "root" {}`,
+		"prod/.terraform/modules/vpc/main.tf":        `provider "aws" {}`,
+		"live/dev/.terragrunt-cache/abc/main.tf":     `provider "aws" {}`,
+		"node_modules/some-package/terraform/aws.tf": `provider "aws" {}`,
+	}
+
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+	}
+
+	projects, err := DiscoverProjects(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		filepath.Join(dir, "live", "dev"),
+		filepath.Join(dir, "prod"),
+		filepath.Join(dir, "staging"),
+	}, projects)
+}
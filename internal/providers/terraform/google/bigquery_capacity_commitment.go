@@ -0,0 +1,78 @@
+package google
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+)
+
+func GetBigqueryCapacityCommitmentRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "google_bigquery_capacity_commitment",
+		RFunc: NewBigqueryCapacityCommitment,
+	}
+}
+
+// NewBigqueryCapacityCommitment prices the flat-rate slots of a commitment.
+// The reservations that the slots are assigned to aren't priced separately.
+// Flex slots are billed per second, and monthly and annual slots are billed
+// per month for the length of the commitment.
+func NewBigqueryCapacityCommitment(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := bigqueryLocationRegion(d.Get("location").String())
+	slots := decimal.NewFromInt(d.Get("slot_count").Int())
+	plan := strings.ToUpper(d.Get("plan").String())
+
+	var c *schema.CostComponent
+
+	switch plan {
+	case "FLEX", "FLEX_FLAT_RATE":
+		c = bigqueryCommitmentCostComponent("Flex slots", "slot-hours", region, "Flex", nil)
+		c.HourlyQuantity = decimalPtr(slots)
+	case "MONTHLY", "MONTHLY_FLAT_RATE":
+		c = bigqueryCommitmentCostComponent("Monthly commitment slots", "slots", region, "Monthly", decimalPtr(slots))
+	case "ANNUAL", "ANNUAL_FLAT_RATE":
+		c = bigqueryCommitmentCostComponent("Annual commitment slots", "slots", region, "Annual", decimalPtr(slots))
+	default:
+		log.Warnf("Skipping resource %s. Unsupported commitment plan %s", d.Address, plan)
+		return nil
+	}
+
+	return &schema.Resource{
+		Name:           d.Address,
+		CostComponents: []*schema.CostComponent{c},
+	}
+}
+
+func bigqueryCommitmentCostComponent(name, unit, region, plan string, quantity *decimal.Decimal) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            unit,
+		UnitMultiplier:  1,
+		MonthlyQuantity: quantity,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("gcp"),
+			Region:        strPtr(region),
+			Service:       strPtr("BigQuery Reservation API"),
+			ProductFamily: strPtr("ApplicationServices"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "description", ValueRegex: strPtr(fmt.Sprintf("/%s/i", plan))},
+			},
+		},
+	}
+}
+
+// bigqueryLocationRegion returns the pricing region of a BigQuery location.
+// The US and EU multi-regions are priced as the us and europe regions.
+func bigqueryLocationRegion(location string) string {
+	switch strings.ToUpper(location) {
+	case "", "US":
+		return "us"
+	case "EU":
+		return "europe"
+	}
+
+	return strings.ToLower(location)
+}
@@ -0,0 +1,43 @@
+package google
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func testBigqueryCapacityCommitment(plan, location string) *schema.ResourceData {
+	return schema.NewResourceData("google_bigquery_capacity_commitment", "google", "google_bigquery_capacity_commitment.commitment", nil, gjson.Parse(`{
+		"location": "`+location+`",
+		"plan": "`+plan+`",
+		"slot_count": 100
+	}`))
+}
+
+func TestBigqueryCapacityCommitment(t *testing.T) {
+	r := NewBigqueryCapacityCommitment(testBigqueryCapacityCommitment("FLEX", "US"), nil)
+	require.NotNil(t, r)
+	require.Len(t, r.CostComponents, 1)
+	assert.Equal(t, "Flex slots", r.CostComponents[0].Name)
+	assert.Equal(t, "us", *r.CostComponents[0].ProductFilter.Region)
+	assert.True(t, decimal.NewFromInt(100).Equal(*r.CostComponents[0].HourlyQuantity))
+	assert.Nil(t, r.CostComponents[0].MonthlyQuantity)
+
+	r = NewBigqueryCapacityCommitment(testBigqueryCapacityCommitment("ANNUAL", "EU"), nil)
+	require.NotNil(t, r)
+	assert.Equal(t, "Annual commitment slots", r.CostComponents[0].Name)
+	assert.Equal(t, "europe", *r.CostComponents[0].ProductFilter.Region)
+	assert.True(t, decimal.NewFromInt(100).Equal(*r.CostComponents[0].MonthlyQuantity))
+
+	r = NewBigqueryCapacityCommitment(testBigqueryCapacityCommitment("MONTHLY", "us-west2"), nil)
+	require.NotNil(t, r)
+	assert.Equal(t, "Monthly commitment slots", r.CostComponents[0].Name)
+	assert.Equal(t, "us-west2", *r.CostComponents[0].ProductFilter.Region)
+
+	assert.Nil(t, NewBigqueryCapacityCommitment(testBigqueryCapacityCommitment("TRIAL", "US"), nil))
+}
@@ -0,0 +1,167 @@
+package google
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+)
+
+// secondsPerMonth is the number of seconds in the 730 hours that are used as
+// a month.
+var secondsPerMonth = decimal.NewFromInt(int64(schema.HourToMonthUnitMultiplier * 60 * 60))
+
+var cloudRunMemorySuffixes = map[string]float64{
+	"Ki": 1 << 10,
+	"Mi": 1 << 20,
+	"Gi": 1 << 30,
+	"K":  1e3,
+	"M":  1e6,
+	"G":  1e9,
+}
+
+func GetCloudRunServiceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "google_cloud_run_service",
+		RFunc: NewCloudRunService,
+	}
+}
+
+// NewCloudRunService prices the CPU and memory that's allocated to the
+// service's instances while they handle requests, and the idle CPU and memory
+// of the minimum instances that are kept warm.
+func NewCloudRunService(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := d.Get("location").String()
+
+	cpu := decimal.NewFromInt(1)
+	if q := d.Get("template.0.spec.0.containers.0.resources.0.limits.cpu").String(); q != "" {
+		v, err := parseCloudRunCPU(q)
+		if err != nil {
+			log.Warnf("Skipping resource %s. %s", d.Address, err)
+			return nil
+		}
+		cpu = decimal.NewFromFloat(v)
+	}
+
+	memoryGB := decimal.NewFromFloat(0.5)
+	if q := d.Get("template.0.spec.0.containers.0.resources.0.limits.memory").String(); q != "" {
+		v, err := parseCloudRunMemoryGB(q)
+		if err != nil {
+			log.Warnf("Skipping resource %s. %s", d.Address, err)
+			return nil
+		}
+		memoryGB = decimal.NewFromFloat(v)
+	}
+
+	requestDuration := decimal.NewFromInt(100)
+	if u != nil && u.Get("request_duration_ms").Exists() {
+		// Round up to nearest 100ms
+		requestDuration = decimal.NewFromInt(u.Get("request_duration_ms").Int()).Div(decimal.NewFromInt(100)).Ceil().Mul(decimal.NewFromInt(100))
+	}
+
+	// Requests that are handled by the same instance at the same time are only
+	// billed once. This defaults to 1 since that's the most conservative.
+	concurrency := decimal.NewFromInt(1)
+	if u != nil && u.Get("average_concurrent_requests").Float() > 0 {
+		concurrency = decimal.NewFromFloat(u.Get("average_concurrent_requests").Float())
+	}
+
+	var requests, cpuSeconds, memorySeconds *decimal.Decimal
+	if u != nil && u.Get("monthly_requests").Exists() {
+		requests = decimalPtr(decimal.NewFromInt(u.Get("monthly_requests").Int()))
+
+		instanceSeconds := requests.Mul(requestDuration.Div(decimal.NewFromInt(1000))).Div(concurrency)
+		cpuSeconds = decimalPtr(instanceSeconds.Mul(cpu))
+		memorySeconds = decimalPtr(instanceSeconds.Mul(memoryGB))
+	}
+
+	costComponents := []*schema.CostComponent{
+		cloudRunCostComponent("CPU allocation time", "vCPU-seconds", region, "CPU Allocation Time", "180000", cpuSeconds),
+		cloudRunCostComponent("Memory allocation time", "GB-seconds", region, "Memory Allocation Time", "360000", memorySeconds),
+		cloudRunCostComponent("Requests", "requests", region, "Requests", "2000000", requests),
+	}
+
+	// The min instances are kept warm for the whole month, which slightly
+	// overestimates their idle time since they also handle requests.
+	minInstances := d.Get("template.0.metadata.0.annotations.autoscaling\\.knative\\.dev/minScale").Int()
+	if minInstances > 0 {
+		instanceSeconds := secondsPerMonth.Mul(decimal.NewFromInt(minInstances))
+
+		costComponents = append(costComponents,
+			cloudRunCostComponent("Idle min instance CPU allocation time", "vCPU-seconds", region, "Idle Min-Instance CPU Allocation Time", "0", decimalPtr(instanceSeconds.Mul(cpu))),
+			cloudRunCostComponent("Idle min instance memory allocation time", "GB-seconds", region, "Idle Min-Instance Memory Allocation Time", "0", decimalPtr(instanceSeconds.Mul(memoryGB))),
+		)
+	}
+
+	return &schema.Resource{
+		Name:           d.Address,
+		CostComponents: costComponents,
+	}
+}
+
+func cloudRunCostComponent(name, unit, region, description, startUsageAmount string, quantity *decimal.Decimal) *schema.CostComponent {
+	// The requests aren't regional
+	if description == "Requests" {
+		region = "global"
+	}
+
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            unit,
+		UnitMultiplier:  1,
+		MonthlyQuantity: quantity,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("gcp"),
+			Region:        strPtr(region),
+			Service:       strPtr("Cloud Run"),
+			ProductFamily: strPtr("ApplicationServices"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "description", ValueRegex: strPtr(fmt.Sprintf("/^%s/", description))},
+			},
+		},
+		PriceFilter: &schema.PriceFilter{
+			StartUsageAmount: strPtr(startUsageAmount),
+		},
+	}
+}
+
+// parseCloudRunCPU parses a CPU limit, e.g. 1000m or 2, into a number of vCPUs.
+func parseCloudRunCPU(q string) (float64, error) {
+	millis := strings.HasSuffix(q, "m")
+
+	v, err := strconv.ParseFloat(strings.TrimSuffix(q, "m"), 64)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid CPU limit '%s'", q)
+	}
+
+	if millis {
+		return v / 1000, nil
+	}
+
+	return v, nil
+}
+
+// parseCloudRunMemoryGB parses a memory limit, e.g. 512Mi or 1G, into GB.
+// Cloud Run bills memory in GiB, which it calls GB.
+func parseCloudRunMemoryGB(q string) (float64, error) {
+	s := q
+	multiplier := 1.0
+
+	for _, suffix := range []string{"Ki", "Mi", "Gi", "K", "M", "G"} {
+		if strings.HasSuffix(s, suffix) {
+			multiplier = cloudRunMemorySuffixes[suffix]
+			s = strings.TrimSuffix(s, suffix)
+			break
+		}
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid memory limit '%s'", q)
+	}
+
+	return v * multiplier / (1 << 30), nil
+}
@@ -0,0 +1,75 @@
+package google
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestCloudRunService(t *testing.T) {
+	d := schema.NewResourceData("google_cloud_run_service", "google", "google_cloud_run_service.service", nil, gjson.Parse(`{
+		"location": "us-central1",
+		"template": [{
+			"metadata": [{"annotations": {"autoscaling.knative.dev/minScale": "2"}}],
+			"spec": [{"containers": [{"resources": [{"limits": {"cpu": "2000m", "memory": "1Gi"}}]}]}]
+		}]
+	}`))
+
+	u := &schema.UsageData{
+		Attributes: map[string]gjson.Result{
+			"monthly_requests":            gjson.Parse(`1000000`),
+			"request_duration_ms":         gjson.Parse(`250`),
+			"average_concurrent_requests": gjson.Parse(`2`),
+		},
+	}
+
+	r := NewCloudRunService(d, u)
+	require.NotNil(t, r)
+	require.Len(t, r.CostComponents, 5)
+
+	// 1M requests * 0.3s / 2 concurrent requests = 150,000 instance seconds
+	assert.Equal(t, "CPU allocation time", r.CostComponents[0].Name)
+	assert.Equal(t, "us-central1", *r.CostComponents[0].ProductFilter.Region)
+	assert.True(t, decimal.NewFromInt(300000).Equal(*r.CostComponents[0].MonthlyQuantity))
+
+	assert.Equal(t, "Memory allocation time", r.CostComponents[1].Name)
+	assert.True(t, decimal.NewFromInt(150000).Equal(*r.CostComponents[1].MonthlyQuantity))
+
+	assert.Equal(t, "Requests", r.CostComponents[2].Name)
+	assert.Equal(t, "global", *r.CostComponents[2].ProductFilter.Region)
+	assert.True(t, decimal.NewFromInt(1000000).Equal(*r.CostComponents[2].MonthlyQuantity))
+
+	assert.Equal(t, "Idle min instance CPU allocation time", r.CostComponents[3].Name)
+	assert.True(t, decimal.NewFromInt(2*2*730*3600).Equal(*r.CostComponents[3].MonthlyQuantity))
+
+	assert.Equal(t, "Idle min instance memory allocation time", r.CostComponents[4].Name)
+	assert.True(t, decimal.NewFromInt(2*730*3600).Equal(*r.CostComponents[4].MonthlyQuantity))
+}
+
+func TestCloudRunServiceDefaults(t *testing.T) {
+	d := schema.NewResourceData("google_cloud_run_service", "google", "google_cloud_run_service.service", nil, gjson.Parse(`{
+		"location": "europe-west1"
+	}`))
+
+	r := NewCloudRunService(d, nil)
+	require.NotNil(t, r)
+	require.Len(t, r.CostComponents, 3)
+
+	for _, c := range r.CostComponents {
+		assert.Nil(t, c.MonthlyQuantity)
+	}
+}
+
+func TestParseCloudRunMemoryGB(t *testing.T) {
+	v, err := parseCloudRunMemoryGB("512Mi")
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, v)
+
+	_, err = parseCloudRunMemoryGB("lots")
+	assert.Error(t, err)
+}
@@ -27,6 +27,7 @@ func NewCloudFunctions(d *schema.ResourceData, u *schema.UsageData) *schema.Reso
 		1024: decimal.NewFromInt(1400),
 		2048: decimal.NewFromInt(2400),
 		4096: decimal.NewFromInt(4800),
+		8192: decimal.NewFromInt(4800),
 	}
 
 	cpuSize := cpuMapping[int(memorySize.IntPart())]
@@ -46,7 +47,7 @@ func NewCloudFunctions(d *schema.ResourceData, u *schema.UsageData) *schema.Reso
 
 	var networkEgrees *decimal.Decimal
 	if u != nil && u.Get("monthly_outbound_data_gb").Exists() {
-		networkEgrees = decimalPtr(decimal.NewFromInt(u.Get("monthly_outbound_data_gb").Int()))
+		networkEgrees = decimalPtr(decimal.NewFromFloat(u.Get("monthly_outbound_data_gb").Float()))
 	}
 
 	return &schema.Resource{
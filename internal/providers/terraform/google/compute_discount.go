@@ -0,0 +1,103 @@
+package google
+
+import (
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+	log "github.com/sirupsen/logrus"
+)
+
+// computeCommitment returns the committed use discount term of a VM from the
+// commitment usage key, which can be 1yr or 3yr. Resource-based commitments
+// are for a region's vCPUs and memory, so they can't be read from the VM's
+// attributes and have to be declared in the usage file.
+func computeCommitment(address string, u *schema.UsageData) string {
+	if u == nil || !u.Get("commitment").Exists() {
+		return ""
+	}
+
+	commitment := strings.ToLower(u.Get("commitment").String())
+	switch commitment {
+	case "1yr", "3yr":
+		return commitment
+	case "", "none":
+		return ""
+	}
+
+	log.Warnf("Ignoring the commitment of %s. Unsupported commitment %s, it must be 1yr or 3yr", address, commitment)
+	return ""
+}
+
+// computeMonthlyDiscount returns the discount of the monthly cost of a VM's
+// machine type. Committed use discounts replace sustained use discounts since
+// the committed resources aren't eligible for both. The discounts are the
+// maximum for a VM that runs for the whole month. It also returns whether the
+// committed use discount was applied.
+func computeMonthlyDiscount(machineType, purchaseOption, commitment string) (float64, bool) {
+	if purchaseOption != "on_demand" {
+		return 0, false
+	}
+
+	family := strings.Split(machineType, "-")[0]
+
+	if commitment != "" {
+		if d, ok := committedUseDiscount(family, commitment); ok {
+			return d, true
+		}
+	}
+
+	return sustainedUseDiscount(family), false
+}
+
+// sustainedUseDiscount returns the discount of a machine family that's
+// applied automatically to a VM that runs for the whole month. E2 and A2
+// machine types don't get sustained use discounts.
+func sustainedUseDiscount(family string) float64 {
+	switch family {
+	case "c2", "n2", "n2d":
+		return 0.2
+	case "n1", "f1", "g1", "m1", "m2":
+		return 0.3
+	}
+
+	return 0
+}
+
+// committedUseDiscount returns the discount of a resource-based commitment
+// for a machine family. Shared-core machine types can't be committed to.
+func committedUseDiscount(family, commitment string) (float64, bool) {
+	switch family {
+	case "f1", "g1":
+		return 0, false
+	case "m1", "m2", "m3":
+		if commitment == "3yr" {
+			return 0.7, true
+		}
+		return 0.41, true
+	}
+
+	if commitment == "3yr" {
+		return 0.55, true
+	}
+	return 0.37, true
+}
+
+// guestAcceleratorMonthlyDiscount returns the discount of the monthly cost of
+// a GPU, which has the same sustained use and committed use discounts as N1
+// machine types.
+func guestAcceleratorMonthlyDiscount(purchaseOption, commitment string) (float64, bool) {
+	return computeMonthlyDiscount("n1", purchaseOption, commitment)
+}
+
+// discountedPurchaseOptionLabel returns the label of the purchase option, or
+// of the commitment if its discount was applied.
+func discountedPurchaseOptionLabel(purchaseOption, commitment string, committed bool) string {
+	if committed {
+		return map[string]string{
+			"1yr": "1 year commitment",
+			"3yr": "3 year commitment",
+		}[commitment]
+	}
+
+	return purchaseOptionLabel(purchaseOption)
+}
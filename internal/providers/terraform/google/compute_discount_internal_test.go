@@ -0,0 +1,77 @@
+package google
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestComputeMonthlyDiscount(t *testing.T) {
+	tests := []struct {
+		machineType       string
+		purchaseOption    string
+		commitment        string
+		expected          float64
+		expectedCommitted bool
+	}{
+		{"n1-standard-2", "on_demand", "", 0.3, false},
+		{"n2-standard-2", "on_demand", "", 0.2, false},
+		{"e2-standard-2", "on_demand", "", 0, false},
+		{"n1-standard-2", "preemptible", "", 0, false},
+		{"n1-standard-2", "on_demand", "1yr", 0.37, true},
+		{"e2-standard-2", "on_demand", "3yr", 0.55, true},
+		{"m1-ultramem-40", "on_demand", "3yr", 0.7, true},
+		{"f1-micro", "on_demand", "1yr", 0.3, false},
+		{"n1-standard-2", "preemptible", "1yr", 0, false},
+	}
+
+	for _, test := range tests {
+		discount, committed := computeMonthlyDiscount(test.machineType, test.purchaseOption, test.commitment)
+		assert.Equal(t, test.expected, discount, test.machineType, test.purchaseOption, test.commitment)
+		assert.Equal(t, test.expectedCommitted, committed, test.machineType, test.purchaseOption, test.commitment)
+	}
+}
+
+func TestComputeInstanceCommitment(t *testing.T) {
+	d := schema.NewResourceData("google_compute_instance", "google", "google_compute_instance.instance", nil, gjson.Parse(`{
+		"zone": "us-central1-a",
+		"machine_type": "n1-standard-2",
+		"guest_accelerator": [{"type": "nvidia-tesla-t4", "count": 1}]
+	}`))
+
+	u := &schema.UsageData{
+		Attributes: map[string]gjson.Result{
+			"commitment": gjson.Parse(`"3yr"`),
+		},
+	}
+
+	r := NewComputeInstance(d, u)
+
+	assert.Equal(t, "Instance usage (Linux/UNIX, 3 year commitment, n1-standard-2)", r.CostComponents[0].Name)
+	assert.Equal(t, 0.55, r.CostComponents[0].MonthlyDiscountPerc)
+	assert.Equal(t, "on_demand", *r.CostComponents[0].PriceFilter.PurchaseOption)
+
+	assert.Equal(t, "NVIDIA Tesla T4 (3 year commitment)", r.CostComponents[1].Name)
+	assert.Equal(t, 0.55, r.CostComponents[1].MonthlyDiscountPerc)
+}
+
+func TestComputeInstanceUnsupportedCommitment(t *testing.T) {
+	d := schema.NewResourceData("google_compute_instance", "google", "google_compute_instance.instance", nil, gjson.Parse(`{
+		"zone": "us-central1-a",
+		"machine_type": "n1-standard-2"
+	}`))
+
+	u := &schema.UsageData{
+		Attributes: map[string]gjson.Result{
+			"commitment": gjson.Parse(`"5yr"`),
+		},
+	}
+
+	r := NewComputeInstance(d, u)
+
+	assert.Equal(t, "Instance usage (Linux/UNIX, on-demand, n1-standard-2)", r.CostComponents[0].Name)
+	assert.Equal(t, 0.3, r.CostComponents[0].MonthlyDiscountPerc)
+}
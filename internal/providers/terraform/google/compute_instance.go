@@ -16,6 +16,7 @@ func GetComputeInstanceRegistryItem() *schema.RegistryItem {
 		RFunc: NewComputeInstance,
 		Notes: []string{
 			"Sustained use discounts are applied to monthly costs, but not to hourly costs.",
+			"Resource-based committed use discounts can be set with the commitment usage key, they replace sustained use discounts.",
 			"Costs associated with non-standard Linux images, such as Windows and RHEL are not supported.",
 			"Custom machine types are not supported.",
 			"Sole-tenant VMs are not supported.",
@@ -36,12 +37,10 @@ func NewComputeInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Res
 		region = zoneToRegion(zone)
 	}
 
-	purchaseOption := "on_demand"
-	if d.Get("scheduling.0.preemptible").Bool() {
-		purchaseOption = "preemptible"
-	}
+	purchaseOption := schedulingPurchaseOption(d.Get("scheduling.0"))
+	commitment := computeCommitment(d.Address, u)
 
-	costComponents := []*schema.CostComponent{computeCostComponent(region, machineType, purchaseOption)}
+	costComponents := []*schema.CostComponent{computeCostComponent(region, machineType, purchaseOption, commitment)}
 
 	if d.Get("boot_disk.0.initialize_params.0").Exists() {
 		costComponents = append(costComponents, bootDisk(region, d.Get("boot_disk.0.initialize_params.0")))
@@ -53,32 +52,37 @@ func NewComputeInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Res
 	}
 
 	for _, guestAccel := range d.Get("guest_accelerator").Array() {
-		costComponents = append(costComponents, guestAccelerator(region, purchaseOption, guestAccel))
+		costComponents = append(costComponents, guestAccelerator(region, purchaseOption, commitment, guestAccel))
 	}
 
 	return &schema.Resource{
 		Name:           d.Address,
 		CostComponents: costComponents,
+		SubResources: []*schema.Resource{
+			computeNetworkEgress(region, u),
+		},
 	}
 }
 
-func computeCostComponent(region, machineType string, purchaseOption string) *schema.CostComponent {
-	sustainedUseDiscount := 0.0
-	if purchaseOption == "on_demand" {
-		switch strings.Split(machineType, "-")[0] {
-		case "c2", "n2", "n2d":
-			sustainedUseDiscount = 0.2
-		case "n1", "f1", "g1", "m1":
-			sustainedUseDiscount = 0.3
-		}
-	}
+// computeNetworkEgress returns the data transfer from an instance to the
+// instances in other regions and to the internet.
+func computeNetworkEgress(region string, u *schema.UsageData) *schema.Resource {
+	egress := networkEgress(region, u, "Network egress", "Inter-region data transfer", ComputeInterRegionEgress)
+	internetEgress := networkEgress(region, u, "Network egress", "Internet data transfer", ComputeInternetEgress)
+	egress.CostComponents = append(egress.CostComponents, internetEgress.CostComponents...)
+
+	return egress
+}
+
+func computeCostComponent(region, machineType string, purchaseOption string, commitment string) *schema.CostComponent {
+	discount, committed := computeMonthlyDiscount(machineType, purchaseOption, commitment)
 
 	return &schema.CostComponent{
-		Name:                fmt.Sprintf("Instance usage (Linux/UNIX, %s, %s)", purchaseOptionLabel(purchaseOption), machineType),
+		Name:                fmt.Sprintf("Instance usage (Linux/UNIX, %s, %s)", discountedPurchaseOptionLabel(purchaseOption, commitment, committed), machineType),
 		Unit:                "hours",
 		UnitMultiplier:      1,
 		HourlyQuantity:      decimalPtr(decimal.NewFromInt(1)),
-		MonthlyDiscountPerc: sustainedUseDiscount,
+		MonthlyDiscountPerc: discount,
 		ProductFilter: &schema.ProductFilter{
 			VendorName:    strPtr("gcp"),
 			Region:        strPtr(region),
@@ -128,7 +132,7 @@ func scratchDisk(region string, purchaseOption string, count int) *schema.CostCo
 	}
 }
 
-func guestAccelerator(region string, purchaseOption string, guestAccel gjson.Result) *schema.CostComponent {
+func guestAccelerator(region string, purchaseOption string, commitment string, guestAccel gjson.Result) *schema.CostComponent {
 	model := guestAccel.Get("type").String()
 
 	var (
@@ -163,17 +167,14 @@ func guestAccelerator(region string, purchaseOption string, guestAccel gjson.Res
 
 	count := decimal.NewFromInt(guestAccel.Get("count").Int())
 
-	sustainedUseDiscount := 0.0
-	if purchaseOption == "on_demand" {
-		sustainedUseDiscount = 0.3
-	}
+	discount, committed := guestAcceleratorMonthlyDiscount(purchaseOption, commitment)
 
 	return &schema.CostComponent{
-		Name:                fmt.Sprintf("%s (%s)", name, purchaseOptionLabel(purchaseOption)),
+		Name:                fmt.Sprintf("%s (%s)", name, discountedPurchaseOptionLabel(purchaseOption, commitment, committed)),
 		Unit:                "hours",
 		UnitMultiplier:      1,
 		HourlyQuantity:      decimalPtr(count),
-		MonthlyDiscountPerc: sustainedUseDiscount,
+		MonthlyDiscountPerc: discount,
 		ProductFilter: &schema.ProductFilter{
 			VendorName:    strPtr("gcp"),
 			Region:        strPtr(region),
@@ -189,6 +190,17 @@ func guestAccelerator(region string, purchaseOption string, guestAccel gjson.Res
 	}
 }
 
+// schedulingPurchaseOption returns the purchase option from the scheduling
+// block of an instance or instance template. Spot VMs use the same prices as
+// preemptible VMs.
+func schedulingPurchaseOption(scheduling gjson.Result) string {
+	if scheduling.Get("preemptible").Bool() || strings.EqualFold(scheduling.Get("provisioning_model").String(), "SPOT") {
+		return "preemptible"
+	}
+
+	return "on_demand"
+}
+
 func purchaseOptionLabel(purchaseOption string) string {
 	return map[string]string{
 		"on_demand":   "on-demand",
@@ -0,0 +1,101 @@
+package google
+
+import (
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+)
+
+func GetComputeInstanceGroupManagerRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:                "google_compute_instance_group_manager",
+		RFunc:               NewComputeInstanceGroupManager,
+		ReferenceAttributes: []string{"version.0.instance_template"},
+		Notes: []string{
+			"Sustained use discounts are applied to monthly costs, but not to hourly costs.",
+			"Resource-based committed use discounts can be set with the commitment usage key, they replace sustained use discounts.",
+			"Costs associated with non-standard Linux images, such as Windows and RHEL are not supported.",
+			"Custom machine types are not supported.",
+			"Only the instance template of the first version is used.",
+		},
+	}
+}
+
+func NewComputeInstanceGroupManager(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := zoneToRegion(d.Get("zone").String())
+
+	return newInstanceGroupManager(d, u, region)
+}
+
+func newInstanceGroupManager(d *schema.ResourceData, u *schema.UsageData, region string) *schema.Resource {
+	templates := d.References("version.0.instance_template")
+	if len(templates) == 0 {
+		log.Warnf("Skipping resource %s. Unable to find its instance template", d.Address)
+		return nil
+	}
+	template := templates[0]
+
+	if region == "" {
+		region = template.Get("region").String()
+	}
+
+	if region == "" {
+		log.Warnf("Skipping resource %s. Unable to determine region", d.Address)
+		return nil
+	}
+
+	if strings.HasPrefix(template.Get("machine_type").String(), "custom-") {
+		return nil
+	}
+
+	instanceCount := decimal.NewFromInt(d.Get("target_size").Int())
+	if u != nil && u.Get("instances").Type != gjson.Null {
+		instanceCount = decimal.NewFromFloat(u.Get("instances").Float())
+	}
+
+	r := &schema.Resource{
+		Name:           d.Address,
+		CostComponents: instanceTemplateCostComponents(region, template, computeCommitment(d.Address, u)),
+	}
+
+	schema.MultiplyQuantities(r, instanceCount)
+
+	return r
+}
+
+// instanceTemplateCostComponents returns the cost components of a single
+// instance that's created from the instance template.
+func instanceTemplateCostComponents(region string, template *schema.ResourceData, commitment string) []*schema.CostComponent {
+	machineType := template.Get("machine_type").String()
+	purchaseOption := schedulingPurchaseOption(template.Get("scheduling.0"))
+
+	costComponents := []*schema.CostComponent{computeCostComponent(region, machineType, purchaseOption, commitment)}
+
+	scratchDiskCount := 0
+	for _, disk := range template.Get("disk").Array() {
+		if disk.Get("type").String() == "SCRATCH" {
+			scratchDiskCount++
+			continue
+		}
+
+		size := decimalPtr(decimal.NewFromInt(int64(defaultVolumeSize)))
+		if disk.Get("disk_size_gb").Exists() {
+			size = decimalPtr(decimal.NewFromFloat(disk.Get("disk_size_gb").Float()))
+		}
+
+		costComponents = append(costComponents, computeDisk(region, disk.Get("disk_type").String(), size))
+	}
+
+	if scratchDiskCount > 0 {
+		costComponents = append(costComponents, scratchDisk(region, purchaseOption, scratchDiskCount))
+	}
+
+	for _, guestAccel := range template.Get("guest_accelerator").Array() {
+		costComponents = append(costComponents, guestAccelerator(region, purchaseOption, commitment, guestAccel))
+	}
+
+	return costComponents
+}
@@ -0,0 +1,81 @@
+package google
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func testInstanceTemplate(scheduling string) *schema.ResourceData {
+	return schema.NewResourceData("google_compute_instance_template", "google", "google_compute_instance_template.template", nil, gjson.Parse(`{
+		"machine_type": "n1-standard-2",
+		"disk": [
+			{"boot": true, "disk_type": "pd-ssd", "disk_size_gb": 50},
+			{"type": "SCRATCH"},
+			{"type": "SCRATCH"}
+		],
+		"guest_accelerator": [{"type": "nvidia-tesla-t4", "count": 1}],
+		"scheduling": [`+scheduling+`]
+	}`))
+}
+
+func TestComputeInstanceGroupManager(t *testing.T) {
+	d := schema.NewResourceData("google_compute_instance_group_manager", "google", "google_compute_instance_group_manager.group", nil, gjson.Parse(`{
+		"zone": "us-central1-a",
+		"target_size": 3
+	}`))
+	d.AddReference("version.0.instance_template", testInstanceTemplate(`{}`))
+
+	r := NewComputeInstanceGroupManager(d, nil)
+	require.NotNil(t, r)
+	require.Len(t, r.CostComponents, 4)
+
+	assert.Equal(t, "Instance usage (Linux/UNIX, on-demand, n1-standard-2)", r.CostComponents[0].Name)
+	assert.Equal(t, "us-central1", *r.CostComponents[0].ProductFilter.Region)
+	assert.True(t, decimal.NewFromInt(3).Equal(*r.CostComponents[0].HourlyQuantity))
+
+	assert.Equal(t, "SSD provisioned storage (pd-ssd)", r.CostComponents[1].Name)
+	assert.True(t, decimal.NewFromInt(150).Equal(*r.CostComponents[1].MonthlyQuantity))
+
+	assert.Equal(t, "Local SSD provisioned storage", r.CostComponents[2].Name)
+	assert.True(t, decimal.NewFromInt(2250).Equal(*r.CostComponents[2].MonthlyQuantity))
+
+	assert.Equal(t, "NVIDIA Tesla T4 (on-demand)", r.CostComponents[3].Name)
+}
+
+func TestComputeRegionInstanceGroupManagerSpotUsage(t *testing.T) {
+	d := schema.NewResourceData("google_compute_region_instance_group_manager", "google", "google_compute_region_instance_group_manager.group", nil, gjson.Parse(`{
+		"region": "europe-west1",
+		"target_size": 3
+	}`))
+	d.AddReference("version.0.instance_template", testInstanceTemplate(`{"provisioning_model": "SPOT"}`))
+
+	u := &schema.UsageData{
+		Attributes: map[string]gjson.Result{
+			"instances": gjson.Parse(`1.5`),
+		},
+	}
+
+	r := NewComputeRegionInstanceGroupManager(d, u)
+	require.NotNil(t, r)
+
+	c := r.CostComponents[0]
+	assert.Equal(t, "Instance usage (Linux/UNIX, preemptible, n1-standard-2)", c.Name)
+	assert.Equal(t, "europe-west1", *c.ProductFilter.Region)
+	assert.Equal(t, "preemptible", *c.PriceFilter.PurchaseOption)
+	assert.True(t, decimal.NewFromFloat(1.5).Equal(*c.HourlyQuantity))
+}
+
+func TestComputeInstanceGroupManagerWithoutTemplate(t *testing.T) {
+	d := schema.NewResourceData("google_compute_instance_group_manager", "google", "google_compute_instance_group_manager.group", nil, gjson.Parse(`{
+		"zone": "us-central1-a",
+		"target_size": 3
+	}`))
+
+	assert.Nil(t, NewComputeInstanceGroupManager(d, nil))
+}
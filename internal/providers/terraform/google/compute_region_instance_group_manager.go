@@ -0,0 +1,24 @@
+package google
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetComputeRegionInstanceGroupManagerRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:                "google_compute_region_instance_group_manager",
+		RFunc:               NewComputeRegionInstanceGroupManager,
+		ReferenceAttributes: []string{"version.0.instance_template"},
+		Notes: []string{
+			"Sustained use discounts are applied to monthly costs, but not to hourly costs.",
+			"Resource-based committed use discounts can be set with the commitment usage key, they replace sustained use discounts.",
+			"Costs associated with non-standard Linux images, such as Windows and RHEL are not supported.",
+			"Custom machine types are not supported.",
+			"Only the instance template of the first version is used.",
+		},
+	}
+}
+
+func NewComputeRegionInstanceGroupManager(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	return newInstanceGroupManager(d, u, d.Get("region").String())
+}
@@ -62,5 +62,8 @@ func NewComputeRouterNAT(d *schema.ResourceData, u *schema.UsageData) *schema.Re
 				},
 			},
 		},
+		SubResources: []*schema.Resource{
+			networkEgress(region, u, "Network egress", "Internet data transfer", ComputeInternetEgress),
+		},
 	}
 }
@@ -105,7 +105,7 @@ func nodePoolCostComponents(region string, nodeConfig gjson.Result) []*schema.Co
 	}
 
 	purchaseOption := "on_demand"
-	if nodeConfig.Get("preemptible").Bool() {
+	if nodeConfig.Get("preemptible").Bool() || nodeConfig.Get("spot").Bool() {
 		purchaseOption = "preemptible"
 	}
 
@@ -120,7 +120,7 @@ func nodePoolCostComponents(region string, nodeConfig gjson.Result) []*schema.Co
 	}
 
 	costComponents := []*schema.CostComponent{
-		computeCostComponent(region, machineType, purchaseOption),
+		computeCostComponent(region, machineType, purchaseOption, ""),
 		computeDisk(region, diskType, &diskSize),
 	}
 
@@ -130,7 +130,7 @@ func nodePoolCostComponents(region string, nodeConfig gjson.Result) []*schema.Co
 	}
 
 	for _, guestAccel := range nodeConfig.Get("guest_accelerator").Array() {
-		costComponents = append(costComponents, guestAccelerator(region, purchaseOption, guestAccel))
+		costComponents = append(costComponents, guestAccelerator(region, purchaseOption, "", guestAccel))
 	}
 
 	return costComponents
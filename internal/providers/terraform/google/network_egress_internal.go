@@ -14,6 +14,8 @@ const (
 	ContainerRegistryEgress
 	ComputeVPNGateway
 	ComputeExternalVPNGateway
+	ComputeInterRegionEgress
+	ComputeInternetEgress
 )
 
 type egressRegionData struct {
@@ -135,7 +137,7 @@ func networkEgress(region string, u *schema.UsageData, resourceName, prefixName
 
 func doesEgressIncludeSameContinent(egressResourceType EgressResourceType) bool {
 	switch egressResourceType {
-	case ComputeExternalVPNGateway, ComputeVPNGateway:
+	case ComputeExternalVPNGateway, ComputeVPNGateway, ComputeInterRegionEgress, ComputeInternetEgress:
 		return false
 	default:
 		return true
@@ -210,6 +212,65 @@ func getEgressRegionsData(prefixName string, egressResourceType EgressResourceTy
 				usageKey:            "monthly_egress_data_transfer_gb.australia",
 			},
 		}
+	case ComputeInterRegionEgress:
+		return []*egressRegionData{
+			{
+				gRegion:        fmt.Sprintf("%s within the US or Canada", prefixName),
+				apiDescription: "Network Inter Region Egress from Americas to Montreal",
+				usageKey:       "monthly_inter_region_data_transfer_gb.us_or_canada",
+				fixedRegion:    "us-central1",
+			},
+			{
+				gRegion:        fmt.Sprintf("%s within Europe", prefixName),
+				apiDescription: "Network Inter Region Egress from EMEA to EMEA",
+				usageKey:       "monthly_inter_region_data_transfer_gb.europe",
+				fixedRegion:    "europe-west1",
+			},
+			{
+				gRegion:        fmt.Sprintf("%s within Asia", prefixName),
+				apiDescription: "Network Inter Region Egress from Japan to Seoul",
+				usageKey:       "monthly_inter_region_data_transfer_gb.asia",
+				fixedRegion:    "asia-northeast1",
+			},
+			{
+				gRegion:        fmt.Sprintf("%s within South America", prefixName),
+				apiDescription: "Network Inter Region Egress from Sao Paulo to Sao Paulo",
+				usageKey:       "monthly_inter_region_data_transfer_gb.south_america",
+				fixedRegion:    "southamerica-east1",
+			},
+			{
+				gRegion:        fmt.Sprintf("%s to/from Indonesia and Oceania", prefixName),
+				apiDescription: "Network Inter Region Egress from Sydney to Jakarta",
+				usageKey:       "monthly_inter_region_data_transfer_gb.oceania",
+				fixedRegion:    "australia-southeast1",
+			},
+			{
+				gRegion:        fmt.Sprintf("%s between continents (excludes Oceania)", prefixName),
+				apiDescription: "Network Inter Region Egress from Finland to Singapore",
+				usageKey:       "monthly_inter_region_data_transfer_gb.worldwide",
+				fixedRegion:    "europe-north1",
+			},
+		}
+
+	case ComputeInternetEgress:
+		return []*egressRegionData{
+			{
+				gRegion: fmt.Sprintf("%s to worldwide excluding China, Australia but including Hong Kong", prefixName),
+				// The price is the same for all destinations other than China and Australia.
+				apiDescriptionRegex: "/^Network Internet Egress from .* to Americas/",
+				usageKey:            "monthly_egress_data_transfer_gb.worldwide",
+			},
+			{
+				gRegion:             fmt.Sprintf("%s to China excluding Hong Kong", prefixName),
+				apiDescriptionRegex: "/^Network Internet Egress from .* to China/",
+				usageKey:            "monthly_egress_data_transfer_gb.china",
+			},
+			{
+				gRegion:             fmt.Sprintf("%s to Australia", prefixName),
+				apiDescriptionRegex: "/^Network Internet Egress from .* to Australia/",
+				usageKey:            "monthly_egress_data_transfer_gb.australia",
+			},
+		}
 	default:
 		return []*egressRegionData{
 			{
@@ -238,7 +299,7 @@ func getEgressRegionsData(prefixName string, egressResourceType EgressResourceTy
 
 func getEgressUsageFiltersData(egressResourceType EgressResourceType) []*egressRegionUsageFilterData {
 	switch egressResourceType {
-	case ComputeVPNGateway:
+	case ComputeVPNGateway, ComputeInterRegionEgress:
 		return []*egressRegionUsageFilterData{
 			{
 				usageNumber: 0,
@@ -264,7 +325,7 @@ func getEgressUsageFiltersData(egressResourceType EgressResourceType) []*egressR
 
 func getEgressAPIRegionName(region string, egressResourceType EgressResourceType) *string {
 	switch egressResourceType {
-	case ComputeExternalVPNGateway, ComputeVPNGateway:
+	case ComputeExternalVPNGateway, ComputeVPNGateway, ComputeInterRegionEgress, ComputeInternetEgress:
 		return strPtr(region)
 	default:
 		return nil
@@ -273,7 +334,7 @@ func getEgressAPIRegionName(region string, egressResourceType EgressResourceType
 
 func getEgressAPIServiceName(egressResourceType EgressResourceType) *string {
 	switch egressResourceType {
-	case ComputeExternalVPNGateway, ComputeVPNGateway:
+	case ComputeExternalVPNGateway, ComputeVPNGateway, ComputeInterRegionEgress, ComputeInternetEgress:
 		return strPtr("Compute Engine")
 	default:
 		return strPtr("Cloud Storage")
@@ -0,0 +1,65 @@
+package google
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestComputeInstanceNetworkEgress(t *testing.T) {
+	d := schema.NewResourceData("google_compute_instance", "google", "google_compute_instance.instance", nil, gjson.Parse(`{
+		"machine_type": "f1-micro",
+		"zone": "us-central1-a"
+	}`))
+	u := schema.NewUsageData("google_compute_instance.instance", schema.ParseAttributes(map[string]interface{}{
+		"monthly_inter_region_data_transfer_gb": map[string]interface{}{
+			"europe": 70,
+		},
+		"monthly_egress_data_transfer_gb": map[string]interface{}{
+			"worldwide": 2048,
+		},
+	}))
+
+	r := NewComputeInstance(d, u)
+	require.NotNil(t, r)
+	require.Len(t, r.SubResources, 1)
+
+	egress := r.SubResources[0]
+	assert.Equal(t, "Network egress", egress.Name)
+
+	quantities := make(map[string]int64)
+	for _, c := range egress.CostComponents {
+		if c.MonthlyQuantity != nil {
+			quantities[c.Name] = c.MonthlyQuantity.IntPart()
+		}
+	}
+
+	assert.Equal(t, map[string]int64{
+		"Inter-region data transfer within Europe":                                                           70,
+		"Internet data transfer to worldwide excluding China, Australia but including Hong Kong (first 1TB)": 1024,
+		"Internet data transfer to worldwide excluding China, Australia but including Hong Kong (next 9TB)":  1024,
+	}, quantities)
+
+	for _, c := range egress.CostComponents {
+		assert.Equal(t, "Compute Engine", *c.ProductFilter.Service, c.Name)
+	}
+}
+
+func TestComputeRouterNATNetworkEgress(t *testing.T) {
+	d := schema.NewResourceData("google_compute_router_nat", "google", "google_compute_router_nat.nat", nil, gjson.Parse(`{
+		"region": "us-central1"
+	}`))
+
+	r := NewComputeRouterNAT(d, nil)
+	require.NotNil(t, r)
+	require.Len(t, r.SubResources, 1)
+
+	egress := r.SubResources[0]
+	require.Len(t, egress.CostComponents, 3)
+	assert.Equal(t, "us-central1", *egress.CostComponents[0].ProductFilter.Region)
+	assert.Equal(t, "/^Network Internet Egress from .* to China/", *egress.CostComponents[1].ProductFilter.AttributeFilters[0].ValueRegex)
+}
@@ -17,31 +17,14 @@ func GetRedisInstanceRegistryItem() *schema.RegistryItem {
 
 func NewRedisInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 	region := d.Get("region").String()
-	serviceTier := "Basic"
-
-	var tierMapping = map[string]string{
-		"BASIC":       "Basic",
-		"STANDARD_HA": "Standard",
-	}
 
-	if d.Get("tier").Exists() {
-		serviceTier = tierMapping[d.Get("tier").String()]
+	serviceTier := "Basic"
+	if strings.EqualFold(d.Get("tier").String(), "STANDARD_HA") {
+		serviceTier = "Standard"
 	}
 
-	var memorySize = d.Get("memory_size_gb").Int()
-	var capacityTier string
-
-	if memorySize >= 1 && memorySize <= 4 {
-		capacityTier = "M1"
-	} else if memorySize >= 5 && memorySize <= 10 {
-		capacityTier = "M2"
-	} else if memorySize >= 11 && memorySize <= 35 {
-		capacityTier = "M3"
-	} else if memorySize >= 36 && memorySize <= 100 {
-		capacityTier = "M4"
-	} else {
-		capacityTier = "M5"
-	}
+	memorySize := d.Get("memory_size_gb").Int()
+	capacityTier := redisCapacityTier(memorySize)
 
 	description := fmt.Sprintf("/Redis Capacity %s %s/", serviceTier, capacityTier)
 	name := fmt.Sprintf("Redis instance (%s, %s)", strings.ToLower(serviceTier), capacityTier)
@@ -50,10 +33,10 @@ func NewRedisInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resou
 		Name: d.Address,
 		CostComponents: []*schema.CostComponent{
 			{
-				Name:            name,
-				Unit:            "GB",
-				UnitMultiplier:  1,
-				MonthlyQuantity: decimalPtr(decimal.NewFromInt(memorySize)),
+				Name:           name,
+				Unit:           "GB-hours",
+				UnitMultiplier: 1,
+				HourlyQuantity: decimalPtr(decimal.NewFromInt(memorySize)),
 				ProductFilter: &schema.ProductFilter{
 					VendorName:    strPtr("gcp"),
 					Region:        strPtr(region),
@@ -67,3 +50,20 @@ func NewRedisInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resou
 		},
 	}
 }
+
+// redisCapacityTier returns the capacity tier that the instance's memory is
+// billed at. The price per GB-hour is lower for the larger tiers.
+func redisCapacityTier(memorySize int64) string {
+	switch {
+	case memorySize <= 4:
+		return "M1"
+	case memorySize <= 10:
+		return "M2"
+	case memorySize <= 35:
+		return "M3"
+	case memorySize <= 100:
+		return "M4"
+	default:
+		return "M5"
+	}
+}
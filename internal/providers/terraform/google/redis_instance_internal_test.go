@@ -0,0 +1,50 @@
+package google
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestRedisInstanceTier(t *testing.T) {
+	d := schema.NewResourceData("google_redis_instance", "google", "google_redis_instance.redis", nil, gjson.Parse(`{
+		"region": "us-central1",
+		"tier": "standard_ha",
+		"memory_size_gb": 36
+	}`))
+
+	r := NewRedisInstance(d, nil)
+	require.NotNil(t, r)
+	require.Len(t, r.CostComponents, 1)
+
+	c := r.CostComponents[0]
+	assert.Equal(t, "Redis instance (standard, M4)", c.Name)
+	assert.Equal(t, "/Redis Capacity Standard M4/", *c.ProductFilter.AttributeFilters[0].ValueRegex)
+	assert.Equal(t, int64(36), c.HourlyQuantity.IntPart())
+}
+
+func TestRedisCapacityTier(t *testing.T) {
+	tests := []struct {
+		memorySize int64
+		expected   string
+	}{
+		{1, "M1"},
+		{4, "M1"},
+		{5, "M2"},
+		{10, "M2"},
+		{11, "M3"},
+		{35, "M3"},
+		{36, "M4"},
+		{100, "M4"},
+		{101, "M5"},
+		{300, "M5"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, redisCapacityTier(tt.memorySize), tt.memorySize)
+	}
+}
@@ -3,9 +3,11 @@ package google
 import "github.com/infracost/infracost/internal/schema"
 
 var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
+	GetBigqueryCapacityCommitmentRegistryItem(),
 	GetBigqueryDatasetRegistryItem(),
 	GetBigqueryTableRegistryItem(),
 	GetCloudFunctionsRegistryItem(),
+	GetCloudRunServiceRegistryItem(),
 	GetComputeAddressRegistryItem(),
 	GetComputeDiskRegistryItem(),
 	GetComputeExternalVPNGatewayRegistryItem(),
@@ -15,7 +17,9 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	GetComputeHAVPNGatewayRegistryItem(),
 	GetComputeImageRegistryItem(),
 	GetComputeInstanceRegistryItem(),
+	GetComputeInstanceGroupManagerRegistryItem(),
 	GetComputeMachineImageRegistryItem(),
+	GetComputeRegionInstanceGroupManagerRegistryItem(),
 	GetComputeRegionTargetHTTPProxyRegistryItem(),
 	GetComputeRegionTargetHTTPSProxyRegistryItem(),
 	GetComputeRouterNATRegistryItem(),
@@ -56,6 +60,8 @@ var FreeResources []string = []string{
 	"google_bigquery_dataset_iam_member",
 	"google_bigquery_dataset_iam_policy",
 	"google_bigquery_job",
+	"google_bigquery_reservation",
+	"google_bigquery_reservation_assignment",
 	"google_bigquery_routine",
 	"google_bigquery_table_iam_binding",
 	"google_bigquery_table_iam_member",
@@ -63,6 +69,10 @@ var FreeResources []string = []string{
 	"google_cloudfunctions_function_iam_binding",
 	"google_cloudfunctions_function_iam_member",
 	"google_cloudfunctions_function_iam_policy",
+	"google_cloud_run_domain_mapping",
+	"google_cloud_run_service_iam_binding",
+	"google_cloud_run_service_iam_member",
+	"google_cloud_run_service_iam_policy",
 	"google_compute_attached_disk",
 	"google_compute_backend_bucket",
 	"google_compute_backend_bucket_signed_url_key",
@@ -86,6 +96,7 @@ var FreeResources []string = []string{
 	"google_compute_instance_iam_binding",
 	"google_compute_instance_iam_member",
 	"google_compute_instance_iam_policy",
+	"google_compute_instance_template",
 	"google_compute_machine_image_iam_binding",
 	"google_compute_machine_image_iam_member",
 	"google_compute_machine_image_iam_policy",
@@ -194,14 +205,11 @@ var UsageOnlyResources []string = []string{}
 //
 // Node groups and autoscaling:
 // google_compute_autoscaler
-// google_compute_instance_template
 // google_compute_target_pool
-// google_compute_instance_group_manager
 // google_compute_per_instance_config
 // google_compute_region_autoscaler
 // google_compute_node_group
 // google_compute_node_template
-// google_compute_region_instance_group_manager
 // google_compute_region_per_instance_config
 //
 // Disk and images (https://cloud.google.com/compute/disks-image-pricing):
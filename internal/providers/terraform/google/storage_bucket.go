@@ -43,6 +43,7 @@ func getDSRegionResourceGroup(location, storageClass string) (string, string) {
 	// its "MultiRegionalStorage". For other storage classes they are fixed.
 
 	// Convert the location field in terraform to a valid region name
+	location = strings.ToUpper(location)
 	region := strings.ToLower(location)
 
 	// Get the right resourceGroup for api query
@@ -58,16 +59,8 @@ func getDSRegionResourceGroup(location, storageClass string) (string, string) {
 		resourceGroup = "RegionalStorage"
 	}
 	// Set the resource group to the right value if the location is a multi-region region
-	if resourceGroup == "RegionalStorage" {
-		switch location {
-		// Multi-region locations
-		case "ASIA", "EU", "US":
-			resourceGroup = "MultiRegionalStorage"
-		// Dual-region locations
-		case "ASIA1", "EUR4", "NAM4":
-			// The pricing api treats a dual-region as a multi-region
-			resourceGroup = "MultiRegionalStorage"
-		}
+	if resourceGroup == "RegionalStorage" && isMultiRegionLocation(location) {
+		resourceGroup = "MultiRegionalStorage"
 	}
 
 	// Handling an exceptional naming
@@ -78,16 +71,38 @@ func getDSRegionResourceGroup(location, storageClass string) (string, string) {
 	return region, resourceGroup
 }
 
+// isMultiRegionLocation returns true for the multi-region and dual-region
+// locations, which the pricing api treats the same.
+func isMultiRegionLocation(location string) bool {
+	switch strings.ToUpper(location) {
+	// Multi-region locations
+	case "ASIA", "EU", "US":
+		return true
+	// Dual-region locations
+	case "ASIA1", "EUR4", "EUR5", "EUR7", "EUR8", "NAM4":
+		return true
+	}
+
+	return false
+}
+
+// storageBucketClass returns the storage class of the bucket, which defaults
+// to STANDARD.
+func storageBucketClass(d *schema.ResourceData) string {
+	if d.Get("storage_class").String() != "" {
+		return strings.ToUpper(d.Get("storage_class").String())
+	}
+
+	return "STANDARD"
+}
+
 func dataStorage(d *schema.ResourceData, u *schema.UsageData) *schema.CostComponent {
 	location := d.Get("location").String()
 	var quantity *decimal.Decimal
 	if u != nil && u.Get("storage_gb").Exists() {
-		quantity = decimalPtr(decimal.NewFromInt(u.Get("storage_gb").Int()))
-	}
-	storageClass := "STANDARD"
-	if d.Get("storage_class").Exists() {
-		storageClass = d.Get("storage_class").String()
+		quantity = decimalPtr(decimal.NewFromFloat(u.Get("storage_gb").Float()))
 	}
+	storageClass := storageBucketClass(d)
 
 	region, resourceGroup := getDSRegionResourceGroup(location, storageClass)
 	return &schema.CostComponent{
@@ -119,9 +134,12 @@ func operations(d *schema.ResourceData, u *schema.UsageData) []*schema.CostCompo
 	if u != nil && u.Get("monthly_class_b_operations").Exists() {
 		classBQuantity = decimalPtr(decimal.NewFromInt(u.Get("monthly_class_b_operations").Int()))
 	}
-	storageClass := "STANDARD"
-	if d.Get("storage_class").Exists() {
-		storageClass = d.Get("storage_class").String()
+	storageClass := storageBucketClass(d)
+
+	// Standard operations in multi-regions and dual-regions are priced the
+	// same as the legacy multi-regional storage class
+	if storageClass == "STANDARD" && isMultiRegionLocation(d.Get("location").String()) {
+		storageClass = "MULTI_REGIONAL"
 	}
 
 	storageClassResourceGroupMap := map[string]string{
@@ -174,13 +192,10 @@ func operations(d *schema.ResourceData, u *schema.UsageData) []*schema.CostCompo
 func dataRetrieval(d *schema.ResourceData, u *schema.UsageData) *schema.CostComponent {
 	var quantity *decimal.Decimal
 	if u != nil && u.Get("monthly_data_retrieval_gb").Exists() {
-		quantity = decimalPtr(decimal.NewFromInt(u.Get("monthly_data_retrieval_gb").Int()))
+		quantity = decimalPtr(decimal.NewFromFloat(u.Get("monthly_data_retrieval_gb").Float()))
 	}
 
-	storageClass := "STANDARD"
-	if d.Get("storage_class").Exists() {
-		storageClass = d.Get("storage_class").String()
-	}
+	storageClass := storageBucketClass(d)
 
 	storageClassResourceGroupMap := map[string]string{
 		"NEARLINE": "NearlineOps",
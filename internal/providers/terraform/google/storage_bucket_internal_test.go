@@ -0,0 +1,54 @@
+package google
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestStorageBucketMultiRegionOperations(t *testing.T) {
+	d := schema.NewResourceData("google_storage_bucket", "google", "google_storage_bucket.bucket", nil, gjson.Parse(`{
+		"location": "eur4"
+	}`))
+
+	r := NewStorageBucket(d, nil)
+	require.NotNil(t, r)
+	require.Len(t, r.CostComponents, 3)
+
+	assert.Equal(t, "Storage (standard)", r.CostComponents[0].Name)
+	assert.Equal(t, "MultiRegionalStorage", *r.CostComponents[0].ProductFilter.AttributeFilters[0].Value)
+	assert.Equal(t, "MultiRegionalOps", *r.CostComponents[1].ProductFilter.AttributeFilters[0].Value)
+	assert.Equal(t, "MultiRegionalOps", *r.CostComponents[2].ProductFilter.AttributeFilters[0].Value)
+}
+
+func TestStorageBucketRegionalOperations(t *testing.T) {
+	d := schema.NewResourceData("google_storage_bucket", "google", "google_storage_bucket.bucket", nil, gjson.Parse(`{
+		"location": "US-CENTRAL1",
+		"storage_class": "nearline"
+	}`))
+
+	u := &schema.UsageData{
+		Attributes: map[string]gjson.Result{
+			"storage_gb":                gjson.Parse(`10.5`),
+			"monthly_data_retrieval_gb": gjson.Parse(`2.5`),
+		},
+	}
+
+	r := NewStorageBucket(d, u)
+	require.NotNil(t, r)
+	require.Len(t, r.CostComponents, 4)
+
+	assert.Equal(t, "Storage (nearline)", r.CostComponents[0].Name)
+	assert.Equal(t, "us-central1", *r.CostComponents[0].ProductFilter.Region)
+	assert.True(t, decimal.NewFromFloat(10.5).Equal(*r.CostComponents[0].MonthlyQuantity))
+
+	assert.Equal(t, "Data retrieval", r.CostComponents[1].Name)
+	assert.True(t, decimal.NewFromFloat(2.5).Equal(*r.CostComponents[1].MonthlyQuantity))
+
+	assert.Equal(t, "NearlineOps", *r.CostComponents[2].ProductFilter.AttributeFilters[0].Value)
+}
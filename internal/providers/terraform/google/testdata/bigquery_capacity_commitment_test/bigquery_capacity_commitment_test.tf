@@ -0,0 +1,22 @@
+provider "google" {
+  credentials = "{\"type\":\"service_account\"}"
+  region      = "us-central1"
+}
+
+resource "google_bigquery_capacity_commitment" "flex" {
+  location   = "US"
+  slot_count = 100
+  plan       = "FLEX"
+}
+
+resource "google_bigquery_capacity_commitment" "monthly" {
+  location   = "EU"
+  slot_count = 500
+  plan       = "MONTHLY"
+}
+
+resource "google_bigquery_capacity_commitment" "annual" {
+  location   = "asia-northeast1"
+  slot_count = 1000
+  plan       = "ANNUAL"
+}
@@ -0,0 +1,63 @@
+provider "google" {
+  credentials = "{\"type\":\"service_account\"}"
+  region      = "us-central1"
+}
+
+resource "google_cloud_run_service" "default" {
+  name     = "default"
+  location = "us-central1"
+
+  template {
+    spec {
+      containers {
+        image = "us-docker.pkg.dev/cloudrun/container/hello"
+      }
+    }
+  }
+}
+
+resource "google_cloud_run_service" "with_usage" {
+  name     = "with-usage"
+  location = "us-central1"
+
+  template {
+    spec {
+      containers {
+        image = "us-docker.pkg.dev/cloudrun/container/hello"
+
+        resources {
+          limits = {
+            cpu    = "2000m"
+            memory = "1Gi"
+          }
+        }
+      }
+    }
+  }
+}
+
+resource "google_cloud_run_service" "min_instances" {
+  name     = "min-instances"
+  location = "us-central1"
+
+  template {
+    metadata {
+      annotations = {
+        "autoscaling.knative.dev/minScale" = "2"
+      }
+    }
+
+    spec {
+      containers {
+        image = "us-docker.pkg.dev/cloudrun/container/hello"
+
+        resources {
+          limits = {
+            cpu    = "1"
+            memory = "512Mi"
+          }
+        }
+      }
+    }
+  }
+}
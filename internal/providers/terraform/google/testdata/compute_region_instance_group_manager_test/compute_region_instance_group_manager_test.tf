@@ -0,0 +1,108 @@
+provider "google" {
+  credentials = "{\"type\":\"service_account\"}"
+  region      = "us-central1"
+}
+
+resource "google_compute_instance_template" "standard" {
+  name         = "standard"
+  machine_type = "n1-standard-1"
+
+  disk {
+    source_image = "debian-cloud/debian-11"
+    boot         = true
+  }
+
+  disk {
+    disk_type    = "pd-ssd"
+    disk_size_gb = 50
+  }
+
+  network_interface {
+    network = "default"
+  }
+}
+
+resource "google_compute_instance_template" "spot" {
+  name         = "spot"
+  machine_type = "n1-standard-1"
+
+  disk {
+    source_image = "debian-cloud/debian-11"
+    boot         = true
+  }
+
+  scheduling {
+    preemptible        = true
+    automatic_restart  = false
+    provisioning_model = "SPOT"
+  }
+
+  network_interface {
+    network = "default"
+  }
+}
+
+resource "google_compute_instance_template" "local_ssd" {
+  name         = "local-ssd"
+  machine_type = "n1-standard-1"
+
+  disk {
+    source_image = "debian-cloud/debian-11"
+    boot         = true
+  }
+
+  disk {
+    type         = "SCRATCH"
+    disk_type    = "local-ssd"
+    disk_size_gb = 375
+    interface    = "NVME"
+  }
+
+  network_interface {
+    network = "default"
+  }
+}
+
+resource "google_compute_region_instance_group_manager" "standard" {
+  name               = "standard"
+  base_instance_name = "standard"
+  region             = "us-central1"
+  target_size        = 2
+
+  version {
+    instance_template = google_compute_instance_template.standard.id
+  }
+}
+
+resource "google_compute_region_instance_group_manager" "with_usage" {
+  name               = "with-usage"
+  base_instance_name = "with-usage"
+  region             = "us-central1"
+  target_size        = 2
+
+  version {
+    instance_template = google_compute_instance_template.standard.id
+  }
+}
+
+resource "google_compute_region_instance_group_manager" "spot" {
+  name               = "spot"
+  base_instance_name = "spot"
+  region             = "us-central1"
+  target_size        = 3
+
+  version {
+    instance_template = google_compute_instance_template.spot.id
+  }
+}
+
+resource "google_compute_region_instance_group_manager" "local_ssd" {
+  name               = "local-ssd"
+  base_instance_name = "local-ssd"
+  region             = "us-central1"
+  target_size        = 1
+
+  version {
+    instance_template = google_compute_instance_template.local_ssd.id
+  }
+}
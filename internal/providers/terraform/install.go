@@ -0,0 +1,263 @@
+package terraform
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// downloadClient downloads the binaries, its timeout allows for large zips on
+// slow connections.
+var downloadClient = &http.Client{Timeout: 5 * time.Minute}
+
+// releaseSources are the binaries that can be installed automatically if
+// they can't be found, keyed by the binary name.
+var releaseSources = map[string]releaseSource{
+	"terraform": {
+		DefaultVersion: "0.15.5",
+		URL: func(v, filename string) string {
+			return fmt.Sprintf("https://releases.hashicorp.com/terraform/%s/%s", v, filename)
+		},
+	},
+	"tofu": {
+		DefaultVersion: "1.6.2",
+		URL: func(v, filename string) string {
+			return fmt.Sprintf("https://github.com/opentofu/opentofu/releases/download/v%s/%s", v, filename)
+		},
+	},
+}
+
+type releaseSource struct {
+	DefaultVersion string
+	URL            func(version string, filename string) string
+}
+
+// TerraformInstaller is implemented by providers that run Terraform so the
+// binary can be installed before it is used.
+type TerraformInstaller interface {
+	InstallTerraformIfMissing() (string, error)
+}
+
+// InstallTerraformIfMissing downloads a Terraform (or OpenTofu) release
+// matching the current OS and architecture to the install dir if the
+// Terraform binary can't be found. By default this is only done when running
+// in CI or a container, since that's where the binary is most often missing.
+// It returns the path of the binary that should be used.
+func (p *DirProvider) InstallTerraformIfMissing() (string, error) {
+	if _, err := exec.LookPath(p.TerraformBinary); err == nil {
+		return p.TerraformBinary, nil
+	}
+
+	source, ok := releaseSources[p.TerraformBinary]
+	if !ok || !p.shouldAutoInstall() {
+		return p.TerraformBinary, nil
+	}
+
+	version := strings.TrimPrefix(p.installVersion, "v")
+	if version == "" {
+		version = source.DefaultVersion
+	}
+
+	dir := p.installDir
+	if dir == "" {
+		dir = defaultInstallDir()
+	}
+
+	binary, err := installRelease(p.TerraformBinary, version, source, dir, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return p.TerraformBinary, errors.Wrapf(err, "Error installing %s %s", p.TerraformBinary, version)
+	}
+
+	p.TerraformBinary = binary
+
+	// Each version and platform of each binary is an entry in the install dir,
+	// the one that's being used is kept even if it's over the max size
+	_, err = config.PruneCache(dir, "*/*/*", p.maxCacheSize, filepath.Dir(binary))
+	if err != nil {
+		log.Warnf("Error pruning %s: %v", dir, err)
+	}
+
+	return binary, nil
+}
+
+func (p *DirProvider) shouldAutoInstall() bool {
+	if p.autoInstall != nil {
+		return *p.autoInstall
+	}
+
+	return p.env.CIPlatform != "" || isContainer()
+}
+
+func isContainer() bool {
+	if _, err := os.Stat("/.dockerenv"); err == nil {
+		return true
+	}
+
+	if os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
+		return true
+	}
+
+	b, err := ioutil.ReadFile("/proc/1/cgroup")
+	if err != nil {
+		return false
+	}
+
+	s := string(b)
+	return strings.Contains(s, "docker") || strings.Contains(s, "kubepods") || strings.Contains(s, "containerd")
+}
+
+func defaultInstallDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir, _ = homedir.Expand("~/.cache")
+	}
+
+	return filepath.Join(dir, "infracost", "bin")
+}
+
+// installRelease downloads and extracts the release zip file, verifying it
+// against the release's SHA256SUMS file. Releases that have already been
+// installed to the dir are reused.
+func installRelease(name string, version string, source releaseSource, dir string, goos string, goarch string) (string, error) {
+	exe := name
+	if goos == "windows" {
+		exe += ".exe"
+	}
+
+	binary := filepath.Join(dir, name, version, fmt.Sprintf("%s_%s", goos, goarch), exe)
+	if _, err := os.Stat(binary); err == nil {
+		log.Debugf("Using previously installed %s at %s", name, binary)
+
+		err = config.TouchCacheEntry(filepath.Dir(binary))
+		if err != nil {
+			log.Debugf("Error marking %s as used: %v", binary, err)
+		}
+
+		return binary, nil
+	}
+
+	zipFilename := fmt.Sprintf("%s_%s_%s_%s.zip", name, version, goos, goarch)
+	sumsFilename := fmt.Sprintf("%s_%s_SHA256SUMS", name, version)
+
+	m := fmt.Sprintf("%s could not be found, installing %s %s to %s", name, name, version, ui.DisplayPath(filepath.Dir(binary)))
+	log.Info(m)
+
+	sums, err := download(source.URL(version, sumsFilename))
+	if err != nil {
+		return "", err
+	}
+
+	expectedSum, err := findSHA256Sum(sums, zipFilename)
+	if err != nil {
+		return "", err
+	}
+
+	zipContents, err := download(source.URL(version, zipFilename))
+	if err != nil {
+		return "", err
+	}
+
+	actualSum := fmt.Sprintf("%x", sha256.Sum256(zipContents))
+	if actualSum != expectedSum {
+		return "", errors.Errorf("Checksum mismatch for %s, expected %s but got %s", zipFilename, expectedSum, actualSum)
+	}
+
+	err = extractBinary(zipContents, exe, binary)
+	if err != nil {
+		return "", err
+	}
+
+	return binary, nil
+}
+
+func download(url string) ([]byte, error) {
+	log.Debugf("Downloading %s", url)
+
+	resp, err := downloadClient.Get(url) // nolint:gosec
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error downloading %s", url)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, errors.Errorf("Error downloading %s: %s", url, resp.Status)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error downloading %s", url)
+	}
+
+	return b, nil
+}
+
+func findSHA256Sum(sums []byte, filename string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(sums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == filename {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+
+	return "", errors.Errorf("No checksum found for %s", filename)
+}
+
+func extractBinary(zipContents []byte, exe string, dest string) error {
+	r, err := zip.NewReader(bytes.NewReader(zipContents), int64(len(zipContents)))
+	if err != nil {
+		return errors.Wrap(err, "Error reading release zip file")
+	}
+
+	for _, f := range r.File {
+		if f.Name != exe {
+			continue
+		}
+
+		err = os.MkdirAll(filepath.Dir(dest), 0755)
+		if err != nil {
+			return errors.Wrap(err, "Error creating install directory")
+		}
+
+		src, err := f.Open()
+		if err != nil {
+			return errors.Wrap(err, "Error reading release zip file")
+		}
+		defer src.Close()
+
+		// Write to a temporary file first so a partial download is never used
+		tmp := dest + ".tmp"
+		out, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755) // nolint:gosec
+		if err != nil {
+			return errors.Wrap(err, "Error writing binary")
+		}
+
+		_, err = io.Copy(out, src) // nolint:gosec
+		out.Close()
+		if err != nil {
+			return errors.Wrap(err, "Error writing binary")
+		}
+
+		return os.Rename(tmp, dest)
+	}
+
+	return errors.Errorf("%s not found in release zip file", exe)
+}
@@ -0,0 +1,70 @@
+package terraform
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstallRelease(t *testing.T) {
+	var zipBuf bytes.Buffer
+	w := zip.NewWriter(&zipBuf)
+	f, err := w.Create("terraform")
+	require.NoError(t, err)
+	_, err = f.Write([]byte("#!/bin/sh\necho Terraform v0.15.5\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	zipFilename := "terraform_0.15.5_linux_arm64.zip"
+	sums := fmt.Sprintf("%x  terraform_0.15.5_darwin_amd64.zip\n%x  %s\n", sha256.Sum256([]byte("other")), sha256.Sum256(zipBuf.Bytes()), zipFilename)
+
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/terraform_0.15.5_SHA256SUMS":
+			_, _ = w.Write([]byte(sums))
+		case "/" + zipFilename:
+			_, _ = w.Write(zipBuf.Bytes())
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	source := releaseSource{
+		URL: func(v, filename string) string {
+			return fmt.Sprintf("%s/%s", ts.URL, filename)
+		},
+	}
+
+	dir := t.TempDir()
+	binary, err := installRelease("terraform", "0.15.5", source, dir, "linux", "arm64")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "terraform", "0.15.5", "linux_arm64", "terraform"), binary)
+
+	contents, err := ioutil.ReadFile(binary)
+	require.NoError(t, err)
+	assert.Contains(t, string(contents), "Terraform v0.15.5")
+	assert.Equal(t, 2, requests)
+
+	// The installed binary should be reused
+	_, err = installRelease("terraform", "0.15.5", source, dir, "linux", "arm64")
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests)
+
+	// The checksum should be verified
+	sums = fmt.Sprintf("%x  %s\n", sha256.Sum256([]byte("tampered")), zipFilename)
+	_, err = installRelease("terraform", "0.15.5", source, t.TempDir(), "linux", "arm64")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Checksum mismatch")
+}
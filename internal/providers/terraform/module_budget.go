@@ -0,0 +1,190 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl2/hclparse"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+// ModuleBudgetFilename is the file that module authors add to a module to
+// set the monthly cost that each instance of the module is intended to stay
+// within.
+const ModuleBudgetFilename = ".infracost-module.yml"
+
+var moduleBudgetVersion = "0.1"
+
+type moduleBudgetFile struct {
+	Version       string   `yaml:"version"`
+	MonthlyBudget *float64 `yaml:"monthly_budget"`
+	Message       string   `yaml:"message,omitempty"`
+}
+
+// ModuleBudget is the budget of a module call, e.g. module.vpc, from the
+// module's budget file.
+type ModuleBudget struct {
+	Address       string
+	Source        string
+	MonthlyBudget decimal.Decimal
+	Message       string
+}
+
+// modulesManifest is the .terraform/modules/modules.json file that
+// terraform init writes with the directory of each module.
+type modulesManifest struct {
+	Modules []struct {
+		Key    string `json:"Key"`
+		Source string `json:"Source"`
+		Dir    string `json:"Dir"`
+	} `json:"Modules"`
+}
+
+// ModuleBudgets returns the budgets of the modules called from the Terraform
+// directory, keyed by the module address without any count or for_each
+// indexes, e.g. module.vpc.module.subnets. Local modules are always found,
+// remote modules are only found if terraform init has downloaded them.
+func ModuleBudgets(dir string) (map[string]*ModuleBudget, error) {
+	manifest := loadModulesManifest(dir)
+
+	budgets := make(map[string]*ModuleBudget)
+	err := addModuleBudgets(hclparse.NewParser(), dir, dir, "", "", manifest, budgets, 0)
+	return budgets, err
+}
+
+func addModuleBudgets(parser *hclparse.Parser, rootDir string, dir string, prefix string, key string, manifest map[string]string, budgets map[string]*ModuleBudget, depth int) error {
+	if depth > maxModuleDepth {
+		log.Debugf("Skipping module %s since it is nested too deeply", prefix)
+		return nil
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return errors.Wrapf(err, "Error reading Terraform directory %s", dir)
+	}
+
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".tf" {
+			continue
+		}
+
+		filename := filepath.Join(dir, f.Name())
+
+		file, diags := parser.ParseHCLFile(filename)
+		if diags.HasErrors() {
+			log.Debugf("Error parsing %s: %s", filename, diags.Error())
+			continue
+		}
+
+		content, _, _ := file.Body.PartialContent(sourceFileSchema)
+
+		for _, block := range content.Blocks {
+			if block.Type != "module" {
+				continue
+			}
+
+			name := block.Labels[0]
+			source := moduleSource(block)
+			moduleAddress := prefix + "module." + name
+			moduleKey := name
+			if key != "" {
+				moduleKey = key + "." + name
+			}
+
+			var moduleDir string
+			if d, ok := manifest[moduleKey]; ok {
+				moduleDir = d
+				if !filepath.IsAbs(d) {
+					moduleDir = filepath.Join(rootDir, d)
+				}
+			} else if isLocalModuleSource(source) {
+				moduleDir = filepath.Join(dir, source)
+			} else {
+				log.Debugf("Skipping module %s since it hasn't been downloaded, run terraform init to check its budget", moduleAddress)
+				continue
+			}
+
+			budget, err := loadModuleBudget(moduleDir)
+			if err != nil {
+				return errors.Wrapf(err, "Error reading the budget of %s", moduleAddress)
+			}
+			if budget != nil {
+				budget.Address = moduleAddress
+				budget.Source = source
+				budgets[moduleAddress] = budget
+			}
+
+			err = addModuleBudgets(parser, rootDir, moduleDir, moduleAddress+".", moduleKey, manifest, budgets, depth+1)
+			if err != nil {
+				log.Debugf("Error reading module %s: %v", moduleAddress, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// loadModuleBudget returns the budget from the module's budget file, or nil
+// if the module doesn't have one.
+func loadModuleBudget(dir string) (*ModuleBudget, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, ModuleBudgetFilename))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var f moduleBudgetFile
+	err = yaml.Unmarshal(b, &f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error parsing %s", ModuleBudgetFilename)
+	}
+
+	if f.Version != moduleBudgetVersion {
+		return nil, fmt.Errorf("Invalid %s version. Supported versions are %s", ModuleBudgetFilename, moduleBudgetVersion)
+	}
+
+	if f.MonthlyBudget == nil || *f.MonthlyBudget < 0 {
+		return nil, fmt.Errorf("Invalid %s: monthly_budget must be set to 0 or more", ModuleBudgetFilename)
+	}
+
+	return &ModuleBudget{
+		MonthlyBudget: decimal.NewFromFloat(*f.MonthlyBudget),
+		Message:       strings.TrimSpace(f.Message),
+	}, nil
+}
+
+// loadModulesManifest returns the directories of the modules that terraform
+// init has installed, keyed by the module key, e.g. vpc.subnets. The
+// directories are relative to the Terraform directory.
+func loadModulesManifest(dir string) map[string]string {
+	dirs := make(map[string]string)
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, ".terraform", "modules", "modules.json"))
+	if err != nil {
+		return dirs
+	}
+
+	var m modulesManifest
+	err = json.Unmarshal(b, &m)
+	if err != nil {
+		log.Debugf("Error parsing the Terraform modules manifest: %v", err)
+		return dirs
+	}
+
+	for _, mod := range m.Modules {
+		if mod.Key != "" {
+			dirs[mod.Key] = mod.Dir
+		}
+	}
+
+	return dirs
+}
@@ -0,0 +1,91 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModuleBudgets(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"main.tf": `
+module "web" {
+  source = "./modules/web"
+}
+
+module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "3.0.0"
+}
+
+module "not_downloaded" {
+  source = "git::https://example.com/modules.git"
+}
+`,
+		"modules/web/main.tf": `
+module "cache" {
+  source = "../cache"
+}
+
+resource "aws_instance" "web" {}
+`,
+		"modules/web/.infracost-module.yml": `version: 0.1
+monthly_budget: 100
+message: Use the large-web module for production workloads
+`,
+		"modules/cache/.infracost-module.yml": "version: 0.1\nmonthly_budget: 25.5\n",
+		"modules/cache/main.tf":               `resource "aws_elasticache_cluster" "cache" {}`,
+		".terraform/modules/modules.json": `{"Modules":[
+  {"Key":"","Source":"","Dir":"."},
+  {"Key":"web","Source":"./modules/web","Dir":"modules/web"},
+  {"Key":"web.cache","Source":"../cache","Dir":"modules/cache"},
+  {"Key":"vpc","Source":"registry.terraform.io/terraform-aws-modules/vpc/aws","Dir":".terraform/modules/vpc"}
+]}`,
+		".terraform/modules/vpc/main.tf":               `resource "aws_vpc" "this" {}`,
+		".terraform/modules/vpc/.infracost-module.yml": "version: 0.1\nmonthly_budget: 0\n",
+	}
+
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+	}
+
+	budgets, err := ModuleBudgets(dir)
+	require.NoError(t, err)
+
+	require.Len(t, budgets, 3)
+
+	assert.Equal(t, "module.web", budgets["module.web"].Address)
+	assert.Equal(t, "./modules/web", budgets["module.web"].Source)
+	assert.Equal(t, "100", budgets["module.web"].MonthlyBudget.String())
+	assert.Equal(t, "Use the large-web module for production workloads", budgets["module.web"].Message)
+
+	assert.Equal(t, "25.5", budgets["module.web.module.cache"].MonthlyBudget.String())
+	assert.Equal(t, "terraform-aws-modules/vpc/aws", budgets["module.vpc"].Source)
+	assert.Equal(t, "0", budgets["module.vpc"].MonthlyBudget.String())
+
+	// Local modules are found without the modules manifest
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, ".terraform")))
+
+	budgets, err = ModuleBudgets(dir)
+	require.NoError(t, err)
+	assert.Len(t, budgets, 2)
+	assert.Contains(t, budgets, "module.web.module.cache")
+}
+
+func TestModuleBudgetsInvalidFile(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "modules", "web"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.tf"), []byte(`module "web" { source = "./modules/web" }`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "modules", "web", ModuleBudgetFilename), []byte("version: 0.1\n"), 0600))
+
+	_, err := ModuleBudgets(dir)
+	assert.EqualError(t, err, "Error reading the budget of module.web: Invalid .infracost-module.yml: monthly_budget must be set to 0 or more")
+}
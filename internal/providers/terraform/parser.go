@@ -54,12 +54,14 @@ func (p *Parser) createResource(d *schema.ResourceData, u *schema.UsageData) *sc
 	if registryItem, ok := (*registryMap)[d.Type]; ok {
 		if registryItem.NoPrice {
 			return &schema.Resource{
-				Name:         d.Address,
-				ResourceType: d.Type,
-				Tags:         d.Tags,
-				IsSkipped:    true,
-				NoPrice:      true,
-				SkipMessage:  "Free resource.",
+				Name:          d.Address,
+				ResourceType:  d.Type,
+				Tags:          d.Tags,
+				SensitiveTags: sensitiveTags(d),
+				IsSkipped:     true,
+				NoPrice:       true,
+				SkipMessage:   "Free resource.",
+				SkipReason:    schema.SkipReasonFree,
 			}
 		}
 
@@ -67,16 +69,40 @@ func (p *Parser) createResource(d *schema.ResourceData, u *schema.UsageData) *sc
 		if res != nil {
 			res.ResourceType = d.Type
 			res.Tags = d.Tags
+			res.SensitiveTags = sensitiveTags(d)
+			res.RedactValues(d.SensitiveValues())
+			if res.IsSkipped && res.SkipReason == "" {
+				res.SkipReason = schema.SkipReasonUnsupported
+				if res.NoPrice {
+					res.SkipReason = schema.SkipReasonFree
+				}
+			}
 			return res
 		}
+
+		// The resources of the cloud providers are priced by region so they
+		// can't be priced without one
+		if defaultProviderRegions[resourceTypeProvider(d.Type)] != "" && d.Get("region").String() == "" {
+			return &schema.Resource{
+				Name:          d.Address,
+				ResourceType:  d.Type,
+				Tags:          d.Tags,
+				SensitiveTags: sensitiveTags(d),
+				IsSkipped:     true,
+				SkipMessage:   "The region of this resource could not be found",
+				SkipReason:    schema.SkipReasonMissingRegion,
+			}
+		}
 	}
 
 	return &schema.Resource{
-		Name:         d.Address,
-		ResourceType: d.Type,
-		Tags:         d.Tags,
-		IsSkipped:    true,
-		SkipMessage:  "This resource is not currently supported",
+		Name:          d.Address,
+		ResourceType:  d.Type,
+		Tags:          d.Tags,
+		SensitiveTags: sensitiveTags(d),
+		IsSkipped:     true,
+		SkipMessage:   "This resource is not currently supported",
+		SkipReason:    schema.SkipReasonUnsupported,
 	}
 }
 
@@ -100,17 +126,7 @@ func (p *Parser) parseJSONResources(parsePrior bool, baseResources []*schema.Res
 	p.stripDataResources(resData)
 
 	for _, d := range resData {
-		var usageData *schema.UsageData
-
-		if ud := usage[d.Address]; ud != nil {
-			usageData = ud
-		} else if strings.HasSuffix(d.Address, "]") {
-			lastIndexOfOpenBracket := strings.LastIndex(d.Address, "[")
-
-			if arrayUsageData := usage[fmt.Sprintf("%s[*]", d.Address[:lastIndexOfOpenBracket])]; arrayUsageData != nil {
-				usageData = arrayUsageData
-			}
-		}
+		usageData := schema.FindUsageData(usage, d.Address)
 		if r := p.createResource(d, usageData); r != nil {
 			resources = append(resources, r)
 		}
@@ -134,9 +150,35 @@ func (p *Parser) parseJSON(j []byte, usage map[string]*schema.UsageData) ([]*sch
 	pastResources := p.parseJSONResources(true, baseResources, usage, parsed, providerConf, conf, vars)
 	resources := p.parseJSONResources(false, baseResources, usage, parsed, providerConf, conf, vars)
 
+	planActions := parsePlanActions(parsed)
+	for _, r := range append(pastResources, resources...) {
+		if actions, ok := planActions[r.Name]; ok {
+			r.PlanActions = actions
+		}
+	}
+
 	return pastResources, resources, nil
 }
 
+// parsePlanActions returns the actions of each resource in the plan's
+// resource changes, keyed by the resource address.
+func parsePlanActions(parsed gjson.Result) map[string][]string {
+	planActions := make(map[string][]string)
+
+	for _, change := range parsed.Get("resource_changes").Array() {
+		actions := make([]string, 0)
+		for _, a := range change.Get("change.actions").Array() {
+			actions = append(actions, a.String())
+		}
+
+		if len(actions) > 0 {
+			planActions[change.Get("address").String()] = actions
+		}
+	}
+
+	return planActions
+}
+
 func (p *Parser) loadUsageFileResources(u map[string]*schema.UsageData) []*schema.Resource {
 	resources := make([]*schema.Resource, 0)
 
@@ -166,7 +208,8 @@ func (p *Parser) parseResourceData(providerConf, planVals gjson.Result, conf gjs
 		resConf := getConfJSON(conf, addr)
 
 		// Try getting the region from the ARN
-		region := resourceRegion(t, v)
+		sensitivePaths := schema.ParseSensitivePaths(r.Get("sensitive_values"))
+		region := resourceRegion(t, v, sensitivePaths)
 
 		// Otherwise use region from the provider conf
 		if region == "" {
@@ -177,7 +220,9 @@ func (p *Parser) parseResourceData(providerConf, planVals gjson.Result, conf gjs
 
 		tags := parseTags(t, v)
 
-		resources[addr] = schema.NewResourceData(t, provider, addr, tags, v)
+		d := schema.NewResourceData(t, provider, addr, tags, v)
+		d.SensitivePaths = sensitivePaths
+		resources[addr] = d
 	}
 
 	// Recursively add any resources for child modules
@@ -205,7 +250,29 @@ func parseTags(resourceType string, v gjson.Result) map[string]string {
 	return tags
 }
 
-func resourceRegion(resourceType string, v gjson.Result) string {
+// sensitiveTags returns the keys of the tags that Terraform marks as
+// sensitive, so their values can be redacted in the output.
+func sensitiveTags(d *schema.ResourceData) map[string]bool {
+	a := "tags"
+	if strings.HasPrefix(d.Type, "google_") {
+		a = "labels"
+	}
+
+	keys := make(map[string]bool)
+	for k := range d.Tags {
+		if d.IsSensitive(a, k) {
+			keys[k] = true
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return keys
+}
+
+func resourceRegion(resourceType string, v gjson.Result, sensitivePaths map[string]bool) string {
 	providerPrefix := strings.Split(resourceType, "_")[0]
 	if providerPrefix != "aws" {
 		return ""
@@ -229,6 +296,10 @@ func resourceRegion(resourceType string, v gjson.Result) string {
 	arn := v.Get(arnAttr).String()
 	p := strings.Split(arn, ":")
 	if len(p) < 4 {
+		// Don't log values from sensitive variables
+		if sensitivePaths[arnAttr] {
+			arn = schema.RedactedValue
+		}
 		log.Debugf("Unexpected ARN format for %s", arn)
 		return ""
 	}
@@ -575,3 +646,10 @@ func gjsonEscape(s string) string {
 
 	return s
 }
+
+// NewResource creates a resource from the resource data using the resource
+// registry, so resources that don't come from Terraform can be priced the
+// same way.
+func NewResource(env *config.Environment, d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	return NewParser(env).createResource(d, u)
+}
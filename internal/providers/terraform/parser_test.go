@@ -302,6 +302,7 @@ func TestParseJSONResources(t *testing.T) {
 func TestCreateResource(t *testing.T) {
 	tests := []struct {
 		data     *schema.ResourceData
+		usage    *schema.UsageData
 		expected *schema.Resource
 	}{
 		{
@@ -327,6 +328,7 @@ func TestCreateResource(t *testing.T) {
 				IsSkipped:    true,
 				NoPrice:      true,
 				SkipMessage:  "Free resource.",
+				SkipReason:   schema.SkipReasonFree,
 			},
 		},
 		{
@@ -340,6 +342,22 @@ func TestCreateResource(t *testing.T) {
 				IsSkipped:    true,
 				NoPrice:      false,
 				SkipMessage:  "This resource is not currently supported",
+				SkipReason:   schema.SkipReasonUnsupported,
+			},
+		},
+		{
+			data: &schema.ResourceData{
+				Address: "aws_data_transfer.missing_region",
+				Type:    "aws_data_transfer",
+			},
+			usage: schema.NewUsageData("aws_data_transfer.missing_region", map[string]gjson.Result{}),
+			expected: &schema.Resource{
+				Name:         "aws_data_transfer.missing_region",
+				ResourceType: "aws_data_transfer",
+				IsSkipped:    true,
+				NoPrice:      false,
+				SkipMessage:  "The region of this resource could not be found",
+				SkipReason:   schema.SkipReasonMissingRegion,
 			},
 		},
 	}
@@ -347,11 +365,12 @@ func TestCreateResource(t *testing.T) {
 	p := NewParser(config.NewEnvironment())
 
 	for _, test := range tests {
-		actual := p.createResource(test.data, nil)
+		actual := p.createResource(test.data, test.usage)
 		assert.Equal(t, test.expected.Name, actual.Name)
 		assert.Equal(t, test.expected.ResourceType, actual.ResourceType)
 		assert.Equal(t, test.expected.IsSkipped, actual.IsSkipped)
 		assert.Equal(t, test.expected.SkipMessage, actual.SkipMessage)
+		assert.Equal(t, test.expected.SkipReason, actual.SkipReason)
 	}
 }
 
@@ -534,6 +553,46 @@ func TestParseResourceData(t *testing.T) {
 	}
 }
 
+func TestParseResourceData_sensitiveValues(t *testing.T) {
+	planVals := gjson.Parse(`{
+		"resources": [
+			{
+				"address": "aws_instance.web",
+				"type": "aws_instance",
+				"provider_name": "registry.terraform.io/hashicorp/aws",
+				"values": {"instance_type": "m5.large", "tags": {"Environment": "prod", "Owner": "secret-owner"}},
+				"sensitive_values": {"instance_type": true, "tags": {"Owner": true}}
+			},
+			{
+				"address": "google_compute_instance.vm",
+				"type": "google_compute_instance",
+				"provider_name": "registry.terraform.io/hashicorp/google",
+				"values": {"labels": {"team": "secret-team"}},
+				"sensitive_values": {"labels": true}
+			}
+		]
+	}`)
+
+	p := NewParser(config.NewEnvironment())
+	resources := p.parseResourceData(gjson.Result{}, planVals, gjson.Result{}, gjson.Result{})
+
+	web := resources["aws_instance.web"]
+	assert.Equal(t, map[string]string{"Environment": "prod", "Owner": "secret-owner"}, web.Tags)
+	assert.Equal(t, map[string]bool{"instance_type": true, "tags.Owner": true}, web.SensitivePaths)
+	assert.Equal(t, []string{"secret-owner", "m5.large"}, web.SensitiveValues())
+
+	vm := resources["google_compute_instance.vm"]
+	assert.Equal(t, map[string]bool{"labels": true}, vm.SensitivePaths)
+	assert.Equal(t, map[string]bool{"team": true}, sensitiveTags(vm))
+
+	r := p.createResource(web, nil)
+	assert.Equal(t, map[string]bool{"Owner": true}, r.SensitiveTags)
+	for _, c := range r.CostComponents {
+		assert.NotContains(t, c.Name, "m5.large")
+	}
+	assert.Equal(t, "Instance usage (Linux/UNIX, on-demand, REDACTED)", r.CostComponents[0].Name)
+}
+
 func TestParseReferences_plan(t *testing.T) {
 	vol1 := schema.NewResourceData(
 		"aws_ebs_volume",
@@ -637,3 +696,22 @@ func TestParseReferences_state(t *testing.T) {
 
 	assert.Equal(t, []*schema.ResourceData{vol1}, resData["aws_ebs_snapshot.snapshot1"].References("volume_id"))
 }
+
+func TestParsePlanActions(t *testing.T) {
+	parsed := gjson.Parse(`{
+		"resource_changes": [
+			{"address": "aws_instance.created", "change": {"actions": ["create"]}},
+			{"address": "module.web.aws_instance.replaced[0]", "change": {"actions": ["create", "delete"]}},
+			{"address": "aws_instance.unchanged", "change": {"actions": ["no-op"]}},
+			{"address": "aws_instance.missing", "change": {}}
+		]
+	}`)
+
+	actual := parsePlanActions(parsed)
+
+	assert.Equal(t, map[string][]string{
+		"aws_instance.created":                {"create"},
+		"module.web.aws_instance.replaced[0]": {"create", "delete"},
+		"aws_instance.unchanged":              {"no-op"},
+	}, actual)
+}
@@ -1,16 +1,27 @@
 package terraform
 
 import (
+	"io"
 	"io/ioutil"
 
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
 )
 
+// PlanJSONGenerator is implemented by providers that load their resources from
+// Terraform plan JSON, so the plan JSON can be parsed again with different usage
+// data without rerunning Terraform.
+type PlanJSONGenerator interface {
+	PlanJSON() ([]byte, error)
+}
+
 type PlanJSONProvider struct {
 	Path string
 	env  *config.Environment
+	// planJSON is set when the plan JSON has already been read, e.g. from stdin
+	planJSON []byte
 }
 
 func NewPlanJSONProvider(cfg *config.Config, projectCfg *config.Project) schema.Provider {
@@ -20,25 +31,68 @@ func NewPlanJSONProvider(cfg *config.Config, projectCfg *config.Project) schema.
 	}
 }
 
+// NewStdinPlanJSONProvider reads the plan JSON from the reader, this is used
+// when the path is - so the output of terraform show -json can be piped in.
+func NewStdinPlanJSONProvider(cfg *config.Config, projectCfg *config.Project, r io.Reader) (schema.Provider, error) {
+	j, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading Terraform plan JSON from stdin")
+	}
+
+	if !gjson.ValidBytes(j) || !gjson.GetBytes(j, "format_version").Exists() || !gjson.GetBytes(j, "planned_values").Exists() {
+		return nil, errors.New("Could not read Terraform plan JSON from stdin")
+	}
+
+	return &PlanJSONProvider{
+		Path:     projectCfg.Path,
+		env:      cfg.Environment,
+		planJSON: j,
+	}, nil
+}
+
 func (p *PlanJSONProvider) Type() string {
 	return "terraform_plan_json"
 }
 
 func (p *PlanJSONProvider) DisplayType() string {
+	if p.planJSON != nil {
+		return "Terraform plan JSON from stdin"
+	}
 	return "Terraform plan JSON file"
 }
 
 func (p *PlanJSONProvider) AddMetadata(metadata *schema.ProjectMetadata) {
-	// no op
+	if p.planJSON != nil {
+		metadata.Path = "stdin"
+	}
 }
 
-func (p *PlanJSONProvider) LoadResources(project *schema.Project, usage map[string]*schema.UsageData) error {
+func (p *PlanJSONProvider) PlanJSON() ([]byte, error) {
+	if p.planJSON != nil {
+		return p.planJSON, nil
+	}
+
 	j, err := ioutil.ReadFile(p.Path)
 	if err != nil {
-		return errors.Wrap(err, "Error reading Terraform plan JSON file")
+		return []byte{}, errors.Wrap(err, "Error reading Terraform plan JSON file")
 	}
 
-	parser := NewParser(p.env)
+	return j, nil
+}
+
+func (p *PlanJSONProvider) LoadResources(project *schema.Project, usage map[string]*schema.UsageData) error {
+	j, err := p.PlanJSON()
+	if err != nil {
+		return err
+	}
+
+	return LoadPlanJSONResources(p.env, project, j, usage)
+}
+
+// LoadPlanJSONResources parses the Terraform plan JSON and adds the past and
+// planned resources to the project. This can be used when the plan JSON isn't in a file.
+func LoadPlanJSONResources(env *config.Environment, project *schema.Project, j []byte, usage map[string]*schema.UsageData) error {
+	parser := NewParser(env)
 
 	pastResources, resources, err := parser.parseJSON(j, usage)
 	if err != nil {
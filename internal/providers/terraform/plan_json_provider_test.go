@@ -0,0 +1,54 @@
+package terraform
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStdinPlanJSONProvider(t *testing.T) {
+	cfg := &config.Config{Environment: &config.Environment{}}
+	projectCfg := &config.Project{Path: "-"}
+
+	planJSON := `{
+		"format_version": "0.1",
+		"planned_values": {
+			"root_module": {
+				"resources": [
+					{
+						"address": "aws_eip.nat",
+						"mode": "managed",
+						"type": "aws_eip",
+						"name": "nat",
+						"provider_name": "aws",
+						"values": {}
+					}
+				]
+			}
+		},
+		"configuration": {
+			"provider_config": {"aws": {"name": "aws", "expressions": {"region": {"constant_value": "us-east-1"}}}},
+			"root_module": {}
+		}
+	}`
+
+	p, err := NewStdinPlanJSONProvider(cfg, projectCfg, strings.NewReader(planJSON))
+	require.NoError(t, err)
+	assert.Equal(t, "Terraform plan JSON from stdin", p.DisplayType())
+
+	metadata := &schema.ProjectMetadata{Path: "-"}
+	p.AddMetadata(metadata)
+	assert.Equal(t, "stdin", metadata.Path)
+
+	project := schema.NewProject("stdin", metadata)
+	require.NoError(t, p.LoadResources(project, map[string]*schema.UsageData{}))
+	require.Len(t, project.Resources, 1)
+	assert.Equal(t, "aws_eip.nat", project.Resources[0].Name)
+
+	_, err = NewStdinPlanJSONProvider(cfg, projectCfg, strings.NewReader(`{"resources": []}`))
+	assert.Error(t, err)
+}
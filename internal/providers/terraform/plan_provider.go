@@ -36,8 +36,12 @@ func (p *PlanProvider) DisplayType() string {
 	return "Terraform plan file"
 }
 
+func (p *PlanProvider) PlanJSON() ([]byte, error) {
+	return p.generatePlanJSON()
+}
+
 func (p *PlanProvider) LoadResources(project *schema.Project, usage map[string]*schema.UsageData) error {
-	j, err := p.generatePlanJSON()
+	j, err := p.PlanJSON()
 	if err != nil {
 		return err
 	}
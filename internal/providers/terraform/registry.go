@@ -9,6 +9,7 @@ import (
 	"github.com/infracost/infracost/internal/providers/terraform/aws"
 	"github.com/infracost/infracost/internal/providers/terraform/azure"
 	"github.com/infracost/infracost/internal/providers/terraform/google"
+	"github.com/infracost/infracost/internal/providers/terraform/saas"
 )
 
 type ResourceRegistryMap map[string]*schema.RegistryItem
@@ -16,6 +17,10 @@ type ResourceRegistryMap map[string]*schema.RegistryItem
 var (
 	resourceRegistryMap ResourceRegistryMap
 	once                sync.Once
+
+	// addedResourceTypes are the types of the resources that are priced by the
+	// user's custom prices file or plugins.
+	addedResourceTypes = make(map[string]bool)
 )
 
 func GetResourceRegistryMap() *ResourceRegistryMap {
@@ -43,11 +48,28 @@ func GetResourceRegistryMap() *ResourceRegistryMap {
 		for _, registryItem := range createFreeResources(google.FreeResources) {
 			resourceRegistryMap[registryItem.Name] = registryItem
 		}
+
+		for _, registryItem := range saas.ResourceRegistry {
+			resourceRegistryMap[registryItem.Name] = registryItem
+		}
 	})
 
 	return &resourceRegistryMap
 }
 
+// AddResources adds the resources of the user's custom prices file or plugins
+// to the registry, replacing the registry items of any resources of the same
+// type. It must be called before any resources are loaded since the registry
+// isn't locked.
+func AddResources(items []*schema.RegistryItem) {
+	registryMap := GetResourceRegistryMap()
+
+	for _, item := range items {
+		(*registryMap)[item.Name] = item
+		addedResourceTypes[item.Name] = true
+	}
+}
+
 func GetUsageOnlyResources() []string {
 	r := []string{}
 	r = append(r, aws.UsageOnlyResources...)
@@ -57,7 +79,7 @@ func GetUsageOnlyResources() []string {
 }
 
 func HasSupportedProvider(rType string) bool {
-	return strings.HasPrefix(rType, "aws_") || strings.HasPrefix(rType, "google_") || strings.HasPrefix(rType, "azurerm_")
+	return strings.HasPrefix(rType, "aws_") || strings.HasPrefix(rType, "google_") || strings.HasPrefix(rType, "azurerm_") || saas.HasResource(rType) || addedResourceTypes[rType]
 }
 
 func createFreeResources(l []string) []*schema.RegistryItem {
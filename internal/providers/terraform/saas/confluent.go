@@ -0,0 +1,100 @@
+package saas
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+)
+
+// confluentKafkaClusterPrices are the list prices of a Kafka cluster by its
+// type. Basic, standard and enterprise clusters scale elastically and are
+// priced per eCKU, and dedicated clusters are priced per CKU.
+var confluentKafkaClusterPrices = map[string]struct {
+	capacityUnit  string
+	singleZone    decimal.Decimal
+	multiZone     decimal.Decimal
+	dataTransfer  decimal.Decimal
+	storagePerGB  decimal.Decimal
+	fixedCapacity bool
+}{
+	"basic": {
+		capacityUnit: "eCKU",
+		singleZone:   decimal.RequireFromString("0.14"),
+		multiZone:    decimal.RequireFromString("0.14"),
+		dataTransfer: decimal.RequireFromString("0.05"),
+		storagePerGB: decimal.RequireFromString("0.08"),
+	},
+	"standard": {
+		capacityUnit: "eCKU",
+		singleZone:   decimal.RequireFromString("0.75"),
+		multiZone:    decimal.RequireFromString("0.75"),
+		dataTransfer: decimal.RequireFromString("0.05"),
+		storagePerGB: decimal.RequireFromString("0.08"),
+	},
+	"enterprise": {
+		capacityUnit: "eCKU",
+		singleZone:   decimal.RequireFromString("2.25"),
+		multiZone:    decimal.RequireFromString("2.25"),
+		dataTransfer: decimal.RequireFromString("0.05"),
+		storagePerGB: decimal.RequireFromString("0.08"),
+	},
+	"dedicated": {
+		capacityUnit:  "CKU",
+		singleZone:    decimal.RequireFromString("1.50"),
+		multiZone:     decimal.RequireFromString("2.25"),
+		dataTransfer:  decimal.RequireFromString("0.05"),
+		storagePerGB:  decimal.RequireFromString("0.10"),
+		fixedCapacity: true,
+	},
+}
+
+func getConfluentKafkaClusterRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "confluent_kafka_cluster",
+		Notes: []string{"List prices from https://www.confluent.io/confluent-cloud/pricing/, checked 2026-10."},
+		RFunc: newConfluentKafkaCluster,
+	}
+}
+
+func newConfluentKafkaCluster(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	clusterType := ""
+	for _, t := range []string{"basic", "standard", "enterprise", "dedicated"} {
+		if len(d.Get(t).Array()) > 0 {
+			clusterType = t
+			break
+		}
+	}
+
+	prices, ok := confluentKafkaClusterPrices[clusterType]
+	if !ok {
+		log.Warnf("Skipping resource %s. Unsupported cluster type", d.Address)
+		return nil
+	}
+
+	capacityPrice := prices.singleZone
+	if availability := strings.ToUpper(d.Get("availability").String()); availability == "MULTI_ZONE" || availability == "HIGH" {
+		capacityPrice = prices.multiZone
+	}
+
+	// Dedicated clusters have a fixed number of CKUs. The eCKUs of the other
+	// clusters scale with the throughput, so they're read from the usage.
+	var capacity *decimal.Decimal
+	if prices.fixedCapacity {
+		capacity = decimalPtr(decimal.NewFromInt(d.Get("dedicated.0.cku").Int()))
+	} else {
+		capacity = usageQuantity(u, "ecku")
+	}
+
+	return &schema.Resource{
+		Name: d.Address,
+		CostComponents: []*schema.CostComponent{
+			hourlyCostComponent(fmt.Sprintf("Cluster capacity (%s)", clusterType), fmt.Sprintf("%s-hours", prices.capacityUnit), capacityPrice, capacity),
+			monthlyCostComponent("Data in", "GB", prices.dataTransfer, usageQuantity(u, "monthly_ingress_gb")),
+			monthlyCostComponent("Data out", "GB", prices.dataTransfer, usageQuantity(u, "monthly_egress_gb")),
+			monthlyCostComponent("Storage", "GB", prices.storagePerGB, usageQuantity(u, "storage_gb")),
+		},
+	}
+}
@@ -0,0 +1,155 @@
+package saas
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+)
+
+const databricksPricingSource = "https://www.databricks.com/product/pricing"
+
+// databricksDBURates are the list prices per DBU of classic compute by the
+// workload and the plan tier of the workspace.
+var databricksDBURates = map[string]map[string]decimal.Decimal{
+	"all-purpose": {
+		"standard":   decimal.RequireFromString("0.40"),
+		"premium":    decimal.RequireFromString("0.55"),
+		"enterprise": decimal.RequireFromString("0.65"),
+	},
+	"jobs": {
+		"standard":   decimal.RequireFromString("0.10"),
+		"premium":    decimal.RequireFromString("0.15"),
+		"enterprise": decimal.RequireFromString("0.20"),
+	},
+}
+
+// databricksNodeDBUs are the DBUs per hour of common node types. The DBUs of
+// other node types are read from the dbus_per_node_hour usage.
+var databricksNodeDBUs = map[string]float64{
+	"i3.xlarge":        1,
+	"i3.2xlarge":       2,
+	"i3.4xlarge":       4,
+	"i3.8xlarge":       8,
+	"i3.16xlarge":      16,
+	"Standard_DS3_v2":  0.75,
+	"Standard_DS4_v2":  1.5,
+	"Standard_DS5_v2":  3,
+	"Standard_D4ds_v5": 1,
+	"Standard_D8ds_v5": 2,
+}
+
+func getDatabricksClusterRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "databricks_cluster",
+		Notes: []string{fmt.Sprintf("List prices from %s, checked 2026-10.", databricksPricingSource), "The cloud provider's instances are billed separately."},
+		RFunc: newDatabricksCluster,
+	}
+}
+
+func getDatabricksJobRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "databricks_job",
+		Notes: []string{fmt.Sprintf("List prices from %s, checked 2026-10.", databricksPricingSource), "Jobs that run on an existing cluster are billed on the cluster."},
+		RFunc: newDatabricksJob,
+	}
+}
+
+func newDatabricksCluster(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	return &schema.Resource{
+		Name: d.Address,
+		CostComponents: []*schema.CostComponent{
+			databricksDBUCostComponent(d.Address, "All-purpose compute", "all-purpose", d.RawValues, u),
+		},
+	}
+}
+
+func newDatabricksJob(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	var cluster gjson.Result
+	for _, path := range []string{"new_cluster.0", "job_cluster.0.new_cluster.0", "task.0.new_cluster.0"} {
+		if d.Get(path).Exists() {
+			cluster = d.Get(path)
+			break
+		}
+	}
+
+	if !cluster.Exists() {
+		return &schema.Resource{
+			Name:      d.Address,
+			IsSkipped: true,
+			NoPrice:   true,
+		}
+	}
+
+	return &schema.Resource{
+		Name: d.Address,
+		CostComponents: []*schema.CostComponent{
+			databricksDBUCostComponent(d.Address, "Jobs compute", "jobs", cluster, u),
+		},
+	}
+}
+
+// databricksDBUCostComponent prices the DBUs of the driver and workers of a
+// cluster for the hours that it runs each month.
+func databricksDBUCostComponent(address, name, workload string, cluster gjson.Result, u *schema.UsageData) *schema.CostComponent {
+	tier := "premium"
+	if u != nil && u.Get("tier").String() != "" {
+		tier = strings.ToLower(u.Get("tier").String())
+	}
+
+	rate, ok := databricksDBURates[workload][tier]
+	if !ok {
+		log.Warnf("Unsupported Databricks tier %s for %s, using premium", tier, address)
+		tier = "premium"
+		rate = databricksDBURates[workload][tier]
+	}
+
+	var dbus *decimal.Decimal
+	if dbusPerHour, ok := databricksClusterDBUsPerHour(cluster, u); ok {
+		if hours := usageQuantity(u, "monthly_hrs"); hours != nil {
+			dbus = decimalPtr(decimal.NewFromFloat(dbusPerHour).Mul(*hours))
+		}
+	} else {
+		log.Debugf("No DBUs for the node type of %s, set dbus_per_node_hour in the usage file", address)
+	}
+
+	return monthlyCostComponent(fmt.Sprintf("%s (%s)", name, tier), "DBU", rate, dbus)
+}
+
+// databricksClusterDBUsPerHour returns the DBUs per hour of the driver and the
+// workers. Autoscaling clusters are assumed to run with their min workers
+// unless the average_workers usage is set.
+func databricksClusterDBUsPerHour(cluster gjson.Result, u *schema.UsageData) (float64, bool) {
+	workers := cluster.Get("num_workers").Float()
+	if cluster.Get("autoscale.0").Exists() {
+		workers = cluster.Get("autoscale.0.min_workers").Float()
+	}
+	if u != nil && u.GetFloat("average_workers") != nil {
+		workers = *u.GetFloat("average_workers")
+	}
+
+	nodeType := cluster.Get("node_type_id").String()
+	driverNodeType := cluster.Get("driver_node_type_id").String()
+	if driverNodeType == "" {
+		driverNodeType = nodeType
+	}
+
+	if u != nil && u.GetFloat("dbus_per_node_hour") != nil {
+		return (workers + 1) * *u.GetFloat("dbus_per_node_hour"), true
+	}
+
+	nodeDBUs, ok := databricksNodeDBUs[nodeType]
+	if !ok {
+		return 0, false
+	}
+
+	driverDBUs, ok := databricksNodeDBUs[driverNodeType]
+	if !ok {
+		return 0, false
+	}
+
+	return workers*nodeDBUs + driverDBUs, true
+}
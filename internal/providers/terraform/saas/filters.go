@@ -0,0 +1,129 @@
+package saas
+
+import (
+	"fmt"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// productFilter maps a resource to the products of the Cloud Pricing API, so
+// the price of a cost component can be looked up instead of being fixed.
+// Values can be read from the resource's attributes, e.g. its instance type.
+type productFilter struct {
+	VendorName    string `yaml:"vendor_name,omitempty"`
+	Service       string `yaml:"service,omitempty"`
+	ProductFamily string `yaml:"product_family,omitempty"`
+	// Region is a fixed region. If it isn't set the region is read from the
+	// RegionAttribute of the resource, which defaults to region.
+	Region           string            `yaml:"region,omitempty"`
+	RegionAttribute  string            `yaml:"region_attribute,omitempty"`
+	Sku              string            `yaml:"sku,omitempty"`
+	AttributeFilters []attributeFilter `yaml:"attribute_filters,omitempty"`
+}
+
+type attributeFilter struct {
+	Key            string `yaml:"key"`
+	Value          string `yaml:"value,omitempty"`
+	ValueRegex     string `yaml:"value_regex,omitempty"`
+	ValueAttribute string `yaml:"value_attribute,omitempty"`
+}
+
+type priceFilter struct {
+	PurchaseOption     string `yaml:"purchase_option,omitempty"`
+	Unit               string `yaml:"unit,omitempty"`
+	Description        string `yaml:"description,omitempty"`
+	DescriptionRegex   string `yaml:"description_regex,omitempty"`
+	StartUsageAmount   string `yaml:"start_usage_amount,omitempty"`
+	EndUsageAmount     string `yaml:"end_usage_amount,omitempty"`
+	TermLength         string `yaml:"term_length,omitempty"`
+	TermPurchaseOption string `yaml:"term_purchase_option,omitempty"`
+	TermOfferingClass  string `yaml:"term_offering_class,omitempty"`
+}
+
+func (f *productFilter) validate() error {
+	for _, a := range f.AttributeFilters {
+		if a.Key == "" {
+			return fmt.Errorf("product_filter has an attribute filter without a key")
+		}
+
+		set := 0
+		for _, v := range []string{a.Value, a.ValueRegex, a.ValueAttribute} {
+			if v != "" {
+				set++
+			}
+		}
+
+		if set != 1 {
+			return fmt.Errorf("product_filter attribute filter %s must have one of value, value_regex or value_attribute", a.Key)
+		}
+	}
+
+	return nil
+}
+
+func (f *productFilter) toSchema(d *schema.ResourceData) *schema.ProductFilter {
+	region := f.Region
+	if region == "" {
+		regionAttribute := f.RegionAttribute
+		if regionAttribute == "" {
+			regionAttribute = "region"
+		}
+		region = d.Get(regionAttribute).String()
+	}
+
+	attributeFilters := make([]*schema.AttributeFilter, 0, len(f.AttributeFilters))
+	for _, a := range f.AttributeFilters {
+		filter := &schema.AttributeFilter{Key: a.Key}
+
+		switch {
+		case a.ValueAttribute != "":
+			filter.Value = strPtr(d.Get(a.ValueAttribute).String())
+		case a.ValueRegex != "":
+			filter.ValueRegex = strPtr(a.ValueRegex)
+		default:
+			filter.Value = strPtr(a.Value)
+		}
+
+		attributeFilters = append(attributeFilters, filter)
+	}
+
+	return &schema.ProductFilter{
+		VendorName:       optionalStrPtr(f.VendorName),
+		Service:          optionalStrPtr(f.Service),
+		ProductFamily:    optionalStrPtr(f.ProductFamily),
+		Region:           optionalStrPtr(region),
+		Sku:              optionalStrPtr(f.Sku),
+		AttributeFilters: attributeFilters,
+	}
+}
+
+func (f *priceFilter) toSchema() *schema.PriceFilter {
+	if f == nil {
+		return nil
+	}
+
+	return &schema.PriceFilter{
+		PurchaseOption:     optionalStrPtr(f.PurchaseOption),
+		Unit:               optionalStrPtr(f.Unit),
+		Description:        optionalStrPtr(f.Description),
+		DescriptionRegex:   optionalStrPtr(f.DescriptionRegex),
+		StartUsageAmount:   optionalStrPtr(f.StartUsageAmount),
+		EndUsageAmount:     optionalStrPtr(f.EndUsageAmount),
+		TermLength:         optionalStrPtr(f.TermLength),
+		TermPurchaseOption: optionalStrPtr(f.TermPurchaseOption),
+		TermOfferingClass:  optionalStrPtr(f.TermOfferingClass),
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+// optionalStrPtr returns nil for an empty string so the filter isn't used.
+func optionalStrPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
@@ -0,0 +1,296 @@
+// Package saas prices the resources of SaaS Terraform providers that have
+// fixed, published prices from a price table in this package instead of the
+// Cloud Pricing API. Resources whose quantities depend on their attributes,
+// such as the DBUs of a Databricks cluster, are priced in Go.
+package saas
+
+import (
+	_ "embed" // nolint:golint
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v2"
+)
+
+//go:embed prices.yml
+var priceTableContents []byte
+
+const customPricesFileVersion = "0.1"
+
+type priceTable struct {
+	Version   string                    `yaml:"version"`
+	Resources map[string]resourcePrices `yaml:"resources"`
+}
+
+type resourcePrices struct {
+	Source         string               `yaml:"source"`
+	CheckedAt      string               `yaml:"checked_at"`
+	CostComponents []costComponentPrice `yaml:"cost_components"`
+}
+
+type costComponentPrice struct {
+	Name              string            `yaml:"name"`
+	Unit              string            `yaml:"unit"`
+	UnitMultiplier    int               `yaml:"unit_multiplier,omitempty"`
+	MonthlyPrice      string            `yaml:"monthly_price"`
+	MonthlyQuantity   *float64          `yaml:"monthly_quantity,omitempty"`
+	UsageKey          string            `yaml:"usage_key,omitempty"`
+	QuantityAttribute string            `yaml:"quantity_attribute,omitempty"`
+	IncludedUsage     float64           `yaml:"included_usage,omitempty"`
+	Attributes        map[string]string `yaml:"attributes,omitempty"`
+	// Cost components with a product filter get their price from the Cloud
+	// Pricing API instead of having a monthly_price. Hourly is set if their
+	// quantity is per hour, e.g. for instances.
+	ProductFilter *productFilter `yaml:"product_filter,omitempty"`
+	PriceFilter   *priceFilter   `yaml:"price_filter,omitempty"`
+	Hourly        bool           `yaml:"hourly,omitempty"`
+}
+
+// ResourceRegistry is the resources from the price table, and the resources
+// whose quantities are calculated from their attributes so they can't be
+// described by the price table.
+var ResourceRegistry = append(mustLoadRegistry(priceTableContents),
+	getDatabricksClusterRegistryItem(),
+	getDatabricksJobRegistryItem(),
+	getConfluentKafkaClusterRegistryItem(),
+)
+
+// HasResource returns true if the resource type is in the price table.
+func HasResource(resourceType string) bool {
+	for _, item := range ResourceRegistry {
+		if item.Name == resourceType {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadCustomPricesFromFile loads a user-supplied custom prices file, which has
+// the same format as the price table, e.g. for the internal chargeback rates
+// of on-prem resources such as vsphere_virtual_machine. An empty path returns
+// no items.
+func LoadCustomPricesFromFile(path string) ([]*schema.RegistryItem, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading custom prices file")
+	}
+
+	var table priceTable
+	err = yaml.Unmarshal(contents, &table)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing custom prices file")
+	}
+
+	if table.Version != customPricesFileVersion {
+		return nil, fmt.Errorf("Invalid custom prices file version. Supported versions are %s", customPricesFileVersion)
+	}
+
+	items, err := loadRegistry(contents)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid custom prices file")
+	}
+
+	for _, item := range items {
+		item.Notes = []string{fmt.Sprintf("Prices from the custom prices file %s.", path)}
+	}
+
+	return items, nil
+}
+
+func mustLoadRegistry(contents []byte) []*schema.RegistryItem {
+	items, err := loadRegistry(contents)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid SaaS price table: %s", err))
+	}
+	return items
+}
+
+func loadRegistry(contents []byte) ([]*schema.RegistryItem, error) {
+	var table priceTable
+	err := yaml.UnmarshalStrict(contents, &table)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(table.Resources))
+	for name := range table.Resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	items := make([]*schema.RegistryItem, 0, len(names))
+	for _, name := range names {
+		prices := table.Resources[name]
+
+		if len(prices.CostComponents) == 0 {
+			return nil, fmt.Errorf("%s has no cost components", name)
+		}
+
+		for _, c := range prices.CostComponents {
+			if err := validateCostComponentPrice(c); err != nil {
+				return nil, fmt.Errorf("%s %s %s", name, c.Name, err)
+			}
+
+		}
+
+		items = append(items, &schema.RegistryItem{
+			Name:  name,
+			Notes: []string{fmt.Sprintf("List prices from %s, checked %s.", prices.Source, prices.CheckedAt)},
+			RFunc: newResourceFunc(prices),
+		})
+	}
+
+	return items, nil
+}
+
+func validateCostComponentPrice(c costComponentPrice) error {
+	if c.ProductFilter != nil {
+		if c.MonthlyPrice != "" {
+			return fmt.Errorf("can't have both a monthly_price and a product_filter")
+		}
+
+		if err := c.ProductFilter.validate(); err != nil {
+			return err
+		}
+	} else {
+		if _, err := decimal.NewFromString(c.MonthlyPrice); err != nil {
+			return fmt.Errorf("has an invalid monthly_price %q", c.MonthlyPrice)
+		}
+
+		if c.PriceFilter != nil || c.Hourly {
+			return fmt.Errorf("can only have a price_filter or be hourly if it has a product_filter")
+		}
+	}
+
+	if c.UsageKey != "" && c.QuantityAttribute != "" {
+		return fmt.Errorf("can't have both a usage_key and a quantity_attribute")
+	}
+
+	if c.IncludedUsage != 0 && c.UsageKey == "" {
+		return fmt.Errorf("has included_usage but no usage_key")
+	}
+
+	return nil
+}
+
+func newResourceFunc(prices resourcePrices) schema.ResourceFunc {
+	return func(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+		costComponents := make([]*schema.CostComponent, 0, len(prices.CostComponents))
+
+		for _, p := range prices.CostComponents {
+			if !matchesAttributes(d, p.Attributes) {
+				continue
+			}
+
+			costComponents = append(costComponents, newCostComponent(p, d, u))
+		}
+
+		return &schema.Resource{
+			Name:           d.Address,
+			CostComponents: costComponents,
+		}
+	}
+}
+
+// newCostComponent creates a cost component with a fixed price, which isn't
+// looked up in the pricing API, or with the product and price filters that
+// its price is looked up with.
+func newCostComponent(p costComponentPrice, d *schema.ResourceData, u *schema.UsageData) *schema.CostComponent {
+	unitMultiplier := p.UnitMultiplier
+	if unitMultiplier == 0 {
+		unitMultiplier = 1
+	}
+
+	var quantity *decimal.Decimal
+	switch {
+	case p.UsageKey != "":
+		if u != nil && u.GetFloat(p.UsageKey) != nil {
+			// Only the usage over the amount that's included in the price is billed
+			billed := decimal.NewFromFloat(*u.GetFloat(p.UsageKey)).Sub(decimal.NewFromFloat(p.IncludedUsage))
+			quantity = decimalPtr(decimal.Max(billed, decimal.Zero))
+		}
+	case p.QuantityAttribute != "":
+		quantity = decimalPtr(decimal.NewFromFloat(d.Get(p.QuantityAttribute).Float()))
+	case p.MonthlyQuantity != nil:
+		quantity = decimalPtr(decimal.NewFromFloat(*p.MonthlyQuantity))
+	default:
+		quantity = decimalPtr(decimal.NewFromInt(1))
+	}
+
+	c := &schema.CostComponent{
+		Name:           p.Name,
+		Unit:           p.Unit,
+		UnitMultiplier: unitMultiplier,
+	}
+
+	if p.Hourly {
+		c.HourlyQuantity = quantity
+	} else {
+		c.MonthlyQuantity = quantity
+	}
+
+	if p.ProductFilter != nil {
+		c.ProductFilter = p.ProductFilter.toSchema(d)
+		c.PriceFilter = p.PriceFilter.toSchema()
+		return c
+	}
+
+	c.SetPrice(decimal.RequireFromString(p.MonthlyPrice))
+
+	return c
+}
+
+// hourlyCostComponent creates a cost component with a fixed hourly price.
+func hourlyCostComponent(name, unit string, price decimal.Decimal, quantity *decimal.Decimal) *schema.CostComponent {
+	c := &schema.CostComponent{
+		Name:           name,
+		Unit:           unit,
+		UnitMultiplier: 1,
+		HourlyQuantity: quantity,
+	}
+	c.SetPrice(price)
+
+	return c
+}
+
+// monthlyCostComponent creates a cost component with a fixed monthly price.
+func monthlyCostComponent(name, unit string, price decimal.Decimal, quantity *decimal.Decimal) *schema.CostComponent {
+	c := &schema.CostComponent{
+		Name:            name,
+		Unit:            unit,
+		UnitMultiplier:  1,
+		MonthlyQuantity: quantity,
+	}
+	c.SetPrice(price)
+
+	return c
+}
+
+func usageQuantity(u *schema.UsageData, key string) *decimal.Decimal {
+	if u == nil || u.GetFloat(key) == nil {
+		return nil
+	}
+
+	return decimalPtr(decimal.NewFromFloat(*u.GetFloat(key)))
+}
+
+func matchesAttributes(d *schema.ResourceData, attributes map[string]string) bool {
+	for k, v := range attributes {
+		if d.Get(k).String() != v {
+			return false
+		}
+	}
+	return true
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
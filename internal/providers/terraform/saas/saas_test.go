@@ -0,0 +1,299 @@
+package saas
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func registryItem(t *testing.T, name string) *schema.RegistryItem {
+	for _, item := range ResourceRegistry {
+		if item.Name == name {
+			return item
+		}
+	}
+
+	t.Fatalf("%s is not in the registry", name)
+	return nil
+}
+
+func TestFixedPriceResource(t *testing.T) {
+	d := schema.NewResourceData("pagerduty_user", "registry.terraform.io/pagerduty/pagerduty", "pagerduty_user.oncall", nil, gjson.Parse(`{}`))
+	r := registryItem(t, "pagerduty_user").RFunc(d, nil)
+
+	require.Len(t, r.CostComponents, 1)
+	c := r.CostComponents[0]
+	assert.Nil(t, c.ProductFilter)
+	assert.Equal(t, "21", c.Price().String())
+	assert.Equal(t, "1", c.MonthlyQuantity.String())
+
+	schema.CalculateCosts(&schema.Project{Resources: []*schema.Resource{r}})
+	assert.Equal(t, "21", r.MonthlyCost.String())
+}
+
+func TestUsageBasedResourceWithAttributes(t *testing.T) {
+	item := registryItem(t, "datadog_synthetics_test")
+
+	d := schema.NewResourceData("datadog_synthetics_test", "registry.terraform.io/datadog/datadog", "datadog_synthetics_test.checkout", nil, gjson.Parse(`{"type": "browser"}`))
+
+	r := item.RFunc(d, nil)
+	require.Len(t, r.CostComponents, 1)
+	assert.Equal(t, "Browser test runs", r.CostComponents[0].Name)
+	assert.Nil(t, r.CostComponents[0].MonthlyQuantity)
+
+	u := schema.NewUsageData("datadog_synthetics_test.checkout", map[string]gjson.Result{
+		"monthly_test_runs": gjson.Parse("5000"),
+	})
+	r = item.RFunc(d, u)
+
+	schema.CalculateCosts(&schema.Project{Resources: []*schema.Resource{r}})
+	assert.True(t, decimal.NewFromInt(60).Equal(*r.MonthlyCost), r.MonthlyCost.String())
+}
+
+func TestUsageBasedResourceWithIncludedUsage(t *testing.T) {
+	item := registryItem(t, "cloudflare_load_balancer")
+
+	d := schema.NewResourceData("cloudflare_load_balancer", "registry.terraform.io/cloudflare/cloudflare", "cloudflare_load_balancer.lb", nil, gjson.Parse(`{}`))
+
+	u := schema.NewUsageData("cloudflare_load_balancer.lb", map[string]gjson.Result{
+		"monthly_dns_queries": gjson.Parse("1500000"),
+	})
+	r := item.RFunc(d, u)
+
+	require.Len(t, r.CostComponents, 2)
+	assert.Equal(t, "1000000", r.CostComponents[1].MonthlyQuantity.String())
+
+	schema.CalculateCosts(&schema.Project{Resources: []*schema.Resource{r}})
+	assert.True(t, decimal.NewFromInt(6).Equal(*r.MonthlyCost), r.MonthlyCost.String())
+
+	u = schema.NewUsageData("cloudflare_load_balancer.lb", map[string]gjson.Result{
+		"monthly_dns_queries": gjson.Parse("100000"),
+	})
+	r = item.RFunc(d, u)
+	assert.True(t, r.CostComponents[1].MonthlyQuantity.IsZero())
+}
+
+func TestDatabricksCluster(t *testing.T) {
+	d := schema.NewResourceData("databricks_cluster", "registry.terraform.io/databricks/databricks", "databricks_cluster.shared", nil, gjson.Parse(`{
+		"node_type_id": "i3.xlarge",
+		"driver_node_type_id": "i3.2xlarge",
+		"autoscale": [{"min_workers": 2, "max_workers": 8}]
+	}`))
+
+	r := newDatabricksCluster(d, nil)
+	require.Len(t, r.CostComponents, 1)
+	assert.Equal(t, "All-purpose compute (premium)", r.CostComponents[0].Name)
+	assert.Nil(t, r.CostComponents[0].MonthlyQuantity)
+
+	u := schema.NewUsageData("databricks_cluster.shared", map[string]gjson.Result{
+		"monthly_hrs": gjson.Parse("100"),
+		"tier":        gjson.Parse(`"Standard"`),
+	})
+	r = newDatabricksCluster(d, u)
+
+	// 2 workers with 1 DBU and a driver with 2 DBUs for 100 hours
+	c := r.CostComponents[0]
+	assert.Equal(t, "All-purpose compute (standard)", c.Name)
+	assert.Equal(t, "400", c.MonthlyQuantity.String())
+	assert.Equal(t, "0.4", c.Price().String())
+}
+
+func TestDatabricksJob(t *testing.T) {
+	d := schema.NewResourceData("databricks_job", "registry.terraform.io/databricks/databricks", "databricks_job.etl", nil, gjson.Parse(`{
+		"new_cluster": [{"num_workers": 3, "node_type_id": "m5.large"}]
+	}`))
+	u := schema.NewUsageData("databricks_job.etl", map[string]gjson.Result{
+		"monthly_hrs":        gjson.Parse("10"),
+		"dbus_per_node_hour": gjson.Parse("0.5"),
+	})
+
+	r := newDatabricksJob(d, u)
+	require.Len(t, r.CostComponents, 1)
+	assert.Equal(t, "Jobs compute (premium)", r.CostComponents[0].Name)
+	assert.Equal(t, "20", r.CostComponents[0].MonthlyQuantity.String())
+
+	d = schema.NewResourceData("databricks_job", "registry.terraform.io/databricks/databricks", "databricks_job.existing", nil, gjson.Parse(`{
+		"existing_cluster_id": "abc"
+	}`))
+	r = newDatabricksJob(d, u)
+	assert.True(t, r.NoPrice)
+	assert.Empty(t, r.CostComponents)
+}
+
+func TestConfluentKafkaCluster(t *testing.T) {
+	d := schema.NewResourceData("confluent_kafka_cluster", "registry.terraform.io/confluentinc/confluent", "confluent_kafka_cluster.dedicated", nil, gjson.Parse(`{
+		"availability": "MULTI_ZONE",
+		"dedicated": [{"cku": 2}]
+	}`))
+	u := schema.NewUsageData("confluent_kafka_cluster.dedicated", map[string]gjson.Result{
+		"monthly_ingress_gb": gjson.Parse("1000"),
+	})
+
+	r := newConfluentKafkaCluster(d, u)
+	require.Len(t, r.CostComponents, 4)
+	assert.Equal(t, "Cluster capacity (dedicated)", r.CostComponents[0].Name)
+	assert.Equal(t, "2", r.CostComponents[0].HourlyQuantity.String())
+	assert.Equal(t, "2.25", r.CostComponents[0].Price().String())
+
+	schema.CalculateCosts(&schema.Project{Resources: []*schema.Resource{r}})
+	assert.True(t, decimal.NewFromFloat(3335).Equal(*r.MonthlyCost), r.MonthlyCost.String())
+
+	d = schema.NewResourceData("confluent_kafka_cluster", "registry.terraform.io/confluentinc/confluent", "confluent_kafka_cluster.basic", nil, gjson.Parse(`{
+		"basic": [{}]
+	}`))
+	r = newConfluentKafkaCluster(d, nil)
+	assert.Equal(t, "Cluster capacity (basic)", r.CostComponents[0].Name)
+	assert.Nil(t, r.CostComponents[0].HourlyQuantity)
+}
+
+func TestLoadRegistryValidates(t *testing.T) {
+	_, err := loadRegistry([]byte(`
+version: 0.1
+resources:
+  example_seat:
+    cost_components:
+      - name: Seat
+        unit: users
+        monthly_price: ten
+`))
+	assert.EqualError(t, err, `example_seat Seat has an invalid monthly_price "ten"`)
+
+	_, err = loadRegistry([]byte(`
+version: 0.1
+resources:
+  example_seat:
+    cost_components:
+      - name: Seat
+        unit: users
+        monthly_price: "10"
+        included_usage: 5
+`))
+	assert.EqualError(t, err, `example_seat Seat has included_usage but no usage_key`)
+
+	assert.True(t, HasResource("pagerduty_user"))
+	assert.True(t, HasResource("databricks_cluster"))
+	assert.False(t, HasResource("pagerduty_service"))
+}
+
+func TestLoadCustomPricesFromFile(t *testing.T) {
+	items, err := LoadCustomPricesFromFile("../../../../infracost-custom-prices-example.yml")
+	require.NoError(t, err)
+
+	var item *schema.RegistryItem
+	for _, i := range items {
+		if i.Name == "vsphere_virtual_machine" {
+			item = i
+		}
+	}
+	require.NotNil(t, item)
+
+	d := schema.NewResourceData("vsphere_virtual_machine", "registry.terraform.io/hashicorp/vsphere", "vsphere_virtual_machine.vm", nil, gjson.Parse(`{
+		"num_cpus": 4,
+		"memory": 8192
+	}`))
+	r := item.RFunc(d, nil)
+	require.Len(t, r.CostComponents, 2)
+	assert.Equal(t, "4", r.CostComponents[0].MonthlyQuantity.String())
+	assert.Equal(t, "8192", r.CostComponents[1].MonthlyQuantity.String())
+
+	schema.CalculateCosts(&schema.Project{Resources: []*schema.Resource{r}})
+	assert.True(t, decimal.NewFromFloat(80.768).Equal(*r.MonthlyCost), r.MonthlyCost.String())
+
+	items, err = LoadCustomPricesFromFile("")
+	require.NoError(t, err)
+	assert.Empty(t, items)
+
+	path := filepath.Join(t.TempDir(), "custom-prices.yml")
+	require.NoError(t, os.WriteFile(path, []byte("version: 0.2\nresources: {}\n"), 0600))
+	_, err = LoadCustomPricesFromFile(path)
+	assert.EqualError(t, err, "Invalid custom prices file version. Supported versions are 0.1")
+}
+
+func TestCustomPricesWithProductFilter(t *testing.T) {
+	items, err := loadRegistry([]byte(`
+version: 0.1
+resources:
+  aws_gamelift_fleet:
+    cost_components:
+      - name: Instance usage
+        unit: hours
+        hourly: true
+        product_filter:
+          vendor_name: aws
+          service: AmazonGameLift
+          attribute_filters:
+            - key: instanceType
+              value_attribute: ec2_instance_type
+            - key: operatingSystem
+              value_regex: /linux/i
+        price_filter:
+          purchase_option: on_demand
+`))
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+
+	d := schema.NewResourceData("aws_gamelift_fleet", "registry.terraform.io/hashicorp/aws", "aws_gamelift_fleet.fleet", nil, gjson.Parse(`{
+		"region": "us-east-1",
+		"ec2_instance_type": "c5.large"
+	}`))
+	r := items[0].RFunc(d, nil)
+	require.Len(t, r.CostComponents, 1)
+
+	c := r.CostComponents[0]
+	assert.Equal(t, "1", c.HourlyQuantity.String())
+	assert.Nil(t, c.MonthlyQuantity)
+
+	require.NotNil(t, c.ProductFilter)
+	assert.Equal(t, "aws", *c.ProductFilter.VendorName)
+	assert.Equal(t, "us-east-1", *c.ProductFilter.Region)
+	assert.Nil(t, c.ProductFilter.ProductFamily)
+	assert.Equal(t, "c5.large", *c.ProductFilter.AttributeFilters[0].Value)
+	assert.Equal(t, "/linux/i", *c.ProductFilter.AttributeFilters[1].ValueRegex)
+	assert.Equal(t, "on_demand", *c.PriceFilter.PurchaseOption)
+}
+
+func TestCustomPricesValidatesProductFilter(t *testing.T) {
+	_, err := loadRegistry([]byte(`
+version: 0.1
+resources:
+  example_instance:
+    cost_components:
+      - name: Instance usage
+        unit: hours
+        monthly_price: "10"
+        product_filter:
+          service: AmazonEC2
+`))
+	assert.EqualError(t, err, "example_instance Instance usage can't have both a monthly_price and a product_filter")
+
+	_, err = loadRegistry([]byte(`
+version: 0.1
+resources:
+  example_instance:
+    cost_components:
+      - name: Instance usage
+        unit: hours
+        product_filter:
+          attribute_filters:
+            - key: instanceType
+`))
+	assert.EqualError(t, err, "example_instance Instance usage product_filter attribute filter instanceType must have one of value, value_regex or value_attribute")
+
+	_, err = loadRegistry([]byte(`
+version: 0.1
+resources:
+  example_instance:
+    cost_components:
+      - name: Instance usage
+        unit: hours
+        monthly_price: "10"
+        hourly: true
+`))
+	assert.EqualError(t, err, "example_instance Instance usage can only have a price_filter or be hourly if it has a product_filter")
+}
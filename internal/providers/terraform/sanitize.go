@@ -0,0 +1,314 @@
+package terraform
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/pkg/errors"
+)
+
+var (
+	guidRegex         = regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+	ipv4Regex         = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+	awsAccountIDRegex = regexp.MustCompile(`\b\d{12}\b`)
+	sanitizeRegex     = regexp.MustCompile(guidRegex.String() + "|" + ipv4Regex.String() + "|" + awsAccountIDRegex.String())
+
+	// secretKeyParts are the parts of attribute and variable names whose values
+	// are likely to be secrets.
+	secretKeyParts = []string{
+		"password",
+		"secret",
+		"token",
+		"private_key",
+		"access_key",
+		"api_key",
+		"connection_string",
+		"certificate",
+		"key_material",
+	}
+
+	// tagKeys are the attributes whose values are tags. The tag keys are kept
+	// but their values are redacted.
+	tagKeys = map[string]bool{
+		"tags":         true,
+		"tags_all":     true,
+		"default_tags": true,
+		"labels":       true,
+	}
+)
+
+// planSanitizer replaces the sensitive values in a plan JSON. Account IDs,
+// subscription IDs and IPs are replaced by placeholders that are unique for
+// each value, so resources that reference each other by ID or ARN can still
+// be matched when the plan is parsed.
+type planSanitizer struct {
+	guids      map[string]string
+	ips        map[string]string
+	accountIDs map[string]string
+}
+
+// SanitizePlanJSON returns the plan JSON with its secrets, IPs, account IDs,
+// subscription IDs and tag values replaced, so it can be shared to reproduce
+// an estimate. The resource types, addresses and the attributes used for
+// pricing, e.g. instance types and sizes, are kept.
+func SanitizePlanJSON(j []byte) ([]byte, error) {
+	var plan interface{}
+
+	dec := json.NewDecoder(bytes.NewReader(j))
+	dec.UseNumber()
+	if err := dec.Decode(&plan); err != nil {
+		return []byte{}, errors.Wrap(err, "Error parsing plan JSON")
+	}
+
+	m, ok := plan.(map[string]interface{})
+	if !ok {
+		return []byte{}, errors.New("Error parsing plan JSON: expected an object")
+	}
+
+	redactPlanSensitiveValues(m)
+
+	s := &planSanitizer{
+		guids:      make(map[string]string),
+		ips:        make(map[string]string),
+		accountIDs: make(map[string]string),
+	}
+
+	b, err := json.MarshalIndent(s.sanitizeValue("", m), "", "  ")
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "Error generating plan JSON")
+	}
+
+	return b, nil
+}
+
+// redactPlanSensitiveValues redacts the values that Terraform marks as
+// sensitive. The plan JSON includes their values and marks them in separate
+// fields.
+func redactPlanSensitiveValues(plan map[string]interface{}) {
+	for _, path := range [][]string{
+		{"planned_values", "root_module"},
+		{"prior_state", "values", "root_module"},
+		{"values", "root_module"},
+	} {
+		if module, ok := getJSONObject(plan, path...); ok {
+			redactModuleSensitiveValues(module)
+		}
+	}
+
+	if changes, ok := plan["resource_changes"].([]interface{}); ok {
+		for _, c := range changes {
+			if change, ok := getJSONObject(c, "change"); ok {
+				redactChangeSensitiveValues(change)
+			}
+		}
+	}
+
+	if changes, ok := plan["output_changes"].(map[string]interface{}); ok {
+		for _, c := range changes {
+			if change, ok := c.(map[string]interface{}); ok {
+				redactChangeSensitiveValues(change)
+			}
+		}
+	}
+
+	for _, path := range [][]string{
+		{"planned_values", "outputs"},
+		{"prior_state", "values", "outputs"},
+		{"values", "outputs"},
+	} {
+		outputs, _ := getJSONObject(plan, path...)
+		for _, o := range outputs {
+			output, ok := o.(map[string]interface{})
+			if ok && output["sensitive"] == true {
+				output["value"] = redactAll(output["value"])
+			}
+		}
+	}
+}
+
+func redactModuleSensitiveValues(module map[string]interface{}) {
+	if resources, ok := module["resources"].([]interface{}); ok {
+		for _, r := range resources {
+			if resource, ok := r.(map[string]interface{}); ok {
+				resource["values"] = redactSensitive(resource["values"], resource["sensitive_values"])
+			}
+		}
+	}
+
+	if children, ok := module["child_modules"].([]interface{}); ok {
+		for _, c := range children {
+			if child, ok := c.(map[string]interface{}); ok {
+				redactModuleSensitiveValues(child)
+			}
+		}
+	}
+}
+
+func redactChangeSensitiveValues(change map[string]interface{}) {
+	change["before"] = redactSensitive(change["before"], change["before_sensitive"])
+	change["after"] = redactSensitive(change["after"], change["after_sensitive"])
+}
+
+// redactSensitive redacts the parts of the value that are marked as sensitive.
+// The sensitive marker has the same structure as the value, with true for the
+// sensitive parts, or is true if the whole value is sensitive.
+func redactSensitive(v interface{}, sensitive interface{}) interface{} {
+	switch s := sensitive.(type) {
+	case bool:
+		if s {
+			return redactAll(v)
+		}
+	case map[string]interface{}:
+		if m, ok := v.(map[string]interface{}); ok {
+			for k, sv := range s {
+				if val, ok := m[k]; ok {
+					m[k] = redactSensitive(val, sv)
+				}
+			}
+		}
+	case []interface{}:
+		if a, ok := v.([]interface{}); ok {
+			for i, sv := range s {
+				if i < len(a) {
+					a[i] = redactSensitive(a[i], sv)
+				}
+			}
+		}
+	}
+
+	return v
+}
+
+// redactAll replaces all the strings in the value. Numbers and bools are
+// kept since they're not likely to be secrets and can be needed for pricing.
+func redactAll(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			t[k] = redactAll(val)
+		}
+	case []interface{}:
+		for i, val := range t {
+			t[i] = redactAll(val)
+		}
+	case string:
+		return schema.RedactedValue
+	}
+
+	return v
+}
+
+func (s *planSanitizer) sanitizeValue(key string, v interface{}) interface{} {
+	if isSecretKey(key) {
+		return redactAll(v)
+	}
+
+	if tagKeys[key] {
+		if m, ok := v.(map[string]interface{}); ok {
+			for k, val := range m {
+				m[k] = redactAll(val)
+			}
+			return m
+		}
+	}
+
+	switch t := v.(type) {
+	case map[string]interface{}:
+		// Sort the keys so the placeholders are the same each time the plan is
+		// sanitized
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			t[k] = s.sanitizeValue(k, t[k])
+		}
+	case []interface{}:
+		for i, val := range t {
+			t[i] = s.sanitizeValue(key, val)
+		}
+	case string:
+		return s.sanitizeString(t)
+	}
+
+	return v
+}
+
+// sanitizeString replaces the subscription IDs, IPs and account IDs in the
+// string. They're matched in one pass so the placeholders aren't replaced
+// again and GUIDs are matched before the account IDs that their last part can
+// look like.
+func (s *planSanitizer) sanitizeString(v string) string {
+	return sanitizeRegex.ReplaceAllStringFunc(v, func(m string) string {
+		switch {
+		case guidRegex.MatchString(m):
+			return placeholder(s.guids, strings.ToLower(m), func(n int) string {
+				return fmt.Sprintf("00000000-0000-0000-0000-%012d", n)
+			})
+		case ipv4Regex.MatchString(m):
+			return placeholder(s.ips, m, func(n int) string {
+				return fmt.Sprintf("10.%d.%d.%d", (n>>16)&255, (n>>8)&255, n&255)
+			})
+		default:
+			return placeholder(s.accountIDs, m, func(n int) string {
+				return fmt.Sprintf("%012d", n)
+			})
+		}
+	})
+}
+
+// placeholder returns the placeholder for the value, creating a new one if the
+// value hasn't been seen before.
+func placeholder(placeholders map[string]string, v string, format func(n int) string) string {
+	if p, ok := placeholders[v]; ok {
+		return p
+	}
+
+	p := format(len(placeholders) + 1)
+	placeholders[v] = p
+
+	return p
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+
+	// These reference a secret rather than contain it, e.g. secret_arn or
+	// key_vault_secret_id
+	for _, suffix := range []string{"_arn", "_id", "_ids", "_name"} {
+		if strings.HasSuffix(key, suffix) {
+			return false
+		}
+	}
+
+	for _, part := range secretKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getJSONObject(v interface{}, path ...string) (map[string]interface{}, bool) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	for _, p := range path {
+		m, ok = m[p].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return m, true
+}
@@ -0,0 +1,88 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestSanitizePlanJSON(t *testing.T) {
+	plan := `{
+		"format_version": "0.2",
+		"variables": {
+			"region": {"value": "us-east-1"},
+			"db_password": {"value": "hunter2"}
+		},
+		"planned_values": {
+			"root_module": {
+				"resources": [
+					{
+						"address": "aws_instance.web",
+						"type": "aws_instance",
+						"values": {
+							"instance_type": "m5.large",
+							"private_ip": "10.1.2.3",
+							"iam_instance_profile": "arn:aws:iam::123456789012:instance-profile/web",
+							"user_data": "export KEY=abc",
+							"tags": {"Owner": "alice@example.com"}
+						},
+						"sensitive_values": {"user_data": true}
+					},
+					{
+						"address": "aws_db_instance.db",
+						"type": "aws_db_instance",
+						"values": {
+							"allocated_storage": 100,
+							"password": "hunter2",
+							"monitoring_role_arn": "arn:aws:iam::123456789012:role/monitoring",
+							"security_group_arn": "arn:aws:ec2:us-east-1:111111111111:security-group/sg-1"
+						}
+					},
+					{
+						"address": "azurerm_linux_virtual_machine.vm",
+						"type": "azurerm_linux_virtual_machine",
+						"values": {
+							"id": "/subscriptions/0A1B2C3D-1111-2222-3333-444455556666/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm",
+							"secret_arn": "arn"
+						}
+					}
+				]
+			}
+		}
+	}`
+
+	b, err := SanitizePlanJSON([]byte(plan))
+	require.NoError(t, err)
+
+	parsed := gjson.ParseBytes(b)
+	web := parsed.Get("planned_values.root_module.resources.0.values")
+	db := parsed.Get("planned_values.root_module.resources.1.values")
+	vm := parsed.Get("planned_values.root_module.resources.2.values")
+
+	assert.Equal(t, "us-east-1", parsed.Get("variables.region.value").String())
+	assert.Equal(t, "REDACTED", parsed.Get("variables.db_password.value").String())
+
+	assert.Equal(t, "m5.large", web.Get("instance_type").String())
+	assert.Equal(t, "10.0.0.1", web.Get("private_ip").String())
+	assert.Equal(t, "arn:aws:iam::000000000001:instance-profile/web", web.Get("iam_instance_profile").String())
+	assert.Equal(t, "REDACTED", web.Get("user_data").String())
+	assert.Equal(t, "REDACTED", web.Get("tags.Owner").String())
+
+	assert.Equal(t, int64(100), db.Get("allocated_storage").Int())
+	assert.Equal(t, "REDACTED", db.Get("password").String())
+	assert.Equal(t, "arn:aws:iam::000000000001:role/monitoring", db.Get("monitoring_role_arn").String())
+	assert.Equal(t, "arn:aws:ec2:us-east-1:000000000002:security-group/sg-1", db.Get("security_group_arn").String())
+
+	assert.Equal(t, "/subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm", vm.Get("id").String())
+	assert.Equal(t, "arn", vm.Get("secret_arn").String())
+}
+
+func TestSanitizePlanJSON_invalid(t *testing.T) {
+	_, err := SanitizePlanJSON([]byte(`[]`))
+	assert.Error(t, err)
+
+	_, err = SanitizePlanJSON([]byte(`{`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,137 @@
+package terraform
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	"github.com/hashicorp/hcl2/hclparse"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// maxModuleDepth stops local modules that reference each other from being
+// followed forever.
+const maxModuleDepth = 10
+
+// SourceRange is where a resource is declared in the Terraform files.
+type SourceRange struct {
+	Filename    string
+	StartLine   int
+	StartColumn int
+	EndLine     int
+	EndColumn   int
+}
+
+var sourceFileSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "resource", LabelNames: []string{"type", "name"}},
+		{Type: "module", LabelNames: []string{"name"}},
+	},
+}
+
+var moduleBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "source"},
+	},
+}
+
+// ResourceSourceRanges parses the Terraform files in the directory and returns
+// where each resource is declared, keyed by the resource address without any
+// count or for_each indexes, e.g. module.vpc.aws_nat_gateway.nat. Modules with
+// local sources are followed, remote modules are skipped since their files
+// aren't part of the repo.
+func ResourceSourceRanges(dir string) (map[string]SourceRange, error) {
+	ranges := make(map[string]SourceRange)
+	err := addResourceSourceRanges(hclparse.NewParser(), dir, "", ranges, 0)
+	return ranges, err
+}
+
+func addResourceSourceRanges(parser *hclparse.Parser, dir string, prefix string, ranges map[string]SourceRange, depth int) error {
+	if depth > maxModuleDepth {
+		log.Debugf("Skipping module %s since it is nested too deeply", prefix)
+		return nil
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return errors.Wrapf(err, "Error reading Terraform directory %s", dir)
+	}
+
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".tf" {
+			continue
+		}
+
+		filename := filepath.Join(dir, f.Name())
+
+		file, diags := parser.ParseHCLFile(filename)
+		if diags.HasErrors() {
+			log.Debugf("Error parsing %s: %s", filename, diags.Error())
+			continue
+		}
+
+		content, _, _ := file.Body.PartialContent(sourceFileSchema)
+
+		for _, block := range content.Blocks {
+			switch block.Type {
+			case "resource":
+				ranges[prefix+block.Labels[0]+"."+block.Labels[1]] = blockSourceRange(block)
+			case "module":
+				source := moduleSource(block)
+				if !isLocalModuleSource(source) {
+					continue
+				}
+
+				modulePrefix := prefix + "module." + block.Labels[0] + "."
+				err := addResourceSourceRanges(parser, filepath.Join(dir, source), modulePrefix, ranges, depth+1)
+				if err != nil {
+					log.Debugf("Error reading module %s: %v", strings.TrimSuffix(modulePrefix, "."), err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func blockSourceRange(block *hcl.Block) SourceRange {
+	r := SourceRange{
+		Filename:    block.DefRange.Filename,
+		StartLine:   block.DefRange.Start.Line,
+		StartColumn: block.DefRange.Start.Column,
+		EndLine:     block.DefRange.End.Line,
+		EndColumn:   block.DefRange.End.Column,
+	}
+
+	// Use the end of the block's body if it's available so the whole resource
+	// is covered, not just the header.
+	if body, ok := block.Body.(*hclsyntax.Body); ok {
+		r.EndLine = body.SrcRange.End.Line
+		r.EndColumn = body.SrcRange.End.Column
+	}
+
+	return r
+}
+
+func moduleSource(block *hcl.Block) string {
+	content, _, _ := block.Body.PartialContent(moduleBlockSchema)
+	attr, ok := content.Attributes["source"]
+	if !ok {
+		return ""
+	}
+
+	v, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || v.Type() != cty.String || !v.IsKnown() || v.IsNull() {
+		return ""
+	}
+
+	return v.AsString()
+}
+
+func isLocalModuleSource(source string) bool {
+	return strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../")
+}
@@ -2,6 +2,7 @@ package tftest
 
 import (
 	"bytes"
+	"context"
 	"flag"
 	"fmt"
 	"io/ioutil"
@@ -9,6 +10,7 @@ import (
 	"path/filepath"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/infracost/infracost/internal/output"
 	"github.com/infracost/infracost/internal/usage"
@@ -172,6 +174,25 @@ func GoldenFileResourceTests(t *testing.T, testName string) {
 	err := cfg.LoadFromEnv()
 	require.NoError(t, err)
 
+	// Skip the test until its golden file has been generated, since there's
+	// nothing to compare the output against.
+	goldenFilePath := filepath.Join("testdata", testName, testName+".golden")
+	if _, err := os.Stat(goldenFilePath); os.IsNotExist(err) && !*update {
+		t.Skipf("Golden file %s doesn't exist, run the test with -update to generate it", goldenFilePath)
+	}
+
+	// Replay the recorded pricing fixtures if there are any so the test doesn't
+	// need the pricing API. They are recorded again when the golden files are updated.
+	fixturesDir := filepath.Join("testdata", testName, "pricing_fixtures")
+	if *update {
+		err = os.RemoveAll(fixturesDir)
+		require.NoError(t, err)
+		cfg.RecordFixturesDir = fixturesDir
+	} else if _, err := os.Stat(fixturesDir); err == nil {
+		cfg.PricingBackend = "fixtures"
+		cfg.PricingFixturesDir = fixturesDir
+	}
+
 	// Load the terraform projects
 	tfProjectData, err := ioutil.ReadFile(filepath.Join("testdata", testName, testName+".tf"))
 	require.NoError(t, err)
@@ -216,7 +237,6 @@ func GoldenFileResourceTests(t *testing.T, testName string) {
 
 	// Load the snapshot result
 	expected := []byte("")
-	goldenFilePath := filepath.Join("testdata", testName, testName+".golden")
 	if _, err := os.Stat(goldenFilePath); err == nil || !os.IsNotExist(err) {
 		// golden file exists, load the data
 		expected, err = ioutil.ReadFile(goldenFilePath)
@@ -251,7 +271,7 @@ func RunCostCalculations(t *testing.T, cfg *config.Config, tfProject TerraformPr
 	if err != nil {
 		return project, err
 	}
-	err = prices.PopulatePrices(cfg, project)
+	err = prices.PopulatePrices(context.Background(), cfg, project, nil, nil, time.Time{})
 	if err != nil {
 		return project, err
 	}
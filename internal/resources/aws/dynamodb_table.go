@@ -13,6 +13,9 @@ type DynamoDbTableArguments struct {
 	WriteCapacity  int64    `json:"writeCapacity,omitempty"`
 	ReadCapacity   int64    `json:"readCapacity,omitempty"`
 	ReplicaRegions []string `json:"replicaRegions,omitempty"`
+	// Only the provisioned capacity of the indexes is priced, on-demand index
+	// writes should be included in the table's monthly request units.
+	GlobalSecondaryIndexes []DynamoDbGlobalSecondaryIndex `json:"globalSecondaryIndexes,omitempty"`
 
 	MonthlyWriteRequestUnits       *int64 `json:"monthlyWriteRequestUnits,omitempty"`
 	MonthlyReadRequestUnits        *int64 `json:"monthlyReadRequestUnits,omitempty"`
@@ -23,6 +26,12 @@ type DynamoDbTableArguments struct {
 	MonthlyStreamsReadRequestUnits *int64 `json:"monthlyStreamsReadRequestUnits,omitempty"`
 }
 
+type DynamoDbGlobalSecondaryIndex struct {
+	Name          string `json:"name,omitempty"`
+	WriteCapacity int64  `json:"writeCapacity,omitempty"`
+	ReadCapacity  int64  `json:"readCapacity,omitempty"`
+}
+
 func (args *DynamoDbTableArguments) PopulateUsage(u *schema.UsageData) {
 	if u != nil {
 		args.MonthlyWriteRequestUnits = u.GetInt("monthly_write_request_units")
@@ -77,6 +86,19 @@ func NewDynamoDBTable(args *DynamoDbTableArguments) *schema.Resource {
 	// Stream reads
 	costComponents = append(costComponents, streamCostComponent(args.Region, args.MonthlyStreamsReadRequestUnits))
 
+	// Global secondary indexes
+	if args.BillingMode == "PROVISIONED" {
+		for _, index := range args.GlobalSecondaryIndexes {
+			subResources = append(subResources, &schema.Resource{
+				Name: fmt.Sprintf("Global secondary index (%s)", index.Name),
+				CostComponents: []*schema.CostComponent{
+					wcuCostComponent(args.Region, index.WriteCapacity),
+					rcuCostComponent(args.Region, index.ReadCapacity),
+				},
+			})
+		}
+	}
+
 	// Global tables (replica)
 	subResources = append(subResources, globalTables(args.BillingMode, args.ReplicaRegions, args.WriteCapacity, args.MonthlyWriteRequestUnits)...)
 
@@ -0,0 +1,113 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+)
+
+// The retention period that's included in the shard hours, longer retention
+// periods are charged as extended retention.
+const kinesisStreamDefaultRetentionHours = 24
+
+type KinesisStreamArguments struct {
+	Address        string `json:"address,omitempty"`
+	Region         string `json:"region,omitempty"`
+	StreamMode     string `json:"streamMode,omitempty"`
+	ShardCount     int64  `json:"shardCount,omitempty"`
+	RetentionHours int64  `json:"retentionHours,omitempty"`
+
+	MonthlyPutPayloadUnits *float64 `json:"monthlyPutPayloadUnits,omitempty"`
+	MonthlyDataIngestedGB  *float64 `json:"monthlyDataIngestedGB,omitempty"`
+	MonthlyDataRetrievedGB *float64 `json:"monthlyDataRetrievedGB,omitempty"`
+}
+
+func (args *KinesisStreamArguments) PopulateUsage(u *schema.UsageData) {
+	if u != nil {
+		args.MonthlyPutPayloadUnits = u.GetFloat("monthly_put_payload_units")
+		args.MonthlyDataIngestedGB = u.GetFloat("monthly_data_ingested_gb")
+		args.MonthlyDataRetrievedGB = u.GetFloat("monthly_data_retrieved_gb")
+	}
+}
+
+var KinesisStreamUsageSchema = []*schema.UsageSchemaItem{
+	{Key: "monthly_put_payload_units", DefaultValue: 0, ValueType: schema.Float64},
+	{Key: "monthly_data_ingested_gb", DefaultValue: 0, ValueType: schema.Float64},
+	{Key: "monthly_data_retrieved_gb", DefaultValue: 0, ValueType: schema.Float64},
+}
+
+func NewKinesisStream(args *KinesisStreamArguments) *schema.Resource {
+	var costComponents []*schema.CostComponent
+
+	if args.StreamMode == "ON_DEMAND" {
+		costComponents = []*schema.CostComponent{
+			{
+				Name:           "Stream (on-demand)",
+				Unit:           "hours",
+				UnitMultiplier: 1,
+				HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+				ProductFilter:  kinesisStreamProductFilter(args.Region, "/OnDemand-StreamHour$/"),
+			},
+			{
+				Name:            "Data ingested (on-demand)",
+				Unit:            "GB",
+				UnitMultiplier:  1,
+				MonthlyQuantity: floatPtrToDecimalPtr(args.MonthlyDataIngestedGB),
+				ProductFilter:   kinesisStreamProductFilter(args.Region, "/OnDemand-BilledIncomingBytes$/"),
+			},
+			{
+				Name:            "Data retrieved (on-demand)",
+				Unit:            "GB",
+				UnitMultiplier:  1,
+				MonthlyQuantity: floatPtrToDecimalPtr(args.MonthlyDataRetrievedGB),
+				ProductFilter:   kinesisStreamProductFilter(args.Region, "/OnDemand-BilledOutgoingBytes$/"),
+			},
+		}
+	} else {
+		shards := decimal.NewFromInt(args.ShardCount)
+
+		costComponents = []*schema.CostComponent{
+			{
+				Name:           "Shard hours",
+				Unit:           "hours",
+				UnitMultiplier: 1,
+				HourlyQuantity: decimalPtr(shards),
+				ProductFilter:  kinesisStreamProductFilter(args.Region, "/Storage-ShardHour$/"),
+			},
+			{
+				Name:            "PUT payload units",
+				Unit:            "1M units",
+				UnitMultiplier:  1000000,
+				MonthlyQuantity: floatPtrToDecimalPtr(args.MonthlyPutPayloadUnits),
+				ProductFilter:   kinesisStreamProductFilter(args.Region, "/PutRequestPayloadUnits$/"),
+			},
+		}
+
+		if args.RetentionHours > kinesisStreamDefaultRetentionHours {
+			costComponents = append(costComponents, &schema.CostComponent{
+				Name:           "Extended retention",
+				Unit:           "hours",
+				UnitMultiplier: 1,
+				HourlyQuantity: decimalPtr(shards),
+				ProductFilter:  kinesisStreamProductFilter(args.Region, "/Extended-ShardHour$/"),
+			})
+		}
+	}
+
+	return &schema.Resource{
+		Name:           args.Address,
+		UsageSchema:    KinesisStreamUsageSchema,
+		CostComponents: costComponents,
+	}
+}
+
+func kinesisStreamProductFilter(region string, usageType string) *schema.ProductFilter {
+	return &schema.ProductFilter{
+		VendorName:    strPtr("aws"),
+		Region:        strPtr(region),
+		Service:       strPtr("AmazonKinesis"),
+		ProductFamily: strPtr("Kinesis Streams"),
+		AttributeFilters: []*schema.AttributeFilter{
+			{Key: "usagetype", ValueRegex: strPtr(usageType)},
+		},
+	}
+}
@@ -9,3 +9,10 @@ func strPtr(s string) *string {
 func decimalPtr(d decimal.Decimal) *decimal.Decimal {
 	return &d
 }
+
+func floatPtrToDecimalPtr(f *float64) *decimal.Decimal {
+	if f == nil {
+		return nil
+	}
+	return decimalPtr(decimal.NewFromFloat(*f))
+}
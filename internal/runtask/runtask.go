@@ -0,0 +1,293 @@
+// Package runtask implements the Terraform Cloud and Terraform Enterprise run
+// task protocol, so workspaces can get a cost estimate of each plan and have
+// runs fail when the costs break a policy.
+package runtask
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SignatureHeader is the header of the HMAC-SHA512 signature of the request
+// body, which is only sent if the run task has an HMAC key.
+const SignatureHeader = "X-Tfc-Task-Signature"
+
+// StagePostPlan is the only run task stage that has a plan to estimate.
+const StagePostPlan = "post_plan"
+
+// verificationAccessToken is the access token of the request that Terraform
+// Cloud sends when the run task is created to check that the URL works.
+const verificationAccessToken = "test-token"
+
+// The statuses of a task result.
+const (
+	StatusPassed = "passed"
+	StatusFailed = "failed"
+)
+
+var apiTimeout = 60 * time.Second
+
+// Request is the body of a run task request. Only the fields that are used
+// are included.
+type Request struct {
+	PayloadVersion        int    `json:"payload_version"`
+	AccessToken           string `json:"access_token"`
+	Stage                 string `json:"stage"`
+	IsSpeculative         bool   `json:"is_speculative"`
+	TaskResultID          string `json:"task_result_id"`
+	TaskResultCallbackURL string `json:"task_result_callback_url"`
+	RunAppURL             string `json:"run_app_url"`
+	RunID                 string `json:"run_id"`
+	OrganizationName      string `json:"organization_name"`
+	WorkspaceName         string `json:"workspace_name"`
+	PlanJSONAPIURL        string `json:"plan_json_api_url"`
+}
+
+// ParseRequest parses and checks the run task request body.
+func ParseRequest(body []byte) (Request, error) {
+	var r Request
+	err := json.Unmarshal(body, &r)
+	if err != nil {
+		return r, errors.Wrap(err, "Error parsing run task request")
+	}
+
+	if r.TaskResultCallbackURL == "" || r.AccessToken == "" {
+		return r, errors.New("Invalid run task request, task_result_callback_url and access_token are required")
+	}
+
+	if !r.IsVerification() && r.Stage == StagePostPlan && r.PlanJSONAPIURL == "" {
+		return r, errors.New("Invalid run task request, plan_json_api_url is required")
+	}
+
+	return r, nil
+}
+
+// IsVerification returns true if the request is the one that's sent when the
+// run task is created, which should only be responded to.
+func (r Request) IsVerification() bool {
+	return r.AccessToken == verificationAccessToken
+}
+
+// ProjectName is the name of the workspace including its organization.
+func (r Request) ProjectName() string {
+	return fmt.Sprintf("%s/%s", r.OrganizationName, r.WorkspaceName)
+}
+
+// VerifySignature checks the signature of the request body. Requests are never
+// verified if there's no HMAC key.
+func VerifySignature(hmacKey string, body []byte, signature string) bool {
+	if hmacKey == "" {
+		return false
+	}
+
+	mac := hmac.New(sha512.New, []byte(hmacKey))
+	mac.Write(body)
+
+	expected := hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(strings.ToLower(signature)))
+}
+
+// FetchPlanJSON downloads the plan JSON of the run. Terraform Cloud redirects
+// to a temporary URL of the plan JSON, which is followed.
+func (r Request) FetchPlanJSON() ([]byte, error) {
+	req, err := http.NewRequest("GET", r.PlanJSONAPIURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", r.AccessToken))
+
+	client := &http.Client{Timeout: apiTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error downloading plan JSON")
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error downloading plan JSON")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("Error downloading plan JSON: invalid response %s", resp.Status)
+	}
+
+	return body, nil
+}
+
+// Policies are the checks of the costs that fail the task result.
+type Policies struct {
+	// MaxIncrease fails the task result if the total monthly cost increases
+	// by at least the threshold
+	MaxIncrease *output.DiffThreshold
+	// MaxMonthlyCost fails the task result if the total monthly cost after
+	// the plan is over it
+	MaxMonthlyCost *decimal.Decimal
+}
+
+// Outcome is a detailed result of the task, shown in the run. The policy
+// outcomes are passed or failed and the estimate's outcome is neither.
+type Outcome struct {
+	ID          string
+	Description string
+	Body        string
+	Passed      *bool
+}
+
+// Result is the result of the task that's sent back to Terraform Cloud.
+type Result struct {
+	Status   string
+	Message  string
+	URL      string
+	Outcomes []Outcome
+}
+
+// NewResult returns the task result of the estimate. It has an outcome with
+// the diff of the estimate and an outcome for each policy, and fails if any
+// of the policies fail.
+func NewResult(out output.Root, policies Policies) (Result, error) {
+	b, err := output.ToDiff(out, output.Options{NoColor: true})
+	if err != nil {
+		return Result{}, errors.Wrap(err, "Error generating run task result")
+	}
+
+	summary := output.BuildNotification(out, "", "").Title
+	result := Result{
+		Status:  StatusPassed,
+		Message: summary,
+		Outcomes: []Outcome{
+			{
+				ID:          "infracost-estimate",
+				Description: summary,
+				Body:        fmt.Sprintf("```\n%s\n```", strings.TrimSpace(ui.StripColor(string(b)))),
+			},
+		},
+	}
+
+	failed := 0
+	addPolicy := func(id string, passed bool, description string) {
+		if !passed {
+			failed++
+		}
+
+		result.Outcomes = append(result.Outcomes, Outcome{ID: id, Description: description, Passed: &passed})
+	}
+
+	if policies.MaxIncrease != nil {
+		change := output.TotalMonthlyCostChange(out)
+		passed := !change.IsPositive() || !policies.MaxIncrease.IsSignificant(out)
+		addPolicy("infracost-max-increase", passed, fmt.Sprintf("The monthly cost must increase by less than %s", policies.MaxIncrease.String()))
+	}
+
+	if policies.MaxMonthlyCost != nil {
+		total := decimal.Zero
+		if out.TotalMonthlyCost != nil {
+			total = *out.TotalMonthlyCost
+		}
+
+		passed := !total.GreaterThan(*policies.MaxMonthlyCost)
+		addPolicy("infracost-max-monthly-cost", passed, fmt.Sprintf("The monthly cost must be at most $%s", policies.MaxMonthlyCost.StringFixed(2)))
+	}
+
+	if failed > 0 {
+		result.Status = StatusFailed
+		result.Message = fmt.Sprintf("%s. %d of %d cost policies failed", summary, failed, len(result.Outcomes)-1)
+	}
+
+	return result, nil
+}
+
+// ErrorResult returns the failed task result for an error estimating the
+// costs, so the run doesn't wait for the result until it times out.
+func ErrorResult(err error) Result {
+	return Result{
+		Status:  StatusFailed,
+		Message: fmt.Sprintf("Infracost couldn't estimate the costs: %s", err),
+	}
+}
+
+// SendResult sends the task result to the callback URL of the request.
+func (r Request) SendResult(result Result) error {
+	outcomes := make([]map[string]interface{}, 0, len(result.Outcomes))
+	for _, o := range result.Outcomes {
+		attributes := map[string]interface{}{
+			"outcome-id":  o.ID,
+			"description": o.Description,
+			"body":        o.Body,
+		}
+
+		if o.Passed != nil {
+			tag := map[string]string{"label": "Passed", "level": "info"}
+			if !*o.Passed {
+				tag = map[string]string{"label": "Failed", "level": "error"}
+			}
+			attributes["tags"] = map[string]interface{}{"Status": []map[string]string{tag}}
+		}
+
+		outcomes = append(outcomes, map[string]interface{}{
+			"type":       "task-result-outcomes",
+			"attributes": attributes,
+		})
+	}
+
+	attributes := map[string]string{
+		"status":  result.Status,
+		"message": result.Message,
+	}
+	if result.URL != "" {
+		attributes["url"] = result.URL
+	}
+
+	data := map[string]interface{}{
+		"type":       "task-results",
+		"attributes": attributes,
+	}
+	if len(outcomes) > 0 {
+		data["relationships"] = map[string]interface{}{
+			"outcomes": map[string]interface{}{"data": outcomes},
+		}
+	}
+
+	b, err := json.Marshal(map[string]interface{}{"data": data})
+	if err != nil {
+		return errors.Wrap(err, "Error generating run task result")
+	}
+
+	log.Debugf("Sending run task result %s to %s", result.Status, r.TaskResultCallbackURL)
+
+	req, err := http.NewRequest("PATCH", r.TaskResultCallbackURL, bytes.NewBuffer(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.api+json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", r.AccessToken))
+
+	client := &http.Client{Timeout: apiTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "Error sending run task result")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("Error sending run task result: invalid response %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package runtask
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/output"
+)
+
+func TestParseRequest(t *testing.T) {
+	r, err := ParseRequest([]byte(`{"payload_version":1,"access_token":"test-token","task_result_callback_url":"https://app.terraform.io/api/v2/task-results/taskrs-1/callback"}`))
+	require.NoError(t, err)
+	assert.True(t, r.IsVerification())
+
+	r, err = ParseRequest([]byte(`{"access_token":"token","stage":"post_plan","task_result_callback_url":"https://app.terraform.io/callback","organization_name":"my-org","workspace_name":"prod","plan_json_api_url":"https://app.terraform.io/api/v2/plans/plan-1/json-output"}`))
+	require.NoError(t, err)
+	assert.False(t, r.IsVerification())
+	assert.Equal(t, "my-org/prod", r.ProjectName())
+
+	_, err = ParseRequest([]byte(`{"access_token":"token","stage":"post_plan","task_result_callback_url":"https://app.terraform.io/callback"}`))
+	assert.EqualError(t, err, "Invalid run task request, plan_json_api_url is required")
+
+	_, err = ParseRequest([]byte(`{"access_token":"token"}`))
+	assert.EqualError(t, err, "Invalid run task request, task_result_callback_url and access_token are required")
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"access_token":"test-token"}`)
+
+	mac := hmac.New(sha512.New, []byte("key"))
+	mac.Write(body)
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	assert.True(t, VerifySignature("key", body, signature))
+	assert.False(t, VerifySignature("other-key", body, signature))
+	assert.False(t, VerifySignature("key", body, ""))
+	assert.False(t, VerifySignature("", body, ""))
+}
+
+func TestNewResult(t *testing.T) {
+	decimalPtr := func(i int64) *decimal.Decimal {
+		d := decimal.NewFromInt(i)
+		return &d
+	}
+
+	out := output.Root{
+		Projects: []output.Project{
+			{
+				Name:          "my-org/prod",
+				PastBreakdown: &output.Breakdown{TotalMonthlyCost: decimalPtr(100)},
+				Breakdown:     &output.Breakdown{TotalMonthlyCost: decimalPtr(150)},
+				Diff:          &output.Breakdown{TotalMonthlyCost: decimalPtr(50)},
+			},
+		},
+		TotalMonthlyCost: decimalPtr(150),
+		Summary:          &output.Summary{},
+	}
+
+	result, err := NewResult(out, Policies{})
+	require.NoError(t, err)
+	assert.Equal(t, StatusPassed, result.Status)
+	assert.Equal(t, "Monthly cost will change by +$50.00 (+50%), from $100 to $150", result.Message)
+	require.Len(t, result.Outcomes, 1)
+	assert.Nil(t, result.Outcomes[0].Passed)
+	assert.Contains(t, result.Outcomes[0].Body, "Project: my-org/prod")
+
+	maxIncrease, err := output.ParseThreshold("10%")
+	require.NoError(t, err)
+
+	result, err = NewResult(out, Policies{MaxIncrease: maxIncrease, MaxMonthlyCost: decimalPtr(200)})
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, result.Status)
+	assert.Equal(t, "Monthly cost will change by +$50.00 (+50%), from $100 to $150. 1 of 2 cost policies failed", result.Message)
+	require.Len(t, result.Outcomes, 3)
+	assert.False(t, *result.Outcomes[1].Passed)
+	assert.Equal(t, "The monthly cost must increase by less than 10%", result.Outcomes[1].Description)
+	assert.True(t, *result.Outcomes[2].Passed)
+	assert.Equal(t, "The monthly cost must be at most $200.00", result.Outcomes[2].Description)
+}
+
+func TestFetchPlanJSONAndSendResult(t *testing.T) {
+	var sent map[string]interface{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/plan":
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			http.Redirect(w, r, "/archivist", http.StatusTemporaryRedirect)
+		case "/archivist":
+			_, _ = w.Write([]byte(`{"format_version":"1.0"}`))
+		case "/callback":
+			assert.Equal(t, "PATCH", r.Method)
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			assert.Equal(t, "application/vnd.api+json", r.Header.Get("Content-Type"))
+
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.NoError(t, json.Unmarshal(body, &sent))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	req := Request{AccessToken: "token", TaskResultCallbackURL: ts.URL + "/callback", PlanJSONAPIURL: ts.URL + "/plan"}
+
+	planJSON, err := req.FetchPlanJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"format_version":"1.0"}`, string(planJSON))
+
+	passed := false
+	err = req.SendResult(Result{
+		Status:   StatusFailed,
+		Message:  "1 of 1 cost policies failed",
+		URL:      "https://app.terraform.io/app/my-org/prod/runs/run-1",
+		Outcomes: []Outcome{{ID: "infracost-max-increase", Description: "The monthly cost must increase by less than 10%", Passed: &passed}},
+	})
+	require.NoError(t, err)
+
+	data := sent["data"].(map[string]interface{})
+	assert.Equal(t, "task-results", data["type"])
+	assert.Equal(t, map[string]interface{}{
+		"status":  "failed",
+		"message": "1 of 1 cost policies failed",
+		"url":     "https://app.terraform.io/app/my-org/prod/runs/run-1",
+	}, data["attributes"])
+
+	outcomes := data["relationships"].(map[string]interface{})["outcomes"].(map[string]interface{})["data"].([]interface{})
+	require.Len(t, outcomes, 1)
+	attributes := outcomes[0].(map[string]interface{})["attributes"].(map[string]interface{})
+	assert.Equal(t, "infracost-max-increase", attributes["outcome-id"])
+	assert.Equal(t, map[string]interface{}{"Status": []interface{}{map[string]interface{}{"label": "Failed", "level": "error"}}}, attributes["tags"])
+
+	req.TaskResultCallbackURL = ts.URL + "/missing"
+	assert.EqualError(t, req.SendResult(ErrorResult(errors.New("invalid plan JSON"))), "Error sending run task result: invalid response 404 Not Found")
+}
@@ -0,0 +1,158 @@
+package schema
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+const costAdjustmentsFileVersion = "0.1"
+
+type CostAdjustmentsFile struct {
+	Version     string            `yaml:"version"`
+	Adjustments []*CostAdjustment `yaml:"adjustments"`
+}
+
+// CostAdjustment changes the costs of the cost components matching all its
+// specified fields after they're priced, e.g. to add an overhead to data
+// transfer or to estimate dev resources at a lower utilization. Either
+// Percent, the percentage to add to the costs (negative to reduce them), or
+// Multiplier can be set. The resource type can be a glob pattern like aws_*
+// and the cost component matches if its name contains the given text.
+type CostAdjustment struct {
+	Name          string            `yaml:"name"`
+	Description   string            `yaml:"description,omitempty"`
+	ResourceType  string            `yaml:"resource_type,omitempty"`
+	CostComponent string            `yaml:"cost_component,omitempty"`
+	Tags          map[string]string `yaml:"tags,omitempty"`
+	Percent       *float64          `yaml:"percent,omitempty"`
+	Multiplier    *float64          `yaml:"multiplier,omitempty"`
+}
+
+// AppliedCostAdjustment is a cost adjustment that's applied to a cost
+// component, with the change it made to the monthly cost.
+type AppliedCostAdjustment struct {
+	Name        string
+	Multiplier  decimal.Decimal
+	MonthlyCost decimal.Decimal
+}
+
+func (a *CostAdjustment) validate() error {
+	if a.Name == "" {
+		return errors.New("Cost adjustment is missing a name")
+	}
+
+	if (a.Percent == nil) == (a.Multiplier == nil) {
+		return fmt.Errorf("Cost adjustment '%s' must have either a percent or a multiplier", a.Name)
+	}
+
+	if a.Percent != nil && *a.Percent < -100 {
+		return fmt.Errorf("Cost adjustment '%s' has an invalid percent %v, it must be -100 or more", a.Name, *a.Percent)
+	}
+
+	if a.Multiplier != nil && *a.Multiplier < 0 {
+		return fmt.Errorf("Cost adjustment '%s' has an invalid multiplier %v, it must be 0 or more", a.Name, *a.Multiplier)
+	}
+
+	if _, err := filepath.Match(a.ResourceType, ""); err != nil {
+		return fmt.Errorf("Cost adjustment '%s' has an invalid resource type pattern '%s'", a.Name, a.ResourceType)
+	}
+
+	return nil
+}
+
+func (a *CostAdjustment) multiplier() decimal.Decimal {
+	if a.Multiplier != nil {
+		return decimal.NewFromFloat(*a.Multiplier)
+	}
+
+	return decimal.NewFromInt(100).Add(decimal.NewFromFloat(*a.Percent)).Div(decimal.NewFromInt(100))
+}
+
+// matchesResource checks the fields of the adjustment that apply to the
+// top-level resource, since sub-resources don't have their own type or tags.
+func (a *CostAdjustment) matchesResource(r *Resource) bool {
+	if a.ResourceType != "" {
+		if ok, _ := filepath.Match(a.ResourceType, r.ResourceType); !ok {
+			return false
+		}
+	}
+
+	for k, v := range a.Tags {
+		if tag, ok := r.Tags[k]; !ok || !strings.EqualFold(tag, v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (a *CostAdjustment) matchesCostComponent(c *CostComponent) bool {
+	return a.CostComponent == "" || strings.Contains(strings.ToLower(c.Name), strings.ToLower(a.CostComponent))
+}
+
+func LoadCostAdjustmentsFromFile(path string) ([]*CostAdjustment, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	out, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading cost adjustments file")
+	}
+
+	var f CostAdjustmentsFile
+	err = yaml.Unmarshal(out, &f)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing cost adjustments file")
+	}
+
+	if f.Version != costAdjustmentsFileVersion {
+		return nil, fmt.Errorf("Invalid cost adjustments file version. Supported versions are %s", costAdjustmentsFileVersion)
+	}
+
+	for _, a := range f.Adjustments {
+		err = a.validate()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return f.Adjustments, nil
+}
+
+// ApplyCostAdjustments adds every matching adjustment to the cost components
+// of the project, so they're applied in order when the costs are calculated.
+// This must be called before CalculateCosts.
+func ApplyCostAdjustments(project *Project, adjustments []*CostAdjustment) {
+	if len(adjustments) == 0 {
+		return
+	}
+
+	for _, r := range project.AllResources() {
+		for _, a := range adjustments {
+			if a.matchesResource(r) {
+				applyResourceCostAdjustment(r, a)
+			}
+		}
+	}
+}
+
+func applyResourceCostAdjustment(r *Resource, a *CostAdjustment) {
+	for _, c := range r.CostComponents {
+		if a.matchesCostComponent(c) {
+			log.Debugf("Using cost adjustment '%s' for %s %s", a.Name, r.Name, c.Name)
+			c.addAdjustment(a.Name, a.multiplier())
+		}
+	}
+
+	for _, s := range r.SubResources {
+		applyResourceCostAdjustment(s, a)
+	}
+}
@@ -0,0 +1,131 @@
+package schema
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadCostAdjustmentsFromFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			content: `version: 0.1
+adjustments:
+  - name: Data transfer overhead
+    cost_component: data transfer
+    percent: 15
+  - name: Dev utilization
+    tags:
+      env: dev
+    multiplier: 0.3
+`,
+		},
+		{
+			name:    "invalid version",
+			content: "version: 0.2\nadjustments: []\n",
+			wantErr: "Invalid cost adjustments file version. Supported versions are 0.1",
+		},
+		{
+			name:    "missing name",
+			content: "version: 0.1\nadjustments:\n  - percent: 10\n",
+			wantErr: "Cost adjustment is missing a name",
+		},
+		{
+			name:    "percent and multiplier",
+			content: "version: 0.1\nadjustments:\n  - name: both\n    percent: 10\n    multiplier: 2\n",
+			wantErr: "Cost adjustment 'both' must have either a percent or a multiplier",
+		},
+		{
+			name:    "negative multiplier",
+			content: "version: 0.1\nadjustments:\n  - name: negative\n    multiplier: -1\n",
+			wantErr: "Cost adjustment 'negative' has an invalid multiplier -1, it must be 0 or more",
+		},
+		{
+			name:    "invalid resource type",
+			content: "version: 0.1\nadjustments:\n  - name: glob\n    resource_type: aws_[\n    percent: 10\n",
+			wantErr: "Cost adjustment 'glob' has an invalid resource type pattern 'aws_['",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "adjustments.yml")
+			require.NoError(t, ioutil.WriteFile(path, []byte(tt.content), 0600))
+
+			adjustments, err := LoadCostAdjustmentsFromFile(path)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, adjustments, 2)
+		})
+	}
+}
+
+func TestApplyCostAdjustments(t *testing.T) {
+	percent := 15.0
+	multiplier := 0.3
+
+	project := &Project{
+		Resources: []*Resource{
+			{
+				Name:         "aws_instance.dev",
+				ResourceType: "aws_instance",
+				Tags:         map[string]string{"env": "Dev"},
+				CostComponents: []*CostComponent{
+					{Name: "Instance usage", HourlyQuantity: decimalPtr(decimal.NewFromInt(1)), price: decimal.NewFromInt(1)},
+				},
+			},
+			{
+				Name:         "aws_nat_gateway.prod",
+				ResourceType: "aws_nat_gateway",
+				Tags:         map[string]string{"env": "prod"},
+				CostComponents: []*CostComponent{
+					{Name: "NAT gateway", HourlyQuantity: decimalPtr(decimal.NewFromInt(1)), price: decimal.NewFromInt(1)},
+					{Name: "Data processed", MonthlyQuantity: decimalPtr(decimal.NewFromInt(100)), price: decimal.NewFromInt(1)},
+				},
+				SubResources: []*Resource{
+					{
+						Name: "Outbound data transfer",
+						CostComponents: []*CostComponent{
+							{Name: "Data transfer out", MonthlyQuantity: decimalPtr(decimal.NewFromInt(200)), price: decimal.NewFromInt(1)},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	ApplyCostAdjustments(project, []*CostAdjustment{
+		{Name: "Data transfer overhead", CostComponent: "Data Transfer", Percent: &percent},
+		{Name: "Dev utilization", ResourceType: "aws_*", Tags: map[string]string{"env": "dev"}, Multiplier: &multiplier},
+	})
+	CalculateCosts(project)
+
+	dev := project.Resources[0].CostComponents[0]
+	assert.Equal(t, "0.3", dev.HourlyCost.String())
+	assert.Equal(t, "219", dev.MonthlyCost.String())
+	require.Len(t, dev.Adjustments(), 1)
+	assert.Equal(t, "Dev utilization", dev.Adjustments()[0].Name)
+	assert.Equal(t, "-511", dev.Adjustments()[0].MonthlyCost.String())
+
+	prod := project.Resources[1]
+	assert.Empty(t, prod.CostComponents[0].Adjustments())
+	assert.Empty(t, prod.CostComponents[1].Adjustments())
+
+	transfer := prod.SubResources[0].CostComponents[0]
+	assert.Equal(t, "230", transfer.MonthlyCost.String())
+	require.Len(t, transfer.Adjustments(), 1)
+	assert.Equal(t, "30", transfer.Adjustments()[0].MonthlyCost.String())
+}
@@ -16,12 +16,23 @@ type CostComponent struct {
 	MonthlyDiscountPerc  float64
 	price                decimal.Decimal
 	priceHash            string
+	productHash          string
+	productSKU           string
+	priceOverride        string
+	unpriced             bool
+	adjustments          []*AppliedCostAdjustment
 	HourlyCost           *decimal.Decimal
 	MonthlyCost          *decimal.Decimal
+	// PastName is set on the cost components of a diff when the name of the
+	// cost component changed, e.g. because the instance type changed.
+	PastName string
 }
 
 func (c *CostComponent) CalculateCosts() {
 	c.fillQuantities()
+	if c.unpriced {
+		return
+	}
 	if c.HourlyQuantity != nil {
 		c.HourlyCost = decimalPtr(c.price.Mul(*c.HourlyQuantity))
 	}
@@ -29,6 +40,24 @@ func (c *CostComponent) CalculateCosts() {
 		discountMul := decimal.NewFromFloat(1.0 - c.MonthlyDiscountPerc)
 		c.MonthlyCost = decimalPtr(c.price.Mul(*c.MonthlyQuantity).Mul(discountMul))
 	}
+	c.applyAdjustments()
+}
+
+// applyAdjustments multiplies the costs by each of the cost adjustments in
+// turn, recording how much each one changed the monthly cost.
+func (c *CostComponent) applyAdjustments() {
+	for _, a := range c.adjustments {
+		if c.HourlyCost != nil {
+			c.HourlyCost = decimalPtr(c.HourlyCost.Mul(a.Multiplier))
+		}
+
+		a.MonthlyCost = decimal.Zero
+		if c.MonthlyCost != nil {
+			adjusted := c.MonthlyCost.Mul(a.Multiplier)
+			a.MonthlyCost = adjusted.Sub(*c.MonthlyCost)
+			c.MonthlyCost = &adjusted
+		}
+	}
 }
 
 func (c *CostComponent) fillQuantities() {
@@ -55,6 +84,49 @@ func (c *CostComponent) PriceHash() string {
 	return c.priceHash
 }
 
+func (c *CostComponent) SetProductHash(productHash string) {
+	c.productHash = productHash
+}
+
+func (c *CostComponent) ProductHash() string {
+	return c.productHash
+}
+
+func (c *CostComponent) SetProductSKU(sku string) {
+	c.productSKU = sku
+}
+
+func (c *CostComponent) ProductSKU() string {
+	return c.productSKU
+}
+
+func (c *CostComponent) SetPriceOverride(name string) {
+	c.priceOverride = name
+}
+
+func (c *CostComponent) PriceOverride() string {
+	return c.priceOverride
+}
+
+// SetUnpriced marks the cost component as not priced, e.g. since the
+// deadline was reached before its price was fetched, so it has no costs.
+func (c *CostComponent) SetUnpriced() {
+	c.unpriced = true
+}
+
+func (c *CostComponent) IsUnpriced() bool {
+	return c.unpriced
+}
+
+func (c *CostComponent) addAdjustment(name string, multiplier decimal.Decimal) {
+	c.adjustments = append(c.adjustments, &AppliedCostAdjustment{Name: name, Multiplier: multiplier})
+}
+
+// Adjustments returns the cost adjustments applied to the cost component.
+func (c *CostComponent) Adjustments() []*AppliedCostAdjustment {
+	return c.adjustments
+}
+
 func (c *CostComponent) UnitMultiplierPrice() decimal.Decimal {
 	return c.Price().Mul(decimal.NewFromInt(int64(c.UnitMultiplier)))
 }
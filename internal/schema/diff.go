@@ -2,6 +2,7 @@ package schema
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
@@ -72,12 +73,15 @@ func diffResourcesByKey(resourceKey string, pastResMap, currentResMap map[string
 	}
 	changed := false
 	diff := &Resource{
-		Name:         baseResource.Name,
-		IsSkipped:    baseResource.IsSkipped,
-		NoPrice:      baseResource.NoPrice,
-		SkipMessage:  baseResource.SkipMessage,
-		ResourceType: baseResource.ResourceType,
-		Tags:         baseResource.Tags,
+		Name:          baseResource.Name,
+		IsSkipped:     baseResource.IsSkipped,
+		NoPrice:       baseResource.NoPrice,
+		SkipMessage:   baseResource.SkipMessage,
+		SkipReason:    baseResource.SkipReason,
+		ResourceType:  baseResource.ResourceType,
+		Tags:          baseResource.Tags,
+		SensitiveTags: baseResource.SensitiveTags,
+		PlanActions:   baseResource.PlanActions,
 
 		HourlyCost:  diffDecimals(current.HourlyCost, past.HourlyCost),
 		MonthlyCost: diffDecimals(current.MonthlyCost, past.MonthlyCost),
@@ -118,14 +122,21 @@ func diffResourcesByKey(resourceKey string, pastResMap, currentResMap map[string
 
 // diffCostComponentsByResource calculates the diff of cost components of two resource.
 // It uses the same strategy as the calculating the diff of resources in the CalculateDiff func.
+// Cost components that were renamed, e.g. because the instance type changed,
+// are diffed with each other rather than shown as removed and added.
 func diffCostComponentsByResource(past, current *Resource) (bool, []*CostComponent) {
 	result := make([]*CostComponent, 0)
 	changed := false
 	pastCCMap := getCostComponentsMap(past)
 	currentCCMap := getCostComponentsMap(current)
+	renamed := renamedCostComponents(past, current)
 	for _, costComponent := range past.CostComponents {
 		key := costComponent.Name
-		changed, diff := diffCostComponentsByKey(key, pastCCMap, currentCCMap)
+		currentKey := key
+		if k, ok := renamed[key]; ok {
+			currentKey = k
+		}
+		changed, diff := diffCostComponentsByKey(key, currentKey, pastCCMap, currentCCMap)
 		if changed {
 			result = append(result, diff)
 		}
@@ -135,7 +146,7 @@ func diffCostComponentsByResource(past, current *Resource) (bool, []*CostCompone
 		if _, ok := currentCCMap[key]; !ok {
 			continue
 		}
-		changed, diff := diffCostComponentsByKey(key, pastCCMap, currentCCMap)
+		changed, diff := diffCostComponentsByKey(key, key, pastCCMap, currentCCMap)
 		if changed {
 			result = append(result, diff)
 		}
@@ -146,13 +157,62 @@ func diffCostComponentsByResource(past, current *Resource) (bool, []*CostCompone
 	return changed, result
 }
 
+// renamedCostComponents maps the names of the past cost components that
+// were renamed to their current names. A cost component is renamed if it's
+// the only removed and the only added cost component with the same name
+// before its details, e.g. "Instance usage (Linux/UNIX, on-demand, m5.large)"
+// and "Instance usage (Linux/UNIX, on-demand, m5.2xlarge)".
+func renamedCostComponents(past, current *Resource) map[string]string {
+	pastNames := make(map[string]bool, len(past.CostComponents))
+	for _, c := range past.CostComponents {
+		pastNames[c.Name] = true
+	}
+
+	currentNames := make(map[string]bool, len(current.CostComponents))
+	for _, c := range current.CostComponents {
+		currentNames[c.Name] = true
+	}
+
+	removed := make(map[string][]string)
+	for _, c := range past.CostComponents {
+		if !currentNames[c.Name] {
+			base := costComponentBaseName(c.Name)
+			removed[base] = append(removed[base], c.Name)
+		}
+	}
+
+	added := make(map[string][]string)
+	for _, c := range current.CostComponents {
+		if !pastNames[c.Name] {
+			base := costComponentBaseName(c.Name)
+			added[base] = append(added[base], c.Name)
+		}
+	}
+
+	renamed := make(map[string]string)
+	for base, names := range removed {
+		if len(names) == 1 && len(added[base]) == 1 {
+			renamed[names[0]] = added[base][0]
+		}
+	}
+
+	return renamed
+}
+
+// costComponentBaseName returns the name of the cost component without the
+// details in brackets.
+func costComponentBaseName(name string) string {
+	return strings.TrimSpace(strings.SplitN(name, "(", 2)[0])
+}
+
 // diffCostComponentsByKey calculates the diff between two cost components given
-// their costComponentsMap and their key.
-func diffCostComponentsByKey(key string, pastCCMap, currentCCMap map[string]*CostComponent) (bool, *CostComponent) {
-	past, pastOk := pastCCMap[key]
-	current, currentOk := currentCCMap[key]
+// their costComponentsMap and their keys, which are different if the cost
+// component was renamed.
+func diffCostComponentsByKey(pastKey, currentKey string, pastCCMap, currentCCMap map[string]*CostComponent) (bool, *CostComponent) {
+	past, pastOk := pastCCMap[pastKey]
+	current, currentOk := currentCCMap[currentKey]
 	if current == nil && past == nil {
-		log.Debugf("diffCostComponentsByKey nil current and past with key %s", key)
+		log.Debugf("diffCostComponentsByKey nil current and past with keys %s and %s", pastKey, currentKey)
 		return false, nil
 	}
 	baseCostComponent := current
@@ -172,6 +232,9 @@ func diffCostComponentsByKey(key string, pastCCMap, currentCCMap map[string]*Cos
 		ProductFilter:        baseCostComponent.ProductFilter,
 		PriceFilter:          baseCostComponent.PriceFilter,
 		priceHash:            baseCostComponent.priceHash,
+		productHash:          baseCostComponent.productHash,
+		productSKU:           baseCostComponent.productSKU,
+		priceOverride:        baseCostComponent.priceOverride,
 
 		HourlyQuantity:      diffDecimals(current.HourlyQuantity, past.HourlyQuantity),
 		MonthlyQuantity:     diffDecimals(current.MonthlyQuantity, past.MonthlyQuantity),
@@ -180,16 +243,20 @@ func diffCostComponentsByKey(key string, pastCCMap, currentCCMap map[string]*Cos
 		HourlyCost:          diffDecimals(current.HourlyCost, past.HourlyCost),
 		MonthlyCost:         diffDecimals(current.MonthlyCost, past.MonthlyCost),
 	}
+	if pastOk && currentOk && pastKey != currentKey {
+		diff.PastName = pastKey
+		changed = true
+	}
 	if !diff.HourlyQuantity.IsZero() || !diff.MonthlyQuantity.IsZero() ||
 		diff.MonthlyDiscountPerc != 0 || !diff.price.IsZero() ||
 		!diff.HourlyCost.IsZero() || !diff.MonthlyCost.IsZero() {
 		changed = true
 	}
 	if pastOk {
-		delete(pastCCMap, key)
+		delete(pastCCMap, pastKey)
 	}
 	if currentOk {
-		delete(currentCCMap, key)
+		delete(currentCCMap, currentKey)
 	}
 
 	return changed, diff
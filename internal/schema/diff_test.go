@@ -286,6 +286,41 @@ func TestDiffResourcesByKey_bothNil(t *testing.T) {
 
 func TestDiffCostComponentsByKey_bothNil(t *testing.T) {
 	emptyRMap := make(map[string]*CostComponent)
-	changed, _ := diffCostComponentsByKey("random_resource", emptyRMap, emptyRMap)
+	changed, _ := diffCostComponentsByKey("random_resource", "random_resource", emptyRMap, emptyRMap)
 	assert.Equal(t, false, changed)
 }
+
+func TestDiffCostComponentsByResource_renamed(t *testing.T) {
+	pastRS := &Resource{
+		Name: "aws_instance.web",
+		CostComponents: []*CostComponent{
+			{Name: "Instance usage (Linux/UNIX, on-demand, m5.large)", HourlyQuantity: decimalPtr(decimal.NewFromInt(1)), price: decimal.NewFromFloat(0.096), MonthlyCost: decimalPtr(decimal.NewFromInt(70))},
+			{Name: "Storage (gp2)", MonthlyQuantity: decimalPtr(decimal.NewFromInt(8)), price: decimal.NewFromFloat(0.1), MonthlyCost: decimalPtr(decimal.NewFromFloat(0.8))},
+			{Name: "Storage (gp3)", MonthlyQuantity: decimalPtr(decimal.NewFromInt(8)), price: decimal.NewFromFloat(0.08), MonthlyCost: decimalPtr(decimal.NewFromFloat(0.64))},
+		},
+	}
+	currentRS := &Resource{
+		Name: "aws_instance.web",
+		CostComponents: []*CostComponent{
+			{Name: "Instance usage (Linux/UNIX, on-demand, m5.2xlarge)", HourlyQuantity: decimalPtr(decimal.NewFromInt(1)), price: decimal.NewFromFloat(0.384), MonthlyCost: decimalPtr(decimal.NewFromInt(280))},
+			{Name: "Storage (io1)", MonthlyQuantity: decimalPtr(decimal.NewFromInt(8)), price: decimal.NewFromFloat(0.125), MonthlyCost: decimalPtr(decimal.NewFromInt(1))},
+		},
+	}
+
+	changed, diff := diffCostComponentsByResource(pastRS, currentRS)
+	assert.Equal(t, true, changed)
+
+	names := make([]string, 0, len(diff))
+	for _, c := range diff {
+		names = append(names, c.Name+"|"+c.PastName)
+	}
+
+	// The storage components are ambiguous so they aren't paired
+	assert.Equal(t, []string{
+		"Instance usage (Linux/UNIX, on-demand, m5.2xlarge)|Instance usage (Linux/UNIX, on-demand, m5.large)",
+		"Storage (gp2)|",
+		"Storage (gp3)|",
+		"Storage (io1)|",
+	}, names)
+	assert.Equal(t, "210", diff[0].MonthlyCost.String())
+}
@@ -8,12 +8,18 @@ import (
 )
 
 type ProjectMetadata struct {
-	Path               string `json:"path"`
-	Type               string `json:"type"`
-	VCSRepoURL         string `json:"vcsRepoUrl,omitempty"`
-	VCSSubPath         string `json:"vcsSubPath,omitempty"`
-	VCSPullRequestURL  string `json:"vcsPullRequestUrl,omitempty"`
-	TerraformWorkspace string `json:"terraformWorkspace,omitempty"`
+	Path                 string            `json:"path"`
+	Type                 string            `json:"type"`
+	VCSRepoURL           string            `json:"vcsRepoUrl,omitempty"`
+	VCSSubPath           string            `json:"vcsSubPath,omitempty"`
+	VCSBranch            string            `json:"vcsBranch,omitempty"`
+	VCSCommitSHA         string            `json:"vcsCommitSha,omitempty"`
+	VCSPullRequestURL    string            `json:"vcsPullRequestUrl,omitempty"`
+	VCSPullRequestNumber string            `json:"vcsPullRequestNumber,omitempty"`
+	CIPlatform           string            `json:"ciPlatform,omitempty"`
+	CIPipelineID         string            `json:"ciPipelineId,omitempty"`
+	TerraformWorkspace   string            `json:"terraformWorkspace,omitempty"`
+	Labels               map[string]string `json:"labels,omitempty"`
 }
 
 // Project contains the existing, planned state of
@@ -0,0 +1,94 @@
+package schema
+
+import (
+	"regexp"
+)
+
+// regionVendors are the patterns of the region names of each cloud vendor in
+// the Cloud Pricing API, in the order they're checked.
+var regionVendors = []struct {
+	vendor  string
+	pattern *regexp.Regexp
+}{
+	{"aws", regexp.MustCompile(`^[a-z]{2}(-gov|-iso[a-z]*)?-[a-z]+-\d+$`)},
+	{"gcp", regexp.MustCompile(`^[a-z]+-[a-z]+\d+$`)},
+	{"azure", regexp.MustCompile(`^[a-z]+\d*$`)},
+}
+
+// RegionVendor returns the cloud vendor of a region name, e.g. aws for
+// us-east-1, or an empty string if it isn't a region of a cloud vendor.
+func RegionVendor(region string) string {
+	if region == "global" {
+		return ""
+	}
+
+	for _, v := range regionVendors {
+		if v.pattern.MatchString(region) {
+			return v.vendor
+		}
+	}
+
+	return ""
+}
+
+// InRegion returns a copy of the project with its resources moved to the
+// region, so the same resources can be priced in different regions. Only the
+// cost components of the region's vendor that are priced in a region are
+// moved, e.g. global prices and the prices of other vendors stay the same.
+func (p *Project) InRegion(region string) *Project {
+	vendor := RegionVendor(region)
+
+	c := &Project{
+		Name:          p.Name,
+		PastResources: resourcesInRegion(p.PastResources, vendor, region),
+		Resources:     resourcesInRegion(p.Resources, vendor, region),
+		HasDiff:       p.HasDiff,
+	}
+
+	if p.Metadata != nil {
+		m := *p.Metadata
+		m.Labels = make(map[string]string, len(p.Metadata.Labels))
+		for k, v := range p.Metadata.Labels {
+			m.Labels[k] = v
+		}
+		c.Metadata = &m
+	}
+
+	return c
+}
+
+func resourcesInRegion(resources []*Resource, vendor, region string) []*Resource {
+	if resources == nil {
+		return nil
+	}
+
+	copied := make([]*Resource, 0, len(resources))
+
+	for _, r := range resources {
+		c := *r
+		c.CostComponents = make([]*CostComponent, 0, len(r.CostComponents))
+		for _, cc := range r.CostComponents {
+			c.CostComponents = append(c.CostComponents, costComponentInRegion(cc, vendor, region))
+		}
+		c.SubResources = resourcesInRegion(r.SubResources, vendor, region)
+
+		copied = append(copied, &c)
+	}
+
+	return copied
+}
+
+func costComponentInRegion(cc *CostComponent, vendor, region string) *CostComponent {
+	c := *cc
+
+	f := cc.ProductFilter
+	if f == nil || f.VendorName == nil || *f.VendorName != vendor || f.Region == nil || RegionVendor(*f.Region) != vendor {
+		return &c
+	}
+
+	pf := *f
+	pf.Region = &region
+	c.ProductFilter = &pf
+
+	return &c
+}
@@ -0,0 +1,73 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegionVendor(t *testing.T) {
+	tests := []struct {
+		region string
+		vendor string
+	}{
+		{"us-east-1", "aws"},
+		{"us-gov-west-1", "aws"},
+		{"ap-southeast-2", "aws"},
+		{"us-central1", "gcp"},
+		{"northamerica-northeast1", "gcp"},
+		{"eastus", "azure"},
+		{"eastus2", "azure"},
+		{"global", ""},
+		{"Global", ""},
+		{"US East (N. Virginia)", ""},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.vendor, RegionVendor(test.region), test.region)
+	}
+}
+
+func TestProjectInRegion(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	project := NewProject("my-project", &ProjectMetadata{Labels: map[string]string{"team": "platform"}})
+	project.Resources = []*Resource{
+		{
+			Name: "aws_instance.web",
+			CostComponents: []*CostComponent{
+				{Name: "Instance usage", ProductFilter: &ProductFilter{VendorName: strPtr("aws"), Region: strPtr("us-east-1")}},
+				{Name: "Global usage", ProductFilter: &ProductFilter{VendorName: strPtr("aws"), Region: strPtr("global")}},
+			},
+			SubResources: []*Resource{
+				{
+					Name: "root_block_device",
+					CostComponents: []*CostComponent{
+						{Name: "Storage", ProductFilter: &ProductFilter{VendorName: strPtr("aws"), Region: strPtr("us-east-1")}},
+					},
+				},
+			},
+		},
+		{
+			Name: "google_compute_instance.web",
+			CostComponents: []*CostComponent{
+				{Name: "Instance usage", ProductFilter: &ProductFilter{VendorName: strPtr("gcp"), Region: strPtr("us-central1")}},
+			},
+		},
+	}
+
+	p := project.InRegion("eu-west-1")
+	p.Metadata.Labels["region"] = "eu-west-1"
+
+	require.Len(t, p.Resources, 2)
+	assert.Equal(t, "eu-west-1", *p.Resources[0].CostComponents[0].ProductFilter.Region)
+	assert.Equal(t, "global", *p.Resources[0].CostComponents[1].ProductFilter.Region)
+	assert.Equal(t, "eu-west-1", *p.Resources[0].SubResources[0].CostComponents[0].ProductFilter.Region)
+	assert.Equal(t, "us-central1", *p.Resources[1].CostComponents[0].ProductFilter.Region)
+
+	// The original project isn't changed
+	assert.Equal(t, "us-east-1", *project.Resources[0].CostComponents[0].ProductFilter.Region)
+	assert.Equal(t, "us-east-1", *project.Resources[0].SubResources[0].CostComponents[0].ProductFilter.Region)
+	assert.NotContains(t, project.Metadata.Labels, "region")
+}
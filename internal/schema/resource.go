@@ -2,6 +2,8 @@ package schema
 
 import (
 	"sort"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/shopspring/decimal"
 )
@@ -11,6 +13,14 @@ var HourToMonthUnitMultiplier = 730
 
 type ResourceFunc func(*ResourceData, *UsageData) *Resource
 
+// The reasons that resources are skipped, which are output so tools can tell
+// why a resource wasn't priced.
+const (
+	SkipReasonUnsupported   = "unsupported"
+	SkipReasonFree          = "free"
+	SkipReasonMissingRegion = "missing_region"
+)
+
 type Resource struct {
 	Name           string
 	CostComponents []*CostComponent
@@ -20,9 +30,77 @@ type Resource struct {
 	IsSkipped      bool
 	NoPrice        bool
 	SkipMessage    string
+	SkipReason     string
 	ResourceType   string
 	Tags           map[string]string
-	UsageSchema    []*UsageSchemaItem
+	// SensitiveTags are the keys of the tags whose values come from sensitive
+	// Terraform variables. Their values are redacted in the output.
+	SensitiveTags map[string]bool
+	UsageSchema   []*UsageSchemaItem
+	// PlanActions are the actions of the resource in the Terraform plan, e.g.
+	// [create] or [delete, create] for a resource that's replaced.
+	PlanActions []string
+}
+
+// RedactValues replaces the values in the names of the resource's cost
+// components and sub-resources, e.g. an instance type that comes from a
+// sensitive Terraform variable. The values should be sorted longest first.
+// Only whole tokens are replaced so short values such as "1" or "us" don't
+// corrupt other words in the names, e.g. "us-east-1" or "t3.micro".
+func (r *Resource) RedactValues(values []string) {
+	if len(values) == 0 {
+		return
+	}
+
+	var redact func(r *Resource)
+	redact = func(r *Resource) {
+		for _, c := range r.CostComponents {
+			c.Name = redactTokens(c.Name, values)
+		}
+
+		for _, s := range r.SubResources {
+			s.Name = redactTokens(s.Name, values)
+			redact(s)
+		}
+	}
+
+	redact(r)
+}
+
+// redactTokens replaces the values in s where they aren't part of a longer
+// token, i.e. they aren't next to a letter, digit or one of "_.-".
+func redactTokens(s string, values []string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(s); {
+		if i == 0 || !isTokenByte(s[i-1]) {
+			if v := tokenAt(s[i:], values); v != "" {
+				b.WriteString(RedactedValue)
+				i += len(v)
+				continue
+			}
+		}
+
+		b.WriteByte(s[i])
+		i++
+	}
+
+	return b.String()
+}
+
+// tokenAt returns the first of the values that s starts with as a whole token.
+func tokenAt(s string, values []string) string {
+	for _, v := range values {
+		if v != "" && strings.HasPrefix(s, v) && (len(s) == len(v) || !isTokenByte(s[len(v)])) {
+			return v
+		}
+	}
+
+	return ""
+}
+
+func isTokenByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '.' || c == '-' || c >= utf8.RuneSelf
 }
 
 func CalculateCosts(project *Project) {
@@ -92,6 +170,21 @@ func (r *Resource) RemoveCostComponent(costComponent *CostComponent) {
 	r.CostComponents = n
 }
 
+// SetUnpriced marks the cost components of the resource and its
+// sub-resources that are priced by the pricing API as not priced. The cost
+// components without a product filter have fixed prices so they're kept.
+func (r *Resource) SetUnpriced() {
+	for _, c := range r.CostComponents {
+		if c.ProductFilter != nil {
+			c.SetUnpriced()
+		}
+	}
+
+	for _, s := range r.SubResources {
+		s.SetUnpriced()
+	}
+}
+
 func SortResources(project *Project) {
 	resources := project.AllResources()
 	sort.Slice(resources, func(i, j int) bool {
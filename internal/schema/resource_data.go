@@ -2,17 +2,40 @@ package schema
 
 import (
 	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/tidwall/gjson"
 )
 
+// RedactedValue replaces the values from sensitive Terraform variables in the
+// output and logs.
+const RedactedValue = "REDACTED"
+
+// pathKeyEscaper escapes the characters that have a meaning in gjson paths,
+// so map keys like `kubernetes.io/cluster` can be used as a part of a path.
+var pathKeyEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	".", `\.`,
+	"*", `\*`,
+	"?", `\?`,
+	"|", `\|`,
+	"#", `\#`,
+	"@", `\@`,
+)
+
 type ResourceData struct {
-	Type          string
-	ProviderName  string
-	Address       string
-	Tags          map[string]string
-	RawValues     gjson.Result
-	referencesMap map[string][]*ResourceData
+	Type         string
+	ProviderName string
+	Address      string
+	Tags         map[string]string
+	RawValues    gjson.Result
+	// SensitivePaths are the gjson paths of the values that Terraform marks
+	// as sensitive, e.g. `instance_type` or `tags.Owner`, since they come from
+	// sensitive variables. Their values are redacted in the output and logs.
+	SensitivePaths map[string]bool
+	referencesMap  map[string][]*ResourceData
 }
 
 func NewResourceData(resourceType string, providerName string, address string, tags map[string]string, rawValues gjson.Result) *ResourceData {
@@ -30,6 +53,101 @@ func (d *ResourceData) Get(key string) gjson.Result {
 	return d.RawValues.Get(key)
 }
 
+// IsSensitive checks if the value at the path, or any value that contains it,
+// is sensitive. The parts of the path are the unescaped keys and indexes,
+// e.g. IsSensitive("tags", "kubernetes.io/cluster").
+func (d *ResourceData) IsSensitive(parts ...string) bool {
+	if len(d.SensitivePaths) == 0 {
+		return false
+	}
+
+	escaped := make([]string, 0, len(parts))
+	for _, p := range parts {
+		escaped = append(escaped, pathKeyEscaper.Replace(p))
+		if d.SensitivePaths[strings.Join(escaped, ".")] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SensitiveValues returns the strings of the sensitive values, longest first
+// so that a value that contains another value is redacted as a whole.
+func (d *ResourceData) SensitiveValues() []string {
+	seen := make(map[string]bool)
+	values := make([]string, 0)
+
+	var add func(v gjson.Result)
+	add = func(v gjson.Result) {
+		if v.IsObject() || v.IsArray() {
+			v.ForEach(func(_, val gjson.Result) bool {
+				add(val)
+				return true
+			})
+			return
+		}
+
+		if v.Type == gjson.String && v.String() != "" && !seen[v.String()] {
+			seen[v.String()] = true
+			values = append(values, v.String())
+		}
+	}
+
+	for p := range d.SensitivePaths {
+		add(d.RawValues.Get(p))
+	}
+
+	sort.Slice(values, func(i, j int) bool {
+		if len(values[i]) != len(values[j]) {
+			return len(values[i]) > len(values[j])
+		}
+		return values[i] < values[j]
+	})
+
+	return values
+}
+
+// ParseSensitivePaths returns the paths of the sensitive values of a
+// resource in a Terraform plan. The sensitive values have the same structure
+// as the values, with true for the parts that are sensitive.
+func ParseSensitivePaths(sensitiveValues gjson.Result) map[string]bool {
+	paths := make(map[string]bool)
+
+	var walk func(path string, v gjson.Result)
+	walk = func(path string, v gjson.Result) {
+		switch {
+		case v.Type == gjson.True && path != "":
+			paths[path] = true
+		case v.IsObject():
+			v.ForEach(func(k, val gjson.Result) bool {
+				walk(joinPath(path, pathKeyEscaper.Replace(k.String())), val)
+				return true
+			})
+		case v.IsArray():
+			for i, val := range v.Array() {
+				walk(joinPath(path, strconv.Itoa(i)), val)
+			}
+		}
+	}
+
+	walk("", sensitiveValues)
+
+	if len(paths) == 0 {
+		return nil
+	}
+
+	return paths
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
 func (d *ResourceData) References(key string) []*ResourceData {
 	return d.referencesMap[key]
 }
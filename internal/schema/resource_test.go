@@ -0,0 +1,29 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactValues(t *testing.T) {
+	r := &Resource{
+		CostComponents: []*CostComponent{
+			{Name: "Instance usage (Linux/UNIX, on-demand, t3.micro)"},
+			{Name: "Data transfer to us-east-1 (first 1 GB)"},
+		},
+		SubResources: []*Resource{
+			{
+				Name:           "secret-volume",
+				CostComponents: []*CostComponent{{Name: "Storage (secret-volume, gp2)"}},
+			},
+		},
+	}
+
+	r.RedactValues([]string{"secret-volume", "t3.micro", "us", "1"})
+
+	assert.Equal(t, "Instance usage (Linux/UNIX, on-demand, REDACTED)", r.CostComponents[0].Name)
+	assert.Equal(t, "Data transfer to us-east-1 (first REDACTED GB)", r.CostComponents[1].Name)
+	assert.Equal(t, "REDACTED", r.SubResources[0].Name)
+	assert.Equal(t, "Storage (REDACTED, gp2)", r.SubResources[0].CostComponents[0].Name)
+}
@@ -0,0 +1,118 @@
+package schema
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	RoundingModeHalfUp   = "half_up"
+	RoundingModeHalfEven = "half_even"
+
+	RoundingLevelComponent = "component"
+	RoundingLevelResource  = "resource"
+	RoundingLevelTotal     = "total"
+)
+
+// RoundingPolicy controls how hourly and monthly costs are rounded before they
+// are output. When the level is component each cost component is rounded and
+// the resource totals are the sum of the rounded components, so the totals
+// always match the sum of the lines. When the level is resource only the
+// resource totals are rounded. When the level is total only the project and
+// overall totals are rounded. The totals are always rounded by the output.
+type RoundingPolicy struct {
+	Mode      string
+	Precision int32
+	Level     string
+}
+
+func (p *RoundingPolicy) Validate() error {
+	if p.Mode != RoundingModeHalfUp && p.Mode != RoundingModeHalfEven {
+		return fmt.Errorf("Invalid rounding mode '%s', valid modes are: %s, %s", p.Mode, RoundingModeHalfUp, RoundingModeHalfEven)
+	}
+
+	if p.Level != RoundingLevelComponent && p.Level != RoundingLevelResource && p.Level != RoundingLevelTotal {
+		return fmt.Errorf("Invalid rounding level '%s', valid levels are: %s, %s, %s", p.Level, RoundingLevelComponent, RoundingLevelResource, RoundingLevelTotal)
+	}
+
+	if p.Precision < 0 {
+		return fmt.Errorf("Invalid rounding precision %d, precision must be 0 or more", p.Precision)
+	}
+
+	return nil
+}
+
+// Round rounds the cost to the precision using the rounding mode.
+func (p *RoundingPolicy) Round(d *decimal.Decimal) *decimal.Decimal {
+	if d == nil {
+		return nil
+	}
+
+	if p.Mode == RoundingModeHalfEven {
+		return decimalPtr(d.RoundBank(p.Precision))
+	}
+
+	return decimalPtr(d.Round(p.Precision))
+}
+
+// RoundCosts applies the rounding policy to the hourly and monthly costs of
+// all the resources in the project. CalculateCosts must be called before this.
+func RoundCosts(project *Project, policy *RoundingPolicy) {
+	if policy == nil || policy.Level == RoundingLevelTotal {
+		return
+	}
+
+	for _, r := range project.AllResources() {
+		roundResourceCosts(r, policy)
+	}
+}
+
+func roundResourceCosts(r *Resource, policy *RoundingPolicy) {
+	if policy.Level == RoundingLevelResource {
+		r.HourlyCost = policy.Round(r.HourlyCost)
+		r.MonthlyCost = policy.Round(r.MonthlyCost)
+		return
+	}
+
+	for _, c := range r.CostComponents {
+		c.HourlyCost = policy.Round(c.HourlyCost)
+		c.MonthlyCost = policy.Round(c.MonthlyCost)
+	}
+
+	for _, s := range r.SubResources {
+		roundResourceCosts(s, policy)
+	}
+
+	if r.HourlyCost != nil {
+		h := decimal.Zero
+		for _, c := range r.CostComponents {
+			if c.HourlyCost != nil {
+				h = h.Add(*c.HourlyCost)
+			}
+		}
+		for _, s := range r.SubResources {
+			if s.HourlyCost != nil {
+				h = h.Add(*s.HourlyCost)
+			}
+		}
+
+		r.HourlyCost = &h
+	}
+
+	if r.MonthlyCost != nil {
+		m := decimal.Zero
+		for _, c := range r.CostComponents {
+			if c.MonthlyCost != nil {
+				m = m.Add(*c.MonthlyCost)
+			}
+		}
+		for _, s := range r.SubResources {
+			if s.MonthlyCost != nil {
+				m = m.Add(*s.MonthlyCost)
+			}
+		}
+
+		r.MonthlyCost = &m
+	}
+}
@@ -0,0 +1,79 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func roundingTestProject() *Project {
+	return &Project{
+		Resources: []*Resource{
+			{
+				Name:        "rs1",
+				HourlyCost:  decimalPtr(decimal.RequireFromString("0.00015")),
+				MonthlyCost: decimalPtr(decimal.RequireFromString("0.05")),
+				CostComponents: []*CostComponent{
+					{Name: "cc1", HourlyCost: decimalPtr(decimal.RequireFromString("0.000075")), MonthlyCost: decimalPtr(decimal.RequireFromString("0.025"))},
+					{Name: "cc2", HourlyCost: decimalPtr(decimal.RequireFromString("0.000075")), MonthlyCost: decimalPtr(decimal.RequireFromString("0.025"))},
+				},
+			},
+		},
+	}
+}
+
+func TestRoundCosts_component(t *testing.T) {
+	project := roundingTestProject()
+	RoundCosts(project, &RoundingPolicy{Mode: RoundingModeHalfUp, Level: RoundingLevelComponent, Precision: 2})
+
+	r := project.Resources[0]
+	assert.Equal(t, "0.03", r.CostComponents[0].MonthlyCost.String())
+	assert.Equal(t, "0.03", r.CostComponents[1].MonthlyCost.String())
+	assert.Equal(t, "0.06", r.MonthlyCost.String())
+}
+
+func TestRoundCosts_componentHourly(t *testing.T) {
+	project := roundingTestProject()
+	RoundCosts(project, &RoundingPolicy{Mode: RoundingModeHalfUp, Level: RoundingLevelComponent, Precision: 4})
+
+	r := project.Resources[0]
+	assert.Equal(t, "0.0001", r.CostComponents[0].HourlyCost.String())
+	assert.Equal(t, "0.0002", r.HourlyCost.String())
+}
+
+func TestRoundCosts_componentHalfEven(t *testing.T) {
+	project := roundingTestProject()
+	RoundCosts(project, &RoundingPolicy{Mode: RoundingModeHalfEven, Level: RoundingLevelComponent, Precision: 2})
+
+	r := project.Resources[0]
+	assert.Equal(t, "0.02", r.CostComponents[0].MonthlyCost.String())
+	assert.Equal(t, "0.04", r.MonthlyCost.String())
+}
+
+func TestRoundCosts_resource(t *testing.T) {
+	project := roundingTestProject()
+	RoundCosts(project, &RoundingPolicy{Mode: RoundingModeHalfUp, Level: RoundingLevelResource, Precision: 1})
+
+	r := project.Resources[0]
+	assert.Equal(t, "0.025", r.CostComponents[0].MonthlyCost.String())
+	assert.Equal(t, "0.1", r.MonthlyCost.String())
+	assert.Equal(t, "0", r.HourlyCost.String())
+}
+
+func TestRoundCosts_total(t *testing.T) {
+	project := roundingTestProject()
+	RoundCosts(project, &RoundingPolicy{Mode: RoundingModeHalfUp, Level: RoundingLevelTotal, Precision: 1})
+
+	r := project.Resources[0]
+	assert.Equal(t, "0.025", r.CostComponents[0].MonthlyCost.String())
+	assert.Equal(t, "0.05", r.MonthlyCost.String())
+}
+
+func TestRoundingPolicyValidate(t *testing.T) {
+	assert.NoError(t, (&RoundingPolicy{Mode: RoundingModeHalfUp, Level: RoundingLevelComponent}).Validate())
+	assert.NoError(t, (&RoundingPolicy{Mode: RoundingModeHalfEven, Level: RoundingLevelTotal}).Validate())
+	assert.Error(t, (&RoundingPolicy{Mode: "up", Level: RoundingLevelComponent}).Validate())
+	assert.Error(t, (&RoundingPolicy{Mode: RoundingModeHalfUp, Level: "project"}).Validate())
+	assert.Error(t, (&RoundingPolicy{Mode: RoundingModeHalfUp, Level: RoundingLevelComponent, Precision: -1}).Validate())
+}
@@ -3,8 +3,10 @@ package schema
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
 )
 
@@ -14,6 +16,7 @@ const (
 	Int64 UsageVariableType = iota
 	String
 	Float64
+	Bool
 )
 
 // type UsageDataValidatorFuncType = func(value interface{}) error
@@ -22,23 +25,33 @@ type UsageSchemaItem struct {
 	Key          string
 	DefaultValue interface{}
 	ValueType    UsageVariableType
+	Description  string
 
 	// These aren't used yet and I'm not entirely sure how they fit in, but they were part of the discussion about usage schema.
 	// ValidatorFunc UsageDataValidatorFuncType
 	// SubUsageData  *UsageSchemaItem
-	// Description   string
 }
 
 type UsageData struct {
 	Address    string
 	Attributes map[string]gjson.Result
+
+	// pattern is the compiled address if it has `*` wildcards, so that it's
+	// only compiled once however many resources are looked up.
+	pattern *regexp.Regexp
 }
 
 func NewUsageData(address string, attributes map[string]gjson.Result) *UsageData {
-	return &UsageData{
+	u := &UsageData{
 		Address:    address,
 		Attributes: attributes,
 	}
+
+	if strings.Contains(address, "*") {
+		u.pattern = compileUsageKeyPattern(address)
+	}
+
+	return u
 }
 
 func (u *UsageData) Get(key string) gjson.Result {
@@ -89,6 +102,134 @@ func NewUsageMap(m map[string]interface{}) map[string]*UsageData {
 	return usageMap
 }
 
+// FindUsageData returns the usage data for a resource address. An exact
+// match on the address is preferred, then any usage keys containing `*`
+// wildcards are checked, e.g. `aws_instance.web[*]` or
+// `module.workers[*].aws_instance.*`. If multiple wildcard keys match then
+// the most specific one (the one with the most non-wildcard characters) is used.
+func FindUsageData(usage map[string]*UsageData, addr string) *UsageData {
+	if ud := usage[addr]; ud != nil {
+		return ud
+	}
+
+	var matchKey string
+
+	for k, ud := range usage {
+		if ud == nil || ud.pattern == nil || ud.Address != k || !ud.pattern.MatchString(addr) {
+			continue
+		}
+
+		if matchKey == "" || usageKeySpecificity(k) > usageKeySpecificity(matchKey) ||
+			(usageKeySpecificity(k) == usageKeySpecificity(matchKey) && k < matchKey) {
+			matchKey = k
+		}
+	}
+
+	if matchKey == "" {
+		return nil
+	}
+
+	log.Debugf("Using usage data from %s for %s", matchKey, addr)
+
+	return usage[matchKey]
+}
+
+// MergeUsageMaps returns the usage data of both maps merged key by key, with
+// the usage winning over the defaults. Each entry is merged with the entry
+// of the other map that FindUsageData would use for its address, so that an
+// exact address in one map doesn't hide the wildcard keys of the other, e.g.
+// a default for `aws_instance.*` is still used for the keys that aren't set
+// by `aws_instance.web` in the usage.
+func MergeUsageMaps(defaults map[string]*UsageData, usage map[string]*UsageData) map[string]*UsageData {
+	merged := make(map[string]*UsageData, len(defaults)+len(usage))
+
+	for k, d := range defaults {
+		merged[k] = mergeUsageData(k, d, FindUsageData(usage, k))
+	}
+
+	for k, u := range usage {
+		merged[k] = mergeUsageData(k, FindUsageData(defaults, k), u)
+	}
+
+	return merged
+}
+
+func mergeUsageData(address string, defaults *UsageData, usage *UsageData) *UsageData {
+	attributes := make(map[string]gjson.Result)
+
+	for _, u := range []*UsageData{defaults, usage} {
+		if u == nil {
+			continue
+		}
+
+		for k, v := range u.Attributes {
+			attributes[k] = v
+		}
+	}
+
+	return NewUsageData(address, attributes)
+}
+
+const (
+	// quotedIndexChars matches the characters of a quoted index, which can
+	// have any characters including `.` and escaped quotes.
+	quotedIndexChars = `(?:[^"\\]|\\.)*`
+	// indexValue matches a quoted for_each key or a count index.
+	indexValue = `(?:"` + quotedIndexChars + `"|[^\]"]*)`
+	// addressPartName matches the name of an address part and its index if it has one.
+	addressPartName = `[^.\[]*(?:\[` + indexValue + `\])?`
+)
+
+// compileUsageKeyPattern compiles a usage key with `*` wildcards to a regex
+// that matches resource addresses. A `*` within an index, e.g. `web[*]`,
+// matches any index including quoted keys with dots like
+// `web["www.example.com"]`, a `*` within a quoted index matches any part of
+// the key, otherwise a `*` matches any characters within a single address
+// part so it doesn't match across `.` separators.
+func compileUsageKeyPattern(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+
+	inIndex, inQuotes := false, false
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+
+		switch {
+		case c == '*' && inQuotes:
+			b.WriteString(quotedIndexChars)
+		case c == '*' && inIndex:
+			b.WriteString(indexValue)
+		case c == '*':
+			b.WriteString(addressPartName)
+		default:
+			if c == '\\' && inQuotes && i+1 < len(pattern) {
+				b.WriteString(regexp.QuoteMeta(pattern[i : i+2]))
+				i++
+				continue
+			}
+
+			switch {
+			case c == '"' && inIndex:
+				inQuotes = !inQuotes
+			case c == '[' && !inQuotes:
+				inIndex = true
+			case c == ']' && !inQuotes:
+				inIndex = false
+			}
+
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	b.WriteString("$")
+
+	return regexp.MustCompile(b.String())
+}
+
+func usageKeySpecificity(pattern string) int {
+	return len(strings.ReplaceAll(pattern, "*", ""))
+}
+
 func NewEmptyUsageMap() map[string]*UsageData {
 	return map[string]*UsageData{}
 }
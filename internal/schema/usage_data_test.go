@@ -0,0 +1,97 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindUsageData(t *testing.T) {
+	exact := NewUsageData("aws_instance.web[0]", nil)
+	arrayWildcard := NewUsageData("aws_instance.web[*]", nil)
+	typeWildcard := NewUsageData("aws_lambda_function.*", nil)
+	moduleWildcard := NewUsageData("module.workers[*].aws_instance.node", nil)
+	anyModuleWildcard := NewUsageData("module.*.aws_instance.*", nil)
+	domainWildcard := NewUsageData("aws_route53_record.r[*]", nil)
+	domainPrefixWildcard := NewUsageData(`aws_s3_bucket.b["logs.*"]`, nil)
+
+	usage := map[string]*UsageData{
+		exact.Address:                exact,
+		arrayWildcard.Address:        arrayWildcard,
+		typeWildcard.Address:         typeWildcard,
+		moduleWildcard.Address:       moduleWildcard,
+		anyModuleWildcard.Address:    anyModuleWildcard,
+		domainWildcard.Address:       domainWildcard,
+		domainPrefixWildcard.Address: domainPrefixWildcard,
+	}
+
+	tests := []struct {
+		addr     string
+		expected *UsageData
+	}{
+		{"aws_instance.web[0]", exact},
+		{"aws_instance.web[1]", arrayWildcard},
+		{"aws_lambda_function.hello", typeWildcard},
+		{"aws_lambda_function.hello[\"a\"]", typeWildcard},
+		{"module.workers[2].aws_instance.node", moduleWildcard},
+		{"module.workers[2].aws_instance.other", anyModuleWildcard},
+		{"aws_lambda_function.hello[\"a.b\"]", typeWildcard},
+		{"aws_route53_record.r[\"www.example.com\"]", domainWildcard},
+		{"aws_route53_record.r[3]", domainWildcard},
+		{"aws_route53_record.r.other", nil},
+		{"aws_s3_bucket.b[\"logs.example.com\"]", domainPrefixWildcard},
+		{"aws_s3_bucket.b[\"www.example.com\"]", nil},
+		{"module.dns[\"example.com\"].aws_instance.web", anyModuleWildcard},
+		{"module.lambdas.aws_lambda_function.hello", nil},
+		{"aws_instance.other", nil},
+	}
+
+	for _, test := range tests {
+		actual := FindUsageData(usage, test.addr)
+		assert.Equal(t, test.expected, actual, test.addr)
+	}
+}
+
+func TestMergeUsageMaps(t *testing.T) {
+	defaults := NewUsageMap(map[string]interface{}{
+		"aws_instance.*": map[string]interface{}{
+			"operating_system":  "linux",
+			"monthly_hrs":       730,
+			"reserved_instance": "no_upfront",
+		},
+		"aws_lambda_function.hello": map[string]interface{}{
+			"monthly_requests":    1000,
+			"request_duration_ms": 100,
+		},
+	})
+
+	usage := NewUsageMap(map[string]interface{}{
+		"aws_instance.web": map[string]interface{}{
+			"monthly_hrs": 100,
+		},
+		"aws_lambda_function.*": map[string]interface{}{
+			"monthly_requests": 5000,
+		},
+	})
+
+	merged := MergeUsageMaps(defaults, usage)
+
+	web := FindUsageData(merged, "aws_instance.web")
+	assert.Equal(t, int64(100), web.Get("monthly_hrs").Int())
+	assert.Equal(t, "linux", web.Get("operating_system").String())
+	assert.Equal(t, "no_upfront", web.Get("reserved_instance").String())
+
+	other := FindUsageData(merged, "aws_instance.other")
+	assert.Equal(t, int64(730), other.Get("monthly_hrs").Int())
+
+	hello := FindUsageData(merged, "aws_lambda_function.hello")
+	assert.Equal(t, int64(5000), hello.Get("monthly_requests").Int())
+	assert.Equal(t, int64(100), hello.Get("request_duration_ms").Int())
+
+	world := FindUsageData(merged, "aws_lambda_function.world")
+	assert.Equal(t, int64(5000), world.Get("monthly_requests").Int())
+	assert.False(t, world.Get("request_duration_ms").Exists())
+
+	assert.Equal(t, int64(100), usage["aws_instance.web"].Get("monthly_hrs").Int())
+	assert.False(t, usage["aws_instance.web"].Get("operating_system").Exists())
+}
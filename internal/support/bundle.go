@@ -0,0 +1,381 @@
+// Package support generates support bundles, which collect the diagnostics
+// that are needed to investigate a bug report into a single zip file.
+package support
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/version"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const redacted = "REDACTED"
+
+// healthCheckTimeout is how long to wait for the pricing API's health check.
+const healthCheckTimeout = 10 * time.Second
+
+// secretEnvParts are the parts of environment variable names whose values are
+// likely to be secrets.
+var secretEnvParts = []string{"TOKEN", "SECRET", "KEY", "PASSWORD", "CREDENTIALS"}
+
+// Options are the optional parts of a support bundle.
+type Options struct {
+	// LogFile is the path of a log file to add, e.g. the output of a run with
+	// --log-level debug. It's redacted the same as the rest of the bundle.
+	LogFile string
+	// SkipHealthCheck skips the request to the pricing API, e.g. when there's
+	// no network access.
+	SkipHealthCheck bool
+}
+
+// VersionInfo is the versions of infracost and the tools that it runs.
+type VersionInfo struct {
+	Version          string `json:"version"`
+	GoVersion        string `json:"goVersion"`
+	OS               string `json:"os"`
+	Arch             string `json:"arch"`
+	TerraformBinary  string `json:"terraformBinary"`
+	TerraformVersion string `json:"terraformVersion,omitempty"`
+	TerraformError   string `json:"terraformError,omitempty"`
+}
+
+// PricingDiagnostics describes how prices are fetched and whether the pricing
+// API can be reached.
+type PricingDiagnostics struct {
+	Endpoint          string  `json:"endpoint"`
+	IsDefaultEndpoint bool    `json:"isDefaultEndpoint"`
+	Backend           string  `json:"backend"`
+	Offline           bool    `json:"offline"`
+	HasAPIKey         bool    `json:"hasApiKey"`
+	APITimeout        int     `json:"apiTimeout,omitempty"`
+	APIRetries        *int    `json:"apiRetries,omitempty"`
+	APIRateLimit      float64 `json:"apiRateLimit,omitempty"`
+	SnapshotFile      string  `json:"snapshotFile"`
+	SnapshotExists    bool    `json:"snapshotExists"`
+	SnapshotUpdatedAt string  `json:"snapshotUpdatedAt,omitempty"`
+	HealthCheck       *Check  `json:"healthCheck,omitempty"`
+}
+
+// Check is the result of a request to an API.
+type Check struct {
+	URL        string `json:"url"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+	Error      string `json:"error,omitempty"`
+}
+
+// bundleFile is a file in the support bundle, which is generated when the
+// bundle is written.
+type bundleFile struct {
+	name string
+	data func() ([]byte, error)
+}
+
+// Generate returns a zip file with the version info, the resolved config and
+// environment, the pricing diagnostics and the log file. Secrets such as API
+// keys and tokens are redacted wherever they appear.
+func Generate(cfg *config.Config, opts Options) ([]byte, error) {
+	r := newRedactor(cfg)
+
+	files := []bundleFile{
+		{"version.json", func() ([]byte, error) { return json.MarshalIndent(versionInfo(cfg), "", "  ") }},
+		{"environment.json", func() ([]byte, error) { return json.MarshalIndent(environment(cfg), "", "  ") }},
+		{"config.yml", func() ([]byte, error) { return yaml.Marshal(sanitizedConfig(cfg)) }},
+		{"pricing.json", func() ([]byte, error) { return json.MarshalIndent(pricingDiagnostics(cfg, opts), "", "  ") }},
+	}
+
+	if opts.LogFile != "" {
+		files = append(files, bundleFile{"logs.txt", func() ([]byte, error) {
+			b, err := ioutil.ReadFile(opts.LogFile)
+			return b, errors.Wrap(err, "Error reading log file")
+		}})
+	}
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+
+	for _, f := range files {
+		b, err := f.data()
+		if err != nil {
+			return nil, err
+		}
+
+		fw, err := w.Create(f.name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error adding %s to the support bundle", f.name)
+		}
+
+		_, err = fw.Write([]byte(r.redact(string(b))))
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error adding %s to the support bundle", f.name)
+		}
+	}
+
+	err := w.Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error writing support bundle")
+	}
+
+	return buf.Bytes(), nil
+}
+
+func versionInfo(cfg *config.Config) VersionInfo {
+	binary := ""
+	if cfg.Environment != nil {
+		binary = cfg.Environment.TerraformBinary
+	}
+	if binary == "" && len(cfg.Projects) > 0 {
+		binary = cfg.Projects[0].TerraformBinary
+	}
+	if binary == "" {
+		binary = "terraform"
+	}
+
+	v := VersionInfo{
+		Version:         version.Version,
+		GoVersion:       runtime.Version(),
+		OS:              runtime.GOOS,
+		Arch:            runtime.GOARCH,
+		TerraformBinary: binary,
+	}
+
+	out, err := exec.Command(binary, "-version").Output()
+	if err != nil {
+		v.TerraformError = err.Error()
+	} else {
+		v.TerraformVersion = strings.TrimSpace(strings.SplitN(string(out), "\n", 2)[0])
+	}
+
+	return v
+}
+
+func environment(cfg *config.Config) map[string]interface{} {
+	env := make(map[string]string)
+
+	for _, kv := range os.Environ() {
+		parts := strings.SplitN(kv, "=", 2)
+		k := parts[0]
+		if !strings.HasPrefix(k, "INFRACOST_") && !strings.HasPrefix(k, "TF_") {
+			continue
+		}
+
+		v := ""
+		if len(parts) > 1 {
+			v = parts[1]
+		}
+		if isSecretEnvVar(k) && v != "" {
+			v = redacted
+		}
+		env[k] = v
+	}
+
+	return map[string]interface{}{
+		"environment": cfg.Environment,
+		"envVars":     env,
+	}
+}
+
+func isSecretEnvVar(k string) bool {
+	// Terraform variables can contain anything
+	if strings.HasPrefix(k, "TF_VAR_") {
+		return true
+	}
+
+	for _, part := range secretEnvParts {
+		if strings.Contains(k, part) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sanitizedConfig returns a copy of the config without the secrets of its
+// projects, outputs and webhooks. The global secrets, state and credentials aren't
+// marshaled.
+func sanitizedConfig(cfg *config.Config) config.Config {
+	c := *cfg
+
+	c.Projects = make([]*config.Project, 0, len(cfg.Projects))
+	for _, p := range cfg.Projects {
+		pc := *p
+		pc.TerraformCloudToken = redactValue(pc.TerraformCloudToken)
+		pc.TerraformVars = redactMap(p.TerraformVars)
+		pc.Env = redactMap(p.Env)
+		c.Projects = append(c.Projects, &pc)
+	}
+
+	c.Outputs = make([]*config.Output, 0, len(cfg.Outputs))
+	for _, o := range cfg.Outputs {
+		oc := *o
+		oc.Headers = make(map[string]string, len(o.Headers))
+		for k, v := range o.Headers {
+			oc.Headers[k] = redactValue(v)
+		}
+		c.Outputs = append(c.Outputs, &oc)
+	}
+
+	c.Webhooks = make([]*config.Webhook, 0, len(cfg.Webhooks))
+	for _, w := range cfg.Webhooks {
+		wc := *w
+		wc.Secret = redactValue(wc.Secret)
+		wc.Headers = make(map[string]string, len(w.Headers))
+		for k, v := range w.Headers {
+			wc.Headers[k] = redactValue(v)
+		}
+		c.Webhooks = append(c.Webhooks, &wc)
+	}
+
+	return c
+}
+
+// redactMap returns a copy of the map with its values redacted, since the
+// Terraform vars and environment of a project can contain anything.
+func redactMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+
+	redactedMap := make(map[string]string, len(m))
+	for k, v := range m {
+		redactedMap[k] = redactValue(v)
+	}
+
+	return redactedMap
+}
+
+func redactValue(v string) string {
+	if v == "" {
+		return ""
+	}
+
+	return redacted
+}
+
+func pricingDiagnostics(cfg *config.Config, opts Options) PricingDiagnostics {
+	backend := cfg.PricingBackend
+	if backend == "" {
+		backend = "graphql"
+	}
+
+	d := PricingDiagnostics{
+		Endpoint:          cfg.PricingAPIEndpoint,
+		IsDefaultEndpoint: cfg.PricingAPIEndpoint == cfg.DefaultPricingAPIEndpoint,
+		Backend:           backend,
+		Offline:           cfg.Offline,
+		HasAPIKey:         cfg.APIKey != "",
+		APITimeout:        cfg.APITimeout,
+		APIRetries:        cfg.APIRetries,
+		APIRateLimit:      cfg.APIRateLimit,
+		SnapshotFile:      config.PricingSnapshotFilePath(),
+	}
+
+	if info, err := os.Stat(d.SnapshotFile); err == nil {
+		d.SnapshotExists = true
+		d.SnapshotUpdatedAt = info.ModTime().UTC().Format(time.RFC3339)
+	}
+
+	if !opts.SkipHealthCheck && !cfg.Offline {
+		d.HealthCheck = healthCheck(fmt.Sprintf("%s/health", cfg.PricingAPIEndpoint))
+	}
+
+	return d
+}
+
+func healthCheck(url string) *Check {
+	c := &Check{URL: url}
+
+	client := http.Client{Timeout: healthCheckTimeout}
+	start := time.Now()
+	resp, err := client.Get(url)
+	c.DurationMs = time.Since(start).Milliseconds()
+
+	if err != nil {
+		c.Error = err.Error()
+		return c
+	}
+	defer resp.Body.Close()
+
+	c.StatusCode = resp.StatusCode
+
+	return c
+}
+
+// redactor replaces the secret values of the config wherever they appear, e.g.
+// in the log file or in the URLs of outputs.
+type redactor struct {
+	secrets []string
+}
+
+func newRedactor(cfg *config.Config) *redactor {
+	secrets := []string{cfg.APIKey, cfg.WebhookSecret, cfg.GitHubAppPrivateKey, cfg.SlackWebhookURL, cfg.TeamsWebhookURL, cfg.BitbucketToken, cfg.AzureReposToken, cfg.RunTaskHMACKey, cfg.GitHubToken, cfg.GitHubWebhookSecret}
+
+	for _, p := range cfg.Credentials {
+		secrets = append(secrets, p.APIKey)
+	}
+
+	for _, p := range cfg.Projects {
+		secrets = append(secrets, p.TerraformCloudToken)
+		for _, v := range p.TerraformVars {
+			secrets = append(secrets, v)
+		}
+		for _, v := range p.Env {
+			secrets = append(secrets, v)
+		}
+	}
+
+	for _, o := range cfg.Outputs {
+		for _, v := range o.Headers {
+			secrets = append(secrets, v)
+		}
+	}
+
+	for _, w := range cfg.Webhooks {
+		secrets = append(secrets, w.Secret)
+		for _, v := range w.Headers {
+			secrets = append(secrets, v)
+		}
+	}
+
+	for _, kv := range os.Environ() {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) == 2 && isSecretEnvVar(parts[0]) {
+			secrets = append(secrets, parts[1])
+		}
+	}
+
+	// Replace the longest secrets first so a secret that contains another one
+	// is fully replaced
+	sort.Slice(secrets, func(i, j int) bool {
+		return len(secrets[i]) > len(secrets[j])
+	})
+
+	return &redactor{secrets: secrets}
+}
+
+func (r *redactor) redact(s string) string {
+	for _, secret := range r.secrets {
+		// Short values are likely to be flags or numbers rather than secrets,
+		// and replacing them would corrupt the rest of the bundle
+		if len(secret) < 8 {
+			continue
+		}
+
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+
+	return s
+}
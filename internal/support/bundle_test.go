@@ -0,0 +1,83 @@
+package support
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+)
+
+func readBundle(t *testing.T, b []byte) map[string]string {
+	r, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	require.NoError(t, err)
+
+	files := make(map[string]string)
+	for _, f := range r.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		contents, err := ioutil.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(contents)
+	}
+
+	return files
+}
+
+func TestGenerateRedactsSecrets(t *testing.T) {
+	os.Setenv("INFRACOST_TEST_SECRET", "env-secret-value")
+	os.Setenv("INFRACOST_TEST_LOG_LEVEL", "debug")
+	defer os.Unsetenv("INFRACOST_TEST_SECRET")
+	defer os.Unsetenv("INFRACOST_TEST_LOG_LEVEL")
+
+	cfg := config.DefaultConfig()
+	cfg.APIKey = "ico-my-secret-api-key"
+	cfg.Projects = []*config.Project{{
+		Path:                "infra",
+		TerraformCloudToken: "tfc-secret-token",
+		TerraformVars:       map[string]string{"db_password": "tfvar-secret-value"},
+		Env:                 map[string]string{"AWS_PROFILE": "project-env-value"},
+	}}
+	cfg.Outputs = []*config.Output{{Format: "json", Destination: "https://example.com/costs", Headers: map[string]string{"Authorization": "Bearer header-secret"}}}
+
+	logFile := filepath.Join(t.TempDir(), "infracost.log")
+	logs := "level=debug msg=\"Using API key ico-my-secret-api-key\"\n"
+	logs += "level=info msg=\"Running command: terraform plan -var=db_password=tfvar-secret-value\"\n"
+	require.NoError(t, ioutil.WriteFile(logFile, []byte(logs), 0600))
+
+	b, err := Generate(cfg, Options{LogFile: logFile, SkipHealthCheck: true})
+	require.NoError(t, err)
+
+	files := readBundle(t, b)
+	assert.Len(t, files, 5)
+	for _, name := range []string{"version.json", "environment.json", "config.yml", "pricing.json", "logs.txt"} {
+		assert.Contains(t, files, name)
+	}
+
+	for name, contents := range files {
+		for _, secret := range []string{"ico-my-secret-api-key", "tfc-secret-token", "header-secret", "env-secret-value", "tfvar-secret-value", "project-env-value"} {
+			assert.NotContains(t, contents, secret, name)
+		}
+	}
+
+	assert.Contains(t, files["logs.txt"], "Using API key REDACTED")
+	assert.Contains(t, files["config.yml"], "path: infra")
+	assert.Contains(t, files["config.yml"], "db_password: REDACTED")
+	assert.Contains(t, files["config.yml"], "AWS_PROFILE: REDACTED")
+	assert.Contains(t, files["environment.json"], `"INFRACOST_TEST_LOG_LEVEL": "debug"`)
+	assert.Contains(t, files["environment.json"], `"INFRACOST_TEST_SECRET": "REDACTED"`)
+	assert.Contains(t, files["pricing.json"], `"hasApiKey": true`)
+	assert.NotContains(t, files["pricing.json"], "healthCheck")
+}
+
+func TestGenerateMissingLogFile(t *testing.T) {
+	_, err := Generate(config.DefaultConfig(), Options{LogFile: filepath.Join(t.TempDir(), "missing.log"), SkipHealthCheck: true})
+	assert.Error(t, err)
+}
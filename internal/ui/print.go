@@ -4,12 +4,13 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/infracost/infracost/internal/i18n"
 	"github.com/infracost/infracost/internal/version"
 	"github.com/spf13/cobra"
 )
 
 func PrintSuccess(msg string) {
-	fmt.Fprintf(os.Stderr, "%s %s\n", SuccessString("Success:"), msg)
+	fmt.Fprintf(os.Stderr, "%s %s\n", SuccessString(i18n.T("Success:")), msg)
 }
 
 func PrintSuccessf(msg string, a ...interface{}) {
@@ -17,7 +18,7 @@ func PrintSuccessf(msg string, a ...interface{}) {
 }
 
 func PrintError(msg string) {
-	fmt.Fprintf(os.Stderr, "%s %s\n", ErrorString("Error:"), msg)
+	fmt.Fprintf(os.Stderr, "%s %s\n", ErrorString(i18n.T("Error:")), msg)
 }
 
 func PrintErrorf(msg string, a ...interface{}) {
@@ -25,7 +26,7 @@ func PrintErrorf(msg string, a ...interface{}) {
 }
 
 func PrintWarning(msg string) {
-	fmt.Fprintf(os.Stderr, "%s %s\n", WarningString("Warning:"), msg)
+	fmt.Fprintf(os.Stderr, "%s %s\n", WarningString(i18n.T("Warning:")), msg)
 }
 
 func PrintWarningf(msg string, a ...interface{}) {
@@ -42,12 +43,12 @@ func PrintUsageErrorAndExit(cmd *cobra.Command, msg string) {
 
 func PrintUnexpectedError(err interface{}, stack string) {
 	msg := fmt.Sprintf("\n%s %s\n\n%s\n%s\nEnvironment:\n%s\n\n%s %s\n",
-		ErrorString("Error:"),
-		"An unexpected error occurred",
+		ErrorString(i18n.T("Error:")),
+		i18n.T("An unexpected error occurred"),
 		err,
 		stack,
 		fmt.Sprintf("Infracost %s", version.Version),
-		"Please copy the above output and create a new issue at",
+		i18n.T("Please copy the above output and create a new issue at"),
 		LinkString("https://github.com/infracost/infracost/issues/new"),
 	)
 
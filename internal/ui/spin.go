@@ -4,12 +4,18 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"sync"
 	"time"
 
 	spinnerpkg "github.com/briandowns/spinner"
+	"github.com/dustin/go-humanize"
 	log "github.com/sirupsen/logrus"
 )
 
+// showElapsedAfter is how long a spinner runs before the elapsed time is
+// shown, so long running steps don't look like they've hung.
+var showElapsedAfter = 10 * time.Second
+
 type SpinnerOptions struct {
 	EnableLogging bool
 	NoColor       bool
@@ -17,9 +23,14 @@ type SpinnerOptions struct {
 }
 
 type Spinner struct {
-	spinner *spinnerpkg.Spinner
-	msg     string
-	opts    SpinnerOptions
+	spinner   *spinnerpkg.Spinner
+	msg       string
+	opts      SpinnerOptions
+	startedAt time.Time
+
+	mu             sync.Mutex
+	progress       string
+	lastLoggedTens int
 }
 
 func NewSpinner(msg string, opts SpinnerOptions) *Spinner {
@@ -28,9 +39,10 @@ func NewSpinner(msg string, opts SpinnerOptions) *Spinner {
 		spinnerCharNumb = 9
 	}
 	s := &Spinner{
-		spinner: spinnerpkg.New(spinnerpkg.CharSets[spinnerCharNumb], 100*time.Millisecond, spinnerpkg.WithWriter(os.Stderr)),
-		msg:     msg,
-		opts:    opts,
+		spinner:   spinnerpkg.New(spinnerpkg.CharSets[spinnerCharNumb], 100*time.Millisecond, spinnerpkg.WithWriter(os.Stderr)),
+		msg:       msg,
+		opts:      opts,
+		startedAt: time.Now(),
 	}
 
 	if s.opts.EnableLogging {
@@ -38,6 +50,9 @@ func NewSpinner(msg string, opts SpinnerOptions) *Spinner {
 	} else {
 		s.spinner.Prefix = opts.Indent
 		s.spinner.Suffix = fmt.Sprintf(" %s", msg)
+		s.spinner.PreUpdate = func(sp *spinnerpkg.Spinner) {
+			sp.Suffix = fmt.Sprintf(" %s", s.status())
+		}
 		if !s.opts.NoColor {
 			_ = s.spinner.Color("fgHiCyan", "bold")
 		}
@@ -47,6 +62,44 @@ func NewSpinner(msg string, opts SpinnerOptions) *Spinner {
 	return s
 }
 
+// SetProgress shows how many of the total items of the step are done after
+// its message, e.g. (priced 412/1,860 resources). The verb and noun describe
+// the items. It's safe to call from multiple goroutines.
+func (s *Spinner) SetProgress(verb string, noun string, done int, total int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.progress = fmt.Sprintf("%s %s/%s %s", verb, humanize.Comma(int64(done)), humanize.Comma(int64(total)), noun)
+
+	// Log every 10% so the progress isn't lost in the logs
+	if s.opts.EnableLogging && total > 0 {
+		tens := done * 10 / total
+		if tens > s.lastLoggedTens {
+			s.lastLoggedTens = tens
+			log.Infof("progress: %s (%s)", s.msg, s.progress)
+		}
+	}
+}
+
+func (s *Spinner) status() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	details := s.progress
+	if elapsed := time.Since(s.startedAt); elapsed >= showElapsedAfter {
+		if details != "" {
+			details += ", "
+		}
+		details += elapsed.Truncate(time.Second).String()
+	}
+
+	if details == "" {
+		return s.msg
+	}
+
+	return fmt.Sprintf("%s (%s)", s.msg, details)
+}
+
 func (s *Spinner) Stop() {
 	s.spinner.Stop()
 }
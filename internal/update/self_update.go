@@ -0,0 +1,213 @@
+package update
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/mod/semver"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/version"
+)
+
+// executablePath is the path of the binary that is replaced, it's a variable
+// so it can be changed in tests.
+var executablePath = os.Executable
+
+// downloadClient is used to download the release archives and checksums. The
+// timeout is long enough to download an archive on a slow connection.
+var downloadClient = &http.Client{Timeout: 5 * time.Minute}
+
+// SelfUpdate replaces the running binary with the latest release of the
+// channel. The release archive is verified against its published checksum
+// and the binary is replaced with a rename, so the binary is never left half
+// written. It returns the version that was installed, or an empty string if
+// the current version is already the latest.
+func SelfUpdate(cfg *config.Config, channel string) (string, error) {
+	if cfg.Offline {
+		return "", errors.New("Self-update is not available in offline mode")
+	}
+
+	if cfg.SkipUpdateCheck {
+		return "", errors.New("Self-update is disabled by the skip_update_check setting or INFRACOST_SKIP_UPDATE_CHECK environment variable")
+	}
+
+	if runtime.GOOS == "windows" {
+		return "", errors.New("Self-update is not supported on Windows, go to https://www.infracost.io/docs/update for instructions")
+	}
+
+	isBrew, err := isBrewInstall()
+	if err != nil {
+		log.Debugf("error checking if executable was installed via brew: %v", err)
+	}
+	if isBrew {
+		return "", errors.New("Infracost was installed with Homebrew, run brew upgrade infracost instead")
+	}
+
+	latestVersion, err := getLatestGitHubVersion(channel)
+	if err != nil {
+		return "", errors.Wrap(err, "Error getting the latest version")
+	}
+
+	if semver.Compare(version.Version, latestVersion) >= 0 {
+		return "", nil
+	}
+
+	binary, err := downloadRelease(latestVersion, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return "", err
+	}
+
+	exe, err := executablePath()
+	if err != nil {
+		return "", errors.Wrap(err, "Error finding infracost executable")
+	}
+
+	err = replaceExecutable(exe, binary)
+	if err != nil {
+		return "", err
+	}
+
+	return latestVersion, nil
+}
+
+// downloadRelease downloads the release archive for the OS and architecture,
+// verifies its checksum and returns the binary from it.
+func downloadRelease(tag string, goos string, goarch string) ([]byte, error) {
+	name := fmt.Sprintf("infracost-%s-%s", goos, goarch)
+	archiveURL := fmt.Sprintf("%s/%s/%s.tar.gz", githubDownloadURL, tag, name)
+
+	log.Infof("Downloading %s", archiveURL)
+
+	archive, err := download(archiveURL)
+	if err != nil {
+		return nil, err
+	}
+
+	checksum, err := download(archiveURL + ".sha256")
+	if err != nil {
+		return nil, err
+	}
+
+	err = verifyChecksum(archive, checksum)
+	if err != nil {
+		return nil, err
+	}
+
+	return extractBinary(archive, name)
+}
+
+func download(url string) ([]byte, error) {
+	resp, err := downloadClient.Get(url) // nolint:gosec
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error downloading %s", url)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Error downloading %s: %s", url, resp.Status)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error downloading %s", url)
+	}
+
+	return b, nil
+}
+
+// verifyChecksum checks the data against a sha256sum file, which contains
+// the hex encoded hash followed by the file name.
+func verifyChecksum(data []byte, checksumFile []byte) error {
+	fields := strings.Fields(string(checksumFile))
+	if len(fields) == 0 {
+		return errors.New("Invalid checksum file for the release")
+	}
+
+	sum := sha256.Sum256(data)
+	if !strings.EqualFold(fields[0], hex.EncodeToString(sum[:])) {
+		return errors.New("The checksum of the downloaded release does not match, the binary was not replaced")
+	}
+
+	return nil
+}
+
+func extractBinary(archive []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading the release archive")
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "Error reading the release archive")
+		}
+
+		if h.Typeflag == tar.TypeReg && filepath.Base(h.Name) == name {
+			return ioutil.ReadAll(tr)
+		}
+	}
+
+	return nil, fmt.Errorf("The release archive does not contain %s", name)
+}
+
+// replaceExecutable writes the binary to a temporary file next to the
+// executable and renames it over the executable, which is atomic on the same
+// file system.
+func replaceExecutable(exe string, binary []byte) error {
+	path, err := filepath.EvalSymlinks(exe)
+	if err != nil {
+		return errors.Wrap(err, "Error evaluating infracost executable symlink")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return errors.Wrap(err, "Error finding infracost executable")
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".infracost-update-")
+	if err != nil {
+		return errors.Wrapf(err, "Error replacing %s, you might need to run this with sudo", path)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(binary)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Wrap(err, "Error writing the new infracost binary")
+	}
+
+	err = os.Chmod(tmp.Name(), info.Mode().Perm())
+	if err != nil {
+		return errors.Wrap(err, "Error writing the new infracost binary")
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		return errors.Wrapf(err, "Error replacing %s, you might need to run this with sudo", path)
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package update
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/version"
+)
+
+func releaseArchive(t *testing.T, name string, content []byte) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg})
+	require.NoError(t, err)
+	_, err = tw.Write(content)
+	require.NoError(t, err)
+
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	return buf.Bytes()
+}
+
+func setupReleaseServer(t *testing.T, checksum string) {
+	name := fmt.Sprintf("infracost-%s-%s", runtime.GOOS, runtime.GOARCH)
+	archive := releaseArchive(t, name, []byte("new binary"))
+
+	if checksum == "" {
+		sum := sha256.Sum256(archive)
+		checksum = hex.EncodeToString(sum[:])
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/releases/latest", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"tag_name": "v0.10.0"}`)
+	})
+	mux.HandleFunc("/api/releases", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"tag_name": "v0.11.0", "draft": true},
+			{"tag_name": "v0.10.0"},
+			{"tag_name": "v0.10.1-beta.1", "prerelease": true}
+		]`)
+	})
+	mux.HandleFunc("/download/", func(w http.ResponseWriter, r *http.Request) {
+		switch filepath.Base(r.URL.Path) {
+		case name + ".tar.gz":
+			_, _ = w.Write(archive)
+		case name + ".tar.gz.sha256":
+			fmt.Fprintf(w, "%s  %s.tar.gz\n", checksum, name)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	origAPIURL, origDownloadURL, origVersion, origExecutablePath := githubAPIURL, githubDownloadURL, version.Version, executablePath
+	t.Cleanup(func() {
+		githubAPIURL, githubDownloadURL, version.Version, executablePath = origAPIURL, origDownloadURL, origVersion, origExecutablePath
+	})
+
+	githubAPIURL = ts.URL + "/api"
+	githubDownloadURL = ts.URL + "/download"
+	version.Version = "v0.9.0"
+}
+
+func setupExecutable(t *testing.T) string {
+	exe := filepath.Join(t.TempDir(), "infracost")
+	err := os.WriteFile(exe, []byte("old binary"), 0755)
+	require.NoError(t, err)
+
+	executablePath = func() (string, error) {
+		return exe, nil
+	}
+
+	return exe
+}
+
+func TestGetLatestGitHubVersion(t *testing.T) {
+	setupReleaseServer(t, "")
+
+	v, err := getLatestGitHubVersion(ChannelStable)
+	require.NoError(t, err)
+	assert.Equal(t, "v0.10.0", v)
+
+	v, err = getLatestGitHubVersion(ChannelPrerelease)
+	require.NoError(t, err)
+	assert.Equal(t, "v0.10.1-beta.1", v)
+}
+
+func TestSelfUpdate(t *testing.T) {
+	setupReleaseServer(t, "")
+	exe := setupExecutable(t)
+
+	v, err := SelfUpdate(config.DefaultConfig(), ChannelStable)
+	require.NoError(t, err)
+	assert.Equal(t, "v0.10.0", v)
+
+	b, err := os.ReadFile(exe)
+	require.NoError(t, err)
+	assert.Equal(t, "new binary", string(b))
+
+	info, err := os.Stat(exe)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+
+	// Nothing is left behind in the directory
+	entries, err := os.ReadDir(filepath.Dir(exe))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestSelfUpdateAlreadyLatest(t *testing.T) {
+	setupReleaseServer(t, "")
+	exe := setupExecutable(t)
+	version.Version = "v0.10.0"
+
+	v, err := SelfUpdate(config.DefaultConfig(), ChannelStable)
+	require.NoError(t, err)
+	assert.Equal(t, "", v)
+
+	b, err := os.ReadFile(exe)
+	require.NoError(t, err)
+	assert.Equal(t, "old binary", string(b))
+}
+
+func TestSelfUpdateChecksumMismatch(t *testing.T) {
+	setupReleaseServer(t, "0000000000000000000000000000000000000000000000000000000000000000")
+	exe := setupExecutable(t)
+
+	_, err := SelfUpdate(config.DefaultConfig(), ChannelStable)
+	assert.EqualError(t, err, "The checksum of the downloaded release does not match, the binary was not replaced")
+
+	b, err := os.ReadFile(exe)
+	require.NoError(t, err)
+	assert.Equal(t, "old binary", string(b))
+}
+
+func TestSelfUpdateDisabled(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.SkipUpdateCheck = true
+
+	_, err := SelfUpdate(cfg, ChannelStable)
+	assert.Error(t, err)
+
+	cfg = config.DefaultConfig()
+	cfg.Offline = true
+
+	_, err = SelfUpdate(cfg, ChannelStable)
+	assert.Error(t, err)
+}
+
+func TestParseChannel(t *testing.T) {
+	c, err := ParseChannel("")
+	require.NoError(t, err)
+	assert.Equal(t, ChannelStable, c)
+
+	c, err = ParseChannel("Prerelease")
+	require.NoError(t, err)
+	assert.Equal(t, ChannelPrerelease, c)
+
+	_, err = ParseChannel("nightly")
+	assert.Error(t, err)
+}
@@ -21,18 +21,48 @@ import (
 	"github.com/infracost/infracost/internal/version"
 )
 
+const (
+	ChannelStable     = "stable"
+	ChannelPrerelease = "prerelease"
+)
+
+var (
+	githubAPIURL      = "https://api.github.com/repos/infracost/infracost"
+	githubDownloadURL = "https://github.com/infracost/infracost/releases/download"
+)
+
+// httpClient is used to check the latest versions.
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
 type Info struct {
 	LatestVersion string
 	Cmd           string
 }
 
+// ParseChannel returns the release channel, which defaults to stable.
+func ParseChannel(channel string) (string, error) {
+	switch strings.ToLower(channel) {
+	case "", ChannelStable:
+		return ChannelStable, nil
+	case ChannelPrerelease:
+		return ChannelPrerelease, nil
+	}
+
+	return "", fmt.Errorf("Invalid update channel %s, valid channels are %s and %s", channel, ChannelStable, ChannelPrerelease)
+}
+
 func CheckForUpdate(cfg *config.Config) (*Info, error) {
 	if skipUpdateCheck(cfg) {
 		return nil, nil
 	}
 
+	channel, err := ParseChannel(cfg.UpdateChannel)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check cache for the latest version
-	cachedLatestVersion, err := checkCachedLatestVersion(cfg)
+	cachedLatestVersion, err := checkCachedLatestVersion(cfg, channel)
 	if err != nil {
 		log.Debugf("error getting cached latest version: %v", err)
 	}
@@ -54,7 +84,10 @@ func CheckForUpdate(cfg *config.Config) (*Info, error) {
 	} else {
 		cmd = "Go to https://www.infracost.io/docs/update for instructions"
 		if runtime.GOOS == "linux" || runtime.GOOS == "darwin" {
-			cmd = "$ curl -fsSL https://raw.githubusercontent.com/infracost/infracost/master/scripts/install.sh | sh"
+			cmd = "$ infracost self-update"
+			if channel != ChannelStable {
+				cmd += fmt.Sprintf(" --channel %s", channel)
+			}
 		}
 	}
 
@@ -62,9 +95,10 @@ func CheckForUpdate(cfg *config.Config) (*Info, error) {
 	latestVersion := cachedLatestVersion
 	if latestVersion == "" {
 		if isBrew {
+			// Homebrew only has stable releases
 			latestVersion, err = getLatestBrewVersion()
 		} else {
-			latestVersion, err = getLatestGitHubVersion()
+			latestVersion, err = getLatestGitHubVersion(channel)
 		}
 		if err != nil {
 			return nil, err
@@ -73,7 +107,7 @@ func CheckForUpdate(cfg *config.Config) (*Info, error) {
 
 	// Save the latest version in the cache
 	if latestVersion != cachedLatestVersion {
-		err := setCachedLatestVersion(cfg, latestVersion)
+		err := setCachedLatestVersion(cfg, channel, latestVersion)
 		if err != nil {
 			log.Debugf("error saving cached latest version: %v", err)
 		}
@@ -90,7 +124,7 @@ func CheckForUpdate(cfg *config.Config) (*Info, error) {
 }
 
 func skipUpdateCheck(cfg *config.Config) bool {
-	return cfg.SkipUpdateCheck || cfg.Environment.IsTest || cfg.Environment.IsDev
+	return cfg.SkipUpdateCheck || cfg.Offline || cfg.Environment.IsTest || cfg.Environment.IsDev
 }
 
 func isBrewInstall() (bool, error) {
@@ -137,7 +171,7 @@ func getLatestBrewVersion() (string, error) {
 		Versions versionsResp `json:"versions"`
 	}
 
-	resp, err := http.Get("https://formulae.brew.sh/api/formula/infracost.json")
+	resp, err := httpClient.Get("https://formulae.brew.sh/api/formula/infracost.json")
 	if err != nil {
 		return "", err
 	}
@@ -161,40 +195,90 @@ func getLatestBrewVersion() (string, error) {
 	return v, nil
 }
 
-func getLatestGitHubVersion() (string, error) {
-	type releaseResp struct {
-		TagName string `json:"tag_name"`
+type githubRelease struct {
+	TagName    string `json:"tag_name"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+// getLatestGitHubVersion returns the latest release of the channel. The
+// prerelease channel includes the stable releases, so it's the version of
+// whichever was released last.
+func getLatestGitHubVersion(channel string) (string, error) {
+	if channel != ChannelPrerelease {
+		var release githubRelease
+		if err := getGitHubJSON(fmt.Sprintf("%s/releases/latest", githubAPIURL), &release); err != nil {
+			return "", err
+		}
+
+		return normalizeVersion(release.TagName), nil
 	}
 
-	resp, err := http.Get("https://api.github.com/repos/infracost/infracost/releases/latest")
-	if err != nil {
+	var releases []githubRelease
+	if err := getGitHubJSON(fmt.Sprintf("%s/releases?per_page=30", githubAPIURL), &releases); err != nil {
 		return "", err
 	}
+
+	latest := ""
+	for _, r := range releases {
+		if r.Draft {
+			continue
+		}
+
+		v := normalizeVersion(r.TagName)
+		if semver.IsValid(v) && (latest == "" || semver.Compare(v, latest) > 0) {
+			latest = v
+		}
+	}
+
+	if latest == "" {
+		return "", errors.New("No releases found")
+	}
+
+	return latest, nil
+}
+
+func getGitHubJSON(url string, v interface{}) error {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return err
+	}
 	defer resp.Body.Close()
 
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	var parsedResp *releaseResp
-	if json.Unmarshal(body, &parsedResp) != nil {
-		return "", err
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Error getting releases from GitHub: %s", resp.Status)
 	}
 
-	v := parsedResp.TagName
+	return json.Unmarshal(body, v)
+}
+
+func normalizeVersion(v string) string {
 	if !strings.HasPrefix(v, "v") {
 		v = fmt.Sprintf("v%s", v)
 	}
 
-	return v, nil
+	return v
 }
 
-func checkCachedLatestVersion(cfg *config.Config) (string, error) {
+func checkCachedLatestVersion(cfg *config.Config, channel string) (string, error) {
 	if cfg.State.LatestReleaseCheckedAt == "" {
 		return "", nil
 	}
 
+	// The cache was for a different channel
+	cachedChannel := cfg.State.LatestReleaseChannel
+	if cachedChannel == "" {
+		cachedChannel = ChannelStable
+	}
+	if cachedChannel != channel {
+		return "", nil
+	}
+
 	checkedAt, err := time.Parse(time.RFC3339, cfg.State.LatestReleaseCheckedAt)
 	if err != nil {
 		return "", err
@@ -207,7 +291,8 @@ func checkCachedLatestVersion(cfg *config.Config) (string, error) {
 	return cfg.State.LatestReleaseVersion, nil
 }
 
-func setCachedLatestVersion(cfg *config.Config, latestVersion string) error {
+func setCachedLatestVersion(cfg *config.Config, channel string, latestVersion string) error {
+	cfg.State.LatestReleaseChannel = channel
 	cfg.State.LatestReleaseVersion = latestVersion
 	cfg.State.LatestReleaseCheckedAt = time.Now().Format(time.RFC3339)
 
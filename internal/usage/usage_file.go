@@ -1,18 +1,23 @@
 package usage
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/infracost/infracost"
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
 	"golang.org/x/mod/semver"
 	"gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
 )
 
 const minUsageFileVersion = "0.1"
@@ -23,12 +28,6 @@ type UsageFile struct { // nolint:golint
 	ResourceUsage map[string]interface{} `yaml:"resource_usage"`
 }
 
-type SchemaItem struct {
-	Key          string
-	ValueType    schema.UsageVariableType
-	DefaultValue interface{}
-}
-
 func SyncUsageData(project *schema.Project, existingUsageData map[string]*schema.UsageData, usageFilePath string) error {
 	if usageFilePath == "" {
 		return nil
@@ -38,153 +37,428 @@ func SyncUsageData(project *schema.Project, existingUsageData map[string]*schema
 		return err
 	}
 	syncedResourcesUsage := syncResourcesUsage(project.Resources, usageSchema, existingUsageData)
-	// yaml.MapSlice is used to maintain the order of keys, so re-running
-	// the code won't change the output.
-	syncedUsageData := yaml.MapSlice{
-		{Key: "version", Value: 0.1},
-		{Key: "resource_usage", Value: syncedResourcesUsage},
-	}
-	d, err := yaml.Marshal(syncedUsageData)
+	err = ioutil.WriteFile(usageFilePath, []byte(usageNodesToYAML(syncedResourcesUsage)), 0600)
 	if err != nil {
 		return err
 	}
-	err = ioutil.WriteFile(usageFilePath, d, 0600)
+	return nil
+}
+
+// usageNode is a key in the synced usage file. Keys that don't have a value
+// in the existing usage file are written as comments so they don't change
+// the cost estimate until they're uncommented.
+type usageNode struct {
+	Key         string
+	Value       interface{}
+	Description string
+	Commented   bool
+	Children    []*usageNode
+}
+
+func (n *usageNode) child(key string) *usageNode {
+	for _, c := range n.Children {
+		if c.Key == key {
+			return c
+		}
+	}
+	c := &usageNode{Key: key}
+	n.Children = append(n.Children, c)
+	return c
+}
+
+// add adds a flattened key, e.g. storage_os_disk.monthly_disk_operations,
+// creating the parent nodes as needed.
+func (n *usageNode) add(key string, value interface{}, description string, commented bool) {
+	parent := n
+	splittedKey := strings.Split(key, ".")
+	for _, k := range splittedKey[:len(splittedKey)-1] {
+		parent = parent.child(k)
+	}
+	c := parent.child(splittedKey[len(splittedKey)-1])
+	c.Value = value
+	c.Description = description
+	c.Commented = commented
+}
+
+// updateCommented marks parent nodes as commented if all their children are commented.
+func (n *usageNode) updateCommented() bool {
+	if len(n.Children) == 0 {
+		return n.Commented
+	}
+	commented := true
+	for _, c := range n.Children {
+		if !c.updateCommented() {
+			commented = false
+		}
+	}
+	n.Commented = commented
+	return commented
+}
+
+func (n *usageNode) lines(parentCommented bool) []string {
+	var line string
+	if len(n.Children) == 0 {
+		line = fmt.Sprintf("%s: %s", formatUsageKey(n.Key), formatUsageValue(n.Value))
+		if n.Description != "" {
+			line += fmt.Sprintf(" # %s", n.Description)
+		}
+	} else {
+		line = fmt.Sprintf("%s:", formatUsageKey(n.Key))
+	}
+
+	lines := []string{line}
+	for _, c := range n.Children {
+		for _, l := range c.lines(n.Commented || parentCommented) {
+			lines = append(lines, "  "+l)
+		}
+	}
+
+	if n.Commented && !parentCommented {
+		for i, l := range lines {
+			lines[i] = "# " + l
+		}
+	}
+
+	return lines
+}
+
+func formatUsageKey(key string) string {
+	d, err := yaml.Marshal(key)
 	if err != nil {
-		return err
+		return key
 	}
-	return nil
+	return strings.TrimSuffix(string(d), "\n")
 }
 
-func syncResourcesUsage(resources []*schema.Resource, usageSchema map[string][]*SchemaItem, existingUsageData map[string]*schema.UsageData) yaml.MapSlice {
-	syncedResourceUsage := make(map[string]interface{})
+func formatUsageValue(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+			return strconv.FormatFloat(v, 'f', 1, 64)
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+		return ""
+	}
+
+	d, err := yaml.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return strings.TrimSuffix(string(d), "\n")
+}
+
+func usageNodesToYAML(resources []*usageNode) string {
+	var b strings.Builder
+	b.WriteString("version: 0.1\n")
+	b.WriteString("resource_usage:\n")
+	for i, r := range resources {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		// The resource key itself is never commented so it's easy to see which
+		// resources are in the project.
+		b.WriteString(fmt.Sprintf("  %s:\n", formatUsageKey(r.Key)))
+		for _, c := range r.Children {
+			for _, l := range c.lines(false) {
+				b.WriteString(fmt.Sprintf("    %s\n", l))
+			}
+		}
+	}
+	return b.String()
+}
+
+func syncResourcesUsage(resources []*schema.Resource, usageSchema map[string][]*schema.UsageSchemaItem, existingUsageData map[string]*schema.UsageData) []*usageNode {
+	synced := make([]*usageNode, 0)
+	seen := make(map[string]bool)
+
 	for _, resource := range resources {
 		resourceName := resource.Name
-		resourceUSchema := resource.UsageSchema
-		if resource.UsageSchema == nil {
-			// There is no explicitly defined UsageSchema for this resource.  Use the old way and create one from
-			// infracost-usage-example.yml.
-			resourceTypeNames := strings.Split(resourceName, ".")
-			if len(resourceTypeNames) < 2 {
-				// It's a resource with no name
-				continue
-			}
-			// This handles module names appearing in the resourceName too
-			resourceTypeName := resourceTypeNames[len(resourceTypeNames)-2]
-			schemaItems, ok := usageSchema[resourceTypeName]
-			if !ok {
-				continue
-			}
+		existingUsage := existingUsageData[resourceName]
 
-			resourceUSchema = make([]*schema.UsageSchemaItem, 0, len(schemaItems))
-			for _, s := range schemaItems {
-				resourceUSchema = append(resourceUSchema, &schema.UsageSchemaItem{
-					Key:          s.Key,
-					DefaultValue: s.DefaultValue,
-					ValueType:    s.ValueType,
-				})
-			}
+		// Don't add resources that already get their usage from a wildcard key
+		// since adding an entry for them would override it.
+		if existingUsage == nil && schema.FindUsageData(existingUsageData, resourceName) != nil {
+			continue
+		}
+
+		resourceUSchema := resourceUsageSchema(resource, usageSchema)
+		if len(resourceUSchema) == 0 && existingUsage == nil {
+			continue
 		}
 
-		resourceUsage := make(map[string]interface{})
+		node := &usageNode{Key: resourceName}
 		for _, usageSchemaItem := range resourceUSchema {
 			usageKey := usageSchemaItem.Key
-			usageValueType := usageSchemaItem.ValueType
-			var usageValue interface{}
-			usageValue = usageSchemaItem.DefaultValue
-			if existingUsage, ok := existingUsageData[resourceName]; ok {
-				switch usageValueType {
-				case schema.Float64:
-					usageValue = existingUsage.Get(usageKey).Float()
-				case schema.Int64:
-					usageValue = existingUsage.Get(usageKey).Int()
-				case schema.String:
-					usageValue = existingUsage.Get(usageKey).String()
-				}
+			if existingUsage != nil && existingUsage.Get(usageKey).Type != gjson.Null {
+				node.add(usageKey, existingUsageValue(existingUsage.Get(usageKey), usageSchemaItem.ValueType), usageSchemaItem.Description, false)
+				continue
 			}
-			resourceUsage[usageKey] = usageValue
+			node.add(usageKey, defaultUsageValue(usageSchemaItem), usageSchemaItem.Description, true)
+		}
+
+		if existingUsage != nil {
+			addUnknownUsage(node, existingUsage, resourceUSchema)
+		}
+
+		node.updateCommented()
+		synced = append(synced, node)
+		seen[resourceName] = true
+	}
+
+	sort.Slice(synced, func(i, j int) bool {
+		return synced[i].Key < synced[j].Key
+	})
+
+	// Keep any existing entries that aren't for resources in the project, e.g.
+	// wildcard keys or resources that are only defined in the usage file.
+	keys := make([]string, 0)
+	for k := range existingUsageData {
+		if !seen[k] {
+			keys = append(keys, k)
 		}
-		syncedResourceUsage[resourceName] = unFlattenHelper(resourceUsage)
 	}
-	// yaml.MapSlice is used to maintain the order of keys, so re-running
-	// the code won't change the output.
-	result := mapToSortedMapSlice(syncedResourceUsage)
-	return result
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		node := &usageNode{Key: k}
+		addUnknownUsage(node, existingUsageData[k], nil)
+		synced = append(synced, node)
+	}
+
+	return synced
 }
 
-func loadUsageSchema() (map[string][]*SchemaItem, error) {
-	usageSchema := make(map[string][]*SchemaItem)
-	usageData, err := loadReferenceFile()
+// ResourceUsageSchema returns the usage keys that can be set for the resource
+// in the usage file, with their types and default values.
+func ResourceUsageSchema(resource *schema.Resource) ([]*schema.UsageSchemaItem, error) {
+	usageSchema, err := loadUsageSchema()
 	if err != nil {
-		return usageSchema, err
-	}
-	for _, resUsageData := range usageData {
-		resourceTypeName := strings.Split(resUsageData.Address, ".")[0]
-		usageSchema[resourceTypeName] = make([]*SchemaItem, 0)
-		for usageKeyName, usageRawResult := range resUsageData.Attributes {
-			var defaultValue interface{}
-			usageValueType := schema.Int64
-			defaultValue = 0
-			usageRawValue := usageRawResult.Value()
-			if _, ok := usageRawValue.(string); ok {
-				usageValueType = schema.String
-				defaultValue = usageRawResult.String()
+		return nil, err
+	}
+
+	return resourceUsageSchema(resource, usageSchema), nil
+}
+
+// DefaultUsageValue returns the value that is written to the usage file for
+// the usage key when it doesn't have a value yet.
+func DefaultUsageValue(s *schema.UsageSchemaItem) interface{} {
+	return defaultUsageValue(s)
+}
+
+// SetUsageValue sets a usage key for the resource address. If the resource
+// only gets its usage from a wildcard key then the wildcard's values are copied
+// to the new entry, since it overrides the wildcard key.
+func SetUsageValue(usageData map[string]*schema.UsageData, address string, key string, value interface{}) {
+	u := usageData[address]
+	if u == nil {
+		attributes := make(map[string]gjson.Result)
+		if w := schema.FindUsageData(usageData, address); w != nil {
+			for k, v := range w.Attributes {
+				attributes[k] = v
 			}
-			usageSchema[resourceTypeName] = append(usageSchema[resourceTypeName], &SchemaItem{
-				Key:          usageKeyName,
-				ValueType:    usageValueType,
-				DefaultValue: defaultValue,
-			})
 		}
+
+		u = schema.NewUsageData(address, attributes)
+		usageData[address] = u
 	}
-	return usageSchema, nil
+
+	j, _ := json.Marshal(value)
+	u.Attributes[key] = gjson.ParseBytes(j)
 }
 
-func unFlattenHelper(input map[string]interface{}) map[string]interface{} {
-	result := make(map[string]interface{})
-	for k, v := range input {
-		rootMap := &result
-		splittedKey := strings.Split(k, ".")
-		for it := 0; it < len(splittedKey)-1; it++ {
-			key := splittedKey[it]
-			if _, ok := (*rootMap)[key]; !ok {
-				(*rootMap)[key] = make(map[string]interface{})
-			}
-			casted := (*rootMap)[key].(map[string]interface{})
-			rootMap = &casted
+// resourceUsageSchema returns the usage schema for a resource. If the resource
+// doesn't explicitly define one then it is created from infracost-usage-example.yml.
+// Descriptions that aren't set in the explicit schema are taken from there too.
+func resourceUsageSchema(resource *schema.Resource, usageSchema map[string][]*schema.UsageSchemaItem) []*schema.UsageSchemaItem {
+	referenceItems := usageSchema[resource.ResourceType]
+	if resource.UsageSchema == nil {
+		return referenceItems
+	}
+
+	descriptions := make(map[string]string, len(referenceItems))
+	for _, s := range referenceItems {
+		descriptions[s.Key] = s.Description
+	}
+
+	items := make([]*schema.UsageSchemaItem, 0, len(resource.UsageSchema))
+	for _, s := range resource.UsageSchema {
+		item := *s
+		if item.Description == "" {
+			item.Description = descriptions[item.Key]
 		}
-		key := splittedKey[len(splittedKey)-1]
-		(*rootMap)[key] = v
+		items = append(items, &item)
 	}
-	return result
+
+	return items
 }
 
-func mapToSortedMapSlice(input map[string]interface{}) yaml.MapSlice {
-	result := make(yaml.MapSlice, 0)
-	// sort keys of the input to maintain same output for different runs.
-	keys := make([]string, 0)
-	for k := range input {
+// addUnknownUsage adds any existing usage values that aren't in the schema so that they aren't lost.
+func addUnknownUsage(node *usageNode, existingUsage *schema.UsageData, usageSchema []*schema.UsageSchemaItem) {
+	known := make(map[string]bool, len(usageSchema))
+	for _, s := range usageSchema {
+		known[s.Key] = true
+	}
+
+	keys := make([]string, 0, len(existingUsage.Attributes))
+	for k, v := range existingUsage.Attributes {
+		if k == "" || known[k] || v.Type == gjson.Null {
+			continue
+		}
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
-	// Iterate over sorted keys
+
 	for _, k := range keys {
-		v := input[k]
-		if casted, ok := v.(map[string]interface{}); ok {
-			result = append(result, yaml.MapItem{Key: k, Value: mapToSortedMapSlice(casted)})
-		} else {
-			result = append(result, yaml.MapItem{Key: k, Value: v})
+		node.add(k, existingUsage.Attributes[k].Value(), "", false)
+	}
+}
+
+func existingUsageValue(r gjson.Result, valueType schema.UsageVariableType) interface{} {
+	switch valueType {
+	case schema.Float64:
+		return r.Float()
+	case schema.Int64:
+		// Keep any decimal values that were used for integer keys
+		if r.Float() != math.Trunc(r.Float()) {
+			return r.Float()
+		}
+		return r.Int()
+	case schema.String:
+		return r.String()
+	case schema.Bool:
+		return r.Bool()
+	}
+	return r.Value()
+}
+
+func defaultUsageValue(s *schema.UsageSchemaItem) interface{} {
+	switch s.ValueType {
+	case schema.Float64:
+		switch v := s.DefaultValue.(type) {
+		case int:
+			return float64(v)
+		case int64:
+			return float64(v)
+		}
+	case schema.String:
+		if s.DefaultValue == nil {
+			return ""
+		}
+	case schema.Bool:
+		if s.DefaultValue == nil {
+			return false
 		}
 	}
-	return result
+	if s.DefaultValue == nil {
+		return 0
+	}
+	return s.DefaultValue
 }
 
-func loadReferenceFile() (map[string]*schema.UsageData, error) {
-	referenceUsageFileContents := infracost.GetReferenceUsageFileContents()
-	usageData, err := parseYAML(*referenceUsageFileContents)
+// loadUsageSchema creates a usage schema for each resource type in the
+// reference usage file. Descriptions are taken from the line comments.
+func loadUsageSchema() (map[string][]*schema.UsageSchemaItem, error) {
+	usageSchema := make(map[string][]*schema.UsageSchemaItem)
+
+	var root yamlv3.Node
+	err := yamlv3.Unmarshal(*infracost.GetReferenceUsageFileContents(), &root)
 	if err != nil {
-		return usageData, errors.Wrapf(err, "Error parsing usage file")
+		return usageSchema, errors.Wrapf(err, "Error parsing usage file")
 	}
-	return usageData, nil
+
+	if len(root.Content) == 0 {
+		return usageSchema, nil
+	}
+
+	resourceUsage := mappingValue(root.Content[0], "resource_usage")
+	if resourceUsage == nil {
+		return usageSchema, nil
+	}
+
+	for i := 0; i+1 < len(resourceUsage.Content); i += 2 {
+		resourceTypeName := strings.Split(resourceUsage.Content[i].Value, ".")[0]
+		for _, item := range usageSchemaItems("", resourceUsage.Content[i+1]) {
+			if !hasUsageSchemaItem(usageSchema[resourceTypeName], item.Key) {
+				usageSchema[resourceTypeName] = append(usageSchema[resourceTypeName], item)
+			}
+		}
+	}
+
+	return usageSchema, nil
+}
+
+func mappingValue(n *yamlv3.Node, key string) *yamlv3.Node {
+	if n.Kind != yamlv3.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func usageSchemaItems(prefix string, n *yamlv3.Node) []*schema.UsageSchemaItem {
+	items := make([]*schema.UsageSchemaItem, 0)
+	if n.Kind != yamlv3.MappingNode {
+		return items
+	}
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		keyNode, valueNode := n.Content[i], n.Content[i+1]
+		key := keyNode.Value
+		if prefix != "" {
+			key = fmt.Sprintf("%s.%s", prefix, key)
+		}
+
+		if valueNode.Kind == yamlv3.MappingNode {
+			items = append(items, usageSchemaItems(key, valueNode)...)
+			continue
+		}
+
+		item := &schema.UsageSchemaItem{
+			Key:          key,
+			ValueType:    schema.Int64,
+			DefaultValue: 0,
+			Description:  commentText(valueNode.LineComment, keyNode.LineComment),
+		}
+		switch valueNode.ShortTag() {
+		case "!!float":
+			item.ValueType = schema.Float64
+			item.DefaultValue = 0.0
+		case "!!str":
+			item.ValueType = schema.String
+			item.DefaultValue = valueNode.Value
+		case "!!bool":
+			item.ValueType = schema.Bool
+			item.DefaultValue = false
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+func hasUsageSchemaItem(items []*schema.UsageSchemaItem, key string) bool {
+	for _, item := range items {
+		if item.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func commentText(comments ...string) string {
+	for _, c := range comments {
+		c = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(c), "#"))
+		if c != "" {
+			return c
+		}
+	}
+	return ""
 }
 
 func LoadFromFile(usageFilePath string, createIfNotExisting bool) (map[string]*schema.UsageData, error) {
@@ -228,6 +502,12 @@ func LoadFromFile(usageFilePath string, createIfNotExisting bool) (map[string]*s
 	return usageData, nil
 }
 
+// Parse parses the contents of a usage file. Since JSON is valid YAML this
+// can also be used to parse usage data sent as JSON.
+func Parse(y []byte) (map[string]*schema.UsageData, error) {
+	return parseYAML(y)
+}
+
 func parseYAML(y []byte) (map[string]*schema.UsageData, error) {
 	var usageFile UsageFile
 
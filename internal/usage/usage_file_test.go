@@ -0,0 +1,124 @@
+package usage
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadUsageSchema(t *testing.T) {
+	usageSchema, err := loadUsageSchema()
+	require.NoError(t, err)
+
+	assert.Contains(t, usageSchema["aws_lambda_function"], &schema.UsageSchemaItem{
+		Key:          "monthly_requests",
+		ValueType:    schema.Int64,
+		DefaultValue: 0,
+		Description:  "Monthly requests to the Lambda function.",
+	})
+	assert.Contains(t, usageSchema["azurerm_virtual_machine"], &schema.UsageSchemaItem{
+		Key:          "storage_os_disk.monthly_disk_operations",
+		ValueType:    schema.Int64,
+		DefaultValue: 0,
+		Description:  "Monthly number of main disk operations (writes, reads, deletes) using a unit size of 256KiB.",
+	})
+}
+
+func TestSyncUsageData(t *testing.T) {
+	project := schema.NewProject("test", &schema.ProjectMetadata{})
+	project.Resources = []*schema.Resource{
+		{
+			Name:         "module.lambdas.aws_lambda_function.hello",
+			ResourceType: "aws_lambda_function",
+			UsageSchema: []*schema.UsageSchemaItem{
+				{Key: "monthly_requests", DefaultValue: 0, ValueType: schema.Float64},
+				{Key: "request_duration_ms", DefaultValue: 0, ValueType: schema.Float64},
+			},
+		},
+		{
+			Name:         "azurerm_virtual_machine.vm",
+			ResourceType: "azurerm_virtual_machine",
+		},
+		{
+			Name:         "aws_instance.web[0]",
+			ResourceType: "aws_instance",
+		},
+		{
+			Name:         "aws_vpc.vpc",
+			ResourceType: "aws_vpc",
+		},
+	}
+
+	existingUsageData := schema.NewUsageMap(map[string]interface{}{
+		"module.lambdas.aws_lambda_function.hello": map[string]interface{}{
+			"monthly_requests": 1000000,
+		},
+		"aws_instance.web[*]": map[string]interface{}{
+			"operating_system": "linux",
+		},
+	})
+
+	usageFilePath := filepath.Join(t.TempDir(), "infracost-usage.yml")
+	err := SyncUsageData(project, existingUsageData, usageFilePath)
+	require.NoError(t, err)
+
+	actual, err := ioutil.ReadFile(usageFilePath)
+	require.NoError(t, err)
+
+	expected := `version: 0.1
+resource_usage:
+  azurerm_virtual_machine.vm:
+    # hybrid_benefit: false # For Windows VMs, the Windows license is covered by Azure Hybrid Benefit, overrides the license type.
+    # dev_test: false # For Windows VMs, the VM is in a Dev/Test subscription so it's billed at the Dev/Test rate without the Windows license.
+    # storage_os_disk:
+    #   monthly_disk_operations: 0 # Monthly number of main disk operations (writes, reads, deletes) using a unit size of 256KiB.
+    # storage_data_disk:
+    #   monthly_disk_operations: 0 # Monthly number of disk operations (writes, reads, deletes) using a unit size of 256KiB per additional disk.
+
+  module.lambdas.aws_lambda_function.hello:
+    monthly_requests: 1000000.0 # Monthly requests to the Lambda function.
+    # request_duration_ms: 0.0 # Average duration of each request in milliseconds.
+
+  aws_instance.web[*]:
+    operating_system: linux
+`
+	assert.Equal(t, expected, string(actual))
+
+	// The synced file should load and keep the existing values
+	u, err := LoadFromFile(usageFilePath, false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000000), u["module.lambdas.aws_lambda_function.hello"].Get("monthly_requests").Int())
+	assert.Equal(t, "linux", u["aws_instance.web[*]"].Get("operating_system").String())
+}
+
+func TestSetUsageValue(t *testing.T) {
+	usageData, err := Parse([]byte(`
+version: 0.1
+resource_usage:
+  aws_lambda_function.workers[*]:
+    monthly_requests: 1000
+  aws_lambda_function.api:
+    monthly_requests: 500
+`))
+	require.NoError(t, err)
+
+	SetUsageValue(usageData, "aws_lambda_function.api", "request_duration_ms", 200)
+	SetUsageValue(usageData, "aws_lambda_function.workers[0]", "request_duration_ms", 300)
+	SetUsageValue(usageData, "aws_s3_bucket.bucket", "standard.storage_gb", 1.5)
+
+	api := usageData["aws_lambda_function.api"]
+	assert.Equal(t, int64(500), api.Get("monthly_requests").Int())
+	assert.Equal(t, int64(200), api.Get("request_duration_ms").Int())
+
+	// The values from the wildcard key are kept for the new entry
+	worker := usageData["aws_lambda_function.workers[0]"]
+	assert.Equal(t, int64(1000), worker.Get("monthly_requests").Int())
+	assert.Equal(t, int64(300), worker.Get("request_duration_ms").Int())
+	assert.False(t, usageData["aws_lambda_function.workers[*]"].Get("request_duration_ms").Exists())
+
+	assert.Equal(t, 1.5, usageData["aws_s3_bucket.bucket"].Get("standard.storage_gb").Float())
+}
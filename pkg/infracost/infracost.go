@@ -0,0 +1,155 @@
+// Package infracost lets other Go programs embed Infracost cost estimation.
+//
+// Unlike the CLI it doesn't read the Infracost config or credentials files,
+// doesn't change the global logger, doesn't print anything and doesn't send
+// telemetry unless it's enabled, so everything it needs must be set in the
+// Options.
+package infracost
+
+import (
+	"context"
+	"io/ioutil"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/estimate"
+	"github.com/infracost/infracost/internal/logging"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Options configures a run. Either Path or PlanJSON must be set.
+type Options struct {
+	// Path is a Terraform directory, plan file, plan JSON file or state JSON file.
+	Path string
+	// PlanJSON is Terraform plan JSON to use instead of a path.
+	PlanJSON []byte
+	// ProjectName is the name of the project when PlanJSON is used.
+	ProjectName string
+
+	UsageFile          string
+	TerraformBinary    string
+	TerraformWorkspace string
+	TerraformPlanFlags string
+	TerraformUseState  bool
+
+	APIKey             string
+	PricingAPIEndpoint string
+	// PricingBackend is graphql (the default), rest, pricebook or offline.
+	PricingBackend      string
+	PriceBookFile       string
+	PriceOverridesFile  string
+	CostAdjustmentsFile string
+
+	// Logger receives the messages logged while the prices are fetched, they're
+	// discarded if it isn't set. Messages from parsing the Terraform project
+	// still go to the global logrus logger.
+	Logger logrus.FieldLogger
+	// EnableTelemetry sends the anonymous summary of the resource types that
+	// were estimated to Infracost, like the CLI does. It's off by default.
+	EnableTelemetry bool
+}
+
+// RunBreakdown estimates the monthly costs of the Terraform project.
+//
+// The context is used for the pricing API requests and is checked between
+// each step of the run, so a cancelled context stops the run once the
+// current step or request has finished.
+func RunBreakdown(ctx context.Context, opts Options) (*Result, error) {
+	if opts.Path == "" && len(opts.PlanJSON) == 0 {
+		return nil, errors.New("Either Path or PlanJSON must be set")
+	}
+
+	cfg := newConfig(opts)
+
+	var project *schema.Project
+	var err error
+
+	if len(opts.PlanJSON) > 0 {
+		u := map[string]*schema.UsageData{}
+		if opts.UsageFile != "" {
+			u, err = usage.LoadFromFile(opts.UsageFile, false)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		name := opts.ProjectName
+		if name == "" {
+			name = "plan.json"
+		}
+
+		project, err = estimate.PlanJSONProject(cfg, name, opts.PlanJSON, u, true)
+	} else {
+		project, err = estimate.LoadProject(cfg, cfg.Projects[0])
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	costOpts, err := estimate.LoadCostOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	err = estimate.CalculateCosts(logging.WithLogger(ctx, logger(opts)), cfg, project, costOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return newResult(output.ToOutputFormat([]*schema.Project{project}))
+}
+
+func logger(opts Options) logrus.FieldLogger {
+	if opts.Logger != nil {
+		return opts.Logger
+	}
+
+	l := logrus.New()
+	l.SetOutput(ioutil.Discard)
+	return l
+}
+
+// newConfig creates the config for a run from the options. Unlike the CLI,
+// this doesn't load the config files or state, which would write to the
+// user's home directory.
+func newConfig(opts Options) *config.Config {
+	cfg := config.DefaultConfig()
+	cfg.State = &config.State{}
+
+	cfg.APIKey = opts.APIKey
+	if opts.PricingAPIEndpoint != "" {
+		cfg.PricingAPIEndpoint = opts.PricingAPIEndpoint
+	}
+	cfg.PricingBackend = opts.PricingBackend
+	cfg.PriceBookFile = opts.PriceBookFile
+	cfg.PriceOverridesFile = opts.PriceOverridesFile
+	cfg.CostAdjustmentsFile = opts.CostAdjustmentsFile
+	if cfg.PricingBackend == "offline" {
+		cfg.Offline = true
+	}
+	cfg.DisableTelemetry = !opts.EnableTelemetry
+
+	cfg.Projects = []*config.Project{
+		{
+			Path:               opts.Path,
+			UsageFile:          opts.UsageFile,
+			TerraformBinary:    opts.TerraformBinary,
+			TerraformWorkspace: opts.TerraformWorkspace,
+			TerraformPlanFlags: opts.TerraformPlanFlags,
+			TerraformUseState:  opts.TerraformUseState,
+		},
+	}
+
+	return cfg
+}
@@ -0,0 +1,87 @@
+package infracost
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPlanJSON = []byte(`{
+  "format_version": "0.1",
+  "planned_values": {
+    "root_module": {
+      "resources": [
+        {
+          "address": "aws_instance.web",
+          "mode": "managed",
+          "type": "aws_instance",
+          "name": "web",
+          "provider_name": "registry.terraform.io/hashicorp/aws",
+          "values": {"instance_type": "t3.micro"}
+        }
+      ]
+    }
+  },
+  "configuration": {
+    "provider_config": {
+      "aws": {"name": "aws", "expressions": {"region": {"constant_value": "us-east-1"}}}
+    }
+  }
+}`)
+
+func TestRunBreakdown(t *testing.T) {
+	priceBookFile := filepath.Join(t.TempDir(), "pricebook.json")
+	require.NoError(t, ioutil.WriteFile(priceBookFile, []byte(`{"products": []}`), 0600))
+
+	r, err := RunBreakdown(context.Background(), Options{
+		PlanJSON:       testPlanJSON,
+		ProjectName:    "my-project",
+		PricingBackend: "pricebook",
+		PriceBookFile:  priceBookFile,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, r.Projects, 1)
+	assert.Equal(t, "my-project", r.Projects[0].Name)
+	require.Len(t, r.Projects[0].Breakdown.Resources, 1)
+	assert.Equal(t, "aws_instance.web", r.Projects[0].Breakdown.Resources[0].Name)
+}
+
+func TestRunBreakdownLogger(t *testing.T) {
+	priceBookFile := filepath.Join(t.TempDir(), "pricebook.json")
+	require.NoError(t, ioutil.WriteFile(priceBookFile, []byte(`{"products": []}`), 0600))
+
+	logger, hook := test.NewNullLogger()
+
+	_, err := RunBreakdown(context.Background(), Options{
+		PlanJSON:       testPlanJSON,
+		PricingBackend: "pricebook",
+		PriceBookFile:  priceBookFile,
+		Logger:         logger,
+	})
+	require.NoError(t, err)
+
+	require.NotEmpty(t, hook.AllEntries())
+	assert.Contains(t, hook.LastEntry().Message, "No products found for")
+}
+
+func TestNewConfigTelemetry(t *testing.T) {
+	assert.True(t, newConfig(Options{}).DisableTelemetry)
+	assert.False(t, newConfig(Options{EnableTelemetry: true}).DisableTelemetry)
+}
+
+func TestRunBreakdownErrors(t *testing.T) {
+	_, err := RunBreakdown(context.Background(), Options{})
+	assert.Error(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = RunBreakdown(ctx, Options{PlanJSON: testPlanJSON, PricingBackend: "offline", PriceBookFile: filepath.Join(t.TempDir(), "missing.json")})
+	assert.Equal(t, context.Canceled, err)
+}
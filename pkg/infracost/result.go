@@ -0,0 +1,100 @@
+package infracost
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/output"
+)
+
+// Result has the same fields as the Infracost JSON output, apart from the
+// extra sections that are only added by the CLI flags.
+type Result struct {
+	Version          string           `json:"version"`
+	Projects         []Project        `json:"projects"`
+	TotalHourlyCost  *decimal.Decimal `json:"totalHourlyCost"`
+	TotalMonthlyCost *decimal.Decimal `json:"totalMonthlyCost"`
+	TimeGenerated    time.Time        `json:"timeGenerated"`
+	Summary          *Summary         `json:"summary"`
+}
+
+// Project is the breakdown of a project. PastBreakdown and Diff are only set
+// for plans.
+type Project struct {
+	Name             string            `json:"name"`
+	Metadata         *ProjectMetadata  `json:"metadata"`
+	PastBreakdown    *Breakdown        `json:"pastBreakdown"`
+	Breakdown        *Breakdown        `json:"breakdown"`
+	Diff             *Breakdown        `json:"diff"`
+	SkippedResources []SkippedResource `json:"skippedResources,omitempty"`
+}
+
+type ProjectMetadata struct {
+	Path               string            `json:"path"`
+	Type               string            `json:"type"`
+	TerraformWorkspace string            `json:"terraformWorkspace,omitempty"`
+	Labels             map[string]string `json:"labels,omitempty"`
+}
+
+// SkippedResource is a resource that wasn't priced, e.g. because it's free
+// or its resource type isn't supported yet.
+type SkippedResource struct {
+	Name         string `json:"name"`
+	ResourceType string `json:"resourceType"`
+	Reason       string `json:"reason"`
+	Message      string `json:"message,omitempty"`
+}
+
+type Breakdown struct {
+	Resources        []Resource       `json:"resources"`
+	TotalHourlyCost  *decimal.Decimal `json:"totalHourlyCost"`
+	TotalMonthlyCost *decimal.Decimal `json:"totalMonthlyCost"`
+}
+
+type Resource struct {
+	Name           string            `json:"name"`
+	Tags           map[string]string `json:"tags,omitempty"`
+	Metadata       map[string]string `json:"metadata"`
+	HourlyCost     *decimal.Decimal  `json:"hourlyCost"`
+	MonthlyCost    *decimal.Decimal  `json:"monthlyCost"`
+	CostComponents []CostComponent   `json:"costComponents,omitempty"`
+	SubResources   []Resource        `json:"subresources,omitempty"`
+}
+
+type CostComponent struct {
+	Name            string           `json:"name"`
+	Unit            string           `json:"unit"`
+	HourlyQuantity  *decimal.Decimal `json:"hourlyQuantity"`
+	MonthlyQuantity *decimal.Decimal `json:"monthlyQuantity"`
+	Price           decimal.Decimal  `json:"price"`
+	HourlyCost      *decimal.Decimal `json:"hourlyCost"`
+	MonthlyCost     *decimal.Decimal `json:"monthlyCost"`
+	Unpriced        bool             `json:"unpriced,omitempty"`
+}
+
+type Summary struct {
+	TotalSupportedResources   *int `json:"totalSupportedResources,omitempty"`
+	TotalUnsupportedResources *int `json:"totalUnsupportedResources,omitempty"`
+	TotalNoPriceResources     *int `json:"totalNoPriceResources,omitempty"`
+	TotalResources            *int `json:"totalResources,omitempty"`
+}
+
+// newResult converts the output through its JSON so the result has the same
+// values as the JSON output, without exposing the internal output types.
+func newResult(out output.Root) (*Result, error) {
+	b, err := json.Marshal(out)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error generating result")
+	}
+
+	var r Result
+	err = json.Unmarshal(b, &r)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error generating result")
+	}
+
+	return &r, nil
+}
@@ -136,13 +136,22 @@ build_msg () {
   printf "$msg"
 }
 
+load_github_app_token () {
+  if [ -z "$GITHUB_TOKEN" ] && [ ! -z "$INFRACOST_GITHUB_APP_ID" ]; then
+    echo "Using GitHub App $INFRACOST_GITHUB_APP_ID to post comment"
+    GITHUB_TOKEN=$(infracost github-app-token --repo "$1")
+  fi
+}
+
 post_to_github () {
   if [ "$GITHUB_EVENT_NAME" = "pull_request" ]; then
     GITHUB_SHA=$(cat $GITHUB_EVENT_PATH | jq -r .pull_request.head.sha)
   fi
 
+  load_github_app_token "$GITHUB_REPOSITORY"
+
   if [ -z "$GITHUB_TOKEN" ]; then
-    echo "Error: GITHUB_TOKEN is required to post comment to GitHub"
+    echo "Error: GITHUB_TOKEN or INFRACOST_GITHUB_APP_ID is required to post comment to GitHub"
   else
     echo "Posting comment to GitHub commit $GITHUB_SHA"
     msg="$(build_msg true)"
@@ -172,6 +181,7 @@ post_bitbucket_comment () {
 
 post_to_circle_ci () {
   if echo $CIRCLE_REPOSITORY_URL | grep -Eiq github; then
+    load_github_app_token "$CIRCLE_PROJECT_USERNAME/$CIRCLE_PROJECT_REPONAME"
     echo "Posting comment from CircleCI to GitHub commit $CIRCLE_SHA1"
     msg="$(build_msg true)"
     jq -Mnc --arg msg "$msg" '{"body": "\($msg)"}' | curl -L -X POST -d @- \